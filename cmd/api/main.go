@@ -2,21 +2,27 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // embed the time zone database for user time zones
 
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
 	_ "task-management-system/api/swagger"
 
 	"task-management-system/config"
+	"task-management-system/internal/app"
+	grpcServer "task-management-system/internal/delivery/grpc"
 	httpServer "task-management-system/internal/delivery/http"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/infrastructure/errorreport"
 	"task-management-system/internal/infrastructure/mongodb"
 	"task-management-system/internal/logger"
-	"task-management-system/internal/usecase"
 )
 
 // @title Task Management System API
@@ -34,6 +40,10 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
+	// Parse command-line flags
+	flags := config.BindFlags(flag.CommandLine)
+	flag.Parse()
+
 	// Initialize logger
 	if os.Getenv("APP_ENV") == "development" {
 		logger.SetDefaultLevel(logger.LevelDebug)
@@ -44,16 +54,47 @@ func main() {
 	logger.InfoF("Starting task management API server")
 
 	// Load configuration
-	cfg, err := config.LoadConfig("./config/config.yaml")
+	cfg, err := flags.Load()
 	if err != nil {
 		logger.FatalF("Failed to load configuration: %v", err)
 	}
 
+	// Log through the configured backend, masking sensitive data as configured;
+	// the built-in text logger and redaction rules apply until now
+	log := cfg.Logging.NewLogger(os.Stdout, logger.Default().Level())
+	logger.SetDefault(log)
+
+	// Report error logs and recovered panics, if configured
+	reporter, err := errorreport.NewFromConfig(cfg.ErrorReporting, cfg.App, log)
+	if err != nil {
+		logger.FatalF("Failed to initialize error reporting: %v", err)
+	}
+	if reporter != nil {
+		logger.SetReporter(reporter)
+		defer reporter.Flush(5 * time.Second)
+		logger.InfoF("Reporting errors to Sentry")
+	}
+
 	logger.InfoF("Configuration loaded successfully")
 	logger.DebugF("Database URI: %s, Database name: %s", cfg.Database.MongoDB.URI, cfg.Database.MongoDB.Name)
 
-	// Create MongoDB client
-	client, err := mongodb.NewClient(cfg.Database.MongoDB.URI, cfg.Database.MongoDB.Timeout)
+	// Apply runtime settings and reload them on config file changes and SIGHUP
+	runtimeSettings := config.NewRuntimeSettings(cfg.Runtime, log)
+	runtimeSettings.Start()
+
+	// Let repositories suffer the runtime faults, in development only
+	if cfg.App.Env == "development" {
+		mongodb.InjectFaults(runtimeSettings)
+	}
+
+	// Report "initializing" on the HTTP port until the server is ready
+	startupProbe := httpServer.NewStartupProbe(cfg, logger.WithComponent(log, logger.ComponentHTTP))
+	startupProbe.Start()
+
+	// Create MongoDB client, recording command latencies and logging slow queries;
+	// retry while MongoDB is still starting
+	monitor := mongodb.NewCommandMonitor(cfg.Database.MongoDB.SlowQueryThreshold, logger.WithComponent(log, logger.ComponentMongoDB))
+	client, err := mongodb.Connect(cfg.Database.MongoDB, monitor, logger.WithComponent(log, logger.ComponentMongoDB))
 	if err != nil {
 		logger.FatalF("Failed to connect to MongoDB: %v", err)
 	}
@@ -67,43 +108,96 @@ func main() {
 	db := mongodb.GetDatabase(client, cfg.Database.MongoDB.Name)
 	logger.InfoF("Connected to MongoDB: %s", cfg.Database.MongoDB.Name)
 
-	// Initialize repositories
-	taskRepo := mongodb.NewTaskRepository(db, cfg.Database.MongoDB.Timeout)
-	userRepo := mongodb.NewUserRepository(db, cfg.Database.MongoDB.Timeout)
+	// Apply pending database migrations
+	if err := mongodb.RunMigrations(db, cfg.Database.MongoDB.Timeout, logger.WithComponent(log, logger.ComponentMongoDB)); err != nil {
+		logger.FatalF("Failed to run database migrations: %v", err)
+	}
 
-	logger.InfoF("Repositories initialized successfully")
+	// Create missing indexes and report obsolete ones
+	if err := mongodb.EnsureIndexes(db, cfg.Database.MongoDB, cfg.Search.Engine, logger.WithComponent(log, logger.ComponentMongoDB)); err != nil {
+		logger.FatalF("Failed to ensure database indexes: %v", err)
+	}
 
-	// Initialize usecases
-	taskUseCase := usecase.NewTaskUseCase(taskRepo, userRepo)
-	userUseCase := usecase.NewUserUseCase(userRepo)
-	authUseCase := usecase.NewAuthUseCase(userRepo, cfg.Auth.JWT.Secret, cfg.Auth.JWT.Expiry)
+	// Initialize repositories and use cases
+	application, err := app.New(cfg, log, client, db)
+	if err != nil {
+		logger.FatalF("Failed to initialize use cases: %v", err)
+	}
 
 	logger.InfoF("Use cases initialized successfully")
 
+	// Start background jobs
+	jobs := application.Jobs()
+	jobs.Start()
+	defer jobs.Stop()
+
 	// Create HTTP server
-	server := httpServer.NewServer(cfg, taskUseCase, userUseCase, authUseCase)
+	server := httpServer.NewServer(
+		cfg,
+		logger.WithComponent(log, logger.ComponentHTTP),
+		application.Tasks,
+		application.Users,
+		application.Auth,
+		application.Stars,
+		application.Today,
+		application.Notifications,
+		application.Organizations,
+		application.Invitations,
+		application.Projects,
+		application.Audit,
+		application.Attachments,
+		application.Avatars,
+		application.Exports,
+		application.Merges,
+		application.InboundHooks,
+		application.CalendarFeeds,
+		application.Reminders,
+		application.Status,
+		runtimeSettings,
+	)
 
 	// Add Swagger handler directly to the mux router
 	if router, ok := server.GetRouter().(*mux.Router); ok {
-		// Create a handler to serve the API specification file directly from the file system
-		router.HandleFunc("/swagger/doc.json", func(w http.ResponseWriter, r *http.Request) {
-			http.ServeFile(w, r, "api/swagger/doc.json")
+		swaggerPath := cfg.Server.HTTP.SwaggerPath
+
+		// Serve the API specification from the file system, pointing it at the API as clients reach it
+		router.HandleFunc(swaggerPath+"/doc.json", func(w http.ResponseWriter, r *http.Request) {
+			serveSpec(w, r, "api/swagger/doc.json", cfg.Server.HTTP.BasePath)
 		})
 
-		// Define Swagger UI route
-		router.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
-			httpSwagger.URL("/swagger/doc.json"), // URL to swagger JSON doc
+		// Redirect to the UI below the forwarded prefix, which the UI handler does not know about
+		router.Handle(swaggerPath+"/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, httpUtils.ExternalPath(r, swaggerPath+"/index.html"), http.StatusMovedPermanently)
+		}))
+
+		// Define Swagger UI route; the UI loads the relative doc.json so it works below any prefix
+		router.PathPrefix(swaggerPath + "/").Handler(httpSwagger.Handler(
+			httpSwagger.URL("doc.json"),
 			httpSwagger.DeepLinking(true),
 			httpSwagger.DocExpansion("list"),
 			httpSwagger.DomID("swagger-ui"),
 			httpSwagger.PersistAuthorization(true),
 		))
-		logger.InfoF("Swagger UI initialized at /swagger/, using spec from /swagger/doc.json")
+		logger.InfoF("Swagger UI initialized at %s/, using spec from %s/doc.json", swaggerPath, swaggerPath)
 	} else {
 		logger.WarnF("Could not initialize Swagger UI - router is not of type *mux.Router")
 	}
 
-	// Start HTTP server in a goroutine
+	// Serve the gRPC services to browsers and HTTP clients on the HTTP port
+	if cfg.Server.HTTP.GRPCWeb || cfg.Server.HTTP.Connect {
+		services := grpcServer.NewServices(logger.WithComponent(log, logger.ComponentGRPC), application.Tasks, application.Users, application.Auth)
+		if cfg.Server.HTTP.GRPCWeb {
+			server.EnableGRPCWeb(services)
+		}
+		if cfg.Server.HTTP.Connect {
+			server.EnableConnect(services)
+		}
+	}
+
+	// Free the HTTP port, then start HTTP server in a goroutine
+	if err := startupProbe.Stop(context.Background()); err != nil {
+		logger.WarnF("Failed to stop startup probe: %v", err)
+	}
 	go func() {
 		if err := server.Start(); err != nil {
 			logger.FatalF("Failed to start HTTP server: %v", err)
@@ -127,3 +221,24 @@ func main() {
 
 	logger.InfoF("Server gracefully stopped")
 }
+
+// serveSpec serves an OpenAPI specification with its servers replaced by the
+// API base URL as the client reaches it, honoring reverse proxy headers
+func serveSpec(w http.ResponseWriter, r *http.Request, file, basePath string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		http.Error(w, "API specification not found", http.StatusNotFound)
+		return
+	}
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		logger.ErrorF("Invalid API specification %s: %v", file, err)
+		http.Error(w, "Invalid API specification", http.StatusInternalServerError)
+		return
+	}
+	spec["servers"] = []map[string]string{{"url": httpUtils.ExternalURL(r, basePath)}}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(spec)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"task-management-system/config"
+	"task-management-system/internal/app"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/infrastructure/backup"
+	"task-management-system/internal/infrastructure/mongodb"
+	"task-management-system/internal/logger"
+	"task-management-system/internal/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const usage = `Usage:
+  backup export [-config path] [-o file]         Write all collections to a .tar.gz archive
+  backup import [-config path] [-i file] [-drop] Restore an archive written by export
+  backup org-export [-config path] -org id [-o file] [-no-credentials]
+                                                 Write one organization in the JSON export format
+  backup org-import [-config path] [-org id] [-i file] [-no-credentials]
+                                                 Import a document written by org-export or GET /export
+
+Archives may be streamed to and from an S3 bucket, or a bucket of an S3-compatible
+store such as MinIO, by giving an s3://bucket/key URL as the file. The endpoint,
+region and credentials are those of storage.s3 in the configuration:
+  backup export -o s3://backups/tasks.tar.gz
+
+Use "-" as the file to stream through stdout/stdin instead, e.g. to other tools.
+
+org-export includes password hashes unless -no-credentials is given, so users can
+sign in after org-import. org-import without -org recreates the exported organization.
+`
+
+func main() {
+	// Logs go to stderr so archives can be streamed through stdout
+	logger.SetDefaultWriter(os.Stderr)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "export":
+		runExport(os.Args[2:])
+	case "import":
+		runImport(os.Args[2:])
+	case "org-export":
+		runOrgExport(os.Args[2:])
+	case "org-import":
+		runOrgImport(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// runExport handles the export subcommand
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	configPath := fs.String("config", "./config/config.yaml", "Path to the configuration file")
+	output := fs.String("o", "backup.tar.gz", "Archive to write, s3://bucket/key to upload it, or - for stdout")
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	client, db := connect(cfg)
+	defer mongodb.CloseClient(client, cfg.Database.MongoDB.Timeout)
+
+	var manifest *backup.Manifest
+	var err error
+	if bucket, key, ok := s3Location(*output); ok {
+		manifest, err = exportToS3(db, newS3Store(cfg, bucket), key)
+	} else {
+		var w io.Writer = os.Stdout
+		if *output != "-" {
+			f, err := os.Create(*output)
+			if err != nil {
+				logger.FatalF("Failed to create archive: %v", err)
+			}
+			defer f.Close()
+			w = f
+		}
+		manifest, err = backup.Export(context.Background(), db, w)
+	}
+	if err != nil {
+		logger.FatalF("Backup failed: %v", err)
+	}
+
+	for _, c := range manifest.Collections {
+		logger.InfoF("Exported %d documents from %s", c.Documents, c.Name)
+	}
+	logger.InfoF("Backup of %s completed", manifest.Database)
+}
+
+// runImport handles the import subcommand
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	configPath := fs.String("config", "./config/config.yaml", "Path to the configuration file")
+	input := fs.String("i", "backup.tar.gz", "Archive to read, s3://bucket/key to download it, or - for stdin")
+	drop := fs.Bool("drop", false, "Replace the contents of existing collections")
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	client, db := connect(cfg)
+	defer mongodb.CloseClient(client, cfg.Database.MongoDB.Timeout)
+
+	var r io.Reader = os.Stdin
+	if bucket, key, ok := s3Location(*input); ok {
+		body, err := newS3Store(cfg, bucket).Open(key)
+		if err != nil {
+			logger.FatalF("Failed to open archive: %v", err)
+		}
+		defer body.Close()
+		r = body
+	} else if *input != "-" {
+		f, err := os.Open(*input)
+		if err != nil {
+			logger.FatalF("Failed to open archive: %v", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	manifest, err := backup.Import(context.Background(), db, r, backup.ImportOptions{Drop: *drop})
+	if err != nil {
+		logger.FatalF("Restore failed: %v", err)
+	}
+
+	for _, c := range manifest.Collections {
+		logger.InfoF("Imported %d documents into %s", c.Documents, c.Name)
+	}
+	logger.InfoF("Restore of backup taken at %s completed", manifest.CompletedAt.Format("2006-01-02 15:04:05 MST"))
+}
+
+// runOrgExport handles the org-export subcommand
+func runOrgExport(args []string) {
+	fs := flag.NewFlagSet("org-export", flag.ExitOnError)
+	configPath := fs.String("config", "./config/config.yaml", "Path to the configuration file")
+	org := fs.String("org", "", "ID of the organization to export")
+	output := fs.String("o", "export.json", "Document to write, or - for stdout")
+	noCredentials := fs.Bool("no-credentials", false, "Leave password hashes out of the document")
+	fs.Parse(args)
+
+	orgID, err := primitive.ObjectIDFromHex(*org)
+	if err != nil {
+		logger.FatalF("Invalid organization ID %q", *org)
+	}
+
+	cfg := loadConfig(*configPath)
+	client, db := connect(cfg)
+	defer mongodb.CloseClient(client, cfg.Database.MongoDB.Timeout)
+	application := newApp(cfg, client, db)
+
+	export, err := application.Exports.ExportOrganization(orgID, usecase.TransferOptions{Credentials: !*noCredentials})
+	if err != nil {
+		logger.FatalF("Export failed: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			logger.FatalF("Failed to create document: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		logger.FatalF("Failed to write document: %v", err)
+	}
+
+	logger.InfoF("Exported %d users, %d projects, %d tasks and %d attachments of organization %s",
+		len(export.Users), len(export.Projects), len(export.Tasks), len(export.Attachments), orgID.Hex())
+}
+
+// runOrgImport handles the org-import subcommand
+func runOrgImport(args []string) {
+	fs := flag.NewFlagSet("org-import", flag.ExitOnError)
+	configPath := fs.String("config", "./config/config.yaml", "Path to the configuration file")
+	org := fs.String("org", "", "ID of the organization to import into; the exported organization if empty")
+	input := fs.String("i", "export.json", "Document to read, or - for stdin")
+	noCredentials := fs.Bool("no-credentials", false, "Ignore password hashes in the document")
+	fs.Parse(args)
+
+	var orgID primitive.ObjectID
+	if *org != "" {
+		id, err := primitive.ObjectIDFromHex(*org)
+		if err != nil {
+			logger.FatalF("Invalid organization ID %q", *org)
+		}
+		orgID = id
+	}
+
+	var r io.Reader = os.Stdin
+	if *input != "-" {
+		f, err := os.Open(*input)
+		if err != nil {
+			logger.FatalF("Failed to open document: %v", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var data domain.Export
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		logger.FatalF("Failed to read document: %v", err)
+	}
+
+	cfg := loadConfig(*configPath)
+	client, db := connect(cfg)
+	defer mongodb.CloseClient(client, cfg.Database.MongoDB.Timeout)
+	application := newApp(cfg, client, db)
+
+	summary, err := application.Exports.ImportOrganization(orgID, &data, usecase.TransferOptions{Credentials: !*noCredentials})
+	if err != nil {
+		logger.FatalF("Import failed: %v", err)
+	}
+
+	for _, c := range []struct {
+		name  string
+		count usecase.ImportCount
+	}{
+		{"users", summary.Users},
+		{"projects", summary.Projects},
+		{"tasks", summary.Tasks},
+		{"attachments", summary.Attachments},
+	} {
+		logger.InfoF("Imported %d %s, skipped %d", c.count.Imported, c.name, c.count.Skipped)
+	}
+	logger.InfoF("Import into organization %s completed", summary.OrgID.Hex())
+}
+
+// loadConfig loads the configuration or exits
+func loadConfig(path string) *config.Config {
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		logger.FatalF("Failed to load configuration: %v", err)
+	}
+	logger.SetDefault(cfg.Logging.NewLogger(os.Stderr, logger.Default().Level()))
+	return cfg
+}
+
+// connect opens the configured database or exits
+func connect(cfg *config.Config) (*mongo.Client, *mongo.Database) {
+	client, err := mongodb.NewClient(cfg.Database.MongoDB, nil)
+	if err != nil {
+		logger.FatalF("Failed to connect to MongoDB: %v", err)
+	}
+	return client, mongodb.GetDatabase(client, cfg.Database.MongoDB.Name)
+}
+
+// newApp builds the application or exits
+func newApp(cfg *config.Config, client *mongo.Client, db *mongo.Database) *app.App {
+	application, err := app.New(cfg, logger.Default(), client, db)
+	if err != nil {
+		logger.FatalF("Failed to initialize application: %v", err)
+	}
+	return application
+}
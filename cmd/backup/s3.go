@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"task-management-system/config"
+	"task-management-system/internal/infrastructure/backup"
+	"task-management-system/internal/infrastructure/storage"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// s3Location splits an s3://bucket/key URL into its bucket and key
+func s3Location(path string) (bucket string, key string, ok bool) {
+	rest, found := strings.CutPrefix(path, "s3://")
+	if !found {
+		return "", "", false
+	}
+
+	bucket, key, _ = strings.Cut(rest, "/")
+	if bucket == "" || key == "" {
+		logger.FatalF("Invalid S3 location %q, expected s3://bucket/key", path)
+	}
+	return bucket, key, true
+}
+
+// newS3Store opens a bucket with the endpoint, region and credentials of the
+// storage configuration, or exits
+func newS3Store(cfg *config.Config, bucket string) *storage.S3Store {
+	s3 := cfg.Storage.S3
+	s3.Bucket = bucket
+
+	store, err := storage.NewS3Store(s3, cfg.Database.MongoDB.Timeout)
+	if err != nil {
+		logger.FatalF("Failed to open S3 bucket: %v", err)
+	}
+	return store
+}
+
+// exportToS3 streams a backup of the database to a key of the store while the
+// archive is written, without keeping it on disk
+func exportToS3(db *mongo.Database, store *storage.S3Store, key string) (*backup.Manifest, error) {
+	r, w := io.Pipe()
+
+	type result struct {
+		manifest *backup.Manifest
+		err      error
+	}
+	exported := make(chan result, 1)
+	go func() {
+		manifest, err := backup.Export(context.Background(), db, w)
+		w.CloseWithError(err)
+		exported <- result{manifest, err}
+	}()
+
+	if err := store.PutStream(key, r, "application/gzip"); err != nil {
+		// Stop the export if it is still writing
+		r.CloseWithError(err)
+		<-exported
+		return nil, err
+	}
+
+	res := <-exported
+	return res.manifest, res.err
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"task-management-system/config"
+	"task-management-system/internal/infrastructure/fsck"
+	"task-management-system/internal/infrastructure/mongodb"
+	"task-management-system/internal/logger"
+)
+
+const usage = `Usage:
+  fsck [-config path] [-repair] [-o file]
+
+Scans the database for references to documents that no longer exist, such as tasks
+assigned to deleted users or attachments of deleted tasks, and writes a JSON report.
+Use "-" as the file to write the report to stdout, the default.
+
+With -repair each dangling reference is repaired by the action in its "repair" field:
+  unset   removes an optional reference, e.g. a task's sprint
+  pull    removes the ID from an array, e.g. a task's assignees or a project's members
+  delete  deletes the document, e.g. an attachment, star, snooze or notification
+  none    leaves it for manual repair, e.g. a task's creator or project
+Blobs of deleted attachments stay in storage; their keys are reported as notes.
+
+Stop the servers, or take a backup, before repairing: references created during the
+scan may be reported as dangling. The exit status is 1 when dangling references are
+left unrepaired.
+`
+
+func main() {
+	// Logs go to stderr so the report can be written to stdout
+	logger.SetDefaultWriter(os.Stderr)
+
+	os.Exit(run(os.Args[1:]))
+}
+
+// run checks the database and returns the exit status; deferred cleanup runs before exiting
+func run(args []string) int {
+	fs := flag.NewFlagSet("fsck", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	configPath := fs.String("config", "./config/config.yaml", "Path to the configuration file")
+	repair := fs.Bool("repair", false, "Repair the dangling references found")
+	output := fs.String("o", "-", "Report to write, or - for stdout")
+	fs.Parse(args)
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		logger.FatalF("Failed to load configuration: %v", err)
+	}
+	logger.SetDefault(cfg.Logging.NewLogger(os.Stderr, logger.Default().Level()))
+
+	client, err := mongodb.NewClient(cfg.Database.MongoDB, nil)
+	if err != nil {
+		logger.FatalF("Failed to connect to MongoDB: %v", err)
+	}
+	defer mongodb.CloseClient(client, cfg.Database.MongoDB.Timeout)
+	db := mongodb.GetDatabase(client, cfg.Database.MongoDB.Name)
+
+	report, err := fsck.Check(context.Background(), db, fsck.Options{Repair: *repair})
+	if err != nil {
+		logger.FatalF("Check failed: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			logger.FatalF("Failed to create report: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.FatalF("Failed to write report: %v", err)
+	}
+
+	for _, check := range report.Checks {
+		if check.Dangling > 0 {
+			logger.InfoF("%s: %d dangling references in %d documents scanned, %d repaired", check.Check, check.Dangling, check.Scanned, check.Repaired)
+		}
+	}
+
+	unrepaired := report.Unrepaired()
+	logger.InfoF("Check of %s completed: %d dangling references, %d left unrepaired", report.Database, len(report.Findings), unrepaired)
+	if unrepaired > 0 {
+		return 1
+	}
+	return 0
+}
@@ -1,18 +1,30 @@
 package main
 
 import (
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"task-management-system/config"
 	grpcServer "task-management-system/internal/delivery/grpc"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/infrastructure/breach"
+	"task-management-system/internal/infrastructure/errorreport"
+	"task-management-system/internal/infrastructure/events"
+	"task-management-system/internal/infrastructure/hashing"
 	"task-management-system/internal/infrastructure/mongodb"
+	"task-management-system/internal/infrastructure/notifier"
 	"task-management-system/internal/logger"
 	"task-management-system/internal/usecase"
 )
 
 func main() {
+	// Parse command-line flags
+	flags := config.BindFlags(flag.CommandLine)
+	flag.Parse()
+
 	// Initialize logger
 	if os.Getenv("APP_ENV") == "development" {
 		logger.SetDefaultLevel(logger.LevelDebug)
@@ -23,16 +35,38 @@ func main() {
 	logger.InfoF("Starting task management gRPC server")
 
 	// Load configuration
-	cfg, err := config.LoadConfig("./config/config.yaml")
+	cfg, err := flags.Load()
 	if err != nil {
 		logger.FatalF("Failed to load configuration: %v", err)
 	}
 
+	// Log through the configured backend, masking sensitive data as configured;
+	// the built-in text logger and redaction rules apply until now
+	log := cfg.Logging.NewLogger(os.Stdout, logger.Default().Level())
+	logger.SetDefault(log)
+
+	// Report error logs and recovered panics, if configured
+	reporter, err := errorreport.NewFromConfig(cfg.ErrorReporting, cfg.App, log)
+	if err != nil {
+		logger.FatalF("Failed to initialize error reporting: %v", err)
+	}
+	if reporter != nil {
+		logger.SetReporter(reporter)
+		defer reporter.Flush(5 * time.Second)
+		logger.InfoF("Reporting errors to Sentry")
+	}
+
 	logger.InfoF("Configuration loaded successfully")
 	logger.DebugF("Database URI: %s, Database name: %s", cfg.Database.MongoDB.URI, cfg.Database.MongoDB.Name)
 
-	// Create MongoDB client
-	client, err := mongodb.NewClient(cfg.Database.MongoDB.URI, cfg.Database.MongoDB.Timeout)
+	// Apply the runtime log level and reload it on config file changes and SIGHUP
+	runtimeSettings := config.NewRuntimeSettings(cfg.Runtime, log)
+	runtimeSettings.Start()
+
+	// Create MongoDB client, recording command latencies and logging slow queries;
+	// retry while MongoDB is still starting
+	monitor := mongodb.NewCommandMonitor(cfg.Database.MongoDB.SlowQueryThreshold, logger.WithComponent(log, logger.ComponentMongoDB))
+	client, err := mongodb.Connect(cfg.Database.MongoDB, monitor, logger.WithComponent(log, logger.ComponentMongoDB))
 	if err != nil {
 		logger.FatalF("Failed to connect to MongoDB: %v", err)
 	}
@@ -46,21 +80,105 @@ func main() {
 	db := mongodb.GetDatabase(client, cfg.Database.MongoDB.Name)
 	logger.InfoF("Connected to MongoDB: %s", cfg.Database.MongoDB.Name)
 
+	// Let repositories suffer the faults of the configuration file, in development only
+	if cfg.App.Env == "development" {
+		mongodb.InjectFaults(runtimeSettings)
+	}
+
+	// Apply pending database migrations
+	if err := mongodb.RunMigrations(db, cfg.Database.MongoDB.Timeout, logger.WithComponent(log, logger.ComponentMongoDB)); err != nil {
+		logger.FatalF("Failed to run database migrations: %v", err)
+	}
+
+	// Create missing indexes and report obsolete ones
+	if err := mongodb.EnsureIndexes(db, cfg.Database.MongoDB, cfg.Search.Engine, logger.WithComponent(log, logger.ComponentMongoDB)); err != nil {
+		logger.FatalF("Failed to ensure database indexes: %v", err)
+	}
+
 	// Initialize repositories
 	taskRepo := mongodb.NewTaskRepository(db, cfg.Database.MongoDB.Timeout)
+	taskSearcher, err := mongodb.NewTaskSearcher(db, cfg.Search.Engine, cfg.Search.AtlasIndex, cfg.Database.MongoDB.Timeout)
+	if err != nil {
+		logger.FatalF("Failed to initialize task search: %v", err)
+	}
 	userRepo := mongodb.NewUserRepository(db, cfg.Database.MongoDB.Timeout)
+	orgRepo := mongodb.NewOrganizationRepository(db, cfg.Database.MongoDB.Timeout)
+	projectRepo := mongodb.NewProjectRepository(db, cfg.Database.MongoDB.Timeout)
+	sessionRepo := mongodb.NewSessionRepository(db, cfg.Database.MongoDB.Timeout)
+	loginAttemptRepo := mongodb.NewLoginAttemptRepository(db, cfg.Database.MongoDB.Timeout)
+	counterRepo := mongodb.NewCounterRepository(db, cfg.Database.MongoDB.Timeout)
+	viewRepo := mongodb.NewTaskViewRepository(db, cfg.Database.MongoDB.Timeout)
+	snoozeRepo := mongodb.NewTaskSnoozeRepository(db, cfg.Database.MongoDB.Timeout)
+	notificationRepo := mongodb.NewNotificationRepository(db, cfg.Database.MongoDB.Timeout)
+	notificationPrefsRepo := mongodb.NewNotificationPreferencesRepository(db, cfg.Database.MongoDB.Timeout)
+	auditRepo := mongodb.NewAuditRepository(db, cfg.Database.MongoDB.Timeout)
 
 	logger.InfoF("Repositories initialized successfully")
 
 	// Initialize usecases
-	taskUseCase := usecase.NewTaskUseCase(taskRepo, userRepo)
-	userUseCase := usecase.NewUserUseCase(userRepo)
-	authUseCase := usecase.NewAuthUseCase(userRepo, cfg.Auth.JWT.Secret, cfg.Auth.JWT.Expiry)
+	eventBus := events.NewBus(log)
+	notificationUseCase := usecase.NewNotificationUseCase(
+		notificationRepo,
+		notificationPrefsRepo,
+		mongodb.NewDeferredNotificationRepository(db, cfg.Database.MongoDB.Timeout),
+		userRepo,
+		log,
+		notifier.NewFromConfig(cfg.Notifications)...,
+	)
+	eventBus.Subscribe(notificationUseCase.HandleEvent)
+	eventBus.Subscribe(usecase.NewConnectorUseCase(projectRepo, log, notifier.NewChatPostersFromConfig(cfg.Notifications)...).HandleEvent)
+	if len(cfg.Events.Webhooks) > 0 {
+		eventBus.Subscribe(notifier.NewEventWebhook(cfg.Events.Webhooks, cfg.Notifications.Timeout).HandleEvent)
+	}
+
+	// With the outbox enabled, task events are stored transactionally and delivered by the relay job
+	var unitOfWork domain.UnitOfWork
+	if cfg.Events.Outbox.Enabled {
+		unitOfWork = mongodb.NewUnitOfWork(client, db, cfg.Database.MongoDB.Timeout)
+	}
+
+	policy := usecase.NewPolicy(userRepo, projectRepo)
+	contentPolicy := usecase.ContentPolicy{
+		MaxTitleLength:       cfg.Content.MaxTitleLength,
+		MaxDescriptionLength: cfg.Content.MaxDescriptionLength,
+		Sanitize:             cfg.Content.Sanitize,
+	}
+	pageLimits := usecase.PageLimits{Default: cfg.Pagination.DefaultPageSize, Max: cfg.Pagination.MaxPageSize}
+	queryGuardrails := usecase.QueryGuardrails{MaxDueDateRange: cfg.Queries.MaxDueDateRange}
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, userRepo, taskSearcher, counterRepo, snoozeRepo, viewRepo, auditRepo, eventBus, unitOfWork, policy, contentPolicy, pageLimits, queryGuardrails, log)
+	passwordPolicy := usecase.PasswordPolicy{
+		MinLength:     cfg.Auth.Password.MinLength,
+		RequireUpper:  cfg.Auth.Password.RequireUpper,
+		RequireLower:  cfg.Auth.Password.RequireLower,
+		RequireDigit:  cfg.Auth.Password.RequireDigit,
+		RequireSymbol: cfg.Auth.Password.RequireSymbol,
+		Banned:        cfg.Auth.Password.Banned,
+		MaxAge:        cfg.Auth.Password.MaxAge,
+	}
+	if passwordPolicy.Breached, err = breach.NewCheckerFromConfig(cfg.Auth.Password); err != nil {
+		logger.FatalF("Failed to initialize breached password check: %v", err)
+	}
+	passwordHasher, err := hashing.NewHasher(cfg.Auth.Password.Hashing)
+	if err != nil {
+		logger.FatalF("Failed to initialize password hashing: %v", err)
+	}
+	tokenOptions := usecase.TokenOptions{
+		Secret:   cfg.Auth.JWT.Secret,
+		Expiry:   cfg.Auth.JWT.Expiry,
+		Issuer:   cfg.Auth.JWT.Issuer,
+		Audience: cfg.Auth.JWT.Audience,
+		Leeway:   cfg.Auth.JWT.Leeway,
+		// Impersonations are started over HTTP; the flag still governs the tokens accepted here
+		Impersonation:       cfg.Auth.Impersonation.Enabled,
+		ImpersonationExpiry: cfg.Auth.Impersonation.Expiry,
+	}
+	userUseCase := usecase.NewUserUseCase(userRepo, orgRepo, taskRepo, auditRepo, eventBus, unitOfWork, policy, passwordPolicy, passwordHasher, pageLimits, log)
+	authUseCase := usecase.NewAuthUseCase(userRepo, sessionRepo, loginAttemptRepo, auditRepo, passwordPolicy, passwordHasher, tokenOptions, log)
 
 	logger.InfoF("Use cases initialized successfully")
 
 	// Create gRPC server
-	server, err := grpcServer.NewServer(cfg, taskUseCase, userUseCase, authUseCase)
+	server, err := grpcServer.NewServer(cfg, logger.WithComponent(log, logger.ComponentGRPC), taskUseCase, userUseCase, authUseCase)
 	if err != nil {
 		logger.FatalF("Failed to create gRPC server: %v", err)
 	}
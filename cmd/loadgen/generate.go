@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Shape decides the volume of generated data and how it is spread
+type Shape struct {
+	Users    int
+	Projects int
+	Tasks    int
+	// Span is how far back task creation times are spread
+	Span time.Duration
+}
+
+// generator builds synthetic organizations in the export format. Content is
+// reproducible for a seed; IDs, names and times are not, so that repeated runs
+// against one database do not collide.
+type generator struct {
+	rnd *rand.Rand
+	now time.Time
+	// run distinguishes the usernames and emails of one run from those of others
+	run          string
+	passwordHash string
+}
+
+var (
+	titleVerbs = []string{"Fix", "Implement", "Review", "Refactor", "Document", "Test", "Update", "Investigate", "Design", "Migrate", "Remove", "Optimize"}
+	titleNouns = []string{"login flow", "billing page", "search index", "export job", "user settings", "API client", "onboarding emails", "dashboard", "audit log", "mobile layout", "cache layer", "deploy pipeline", "invoice PDF", "notification center", "rate limiter"}
+	firstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken", "Frances", "Edsger", "Radia", "John", "Katherine", "Tim", "Shafi", "Niklaus"}
+	lastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson", "Allen", "Dijkstra", "Perlman", "Backus", "Johnson", "Berners-Lee", "Goldwasser", "Wirth"}
+	timezones  = []string{"", "UTC", "Europe/Berlin", "America/New_York", "America/Los_Angeles", "Asia/Tokyo", "Asia/Kolkata", "Australia/Sydney"}
+)
+
+// priorityWeights skews priorities towards the middle: 1 to 5
+var priorityWeights = []int{15, 30, 30, 15, 10}
+
+// organization generates one organization of the given shape
+func (g *generator) organization(index int, shape Shape) *domain.Export {
+	orgCreatedAt := g.now.Add(-shape.Span - 24*time.Hour)
+	export := &domain.Export{
+		Format:     domain.ExportFormat,
+		Version:    domain.ExportVersion,
+		ExportedAt: g.now,
+		Organization: domain.ExportedOrganization{
+			ID:        g.id(orgCreatedAt),
+			Name:      fmt.Sprintf("Load test %s-%d", g.run, index+1),
+			CreatedAt: orgCreatedAt,
+		},
+		Users:       make([]domain.ExportedUser, 0, shape.Users),
+		Projects:    make([]domain.ExportedProject, 0, shape.Projects),
+		Tasks:       make([]domain.ExportedTask, 0, shape.Tasks),
+		Attachments: []domain.ExportedAttachment{},
+	}
+
+	for i := 0; i < shape.Users; i++ {
+		export.Users = append(export.Users, g.user(index, i, orgCreatedAt))
+	}
+	export.Organization.CreatedBy = export.Users[0].ID
+
+	members := make(map[primitive.ObjectID][]primitive.ObjectID, shape.Projects)
+	for i := 0; i < shape.Projects; i++ {
+		project := g.project(i, export.Users, orgCreatedAt)
+		export.Projects = append(export.Projects, project)
+		for _, member := range project.Members {
+			members[project.ID] = append(members[project.ID], member.UserID)
+		}
+	}
+
+	allUsers := make([]primitive.ObjectID, 0, len(export.Users))
+	for _, user := range export.Users {
+		allUsers = append(allUsers, user.ID)
+	}
+
+	// Task numbers count up per project in creation order
+	numbers := make(map[primitive.ObjectID]int64, shape.Projects)
+	for _, createdAt := range g.creationTimes(shape.Tasks, shape.Span) {
+		task := g.task(createdAt)
+
+		// Most tasks belong to a project, and involve only its members
+		users := allUsers
+		if len(export.Projects) > 0 && g.rnd.Intn(100) < 80 {
+			project := export.Projects[g.rnd.Intn(len(export.Projects))]
+			numbers[project.ID]++
+			task.ProjectID = project.ID
+			task.Number = numbers[project.ID]
+			task.Key = domain.TaskKey(project.Key, task.Number)
+			users = members[project.ID]
+		}
+
+		task.CreatedBy = users[g.rnd.Intn(len(users))]
+		g.assign(&task, users)
+		export.Tasks = append(export.Tasks, task)
+	}
+
+	return export
+}
+
+// user generates the i-th user of an organization; the first one administers it
+func (g *generator) user(org int, i int, orgCreatedAt time.Time) domain.ExportedUser {
+	createdAt := orgCreatedAt.Add(time.Duration(g.rnd.Int63n(int64(24 * time.Hour))))
+	role := domain.OrgRoleMember
+	if i == 0 {
+		role = domain.OrgRoleAdmin
+	}
+	username := fmt.Sprintf("lg%s-%d-%d", g.run, org+1, i+1)
+	return domain.ExportedUser{
+		ID:                g.id(createdAt),
+		Username:          username,
+		Email:             username + "@loadgen.invalid",
+		FirstName:         firstNames[g.rnd.Intn(len(firstNames))],
+		LastName:          lastNames[g.rnd.Intn(len(lastNames))],
+		Timezone:          timezones[g.rnd.Intn(len(timezones))],
+		OrgRole:           role,
+		PasswordHash:      g.passwordHash,
+		PasswordChangedAt: createdAt,
+		CreatedAt:         createdAt,
+		UpdatedAt:         createdAt,
+	}
+}
+
+// project generates the i-th project of an organization. Each has between a
+// third and all of the users as members, and is administered by its creator.
+func (g *generator) project(i int, users []domain.ExportedUser, orgCreatedAt time.Time) domain.ExportedProject {
+	createdAt := orgCreatedAt.Add(24 * time.Hour)
+	size := len(users)/3 + g.rnd.Intn(len(users)-len(users)/3) + 1
+
+	members := make([]domain.ProjectMember, 0, size)
+	for n, u := range g.rnd.Perm(len(users))[:size] {
+		role := domain.ProjectRoleContributor
+		switch {
+		case n == 0:
+			role = domain.ProjectRoleAdmin
+		case g.rnd.Intn(10) == 0:
+			role = domain.ProjectRoleViewer
+		}
+		members = append(members, domain.ProjectMember{UserID: users[u].ID, Role: role})
+	}
+
+	noun := titleNouns[g.rnd.Intn(len(titleNouns))]
+	return domain.ExportedProject{
+		ID:          g.id(createdAt),
+		Key:         fmt.Sprintf("P%d", i+1),
+		Name:        fmt.Sprintf("Project %d: %s", i+1, noun),
+		Description: "Generated for load testing",
+		Members:     members,
+		CreatedBy:   members[0].UserID,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+}
+
+// task generates a task created at the given time. Older tasks are more likely
+// completed; a fifth have no due date, and the others fall due within weeks of
+// their creation, so open old tasks end up overdue.
+func (g *generator) task(createdAt time.Time) domain.ExportedTask {
+	task := domain.ExportedTask{
+		ID:        g.id(createdAt),
+		Title:     fmt.Sprintf("%s %s", titleVerbs[g.rnd.Intn(len(titleVerbs))], titleNouns[g.rnd.Intn(len(titleNouns))]),
+		Priority:  g.priority(),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	if g.rnd.Intn(2) == 0 {
+		task.Description = "Generated for load testing."
+	}
+
+	if g.rnd.Intn(100) >= 20 {
+		// Exponentially distributed, averaging two weeks
+		days := g.rnd.ExpFloat64() * 14
+		task.DueDate = createdAt.Add(time.Duration(days * float64(24*time.Hour))).Truncate(time.Hour)
+	}
+
+	age := g.now.Sub(createdAt)
+	completed := 1 - math.Exp(-age.Hours()/(24*30))
+	switch r := g.rnd.Float64(); {
+	case r < completed*0.9:
+		task.Status = domain.TaskStatusCompleted
+	case r < completed*0.9+0.25:
+		task.Status = domain.TaskStatusInProgress
+	default:
+		task.Status = domain.TaskStatusPending
+	}
+
+	if task.Status != domain.TaskStatusPending {
+		task.UpdatedAt = createdAt.Add(time.Duration(g.rnd.Int63n(int64(age) + 1)))
+	}
+	return task
+}
+
+// assign assigns tasks that were started to one or, now and then, two users. Pending
+// tasks stay unassigned, as assigning a pending task starts it. A few users carry
+// most of the work.
+func (g *generator) assign(task *domain.ExportedTask, users []primitive.ObjectID) {
+	if task.Status == domain.TaskStatusPending || len(users) == 0 {
+		return
+	}
+
+	zipf := rand.NewZipf(g.rnd, 1.2, 1, uint64(len(users)-1))
+	first := users[zipf.Uint64()]
+	task.AssignedTo = []primitive.ObjectID{first}
+	if len(users) > 1 && g.rnd.Intn(10) == 0 {
+		if second := users[zipf.Uint64()]; second != first {
+			task.AssignedTo = append(task.AssignedTo, second)
+		}
+	}
+}
+
+// priority picks a priority by priorityWeights
+func (g *generator) priority() int {
+	total := 0
+	for _, weight := range priorityWeights {
+		total += weight
+	}
+	r := g.rnd.Intn(total)
+	for i, weight := range priorityWeights {
+		if r < weight {
+			return i + 1
+		}
+		r -= weight
+	}
+	return len(priorityWeights)
+}
+
+// creationTimes spreads n creation times over the span before now, in ascending
+// order. Recent days see more tasks, as a growing team would create.
+func (g *generator) creationTimes(n int, span time.Duration) []time.Time {
+	times := make([]time.Time, n)
+	for i := range times {
+		// The square root of a uniform draw leans towards 1, i.e. towards now
+		offset := time.Duration(math.Sqrt(g.rnd.Float64()) * float64(span))
+		times[i] = g.now.Add(-span + offset)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+// id returns a new ObjectID carrying the given creation time, so that ID order
+// follows creation order as it does for records created through the API
+func (g *generator) id(createdAt time.Time) primitive.ObjectID {
+	id := primitive.NewObjectID()
+	binary.BigEndian.PutUint32(id[0:4], uint32(createdAt.Unix()))
+	return id
+}
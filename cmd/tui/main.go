@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"task-management-system/api/proto"
+	grpcClient "task-management-system/internal/client/grpc"
+)
+
+const help = `Commands:
+  f <status>        Filter by status: all, pending, in_progress or completed
+  n                 Create a task
+  v <#>             Show a task
+  p <#>             Mark a task in progress
+  c <#>             Complete a task
+  a <#> <user>      Assign a task to a user ID, username or email
+  r                 Refresh the list
+  ?                 Show this help
+  q                 Quit
+`
+
+// statuses maps the filter names to task statuses
+var statuses = map[string]proto.TaskStatus{
+	"all":         proto.TaskStatus_TASK_STATUS_UNSPECIFIED,
+	"pending":     proto.TaskStatus_TASK_STATUS_PENDING,
+	"in_progress": proto.TaskStatus_TASK_STATUS_IN_PROGRESS,
+	"completed":   proto.TaskStatus_TASK_STATUS_COMPLETED,
+}
+
+// app holds the state of an interactive session
+type app struct {
+	client  *grpcClient.Client
+	in      *bufio.Scanner
+	userID  string
+	user    string
+	timeout time.Duration
+	filter  proto.TaskStatus
+	tasks   []*proto.TaskResponse
+	message string
+}
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "Address of the gRPC server")
+	login := flag.String("login", "", "Username or email to sign in with; prompted when empty")
+	timeout := flag.Duration("timeout", 10*time.Second, "Timeout for each request")
+	flag.Parse()
+
+	client, err := grpcClient.NewClient(*addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to %s: %v\n", *addr, err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	a := &app{
+		client:  client,
+		in:      bufio.NewScanner(os.Stdin),
+		timeout: *timeout,
+	}
+
+	if err := a.signIn(*login); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to sign in: %v\n", err)
+		os.Exit(1)
+	}
+
+	a.refresh()
+	a.run()
+}
+
+// signIn logs in with the given login, prompting for anything missing.
+// The password is read from TMS_PASSWORD when set.
+func (a *app) signIn(login string) error {
+	if login == "" {
+		login = a.prompt("Login: ")
+	}
+
+	password := os.Getenv("TMS_PASSWORD")
+	if password == "" {
+		password = a.readPassword("Password: ")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+
+	resp, err := a.client.Login(ctx, login, password)
+	if err != nil {
+		return err
+	}
+
+	a.client.SetAuthToken(resp.AccessToken)
+	a.userID = resp.UserId
+	a.user = resp.Username
+	if resp.PasswordExpired {
+		a.message = "Your password has expired; change it before it is required."
+	}
+	return nil
+}
+
+// run reads and executes commands until the user quits or input ends
+func (a *app) run() {
+	for {
+		a.render()
+
+		line, ok := a.readLine("> ")
+		if !ok {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "q", "quit":
+			return
+		case "?", "h", "help":
+			a.message = help
+		case "r":
+			a.refresh()
+		case "f":
+			a.setFilter(fields[1:])
+		case "n":
+			a.create()
+		case "v":
+			a.view(fields[1:])
+		case "p":
+			a.setStatus(fields[1:], proto.TaskStatus_TASK_STATUS_IN_PROGRESS)
+		case "c":
+			a.setStatus(fields[1:], proto.TaskStatus_TASK_STATUS_COMPLETED)
+		case "a":
+			a.assign(fields[1:])
+		default:
+			a.message = fmt.Sprintf("Unknown command %q; type ? for help", fields[0])
+		}
+	}
+}
+
+// render clears the screen and draws the task list and the last message
+func (a *app) render() {
+	fmt.Print("\033[H\033[2J")
+	fmt.Printf("Signed in as %s - showing %s tasks (%d)\n\n", a.user, filterName(a.filter), len(a.tasks))
+
+	if len(a.tasks) == 0 {
+		fmt.Println("  No tasks")
+	} else {
+		fmt.Printf("  %-3s  %-11s  %-3s  %-10s  %-16s  %s\n", "#", "STATUS", "PRI", "DUE", "ASSIGNEES", "TITLE")
+		for i, task := range a.tasks {
+			fmt.Printf("  %-3d  %-11s  %-3d  %-10s  %-16s  %s\n",
+				i+1, statusName(task.Status), task.Priority, dueDate(task), truncate(assignees(task), 16), task.Title)
+		}
+	}
+
+	fmt.Println()
+	if a.message != "" {
+		fmt.Println(strings.TrimRight(a.message, "\n"))
+		a.message = ""
+	} else {
+		fmt.Println("Type ? for help")
+	}
+}
+
+// refresh reloads the task list with the current filter
+func (a *app) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+
+	tasks, err := a.client.ListTasks(ctx, a.filter)
+	if err != nil {
+		a.message = fmt.Sprintf("Failed to list tasks: %v", err)
+		return
+	}
+	a.tasks = tasks
+}
+
+// setFilter changes the status filter and reloads the list
+func (a *app) setFilter(args []string) {
+	if len(args) != 1 {
+		a.message = "Usage: f <all|pending|in_progress|completed>"
+		return
+	}
+
+	status, ok := statuses[strings.ToLower(args[0])]
+	if !ok {
+		a.message = fmt.Sprintf("Unknown status %q", args[0])
+		return
+	}
+
+	a.filter = status
+	a.refresh()
+}
+
+// create prompts for the fields of a new task and creates it
+func (a *app) create() {
+	req := &proto.CreateTaskRequest{CreatedBy: a.userID}
+
+	req.Title = a.prompt("Title: ")
+	if req.Title == "" {
+		a.message = "Cancelled"
+		return
+	}
+	req.Description = a.prompt("Description: ")
+
+	priority, err := strconv.Atoi(a.promptDefault("Priority 1-5", "3"))
+	if err != nil {
+		a.message = "Priority must be a number"
+		return
+	}
+	req.Priority = int32(priority)
+
+	if due := a.prompt("Due date (YYYY-MM-DD, empty for none): "); due != "" {
+		t, err := time.ParseInLocation("2006-01-02", due, time.Local)
+		if err != nil {
+			a.message = "Due date must look like 2024-12-31"
+			return
+		}
+		req.DueDate = timestamppb.New(t)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+
+	task, err := a.client.CreateTask(ctx, req)
+	if err != nil {
+		a.message = fmt.Sprintf("Failed to create task: %v", err)
+		return
+	}
+
+	a.refresh()
+	a.message = fmt.Sprintf("Created %q", task.Title)
+}
+
+// view shows the details of a task
+func (a *app) view(args []string) {
+	task, ok := a.selected(args, "v <#>")
+	if !ok {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", task.Title)
+	fmt.Fprintf(&b, "  ID:        %s\n", task.Id)
+	fmt.Fprintf(&b, "  Status:    %s\n", statusName(task.Status))
+	fmt.Fprintf(&b, "  Priority:  %d\n", task.Priority)
+	fmt.Fprintf(&b, "  Due:       %s\n", dueDate(task))
+	if task.Creator != nil {
+		fmt.Fprintf(&b, "  Creator:   %s\n", task.Creator.Username)
+	}
+	fmt.Fprintf(&b, "  Assignees: %s\n", assignees(task))
+	if task.ProjectId != "" {
+		fmt.Fprintf(&b, "  Project:   %s\n", task.ProjectId)
+	}
+	if task.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", task.Description)
+	}
+	a.message = b.String()
+}
+
+// setStatus moves a task to the given status
+func (a *app) setStatus(args []string, status proto.TaskStatus) {
+	task, ok := a.selected(args, "c|p <#>")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+
+	_, err := a.client.UpdateTask(ctx, &proto.UpdateTaskRequest{
+		Id:         task.Id,
+		Status:     status,
+		UpdatedBy:  a.userID,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}},
+	})
+	if err != nil {
+		a.message = fmt.Sprintf("Failed to update task: %v", err)
+		return
+	}
+
+	a.refresh()
+	a.message = fmt.Sprintf("Marked %q %s", task.Title, statusName(status))
+}
+
+// assign adds an assignee to a task
+func (a *app) assign(args []string) {
+	if len(args) != 2 {
+		a.message = "Usage: a <#> <user>"
+		return
+	}
+
+	task, ok := a.selected(args[:1], "a <#> <user>")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+
+	if _, err := a.client.AssignTask(ctx, task.Id, args[1], a.userID); err != nil {
+		a.message = fmt.Sprintf("Failed to assign task: %v", err)
+		return
+	}
+
+	a.refresh()
+	a.message = fmt.Sprintf("Assigned %q to %s", task.Title, args[1])
+}
+
+// selected returns the task numbered by the first argument
+func (a *app) selected(args []string, usage string) (*proto.TaskResponse, bool) {
+	if len(args) != 1 {
+		a.message = "Usage: " + usage
+		return nil, false
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(a.tasks) {
+		a.message = fmt.Sprintf("No task #%s", args[0])
+		return nil, false
+	}
+	return a.tasks[n-1], true
+}
+
+// readLine prints a prompt and reads a line; ok is false at the end of input
+func (a *app) readLine(prompt string) (string, bool) {
+	fmt.Print(prompt)
+	if !a.in.Scan() {
+		return "", false
+	}
+	return strings.TrimSpace(a.in.Text()), true
+}
+
+// prompt reads a line, returning an empty string at the end of input
+func (a *app) prompt(prompt string) string {
+	line, _ := a.readLine(prompt)
+	return line
+}
+
+// promptDefault reads a line, returning def when it is empty
+func (a *app) promptDefault(prompt, def string) string {
+	if line := a.prompt(fmt.Sprintf("%s [%s]: ", prompt, def)); line != "" {
+		return line
+	}
+	return def
+}
+
+// readPassword reads a line with terminal echo turned off. When echo cannot
+// be turned off, e.g. when stdin is not a terminal, the line is read as is.
+func (a *app) readPassword(prompt string) string {
+	if err := stty("-echo"); err != nil {
+		return a.prompt(prompt)
+	}
+	defer func() {
+		stty("echo")
+		fmt.Println()
+	}()
+	return a.prompt(prompt)
+}
+
+// stty changes the settings of the terminal attached to stdin
+func stty(args ...string) error {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// filterName returns the name of a status filter
+func filterName(status proto.TaskStatus) string {
+	if status == proto.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return "all"
+	}
+	return statusName(status)
+}
+
+// statusName returns the short name of a task status
+func statusName(status proto.TaskStatus) string {
+	switch status {
+	case proto.TaskStatus_TASK_STATUS_PENDING:
+		return "pending"
+	case proto.TaskStatus_TASK_STATUS_IN_PROGRESS:
+		return "in_progress"
+	case proto.TaskStatus_TASK_STATUS_COMPLETED:
+		return "completed"
+	default:
+		return "unknown"
+	}
+}
+
+// dueDate formats the due date of a task
+func dueDate(task *proto.TaskResponse) string {
+	if task.DueDate == nil {
+		return "-"
+	}
+	return task.DueDate.AsTime().Local().Format("2006-01-02")
+}
+
+// assignees lists the usernames of the assignees of a task
+func assignees(task *proto.TaskResponse) string {
+	if len(task.Assignees) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(task.Assignees))
+	for _, user := range task.Assignees {
+		names = append(names, user.Username)
+	}
+	return strings.Join(names, ",")
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
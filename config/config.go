@@ -2,17 +2,38 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"time"
 
+	"task-management-system/internal/logger"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	App      AppConfig
-	Server   ServerConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
+	App            AppConfig
+	Server         ServerConfig
+	Database       DatabaseConfig
+	Auth           AuthConfig
+	Notifications  NotificationsConfig
+	Jobs           JobsConfig
+	Search         SearchConfig
+	Content        ContentConfig
+	Pagination     PaginationConfig
+	Queries        QueriesConfig
+	Storage        StorageConfig
+	Scanning       ScanningConfig
+	Events         EventsConfig
+	Retention      RetentionConfig
+	Escalation     EscalationConfig
+	Aging          AgingConfig
+	Invitations    InvitationsConfig
+	Secrets        SecretsConfig
+	Admin          AdminConfig
+	Logging        LoggingConfig
+	ErrorReporting ErrorReportingConfig
+	Runtime        RuntimeConfig
 }
 
 // AppConfig holds application-specific configuration
@@ -30,12 +51,66 @@ type ServerConfig struct {
 
 // HTTPServerConfig holds HTTP server configuration
 type HTTPServerConfig struct {
-	Port int
+	Port              int
+	BasePath          string // Path prefix of the REST API routes, e.g. /api/v1
+	SwaggerPath       string // Path prefix of the Swagger UI and the API specification
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	Timeouts          HandlerTimeoutsConfig
+	Cache             HTTPCacheConfig
+	Status            StatusPageConfig
+	// GRPCWeb serves the gRPC services to browsers over gRPC-Web on the HTTP port
+	GRPCWeb bool
+	// Connect serves the gRPC services over the Connect protocol on the HTTP port
+	Connect bool
+}
+
+// HandlerTimeoutsConfig holds how long requests may take per kind of route; zero disables the timeout
+type HandlerTimeoutsConfig struct {
+	Default time.Duration
+	Auth    time.Duration // Login, registration and token refresh
+	Long    time.Duration // Exports, reports and other long-running routes
+}
+
+// HTTPCacheConfig holds how long clients may reuse the responses of list and stat
+// routes. Responses are private to the user, so proxies and other shared caches do
+// not store them; zero sends no cache headers.
+type HTTPCacheConfig struct {
+	MaxAge time.Duration
+	Routes map[string]time.Duration // Overrides by route path, e.g. /tasks/count
+}
+
+// MaxAgeFor returns the max-age of a route path
+func (c HTTPCacheConfig) MaxAgeFor(path string) time.Duration {
+	if maxAge, ok := c.Routes[path]; ok {
+		return maxAge
+	}
+	return c.MaxAge
+}
+
+// StatusPageConfig holds the public status route polled by uptime monitors and
+// status pages without credentials
+type StatusPageConfig struct {
+	// MaxAge is how long clients and shared caches may reuse a status, and how
+	// long the server reuses its own check of the database
+	MaxAge time.Duration
+	// RequestsPerMinute and Burst limit each client on top of the global rate limit
+	RequestsPerMinute int
+	Burst             int
+	// SigningKey signs every status with HMAC-SHA256 in the X-Status-Signature
+	// header when set
+	SigningKey string
 }
 
 // GRPCServerConfig holds gRPC server configuration
 type GRPCServerConfig struct {
 	Port int
+	// Reflection registers the reflection service, which lets tools such as grpcurl
+	// list the API. Defaults to on only when app.env is development.
+	Reflection bool
 }
 
 // DatabaseConfig holds database configuration
@@ -48,21 +123,293 @@ type MongoDBConfig struct {
 	URI     string
 	Name    string
 	Timeout time.Duration
+	// SlowQueryThreshold logs queries taking longer, with their filter; zero disables the log
+	SlowQueryThreshold time.Duration
+	// MaxPoolSize and MinPoolSize bound the connections per server; zero keeps the URI's or the driver's default
+	MaxPoolSize uint64
+	MinPoolSize uint64
+	// ReadPreference is the mode reads are routed by, e.g. "secondaryPreferred"; empty keeps the URI's or primary
+	ReadPreference string
+	// WriteConcern is "majority" or the number of members acknowledging writes; empty keeps the URI's or the server's default
+	WriteConcern string
+	// RetryWrites retries writes once after network errors and failovers; on unless disabled
+	RetryWrites bool
+	// Indexes controls how the declared indexes are ensured at startup
+	Indexes MongoDBIndexConfig
+	// ConnectRetries is how often connecting at startup is retried before giving
+	// up, waiting ConnectBackoff at first and twice as long each time after, up to
+	// ConnectMaxBackoff; zero fails on the first error
+	ConnectRetries    int
+	ConnectBackoff    time.Duration
+	ConnectMaxBackoff time.Duration
+}
+
+// MongoDBIndexConfig holds the options of the startup index step
+type MongoDBIndexConfig struct {
+	// Strict fails startup when an index cannot be created or dropped instead of logging it
+	Strict bool
+	// DropObsolete drops indexes that are no longer declared instead of only reporting them
+	DropObsolete bool
 }
 
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
-	JWT JWTConfig
+	JWT           JWTConfig
+	Password      PasswordConfig
+	Cookie        CookieConfig
+	Impersonation ImpersonationConfig
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret string
-	Expiry time.Duration
+	Secret   string
+	Expiry   time.Duration
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// CookieConfig holds cookie-based authentication configuration for browser frontends
+type CookieConfig struct {
+	Enabled  bool
+	Name     string
+	Domain   string
+	Secure   bool
+	SameSite string
+}
+
+// ImpersonationConfig holds configuration for organization admins acting as
+// members of their organization, for support debugging
+type ImpersonationConfig struct {
+	Enabled bool
+	Expiry  time.Duration
+}
+
+// PasswordConfig holds the password policy
+type PasswordConfig struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	Banned        []string
+	MaxAge        time.Duration
+	BreachCheck   string
+	BreachList    string
+	BreachTimeout time.Duration
+	Hashing       HashingConfig
+}
+
+// HashingConfig holds password hashing configuration
+type HashingConfig struct {
+	Algorithm     string
+	BcryptCost    int
+	Argon2Time    int
+	Argon2Memory  int // KiB
+	Argon2Threads int
+}
+
+// NotificationsConfig holds notification delivery configuration
+type NotificationsConfig struct {
+	Timeout time.Duration
+	Email   EmailConfig
+}
+
+// EmailConfig holds SMTP configuration for email notifications
+type EmailConfig struct {
+	SMTPHost string
+	SMTPPort int
+	Username string
+	Password string
+	From     string
+}
+
+// JobsConfig holds background job configuration
+type JobsConfig struct {
+	Enabled           bool
+	DigestInterval    time.Duration
+	PurgeInterval     time.Duration
+	ThumbnailInterval time.Duration
+	ScanInterval      time.Duration
+	UnsnoozeInterval  time.Duration
+	EscalateInterval  time.Duration
+	ReminderInterval  time.Duration
+	AgingInterval     time.Duration
+	DeferredInterval  time.Duration
+}
+
+// RetentionConfig holds how long expiring data is kept; zero keeps it forever
+type RetentionConfig struct {
+	Notifications         time.Duration
+	DeliveredEvents       time.Duration
+	LoginAttempts         time.Duration
+	Invitations           time.Duration // Counted from acceptance, revocation or expiry
+	DeferredNotifications time.Duration // Counted from when they were due
+}
+
+// EscalationConfig holds the rule for escalating overdue high-priority tasks
+type EscalationConfig struct {
+	Enabled            bool
+	MinPriority        int           // Lowest priority escalated
+	Margin             time.Duration // How long past its due date a task is escalated
+	NotifyProjectAdmin bool          // Also notify an admin of the task's project, not just its creator
+}
+
+// AgingConfig holds the rule for raising the priority of tasks left idle or pending
+// for long. Zero periods are off.
+type AgingConfig struct {
+	Enabled      bool
+	IdleAfter    time.Duration // How long a task may go without changes before its priority is raised
+	PendingAfter time.Duration // How long a task may stay pending before its priority is raised
+	MaxPriority  int           // Highest priority aging raises tasks to
+}
+
+// SearchConfig holds task search configuration
+type SearchConfig struct {
+	Engine     string
+	AtlasIndex string
+}
+
+// ContentConfig holds limits and sanitization of user-written task content
+type ContentConfig struct {
+	MaxTitleLength       int // Characters
+	MaxDescriptionLength int // Characters
+	// Sanitize strips HTML tags and unsafe link schemes, such as javascript:,
+	// before content is stored. Defaults to on.
+	Sanitize bool
+}
+
+// PaginationConfig holds the page sizes of task and user listings
+type PaginationConfig struct {
+	DefaultPageSize int64 // Page size when a request sets none
+	MaxPageSize     int64 // Larger requested page sizes are lowered to it
+}
+
+// QueriesConfig holds the guardrails of task list queries
+type QueriesConfig struct {
+	// MaxDueDateRange is the widest due date range listed without a project, sprint or milestone filter
+	MaxDueDateRange time.Duration
+}
+
+// StorageConfig holds where the contents of attachments and avatars are stored
+type StorageConfig struct {
+	// Backend is "gridfs", storing contents in the database, or "s3" for S3 and
+	// S3-compatible stores such as MinIO. With s3, clients upload and download
+	// through presigned URLs, so contents never pass through the API server.
+	Backend           string
+	URLExpiry         time.Duration // How long presigned URLs are valid
+	MaxAttachmentSize int64         // Bytes
+	MaxAvatarSize     int64         // Bytes
+	ThumbnailSize     int           // Pixels on the longer side of attachment thumbnails
+	S3                S3Config
+}
+
+// ScanningConfig holds the malware scanner attachment uploads are checked with
+type ScanningConfig struct {
+	// Backend is "clamav" for a clamd daemon or "icap" for an ICAP antivirus
+	// service; empty disables scanning
+	Backend       string
+	ClamAVAddress string        // e.g. "tcp://localhost:3310" or "unix:///var/run/clamav/clamd.ctl"
+	ICAPURL       string        // RESPMOD service, e.g. "icap://localhost:1344/avscan"
+	Timeout       time.Duration // How long scanning one file may take
+}
+
+// S3Config holds the S3 bucket used by the s3 storage backend
+type S3Config struct {
+	Endpoint        string // e.g. "https://s3.eu-west-1.amazonaws.com" or "http://minio:9000"; defaults to AWS in the region
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle addresses the bucket in the path instead of the host name, as MinIO requires
+	PathStyle bool
+}
+
+// EventsConfig holds domain event delivery configuration
+type EventsConfig struct {
+	Outbox   OutboxConfig
+	Webhooks []string
 }
 
-// LoadConfig loads configuration from file and environment variables
+// OutboxConfig holds transactional outbox configuration
+type OutboxConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	BatchSize    int64
+}
+
+// InvitationsConfig holds organization invitation configuration
+type InvitationsConfig struct {
+	Expiry    time.Duration
+	AcceptURL string
+}
+
+// AdminConfig holds access to operational endpoints
+type AdminConfig struct {
+	// Token authenticates calls to the /admin endpoints; empty disables them
+	Token string
+}
+
+// LoggingConfig holds how log output is written
+type LoggingConfig struct {
+	// Backend writes log entries: "text" (the default), "slog" or "zap"
+	Backend string
+	// Format of slog and zap entries: "text" (the default) or "json"
+	Format string
+	// Redact masks credentials, tokens and email addresses in log lines; on unless disabled
+	Redact bool
+	// RedactPatterns are regular expressions whose matches are also masked
+	RedactPatterns []string
+}
+
+// Redactor returns the redactor for log output, or nil when redaction is disabled.
+// Patterns are checked by Validate; should one be invalid, only the built-in rules apply.
+func (lc LoggingConfig) Redactor() *logger.Redactor {
+	if !lc.Redact {
+		return nil
+	}
+	redactor, err := logger.NewRedactor(lc.RedactPatterns)
+	if err != nil {
+		redactor, _ = logger.NewRedactor(nil)
+	}
+	return redactor
+}
+
+// ErrorReportingConfig holds where errors are reported besides the logs
+type ErrorReportingConfig struct {
+	// SentryDSN reports error logs and recovered panics to a Sentry project; empty disables reporting
+	SentryDSN string
+}
+
+// NewLogger creates the logger of the configured backend, writing to the given
+// writer with the given minimum level and masking sensitive data as configured
+func (lc LoggingConfig) NewLogger(writer io.Writer, level logger.Level) logger.Logger {
+	json := lc.Format == "json"
+
+	var log logger.Logger
+	switch lc.Backend {
+	case "slog":
+		log = logger.NewSlogText(writer, level, json)
+	case "zap":
+		log = logger.NewZapWriter(writer, level, json)
+	default:
+		log = logger.NewText(writer, level)
+	}
+	log.SetRedactor(lc.Redactor())
+	return log
+}
+
+// LoadConfig loads configuration from file and environment variables. Secrets are
+// then overridden from the environment, secret files or Vault; see applySecrets.
+// Missing settings get defaults, and an invalid configuration is rejected with
+// every problem listed.
 func LoadConfig(path string) (*Config, error) {
+	return loadConfig(path, nil)
+}
+
+// loadConfig loads configuration, applying the overrides, if any, after secrets
+// and before defaults and validation
+func loadConfig(path string, overrides func(*Config)) (*Config, error) {
 	viper.SetConfigFile(path)
 	viper.AutomaticEnv()
 
@@ -79,16 +426,200 @@ func LoadConfig(path string) (*Config, error) {
 
 	// Server config
 	cfg.Server.HTTP.Port = viper.GetInt("server.http.port")
+	cfg.Server.HTTP.BasePath = viper.GetString("server.http.base_path")
+	cfg.Server.HTTP.SwaggerPath = viper.GetString("server.http.swagger_path")
+	cfg.Server.HTTP.ReadTimeout = time.Duration(viper.GetInt("server.http.read_timeout")) * time.Second
+	cfg.Server.HTTP.ReadHeaderTimeout = time.Duration(viper.GetInt("server.http.read_header_timeout")) * time.Second
+	cfg.Server.HTTP.WriteTimeout = time.Duration(viper.GetInt("server.http.write_timeout")) * time.Second
+	cfg.Server.HTTP.IdleTimeout = time.Duration(viper.GetInt("server.http.idle_timeout")) * time.Second
+	cfg.Server.HTTP.MaxHeaderBytes = viper.GetInt("server.http.max_header_bytes")
+	cfg.Server.HTTP.Timeouts.Default = time.Duration(viper.GetInt("server.http.timeouts.default")) * time.Second
+	cfg.Server.HTTP.Timeouts.Auth = time.Duration(viper.GetInt("server.http.timeouts.auth")) * time.Second
+	cfg.Server.HTTP.Timeouts.Long = time.Duration(viper.GetInt("server.http.timeouts.long")) * time.Second
+	cfg.Server.HTTP.Cache.MaxAge = time.Duration(viper.GetInt("server.http.cache.max_age")) * time.Second
+	cfg.Server.HTTP.Cache.Routes = make(map[string]time.Duration)
+	for path := range viper.GetStringMap("server.http.cache.routes") {
+		cfg.Server.HTTP.Cache.Routes[path] = time.Duration(viper.GetInt("server.http.cache.routes."+path)) * time.Second
+	}
+	cfg.Server.HTTP.Status.MaxAge = time.Duration(viper.GetInt("server.http.status.max_age")) * time.Second
+	cfg.Server.HTTP.Status.RequestsPerMinute = viper.GetInt("server.http.status.requests_per_minute")
+	cfg.Server.HTTP.Status.Burst = viper.GetInt("server.http.status.burst")
+	cfg.Server.HTTP.Status.SigningKey = viper.GetString("server.http.status.signing_key")
+	cfg.Server.HTTP.GRPCWeb = viper.GetBool("server.http.grpc_web")
+	cfg.Server.HTTP.Connect = viper.GetBool("server.http.connect")
 	cfg.Server.GRPC.Port = viper.GetInt("server.grpc.port")
+	cfg.Server.GRPC.Reflection = viper.GetBool("server.grpc.reflection")
 
 	// Database config
 	cfg.Database.MongoDB.URI = viper.GetString("database.mongodb.uri")
 	cfg.Database.MongoDB.Name = viper.GetString("database.mongodb.name")
 	cfg.Database.MongoDB.Timeout = time.Duration(viper.GetInt("database.mongodb.timeout")) * time.Second
+	cfg.Database.MongoDB.SlowQueryThreshold = time.Duration(viper.GetInt("database.mongodb.slow_query_threshold")) * time.Millisecond
+	cfg.Database.MongoDB.MaxPoolSize = viper.GetUint64("database.mongodb.max_pool_size")
+	cfg.Database.MongoDB.MinPoolSize = viper.GetUint64("database.mongodb.min_pool_size")
+	cfg.Database.MongoDB.ReadPreference = viper.GetString("database.mongodb.read_preference")
+	cfg.Database.MongoDB.WriteConcern = viper.GetString("database.mongodb.write_concern")
+	cfg.Database.MongoDB.RetryWrites = viper.GetBool("database.mongodb.retry_writes") || !viper.IsSet("database.mongodb.retry_writes")
+	cfg.Database.MongoDB.ConnectRetries = viper.GetInt("database.mongodb.connect_retries")
+	cfg.Database.MongoDB.ConnectBackoff = time.Duration(viper.GetInt("database.mongodb.connect_backoff")) * time.Second
+	cfg.Database.MongoDB.ConnectMaxBackoff = time.Duration(viper.GetInt("database.mongodb.connect_max_backoff")) * time.Second
+	cfg.Database.MongoDB.Indexes.Strict = viper.GetBool("database.mongodb.indexes.strict")
+	cfg.Database.MongoDB.Indexes.DropObsolete = viper.GetBool("database.mongodb.indexes.drop_obsolete")
 
 	// Auth config
 	cfg.Auth.JWT.Secret = viper.GetString("auth.jwt.secret")
 	cfg.Auth.JWT.Expiry = time.Duration(viper.GetInt("auth.jwt.expiry")) * time.Hour
+	cfg.Auth.JWT.Issuer = viper.GetString("auth.jwt.issuer")
+	cfg.Auth.JWT.Audience = viper.GetString("auth.jwt.audience")
+	cfg.Auth.JWT.Leeway = time.Duration(viper.GetInt("auth.jwt.leeway")) * time.Second
+	cfg.Auth.Cookie.Enabled = viper.GetBool("auth.cookie.enabled")
+	cfg.Auth.Cookie.Name = viper.GetString("auth.cookie.name")
+	cfg.Auth.Cookie.Domain = viper.GetString("auth.cookie.domain")
+	cfg.Auth.Cookie.Secure = viper.GetBool("auth.cookie.secure")
+	cfg.Auth.Cookie.SameSite = viper.GetString("auth.cookie.same_site")
+	cfg.Auth.Impersonation.Enabled = viper.GetBool("auth.impersonation.enabled")
+	cfg.Auth.Impersonation.Expiry = time.Duration(viper.GetInt("auth.impersonation.expiry")) * time.Minute
+	cfg.Auth.Password.MinLength = viper.GetInt("auth.password.min_length")
+	cfg.Auth.Password.RequireUpper = viper.GetBool("auth.password.require_upper")
+	cfg.Auth.Password.RequireLower = viper.GetBool("auth.password.require_lower")
+	cfg.Auth.Password.RequireDigit = viper.GetBool("auth.password.require_digit")
+	cfg.Auth.Password.RequireSymbol = viper.GetBool("auth.password.require_symbol")
+	cfg.Auth.Password.Banned = viper.GetStringSlice("auth.password.banned")
+	cfg.Auth.Password.MaxAge = time.Duration(viper.GetInt("auth.password.max_age")) * 24 * time.Hour
+	cfg.Auth.Password.BreachCheck = viper.GetString("auth.password.breach_check")
+	cfg.Auth.Password.BreachList = viper.GetString("auth.password.breach_list")
+	cfg.Auth.Password.BreachTimeout = time.Duration(viper.GetInt("auth.password.breach_timeout")) * time.Second
+	cfg.Auth.Password.Hashing.Algorithm = viper.GetString("auth.password.hashing.algorithm")
+	cfg.Auth.Password.Hashing.BcryptCost = viper.GetInt("auth.password.hashing.bcrypt_cost")
+	cfg.Auth.Password.Hashing.Argon2Time = viper.GetInt("auth.password.hashing.argon2_time")
+	cfg.Auth.Password.Hashing.Argon2Memory = viper.GetInt("auth.password.hashing.argon2_memory")
+	cfg.Auth.Password.Hashing.Argon2Threads = viper.GetInt("auth.password.hashing.argon2_threads")
+
+	// Notifications config
+	cfg.Notifications.Timeout = time.Duration(viper.GetInt("notifications.timeout")) * time.Second
+	cfg.Notifications.Email.SMTPHost = viper.GetString("notifications.email.smtp_host")
+	cfg.Notifications.Email.SMTPPort = viper.GetInt("notifications.email.smtp_port")
+	cfg.Notifications.Email.Username = viper.GetString("notifications.email.username")
+	cfg.Notifications.Email.Password = viper.GetString("notifications.email.password")
+	cfg.Notifications.Email.From = viper.GetString("notifications.email.from")
+
+	// Jobs config
+	cfg.Jobs.Enabled = viper.GetBool("jobs.enabled")
+	cfg.Jobs.DigestInterval = time.Duration(viper.GetInt("jobs.digest_interval")) * time.Minute
+	cfg.Jobs.PurgeInterval = time.Duration(viper.GetInt("jobs.purge_interval")) * time.Minute
+	cfg.Jobs.ThumbnailInterval = time.Duration(viper.GetInt("jobs.thumbnail_interval")) * time.Second
+	cfg.Jobs.ScanInterval = time.Duration(viper.GetInt("jobs.scan_interval")) * time.Second
+	cfg.Jobs.UnsnoozeInterval = time.Duration(viper.GetInt("jobs.unsnooze_interval")) * time.Second
+	cfg.Jobs.EscalateInterval = time.Duration(viper.GetInt("jobs.escalate_interval")) * time.Minute
+	cfg.Jobs.ReminderInterval = time.Duration(viper.GetInt("jobs.reminder_interval")) * time.Second
+	cfg.Jobs.AgingInterval = time.Duration(viper.GetInt("jobs.aging_interval")) * time.Minute
+	cfg.Jobs.DeferredInterval = time.Duration(viper.GetInt("jobs.deferred_interval")) * time.Second
+
+	// Search config
+	cfg.Search.Engine = viper.GetString("search.engine")
+	cfg.Search.AtlasIndex = viper.GetString("search.atlas_index")
+
+	// Content config
+	cfg.Content.MaxTitleLength = viper.GetInt("content.max_title_length")
+	cfg.Content.MaxDescriptionLength = viper.GetInt("content.max_description_length")
+	cfg.Content.Sanitize = viper.GetBool("content.sanitize") || !viper.IsSet("content.sanitize")
+
+	// Pagination config
+	cfg.Pagination.DefaultPageSize = viper.GetInt64("pagination.default_page_size")
+	cfg.Pagination.MaxPageSize = viper.GetInt64("pagination.max_page_size")
+
+	// Queries config
+	cfg.Queries.MaxDueDateRange = time.Duration(viper.GetInt("queries.max_due_date_range")) * 24 * time.Hour
+
+	// Storage config
+	cfg.Storage.Backend = viper.GetString("storage.backend")
+	cfg.Storage.URLExpiry = time.Duration(viper.GetInt("storage.url_expiry")) * time.Minute
+	cfg.Storage.MaxAttachmentSize = viper.GetInt64("storage.max_attachment_size") << 20
+	cfg.Storage.MaxAvatarSize = viper.GetInt64("storage.max_avatar_size") << 20
+	cfg.Storage.ThumbnailSize = viper.GetInt("storage.thumbnail_size")
+	cfg.Storage.S3.Endpoint = viper.GetString("storage.s3.endpoint")
+	cfg.Storage.S3.Region = viper.GetString("storage.s3.region")
+	cfg.Storage.S3.Bucket = viper.GetString("storage.s3.bucket")
+	cfg.Storage.S3.AccessKeyID = viper.GetString("storage.s3.access_key_id")
+	cfg.Storage.S3.SecretAccessKey = viper.GetString("storage.s3.secret_access_key")
+	cfg.Storage.S3.PathStyle = viper.GetBool("storage.s3.path_style")
+
+	// Scanning config
+	cfg.Scanning.Backend = viper.GetString("scanning.backend")
+	cfg.Scanning.ClamAVAddress = viper.GetString("scanning.clamav_address")
+	cfg.Scanning.ICAPURL = viper.GetString("scanning.icap_url")
+	cfg.Scanning.Timeout = time.Duration(viper.GetInt("scanning.timeout")) * time.Second
+
+	// Events config
+	cfg.Events.Outbox.Enabled = viper.GetBool("events.outbox.enabled")
+	cfg.Events.Outbox.PollInterval = time.Duration(viper.GetInt("events.outbox.poll_interval")) * time.Second
+	cfg.Events.Outbox.BatchSize = viper.GetInt64("events.outbox.batch_size")
+	cfg.Events.Webhooks = viper.GetStringSlice("events.webhooks")
+
+	// Retention config
+	cfg.Retention.Notifications = time.Duration(viper.GetInt("retention.notifications")) * 24 * time.Hour
+	cfg.Retention.DeliveredEvents = time.Duration(viper.GetInt("retention.delivered_events")) * 24 * time.Hour
+	cfg.Retention.LoginAttempts = time.Duration(viper.GetInt("retention.login_attempts")) * 24 * time.Hour
+	cfg.Retention.Invitations = time.Duration(viper.GetInt("retention.invitations")) * 24 * time.Hour
+	cfg.Retention.DeferredNotifications = time.Duration(viper.GetInt("retention.deferred_notifications")) * 24 * time.Hour
+
+	// Escalation config
+	cfg.Escalation.Enabled = viper.GetBool("escalation.enabled")
+	cfg.Escalation.MinPriority = viper.GetInt("escalation.min_priority")
+	cfg.Escalation.Margin = time.Duration(viper.GetInt("escalation.margin")) * time.Hour
+	cfg.Escalation.NotifyProjectAdmin = viper.GetBool("escalation.notify_project_admin")
+
+	// Aging config
+	cfg.Aging.Enabled = viper.GetBool("aging.enabled")
+	cfg.Aging.IdleAfter = time.Duration(viper.GetInt("aging.idle_after")) * 24 * time.Hour
+	cfg.Aging.PendingAfter = time.Duration(viper.GetInt("aging.pending_after")) * 24 * time.Hour
+	cfg.Aging.MaxPriority = viper.GetInt("aging.max_priority")
+
+	// Invitations config
+	cfg.Invitations.Expiry = time.Duration(viper.GetInt("invitations.expiry")) * time.Hour
+	cfg.Invitations.AcceptURL = viper.GetString("invitations.accept_url")
+
+	// Admin config
+	cfg.Admin.Token = viper.GetString("admin.token")
+
+	// Error reporting config
+	cfg.ErrorReporting.SentryDSN = viper.GetString("error_reporting.sentry_dsn")
+
+	// Logging config
+	cfg.Logging.Backend = viper.GetString("logging.backend")
+	cfg.Logging.Format = viper.GetString("logging.format")
+	cfg.Logging.Redact = viper.GetBool("logging.redact") || !viper.IsSet("logging.redact")
+	cfg.Logging.RedactPatterns = viper.GetStringSlice("logging.redact_patterns")
+
+	// Runtime config
+	cfg.Runtime = loadRuntimeConfig()
+
+	// Secrets config
+	cfg.Secrets.Vault.Address = viper.GetString("secrets.vault.address")
+	cfg.Secrets.Vault.Path = viper.GetString("secrets.vault.path")
+	cfg.Secrets.Vault.TokenFile = viper.GetString("secrets.vault.token_file")
+
+	if err := applySecrets(&cfg); err != nil {
+		return nil, err
+	}
+
+	if overrides != nil {
+		overrides(&cfg)
+	}
+
+	applyDefaults(&cfg)
+
+	// Debug services are opt-in outside development; the environment is only known after defaults
+	if !viper.IsSet("server.grpc.reflection") {
+		cfg.Server.GRPC.Reflection = cfg.App.Env == "development"
+	}
+
+	// Runtime settings only allow faults in development
+	cfg.Runtime.env = cfg.App.Env
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
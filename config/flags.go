@@ -0,0 +1,55 @@
+package config
+
+import (
+	"flag"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultConfigPath is the configuration file used when no --config flag is given
+const DefaultConfigPath = "./config/config.yaml"
+
+// Flags holds the configuration file path and the settings given on the command
+// line. Settings given as flags take precedence over the configuration file,
+// environment variables and secret stores; unset flags change nothing.
+type Flags struct {
+	Path     string
+	HTTPPort int
+	GRPCPort int
+	LogLevel string
+	MongoURI string
+}
+
+// BindFlags defines the configuration flags on a flag set
+func BindFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{}
+	fs.StringVar(&f.Path, "config", DefaultConfigPath, "Path to the configuration file")
+	fs.IntVar(&f.HTTPPort, "http-port", 0, "HTTP server port (overrides server.http.port)")
+	fs.IntVar(&f.GRPCPort, "grpc-port", 0, "gRPC server port (overrides server.grpc.port)")
+	fs.StringVar(&f.LogLevel, "log-level", "", "Log level: debug, info, warn or error (overrides runtime.log_level)")
+	fs.StringVar(&f.MongoURI, "mongo-uri", "", "MongoDB connection URI (overrides database.mongodb.uri and "+EnvMongoDBURI+")")
+	return f
+}
+
+// Load loads the configuration file named by the flags and applies the flag overrides
+func (f *Flags) Load() (*Config, error) {
+	return loadConfig(f.Path, f.apply)
+}
+
+// apply overrides configuration settings with the flags that were set
+func (f *Flags) apply(cfg *Config) {
+	if f.HTTPPort != 0 {
+		cfg.Server.HTTP.Port = f.HTTPPort
+	}
+	if f.GRPCPort != 0 {
+		cfg.Server.GRPC.Port = f.GRPCPort
+	}
+	if f.MongoURI != "" {
+		cfg.Database.MongoDB.URI = f.MongoURI
+	}
+	if f.LogLevel != "" {
+		cfg.Runtime.LogLevel = f.LogLevel
+		// Also keeps the flag's level when the runtime settings are reloaded from the file
+		viper.Set("runtime.log_level", f.LogLevel)
+	}
+}
@@ -0,0 +1,481 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"task-management-system/internal/logger"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// RuntimeConfig holds the settings that are safe to change while the servers run.
+// Everything outside the runtime section of the configuration file needs a restart.
+type RuntimeConfig struct {
+	LogLevel string
+	// LogLevels overrides the log level of components such as "mongodb"
+	LogLevels   map[string]string
+	CORSOrigins []string
+	RateLimit   RateLimitConfig
+	Features    map[string]bool
+	// Faults are injected into database operations by collection, then by
+	// operation; "*" stands for any. They are only allowed when app.env is development.
+	Faults map[string]map[string]Fault
+
+	// env is app.env, which decides whether faults are allowed. Like everything
+	// outside the runtime section it needs a restart, so reloads keep it.
+	env string
+}
+
+// FaultOperations are the database operations faults can be injected into
+var FaultOperations = []string{"find", "insert", "update", "delete", "aggregate", "count"}
+
+// maxFaultLatencyMS bounds the latency a fault can add, so that a typo cannot stall the server
+const maxFaultLatencyMS = 60000
+
+// Fault is artificial latency or failure injected into database operations, to
+// test how clients handle retries and timeouts
+type Fault struct {
+	// LatencyMS delays each operation by that many milliseconds, or until it times out
+	LatencyMS int `json:"latency_ms" example:"500"`
+	// ErrorRate is the share of operations failing, from 0 to 1, after the latency
+	ErrorRate float64 `json:"error_rate" example:"0.1"`
+}
+
+// FaultFor returns the fault to inject into an operation on a collection, preferring
+// rules for the collection to rules for any collection, then rules for the operation
+// to rules for any operation
+func (rc RuntimeConfig) FaultFor(collection string, operation string) (Fault, bool) {
+	for _, c := range []string{collection, "*"} {
+		for _, o := range []string{operation, "*"} {
+			if fault, ok := rc.Faults[c][o]; ok {
+				return fault, true
+			}
+		}
+	}
+	return Fault{}, false
+}
+
+// RateLimitConfig holds the per-client request rate limit; zero requests per minute disables it
+type RateLimitConfig struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+// FeatureEnabled reports whether a feature flag is switched on; unknown flags are off
+func (rc RuntimeConfig) FeatureEnabled(name string) bool {
+	return rc.Features[name]
+}
+
+// loadRuntimeConfig reads the runtime section from viper
+func loadRuntimeConfig() RuntimeConfig {
+	rc := RuntimeConfig{
+		LogLevel:    viper.GetString("runtime.log_level"),
+		LogLevels:   viper.GetStringMapString("runtime.log_levels"),
+		CORSOrigins: viper.GetStringSlice("runtime.cors_origins"),
+		RateLimit: RateLimitConfig{
+			RequestsPerMinute: viper.GetInt("runtime.rate_limit.requests_per_minute"),
+			Burst:             viper.GetInt("runtime.rate_limit.burst"),
+		},
+		Features: make(map[string]bool),
+	}
+	for name, value := range viper.GetStringMap("runtime.features") {
+		enabled, _ := value.(bool)
+		rc.Features[name] = enabled
+	}
+	if faults := viper.GetStringMap("runtime.faults"); len(faults) > 0 {
+		rc.Faults = make(map[string]map[string]Fault, len(faults))
+		for collection := range faults {
+			key := "runtime.faults." + collection
+			rc.Faults[collection] = make(map[string]Fault)
+			for operation := range viper.GetStringMap(key) {
+				rc.Faults[collection][operation] = Fault{
+					LatencyMS: viper.GetInt(key + "." + operation + ".latency_ms"),
+					ErrorRate: viper.GetFloat64(key + "." + operation + ".error_rate"),
+				}
+			}
+		}
+	}
+
+	applyRuntimeDefaults(&rc)
+	return rc
+}
+
+// applyRuntimeDefaults fills runtime settings missing from the configuration
+func applyRuntimeDefaults(rc *RuntimeConfig) {
+	setDefault(&rc.LogLevel, "info")
+	if len(rc.CORSOrigins) == 0 {
+		rc.CORSOrigins = []string{"*"}
+	}
+	if rc.RateLimit.RequestsPerMinute > 0 {
+		setDefault(&rc.RateLimit.Burst, rc.RateLimit.RequestsPerMinute)
+	}
+}
+
+// problems lists what is wrong with the runtime settings
+func (rc RuntimeConfig) problems() []string {
+	var problems []string
+
+	if !validLogLevel(rc.LogLevel) {
+		problems = append(problems, fmt.Sprintf("runtime.log_level must be \"debug\", \"info\", \"warn\" or \"error\", got %q", rc.LogLevel))
+	}
+	for _, component := range sortedKeys(rc.LogLevels) {
+		if !logger.IsComponent(component) {
+			problems = append(problems, fmt.Sprintf("runtime.log_levels: unknown component %q, must be one of %s", component, strings.Join(logger.Components, ", ")))
+		} else if !validLogLevel(rc.LogLevels[component]) {
+			problems = append(problems, fmt.Sprintf("runtime.log_levels.%s must be \"debug\", \"info\", \"warn\" or \"error\", got %q", component, rc.LogLevels[component]))
+		}
+	}
+
+	if rc.RateLimit.RequestsPerMinute < 0 || rc.RateLimit.Burst < 0 {
+		problems = append(problems, "runtime.rate_limit values must not be negative")
+	}
+
+	if len(rc.Faults) > 0 && rc.env != "development" {
+		problems = append(problems, "runtime.faults are only allowed when app.env is development")
+	}
+	for _, collection := range sortedKeys(rc.Faults) {
+		for _, operation := range sortedKeys(rc.Faults[collection]) {
+			key := "runtime.faults." + collection + "." + operation
+			fault := rc.Faults[collection][operation]
+			if !validFaultOperation(operation) {
+				problems = append(problems, fmt.Sprintf("%s: unknown operation, must be one of %s or *", key, strings.Join(FaultOperations, ", ")))
+			}
+			if fault.LatencyMS < 0 || fault.LatencyMS > maxFaultLatencyMS {
+				problems = append(problems, fmt.Sprintf("%s.latency_ms must be between 0 and %d, got %d", key, maxFaultLatencyMS, fault.LatencyMS))
+			}
+			if fault.ErrorRate < 0 || fault.ErrorRate > 1 {
+				problems = append(problems, fmt.Sprintf("%s.error_rate must be between 0 and 1, got %g", key, fault.ErrorRate))
+			}
+		}
+	}
+
+	return problems
+}
+
+// validLogLevel reports whether a log level can be set at runtime
+func validLogLevel(level string) bool {
+	switch strings.ToLower(level) {
+	case "debug", "info", "warn", "error":
+		return true
+	}
+	return false
+}
+
+// validFaultOperation reports whether faults can be injected into an operation
+func validFaultOperation(operation string) bool {
+	if operation == "*" {
+		return true
+	}
+	for _, o := range FaultOperations {
+		if o == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RuntimeChange describes one runtime setting changed by a reload
+type RuntimeChange struct {
+	Key  string `json:"key"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// diff lists the settings that differ between two runtime configurations
+func (rc RuntimeConfig) diff(next RuntimeConfig) []RuntimeChange {
+	var changes []RuntimeChange
+	add := func(key string, from, to interface{}) {
+		if f, t := fmt.Sprint(from), fmt.Sprint(to); f != t {
+			changes = append(changes, RuntimeChange{Key: key, From: f, To: t})
+		}
+	}
+
+	add("runtime.log_level", rc.LogLevel, next.LogLevel)
+	for _, component := range logger.Components {
+		add("runtime.log_levels."+component, rc.LogLevels[component], next.LogLevels[component])
+	}
+	add("runtime.cors_origins", strings.Join(rc.CORSOrigins, ","), strings.Join(next.CORSOrigins, ","))
+	add("runtime.rate_limit.requests_per_minute", rc.RateLimit.RequestsPerMinute, next.RateLimit.RequestsPerMinute)
+	add("runtime.rate_limit.burst", rc.RateLimit.Burst, next.RateLimit.Burst)
+
+	names := make(map[string]struct{})
+	for name := range rc.Features {
+		names[name] = struct{}{}
+	}
+	for name := range next.Features {
+		names[name] = struct{}{}
+	}
+	for _, name := range sortedKeys(names) {
+		add("runtime.features."+name, rc.Features[name], next.Features[name])
+	}
+
+	collections := make(map[string]struct{})
+	for collection := range rc.Faults {
+		collections[collection] = struct{}{}
+	}
+	for collection := range next.Faults {
+		collections[collection] = struct{}{}
+	}
+	for _, collection := range sortedKeys(collections) {
+		operations := make(map[string]struct{})
+		for operation := range rc.Faults[collection] {
+			operations[operation] = struct{}{}
+		}
+		for operation := range next.Faults[collection] {
+			operations[operation] = struct{}{}
+		}
+		for _, operation := range sortedKeys(operations) {
+			from, hadFault := rc.Faults[collection][operation]
+			to, hasFault := next.Faults[collection][operation]
+			add("runtime.faults."+collection+"."+operation, faultString(from, hadFault), faultString(to, hasFault))
+		}
+	}
+
+	return changes
+}
+
+// faultString describes a fault in the log of runtime changes
+func faultString(fault Fault, ok bool) string {
+	if !ok {
+		return "none"
+	}
+	return fmt.Sprintf("latency_ms=%d error_rate=%g", fault.LatencyMS, fault.ErrorRate)
+}
+
+// RuntimeSettings holds the current runtime configuration and reloads it from
+// the configuration file on demand or when the file changes
+type RuntimeSettings struct {
+	mu        sync.RWMutex
+	reloadMu  sync.Mutex
+	current   RuntimeConfig
+	listeners []func(RuntimeConfig, []RuntimeChange)
+	// levelBeforeDebug is the log level SIGUSR1 restores, while it has switched to debug
+	levelBeforeDebug string
+	log              logger.Logger
+}
+
+// NewRuntimeSettings creates runtime settings starting from the loaded configuration.
+// Changes and failed reloads are logged to the given logger.
+func NewRuntimeSettings(initial RuntimeConfig, log logger.Logger) *RuntimeSettings {
+	return &RuntimeSettings{current: initial, log: log}
+}
+
+// Get returns the current runtime configuration
+func (s *RuntimeSettings) Get() RuntimeConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// OnChange registers a function called with the new configuration and the changes
+// after every reload that changed something
+func (s *RuntimeSettings) OnChange(fn func(RuntimeConfig, []RuntimeChange)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// Reload re-reads the configuration file and applies its runtime section. An
+// invalid runtime section is rejected and the current settings are kept. The
+// source, such as "SIGHUP", is logged with every change.
+func (s *RuntimeSettings) Reload(source string) ([]RuntimeChange, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return s.apply(loadRuntimeConfig(), source)
+}
+
+// LogLevels are the global log level and the components logging at their own
+type LogLevels struct {
+	Level      string            `json:"level" example:"info"`
+	Components map[string]string `json:"components"`
+}
+
+// LogLevels returns the current log levels
+func (s *RuntimeSettings) LogLevels() LogLevels {
+	rc := s.Get()
+	components := make(map[string]string, len(rc.LogLevels))
+	for component, level := range rc.LogLevels {
+		components[component] = level
+	}
+	return LogLevels{Level: rc.LogLevel, Components: components}
+}
+
+// SetLogLevels changes the log levels without touching the configuration file,
+// until the next reload. An empty level keeps the global level, and nil
+// components keep the component levels; otherwise they replace them all.
+func (s *RuntimeSettings) SetLogLevels(levels LogLevels, source string) ([]RuntimeChange, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	return s.apply(s.withLogLevels(levels), source)
+}
+
+// withLogLevels returns the current configuration with the given log levels
+func (s *RuntimeSettings) withLogLevels(levels LogLevels) RuntimeConfig {
+	next := s.Get()
+	if levels.Level != "" {
+		next.LogLevel = strings.ToLower(levels.Level)
+	}
+	if levels.Components != nil {
+		next.LogLevels = make(map[string]string, len(levels.Components))
+		for component, level := range levels.Components {
+			next.LogLevels[component] = strings.ToLower(level)
+		}
+	}
+	return next
+}
+
+// SetFaults replaces the injected faults without touching the configuration file,
+// until the next reload. Nil clears them.
+func (s *RuntimeSettings) SetFaults(faults map[string]map[string]Fault, source string) ([]RuntimeChange, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	next := s.Get()
+	next.Faults = faults
+	return s.apply(next, source)
+}
+
+// toggleDebug switches the global log level to debug, or back to the level it
+// had before when it was switched already
+func (s *RuntimeSettings) toggleDebug(source string) error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	// A reload since the switch to debug has set the level from the file already
+	before := s.Get().LogLevel
+	level := "debug"
+	if s.levelBeforeDebug != "" && before == "debug" {
+		level = s.levelBeforeDebug
+	}
+
+	if _, err := s.apply(s.withLogLevels(LogLevels{Level: level}), source); err != nil {
+		return err
+	}
+
+	s.levelBeforeDebug = ""
+	if level == "debug" {
+		s.levelBeforeDebug = before
+	}
+	return nil
+}
+
+// apply replaces the current configuration, logs the changes and notifies listeners of them
+func (s *RuntimeSettings) apply(next RuntimeConfig, source string) ([]RuntimeChange, error) {
+	next.env = s.Get().env
+	if problems := next.problems(); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid runtime configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	s.mu.Lock()
+	changes := s.current.diff(next)
+	s.current = next
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	for _, change := range changes {
+		s.log.Info("Runtime setting changed", map[string]interface{}{
+			"key":    change.Key,
+			"from":   change.From,
+			"to":     change.To,
+			"source": source,
+		})
+	}
+
+	if len(changes) > 0 {
+		for _, fn := range listeners {
+			fn(next, changes)
+		}
+	}
+
+	return changes, nil
+}
+
+// Start applies the runtime log levels and keeps the settings current: they are
+// reloaded whenever the configuration file changes and when the process receives
+// SIGHUP. SIGUSR1 switches the global log level to debug, and a second one back.
+func (s *RuntimeSettings) Start() {
+	applyLogLevel(s.Get(), s.log)
+	s.OnChange(func(rc RuntimeConfig, _ []RuntimeChange) {
+		applyLogLevel(rc, s.log)
+	})
+
+	viper.OnConfigChange(func(fsnotify.Event) {
+		// viper has already re-read the file
+		s.reloadMu.Lock()
+		defer s.reloadMu.Unlock()
+
+		if _, err := s.apply(loadRuntimeConfig(), "file change"); err != nil {
+			s.log.ErrorF("Ignoring changed configuration file: %v", err)
+		}
+	})
+	viper.WatchConfig()
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if _, err := s.Reload("SIGHUP"); err != nil {
+				s.log.ErrorF("Runtime configuration reload failed: %v", err)
+			}
+		}
+	}()
+
+	usr1 := make(chan os.Signal, 1)
+	signal.Notify(usr1, syscall.SIGUSR1)
+	go func() {
+		for range usr1 {
+			if err := s.toggleDebug("SIGUSR1"); err != nil {
+				s.log.ErrorF("Debug log level toggle failed: %v", err)
+			}
+		}
+	}()
+}
+
+// applyLogLevel sets the default logger's level and the component levels from
+// the runtime settings; components without a level follow the default logger.
+// Invalid levels are kept out and logged to the given logger.
+func applyLogLevel(rc RuntimeConfig, log logger.Logger) {
+	level, err := logger.ParseLevel(rc.LogLevel)
+	if err != nil {
+		log.WarnF("Keeping log level: %v", err)
+	} else {
+		logger.SetDefaultLevel(level)
+	}
+
+	for _, component := range logger.Components {
+		name, ok := rc.LogLevels[component]
+		if !ok {
+			logger.ResetComponentLevel(component)
+			continue
+		}
+		level, err := logger.ParseLevel(name)
+		if err != nil {
+			log.WarnF("Keeping %s log level: %v", component, err)
+			continue
+		}
+		logger.SetComponentLevel(component, level)
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Environment variables holding secrets. Each can also be given as a file path in
+// the variable with a _FILE suffix, as used by Docker and Kubernetes secrets.
+const (
+	EnvJWTSecret    = "TMS_JWT_SECRET"
+	EnvMongoDBURI   = "TMS_MONGODB_URI"
+	EnvSMTPPassword = "TMS_SMTP_PASSWORD"
+	EnvAdminToken   = "TMS_ADMIN_TOKEN"
+
+	EnvS3SecretAccessKey = "TMS_S3_SECRET_ACCESS_KEY"
+	EnvSentryDSN         = "TMS_SENTRY_DSN"
+	EnvStatusSigningKey  = "TMS_STATUS_SIGNING_KEY"
+)
+
+// vaultTimeout bounds the request reading secrets from Vault at startup
+const vaultTimeout = 10 * time.Second
+
+// SecretsConfig holds where secrets are read from besides the configuration file
+type SecretsConfig struct {
+	Vault VaultConfig
+}
+
+// VaultConfig holds the HashiCorp Vault secret store configuration. Vault is only
+// used when an address is configured.
+type VaultConfig struct {
+	Address string
+	// Path of the secret, e.g. "secret/data/task-management" for a KV version 2 engine mounted at "secret"
+	Path string
+	// TokenFile holds the Vault token; the VAULT_TOKEN environment variable takes precedence
+	TokenFile string
+}
+
+// secret names a secret, the keys it is read from and the configuration field it overrides
+type secret struct {
+	env      string
+	vaultKey string
+	target   *string
+}
+
+// applySecrets overrides secrets from the configuration file. Sources are tried in
+// order: the environment variable, the file named by <variable>_FILE, then Vault.
+func applySecrets(cfg *Config) error {
+	secrets := []secret{
+		{env: EnvJWTSecret, vaultKey: "jwt_secret", target: &cfg.Auth.JWT.Secret},
+		{env: EnvMongoDBURI, vaultKey: "mongodb_uri", target: &cfg.Database.MongoDB.URI},
+		{env: EnvSMTPPassword, vaultKey: "smtp_password", target: &cfg.Notifications.Email.Password},
+		{env: EnvAdminToken, vaultKey: "admin_token", target: &cfg.Admin.Token},
+		{env: EnvS3SecretAccessKey, vaultKey: "s3_secret_access_key", target: &cfg.Storage.S3.SecretAccessKey},
+		{env: EnvSentryDSN, vaultKey: "sentry_dsn", target: &cfg.ErrorReporting.SentryDSN},
+		{env: EnvStatusSigningKey, vaultKey: "status_signing_key", target: &cfg.Server.HTTP.Status.SigningKey},
+	}
+
+	vault, err := readVaultSecrets(cfg.Secrets.Vault)
+	if err != nil {
+		return fmt.Errorf("failed to read secrets from Vault: %w", err)
+	}
+
+	for _, s := range secrets {
+		if value := os.Getenv(s.env); value != "" {
+			*s.target = value
+			continue
+		}
+
+		if path := os.Getenv(s.env + "_FILE"); path != "" {
+			value, err := readSecretFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s_FILE: %w", s.env, err)
+			}
+			*s.target = value
+			continue
+		}
+
+		if value, ok := vault[s.vaultKey]; ok && value != "" {
+			*s.target = value
+		}
+	}
+
+	return nil
+}
+
+// readSecretFile reads a secret from a file, ignoring the trailing newline editors and tools add
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// readVaultSecrets reads the secret's key/value pairs from Vault's HTTP API. It
+// supports both version 1 and version 2 of the KV secrets engine.
+func readVaultSecrets(cfg VaultConfig) (map[string]string, error) {
+	if cfg.Address == "" {
+		return nil, nil
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" && cfg.TokenFile != "" {
+		var err error
+		if token, err = readSecretFile(cfg.TokenFile); err != nil {
+			return nil, err
+		}
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no Vault token; set VAULT_TOKEN or secrets.vault.token_file")
+	}
+
+	url := strings.TrimRight(cfg.Address, "/") + "/v1/" + strings.TrimLeft(cfg.Path, "/")
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	client := &http.Client{Timeout: vaultTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	// KV version 2 nests the pairs in another data object next to the secret's metadata
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, versioned := data["metadata"]; versioned {
+			data = nested
+		}
+	}
+
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		if s, ok := value.(string); ok {
+			values[key] = s
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management-system/internal/logger"
+)
+
+// applyDefaults fills settings missing from the configuration with sane defaults.
+// Settings where zero is meaningful, such as retention periods, are left alone.
+func applyDefaults(cfg *Config) {
+	setDefault(&cfg.App.Name, "task-management-system")
+	setDefault(&cfg.App.Env, "production")
+
+	setDefault(&cfg.Server.HTTP.Port, 8080)
+	setDefault(&cfg.Server.HTTP.BasePath, "/api/v1")
+	setDefault(&cfg.Server.HTTP.SwaggerPath, "/swagger")
+	setDefault(&cfg.Server.HTTP.ReadTimeout, 15*time.Second)
+	setDefault(&cfg.Server.HTTP.ReadHeaderTimeout, 5*time.Second)
+	setDefault(&cfg.Server.HTTP.WriteTimeout, 15*time.Second)
+	setDefault(&cfg.Server.HTTP.IdleTimeout, 60*time.Second)
+	setDefault(&cfg.Server.HTTP.MaxHeaderBytes, 1<<20)
+	setDefault(&cfg.Server.HTTP.Status.MaxAge, 10*time.Second)
+	setDefault(&cfg.Server.HTTP.Status.RequestsPerMinute, 30)
+	setDefault(&cfg.Server.HTTP.Status.Burst, 10)
+	setDefault(&cfg.Server.GRPC.Port, 50051)
+
+	setDefault(&cfg.Database.MongoDB.Name, "task_management")
+	setDefault(&cfg.Database.MongoDB.Timeout, 10*time.Second)
+	setDefault(&cfg.Database.MongoDB.ConnectBackoff, time.Second)
+	setDefault(&cfg.Database.MongoDB.ConnectMaxBackoff, 30*time.Second)
+
+	setDefault(&cfg.Auth.JWT.Expiry, 24*time.Hour)
+	setDefault(&cfg.Auth.Cookie.Name, "tms_session")
+	setDefault(&cfg.Auth.Cookie.SameSite, "lax")
+	setDefault(&cfg.Auth.Impersonation.Expiry, 15*time.Minute)
+	setDefault(&cfg.Auth.Password.BreachTimeout, 3*time.Second)
+	setDefault(&cfg.Auth.Password.Hashing.Algorithm, "bcrypt")
+
+	setDefault(&cfg.Notifications.Timeout, 5*time.Second)
+	setDefault(&cfg.Notifications.Email.SMTPPort, 587)
+
+	setDefault(&cfg.Jobs.DigestInterval, 15*time.Minute)
+	setDefault(&cfg.Jobs.PurgeInterval, time.Hour)
+	setDefault(&cfg.Jobs.ThumbnailInterval, 30*time.Second)
+	setDefault(&cfg.Jobs.ScanInterval, 10*time.Second)
+	setDefault(&cfg.Jobs.UnsnoozeInterval, time.Minute)
+	setDefault(&cfg.Jobs.EscalateInterval, 15*time.Minute)
+	setDefault(&cfg.Jobs.ReminderInterval, time.Minute)
+	setDefault(&cfg.Jobs.AgingInterval, time.Hour)
+	setDefault(&cfg.Jobs.DeferredInterval, time.Minute)
+
+	setDefault(&cfg.Escalation.MinPriority, 4)
+
+	setDefault(&cfg.Aging.MaxPriority, 4)
+
+	setDefault(&cfg.Search.Engine, "text")
+
+	setDefault(&cfg.Content.MaxTitleLength, 200)
+	setDefault(&cfg.Content.MaxDescriptionLength, 20000)
+
+	setDefault(&cfg.Pagination.MaxPageSize, 500)
+	setDefault(&cfg.Pagination.DefaultPageSize, min(50, cfg.Pagination.MaxPageSize))
+
+	setDefault(&cfg.Queries.MaxDueDateRange, 366*24*time.Hour)
+
+	setDefault(&cfg.Storage.Backend, "gridfs")
+	setDefault(&cfg.Storage.URLExpiry, 15*time.Minute)
+	setDefault(&cfg.Storage.MaxAttachmentSize, 100<<20)
+	setDefault(&cfg.Storage.MaxAvatarSize, 2<<20)
+	setDefault(&cfg.Storage.ThumbnailSize, 256)
+	setDefault(&cfg.Storage.S3.Region, "us-east-1")
+
+	setDefault(&cfg.Scanning.Timeout, 2*time.Minute)
+
+	setDefault(&cfg.Logging.Backend, "text")
+	setDefault(&cfg.Logging.Format, "text")
+
+	setDefault(&cfg.Events.Outbox.PollInterval, 5*time.Second)
+	setDefault(&cfg.Events.Outbox.BatchSize, 100)
+
+	setDefault(&cfg.Invitations.Expiry, 7*24*time.Hour)
+}
+
+// setDefault sets a setting to its default when it is unset
+func setDefault[T comparable](setting *T, value T) {
+	var zero T
+	if *setting == zero {
+		*setting = value
+	}
+}
+
+// Validate checks the configuration, reporting every problem found at once
+func (cfg *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(validPort(cfg.Server.HTTP.Port), "server.http.port must be between 1 and 65535, got %d", cfg.Server.HTTP.Port)
+	check(validPort(cfg.Server.GRPC.Port), "server.grpc.port must be between 1 and 65535, got %d", cfg.Server.GRPC.Port)
+	check(cfg.Server.HTTP.Port != cfg.Server.GRPC.Port, "server.http.port and server.grpc.port must differ")
+	check(validBasePath(cfg.Server.HTTP.BasePath), "server.http.base_path must start with / and not end with it, got %q", cfg.Server.HTTP.BasePath)
+	check(validBasePath(cfg.Server.HTTP.SwaggerPath), "server.http.swagger_path must start with / and not end with it, got %q", cfg.Server.HTTP.SwaggerPath)
+	check(cfg.Server.HTTP.ReadTimeout >= 0 && cfg.Server.HTTP.ReadHeaderTimeout >= 0 &&
+		cfg.Server.HTTP.WriteTimeout >= 0 && cfg.Server.HTTP.IdleTimeout >= 0, "server.http timeouts must not be negative")
+	check(cfg.Server.HTTP.MaxHeaderBytes > 0, "server.http.max_header_bytes must be positive")
+	check(cfg.Server.HTTP.Timeouts.Default >= 0 && cfg.Server.HTTP.Timeouts.Auth >= 0 &&
+		cfg.Server.HTTP.Timeouts.Long >= 0, "server.http.timeouts must not be negative")
+	check(cfg.Server.HTTP.Cache.MaxAge >= 0, "server.http.cache.max_age must not be negative")
+	for path, maxAge := range cfg.Server.HTTP.Cache.Routes {
+		check(maxAge >= 0, "server.http.cache.routes[%q] must not be negative", path)
+	}
+	check(cfg.Server.HTTP.Status.MaxAge >= 0, "server.http.status.max_age must not be negative")
+	check(cfg.Server.HTTP.Status.RequestsPerMinute >= 0 && cfg.Server.HTTP.Status.Burst >= 0, "server.http.status rate limit must not be negative")
+
+	check(cfg.Database.MongoDB.SlowQueryThreshold >= 0, "database.mongodb.slow_query_threshold must not be negative")
+	check(cfg.Database.MongoDB.MaxPoolSize == 0 || cfg.Database.MongoDB.MinPoolSize <= cfg.Database.MongoDB.MaxPoolSize, "database.mongodb.min_pool_size must not exceed database.mongodb.max_pool_size")
+	switch cfg.Database.MongoDB.ReadPreference {
+	case "", "primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest":
+	default:
+		check(false, "database.mongodb.read_preference must be \"primary\", \"primaryPreferred\", \"secondary\", \"secondaryPreferred\" or \"nearest\", got %q", cfg.Database.MongoDB.ReadPreference)
+	}
+	if wc := cfg.Database.MongoDB.WriteConcern; wc != "" && wc != "majority" {
+		w, err := strconv.Atoi(wc)
+		check(err == nil && w >= 0, "database.mongodb.write_concern must be \"majority\" or a number of members, got %q", wc)
+	}
+	check(cfg.Database.MongoDB.ConnectRetries >= 0, "database.mongodb.connect_retries must not be negative")
+	check(cfg.Database.MongoDB.ConnectBackoff > 0 && cfg.Database.MongoDB.ConnectBackoff <= cfg.Database.MongoDB.ConnectMaxBackoff, "database.mongodb.connect_backoff must be positive and must not exceed database.mongodb.connect_max_backoff")
+	check(cfg.Database.MongoDB.URI != "", "database.mongodb.uri is required (or set %s)", EnvMongoDBURI)
+
+	check(cfg.Auth.JWT.Secret != "", "auth.jwt.secret is required (or set %s)", EnvJWTSecret)
+	check(cfg.Auth.JWT.Leeway >= 0 && cfg.Auth.JWT.Leeway < cfg.Auth.JWT.Expiry, "auth.jwt.leeway must not be negative and must be shorter than auth.jwt.expiry")
+	check(cfg.Auth.Impersonation.Expiry > 0 && cfg.Auth.Impersonation.Expiry <= cfg.Auth.JWT.Expiry, "auth.impersonation.expiry must be positive and no longer than auth.jwt.expiry")
+	switch strings.ToLower(cfg.Auth.Cookie.SameSite) {
+	case "lax", "strict":
+	case "none":
+		check(!cfg.Auth.Cookie.Enabled || cfg.Auth.Cookie.Secure, "auth.cookie.secure is required when auth.cookie.same_site is \"none\"")
+	default:
+		check(false, "auth.cookie.same_site must be \"lax\", \"strict\" or \"none\", got %q", cfg.Auth.Cookie.SameSite)
+	}
+	check(cfg.Auth.Password.MinLength >= 0, "auth.password.min_length must not be negative")
+	check(cfg.Auth.Password.MaxAge >= 0, "auth.password.max_age must not be negative")
+
+	switch cfg.Auth.Password.BreachCheck {
+	case "", "hibp":
+	case "list":
+		check(cfg.Auth.Password.BreachList != "", "auth.password.breach_list is required for the list breach check")
+	default:
+		check(false, "auth.password.breach_check must be empty, \"hibp\" or \"list\", got %q", cfg.Auth.Password.BreachCheck)
+	}
+
+	hashing := cfg.Auth.Password.Hashing
+	check(hashing.Algorithm == "bcrypt" || hashing.Algorithm == "argon2id",
+		"auth.password.hashing.algorithm must be \"bcrypt\" or \"argon2id\", got %q", hashing.Algorithm)
+	check(hashing.BcryptCost == 0 || (hashing.BcryptCost >= 4 && hashing.BcryptCost <= 31),
+		"auth.password.hashing.bcrypt_cost must be between 4 and 31, got %d", hashing.BcryptCost)
+	check(hashing.Argon2Threads >= 0 && hashing.Argon2Threads <= 255,
+		"auth.password.hashing.argon2_threads must be between 1 and 255, got %d", hashing.Argon2Threads)
+
+	if cfg.Notifications.Email.SMTPHost != "" {
+		check(validPort(cfg.Notifications.Email.SMTPPort), "notifications.email.smtp_port must be between 1 and 65535, got %d", cfg.Notifications.Email.SMTPPort)
+		check(cfg.Notifications.Email.From != "", "notifications.email.from is required when notifications.email.smtp_host is set")
+	}
+
+	switch cfg.Search.Engine {
+	case "text":
+	case "atlas":
+		check(cfg.Search.AtlasIndex != "", "search.atlas_index is required for the atlas search engine")
+	default:
+		check(false, "search.engine must be \"text\" or \"atlas\", got %q", cfg.Search.Engine)
+	}
+
+	check(cfg.Content.MaxTitleLength > 0 && cfg.Content.MaxDescriptionLength > 0, "content lengths must be positive")
+	check(cfg.Pagination.DefaultPageSize > 0 && cfg.Pagination.DefaultPageSize <= cfg.Pagination.MaxPageSize,
+		"pagination.default_page_size must be positive and at most pagination.max_page_size")
+	check(cfg.Queries.MaxDueDateRange > 0, "queries.max_due_date_range must be positive")
+
+	switch cfg.Storage.Backend {
+	case "gridfs":
+	case "s3":
+		s3 := cfg.Storage.S3
+		check(s3.Bucket != "", "storage.s3.bucket is required for the s3 storage backend")
+		check(s3.AccessKeyID != "" && s3.SecretAccessKey != "", "storage.s3.access_key_id and storage.s3.secret_access_key are required for the s3 storage backend (or set %s)", EnvS3SecretAccessKey)
+		check(s3.Endpoint == "" || strings.HasPrefix(s3.Endpoint, "http://") || strings.HasPrefix(s3.Endpoint, "https://"), "storage.s3.endpoint must be an http or https URL, got %q", s3.Endpoint)
+	default:
+		check(false, "storage.backend must be \"gridfs\" or \"s3\", got %q", cfg.Storage.Backend)
+	}
+	check(cfg.Storage.URLExpiry > 0 && cfg.Storage.URLExpiry <= 7*24*time.Hour, "storage.url_expiry must be between 1 minute and 7 days")
+	check(cfg.Storage.MaxAttachmentSize > 0 && cfg.Storage.MaxAvatarSize > 0, "storage sizes must be positive")
+	check(cfg.Storage.ThumbnailSize > 0 && cfg.Storage.ThumbnailSize <= 2048, "storage.thumbnail_size must be between 1 and 2048, got %d", cfg.Storage.ThumbnailSize)
+
+	switch cfg.Scanning.Backend {
+	case "":
+	case "clamav":
+		check(strings.HasPrefix(cfg.Scanning.ClamAVAddress, "tcp://") || strings.HasPrefix(cfg.Scanning.ClamAVAddress, "unix://"), "scanning.clamav_address must be a tcp:// or unix:// address, got %q", cfg.Scanning.ClamAVAddress)
+	case "icap":
+		check(strings.HasPrefix(cfg.Scanning.ICAPURL, "icap://"), "scanning.icap_url must be an icap:// URL, got %q", cfg.Scanning.ICAPURL)
+	default:
+		check(false, "scanning.backend must be empty, \"clamav\" or \"icap\", got %q", cfg.Scanning.Backend)
+	}
+	check(cfg.Scanning.Timeout > 0, "scanning.timeout must be positive")
+
+	check(cfg.Retention.Notifications >= 0, "retention.notifications must not be negative")
+	check(cfg.Retention.DeliveredEvents >= 0, "retention.delivered_events must not be negative")
+	check(cfg.Retention.LoginAttempts >= 0, "retention.login_attempts must not be negative")
+	check(cfg.Retention.Invitations >= 0, "retention.invitations must not be negative")
+	check(cfg.Retention.DeferredNotifications >= 0, "retention.deferred_notifications must not be negative")
+
+	check(cfg.Escalation.MinPriority >= 1 && cfg.Escalation.MinPriority <= 5, "escalation.min_priority must be between 1 and 5, got %d", cfg.Escalation.MinPriority)
+	check(cfg.Escalation.Margin >= 0, "escalation.margin must not be negative")
+
+	check(cfg.Aging.MaxPriority >= 2 && cfg.Aging.MaxPriority <= 5, "aging.max_priority must be between 2 and 5, got %d", cfg.Aging.MaxPriority)
+	check(cfg.Aging.IdleAfter >= 0, "aging.idle_after must not be negative")
+	check(cfg.Aging.PendingAfter >= 0, "aging.pending_after must not be negative")
+	check(!cfg.Aging.Enabled || cfg.Aging.IdleAfter > 0 || cfg.Aging.PendingAfter > 0, "aging is enabled but both aging.idle_after and aging.pending_after are 0")
+
+	check(cfg.Logging.Backend == "text" || cfg.Logging.Backend == "slog" || cfg.Logging.Backend == "zap", "logging.backend must be \"text\", \"slog\" or \"zap\", got %q", cfg.Logging.Backend)
+	check(cfg.Logging.Format == "text" || cfg.Logging.Format == "json", "logging.format must be \"text\" or \"json\", got %q", cfg.Logging.Format)
+	if _, err := logger.NewRedactor(cfg.Logging.RedactPatterns); err != nil {
+		check(false, "logging.redact_patterns: %v", err)
+	}
+
+	if cfg.ErrorReporting.SentryDSN != "" {
+		dsn, err := url.Parse(cfg.ErrorReporting.SentryDSN)
+		check(err == nil && (dsn.Scheme == "https" || dsn.Scheme == "http") && dsn.User != nil && strings.Trim(dsn.Path, "/") != "",
+			"error_reporting.sentry_dsn must look like https://key@host/project")
+	}
+
+	problems = append(problems, cfg.Runtime.problems()...)
+
+	if cfg.Secrets.Vault.Address != "" {
+		check(cfg.Secrets.Vault.Path != "", "secrets.vault.path is required when secrets.vault.address is set")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// validPort reports whether a port number is usable
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// validBasePath reports whether a path prefix can have the route paths appended to it
+func validBasePath(path string) bool {
+	return len(path) > 1 && strings.HasPrefix(path, "/") && !strings.HasSuffix(path, "/")
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"task-management-system/api/proto"
@@ -20,6 +21,9 @@ func main() {
 	}
 	defer client.Close()
 
+	// Example: Sign in; the token authorizes the following calls
+	loginExample(client)
+
 	// Example: Create a new task
 	createTaskExample(client)
 
@@ -33,13 +37,27 @@ func main() {
 	validateTokenExample(client)
 }
 
-func createTaskExample(client *grpcClient.Client) {
+func loginExample(client *grpcClient.Client) {
 	// Set context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Set auth token (in a real application, this would be obtained from authentication)
-	client.SetAuthToken("sample_jwt_token")
+	// Sign in with example credentials
+	resp, err := client.Login(ctx, "johndoe", "securepassword123")
+	if err != nil {
+		log.Printf("Failed to sign in: %v", err)
+		return
+	}
+
+	// Use the token for subsequent requests
+	client.SetAuthToken(resp.AccessToken)
+	fmt.Printf("Signed in as %s, token expires at %s\n", resp.Username, resp.ExpiresAt.AsTime().Format(time.RFC3339))
+}
+
+func createTaskExample(client *grpcClient.Client) {
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	// Create task request
 	req := &proto.CreateTaskRequest{
@@ -87,11 +105,12 @@ func updateTaskExample(client *grpcClient.Client) {
 	// This assumes a task with this ID exists
 	taskID := "60f1a7c9e113d70001abcdef" // Example task ID
 
-	// Update task request
+	// Update task request; the mask starts the task and clears its due date
 	req := &proto.UpdateTaskRequest{
-		Id:        taskID,
-		Status:    proto.TaskStatus_TASK_STATUS_IN_PROGRESS,
-		UpdatedBy: "60f1a7c9e113d70001234567", // Example user ID
+		Id:         taskID,
+		Status:     proto.TaskStatus_TASK_STATUS_IN_PROGRESS,
+		UpdatedBy:  "60f1a7c9e113d70001234567", // Example user ID
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status", "due_date"}},
 	}
 
 	// Call the server
@@ -0,0 +1,346 @@
+// Package app wires the repositories and use cases of the REST API together
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"task-management-system/config"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/infrastructure/breach"
+	"task-management-system/internal/infrastructure/events"
+	"task-management-system/internal/infrastructure/hashing"
+	"task-management-system/internal/infrastructure/mongodb"
+	"task-management-system/internal/infrastructure/notifier"
+	"task-management-system/internal/infrastructure/scanner"
+	"task-management-system/internal/infrastructure/scheduler"
+	"task-management-system/internal/infrastructure/storage"
+	"task-management-system/internal/infrastructure/thumbnail"
+	"task-management-system/internal/logger"
+	"task-management-system/internal/usecase"
+)
+
+// thumbnailBatchSize is how many attachment thumbnails each job run generates at most
+const thumbnailBatchSize = 20
+
+// scanBatchSize is how many attachments each job run scans at most
+const scanBatchSize = 20
+
+// unsnoozeBatchSize is how many ended task snoozes each job run releases at most
+const unsnoozeBatchSize = 100
+
+// reminderBatchSize is how many due task reminders each job run sends at most
+const reminderBatchSize = 100
+
+// deferredBatchSize is how many users each job run sends notifications held back during quiet hours to at most
+const deferredBatchSize = 100
+
+// escalationBatchSize is how many overdue tasks each job run escalates at most
+const escalationBatchSize = 100
+
+// agingBatchSize is how many idle or long-pending tasks each job run ages at most
+const agingBatchSize = 100
+
+// App holds the use cases served by the REST API
+type App struct {
+	Tasks         *usecase.TaskUseCase
+	Users         *usecase.UserUseCase
+	Auth          *usecase.AuthUseCase
+	Stars         *usecase.StarUseCase
+	Today         *usecase.TodayUseCase
+	Notifications *usecase.NotificationUseCase
+	Organizations *usecase.OrganizationUseCase
+	Invitations   *usecase.InvitationUseCase
+	Projects      *usecase.ProjectUseCase
+	Audit         *usecase.AuditUseCase
+	Attachments   *usecase.AttachmentUseCase
+	Avatars       *usecase.AvatarUseCase
+	Exports       *usecase.ExportUseCase
+	Merges        *usecase.MergeUseCase
+	InboundHooks  *usecase.InboundHookUseCase
+	CalendarFeeds *usecase.CalendarFeedUseCase
+	Reminders     *usecase.ReminderUseCase
+	Status        *usecase.StatusUseCase
+
+	cfg                   *config.Config
+	jobsLog               logger.Logger
+	eventBus              *events.Bus
+	taskRepo              domain.TaskRepository
+	userRepo              domain.UserRepository
+	projectRepo           domain.ProjectRepository
+	notificationRepo      domain.NotificationRepository
+	notificationPrefsRepo domain.NotificationPreferencesRepository
+	snoozeRepo            domain.TaskSnoozeRepository
+	outboxRepo            domain.OutboxRepository
+	loginAttemptRepo      domain.LoginAttemptRepository
+	invitationRepo        domain.InvitationRepository
+	deferredRepo          domain.DeferredNotificationRepository
+}
+
+// New creates the repositories and use cases on a database of the given client.
+// The use cases, the event bus and the background jobs log to the given logger.
+func New(cfg *config.Config, log logger.Logger, client *mongo.Client, db *mongo.Database) (*App, error) {
+	timeout := cfg.Database.MongoDB.Timeout
+
+	// Initialize repositories
+	taskRepo := mongodb.NewTaskRepository(db, timeout)
+	taskSearcher, err := mongodb.NewTaskSearcher(db, cfg.Search.Engine, cfg.Search.AtlasIndex, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize task search: %w", err)
+	}
+	userRepo := mongodb.NewUserRepository(db, timeout)
+	orgRepo := mongodb.NewOrganizationRepository(db, timeout)
+	invitationRepo := mongodb.NewInvitationRepository(db, timeout)
+	projectRepo := mongodb.NewProjectRepository(db, timeout)
+	sprintRepo := mongodb.NewSprintRepository(db, timeout)
+	milestoneRepo := mongodb.NewMilestoneRepository(db, timeout)
+	notificationRepo := mongodb.NewNotificationRepository(db, timeout)
+	notificationPrefsRepo := mongodb.NewNotificationPreferencesRepository(db, timeout)
+	starRepo := mongodb.NewTaskStarRepository(db, timeout)
+	viewRepo := mongodb.NewTaskViewRepository(db, timeout)
+	snoozeRepo := mongodb.NewTaskSnoozeRepository(db, timeout)
+	dayPlanRepo := mongodb.NewDayPlanRepository(db, timeout)
+	inboundHookRepo := mongodb.NewInboundHookRepository(db, timeout)
+	outboxRepo := mongodb.NewOutboxRepository(db, timeout)
+	deferredRepo := mongodb.NewDeferredNotificationRepository(db, timeout)
+	auditRepo := mongodb.NewAuditRepository(db, timeout)
+	sessionRepo := mongodb.NewSessionRepository(db, timeout)
+	loginAttemptRepo := mongodb.NewLoginAttemptRepository(db, timeout)
+	counterRepo := mongodb.NewCounterRepository(db, timeout)
+	attachmentRepo := mongodb.NewAttachmentRepository(db, timeout)
+	blobStore, err := storage.NewFromConfig(cfg.Storage, db, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	attachmentScanner, err := scanner.NewFromConfig(cfg.Scanning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize attachment scanning: %w", err)
+	}
+
+	// Initialize usecases
+	eventBus := events.NewBus(log)
+	notificationUseCase := usecase.NewNotificationUseCase(
+		notificationRepo,
+		notificationPrefsRepo,
+		deferredRepo,
+		userRepo,
+		log,
+		notifier.NewFromConfig(cfg.Notifications)...,
+	)
+	eventBus.Subscribe(notificationUseCase.HandleEvent)
+	eventBus.Subscribe(usecase.NewConnectorUseCase(projectRepo, log, notifier.NewChatPostersFromConfig(cfg.Notifications)...).HandleEvent)
+	if len(cfg.Events.Webhooks) > 0 {
+		eventBus.Subscribe(notifier.NewEventWebhook(cfg.Events.Webhooks, cfg.Notifications.Timeout).HandleEvent)
+	}
+
+	// With the outbox enabled, task events are stored transactionally and delivered by the relay job
+	var unitOfWork domain.UnitOfWork
+	if cfg.Events.Outbox.Enabled {
+		unitOfWork = mongodb.NewUnitOfWork(client, db, timeout)
+	}
+
+	policy := usecase.NewPolicy(userRepo, projectRepo)
+	contentPolicy := usecase.ContentPolicy{
+		MaxTitleLength:       cfg.Content.MaxTitleLength,
+		MaxDescriptionLength: cfg.Content.MaxDescriptionLength,
+		Sanitize:             cfg.Content.Sanitize,
+	}
+	attachmentUseCase := usecase.NewAttachmentUseCase(attachmentRepo, taskRepo, blobStore, thumbnail.New(cfg.Storage.ThumbnailSize), attachmentScanner, eventBus, policy, usecase.UploadLimits{
+		MaxSize:   cfg.Storage.MaxAttachmentSize,
+		URLExpiry: cfg.Storage.URLExpiry,
+	}, log)
+	eventBus.Subscribe(attachmentUseCase.HandleEvent)
+	pageLimits := usecase.PageLimits{Default: cfg.Pagination.DefaultPageSize, Max: cfg.Pagination.MaxPageSize}
+	queryGuardrails := usecase.QueryGuardrails{MaxDueDateRange: cfg.Queries.MaxDueDateRange}
+	reminderUseCase := usecase.NewReminderUseCase(mongodb.NewTaskReminderRepository(db, timeout), taskRepo, notificationUseCase, policy, log)
+	eventBus.Subscribe(reminderUseCase.HandleEvent)
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, userRepo, taskSearcher, counterRepo, snoozeRepo, viewRepo, auditRepo, eventBus, unitOfWork, policy, contentPolicy, pageLimits, queryGuardrails, log)
+	passwordPolicy := usecase.PasswordPolicy{
+		MinLength:     cfg.Auth.Password.MinLength,
+		RequireUpper:  cfg.Auth.Password.RequireUpper,
+		RequireLower:  cfg.Auth.Password.RequireLower,
+		RequireDigit:  cfg.Auth.Password.RequireDigit,
+		RequireSymbol: cfg.Auth.Password.RequireSymbol,
+		Banned:        cfg.Auth.Password.Banned,
+		MaxAge:        cfg.Auth.Password.MaxAge,
+	}
+	if passwordPolicy.Breached, err = breach.NewCheckerFromConfig(cfg.Auth.Password); err != nil {
+		return nil, fmt.Errorf("failed to initialize breached password check: %w", err)
+	}
+	passwordHasher, err := hashing.NewHasher(cfg.Auth.Password.Hashing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize password hashing: %w", err)
+	}
+	tokenOptions := usecase.TokenOptions{
+		Secret:              cfg.Auth.JWT.Secret,
+		Expiry:              cfg.Auth.JWT.Expiry,
+		Issuer:              cfg.Auth.JWT.Issuer,
+		Audience:            cfg.Auth.JWT.Audience,
+		Leeway:              cfg.Auth.JWT.Leeway,
+		Impersonation:       cfg.Auth.Impersonation.Enabled,
+		ImpersonationExpiry: cfg.Auth.Impersonation.Expiry,
+	}
+	userUseCase := usecase.NewUserUseCase(userRepo, orgRepo, taskRepo, auditRepo, eventBus, unitOfWork, policy, passwordPolicy, passwordHasher, pageLimits, log)
+	authUseCase := usecase.NewAuthUseCase(userRepo, sessionRepo, loginAttemptRepo, auditRepo, passwordPolicy, passwordHasher, tokenOptions, log)
+
+	// Invitations are emailed when SMTP is configured; otherwise admins share the returned token
+	var invitationSender domain.EmailSender
+	if email := notifier.NewEmailFromConfig(cfg.Notifications.Email); email != nil {
+		invitationSender = email
+	}
+
+	return &App{
+		Tasks:         taskUseCase,
+		Users:         userUseCase,
+		Auth:          authUseCase,
+		Stars:         usecase.NewStarUseCase(starRepo, taskRepo, userRepo, policy, log),
+		Today:         usecase.NewTodayUseCase(dayPlanRepo, taskRepo, userRepo, notificationRepo, snoozeRepo, policy, log),
+		Notifications: notificationUseCase,
+		Organizations: usecase.NewOrganizationUseCase(orgRepo, userRepo, auditRepo, log),
+		Invitations: usecase.NewInvitationUseCase(invitationRepo, orgRepo, userRepo, auditRepo, userUseCase, authUseCase, invitationSender, usecase.InvitationConfig{
+			Expiry:    cfg.Invitations.Expiry,
+			AcceptURL: cfg.Invitations.AcceptURL,
+			AppName:   cfg.App.Name,
+		}, log),
+		Projects:    usecase.NewProjectUseCase(projectRepo, taskRepo, userRepo, sprintRepo, milestoneRepo, inboundHookRepo, auditRepo, policy, log),
+		Audit:       usecase.NewAuditUseCase(auditRepo, userRepo),
+		Attachments: attachmentUseCase,
+		Avatars: usecase.NewAvatarUseCase(userRepo, blobStore, usecase.UploadLimits{
+			MaxSize:   cfg.Storage.MaxAvatarSize,
+			URLExpiry: cfg.Storage.URLExpiry,
+		}, log),
+		Merges:        usecase.NewMergeUseCase(taskRepo, attachmentRepo, starRepo, eventBus, unitOfWork, policy, log),
+		InboundHooks:  usecase.NewInboundHookUseCase(inboundHookRepo, userRepo, taskUseCase, policy, log),
+		CalendarFeeds: usecase.NewCalendarFeedUseCase(mongodb.NewCalendarFeedRepository(db, timeout), taskRepo, policy, log),
+		Reminders:     reminderUseCase,
+		Status:        usecase.NewStatusUseCase(cfg.App.Version, mongodb.NewPinger(client, timeout), cfg.Server.HTTP.Status.MaxAge, log),
+		Exports:       usecase.NewExportUseCase(orgRepo, userRepo, projectRepo, taskRepo, attachmentRepo, counterRepo, mongodb.NewImportRepository(db, timeout), auditRepo, log),
+
+		cfg:                   cfg,
+		jobsLog:               logger.WithComponent(log, logger.ComponentJobs),
+		eventBus:              eventBus,
+		taskRepo:              taskRepo,
+		userRepo:              userRepo,
+		projectRepo:           projectRepo,
+		notificationRepo:      notificationRepo,
+		notificationPrefsRepo: notificationPrefsRepo,
+		snoozeRepo:            snoozeRepo,
+		outboxRepo:            outboxRepo,
+		loginAttemptRepo:      loginAttemptRepo,
+		invitationRepo:        invitationRepo,
+		deferredRepo:          deferredRepo,
+	}, nil
+}
+
+// Jobs returns the background jobs enabled by the configuration, not yet started
+func (a *App) Jobs() *scheduler.Scheduler {
+	jobs := scheduler.New(a.jobsLog)
+	if !a.cfg.Jobs.Enabled {
+		return jobs
+	}
+
+	if email := notifier.NewEmailFromConfig(a.cfg.Notifications.Email); email != nil {
+		digestUseCase := usecase.NewDigestUseCase(a.taskRepo, a.userRepo, a.notificationRepo, a.notificationPrefsRepo, a.snoozeRepo, email, a.jobsLog)
+		jobs.Every("daily-digest", a.cfg.Jobs.DigestInterval, func() error {
+			sent, err := digestUseCase.SendDueDigests(time.Now())
+			if sent > 0 {
+				a.jobsLog.InfoF("Sent %d daily digests", sent)
+			}
+			return err
+		})
+	} else {
+		a.jobsLog.InfoF("Daily digest job disabled: email is not configured")
+	}
+
+	if a.cfg.Events.Outbox.Enabled {
+		relay := events.NewRelay(a.outboxRepo, a.eventBus, a.cfg.Events.Outbox.BatchSize, a.jobsLog)
+		jobs.Every("outbox-relay", a.cfg.Events.Outbox.PollInterval, relay.Run)
+	}
+
+	jobs.Every("attachment-thumbnails", a.cfg.Jobs.ThumbnailInterval, func() error {
+		generated, err := a.Attachments.GenerateThumbnails(thumbnailBatchSize)
+		if generated > 0 {
+			a.jobsLog.InfoF("Generated %d attachment thumbnails", generated)
+		}
+		return err
+	})
+
+	jobs.Every("task-unsnooze", a.cfg.Jobs.UnsnoozeInterval, func() error {
+		released, err := a.Tasks.ReleaseSnoozes(time.Now(), unsnoozeBatchSize)
+		if released > 0 {
+			a.jobsLog.InfoF("Ended %d task snoozes", released)
+		}
+		return err
+	})
+
+	jobs.Every("task-reminders", a.cfg.Jobs.ReminderInterval, func() error {
+		sent, err := a.Reminders.SendDueReminders(time.Now(), reminderBatchSize)
+		if sent > 0 {
+			a.jobsLog.InfoF("Sent %d task reminders", sent)
+		}
+		return err
+	})
+
+	jobs.Every("quiet-hours-summaries", a.cfg.Jobs.DeferredInterval, func() error {
+		sent, err := a.Notifications.SendDeferredSummaries(time.Now(), deferredBatchSize)
+		if sent > 0 {
+			a.jobsLog.InfoF("Sent %d summaries of notifications held back during quiet hours", sent)
+		}
+		return err
+	})
+
+	if a.cfg.Escalation.Enabled {
+		escalationUseCase := usecase.NewEscalationUseCase(a.taskRepo, a.projectRepo, a.eventBus, usecase.EscalationPolicy{
+			MinPriority:        a.cfg.Escalation.MinPriority,
+			Margin:             a.cfg.Escalation.Margin,
+			NotifyProjectAdmin: a.cfg.Escalation.NotifyProjectAdmin,
+		}, a.jobsLog)
+		jobs.Every("task-escalation", a.cfg.Jobs.EscalateInterval, func() error {
+			escalated, err := escalationUseCase.EscalateOverdue(time.Now(), escalationBatchSize)
+			if escalated > 0 {
+				a.jobsLog.InfoF("Escalated %d overdue tasks", escalated)
+			}
+			return err
+		})
+	}
+
+	if a.cfg.Aging.Enabled {
+		agingUseCase := usecase.NewAgingUseCase(a.Tasks, usecase.AgingPolicy{
+			IdleAfter:    a.cfg.Aging.IdleAfter,
+			PendingAfter: a.cfg.Aging.PendingAfter,
+			MaxPriority:  a.cfg.Aging.MaxPriority,
+		})
+		jobs.Every("task-aging", a.cfg.Jobs.AgingInterval, func() error {
+			aged, err := agingUseCase.AgeTasks(time.Now(), agingBatchSize)
+			if aged > 0 {
+				a.jobsLog.InfoF("Raised the priority of %d idle or long-pending tasks", aged)
+			}
+			return err
+		})
+	}
+
+	if a.cfg.Scanning.Backend != "" {
+		jobs.Every("attachment-scans", a.cfg.Jobs.ScanInterval, func() error {
+			quarantined, err := a.Attachments.ScanAttachments(scanBatchSize)
+			if quarantined > 0 {
+				a.jobsLog.WarnF("Quarantined %d attachments", quarantined)
+			}
+			return err
+		})
+	}
+
+	retentionUseCase := usecase.NewRetentionUseCase(a.notificationRepo, a.outboxRepo, a.loginAttemptRepo, a.invitationRepo, a.deferredRepo, usecase.RetentionPolicy{
+		Notifications:         a.cfg.Retention.Notifications,
+		DeliveredEvents:       a.cfg.Retention.DeliveredEvents,
+		LoginAttempts:         a.cfg.Retention.LoginAttempts,
+		Invitations:           a.cfg.Retention.Invitations,
+		DeferredNotifications: a.cfg.Retention.DeferredNotifications,
+	}, a.jobsLog)
+	jobs.Every("retention-purge", a.cfg.Jobs.PurgeInterval, func() error {
+		return retentionUseCase.Purge(time.Now())
+	})
+
+	return jobs
+}
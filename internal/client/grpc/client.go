@@ -2,7 +2,9 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"google.golang.org/grpc"
@@ -84,6 +86,26 @@ func (c *Client) UpdateTask(ctx context.Context, input *proto.UpdateTaskRequest)
 	return c.taskClient.UpdateTask(ctx, input)
 }
 
+// BatchCreateTasks creates several tasks at once; each result reports whether its task was created
+func (c *Client) BatchCreateTasks(ctx context.Context, tasks []*proto.CreateTaskRequest) ([]*proto.BatchTaskResult, error) {
+	ctx = c.createAuthContext(ctx)
+	resp, err := c.taskClient.BatchCreateTasks(ctx, &proto.BatchCreateTasksRequest{Tasks: tasks})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// BatchDeleteTasks deletes several tasks at once; each result reports whether its task was deleted
+func (c *Client) BatchDeleteTasks(ctx context.Context, ids []string, userID string) ([]*proto.BatchTaskResult, error) {
+	ctx = c.createAuthContext(ctx)
+	resp, err := c.taskClient.BatchDeleteTasks(ctx, &proto.BatchDeleteTasksRequest{Ids: ids, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
 // DeleteTask deletes a task
 func (c *Client) DeleteTask(ctx context.Context, id string, userID string) error {
 	ctx = c.createAuthContext(ctx)
@@ -94,16 +116,69 @@ func (c *Client) DeleteTask(ctx context.Context, id string, userID string) error
 	return err
 }
 
-// ListTasks lists tasks with optional status filter
+// ListTasks lists every task with an optional status filter, requesting the
+// server's pages one after another
 func (c *Client) ListTasks(ctx context.Context, status proto.TaskStatus) ([]*proto.TaskResponse, error) {
 	ctx = c.createAuthContext(ctx)
-	resp, err := c.taskClient.ListTasks(ctx, &proto.ListTasksRequest{
+
+	var tasks []*proto.TaskResponse
+	after := ""
+	for {
+		resp, err := c.taskClient.ListTasks(ctx, &proto.ListTasksRequest{
+			Status: status,
+			After:  after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, resp.Tasks...)
+
+		// A short page is the last one; servers that do not page report no page size
+		if len(resp.Tasks) == 0 || len(resp.Tasks) < int(resp.PageSize) || resp.PageSize == 0 {
+			return tasks, nil
+		}
+		after = resp.Tasks[len(resp.Tasks)-1].Id
+	}
+}
+
+// ExportTasks streams every task with an optional status filter, passing each
+// chunk to fn as it arrives. It stops at the first error fn returns.
+func (c *Client) ExportTasks(ctx context.Context, status proto.TaskStatus, chunkSize int32, fn func([]*proto.TaskResponse) error) error {
+	ctx, cancel := context.WithCancel(c.createAuthContext(ctx))
+	defer cancel() // Ends the stream early if fn fails
+
+	stream, err := c.taskClient.ExportTasks(ctx, &proto.ExportTasksRequest{
+		Status:    status,
+		ChunkSize: chunkSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(chunk.Tasks); err != nil {
+			return err
+		}
+	}
+}
+
+// CountTasks counts tasks with an optional status filter
+func (c *Client) CountTasks(ctx context.Context, status proto.TaskStatus) (int64, error) {
+	ctx = c.createAuthContext(ctx)
+	resp, err := c.taskClient.CountTasks(ctx, &proto.ListTasksRequest{
 		Status: status,
 	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return resp.Tasks, nil
+	return resp.Count, nil
 }
 
 // AssignTask assigns a task to a user
@@ -116,6 +191,16 @@ func (c *Client) AssignTask(ctx context.Context, taskID, assigneeID, assignedBy
 	})
 }
 
+// UnassignTask removes a user from a task's assignees
+func (c *Client) UnassignTask(ctx context.Context, taskID, assigneeID, unassignedBy string) (*proto.TaskResponse, error) {
+	ctx = c.createAuthContext(ctx)
+	return c.taskClient.UnassignTask(ctx, &proto.UnassignTaskRequest{
+		TaskId:       taskID,
+		AssigneeId:   assigneeID,
+		UnassignedBy: unassignedBy,
+	})
+}
+
 // GetUserTasks gets tasks for a user
 func (c *Client) GetUserTasks(ctx context.Context, userID string) ([]*proto.TaskResponse, error) {
 	ctx = c.createAuthContext(ctx)
@@ -130,13 +215,62 @@ func (c *Client) GetUserTasks(ctx context.Context, userID string) ([]*proto.Task
 
 // User Service Methods
 
+// RegisterUser registers a user and signs them in. The returned token is not set
+// on the client; call SetAuthToken to use it.
+func (c *Client) RegisterUser(ctx context.Context, req *proto.RegisterUserRequest) (*proto.AuthResponse, error) {
+	return c.userClient.RegisterUser(ctx, req)
+}
+
+// Login signs a user in. The returned token is not set on the client; call SetAuthToken to use it.
+func (c *Client) Login(ctx context.Context, login string, password string) (*proto.AuthResponse, error) {
+	return c.userClient.Login(ctx, &proto.LoginRequest{Login: login, Password: password})
+}
+
 // GetUser gets a user by ID
 func (c *Client) GetUser(ctx context.Context, id string) (*proto.UserResponse, error) {
 	ctx = c.createAuthContext(ctx)
 	return c.userClient.GetUser(ctx, &proto.GetUserRequest{Id: id})
 }
 
+// UpdateUser updates the signed-in user's profile
+func (c *Client) UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.UserResponse, error) {
+	ctx = c.createAuthContext(ctx)
+	return c.userClient.UpdateUser(ctx, req)
+}
+
+// DeleteUser deletes the signed-in user's account; the request's deletion policy
+// decides what happens to their open tasks
+func (c *Client) DeleteUser(ctx context.Context, req *proto.DeleteUserRequest) error {
+	ctx = c.createAuthContext(ctx)
+	_, err := c.userClient.DeleteUser(ctx, req)
+	return err
+}
+
+// DeactivateUser deactivates an account, keeping the references to it valid
+func (c *Client) DeactivateUser(ctx context.Context, id string) (*proto.UserResponse, error) {
+	ctx = c.createAuthContext(ctx)
+	return c.userClient.DeactivateUser(ctx, &proto.DeactivateUserRequest{Id: id})
+}
+
+// ReactivateUser lets a deactivated account sign in again; requires an organization admin
+func (c *Client) ReactivateUser(ctx context.Context, id string) (*proto.UserResponse, error) {
+	ctx = c.createAuthContext(ctx)
+	return c.userClient.ReactivateUser(ctx, &proto.ReactivateUserRequest{Id: id})
+}
+
 // ValidateToken validates a JWT token
 func (c *Client) ValidateToken(ctx context.Context, token string) (*proto.ValidateTokenResponse, error) {
 	return c.userClient.ValidateToken(ctx, &proto.ValidateTokenRequest{Token: token})
 }
+
+// ListUsers lists a page of the organization's users; requires an organization admin
+func (c *Client) ListUsers(ctx context.Context, page int32, pageSize int32) (*proto.ListUsersResponse, error) {
+	ctx = c.createAuthContext(ctx)
+	return c.userClient.ListUsers(ctx, &proto.ListUsersRequest{Page: page, PageSize: pageSize})
+}
+
+// SearchUsers searches the organization's users by username, email or name; requires an organization admin
+func (c *Client) SearchUsers(ctx context.Context, query string, page int32, pageSize int32) (*proto.ListUsersResponse, error) {
+	ctx = c.createAuthContext(ctx)
+	return c.userClient.SearchUsers(ctx, &proto.SearchUsersRequest{Query: query, Page: page, PageSize: pageSize})
+}
@@ -0,0 +1,311 @@
+// Package connect serves a gRPC server over the Connect protocol, so clients can
+// call it with plain JSON or protobuf over HTTP/1.1 and use server streaming where
+// they support it. See https://connectrpc.com/docs/protocol.
+package connect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Connect content types. Unary RPCs send bare messages; streaming RPCs send enveloped ones.
+const (
+	contentTypeJSON        = "application/json"
+	contentTypeProto       = "application/proto"
+	contentTypeStreamJSON  = "application/connect+json"
+	contentTypeStreamProto = "application/connect+proto"
+	contentTypeGRPC        = "application/grpc+proto"
+)
+
+// Envelope flags
+const (
+	flagCompressed byte = 0x01
+	flagEndStream  byte = 0x02
+)
+
+// maxMessageSize matches the gRPC server's message size limit
+const maxMessageSize = 4 * 1024 * 1024
+
+// method describes an RPC served over Connect
+type method struct {
+	input     protoreflect.MessageType
+	output    protoreflect.MessageType
+	streaming bool
+}
+
+// Handler translates Connect requests into gRPC requests for a gRPC server
+type Handler struct {
+	server  *grpc.Server
+	methods map[string]*method
+}
+
+// NewHandler creates a handler for the services registered on server. Register
+// every service before calling it. Only unary and server-streaming RPCs of services
+// whose descriptors are linked into the binary are served.
+func NewHandler(server *grpc.Server) *Handler {
+	methods := make(map[string]*method)
+	for name, info := range server.GetServiceInfo() {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			continue
+		}
+		service, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			continue
+		}
+
+		for _, m := range info.Methods {
+			md := service.Methods().ByName(protoreflect.Name(m.Name))
+			if md == nil || md.IsStreamingClient() {
+				continue
+			}
+			input, err := protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName())
+			if err != nil {
+				continue
+			}
+			output, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+			if err != nil {
+				continue
+			}
+
+			methods["/"+name+"/"+m.Name] = &method{
+				input:     input,
+				output:    output,
+				streaming: md.IsStreamingServer(),
+			}
+		}
+	}
+
+	return &Handler{
+		server:  server,
+		methods: methods,
+	}
+}
+
+// Handles reports whether a request, including a CORS preflight, is a Connect call
+// of one of the gRPC services. Their paths look like "/task.TaskService/GetTask".
+func (h *Handler) Handles(r *http.Request) bool {
+	if _, ok := h.methods[r.URL.Path]; !ok {
+		return false
+	}
+	if r.Method == http.MethodOptions {
+		return true
+	}
+
+	switch mediaType(r) {
+	case contentTypeJSON, contentTypeProto, contentTypeStreamJSON, contentTypeStreamProto:
+		return true
+	}
+	return false
+}
+
+// ServeHTTP serves a Connect request
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m := h.methods[r.URL.Path]
+	if m == nil || r.Method != http.MethodPost {
+		http.Error(w, "Unsupported Connect request", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType := mediaType(r)
+	switch {
+	case !m.streaming && (contentType == contentTypeJSON || contentType == contentTypeProto):
+		h.serveUnary(w, r, m, contentType == contentTypeJSON)
+	case m.streaming && (contentType == contentTypeStreamJSON || contentType == contentTypeStreamProto):
+		h.serveStream(w, r, m, contentType == contentTypeStreamJSON)
+	default:
+		http.Error(w, "Unsupported content type for this RPC", http.StatusUnsupportedMediaType)
+	}
+}
+
+// serveUnary serves a unary RPC: the body is the request message and the response
+// is either the response message or a JSON error
+func (h *Handler) serveUnary(w http.ResponseWriter, r *http.Request, m *method, json bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+	if err != nil {
+		writeError(w, status.New(codes.ResourceExhausted, "request message too large"))
+		return
+	}
+
+	msg, err := toProto(m.input, body, json)
+	if err != nil {
+		writeError(w, status.New(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	var messages [][]byte
+	resp := newGRPCResponse(func(msg []byte) error {
+		messages = append(messages, msg)
+		return nil
+	})
+	h.server.ServeHTTP(resp, grpcRequest(r, msg))
+
+	// Custom response metadata is sent as headers, trailers with a prefix
+	resp.copyHeaders(w.Header())
+	for key, values := range resp.trailers() {
+		w.Header()["Trailer-"+key] = values
+	}
+
+	st := resp.status()
+	if st.Code() != codes.OK {
+		writeError(w, st)
+		return
+	}
+	if len(messages) != 1 {
+		writeError(w, status.New(codes.Internal, "unary RPC did not return one message"))
+		return
+	}
+
+	out, err := fromProto(m.output, messages[0], json)
+	if err != nil {
+		writeError(w, status.New(codes.Internal, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
+
+// serveStream serves a server-streaming RPC: the body is one enveloped request
+// message and the response streams enveloped messages, ending with an end-stream
+// message that holds the error, if any, and the trailers
+func (h *Handler) serveStream(w http.ResponseWriter, r *http.Request, m *method, json bool) {
+	// Streams end when the RPC does; deadlines bound them instead of the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+
+	body, err := readEnvelope(http.MaxBytesReader(w, r.Body, maxMessageSize+5))
+	if err != nil {
+		writeEndStream(w, status.New(codes.InvalidArgument, err.Error()), nil)
+		return
+	}
+
+	msg, err := toProto(m.input, body, json)
+	if err != nil {
+		writeEndStream(w, status.New(codes.InvalidArgument, err.Error()), nil)
+		return
+	}
+
+	var resp *grpcResponse
+	resp = newGRPCResponse(func(msg []byte) error {
+		out, err := fromProto(m.output, msg, json)
+		if err != nil {
+			return err
+		}
+
+		resp.sendHeaders(w)
+		if _, err := w.Write(envelope(0, out)); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	h.server.ServeHTTP(resp, grpcRequest(r, msg))
+
+	resp.sendHeaders(w)
+	writeEndStream(w, resp.status(), resp.trailers())
+}
+
+// grpcRequest presents a Connect request to the gRPC server as a native gRPC request
+// carrying one protobuf message
+func grpcRequest(r *http.Request, msg []byte) *http.Request {
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor = 2, 0
+	req.ContentLength = -1
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", contentTypeGRPC)
+	req.Body = io.NopCloser(bytes.NewReader(envelope(0, msg)))
+
+	// gRPC timeouts have at most 8 digits
+	if ms := r.Header.Get("Connect-Timeout-Ms"); ms != "" && len(ms) <= 8 {
+		if _, err := strconv.ParseUint(ms, 10, 32); err == nil {
+			req.Header.Set("Grpc-Timeout", ms+"m")
+		}
+	}
+
+	return req
+}
+
+// toProto decodes a request message to protobuf
+func toProto(typ protoreflect.MessageType, data []byte, json bool) ([]byte, error) {
+	if !json {
+		return data, nil
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte("{}")
+	}
+	msg := typ.New().Interface()
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("invalid request message: %w", err)
+	}
+	return proto.Marshal(msg)
+}
+
+// fromProto encodes a protobuf response message for the client
+func fromProto(typ protoreflect.MessageType, data []byte, json bool) ([]byte, error) {
+	if !json {
+		return data, nil
+	}
+
+	msg := typ.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("invalid response message: %w", err)
+	}
+	return protojson.Marshal(msg)
+}
+
+// mediaType returns a request's content type without parameters
+func mediaType(r *http.Request) string {
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return contentType
+}
+
+// envelope frames a message for streaming
+func envelope(flags byte, data []byte) []byte {
+	frame := make([]byte, 5, 5+len(data))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
+
+// readEnvelope reads the single enveloped message of a streaming request
+func readEnvelope(r io.Reader) ([]byte, error) {
+	prefix := make([]byte, 5)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, fmt.Errorf("invalid request envelope: %w", err)
+	}
+	if prefix[0]&flagCompressed != 0 {
+		return nil, fmt.Errorf("compressed messages are not supported")
+	}
+
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxMessageSize {
+		return nil, fmt.Errorf("request message too large")
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("invalid request envelope: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,81 @@
+package connect
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// connectCodes maps gRPC codes to Connect error codes and the HTTP status of unary errors
+var connectCodes = map[codes.Code]struct {
+	name   string
+	status int
+}{
+	codes.Canceled:           {"canceled", 499},
+	codes.Unknown:            {"unknown", http.StatusInternalServerError},
+	codes.InvalidArgument:    {"invalid_argument", http.StatusBadRequest},
+	codes.DeadlineExceeded:   {"deadline_exceeded", http.StatusGatewayTimeout},
+	codes.NotFound:           {"not_found", http.StatusNotFound},
+	codes.AlreadyExists:      {"already_exists", http.StatusConflict},
+	codes.PermissionDenied:   {"permission_denied", http.StatusForbidden},
+	codes.ResourceExhausted:  {"resource_exhausted", http.StatusTooManyRequests},
+	codes.FailedPrecondition: {"failed_precondition", http.StatusBadRequest},
+	codes.Aborted:            {"aborted", http.StatusConflict},
+	codes.OutOfRange:         {"out_of_range", http.StatusBadRequest},
+	codes.Unimplemented:      {"unimplemented", http.StatusNotImplemented},
+	codes.Internal:           {"internal", http.StatusInternalServerError},
+	codes.Unavailable:        {"unavailable", http.StatusServiceUnavailable},
+	codes.DataLoss:           {"data_loss", http.StatusInternalServerError},
+	codes.Unauthenticated:    {"unauthenticated", http.StatusUnauthorized},
+}
+
+// wireError is the JSON representation of an error
+type wireError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// newWireError converts a non-OK status to its JSON representation
+func newWireError(st *status.Status) *wireError {
+	code, ok := connectCodes[st.Code()]
+	if !ok {
+		code = connectCodes[codes.Unknown]
+	}
+	return &wireError{Code: code.name, Message: st.Message()}
+}
+
+// writeError writes the error response of a unary RPC
+func writeError(w http.ResponseWriter, st *status.Status) {
+	code, ok := connectCodes[st.Code()]
+	if !ok {
+		code = connectCodes[codes.Unknown]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code.status)
+	_ = json.NewEncoder(w).Encode(newWireError(st))
+}
+
+// writeEndStream ends a streaming response with the RPC's error, if any, and its trailers
+func writeEndStream(w http.ResponseWriter, st *status.Status, trailers http.Header) {
+	var end struct {
+		Error    *wireError          `json:"error,omitempty"`
+		Metadata map[string][]string `json:"metadata,omitempty"`
+	}
+	if st.Code() != codes.OK {
+		end.Error = newWireError(st)
+	}
+	if len(trailers) > 0 {
+		end.Metadata = make(map[string][]string, len(trailers))
+		for key, values := range trailers {
+			end.Metadata[strings.ToLower(key)] = values
+		}
+	}
+
+	data, _ := json.Marshal(end)
+	_, _ = w.Write(envelope(flagEndStream, data))
+	_ = http.NewResponseController(w).Flush()
+}
@@ -0,0 +1,141 @@
+package connect
+
+import (
+	"encoding/binary"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcResponse receives the gRPC server's response, passing each message to a
+// callback as soon as it is complete and keeping the headers and trailers
+type grpcResponse struct {
+	header      http.Header
+	wroteHeader bool
+	sentHeaders bool
+	buf         []byte
+	onMessage   func([]byte) error
+	err         error
+}
+
+// newGRPCResponse creates a response passing each message to onMessage
+func newGRPCResponse(onMessage func([]byte) error) *grpcResponse {
+	return &grpcResponse{
+		header:    make(http.Header),
+		onMessage: onMessage,
+	}
+}
+
+// Header returns the headers the gRPC server writes, trailers included
+func (g *grpcResponse) Header() http.Header {
+	return g.header
+}
+
+// WriteHeader records that the headers are complete
+func (g *grpcResponse) WriteHeader(int) {
+	g.wroteHeader = true
+}
+
+// Write receives message frames, which may be split across writes
+func (g *grpcResponse) Write(b []byte) (int, error) {
+	g.wroteHeader = true
+	if g.err != nil {
+		return 0, g.err
+	}
+
+	g.buf = append(g.buf, b...)
+	for len(g.buf) >= 5 {
+		size := int(binary.BigEndian.Uint32(g.buf[1:5]))
+		if len(g.buf) < 5+size {
+			break
+		}
+
+		msg := g.buf[5 : 5+size]
+		g.buf = g.buf[5+size:]
+		if err := g.onMessage(msg); err != nil {
+			// Stop the RPC; the client went away or a message could not be encoded
+			g.err = err
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+// Flush does nothing: messages are passed on as soon as they are complete
+func (g *grpcResponse) Flush() {}
+
+// copyHeaders copies the custom response metadata to h
+func (g *grpcResponse) copyHeaders(h http.Header) {
+	for key, values := range g.header {
+		if len(values) == 0 || g.isTrailer(key) || key == "Trailer" || key == "Content-Type" || strings.HasPrefix(key, "Grpc-") {
+			continue
+		}
+		h[key] = values
+	}
+}
+
+// sendHeaders writes the custom response metadata and the status line once, before the first message
+func (g *grpcResponse) sendHeaders(w http.ResponseWriter) {
+	if g.sentHeaders {
+		return
+	}
+	g.sentHeaders = true
+
+	g.copyHeaders(w.Header())
+	w.WriteHeader(http.StatusOK)
+}
+
+// trailers returns the custom trailer metadata, without the gRPC status
+func (g *grpcResponse) trailers() http.Header {
+	trailers := make(http.Header)
+	for key, values := range g.header {
+		if !g.isTrailer(key) {
+			continue
+		}
+		key = strings.TrimPrefix(key, http.TrailerPrefix)
+		if strings.HasPrefix(http.CanonicalHeaderKey(key), "Grpc-") {
+			continue
+		}
+		trailers[key] = values
+	}
+	return trailers
+}
+
+// status returns the RPC's status from the trailers
+func (g *grpcResponse) status() *status.Status {
+	if g.err != nil {
+		return status.New(codes.Internal, g.err.Error())
+	}
+
+	code, err := strconv.Atoi(g.header.Get("Grpc-Status"))
+	if err != nil {
+		return status.New(codes.Internal, "RPC ended without a status")
+	}
+
+	// gRPC percent-encodes messages
+	msg := g.header.Get("Grpc-Message")
+	if decoded, err := url.PathUnescape(msg); err == nil {
+		msg = decoded
+	}
+
+	return status.New(codes.Code(code), msg)
+}
+
+// isTrailer reports whether a header written by the gRPC server is a trailer:
+// either declared in the Trailer header or set with the trailer prefix
+func (g *grpcResponse) isTrailer(key string) bool {
+	if strings.HasPrefix(key, http.TrailerPrefix) {
+		return true
+	}
+	for _, declared := range g.header.Values("Trailer") {
+		if http.CanonicalHeaderKey(declared) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+// Package grpcweb serves a gRPC server to browsers over the gRPC-Web protocol,
+// so single-page apps can call it through the HTTP port without a proxy.
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// gRPC-Web content types; the text variants base64-encode the body
+const (
+	contentTypeGRPCWeb     = "application/grpc-web"
+	contentTypeGRPCWebText = "application/grpc-web-text"
+)
+
+// trailerFrame flags a message frame as holding the trailers
+const trailerFrame byte = 0x80
+
+// Handler translates gRPC-Web requests into gRPC requests for a gRPC server
+type Handler struct {
+	server   *grpc.Server
+	services map[string]bool
+}
+
+// NewHandler creates a handler for the services registered on server. Register
+// every service before calling it.
+func NewHandler(server *grpc.Server) *Handler {
+	services := make(map[string]bool)
+	for name := range server.GetServiceInfo() {
+		services[name] = true
+	}
+
+	return &Handler{
+		server:   server,
+		services: services,
+	}
+}
+
+// Handles reports whether a request, including a CORS preflight, is for one of
+// the gRPC services. Their paths look like "/task.TaskService/GetTask".
+func (h *Handler) Handles(r *http.Request) bool {
+	service, _, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !ok || !h.services[service] {
+		return false
+	}
+
+	return r.Method == http.MethodOptions || strings.HasPrefix(r.Header.Get("Content-Type"), contentTypeGRPCWeb)
+}
+
+// ServeHTTP serves a gRPC-Web request
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if r.Method != http.MethodPost || !strings.HasPrefix(contentType, contentTypeGRPCWeb) {
+		http.Error(w, "Unsupported gRPC-Web request", http.StatusUnsupportedMediaType)
+		return
+	}
+	text := strings.HasPrefix(contentType, contentTypeGRPCWebText)
+
+	// Streams end when the RPC does; gRPC deadlines bound them instead of the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	// Present the request to the gRPC server as a native gRPC request
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor = 2, 0
+	req.ContentLength = -1
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", grpcContentType(contentType))
+	if text {
+		req.Body = io.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	}
+
+	rw := newResponseWriter(w, contentType, text)
+	h.server.ServeHTTP(rw, req)
+	rw.finish()
+}
+
+// grpcContentType maps a gRPC-Web content type, such as
+// "application/grpc-web-text+proto", to its gRPC counterpart
+func grpcContentType(contentType string) string {
+	subtype := strings.TrimPrefix(strings.TrimPrefix(contentType, contentTypeGRPCWebText), contentTypeGRPCWeb)
+	return "application/grpc" + subtype
+}
+
+// responseWriter turns the gRPC server's HTTP/2 response into a gRPC-Web response,
+// sending the trailers as the body's last frame since browsers cannot read HTTP trailers
+type responseWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	contentType string
+	text        bool
+	wroteHeader bool
+}
+
+// newResponseWriter creates a response writer answering with the request's content type
+func newResponseWriter(w http.ResponseWriter, contentType string, text bool) *responseWriter {
+	return &responseWriter{
+		w:           w,
+		header:      make(http.Header),
+		contentType: contentType,
+		text:        text,
+	}
+}
+
+// Header returns the headers the gRPC server writes, trailers included
+func (rw *responseWriter) Header() http.Header {
+	return rw.header
+}
+
+// WriteHeader sends the headers, leaving the trailers for the last frame
+func (rw *responseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+
+	for key, values := range rw.header {
+		if len(values) == 0 || isTrailer(rw.header, key) || key == "Trailer" {
+			continue
+		}
+		rw.w.Header()[key] = values
+	}
+	rw.w.Header().Set("Content-Type", rw.contentType)
+	rw.w.Header().Del("Content-Length")
+	rw.w.WriteHeader(code)
+}
+
+// Write sends message frames
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+
+	if rw.text {
+		if _, err := io.WriteString(rw.w, base64.StdEncoding.EncodeToString(b)); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	return rw.w.Write(b)
+}
+
+// Flush sends buffered frames to the client, as streaming RPCs expect
+func (rw *responseWriter) Flush() {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	_ = http.NewResponseController(rw.w).Flush()
+}
+
+// finish sends the trailers as the last frame. Errors returned before any message
+// was sent have their status sent as headers as well, for clients that only read those.
+func (rw *responseWriter) finish() {
+	trailers := make(http.Header)
+	for key, values := range rw.header {
+		if isTrailer(rw.header, key) {
+			trailers[strings.TrimPrefix(key, http.TrailerPrefix)] = values
+		}
+	}
+
+	if !rw.wroteHeader {
+		for key, values := range trailers {
+			rw.header[key] = values
+		}
+		rw.WriteHeader(http.StatusOK)
+	}
+
+	var block bytes.Buffer
+	for key, values := range trailers {
+		for _, value := range values {
+			block.WriteString(strings.ToLower(key) + ": " + value + "\r\n")
+		}
+	}
+
+	frame := make([]byte, 5, 5+block.Len())
+	frame[0] = trailerFrame
+	binary.BigEndian.PutUint32(frame[1:], uint32(block.Len()))
+	frame = append(frame, block.Bytes()...)
+
+	_, _ = rw.Write(frame)
+	rw.Flush()
+}
+
+// isTrailer reports whether a header written by the gRPC server is a trailer:
+// either declared in the Trailer header or set with the trailer prefix
+func isTrailer(header http.Header, key string) bool {
+	if strings.HasPrefix(key, http.TrailerPrefix) {
+		return true
+	}
+	for _, declared := range header.Values("Trailer") {
+		if http.CanonicalHeaderKey(declared) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"task-management-system/internal/logger"
+)
+
+// RequestIDHeader is the metadata key carrying a request's ID. Clients may set it
+// to correlate their own logs; otherwise the server generates one.
+const RequestIDHeader = "x-request-id"
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID of the request being served, or "" outside an RPC
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Chain returns the server options installing the standard interceptors for unary
+// and streaming RPCs, outermost first: request IDs, logging, metrics and panic
+// recovery. Recovery runs innermost so that a recovered panic is logged and counted as Internal.
+func Chain(log logger.Logger, metrics *Metrics) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			RequestID,
+			Logger(log),
+			metrics.Interceptor,
+			Recover(log),
+		),
+		grpc.ChainStreamInterceptor(
+			StreamRequestID,
+			StreamLogger(log),
+			metrics.StreamInterceptor,
+			StreamRecover(log),
+		),
+	}
+}
+
+// serverStream overrides the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the overridden context
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestID is an interceptor that assigns every request an ID and returns it in the
+// response header
+func RequestID(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := incomingRequestID(ctx)
+
+	// Failing to send the header must not fail the request
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+
+	return handler(context.WithValue(ctx, requestIDKey{}, id), req)
+}
+
+// StreamRequestID is the streaming counterpart of RequestID
+func StreamRequestID(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+	id := incomingRequestID(ctx)
+
+	// Failing to send the header must not fail the request
+	_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, id))
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: context.WithValue(ctx, requestIDKey{}, id)})
+}
+
+// incomingRequestID returns the request ID sent by the client, or a new one
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 && values[0] != "" && len(values[0]) <= 128 {
+			return values[0]
+		}
+	}
+	return newRequestID()
+}
+
+// newRequestID generates a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// Logger returns an interceptor that logs every request to the given logger with
+// its status code and latency
+func Logger(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		logRequest(ctx, log, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamLogger is the streaming counterpart of Logger; latency covers the whole stream
+func StreamLogger(log logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+		logRequest(ss.Context(), log, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// logRequest logs a finished request; server-side failures are logged as errors
+func logRequest(ctx context.Context, log logger.Logger, method string, start time.Time, err error) {
+	fields := map[string]interface{}{
+		"request_id": RequestIDFromContext(ctx),
+		"method":     method,
+		"code":       status.Code(err).String(),
+		"duration":   time.Since(start),
+	}
+	if code := status.Code(err); code == codes.Internal || code == codes.Unknown {
+		log.Error("[gRPC] request failed", fields)
+	} else {
+		log.Info("[gRPC] request", fields)
+	}
+}
+
+// Recover returns an interceptor that recovers from panics in handlers, logging
+// them to the given logger, and fails the request with Internal instead of
+// crashing the server
+func Recover(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				resp, err = nil, recovered(ctx, log, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecover is the streaming counterpart of Recover
+func StreamRecover(log logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), log, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs a recovered panic with its stack and returns the error to fail the request with
+func recovered(ctx context.Context, log logger.Logger, method string, r interface{}) error {
+	log.Error("Panic recovered", map[string]interface{}{
+		"request_id": RequestIDFromContext(ctx),
+		"method":     method,
+		"panic":      r,
+		"stack":      string(debug.Stack()),
+	})
+
+	return status.Error(codes.Internal, "internal server error")
+}
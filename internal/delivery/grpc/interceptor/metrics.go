@@ -0,0 +1,55 @@
+package interceptor
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics counts gRPC requests by method and status code and sums their latency.
+// The counters are published as expvar variables, served by the admin metrics route.
+type Metrics struct {
+	requests *expvar.Map
+	latency  *expvar.Map
+}
+
+// metrics is shared: expvar variables can only be published once per process
+var metrics = &Metrics{
+	requests: expvar.NewMap("grpc_requests_total"),
+	latency:  expvar.NewMap("grpc_request_duration_ms_total"),
+}
+
+// DefaultMetrics returns the process-wide gRPC metrics
+func DefaultMetrics() *Metrics {
+	return metrics
+}
+
+// Interceptor is an interceptor that records every request's status code and latency
+func (m *Metrics) Interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+	m.record(info.FullMethod, start, err)
+
+	return resp, err
+}
+
+// StreamInterceptor is the streaming counterpart of Interceptor; latency covers the whole stream
+func (m *Metrics) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+	m.record(info.FullMethod, start, err)
+
+	return err
+}
+
+// record counts a finished request
+func (m *Metrics) record(method string, start time.Time, err error) {
+	// Keys look like "/task.TaskService/GetTask OK"
+	m.requests.Add(method+" "+status.Code(err).String(), 1)
+	m.latency.AddFloat(method, float64(time.Since(start))/float64(time.Millisecond))
+}
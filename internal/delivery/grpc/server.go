@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"task-management-system/config"
+	"task-management-system/internal/delivery/grpc/interceptor"
 	"task-management-system/internal/delivery/grpc/service"
 	"task-management-system/internal/logger"
 	"task-management-system/internal/usecase"
@@ -19,11 +20,13 @@ type Server struct {
 	server   *grpc.Server
 	listener net.Listener
 	cfg      *config.Config
+	log      logger.Logger
 }
 
-// NewServer creates a new gRPC server
+// NewServer creates a new gRPC server logging to the given logger
 func NewServer(
 	cfg *config.Config,
+	log logger.Logger,
 	taskUseCase *usecase.TaskUseCase,
 	userUseCase *usecase.UserUseCase,
 	authUseCase *usecase.AuthUseCase,
@@ -35,51 +38,72 @@ func NewServer(
 		return nil, err
 	}
 
-	return NewServerWithListener(cfg, listener, taskUseCase, userUseCase, authUseCase)
+	return NewServerWithListener(cfg, log, listener, taskUseCase, userUseCase, authUseCase)
 }
 
 // NewServerWithListener creates a new gRPC server with a provided listener (for testing)
 func NewServerWithListener(
 	cfg *config.Config,
+	log logger.Logger,
 	listener net.Listener,
 	taskUseCase *usecase.TaskUseCase,
 	userUseCase *usecase.UserUseCase,
 	authUseCase *usecase.AuthUseCase,
 ) (*Server, error) {
 
+	server := NewServices(log, taskUseCase, userUseCase, authUseCase)
+
+	// Register reflection service for gRPC tools; it exposes the whole API, so it is opt-in
+	if cfg.Server.GRPC.Reflection {
+		reflection.Register(server)
+		log.InfoF("gRPC reflection enabled")
+	}
+
+	return &Server{
+		server:   server,
+		listener: listener,
+		cfg:      cfg,
+		log:      log,
+	}, nil
+}
+
+// NewServices creates a gRPC server with the task and user services registered but
+// not listening; NewServer listens with it, and the HTTP server can serve it over gRPC-Web.
+// Requests, recovered panics and failed calls are logged to the given logger.
+func NewServices(
+	log logger.Logger,
+	taskUseCase *usecase.TaskUseCase,
+	userUseCase *usecase.UserUseCase,
+	authUseCase *usecase.AuthUseCase,
+) *grpc.Server {
 	// Create gRPC server
-	server := grpc.NewServer(
-		grpc.ConnectionTimeout(5*time.Second),
-		grpc.MaxRecvMsgSize(4*1024*1024), // 4MB
-		grpc.MaxSendMsgSize(4*1024*1024), // 4MB
-	)
+	opts := []grpc.ServerOption{
+		grpc.ConnectionTimeout(5 * time.Second),
+		grpc.MaxRecvMsgSize(4 * 1024 * 1024), // 4MB
+		grpc.MaxSendMsgSize(4 * 1024 * 1024), // 4MB
+	}
+	opts = append(opts, interceptor.Chain(log, interceptor.DefaultMetrics())...)
+	server := grpc.NewServer(opts...)
 
 	// Create and register task service
-	taskService := service.NewTaskService(taskUseCase, authUseCase)
+	taskService := service.NewTaskService(taskUseCase, authUseCase, log)
 	taskService.Register(server)
 
 	// Create and register user service
-	userService := service.NewUserService(userUseCase, authUseCase)
+	userService := service.NewUserService(userUseCase, authUseCase, log)
 	userService.Register(server)
 
-	// Register reflection service for gRPC tools
-	reflection.Register(server)
-
-	return &Server{
-		server:   server,
-		listener: listener,
-		cfg:      cfg,
-	}, nil
+	return server
 }
 
 // Start starts the gRPC server
 func (s *Server) Start() error {
-	logger.InfoF("Starting gRPC server on port %d", s.cfg.Server.GRPC.Port)
+	s.log.InfoF("Starting gRPC server on port %d", s.cfg.Server.GRPC.Port)
 	return s.server.Serve(s.listener)
 }
 
 // Stop stops the gRPC server
 func (s *Server) Stop() {
-	logger.InfoF("Stopping gRPC server")
+	s.log.InfoF("Stopping gRPC server")
 	s.server.GracefulStop()
 }
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"net"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"task-management-system/internal/usecase"
+)
+
+// clientInfo describes the client calling an RPC, for sessions and the login history
+func clientInfo(ctx context.Context) usecase.ClientInfo {
+	var client usecase.ClientInfo
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			ip = p.Addr.String()
+		}
+		client.IP = ip
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			client.UserAgent = values[0]
+		}
+	}
+
+	return client
+}
+
+// callerInfo describes the authenticated client calling an RPC, including the
+// admin acting through an impersonation token, for the audit log
+func callerInfo(ctx context.Context, claims *usecase.Claims) usecase.ClientInfo {
+	client := clientInfo(ctx)
+	if claims.Actor != nil {
+		client.ImpersonatorID, _ = primitive.ObjectIDFromHex(claims.Actor.UserID)
+	}
+	return client
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"task-management-system/internal/domain"
+)
+
+// invalidArgument converts an invalid input error to an InvalidArgument status.
+// The invalid fields of a domain.ValidationError are attached as BadRequest details,
+// and both statuses of a domain.StatusTransitionError as ErrorInfo details.
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	var transition *domain.StatusTransitionError
+	if errors.As(err, &transition) {
+		details := &errdetails.ErrorInfo{
+			Reason: domain.ReasonInvalidStatusTransition,
+			Domain: "tasks",
+			Metadata: map[string]string{
+				"current_status":   string(transition.Current),
+				"requested_status": string(transition.Requested),
+			},
+		}
+		if withDetails, err := st.WithDetails(details); err == nil {
+			return withDetails.Err()
+		}
+		return st.Err()
+	}
+
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) {
+		return st.Err()
+	}
+
+	details := &errdetails.BadRequest{}
+	for _, field := range validation.Fields {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+	}
+	if withDetails, err := st.WithDetails(details); err == nil {
+		return withDetails.Err()
+	}
+	return st.Err()
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
@@ -23,13 +24,15 @@ type TaskService struct {
 	proto.UnimplementedTaskServiceServer
 	taskUseCase *usecase.TaskUseCase
 	authUseCase *usecase.AuthUseCase
+	log         logger.Logger
 }
 
-// NewTaskService creates a new TaskService
-func NewTaskService(taskUseCase *usecase.TaskUseCase, authUseCase *usecase.AuthUseCase) *TaskService {
+// NewTaskService creates a new TaskService logging failures to the given logger
+func NewTaskService(taskUseCase *usecase.TaskUseCase, authUseCase *usecase.AuthUseCase, log logger.Logger) *TaskService {
 	return &TaskService{
 		taskUseCase: taskUseCase,
 		authUseCase: authUseCase,
+		log:         log,
 	}
 }
 
@@ -38,26 +41,46 @@ func (s *TaskService) Register(server *grpc.Server) {
 	proto.RegisterTaskServiceServer(server, s)
 }
 
-// getUserIDFromContext extracts user ID from context metadata
-func (s *TaskService) getUserIDFromContext(ctx context.Context) (string, error) {
+// getClaimsFromContext validates the token in the authorization metadata, checks it
+// grants the scope the method requires and returns its claims
+func getClaimsFromContext(ctx context.Context, authUseCase *usecase.AuthUseCase, log logger.Logger, scope domain.Scope) (*usecase.Claims, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return "", status.Error(codes.Unauthenticated, "metadata is not provided")
+		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
 	}
 
 	values := md.Get("authorization")
 	if len(values) == 0 {
-		return "", status.Error(codes.Unauthenticated, "authorization token is not provided")
+		return nil, status.Error(codes.Unauthenticated, "authorization token is not provided")
 	}
 
-	token := values[0]
-	userID, err := s.authUseCase.ValidateToken(token)
+	token := strings.TrimPrefix(values[0], "Bearer ")
+	claims, err := authUseCase.ParseToken(token)
 	if err != nil {
-		logger.ErrorF("Token validation error: %v", err)
-		return "", status.Error(codes.Unauthenticated, "invalid token")
+		log.ErrorF("Token validation error: %v", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 
-	return userID, nil
+	// Tokens issued before organizations existed carry no org and must be renewed
+	if claims.OrgID == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	if !claims.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "token lacks the required scope %s", scope)
+	}
+
+	return claims, nil
+}
+
+// actingUser returns the token's user as the user acting in a request. Request
+// fields naming the acting user are kept for older clients; one naming someone
+// else is refused rather than trusted.
+func actingUser(claims *usecase.Claims, requested string) (string, error) {
+	if requested != "" && requested != claims.UserID {
+		return "", status.Error(codes.PermissionDenied, "the acting user must be the token's user")
+	}
+	return claims.UserID, nil
 }
 
 // CreateTask implements the CreateTask RPC method
@@ -77,17 +100,39 @@ func (s *TaskService) CreateTask(ctx context.Context, req *proto.CreateTaskReque
 		dueDate = req.DueDate.AsTime()
 	}
 
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	createdBy, err := actingUser(claims, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create task
 	task, err := s.taskUseCase.CreateTask(&usecase.CreateTaskInput{
 		Title:       req.Title,
 		Description: req.Description,
 		Priority:    int(req.Priority),
 		DueDate:     dueDate,
-		CreatedBy:   req.CreatedBy,
+		CreatedBy:   createdBy,
+		OrgID:       claims.OrgID,
+		ProjectID:   req.ProjectId,
+		Client:      callerInfo(ctx, claims),
 	})
 
 	if err != nil {
-		logger.ErrorF("Failed to create task: %v", err)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "project not found")
+		}
+		if errors.Is(err, domain.ErrUnauthorized) {
+			return nil, status.Error(codes.PermissionDenied, "unauthorized to add tasks to this project")
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, invalidArgument(err)
+		}
+		s.log.ErrorF("Failed to create task: %v", err)
 		return nil, status.Error(codes.Internal, "failed to create task")
 	}
 
@@ -102,13 +147,19 @@ func (s *TaskService) GetTask(ctx context.Context, req *proto.GetTaskRequest) (*
 		return nil, status.Error(codes.InvalidArgument, "task id is required")
 	}
 
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get task
-	task, err := s.taskUseCase.GetTaskByID(req.Id)
+	task, err := s.taskUseCase.GetTaskByID(claims.OrgID, req.Id, claims.UserID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, status.Error(codes.NotFound, "task not found")
 		}
-		logger.ErrorF("Failed to get task: %v", err)
+		s.log.ErrorF("Failed to get task: %v", err)
 		return nil, status.Error(codes.Internal, "failed to get task")
 	}
 
@@ -140,6 +191,16 @@ func (s *TaskService) UpdateTask(ctx context.Context, req *proto.UpdateTaskReque
 		taskStatus = domain.TaskStatusCompleted
 	}
 
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	updatedBy, err := actingUser(claims, req.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update task
 	task, err := s.taskUseCase.UpdateTask(&usecase.UpdateTaskInput{
 		ID:          req.Id,
@@ -148,7 +209,10 @@ func (s *TaskService) UpdateTask(ctx context.Context, req *proto.UpdateTaskReque
 		Status:      taskStatus,
 		Priority:    int(req.Priority),
 		DueDate:     dueDate,
-		UpdatedBy:   req.UpdatedBy,
+		UpdatedBy:   updatedBy,
+		OrgID:       claims.OrgID,
+		Fields:      req.GetUpdateMask().GetPaths(),
+		Client:      callerInfo(ctx, claims),
 	})
 
 	if err != nil {
@@ -158,7 +222,10 @@ func (s *TaskService) UpdateTask(ctx context.Context, req *proto.UpdateTaskReque
 		if errors.Is(err, domain.ErrUnauthorized) {
 			return nil, status.Error(codes.PermissionDenied, "unauthorized to update this task")
 		}
-		logger.ErrorF("Failed to update task: %v", err)
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, invalidArgument(err)
+		}
+		s.log.ErrorF("Failed to update task: %v", err)
 		return nil, status.Error(codes.Internal, "failed to update task")
 	}
 
@@ -173,8 +240,18 @@ func (s *TaskService) DeleteTask(ctx context.Context, req *proto.DeleteTaskReque
 		return nil, status.Error(codes.InvalidArgument, "task id is required")
 	}
 
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := actingUser(claims, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
 	// Delete task
-	err := s.taskUseCase.DeleteTask(req.Id, req.UserId)
+	err = s.taskUseCase.DeleteTask(claims.OrgID, req.Id, userID, callerInfo(ctx, claims))
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, status.Error(codes.NotFound, "task not found")
@@ -182,45 +259,152 @@ func (s *TaskService) DeleteTask(ctx context.Context, req *proto.DeleteTaskReque
 		if errors.Is(err, domain.ErrUnauthorized) {
 			return nil, status.Error(codes.PermissionDenied, "unauthorized to delete this task")
 		}
-		logger.ErrorF("Failed to delete task: %v", err)
+		s.log.ErrorF("Failed to delete task: %v", err)
 		return nil, status.Error(codes.Internal, "failed to delete task")
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// BatchCreateTasks implements the BatchCreateTasks RPC method
+func (s *TaskService) BatchCreateTasks(ctx context.Context, req *proto.BatchCreateTasksRequest) (*proto.BatchTasksResponse, error) {
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*usecase.CreateTaskInput, 0, len(req.Tasks))
+	for _, task := range req.Tasks {
+		var dueDate time.Time
+		if task.DueDate != nil {
+			dueDate = task.DueDate.AsTime()
+		}
+
+		createdBy, err := actingUser(claims, task.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, &usecase.CreateTaskInput{
+			Title:       task.Title,
+			Description: task.Description,
+			Priority:    int(task.Priority),
+			DueDate:     dueDate,
+			CreatedBy:   createdBy,
+			ProjectID:   task.ProjectId,
+		})
+	}
+
+	// Create tasks
+	results, err := s.taskUseCase.BatchCreateTasks(claims.OrgID, inputs)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.log.ErrorF("Failed to create tasks: %v", err)
+		return nil, status.Error(codes.Internal, "failed to create tasks")
+	}
+
+	// Convert to response
+	return s.batchResultsToProto(results), nil
+}
+
+// BatchDeleteTasks implements the BatchDeleteTasks RPC method
+func (s *TaskService) BatchDeleteTasks(ctx context.Context, req *proto.BatchDeleteTasksRequest) (*proto.BatchTasksResponse, error) {
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := actingUser(claims, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete tasks
+	results, err := s.taskUseCase.BatchDeleteTasks(claims.OrgID, req.Ids, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrUnauthorized):
+			return nil, status.Error(codes.PermissionDenied, "unauthorized to delete tasks")
+		}
+		s.log.ErrorF("Failed to delete tasks: %v", err)
+		return nil, status.Error(codes.Internal, "failed to delete tasks")
+	}
+
+	// Convert to response
+	return s.batchResultsToProto(results), nil
+}
+
+// batchResultsToProto converts the results of a batch operation to their protobuf representation
+func (s *TaskService) batchResultsToProto(results []*usecase.TaskBatchResult) *proto.BatchTasksResponse {
+	resp := &proto.BatchTasksResponse{
+		Results: make([]*proto.BatchTaskResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		item := &proto.BatchTaskResult{}
+		if result.Task != nil {
+			item.Task = s.domainTaskToProto(result.Task)
+		}
+
+		switch {
+		case result.Err == nil:
+		case errors.Is(result.Err, domain.ErrNotFound):
+			item.Code, item.Error = int32(codes.NotFound), "not found"
+		case errors.Is(result.Err, domain.ErrUnauthorized):
+			item.Code, item.Error = int32(codes.PermissionDenied), "unauthorized"
+		case errors.Is(result.Err, domain.ErrDuplicateKey):
+			item.Code, item.Error = int32(codes.AlreadyExists), "already exists"
+		case errors.Is(result.Err, domain.ErrInternalServer):
+			// The store rejected the item; other storage failures fail the whole batch
+			s.log.ErrorF("Failed to store task of batch: %v", result.Err)
+			item.Code, item.Error = int32(codes.Internal), "failed to store task"
+		default:
+			item.Code, item.Error = int32(codes.InvalidArgument), result.Err.Error()
+		}
+
+		resp.Results = append(resp.Results, item)
+	}
+
+	return resp
+}
+
 // ListTasks implements the ListTasks RPC method
 func (s *TaskService) ListTasks(ctx context.Context, req *proto.ListTasksRequest) (*proto.ListTasksResponse, error) {
-	// Map proto status to domain status
-	var taskStatus domain.TaskStatus
-	switch req.Status {
-	case proto.TaskStatus_TASK_STATUS_PENDING:
-		taskStatus = domain.TaskStatusPending
-	case proto.TaskStatus_TASK_STATUS_IN_PROGRESS:
-		taskStatus = domain.TaskStatusInProgress
-	case proto.TaskStatus_TASK_STATUS_COMPLETED:
-		taskStatus = domain.TaskStatusCompleted
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get tasks
-	var tasks []*domain.Task
-	var err error
-	if req.Status == proto.TaskStatus_TASK_STATUS_UNSPECIFIED {
-		tasks, err = s.taskUseCase.ListTasks(nil)
-	} else {
-		tasks, err = s.taskUseCase.ListTasks(&usecase.ListTasksInput{
-			Status: taskStatus,
-		})
+	// Get a page of tasks
+	input := listTasksInput(claims, req)
+	tasks, page, err := s.taskUseCase.ListTasks(input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.log.ErrorF("Failed to list tasks: %v", err)
+		return nil, status.Error(codes.Internal, "failed to list tasks")
 	}
 
+	// A page holds only some of the matching tasks
+	total, err := s.taskUseCase.CountTasks(input)
 	if err != nil {
-		logger.ErrorF("Failed to list tasks: %v", err)
+		s.log.ErrorF("Failed to count tasks: %v", err)
 		return nil, status.Error(codes.Internal, "failed to list tasks")
 	}
 
 	// Convert to response
 	resp := &proto.ListTasksResponse{
-		Tasks: make([]*proto.TaskResponse, 0, len(tasks)),
+		Tasks:           make([]*proto.TaskResponse, 0, len(tasks)),
+		TotalCount:      total,
+		PageSize:        int32(page.Size),
+		PageSizeClamped: page.Clamped,
 	}
 
 	for _, task := range tasks {
@@ -230,31 +414,127 @@ func (s *TaskService) ListTasks(ctx context.Context, req *proto.ListTasksRequest
 	return resp, nil
 }
 
+// ExportTasks implements the ExportTasks RPC method
+func (s *TaskService) ExportTasks(req *proto.ExportTasksRequest, stream proto.TaskService_ExportTasksServer) error {
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(stream.Context(), s.authUseCase, s.log, domain.ScopeTasksRead)
+	if err != nil {
+		return err
+	}
+
+	input := listTasksInput(claims, &proto.ListTasksRequest{
+		Status:    req.Status,
+		ProjectId: req.ProjectId,
+	})
+
+	// Stream tasks chunk by chunk
+	var sendErr error
+	err = s.taskUseCase.ExportTasks(input, int64(req.ChunkSize), func(tasks []*domain.Task) error {
+		chunk := &proto.ExportTasksResponse{
+			Tasks: make([]*proto.TaskResponse, 0, len(tasks)),
+		}
+		for _, task := range tasks {
+			chunk.Tasks = append(chunk.Tasks, s.domainTaskToProto(task))
+		}
+
+		sendErr = stream.Send(chunk)
+		return sendErr
+	})
+	if err != nil {
+		switch {
+		case sendErr != nil:
+			// The client went away; its status is already decided
+			return sendErr
+		case errors.Is(err, domain.ErrInvalidInput):
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.log.ErrorF("Failed to export tasks: %v", err)
+		return status.Error(codes.Internal, "failed to export tasks")
+	}
+
+	return nil
+}
+
+// CountTasks implements the CountTasks RPC method
+func (s *TaskService) CountTasks(ctx context.Context, req *proto.ListTasksRequest) (*proto.CountTasksResponse, error) {
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.taskUseCase.CountTasks(listTasksInput(claims, req))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.log.ErrorF("Failed to count tasks: %v", err)
+		return nil, status.Error(codes.Internal, "failed to count tasks")
+	}
+
+	return &proto.CountTasksResponse{Count: count}, nil
+}
+
+// listTasksInput maps a list request's filters to the usecase input for the caller
+func listTasksInput(claims *usecase.Claims, req *proto.ListTasksRequest) *usecase.ListTasksInput {
+	input := &usecase.ListTasksInput{
+		OrgID:     claims.OrgID,
+		UserID:    claims.UserID,
+		ProjectID: req.ProjectId,
+		Limit:     int64(req.Limit),
+		After:     req.After,
+	}
+
+	// Map proto status to domain status
+	switch req.Status {
+	case proto.TaskStatus_TASK_STATUS_PENDING:
+		input.Status = domain.TaskStatusPending
+	case proto.TaskStatus_TASK_STATUS_IN_PROGRESS:
+		input.Status = domain.TaskStatusInProgress
+	case proto.TaskStatus_TASK_STATUS_COMPLETED:
+		input.Status = domain.TaskStatusCompleted
+	}
+
+	return input
+}
+
 // AssignTask implements the AssignTask RPC method
 func (s *TaskService) AssignTask(ctx context.Context, req *proto.AssignTaskRequest) (*proto.TaskResponse, error) {
 	// Validate request
 	if req.TaskId == "" {
 		return nil, status.Error(codes.InvalidArgument, "task id is required")
 	}
-	if req.AssigneeId == "" {
-		return nil, status.Error(codes.InvalidArgument, "assignee id is required")
+	if req.AssigneeId == "" && req.Assignee == "" {
+		return nil, status.Error(codes.InvalidArgument, "assignee id, username, or email is required")
+	}
+
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	assignedBy, err := actingUser(claims, req.AssignedBy)
+	if err != nil {
+		return nil, err
 	}
 
 	// Assign task
 	task, err := s.taskUseCase.AssignTask(&usecase.AssignTaskInput{
 		TaskID:     req.TaskId,
-		AssigneeID: req.AssigneeId,
-		AssignedBy: req.AssignedBy,
+		Assignee:   assigneeRef(req.AssigneeId, req.Assignee),
+		AssignedBy: assignedBy,
+		OrgID:      claims.OrgID,
+		Client:     callerInfo(ctx, claims),
 	})
 
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			return nil, status.Error(codes.NotFound, "task or user not found")
+			return nil, status.Error(codes.NotFound, err.Error())
 		}
 		if errors.Is(err, domain.ErrUnauthorized) {
 			return nil, status.Error(codes.PermissionDenied, "unauthorized to assign this task")
 		}
-		logger.ErrorF("Failed to assign task: %v", err)
+		s.log.ErrorF("Failed to assign task: %v", err)
 		return nil, status.Error(codes.Internal, "failed to assign task")
 	}
 
@@ -262,6 +542,50 @@ func (s *TaskService) AssignTask(ctx context.Context, req *proto.AssignTaskReque
 	return s.domainTaskToProto(task), nil
 }
 
+// UnassignTask implements the UnassignTask RPC method
+func (s *TaskService) UnassignTask(ctx context.Context, req *proto.UnassignTaskRequest) (*proto.TaskResponse, error) {
+	// Validate request
+	if req.TaskId == "" {
+		return nil, status.Error(codes.InvalidArgument, "task id is required")
+	}
+	if req.AssigneeId == "" && req.Assignee == "" {
+		return nil, status.Error(codes.InvalidArgument, "assignee id, username, or email is required")
+	}
+
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	unassignedBy, err := actingUser(claims, req.UnassignedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unassign task
+	task, err := s.taskUseCase.UnassignTask(&usecase.UnassignTaskInput{
+		TaskID:       req.TaskId,
+		Assignee:     assigneeRef(req.AssigneeId, req.Assignee),
+		UnassignedBy: unassignedBy,
+		OrgID:        claims.OrgID,
+		Client:       callerInfo(ctx, claims),
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrUnauthorized) {
+			return nil, status.Error(codes.PermissionDenied, "unauthorized to unassign this user")
+		}
+		s.log.ErrorF("Failed to unassign task: %v", err)
+		return nil, status.Error(codes.Internal, "failed to unassign task")
+	}
+
+	// Convert to response
+	return s.domainTaskToProto(task), nil
+}
+
 // GetUserTasks implements the GetUserTasks RPC method
 func (s *TaskService) GetUserTasks(ctx context.Context, req *proto.GetUserTasksRequest) (*proto.ListTasksResponse, error) {
 	// Validate request
@@ -269,16 +593,23 @@ func (s *TaskService) GetUserTasks(ctx context.Context, req *proto.GetUserTasksR
 		return nil, status.Error(codes.InvalidArgument, "user id is required")
 	}
 
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get user tasks
-	tasks, err := s.taskUseCase.GetUserTasks(req.UserId)
+	tasks, err := s.taskUseCase.GetUserTasks(claims.OrgID, req.UserId, claims.UserID)
 	if err != nil {
-		logger.ErrorF("Failed to get user tasks: %v", err)
+		s.log.ErrorF("Failed to get user tasks: %v", err)
 		return nil, status.Error(codes.Internal, "failed to get user tasks")
 	}
 
 	// Convert to response
 	resp := &proto.ListTasksResponse{
-		Tasks: make([]*proto.TaskResponse, 0, len(tasks)),
+		Tasks:      make([]*proto.TaskResponse, 0, len(tasks)),
+		TotalCount: int64(len(tasks)),
 	}
 
 	for _, task := range tasks {
@@ -288,6 +619,14 @@ func (s *TaskService) GetUserTasks(ctx context.Context, req *proto.GetUserTasksR
 	return resp, nil
 }
 
+// assigneeRef returns the assignee reference, preferring the explicit ID over username/email
+func assigneeRef(assigneeID, assignee string) string {
+	if assigneeID != "" {
+		return assigneeID
+	}
+	return assignee
+}
+
 // domainTaskToProto converts a domain task to proto task
 func (s *TaskService) domainTaskToProto(task *domain.Task) *proto.TaskResponse {
 	// Map domain status to proto status
@@ -315,15 +654,38 @@ func (s *TaskService) domainTaskToProto(task *domain.Task) *proto.TaskResponse {
 		UpdatedAt:   timestamppb.New(task.UpdatedAt),
 	}
 
+	// Add project and task key if set
+	if !task.ProjectID.IsZero() {
+		protoTask.ProjectId = task.ProjectID.Hex()
+		protoTask.Key = task.Key
+		protoTask.Number = task.Number
+	}
+
 	// Add due date if set
 	if !task.DueDate.IsZero() {
 		protoTask.DueDate = timestamppb.New(task.DueDate)
 	}
 
-	// Add assigned to if set
-	if !task.AssignedTo.IsZero() {
-		protoTask.AssignedTo = task.AssignedTo.Hex()
+	// Add assignees if set
+	for _, assigneeID := range task.AssignedTo {
+		protoTask.AssignedTo = append(protoTask.AssignedTo, assigneeID.Hex())
+	}
+
+	// Add resolved user references if the task was enriched
+	if task.Creator != nil {
+		protoTask.Creator = domainUserRefToProto(task.Creator)
+	}
+	for _, assignee := range task.Assignees {
+		protoTask.Assignees = append(protoTask.Assignees, domainUserRefToProto(assignee))
 	}
 
 	return protoTask
 }
+
+// domainUserRefToProto converts a domain user reference to proto
+func domainUserRefToProto(ref *domain.UserRef) *proto.UserRef {
+	return &proto.UserRef{
+		Id:       ref.ID.Hex(),
+		Username: ref.Username,
+	}
+}
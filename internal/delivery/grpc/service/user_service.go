@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"task-management-system/api/proto"
@@ -20,13 +22,15 @@ type UserService struct {
 	proto.UnimplementedUserServiceServer
 	userUseCase *usecase.UserUseCase
 	authUseCase *usecase.AuthUseCase
+	log         logger.Logger
 }
 
-// NewUserService creates a new UserService
-func NewUserService(userUseCase *usecase.UserUseCase, authUseCase *usecase.AuthUseCase) *UserService {
+// NewUserService creates a new UserService logging failures to the given logger
+func NewUserService(userUseCase *usecase.UserUseCase, authUseCase *usecase.AuthUseCase, log logger.Logger) *UserService {
 	return &UserService{
 		userUseCase: userUseCase,
 		authUseCase: authUseCase,
+		log:         log,
 	}
 }
 
@@ -35,6 +39,80 @@ func (s *UserService) Register(server *grpc.Server) {
 	proto.RegisterUserServiceServer(server, s)
 }
 
+// RegisterUser implements the RegisterUser RPC method. The new user is signed in
+// straight away so that gRPC clients need not bootstrap credentials over HTTP.
+func (s *UserService) RegisterUser(ctx context.Context, req *proto.RegisterUserRequest) (*proto.AuthResponse, error) {
+	// Validate request
+	if req.Username == "" || req.Email == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "username, email and password are required")
+	}
+
+	// Register user
+	_, err := s.userUseCase.RegisterUser(&usecase.RegisterUserInput{
+		Username:         req.Username,
+		Email:            req.Email,
+		Password:         req.Password,
+		FirstName:        req.FirstName,
+		LastName:         req.LastName,
+		OrganizationName: req.Organization,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrDuplicateKey):
+			return nil, status.Error(codes.AlreadyExists, "username or email already registered")
+		}
+		s.log.ErrorF("Failed to register user: %v", err)
+		return nil, status.Error(codes.Internal, "failed to register user")
+	}
+
+	// Sign the new user in
+	return s.login(ctx, req.Username, req.Password, nil)
+}
+
+// Login implements the Login RPC method
+func (s *UserService) Login(ctx context.Context, req *proto.LoginRequest) (*proto.AuthResponse, error) {
+	// Validate request
+	if req.Login == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "login and password are required")
+	}
+
+	return s.login(ctx, req.Login, req.Password, req.Scopes)
+}
+
+// login authenticates a user and converts the issued token to a response
+func (s *UserService) login(ctx context.Context, login string, password string, scopes []string) (*proto.AuthResponse, error) {
+	result, err := s.authUseCase.Login(&usecase.LoginInput{
+		Login:    login,
+		Password: password,
+		Scopes:   scopes,
+		Client:   clientInfo(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid login credentials")
+	}
+
+	// Convert to response
+	resp := &proto.AuthResponse{
+		AccessToken:     result.AccessToken,
+		ExpiresAt:       timestamppb.New(result.ExpiresAt),
+		UserId:          result.UserID,
+		Username:        result.Username,
+		OrgId:           result.OrgID,
+		Scopes:          make([]string, 0, len(result.Scopes)),
+		PasswordExpired: result.PasswordExpired,
+	}
+	for _, scope := range result.Scopes {
+		resp.Scopes = append(resp.Scopes, string(scope))
+	}
+
+	return resp, nil
+}
+
 // GetUser implements the GetUser RPC method
 func (s *UserService) GetUser(ctx context.Context, req *proto.GetUserRequest) (*proto.UserResponse, error) {
 	// Validate request
@@ -42,25 +120,228 @@ func (s *UserService) GetUser(ctx context.Context, req *proto.GetUserRequest) (*
 		return nil, status.Error(codes.InvalidArgument, "user id is required")
 	}
 
-	// Get user
-	user, err := s.userUseCase.GetUserByID(req.Id)
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeUsersRead)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get user; users of other organizations are reported as not found
+	user, err := s.userUseCase.GetUserInOrg(claims.OrgID, req.Id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
-		logger.ErrorF("Failed to get user: %v", err)
+		s.log.ErrorF("Failed to get user: %v", err)
 		return nil, status.Error(codes.Internal, "failed to get user")
 	}
 
 	// Convert to response
-	return &proto.UserResponse{
-		Id:        user.ID.Hex(),
-		Username:  user.Username,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		CreatedAt: timestamppb.New(user.CreatedAt),
-	}, nil
+	return domainUserToProto(user), nil
+}
+
+// UpdateUser implements the UpdateUser RPC method
+func (s *UserService) UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.UserResponse, error) {
+	// Validate request
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	// Get caller from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update user
+	user, err := s.userUseCase.UpdateUser(&usecase.UpdateUserInput{
+		ID:        req.Id,
+		Email:     req.Email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Timezone:  req.Timezone,
+		Password:  req.Password,
+		UpdatedBy: claims.UserID,
+		Client:    callerInfo(ctx, claims),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return nil, status.Error(codes.PermissionDenied, "you cannot make this change to the user's profile")
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrDuplicateKey):
+			return nil, status.Error(codes.AlreadyExists, "email already in use")
+		}
+		s.log.ErrorF("Failed to update user: %v", err)
+		return nil, status.Error(codes.Internal, "failed to update user")
+	}
+
+	// Convert to response
+	return domainUserToProto(user), nil
+}
+
+// DeleteUser implements the DeleteUser RPC method
+func (s *UserService) DeleteUser(ctx context.Context, req *proto.DeleteUserRequest) (*emptypb.Empty, error) {
+	// Validate request
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	// Get caller from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete user
+	err = s.userUseCase.DeleteUser(&usecase.DeleteUserInput{
+		ID:         req.Id,
+		DeletedBy:  claims.UserID,
+		Policy:     domain.UserDeletionPolicy(req.DeletionPolicy),
+		ReassignTo: req.ReassignTo,
+		Client:     callerInfo(ctx, claims),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			return nil, status.Error(codes.PermissionDenied, "you cannot delete this account")
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		s.log.ErrorF("Failed to delete user: %v", err)
+		return nil, status.Error(codes.Internal, "failed to delete user")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// DeactivateUser implements the DeactivateUser RPC method
+func (s *UserService) DeactivateUser(ctx context.Context, req *proto.DeactivateUserRequest) (*proto.UserResponse, error) {
+	// Validate request
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	// Get caller from the token; deactivating someone else is organization administration
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+	if claims.UserID != req.Id && !claims.HasScope(domain.ScopeUsersAdmin) {
+		return nil, status.Errorf(codes.PermissionDenied, "token lacks the required scope %s", domain.ScopeUsersAdmin)
+	}
+
+	// Deactivate user
+	user, err := s.userUseCase.DeactivateUser(req.Id, claims.UserID)
+	if err != nil {
+		return nil, s.userManagementError(err, "deactivate")
+	}
+
+	// Convert to response
+	return domainUserToProto(user), nil
+}
+
+// ReactivateUser implements the ReactivateUser RPC method
+func (s *UserService) ReactivateUser(ctx context.Context, req *proto.ReactivateUserRequest) (*proto.UserResponse, error) {
+	// Validate request
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	// Get caller from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeUsersAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reactivate user
+	user, err := s.userUseCase.ReactivateUser(req.Id, claims.UserID)
+	if err != nil {
+		return nil, s.userManagementError(err, "reactivate")
+	}
+
+	// Convert to response
+	return domainUserToProto(user), nil
+}
+
+// userManagementError maps an error deactivating or reactivating a user to a gRPC status
+func (s *UserService) userManagementError(err error, action string) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Errorf(codes.PermissionDenied, "you are not allowed to %s this user", action)
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	s.log.ErrorF("Failed to %s user: %v", action, err)
+	return status.Errorf(codes.Internal, "failed to %s user", action)
+}
+
+// ListUsers implements the ListUsers RPC method
+func (s *UserService) ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+	return s.listUsers(ctx, "", req.Page, req.PageSize)
+}
+
+// SearchUsers implements the SearchUsers RPC method
+func (s *UserService) SearchUsers(ctx context.Context, req *proto.SearchUsersRequest) (*proto.ListUsersResponse, error) {
+	// Validate request
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	return s.listUsers(ctx, req.Query, req.Page, req.PageSize)
+}
+
+// listUsers lists a page of the caller's organization's users matching the query
+func (s *UserService) listUsers(ctx context.Context, query string, page int32, pageSize int32) (*proto.ListUsersResponse, error) {
+	// Validate request
+	if page < 0 || pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page and page_size must not be negative")
+	}
+
+	// Get caller's organization from the token
+	claims, err := getClaimsFromContext(ctx, s.authUseCase, s.log, domain.ScopeUsersAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// List users; only organization admins may
+	result, err := s.userUseCase.ListUsers(&usecase.ListUsersInput{
+		OrgID:       claims.OrgID,
+		RequestedBy: claims.UserID,
+		Query:       query,
+		Page:        int64(page),
+		PageSize:    int64(pageSize),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrUnauthorized):
+			return nil, status.Error(codes.PermissionDenied, "only organization admins can list users")
+		}
+		s.log.ErrorF("Failed to list users: %v", err)
+		return nil, status.Error(codes.Internal, "failed to list users")
+	}
+
+	// Convert to response
+	resp := &proto.ListUsersResponse{
+		Users:           make([]*proto.UserResponse, 0, len(result.Users)),
+		TotalCount:      result.Total,
+		PageSize:        int32(result.Page.Size),
+		PageSizeClamped: result.Page.Clamped,
+	}
+	for _, user := range result.Users {
+		resp.Users = append(resp.Users, domainUserToProto(user))
+	}
+
+	return resp, nil
 }
 
 // ValidateToken implements the ValidateToken RPC method
@@ -82,7 +363,7 @@ func (s *UserService) ValidateToken(ctx context.Context, req *proto.ValidateToke
 	// Get username
 	user, err := s.userUseCase.GetUserByID(userID)
 	if err != nil {
-		logger.ErrorF("Failed to get user: %v", err)
+		s.log.ErrorF("Failed to get user: %v", err)
 		return &proto.ValidateTokenResponse{
 			UserId: userID,
 			Valid:  true,
@@ -96,3 +377,20 @@ func (s *UserService) ValidateToken(ctx context.Context, req *proto.ValidateToke
 		Valid:    true,
 	}, nil
 }
+
+// domainUserToProto converts a domain user to its protobuf representation
+func domainUserToProto(user *domain.User) *proto.UserResponse {
+	resp := &proto.UserResponse{
+		Id:        user.ID.Hex(),
+		Username:  user.Username,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		Timezone:  user.Timezone,
+	}
+	if user.DeactivatedAt != nil {
+		resp.DeactivatedAt = timestamppb.New(*user.DeactivatedAt)
+	}
+	return resp
+}
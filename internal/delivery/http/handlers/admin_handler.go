@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"task-management-system/config"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/logger"
+)
+
+// AdminHandler handles operational HTTP requests authenticated with the admin token
+type AdminHandler struct {
+	runtime *config.RuntimeSettings
+	log     logger.Logger
+}
+
+// NewAdminHandler creates a new admin handler logging failures to the given logger
+func NewAdminHandler(runtime *config.RuntimeSettings, log logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		runtime: runtime,
+		log:     log,
+	}
+}
+
+// ReloadConfig godoc
+// @Summary Reload runtime configuration
+// @Description Re-read the configuration file and apply its runtime section (log level, CORS origins, rate limits, feature flags) without a restart. Other settings need a restart. Requires the admin token.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {admin token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]config.RuntimeChange} "Configuration reloaded; lists the changed settings"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid runtime configuration; the current settings are kept"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid admin token"
+// @Router /admin/config/reload [post]
+func (h *AdminHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	// Reload runtime settings
+	changes, err := h.runtime.Reload("admin request from " + clientInfo(r).IP)
+	if err != nil {
+		h.log.ErrorF("Runtime configuration reload failed: %v", err)
+		httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if changes == nil {
+		changes = []config.RuntimeChange{}
+	}
+
+	// Return changed settings
+	httpUtils.RespondWithJSON(w, http.StatusOK, changes)
+}
+
+// GetLogLevels godoc
+// @Summary Get log levels
+// @Description Get the global log level and the levels of components (http, grpc, mongodb, jobs) logging at their own. Requires the admin token.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {admin token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=config.LogLevels} "Log levels retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid admin token"
+// @Router /admin/log-level [get]
+func (h *AdminHandler) GetLogLevels(w http.ResponseWriter, r *http.Request) {
+	httpUtils.RespondWithJSON(w, http.StatusOK, h.runtime.LogLevels())
+}
+
+// SetLogLevels godoc
+// @Summary Change log levels
+// @Description Change the global log level or the component levels without a restart, e.g. to debug a single component. An omitted level is kept; components, when given, replace all component levels, and components left out follow the global level. The change lasts until the configuration is next reloaded. Requires the admin token.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {admin token}"
+// @Param levels body config.LogLevels true "Log levels"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=config.LogLevels} "Log levels changed; returns the current levels"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid log level or component"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid admin token"
+// @Router /admin/log-level [put]
+func (h *AdminHandler) SetLogLevels(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var req config.LogLevels
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Apply the levels like a reload, so that they are validated and logged
+	if _, err := h.runtime.SetLogLevels(req, "admin request from "+clientInfo(r).IP); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Return the current levels
+	httpUtils.RespondWithJSON(w, http.StatusOK, h.runtime.LogLevels())
+}
+
+// GetFaults godoc
+// @Summary Get injected database faults
+// @Description Get the latency and errors injected into database operations, by collection then operation; "*" stands for any. Only available when app.env is development. Requires the admin token.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {admin token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=map[string]map[string]config.Fault} "Faults retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid admin token"
+// @Router /admin/faults [get]
+func (h *AdminHandler) GetFaults(w http.ResponseWriter, r *http.Request) {
+	httpUtils.RespondWithJSON(w, http.StatusOK, h.faults())
+}
+
+// SetFaults godoc
+// @Summary Change injected database faults
+// @Description Replace the latency and errors injected into database operations, to test how clients handle retries and timeouts. Faults are given by collection, such as tasks, then by operation: find, insert, update, delete, aggregate or count; "*" stands for any. The most specific fault applies. An operation is delayed by latency_ms, or until it times out, then fails with the probability error_rate. An empty object clears all faults. The change lasts until the configuration is next reloaded. Only available when app.env is development. Requires the admin token.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {admin token}"
+// @Param faults body map[string]map[string]config.Fault true "Faults by collection and operation"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=map[string]map[string]config.Fault} "Faults changed; returns the current faults"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid operation, latency or error rate"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid admin token"
+// @Router /admin/faults [put]
+func (h *AdminHandler) SetFaults(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var req map[string]map[string]config.Fault
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Apply the faults like a reload, so that they are validated and logged
+	if _, err := h.runtime.SetFaults(req, "admin request from "+clientInfo(r).IP); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Return the current faults
+	httpUtils.RespondWithJSON(w, http.StatusOK, h.faults())
+}
+
+// faults returns the current faults, as an empty object when there are none
+func (h *AdminHandler) faults() map[string]map[string]config.Fault {
+	faults := h.runtime.Get().Faults
+	if faults == nil {
+		return map[string]map[string]config.Fault{}
+	}
+	return faults
+}
+
+// ListFeatures godoc
+// @Summary List feature flags
+// @Description List the feature flags and whether each is enabled. Flags can change at runtime.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=map[string]bool} "Feature flags retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Router /features [get]
+func (h *AdminHandler) ListFeatures(w http.ResponseWriter, r *http.Request) {
+	httpUtils.RespondWithJSON(w, http.StatusOK, h.runtime.Get().Features)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// AssignmentHandler handles HTTP requests answering task assignments, in
+// projects that require assignees to accept the tasks others assign them
+type AssignmentHandler struct {
+	taskUseCase *usecase.TaskUseCase
+}
+
+// NewAssignmentHandler creates a new assignment handler
+func NewAssignmentHandler(taskUseCase *usecase.TaskUseCase) *AssignmentHandler {
+	return &AssignmentHandler{
+		taskUseCase: taskUseCase,
+	}
+}
+
+// AcceptAssignment godoc
+// @Summary Accept a task assignment
+// @Description Accept the authenticated user's pending assignment to a task. A pending task moves to in progress, within the project's WIP limits. The user who made the assignment is notified.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Assignment accepted successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "WIP limit reached"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task or pending assignment not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/assignment/accept [post]
+func (h *AssignmentHandler) AcceptAssignment(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Accept the assignment
+	task, err := h.taskUseCase.AcceptAssignment(orgID, taskID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, notFoundMessage(err, "Task not found"), "Forbidden")
+		return
+	}
+
+	// Return updated task
+	httpUtils.RespondWithJSON(w, http.StatusOK, task)
+}
+
+// DeclineAssignment godoc
+// @Summary Decline a task assignment
+// @Description Decline the authenticated user's pending assignment to a task. The user is removed from the task, which goes back to the assignees it had before the assignment. The user who made the assignment is notified.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Assignment declined successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task or pending assignment not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/assignment/decline [post]
+func (h *AssignmentHandler) DeclineAssignment(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Decline the assignment
+	task, err := h.taskUseCase.DeclineAssignment(orgID, taskID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, notFoundMessage(err, "Task not found"), "Forbidden")
+		return
+	}
+
+	// Return updated task
+	httpUtils.RespondWithJSON(w, http.StatusOK, task)
+}
+
+// ListAssignmentRequests godoc
+// @Summary List pending task assignments
+// @Description Get the tasks whose assignment to the authenticated user awaits their answer
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.Task} "Tasks retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of tasks"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/assignment-requests [get]
+func (h *AssignmentHandler) ListAssignmentRequests(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get the tasks
+	tasks, err := h.taskUseCase.ListAssignmentRequests(orgID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Forbidden")
+		return
+	}
+
+	// Return tasks
+	httpUtils.RespondWithList(w, http.StatusOK, tasks, int64(len(tasks)))
+}
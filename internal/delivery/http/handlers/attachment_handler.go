@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+	"task-management-system/internal/usecase"
+)
+
+// AttachmentHandler handles HTTP requests for task attachments
+type AttachmentHandler struct {
+	attachmentUseCase *usecase.AttachmentUseCase
+	log               logger.Logger
+}
+
+// NewAttachmentHandler creates a new attachment handler logging failures to the given logger
+func NewAttachmentHandler(attachmentUseCase *usecase.AttachmentUseCase, log logger.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUseCase: attachmentUseCase,
+		log:               log,
+	}
+}
+
+// CreateAttachmentRequest represents the request body for attaching a file to a task
+type CreateAttachmentRequest struct {
+	Name        string `json:"name" example:"design.pdf"`
+	ContentType string `json:"content_type,omitempty" example:"application/pdf"`
+	Size        int64  `json:"size" example:"482113"`
+}
+
+// UploadResponse tells clients where to upload a file's content to
+type UploadResponse struct {
+	// UploadURL is where the content is sent with PUT, with the declared content type as Content-Type
+	UploadURL string `json:"upload_url" example:"https://bucket.s3.amazonaws.com/attachments/..."`
+	// Presigned reports whether the upload URL is a presigned storage URL. Presigned
+	// uploads are sent without the Authorization header and finished by calling
+	// CompleteURL; uploads through the API are finished at once.
+	Presigned   bool       `json:"presigned"`
+	CompleteURL string     `json:"complete_url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// AttachmentUploadResponse represents a created attachment and where to upload its content
+type AttachmentUploadResponse struct {
+	Attachment *domain.Attachment `json:"attachment"`
+	UploadResponse
+}
+
+// CreateAttachment godoc
+// @Summary Attach a file to a task
+// @Description Record a pending attachment and get where to upload its content. With S3 storage the content is uploaded directly to a presigned URL, then the attachment is completed; otherwise it is uploaded through the API.
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param attachment body CreateAttachmentRequest true "File information"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=AttachmentUploadResponse} "Attachment created"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not allowed to change the task"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/attachments [post]
+func (h *AttachmentHandler) CreateAttachment(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req CreateAttachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create attachment
+	upload, err := h.attachmentUseCase.CreateAttachment(&usecase.CreateAttachmentInput{
+		OrgID:       orgID,
+		TaskID:      taskID,
+		UserID:      userID,
+		Name:        req.Name,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+	})
+	if err != nil {
+		respondWithAttachmentError(w, err, "Task not found")
+		return
+	}
+
+	// Presigned uploads go to storage; the others to the attachment's content route
+	attachmentPath := path.Join(r.URL.Path, upload.Attachment.ID.Hex())
+	resp := AttachmentUploadResponse{
+		Attachment:     upload.Attachment,
+		UploadResponse: uploadResponse(r, upload.UploadURL, upload.ExpiresAt, attachmentPath+"/content", attachmentPath+"/complete"),
+	}
+
+	w.Header().Set("Location", httpUtils.ExternalURL(r, attachmentPath))
+	httpUtils.RespondWithJSON(w, http.StatusCreated, resp)
+}
+
+// UploadAttachmentContent godoc
+// @Summary Upload an attachment's content
+// @Description Upload the content of a pending attachment through the API, which completes it. Only available when storage does not use presigned URLs; the body must be exactly the declared size.
+// @Tags attachments
+// @Accept octet-stream
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param attachmentId path string true "Attachment ID" example:"60f1a7c9e113d70001fedcba"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Attachment} "Content uploaded"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input or already uploaded"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not the uploader"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Attachment not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/attachments/{attachmentId}/content [put]
+func (h *AttachmentHandler) UploadAttachmentContent(w http.ResponseWriter, r *http.Request) {
+	// Get task and attachment IDs from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	attachmentID := vars["attachmentId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Store content
+	attachment, err := h.attachmentUseCase.UploadAttachmentContent(orgID, taskID, attachmentID, userID, r.Body)
+	if err != nil {
+		respondWithAttachmentError(w, err, "Attachment not found")
+		return
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusOK, attachment)
+}
+
+// CompleteAttachment godoc
+// @Summary Complete an attachment upload
+// @Description Mark an attachment ready once its content has been uploaded to the presigned upload URL. Completing a ready attachment has no effect.
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param attachmentId path string true "Attachment ID" example:"60f1a7c9e113d70001fedcba"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Attachment} "Attachment ready"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Content missing or too large"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not the uploader"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Attachment not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/attachments/{attachmentId}/complete [post]
+func (h *AttachmentHandler) CompleteAttachment(w http.ResponseWriter, r *http.Request) {
+	// Get task and attachment IDs from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	attachmentID := vars["attachmentId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Complete attachment
+	attachment, err := h.attachmentUseCase.CompleteAttachment(orgID, taskID, attachmentID, userID)
+	if err != nil {
+		respondWithAttachmentError(w, err, "Attachment not found")
+		return
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusOK, attachment)
+}
+
+// ListAttachments godoc
+// @Summary List task attachments
+// @Description List the attachments of a task, oldest first, including pending ones
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.Attachment} "Attachments retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of attachments"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get attachments
+	attachments, err := h.attachmentUseCase.ListAttachments(orgID, taskID, userID)
+	if err != nil {
+		respondWithAttachmentError(w, err, "Task not found")
+		return
+	}
+
+	httpUtils.RespondWithList(w, http.StatusOK, attachments, int64(len(attachments)))
+}
+
+// DownloadAttachment godoc
+// @Summary Download an attachment
+// @Description Download the content of a ready attachment, or with size=thumb the thumbnail of an image attachment once its thumbnail is ready. When malware scanning is enabled, attachments can be downloaded once scanned clean. With S3 storage this redirects to a presigned URL; otherwise the content is served by the API.
+// @Tags attachments
+// @Produce octet-stream
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param attachmentId path string true "Attachment ID" example:"60f1a7c9e113d70001fedcba"
+// @Param size query string false "Download the thumbnail instead of the content" Enums(thumb)
+// @Success 200 {file} file "Attachment content"
+// @Success 302 "Redirect to the presigned download URL"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid size, or the attachment is being scanned or was quarantined"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Attachment, content or thumbnail not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/attachments/{attachmentId}/content [get]
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	// Get task and attachment IDs from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	attachmentID := vars["attachmentId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get the requested variant from the query
+	variant := r.URL.Query().Get("size")
+	if variant != "" && variant != usecase.AttachmentVariantThumbnail {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "size must be thumb")
+		return
+	}
+
+	download, err := h.attachmentUseCase.DownloadAttachment(orgID, taskID, attachmentID, userID, variant)
+	if err != nil {
+		respondWithAttachmentError(w, err, "Attachment not found")
+		return
+	}
+
+	if download.URL != "" {
+		http.Redirect(w, r, download.URL, http.StatusFound)
+		return
+	}
+	defer download.Content.Close()
+
+	// Always download uploaded content rather than display it, so uploaded HTML
+	// cannot run as the API's origin. Thumbnails are generated images, shown inline.
+	disposition := "attachment"
+	if variant == usecase.AttachmentVariantThumbnail {
+		disposition = "inline"
+		w.Header().Set("Cache-Control", "private, max-age=3600")
+	}
+	w.Header().Set("Content-Type", download.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(download.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": download.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, download.Content); err != nil {
+		h.log.WarnF("Failed to send attachment %s: %v", download.Attachment.ID.Hex(), err)
+	}
+}
+
+// DeleteAttachment godoc
+// @Summary Delete an attachment
+// @Description Delete an attachment of a task with its content
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param attachmentId path string true "Attachment ID" example:"60f1a7c9e113d70001fedcba"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not allowed to change the task"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Attachment not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	// Get task and attachment IDs from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	attachmentID := vars["attachmentId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.attachmentUseCase.DeleteAttachment(orgID, taskID, attachmentID, userID); err != nil {
+		respondWithAttachmentError(w, err, "Attachment not found")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// uploadResponse describes where to upload content to: the presigned URL when
+// there is one, and otherwise the API's content path
+func uploadResponse(r *http.Request, presignedURL string, expiresAt time.Time, contentPath string, completePath string) UploadResponse {
+	if presignedURL == "" {
+		return UploadResponse{UploadURL: httpUtils.ExternalURL(r, contentPath)}
+	}
+
+	return UploadResponse{
+		UploadURL:   presignedURL,
+		Presigned:   true,
+		CompleteURL: httpUtils.ExternalURL(r, completePath),
+		ExpiresAt:   &expiresAt,
+	}
+}
+
+// respondWithAttachmentError maps attachment and avatar errors to responses
+func respondWithAttachmentError(w http.ResponseWriter, err error, notFound string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		httpUtils.RespondWithInvalidInput(w, err)
+	case errors.Is(err, domain.ErrNotFound):
+		httpUtils.RespondWithError(w, http.StatusNotFound, notFound)
+	case errors.Is(err, domain.ErrUnauthorized):
+		httpUtils.RespondWithError(w, http.StatusForbidden, "You are not authorized to perform this action")
+	default:
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// AuditHandler handles HTTP requests for the audit log
+type AuditHandler struct {
+	auditUseCase *usecase.AuditUseCase
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditUseCase *usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+	}
+}
+
+// ListAuditEntries godoc
+// @Summary Query the audit log
+// @Description List the organization's privileged operations, newest first, with optional filters. Only organization admins may read the audit log.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param actor_id query string false "Only entries made by this user"
+// @Param target_id query string false "Only entries acting on this object"
+// @Param action query string false "Only entries of this action" example:"organization.member_role_changed"
+// @Param from query string false "Only entries at or after this time (RFC 3339)" example:"2025-03-01T00:00:00Z"
+// @Param to query string false "Only entries before this time (RFC 3339)" example:"2025-04-01T00:00:00Z"
+// @Param limit query int false "Maximum number of entries to return (max 100)"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.AuditEntry} "Audit entries retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Number of entries returned"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid filter"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/audit-log [get]
+func (h *AuditHandler) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+
+	from, err := parseTimeParam(query.Get("from"))
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid from time, expected RFC 3339")
+		return
+	}
+
+	to, err := parseTimeParam(query.Get("to"))
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid to time, expected RFC 3339")
+		return
+	}
+
+	// Get audit entries
+	entries, err := h.auditUseCase.ListEntries(&usecase.ListAuditEntriesInput{
+		OrgID:    orgID,
+		UserID:   userID,
+		ActorID:  query.Get("actor_id"),
+		TargetID: query.Get("target_id"),
+		Action:   domain.AuditAction(query.Get("action")),
+		From:     from,
+		To:       to,
+		Limit:    limit,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Organization not found", "Only organization admins can read the audit log")
+		return
+	}
+
+	// Return audit entries
+	httpUtils.RespondWithList(w, http.StatusOK, entries, int64(len(entries)))
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter; empty means unset
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
 	"task-management-system/internal/usecase"
 )
 
@@ -12,13 +14,16 @@ import (
 type AuthHandler struct {
 	authUseCase *usecase.AuthUseCase
 	userUseCase *usecase.UserUseCase
+	// cookies is nil unless cookie-based authentication is enabled
+	cookies *httpUtils.SessionCookies
 }
 
 // NewAuthHandler creates a new authentication handler
-func NewAuthHandler(authUseCase *usecase.AuthUseCase, userUseCase *usecase.UserUseCase) *AuthHandler {
+func NewAuthHandler(authUseCase *usecase.AuthUseCase, userUseCase *usecase.UserUseCase, cookies *httpUtils.SessionCookies) *AuthHandler {
 	return &AuthHandler{
 		authUseCase: authUseCase,
 		userUseCase: userUseCase,
+		cookies:     cookies,
 	}
 }
 
@@ -26,9 +31,11 @@ func NewAuthHandler(authUseCase *usecase.AuthUseCase, userUseCase *usecase.UserU
 type RegisterRequest struct {
 	Username  string `json:"username" example:"johndoe" minLength:"3"`
 	Email     string `json:"email" example:"john.doe@example.com" format:"email"`
-	Password  string `json:"password" example:"securepassword123" minLength:"6"`
+	Password  string `json:"password" example:"securepassword123" description:"Must satisfy the configured password policy"`
 	FirstName string `json:"first_name,omitempty" example:"John"`
 	LastName  string `json:"last_name,omitempty" example:"Doe"`
+	// Organization is the name of the organization created for the new user
+	Organization string `json:"organization,omitempty" example:"Acme Inc."`
 }
 
 // RegisterResponse represents the response for user registration
@@ -38,11 +45,12 @@ type RegisterResponse struct {
 	Email     string `json:"email" example:"john.doe@example.com"`
 	FirstName string `json:"first_name,omitempty" example:"John"`
 	LastName  string `json:"last_name,omitempty" example:"Doe"`
+	OrgID     string `json:"org_id" example:"60f1a7c9e113d70001234599"`
 }
 
 // Register godoc
 // @Summary Register a new user
-// @Description Create a new user account
+// @Description Create a new user account and an organization administered by that user
 // @Tags authentication
 // @Accept json
 // @Produce json
@@ -60,16 +68,22 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 	// Register user
 	user, err := h.userUseCase.RegisterUser(&usecase.RegisterUserInput{
-		Username:  req.Username,
-		Email:     req.Email,
-		Password:  req.Password,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
+		Username:         req.Username,
+		Email:            req.Email,
+		Password:         req.Password,
+		FirstName:        req.FirstName,
+		LastName:         req.LastName,
+		OrganizationName: req.Organization,
 	})
 
 	if err != nil {
 		// Handle error
-		httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput), errors.Is(err, domain.ErrDuplicateKey):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
 		return
 	}
 
@@ -80,6 +94,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		Email:     user.Email,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
+		OrgID:     user.OrgID.Hex(),
 	}
 
 	// Return created user
@@ -90,24 +105,33 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 type LoginRequest struct {
 	Login    string `json:"login" example:"johndoe" description:"Username or email"`
 	Password string `json:"password" example:"securepassword123"`
+	// Scopes optionally limits the token to some of the user's scopes, e.g. for an integration
+	Scopes []string `json:"scopes,omitempty" example:"tasks:read,tasks:write"`
 }
 
 // LoginResponse represents the response for user login
 type LoginResponse struct {
-	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// AccessToken is omitted when cookie-based authentication is enabled; the token is set in the session cookie instead
+	AccessToken string `json:"access_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 	ExpiresAt   string `json:"expires_at" example:"Sat, 08 Mar 2025 15:00:00 GMT"`
 	UserID      string `json:"user_id" example:"60f1a7c9e113d70001234567"`
 	Username    string `json:"username" example:"johndoe"`
+	OrgID       string `json:"org_id" example:"60f1a7c9e113d70001234599"`
+	// Scopes lists the API operations the token grants
+	Scopes []string `json:"scopes" example:"tasks:read,tasks:write"`
+	// PasswordExpired asks the client to have the user change their password
+	PasswordExpired bool `json:"password_expired" example:"false"`
 }
 
 // Login godoc
 // @Summary Authenticate user
-// @Description Authenticate a user and get a JWT token
+// @Description Authenticate a user and get a JWT token. Each login starts a new session, listed under /me/sessions. When cookie-based authentication is enabled the token is set in an HttpOnly session cookie instead of being returned. Tokens grant every scope the user may be granted unless fewer are requested.
 // @Tags authentication
 // @Accept json
 // @Produce json
 // @Param credentials body LoginRequest true "User login credentials"
 // @Success 200 {object} httpUtils.ResponseWrapper{data=LoginResponse} "User authenticated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Unknown or ungrantable scopes"
 // @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Invalid credentials"
 // @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
 // @Router /auth/login [post]
@@ -122,23 +146,21 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	result, err := h.authUseCase.Login(&usecase.LoginInput{
 		Login:    req.Login,
 		Password: req.Password,
+		Scopes:   req.Scopes,
+		Client:   clientInfo(r),
 	})
 
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Invalid login credentials")
 		return
 	}
 
-	// Create response
-	resp := LoginResponse{
-		AccessToken: result.AccessToken,
-		ExpiresAt:   result.ExpiresAt.Format(http.TimeFormat),
-		UserID:      result.UserID,
-		Username:    result.Username,
-	}
-
 	// Return token
-	httpUtils.RespondWithJSON(w, http.StatusOK, resp)
+	respondWithLogin(w, result, h.cookies)
 }
 
 // RefreshTokenRequest represents the request body for refreshing token
@@ -148,7 +170,7 @@ type RefreshTokenRequest struct {
 
 // RefreshToken godoc
 // @Summary Refresh JWT token
-// @Description Get a new JWT token using a valid token
+// @Description Get a new JWT token using a valid token. The new token belongs to the same session, which is extended. With cookie-based authentication the token may be omitted to refresh the session cookie, which requires the X-CSRF-Token header.
 // @Tags authentication
 // @Accept json
 // @Produce json
@@ -164,21 +186,146 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Fall back to the session cookie; like other cookie-authenticated writes it needs the CSRF token
+	token := req.Token
+	if token == "" && h.cookies != nil {
+		if !h.cookies.ValidCSRF(r) {
+			httpUtils.RespondWithError(w, http.StatusForbidden, "Invalid or missing CSRF token")
+			return
+		}
+		token = h.cookies.Session(r)
+	}
+
 	// Refresh token
-	result, err := h.authUseCase.RefreshToken(req.Token)
+	result, err := h.authUseCase.RefreshToken(token)
 	if err != nil {
 		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Invalid token")
 		return
 	}
 
+	// Return new token
+	respondWithLogin(w, result, h.cookies)
+}
+
+// CSRFTokenResponse represents the response for a CSRF token
+type CSRFTokenResponse struct {
+	CSRFToken string `json:"csrf_token" example:"q3J9x0tW0mB1c8bH2Zr5yQ4mVbN7pL6kT1sD9fG0hJw"`
+}
+
+// CSRFToken godoc
+// @Summary Get a CSRF token
+// @Description Issue a CSRF token for cookie-based authentication, also set in a readable cookie. Requests authenticated by the session cookie must send it in the X-CSRF-Token header unless they only read data. Only available when cookie-based authentication is enabled.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Success 200 {object} httpUtils.ResponseWrapper{data=CSRFTokenResponse} "CSRF token issued"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
+// @Router /auth/csrf-token [get]
+func (h *AuthHandler) CSRFToken(w http.ResponseWriter, r *http.Request) {
+	token, err := h.cookies.IssueCSRF(w)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusOK, CSRFTokenResponse{CSRFToken: token})
+}
+
+// Logout godoc
+// @Summary Sign out
+// @Description Sign out the current session. Its tokens stop working immediately and the session cookies, if any, are cleared.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Invalid or missing CSRF token"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	// Get user ID and session ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	sessionID, _ := r.Context().Value("sessionID").(string)
+
+	// Revoke the current session; tokens issued before sessions were tracked have none
+	if sessionID != "" {
+		if err := h.authUseCase.RevokeSession(userID, sessionID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	if h.cookies != nil {
+		h.cookies.ClearSession(w)
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+// @Summary Sign out of all devices
+// @Description Sign out every session of the authenticated user. All previously issued tokens stop working immediately, including the caller's. Impersonation tokens cannot do so.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Forbidden - impersonation token"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
+// @Router /me/logout-all [post]
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.authUseCase.LogoutAll(userID, clientInfo(r)); err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			httpUtils.RespondWithError(w, http.StatusForbidden, "Impersonation tokens cannot sign the user out of all devices")
+			return
+		}
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if h.cookies != nil {
+		h.cookies.ClearSession(w)
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondWithLogin responds with a login result. With cookie-based authentication
+// the token is set in the session cookie and left out of the response body.
+func respondWithLogin(w http.ResponseWriter, result *usecase.LoginOutput, cookies *httpUtils.SessionCookies) {
 	// Create response
 	resp := LoginResponse{
-		AccessToken: result.AccessToken,
-		ExpiresAt:   result.ExpiresAt.Format(http.TimeFormat),
-		UserID:      result.UserID,
-		Username:    result.Username,
+		AccessToken:     result.AccessToken,
+		ExpiresAt:       result.ExpiresAt.Format(http.TimeFormat),
+		UserID:          result.UserID,
+		Username:        result.Username,
+		OrgID:           result.OrgID,
+		Scopes:          make([]string, 0, len(result.Scopes)),
+		PasswordExpired: result.PasswordExpired,
+	}
+	for _, scope := range result.Scopes {
+		resp.Scopes = append(resp.Scopes, string(scope))
+	}
+
+	if cookies != nil {
+		cookies.SetSession(w, result.AccessToken, result.ExpiresAt)
+		resp.AccessToken = ""
 	}
 
-	// Return new token
 	httpUtils.RespondWithJSON(w, http.StatusOK, resp)
 }
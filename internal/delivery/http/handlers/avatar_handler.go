@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/logger"
+	"task-management-system/internal/usecase"
+)
+
+// AvatarHandler handles HTTP requests for user avatars
+type AvatarHandler struct {
+	avatarUseCase *usecase.AvatarUseCase
+	log           logger.Logger
+}
+
+// NewAvatarHandler creates a new avatar handler logging failures to the given logger
+func NewAvatarHandler(avatarUseCase *usecase.AvatarUseCase, log logger.Logger) *AvatarHandler {
+	return &AvatarHandler{
+		avatarUseCase: avatarUseCase,
+		log:           log,
+	}
+}
+
+// AvatarUploadRequest represents the request body for uploading a new avatar
+type AvatarUploadRequest struct {
+	ContentType string `json:"content_type" example:"image/png" enums:"image/png,image/jpeg,image/gif,image/webp"`
+	Size        int64  `json:"size" example:"48211"`
+}
+
+// AvatarUploadResponse represents a new avatar upload and where to upload its content
+type AvatarUploadResponse struct {
+	UploadID string `json:"upload_id" example:"60f1a7c9e113d70001abcdef"`
+	UploadResponse
+}
+
+// BeginAvatarUpload godoc
+// @Summary Upload a new avatar
+// @Description Start uploading a new avatar for the authenticated user and get where to upload the image to. With S3 storage the image is uploaded directly to a presigned URL, then the upload is completed; otherwise it is uploaded through the API.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param upload body AvatarUploadRequest true "Image information"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=AvatarUploadResponse} "Upload started"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/avatar/uploads [post]
+func (h *AvatarHandler) BeginAvatarUpload(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req AvatarUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	upload, err := h.avatarUseCase.BeginAvatarUpload(userID, req.ContentType, req.Size)
+	if err != nil {
+		respondWithAttachmentError(w, err, "User not found")
+		return
+	}
+
+	// Presigned uploads go to storage; the others to the upload's route
+	uploadPath := path.Join(r.URL.Path, upload.UploadID)
+	resp := AvatarUploadResponse{
+		UploadID:       upload.UploadID,
+		UploadResponse: uploadResponse(r, upload.UploadURL, upload.ExpiresAt, uploadPath, uploadPath+"/complete"),
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusCreated, resp)
+}
+
+// UploadAvatarContent godoc
+// @Summary Upload an avatar image
+// @Description Upload the image of an avatar upload through the API, making it the authenticated user's avatar. Only available when storage does not use presigned URLs.
+// @Tags users
+// @Accept png,jpeg,gif,webp
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param uploadId path string true "Upload ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.User} "Avatar updated"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid image"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Upload not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/avatar/uploads/{uploadId} [put]
+func (h *AvatarHandler) UploadAvatarContent(w http.ResponseWriter, r *http.Request) {
+	// Get upload ID from URL
+	vars := mux.Vars(r)
+	uploadID := vars["uploadId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	user, err := h.avatarUseCase.UploadAvatarContent(userID, uploadID, r.Header.Get("Content-Type"), r.Body)
+	if err != nil {
+		respondWithAttachmentError(w, err, "Upload not found")
+		return
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusOK, user)
+}
+
+// CompleteAvatarUpload godoc
+// @Summary Complete an avatar upload
+// @Description Make the image uploaded to the presigned upload URL the authenticated user's avatar
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param uploadId path string true "Upload ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.User} "Avatar updated"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Image missing or too large"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Upload not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/avatar/uploads/{uploadId}/complete [post]
+func (h *AvatarHandler) CompleteAvatarUpload(w http.ResponseWriter, r *http.Request) {
+	// Get upload ID from URL
+	vars := mux.Vars(r)
+	uploadID := vars["uploadId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	user, err := h.avatarUseCase.CompleteAvatarUpload(userID, uploadID)
+	if err != nil {
+		respondWithAttachmentError(w, err, "Upload not found")
+		return
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusOK, user)
+}
+
+// DeleteAvatar godoc
+// @Summary Remove avatar
+// @Description Remove the authenticated user's avatar
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "No avatar"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/avatar [delete]
+func (h *AvatarHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.avatarUseCase.DeleteAvatar(userID); err != nil {
+		respondWithAttachmentError(w, err, "No avatar")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAvatar godoc
+// @Summary Get a user's avatar
+// @Description Get the avatar image of a user of the organization. With S3 storage this redirects to a presigned URL; otherwise the image is served by the API.
+// @Tags users
+// @Produce png,jpeg,gif,webp
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Success 200 {file} file "Avatar image"
+// @Success 302 "Redirect to the presigned download URL"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "User or avatar not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /users/{id}/avatar [get]
+func (h *AvatarHandler) GetAvatar(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from URL
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	download, err := h.avatarUseCase.GetAvatar(orgID, userID)
+	if err != nil {
+		respondWithAttachmentError(w, err, "Avatar not found")
+		return
+	}
+
+	if download.URL != "" {
+		http.Redirect(w, r, download.URL, http.StatusFound)
+		return
+	}
+	defer download.Content.Close()
+
+	w.Header().Set("Content-Type", download.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.Header().Set("Last-Modified", download.UpdatedAt.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, download.Content); err != nil {
+		h.log.WarnF("Failed to send avatar of user %s: %v", userID, err)
+	}
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// CalendarFeedHandler handles HTTP requests for users' iCalendar subscription feeds
+type CalendarFeedHandler struct {
+	feedUseCase *usecase.CalendarFeedUseCase
+}
+
+// NewCalendarFeedHandler creates a new calendar feed handler
+func NewCalendarFeedHandler(feedUseCase *usecase.CalendarFeedUseCase) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		feedUseCase: feedUseCase,
+	}
+}
+
+// CalendarFeedResponse represents a calendar feed in responses
+type CalendarFeedResponse struct {
+	*domain.CalendarFeed
+	// Token is only returned when the feed is created or rotated; calendar apps subscribe to /feeds/{token}.ics
+	Token string `json:"token,omitempty" example:"q5cXh0mC1b0x7Yt4bVq9Xk3sZ8pW2nR6dL1fJ0aH4eE"`
+}
+
+// GetCalendarFeed godoc
+// @Summary Get the user's calendar feed
+// @Description Get the authenticated user's calendar subscription feed, without its token
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=CalendarFeedResponse} "Feed retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "No calendar feed"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/calendar-feed [get]
+func (h *CalendarFeedHandler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get feed
+	feed, err := h.feedUseCase.GetFeed(orgID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "No calendar feed", "Forbidden")
+		return
+	}
+
+	// Return feed
+	httpUtils.RespondWithJSON(w, http.StatusOK, CalendarFeedResponse{CalendarFeed: feed})
+}
+
+// RotateCalendarFeed godoc
+// @Summary Create or rotate the user's calendar feed
+// @Description Create the authenticated user's calendar subscription feed, or replace its token so the old URL stops working. Calendar apps subscribe to /feeds/{token}.ics, which lists the user's open assigned tasks as all-day events on their due dates. The token is only returned here.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=CalendarFeedResponse} "Feed created successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/calendar-feed [post]
+func (h *CalendarFeedHandler) RotateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Create or rotate feed
+	output, err := h.feedUseCase.RotateFeed(orgID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Forbidden")
+		return
+	}
+
+	// Return feed with its token
+	httpUtils.RespondWithJSON(w, http.StatusCreated, CalendarFeedResponse{CalendarFeed: output.Feed, Token: output.Token})
+}
+
+// RevokeCalendarFeed godoc
+// @Summary Revoke the user's calendar feed
+// @Description Delete the authenticated user's calendar subscription feed; its URL stops working right away
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "No calendar feed"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/calendar-feed [delete]
+func (h *CalendarFeedHandler) RevokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Revoke feed
+	if err := h.feedUseCase.RevokeFeed(orgID, userID); err != nil {
+		respondWithOrganizationError(w, err, "No calendar feed", "Forbidden")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ServeCalendarFeed godoc
+// @Summary Get a calendar feed
+// @Description Get a user's open assigned tasks as an iCalendar feed of all-day events on their due dates, in the user's time zone, for calendar apps to subscribe to. Tasks due up to a month ago are included. No bearer token is needed; the feed token authenticates the request.
+// @Tags users
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Feed not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /feeds/{token}.ics [get]
+func (h *CalendarFeedHandler) ServeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	// Get feed token from URL
+	token := mux.Vars(r)["token"]
+
+	// Load feed content
+	now := time.Now()
+	content, err := h.feedUseCase.FeedContent(token, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Feed not found")
+			return
+		}
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Render calendar
+	var calendar strings.Builder
+	writeICalendar(&calendar, "Tasks of "+content.User.Username, content.Tasks, content.User.Location(), now)
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(calendar.String()))
+}
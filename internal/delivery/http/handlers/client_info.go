@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+
+	"task-management-system/internal/usecase"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// clientInfo describes the client that sent the request, and the admin acting as
+// the signed-in user when the request is made with an impersonation token
+func clientInfo(r *http.Request) usecase.ClientInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	// Set by auth middleware for impersonation tokens
+	impersonatorID, _ := r.Context().Value("impersonatorID").(string)
+	impersonator, _ := primitive.ObjectIDFromHex(impersonatorID)
+
+	return usecase.ClientInfo{
+		IP:             ip,
+		UserAgent:      r.UserAgent(),
+		ImpersonatorID: impersonator,
+	}
+}
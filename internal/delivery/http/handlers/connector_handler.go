@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// ConnectorHandler handles HTTP requests for the chat connectors of projects
+type ConnectorHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+}
+
+// NewConnectorHandler creates a new connector handler
+func NewConnectorHandler(projectUseCase *usecase.ProjectUseCase) *ConnectorHandler {
+	return &ConnectorHandler{
+		projectUseCase: projectUseCase,
+	}
+}
+
+// ProjectConnector represents a chat connector posting a project's task events to a Slack, Microsoft Teams or Discord channel
+type ProjectConnector struct {
+	ID         string                        `json:"id,omitempty" example:"60f1a7c9e113d70001234900"` // Assigned by the server
+	Channel    domain.NotificationChannel    `json:"channel" example:"teams" enums:"slack,teams,discord"`
+	WebhookURL string                        `json:"webhook_url" example:"https://example.webhook.office.com/webhookb2/..."`
+	Categories []domain.NotificationCategory `json:"categories,omitempty" enums:"assignment,comment,due_soon,status_change,task_update"` // Events posted; all when empty
+}
+
+// ProjectConnectorsRequest represents the request body for replacing a project's chat connectors
+type ProjectConnectorsRequest struct {
+	Connectors []ProjectConnector `json:"connectors"`
+}
+
+// GetConnectors godoc
+// @Summary Get a project's chat connectors
+// @Description Get the connectors posting a project's task events to chat channels. Webhook URLs are secrets, so only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]ProjectConnector} "Connectors retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/connectors [get]
+func (h *ConnectorHandler) GetConnectors(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	projectID := mux.Vars(r)["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get connectors
+	connectors, err := h.projectUseCase.GetProjectConnectors(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can manage connectors")
+		return
+	}
+
+	// Return connectors
+	httpUtils.RespondWithJSON(w, http.StatusOK, projectConnectors(connectors))
+}
+
+// SetConnectors godoc
+// @Summary Replace a project's chat connectors
+// @Description Replace the connectors posting a project's task events to Slack, Microsoft Teams (as Adaptive Cards) or Discord channels through their incoming webhooks. Each connector posts the events of its categories, or all events when it lists none. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param connectors body ProjectConnectorsRequest true "Connectors"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]ProjectConnector} "Connectors replaced successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/connectors [put]
+func (h *ConnectorHandler) SetConnectors(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	projectID := mux.Vars(r)["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req ProjectConnectorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	inputs := make([]usecase.ProjectConnectorInput, 0, len(req.Connectors))
+	for _, connector := range req.Connectors {
+		inputs = append(inputs, usecase.ProjectConnectorInput{
+			Channel:    connector.Channel,
+			WebhookURL: connector.WebhookURL,
+			Categories: connector.Categories,
+		})
+	}
+
+	// Replace connectors
+	connectors, err := h.projectUseCase.SetProjectConnectors(&usecase.SetProjectConnectorsInput{
+		OrgID:      orgID,
+		ProjectID:  projectID,
+		Connectors: inputs,
+		UpdatedBy:  userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can manage connectors")
+		return
+	}
+
+	// Return connectors
+	httpUtils.RespondWithJSON(w, http.StatusOK, projectConnectors(connectors))
+}
+
+// projectConnectors converts domain connectors to their API representation
+func projectConnectors(connectors []domain.ProjectConnector) []ProjectConnector {
+	result := make([]ProjectConnector, 0, len(connectors))
+	for _, connector := range connectors {
+		result = append(result, ProjectConnector{
+			ID:         connector.ID.Hex(),
+			Channel:    connector.Channel,
+			WebhookURL: connector.WebhookURL,
+			Categories: connector.Categories,
+		})
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+	"task-management-system/internal/usecase"
+)
+
+// maxImportSize bounds the size of an uploaded export document
+const maxImportSize = 512 << 20
+
+// ExportHandler handles HTTP requests for exporting and importing organizations
+type ExportHandler struct {
+	exportUseCase *usecase.ExportUseCase
+	log           logger.Logger
+}
+
+// NewExportHandler creates a new export handler logging failures to the given logger
+func NewExportHandler(exportUseCase *usecase.ExportUseCase, log logger.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportUseCase: exportUseCase,
+		log:           log,
+	}
+}
+
+// Export godoc
+// @Summary Export the organization
+// @Description Download the organization's users, projects, tasks and attachment metadata as a versioned JSON document that POST /import accepts on another instance. Password hashes and attachment contents are not included. Only organization admins may export.
+// @Tags organization
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} domain.Export "Export document"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not an organization admin"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /export [get]
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	export, err := h.exportUseCase.Export(orgID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Organization not found", "Only organization admins can export the organization")
+		return
+	}
+
+	// The document is served as is, not wrapped, so it can be imported unchanged
+	filename := fmt.Sprintf("export-%s-%s.json", export.Organization.ID.Hex(), export.ExportedAt.Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		h.log.WarnF("Failed to send export of organization %s: %v", orgID, err)
+	}
+}
+
+// Import godoc
+// @Summary Import into the organization
+// @Description Import a document written by GET /export into the organization, keeping the exported IDs and timestamps. Records that already exist are skipped, so an import can be run again. Exported users already in the organization, matched by ID or email, are kept. Imported users cannot sign in until an operator imports their credentials with the command line tool. Only organization admins may import.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param export body domain.Export true "Export document"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=usecase.ImportSummary} "Import completed"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid or unsupported export document"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not an organization admin"
+// @Failure 409 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "A user or project key belongs to another organization"
+// @Failure 413 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Export document too large"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /import [post]
+func (h *ExportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var data domain.Export
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxImportSize)).Decode(&data); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httpUtils.RespondWithError(w, http.StatusRequestEntityTooLarge, "Export document too large")
+			return
+		}
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	summary, err := h.exportUseCase.Import(&usecase.ImportInput{
+		OrgID:  orgID,
+		UserID: userID,
+		Data:   &data,
+		Client: clientInfo(r),
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Organization not found", "Only organization admins can import into the organization")
+		return
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusOK, summary)
+}
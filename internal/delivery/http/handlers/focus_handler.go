@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// FocusHandler handles HTTP requests that help users decide what to work on
+type FocusHandler struct {
+	taskUseCase *usecase.TaskUseCase
+}
+
+// NewFocusHandler creates a new focus handler
+func NewFocusHandler(taskUseCase *usecase.TaskUseCase) *FocusHandler {
+	return &FocusHandler{
+		taskUseCase: taskUseCase,
+	}
+}
+
+// GetNextTask godoc
+// @Summary Get the next task to work on
+// @Description Recommend the single most relevant open task assigned to the authenticated user, for focus modes and bots. Tasks are ranked by priority, how soon they are due, and whether they were escalated as overdue; in-progress tasks get a small boost. Snoozed tasks are skipped. The reasons list why the task was picked.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=usecase.TaskRecommendation} "Next task retrieved successfully"
+// @Success 204 "The user has no open tasks"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/next-task [get]
+func (h *FocusHandler) GetNextTask(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get the recommendation
+	next, err := h.taskUseCase.NextTask(orgID, userID, time.Now())
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Forbidden")
+		return
+	}
+
+	// Nothing to work on
+	if next == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// Return the recommendation
+	httpUtils.RespondWithJSON(w, http.StatusOK, next)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// icalLineLimit is the number of octets after which iCalendar content lines are folded
+const icalLineLimit = 75
+
+// icalTextEscaper escapes the characters with a meaning in iCalendar text values
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// writeICalendar renders tasks as an iCalendar (RFC 5545) calendar of all-day
+// events on their due dates, in the given time zone
+func writeICalendar(b *strings.Builder, name string, tasks []*domain.Task, loc *time.Location, now time.Time) {
+	icalLine(b, "BEGIN:VCALENDAR")
+	icalLine(b, "VERSION:2.0")
+	icalLine(b, "PRODID:-//task-management-system//Calendar feed//EN")
+	icalLine(b, "CALSCALE:GREGORIAN")
+	icalLine(b, "METHOD:PUBLISH")
+	icalLine(b, "X-WR-CALNAME:"+icalText(name))
+	icalLine(b, "X-WR-TIMEZONE:"+loc.String())
+
+	for _, task := range tasks {
+		due := task.DueDate.In(loc)
+
+		summary := task.Title
+		if task.Key != "" {
+			summary = fmt.Sprintf("[%s] %s", task.Key, task.Title)
+		}
+
+		stamp := task.UpdatedAt
+		if stamp.IsZero() {
+			stamp = now
+		}
+
+		icalLine(b, "BEGIN:VEVENT")
+		icalLine(b, "UID:"+task.ID.Hex()+"@task-management-system")
+		icalLine(b, "DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"))
+		icalLine(b, "DTSTART;VALUE=DATE:"+due.Format("20060102"))
+		icalLine(b, "DTEND;VALUE=DATE:"+due.AddDate(0, 0, 1).Format("20060102"))
+		icalLine(b, "SUMMARY:"+icalText(summary))
+		icalLine(b, "DESCRIPTION:"+icalText(fmt.Sprintf("Status: %s\nPriority: %d\nDue: %s", task.Status, task.Priority, due.Format(time.RFC3339))))
+		icalLine(b, "TRANSP:TRANSPARENT")
+		icalLine(b, "END:VEVENT")
+	}
+
+	icalLine(b, "END:VCALENDAR")
+}
+
+// icalText escapes an iCalendar text value
+func icalText(s string) string {
+	return icalTextEscaper.Replace(s)
+}
+
+// icalLine writes a content line, folded so that no line exceeds the limit
+// without splitting UTF-8 characters
+func icalLine(b *strings.Builder, line string) {
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > icalLineLimit {
+			// Continuation lines start with a space, which counts towards the limit
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// ImpersonationHandler handles HTTP requests for organization admins acting as members
+type ImpersonationHandler struct {
+	authUseCase *usecase.AuthUseCase
+}
+
+// NewImpersonationHandler creates a new impersonation handler
+func NewImpersonationHandler(authUseCase *usecase.AuthUseCase) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+// ImpersonateRequest represents the request body for impersonating a member
+type ImpersonateRequest struct {
+	// Reason is recorded in the audit log
+	Reason string `json:"reason" example:"Support ticket #4821: tasks missing from the board" maxLength:"500"`
+	// Scopes optionally limits the token to some of the member's scopes, e.g. read-only access
+	Scopes []string `json:"scopes,omitempty" example:"tasks:read,projects:read"`
+}
+
+// ImpersonateMember godoc
+// @Summary Impersonate a member
+// @Description Get a short-lived token acting as a member of the organization, for support debugging. The token's act claim names the admin, it cannot be refreshed, and requests made with it are logged with the admin's ID. Starting and revoking impersonations is recorded in the audit log. Only organization admins may impersonate, and only when impersonation is enabled in the configuration.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Param impersonation body ImpersonateRequest true "Reason and optional scopes"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=usecase.ImpersonationOutput} "Impersonation started"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input or impersonation disabled"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not an organization admin, or already impersonating"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Member not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/members/{id}/impersonate [post]
+func (h *ImpersonationHandler) ImpersonateMember(w http.ResponseWriter, r *http.Request) {
+	// Get member ID from URL
+	vars := mux.Vars(r)
+	memberID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req ImpersonateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Start impersonation
+	result, err := h.authUseCase.Impersonate(&usecase.ImpersonateInput{
+		OrgID:    orgID,
+		UserID:   userID,
+		MemberID: memberID,
+		Reason:   req.Reason,
+		Scopes:   req.Scopes,
+		Client:   clientInfo(r),
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Member not found", "Only organization admins can impersonate members")
+		return
+	}
+
+	// Return the impersonation token
+	httpUtils.RespondWithJSON(w, http.StatusOK, result)
+}
+
+// RevokeImpersonation godoc
+// @Summary Revoke an impersonation
+// @Description End an impersonation of a member of the organization; its token stops working immediately. Any organization admin may end it. The admin holding the token can also end it by signing out, and the member by revoking the session.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Session ID of the impersonation" example:"60f1a7c9e113d70001234800"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not an organization admin"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Impersonation not found or already ended"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/impersonations/{id} [delete]
+func (h *ImpersonationHandler) RevokeImpersonation(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from URL
+	vars := mux.Vars(r)
+	sessionID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Revoke impersonation
+	if err := h.authUseCase.RevokeImpersonation(&usecase.RevokeImpersonationInput{
+		OrgID:     orgID,
+		UserID:    userID,
+		SessionID: sessionID,
+		Client:    clientInfo(r),
+	}); err != nil {
+		respondWithOrganizationError(w, err, "Impersonation not found", "Only organization admins can revoke impersonations")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// maxHookPayloadSize caps the size of payloads posted to inbound hooks
+const maxHookPayloadSize = 64 << 10
+
+// InboundHookHandler handles HTTP requests for inbound hooks, which create tasks
+// from JSON posted by external integrations such as Zapier or IFTTT
+type InboundHookHandler struct {
+	hookUseCase *usecase.InboundHookUseCase
+}
+
+// NewInboundHookHandler creates a new inbound hook handler
+func NewInboundHookHandler(hookUseCase *usecase.InboundHookUseCase) *InboundHookHandler {
+	return &InboundHookHandler{
+		hookUseCase: hookUseCase,
+	}
+}
+
+// InboundHookRequest represents the request body for creating an inbound hook
+type InboundHookRequest struct {
+	Name string `json:"name" example:"Support form"`
+	// CreatorID is the user the tasks are created on behalf of, a contributor or admin of the project; the caller when empty
+	CreatorID string `json:"creator_id,omitempty" example:"60f1a7c9e113d70001234567"`
+	// Mapping holds a Go text/template per task field (title, description, priority, due_date, tags), rendered with
+	// the posted JSON. Fields without one are read from the payload field of the same name. Tags are comma-separated.
+	Mapping map[string]string `json:"mapping,omitempty" example:"title:{{.subject}},tags:support,{{join .labels}}"`
+}
+
+// InboundHookResponse represents an inbound hook in responses
+type InboundHookResponse struct {
+	*domain.InboundHook
+	// Token is only returned when the hook is created; tasks are created by posting JSON to /hooks/{token}
+	Token string `json:"token,omitempty" example:"q5cXh0mC1b0x7Yt4bVq9Xk3sZ8pW2nR6dL1fJ0aH4eE"`
+}
+
+// InboundHookTaskResponse identifies the task created by an inbound hook
+type InboundHookTaskResponse struct {
+	ID  string `json:"id" example:"60f1a7c9e113d70001abcdef"`
+	Key string `json:"key,omitempty" example:"WR-42"`
+}
+
+// CreateHook godoc
+// @Summary Create an inbound hook
+// @Description Create a secret URL that external integrations, such as Zapier or IFTTT, post JSON to in order to create tasks in the project on behalf of a contributor. The token is only returned once. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param hook body InboundHookRequest true "Hook information"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=InboundHookResponse} "Hook created successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/hooks [post]
+func (h *InboundHookHandler) CreateHook(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	projectID := mux.Vars(r)["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req InboundHookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create hook
+	output, err := h.hookUseCase.CreateHook(&usecase.CreateInboundHookInput{
+		OrgID:     orgID,
+		ProjectID: projectID,
+		Name:      req.Name,
+		CreatorID: req.CreatorID,
+		Mapping:   req.Mapping,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can manage inbound hooks")
+		return
+	}
+
+	// Return hook with its token
+	httpUtils.RespondWithJSON(w, http.StatusCreated, InboundHookResponse{InboundHook: output.Hook, Token: output.Token})
+}
+
+// ListHooks godoc
+// @Summary List inbound hooks
+// @Description List the inbound hooks of a project, oldest first. Tokens are not included. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.InboundHook} "Hooks retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/hooks [get]
+func (h *InboundHookHandler) ListHooks(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	projectID := mux.Vars(r)["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get hooks
+	hooks, err := h.hookUseCase.ListHooks(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can manage inbound hooks")
+		return
+	}
+
+	// Return hooks
+	httpUtils.RespondWithJSON(w, http.StatusOK, hooks)
+}
+
+// DeleteHook godoc
+// @Summary Delete an inbound hook
+// @Description Delete an inbound hook of a project; its token stops working right away. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param hookId path string true "Hook ID" example:"60f1a7c9e113d70001234800"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Hook not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/hooks/{hookId} [delete]
+func (h *InboundHookHandler) DeleteHook(w http.ResponseWriter, r *http.Request) {
+	// Get project and hook IDs from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	hookID := vars["hookId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Delete hook
+	if err := h.hookUseCase.DeleteHook(orgID, projectID, hookID, userID); err != nil {
+		respondWithOrganizationError(w, err, "Hook not found", "Only project admins can manage inbound hooks")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReceiveHook godoc
+// @Summary Create a task through an inbound hook
+// @Description Create a task in the hook's project from a JSON object, on behalf of the hook's creator. Without a mapping, the title, description, priority (1-5, default 3), due_date (RFC 3339) and tags (a list or comma-separated) fields are read from the object. No bearer token is needed; the hook token authenticates the request.
+// @Tags hooks
+// @Accept json
+// @Produce json
+// @Param token path string true "Hook token"
+// @Param payload body object true "Any JSON object"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=InboundHookTaskResponse} "Task created successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid payload"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "The hook's creator can no longer add tasks to the project"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Hook not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /hooks/{token} [post]
+func (h *InboundHookHandler) ReceiveHook(w http.ResponseWriter, r *http.Request) {
+	// Get hook token from URL
+	token := mux.Vars(r)["token"]
+
+	// Parse payload
+	var payload map[string]interface{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHookPayloadSize)).Decode(&payload); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Payload must be a JSON object")
+		return
+	}
+
+	// Create task
+	task, err := h.hookUseCase.Receive(token, payload)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithInvalidInput(w, err)
+		case errors.Is(err, domain.ErrNotFound):
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Hook not found")
+		case errors.Is(err, domain.ErrUnauthorized):
+			httpUtils.RespondWithError(w, http.StatusForbidden, "The hook's creator can no longer add tasks to the project")
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return the created task's identity
+	httpUtils.RespondWithJSON(w, http.StatusCreated, InboundHookTaskResponse{ID: task.ID.Hex(), Key: task.Key})
+}
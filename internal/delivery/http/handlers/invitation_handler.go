@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// InvitationHandler handles HTTP requests for organization invitations
+type InvitationHandler struct {
+	invitationUseCase *usecase.InvitationUseCase
+	// cookies is nil unless cookie-based authentication is enabled
+	cookies *httpUtils.SessionCookies
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(invitationUseCase *usecase.InvitationUseCase, cookies *httpUtils.SessionCookies) *InvitationHandler {
+	return &InvitationHandler{
+		invitationUseCase: invitationUseCase,
+		cookies:           cookies,
+	}
+}
+
+// InvitationResponse represents an invitation in API responses
+type InvitationResponse struct {
+	ID        string `json:"id" example:"60f1a7c9e113d70001234600"`
+	Email     string `json:"email" example:"jane.doe@example.com"`
+	Role      string `json:"role" example:"member" enums:"admin,member"`
+	Status    string `json:"status" example:"pending" enums:"pending,accepted,revoked,expired"`
+	InvitedBy string `json:"invited_by" example:"60f1a7c9e113d70001234567"`
+	ExpiresAt string `json:"expires_at" example:"Sat, 15 Mar 2025 12:00:00 GMT"`
+	CreatedAt string `json:"created_at" example:"Sat, 08 Mar 2025 12:00:00 GMT"`
+}
+
+// CreateInvitationResponse represents a new invitation together with its token
+type CreateInvitationResponse struct {
+	InvitationResponse
+	Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	AcceptURL string `json:"accept_url,omitempty" example:"https://app.example.com/invitations/accept?token=eyJhbGciOi..."`
+}
+
+// CreateInvitationRequest represents the request body for inviting a user
+type CreateInvitationRequest struct {
+	Email string         `json:"email" example:"jane.doe@example.com" format:"email"`
+	Role  domain.OrgRole `json:"role,omitempty" example:"member" enums:"admin,member"`
+}
+
+// CreateInvitation godoc
+// @Summary Invite a user to the organization
+// @Description Create a pending invitation for an email address and email it a signed link when email is configured. The token is also returned so it can be shared directly. Re-inviting an address revokes its previous invitation. Only organization admins may invite.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param invitation body CreateInvitationRequest true "Invitation information"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=CreateInvitationResponse} "Invitation created successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 409 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "User is already a member"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/invitations [post]
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req CreateInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create invitation
+	result, err := h.invitationUseCase.CreateInvitation(&usecase.CreateInvitationInput{
+		OrgID:     orgID,
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: userID,
+		Client:    clientInfo(r),
+	})
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrDuplicateKey):
+			httpUtils.RespondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithOrganizationError(w, err, "Organization not found", "Only organization admins can invite users")
+		}
+		return
+	}
+
+	// Return created invitation
+	httpUtils.RespondWithJSON(w, http.StatusCreated, CreateInvitationResponse{
+		InvitationResponse: invitationResponse(result.Invitation),
+		Token:              result.Token,
+		AcceptURL:          result.AcceptURL,
+	})
+}
+
+// ListInvitations godoc
+// @Summary List pending invitations
+// @Description List the organization's invitations that have not been accepted, revoked, or expired
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]InvitationResponse} "Invitations retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of pending invitations"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/invitations [get]
+func (h *InvitationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get invitations
+	invitations, err := h.invitationUseCase.ListInvitations(orgID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	resp := make([]InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		resp = append(resp, invitationResponse(invitation))
+	}
+
+	// Return invitations
+	httpUtils.RespondWithList(w, http.StatusOK, resp, int64(len(resp)))
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Revoke a pending invitation so its token can no longer be used. Only organization admins may do so.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Invitation ID" example:"60f1a7c9e113d70001234600"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invitation is no longer pending"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invitation not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/invitations/{id} [delete]
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	// Get invitation ID from URL
+	vars := mux.Vars(r)
+	invitationID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Revoke invitation
+	if err := h.invitationUseCase.RevokeInvitation(orgID, invitationID, userID, clientInfo(r)); err != nil {
+		respondWithOrganizationError(w, err, "Invitation not found", "Only organization admins can revoke invitations")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AcceptInvitationRequest represents the request body for accepting an invitation
+type AcceptInvitationRequest struct {
+	Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Username  string `json:"username,omitempty" example:"janedoe" description:"Required when no account exists for the invited email"`
+	Password  string `json:"password" example:"securepassword123" description:"Password of the existing account, or of the new account"`
+	FirstName string `json:"first_name,omitempty" example:"Jane"`
+	LastName  string `json:"last_name,omitempty" example:"Doe"`
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Join the inviting organization. If an account exists for the invited email, its password signs it in and the account moves to the organization; otherwise a new account is registered with the given username and password. Returns an access token for the organization.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param invitation body AcceptInvitationRequest true "Invitation token and credentials"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=LoginResponse} "Invitation accepted successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Invalid, expired, or revoked invitation, or invalid registration input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Invalid credentials"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
+// @Router /auth/invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Accept invitation
+	result, err := h.invitationUseCase.AcceptInvitation(&usecase.AcceptInvitationInput{
+		Token:     req.Token,
+		Username:  req.Username,
+		Password:  req.Password,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Client:    clientInfo(r),
+	})
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, domain.ErrUnauthorized):
+			httpUtils.RespondWithError(w, http.StatusUnauthorized, "Invalid login credentials")
+		default:
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	// Return token
+	respondWithLogin(w, result, h.cookies)
+}
+
+// invitationResponse converts a domain invitation to its API representation
+func invitationResponse(invitation *domain.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:        invitation.ID.Hex(),
+		Email:     invitation.Email,
+		Role:      string(invitation.Role),
+		Status:    string(invitation.Status(time.Now())),
+		InvitedBy: invitation.InvitedBy.Hex(),
+		ExpiresAt: invitation.ExpiresAt.Format(http.TimeFormat),
+		CreatedAt: invitation.CreatedAt.Format(http.TimeFormat),
+	}
+}
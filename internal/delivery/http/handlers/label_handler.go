@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// LabelHandler handles HTTP requests for the labels of projects
+type LabelHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+}
+
+// NewLabelHandler creates a new label handler
+func NewLabelHandler(projectUseCase *usecase.ProjectUseCase) *LabelHandler {
+	return &LabelHandler{
+		projectUseCase: projectUseCase,
+	}
+}
+
+// ProjectLabelRequest represents the request body for creating or updating a project label
+type ProjectLabelRequest struct {
+	Name        string `json:"name,omitempty" example:"bug"` // Set on creation only; the label is renamed by deleting and creating it
+	Color       string `json:"color,omitempty" example:"#d73a4a"`
+	Description string `json:"description,omitempty" example:"Something isn't working"`
+}
+
+// ListLabels godoc
+// @Summary List a project's labels
+// @Description List the labels the tasks of a project the caller is a member of are meant to carry as tags, in the order they were created. With strict labels, tasks can only be given tags that are labels.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.ProjectLabel} "Labels retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of labels"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/labels [get]
+func (h *LabelHandler) ListLabels(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	projectID := mux.Vars(r)["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get labels
+	labels, err := h.projectUseCase.ListProjectLabels(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Forbidden")
+		return
+	}
+
+	// Return labels
+	httpUtils.RespondWithList(w, http.StatusOK, labels, int64(len(labels)))
+}
+
+// CreateLabel godoc
+// @Summary Create a project label
+// @Description Add a label to a project. Names are normalized like task tags: trimmed and lowercased. Colors are hex RGB colors. A project has at most 100 labels. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param label body ProjectLabelRequest true "Label"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=domain.ProjectLabel} "Label created successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 409 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Label already exists"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/labels [post]
+func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	projectID := mux.Vars(r)["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req ProjectLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create label
+	label, err := h.projectUseCase.CreateProjectLabel(&usecase.ProjectLabelInput{
+		OrgID:       orgID,
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+		UpdatedBy:   userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can manage labels")
+		return
+	}
+
+	// Return created label
+	httpUtils.RespondWithJSON(w, http.StatusCreated, label)
+}
+
+// UpdateLabel godoc
+// @Summary Update a project label
+// @Description Change the color and description of a project's label. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param name path string true "Label name" example:"bug"
+// @Param label body ProjectLabelRequest true "Label; the name is taken from the path"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.ProjectLabel} "Label updated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project or label not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/labels/{name} [put]
+func (h *LabelHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	// Get project ID and label name from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	name := vars["name"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req ProjectLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Update label
+	label, err := h.projectUseCase.UpdateProjectLabel(&usecase.ProjectLabelInput{
+		OrgID:       orgID,
+		ProjectID:   projectID,
+		Name:        name,
+		Color:       req.Color,
+		Description: req.Description,
+		UpdatedBy:   userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, notFoundMessage(err, "Project not found"), "Only project admins can manage labels")
+		return
+	}
+
+	// Return updated label
+	httpUtils.RespondWithJSON(w, http.StatusOK, label)
+}
+
+// DeleteLabel godoc
+// @Summary Delete a project label
+// @Description Remove a label from a project. Tasks keep the tag; with strict labels it can no longer be given to tasks. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param name path string true "Label name" example:"bug"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project or label not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/labels/{name} [delete]
+func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	// Get project ID and label name from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	name := vars["name"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Delete label
+	if err := h.projectUseCase.DeleteProjectLabel(orgID, projectID, name, userID); err != nil {
+		respondWithOrganizationError(w, err, notFoundMessage(err, "Project not found"), "Only project admins can manage labels")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// LoginHistoryHandler handles HTTP requests for login history
+type LoginHistoryHandler struct {
+	authUseCase *usecase.AuthUseCase
+}
+
+// NewLoginHistoryHandler creates a new login history handler
+func NewLoginHistoryHandler(authUseCase *usecase.AuthUseCase) *LoginHistoryHandler {
+	return &LoginHistoryHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+// GetLoginHistory godoc
+// @Summary Get login history
+// @Description List the login attempts on the authenticated user's account, newest first, including failed ones
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param limit query int false "Maximum number of attempts to return (max 50)"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.LoginAttempt} "Login history retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Number of attempts returned"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/login-history [get]
+func (h *LoginHistoryHandler) GetLoginHistory(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+
+	// Get login history
+	attempts, err := h.authUseCase.GetLoginHistory(userID, limit)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Return login history
+	httpUtils.RespondWithList(w, http.StatusOK, attempts, int64(len(attempts)))
+}
+
+// ListLoginAttempts godoc
+// @Summary Query the organization's login history
+// @Description List login attempts on accounts of the organization, newest first, with optional filters. Only organization admins may do so.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param user_id query string false "Only attempts on this member's account"
+// @Param success query bool false "Only successful (true) or failed (false) attempts"
+// @Param from query string false "Only attempts at or after this time (RFC 3339)" example:"2025-03-01T00:00:00Z"
+// @Param to query string false "Only attempts before this time (RFC 3339)" example:"2025-04-01T00:00:00Z"
+// @Param limit query int false "Maximum number of attempts to return (max 50)"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.LoginAttempt} "Login attempts retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Number of attempts returned"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid filter"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/login-history [get]
+func (h *LoginHistoryHandler) ListLoginAttempts(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+
+	var success *bool
+	if value := query.Get("success"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid success filter, expected true or false")
+			return
+		}
+		success = &parsed
+	}
+
+	from, err := parseTimeParam(query.Get("from"))
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid from time, expected RFC 3339")
+		return
+	}
+
+	to, err := parseTimeParam(query.Get("to"))
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid to time, expected RFC 3339")
+		return
+	}
+
+	// Get login attempts
+	attempts, err := h.authUseCase.ListLoginAttempts(&usecase.ListLoginAttemptsInput{
+		OrgID:    orgID,
+		UserID:   userID,
+		MemberID: query.Get("user_id"),
+		Success:  success,
+		From:     from,
+		To:       to,
+		Limit:    limit,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Organization not found", "Only organization admins can read the login history")
+		return
+	}
+
+	// Return login attempts
+	httpUtils.RespondWithList(w, http.StatusOK, attempts, int64(len(attempts)))
+}
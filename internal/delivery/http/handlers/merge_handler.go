@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// MergeHandler handles HTTP requests for merging duplicate tasks
+type MergeHandler struct {
+	mergeUseCase *usecase.MergeUseCase
+}
+
+// NewMergeHandler creates a new merge handler
+func NewMergeHandler(mergeUseCase *usecase.MergeUseCase) *MergeHandler {
+	return &MergeHandler{
+		mergeUseCase: mergeUseCase,
+	}
+}
+
+// MergeTaskRequest represents the request body for merging a duplicate task
+type MergeTaskRequest struct {
+	// SourceID is the duplicate folded into the task; an ID or a key such as PROJ-123
+	SourceID string `json:"source_id" example:"60f1a7c9e113d70001abcdf0"`
+}
+
+// MergeTaskResponse represents the outcome of a merge
+type MergeTaskResponse struct {
+	Target           *domain.Task `json:"target"`
+	Source           *domain.Task `json:"source"`
+	MovedAttachments int64        `json:"moved_attachments" example:"2"`
+	MovedStars       int64        `json:"moved_stars" example:"3"`
+}
+
+// MergeTask godoc
+// @Summary Merge a duplicate into a task
+// @Description Fold a duplicate task into this one, in a single transaction when transactions are enabled. The duplicate's attachments and stars move to this task, and the duplicate is completed with merged_into pointing to this task. The caller must be allowed to change both tasks.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param merge body MergeTaskRequest true "Duplicate to merge"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=MergeTaskResponse} "Tasks merged successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/merge [post]
+func (h *MergeHandler) MergeTask(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req MergeTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Merge tasks
+	result, err := h.mergeUseCase.MergeTasks(&usecase.MergeTasksInput{
+		OrgID:    orgID,
+		TargetID: taskID,
+		SourceID: req.SourceID,
+		UserID:   userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "You are not authorized to merge these tasks")
+		return
+	}
+
+	// Return both tasks
+	httpUtils.RespondWithJSON(w, http.StatusOK, MergeTaskResponse{
+		Target:           result.Target,
+		Source:           result.Source,
+		MovedAttachments: result.Attachments,
+		MovedStars:       result.Stars,
+	})
+}
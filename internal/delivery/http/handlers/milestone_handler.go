@@ -0,0 +1,390 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// MilestoneHandler handles HTTP requests for project milestones
+type MilestoneHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+}
+
+// NewMilestoneHandler creates a new milestone handler
+func NewMilestoneHandler(projectUseCase *usecase.ProjectUseCase) *MilestoneHandler {
+	return &MilestoneHandler{
+		projectUseCase: projectUseCase,
+	}
+}
+
+// MilestoneRequest represents the request body for creating or updating a milestone
+type MilestoneRequest struct {
+	Name        string    `json:"name" example:"Public beta"`
+	Description string    `json:"description,omitempty" example:"Everything needed to open the beta"`
+	TargetDate  time.Time `json:"target_date" example:"2025-06-30T17:00:00Z"`
+}
+
+// MilestoneProgressResponse represents the progress of a milestone's tasks
+type MilestoneProgressResponse struct {
+	Total     int `json:"total" example:"20"`
+	Completed int `json:"completed" example:"15"`
+	// Overdue counts the incomplete tasks past their due date
+	Overdue int `json:"overdue" example:"1"`
+	// Percent is the share of completed tasks, rounded down
+	Percent int `json:"percent" example:"75"`
+	// AtRisk is set when incomplete tasks are overdue, or the target date has passed with tasks still incomplete
+	AtRisk bool `json:"at_risk" example:"true"`
+}
+
+// MilestoneResponse represents a milestone in API responses
+type MilestoneResponse struct {
+	ID          string                    `json:"id" example:"60f1a7c9e113d70001234a00"`
+	ProjectID   string                    `json:"project_id" example:"60f1a7c9e113d70001234700"`
+	Name        string                    `json:"name" example:"Public beta"`
+	Description string                    `json:"description,omitempty" example:"Everything needed to open the beta"`
+	TargetDate  time.Time                 `json:"target_date" example:"2025-06-30T17:00:00Z"`
+	Progress    MilestoneProgressResponse `json:"progress"`
+	CreatedBy   string                    `json:"created_by" example:"60f1a7c9e113d70001234567"`
+	CreatedAt   string                    `json:"created_at" example:"Sat, 01 Mar 2025 12:00:00 GMT"`
+	UpdatedAt   string                    `json:"updated_at" example:"Sat, 01 Mar 2025 12:00:00 GMT"`
+}
+
+// CreateMilestone godoc
+// @Summary Create a milestone
+// @Description Create a milestone in a project, grouping tasks toward a target date. Only project admins may do so.
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param milestone body MilestoneRequest true "Milestone information"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=MilestoneResponse} "Milestone created successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/milestones [post]
+func (h *MilestoneHandler) CreateMilestone(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req MilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create milestone
+	milestone, err := h.projectUseCase.CreateMilestone(&usecase.CreateMilestoneInput{
+		OrgID:       orgID,
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		TargetDate:  req.TargetDate,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can create milestones")
+		return
+	}
+
+	// Return created milestone
+	httpUtils.RespondWithJSON(w, http.StatusCreated, milestoneResponse(milestone))
+}
+
+// ListMilestones godoc
+// @Summary List a project's milestones
+// @Description List the milestones of a project the caller is a member of, by target date, with the progress of their tasks
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]MilestoneResponse} "Milestones retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of milestones"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/milestones [get]
+func (h *MilestoneHandler) ListMilestones(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get milestones
+	milestones, err := h.projectUseCase.ListMilestones(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Unauthorized")
+		return
+	}
+
+	resp := make([]MilestoneResponse, 0, len(milestones))
+	for _, milestone := range milestones {
+		resp = append(resp, milestoneResponse(milestone))
+	}
+
+	// Return milestones
+	httpUtils.RespondWithList(w, http.StatusOK, resp, int64(len(resp)))
+}
+
+// GetMilestone godoc
+// @Summary Get a milestone
+// @Description Get a milestone of a project the caller is a member of, with the progress of its tasks: how many are completed and overdue, the completion percentage, and whether the milestone is at risk
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Milestone ID" example:"60f1a7c9e113d70001234a00"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=MilestoneResponse} "Milestone retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Milestone not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /milestones/{id} [get]
+func (h *MilestoneHandler) GetMilestone(w http.ResponseWriter, r *http.Request) {
+	// Get milestone ID from URL
+	vars := mux.Vars(r)
+	milestoneID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get milestone
+	milestone, err := h.projectUseCase.GetMilestone(orgID, milestoneID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Milestone not found", "Unauthorized")
+		return
+	}
+
+	// Return milestone
+	httpUtils.RespondWithJSON(w, http.StatusOK, milestoneResponse(milestone))
+}
+
+// UpdateMilestone godoc
+// @Summary Update a milestone
+// @Description Change a milestone's name, description or target date. Only project admins may do so.
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Milestone ID" example:"60f1a7c9e113d70001234a00"
+// @Param milestone body MilestoneRequest true "Updated milestone information"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=MilestoneResponse} "Milestone updated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Milestone not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /milestones/{id} [put]
+func (h *MilestoneHandler) UpdateMilestone(w http.ResponseWriter, r *http.Request) {
+	// Get milestone ID from URL
+	vars := mux.Vars(r)
+	milestoneID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req MilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Update milestone
+	milestone, err := h.projectUseCase.UpdateMilestone(&usecase.UpdateMilestoneInput{
+		OrgID:       orgID,
+		ID:          milestoneID,
+		Name:        req.Name,
+		Description: req.Description,
+		TargetDate:  req.TargetDate,
+		UpdatedBy:   userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Milestone not found", "Only project admins can update milestones")
+		return
+	}
+
+	// Return updated milestone
+	httpUtils.RespondWithJSON(w, http.StatusOK, milestoneResponse(milestone))
+}
+
+// AddMilestoneTask godoc
+// @Summary Add a task to a milestone
+// @Description Add a task of the milestone's project to the milestone, moving it out of any other milestone. Project contributors who may change the task may do so.
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Milestone ID" example:"60f1a7c9e113d70001234a00"
+// @Param taskId path string true "Task ID" example:"60f1a7c9e113d70001234568"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Task added to the milestone"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task of another project"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Milestone or task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /milestones/{id}/tasks/{taskId} [put]
+func (h *MilestoneHandler) AddMilestoneTask(w http.ResponseWriter, r *http.Request) {
+	// Get milestone and task IDs from URL
+	vars := mux.Vars(r)
+	milestoneID := vars["id"]
+	taskID := vars["taskId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Add task
+	task, err := h.projectUseCase.AddMilestoneTask(&usecase.MilestoneTaskInput{
+		OrgID:       orgID,
+		MilestoneID: milestoneID,
+		TaskID:      taskID,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Milestone or task not found", "Only project contributors who can change the task can add it")
+		return
+	}
+
+	// Return task
+	httpUtils.RespondWithJSON(w, http.StatusOK, task)
+}
+
+// RemoveMilestoneTask godoc
+// @Summary Remove a task from a milestone
+// @Description Remove a task from a milestone. Project contributors who may change the task may do so.
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Milestone ID" example:"60f1a7c9e113d70001234a00"
+// @Param taskId path string true "Task ID" example:"60f1a7c9e113d70001234568"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Milestone not found or task not in the milestone"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /milestones/{id}/tasks/{taskId} [delete]
+func (h *MilestoneHandler) RemoveMilestoneTask(w http.ResponseWriter, r *http.Request) {
+	// Get milestone and task IDs from URL
+	vars := mux.Vars(r)
+	milestoneID := vars["id"]
+	taskID := vars["taskId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Remove task
+	if err := h.projectUseCase.RemoveMilestoneTask(&usecase.MilestoneTaskInput{
+		OrgID:       orgID,
+		MilestoneID: milestoneID,
+		TaskID:      taskID,
+		UserID:      userID,
+	}); err != nil {
+		respondWithOrganizationError(w, err, "Task not found in the milestone", "Only project contributors who can change the task can remove it")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// milestoneResponse converts a milestone and its progress to their API representation
+func milestoneResponse(milestone *usecase.MilestoneWithProgress) MilestoneResponse {
+	return MilestoneResponse{
+		ID:          milestone.ID.Hex(),
+		ProjectID:   milestone.ProjectID.Hex(),
+		Name:        milestone.Name,
+		Description: milestone.Description,
+		TargetDate:  milestone.TargetDate,
+		Progress: MilestoneProgressResponse{
+			Total:     milestone.Progress.Total,
+			Completed: milestone.Progress.Completed,
+			Overdue:   milestone.Progress.Overdue,
+			Percent:   milestone.Progress.Percent,
+			AtRisk:    milestone.Progress.AtRisk,
+		},
+		CreatedBy: milestone.CreatedBy.Hex(),
+		CreatedAt: milestone.CreatedAt.Format(http.TimeFormat),
+		UpdatedAt: milestone.UpdatedAt.Format(http.TimeFormat),
+	}
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// NotificationHandler handles notification-related HTTP requests
+type NotificationHandler struct {
+	notificationUseCase *usecase.NotificationUseCase
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationUseCase *usecase.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// UnreadCountResponse represents the response for the unread notification count
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count" example:"3"`
+}
+
+// MarkAllReadResponse represents the response for marking all notifications as read
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated" example:"3"`
+}
+
+// ListNotifications godoc
+// @Summary List notifications
+// @Description Get the authenticated user's notifications, newest first, together with the unread count
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param unread query bool false "Only return unread notifications"
+// @Param limit query int false "Maximum number of notifications to return (max 50)"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=usecase.ListNotificationsOutput} "Notifications retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/notifications [get]
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	unreadOnly, _ := strconv.ParseBool(query.Get("unread"))
+	limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+
+	// Get notifications
+	result, err := h.notificationUseCase.ListNotifications(&usecase.ListNotificationsInput{
+		UserID:     userID,
+		UnreadOnly: unreadOnly,
+		Limit:      limit,
+	})
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Return notifications
+	httpUtils.RespondWithJSON(w, http.StatusOK, result)
+}
+
+// GetUnreadCount godoc
+// @Summary Get unread notification count
+// @Description Get the number of unread notifications for the authenticated user
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=UnreadCountResponse} "Unread count retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Count unread notifications
+	count, err := h.notificationUseCase.CountUnread(userID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Return count
+	httpUtils.RespondWithJSON(w, http.StatusOK, UnreadCountResponse{UnreadCount: count})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Description Mark one of the authenticated user's notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Notification ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Notification not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	// Get notification ID from URL
+	vars := mux.Vars(r)
+	notificationID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Mark notification as read
+	err := h.notificationUseCase.MarkRead(userID, notificationID)
+	if err != nil {
+		// Handle different error types
+		switch err {
+		case domain.ErrNotFound:
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Notification not found")
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark all of the authenticated user's notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=MarkAllReadResponse} "Notifications marked as read"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Mark all notifications as read
+	updated, err := h.notificationUseCase.MarkAllRead(userID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Return number of updated notifications
+	httpUtils.RespondWithJSON(w, http.StatusOK, MarkAllReadResponse{Updated: updated})
+}
+
+// UpdateNotificationPreferencesRequest represents the request body for updating notification preferences
+type UpdateNotificationPreferencesRequest struct {
+	Channels        map[domain.NotificationCategory][]domain.NotificationChannel `json:"channels,omitempty"`
+	SlackWebhookURL *string                                                      `json:"slack_webhook_url,omitempty" example:"https://hooks.slack.com/services/T000/B000/XXXX"`
+	WebhookURL      *string                                                      `json:"webhook_url,omitempty" example:"https://example.com/hooks/tasks"`
+	DailyDigest     *bool                                                        `json:"daily_digest,omitempty" example:"true"`
+	DigestHour      *int                                                         `json:"digest_hour,omitempty" example:"8" minimum:"0" maximum:"23"`
+	QuietHours      *domain.QuietHours                                           `json:"quiet_hours,omitempty"`
+	// DoNotDisturbMinutes pauses notifications for that many minutes from now; 0 resumes them
+	DoNotDisturbMinutes *int `json:"do_not_disturb_minutes,omitempty" example:"120" minimum:"0" maximum:"10080"`
+}
+
+// GetNotificationPreferences godoc
+// @Summary Get notification preferences
+// @Description Get which channels each notification category is delivered to for the authenticated user
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.NotificationPreferences} "Preferences retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/notification-preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get preferences
+	prefs, err := h.notificationUseCase.GetPreferences(userID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Return preferences
+	httpUtils.RespondWithJSON(w, http.StatusOK, prefs)
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Update notification preferences
+// @Description Route notification categories (assignment, comment, due_soon, status_change, task_update) to channels (in_app, email, slack, webhook). Categories omitted from the request keep their current channels. daily_digest opts into a daily summary email sent at digest_hour in the user's time zone. During quiet_hours, between two hours of the day in the user's time zone, and for do_not_disturb_minutes from now, notifications other than escalations and reminders are held back from external channels and sent afterwards in one summary message per channel; in-app notifications are not held back.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param preferences body UpdateNotificationPreferencesRequest true "Updated preferences"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.NotificationPreferences} "Preferences updated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/notification-preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req UpdateNotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Update preferences
+	prefs, err := h.notificationUseCase.UpdatePreferences(&usecase.UpdatePreferencesInput{
+		UserID:              userID,
+		Channels:            req.Channels,
+		SlackWebhookURL:     req.SlackWebhookURL,
+		WebhookURL:          req.WebhookURL,
+		DailyDigest:         req.DailyDigest,
+		DigestHour:          req.DigestHour,
+		QuietHours:          req.QuietHours,
+		DoNotDisturbMinutes: req.DoNotDisturbMinutes,
+	})
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return updated preferences
+	httpUtils.RespondWithJSON(w, http.StatusOK, prefs)
+}
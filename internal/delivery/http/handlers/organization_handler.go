@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// OrganizationHandler handles HTTP requests for the caller's organization
+type OrganizationHandler struct {
+	organizationUseCase *usecase.OrganizationUseCase
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(organizationUseCase *usecase.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUseCase,
+	}
+}
+
+// OrganizationResponse represents an organization in API responses
+type OrganizationResponse struct {
+	ID        string `json:"id" example:"60f1a7c9e113d70001234599"`
+	Name      string `json:"name" example:"Acme Inc."`
+	CreatedBy string `json:"created_by" example:"60f1a7c9e113d70001234567"`
+	CreatedAt string `json:"created_at" example:"Sat, 01 Mar 2025 12:00:00 GMT"`
+	UpdatedAt string `json:"updated_at" example:"Sat, 08 Mar 2025 15:00:00 GMT"`
+}
+
+// MemberResponse represents an organization member in API responses
+type MemberResponse struct {
+	ID        string `json:"id" example:"60f1a7c9e113d70001234567"`
+	Username  string `json:"username" example:"johndoe"`
+	Email     string `json:"email" example:"john.doe@example.com"`
+	FirstName string `json:"first_name,omitempty" example:"John"`
+	LastName  string `json:"last_name,omitempty" example:"Doe"`
+	Role      string `json:"role" example:"member" enums:"admin,member"`
+	// Deactivated is set when the member's account is deactivated
+	Deactivated bool `json:"deactivated" example:"false"`
+}
+
+// GetOrganization godoc
+// @Summary Get the caller's organization
+// @Description Get the organization the authenticated user belongs to
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=OrganizationResponse} "Organization retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Organization not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org [get]
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization
+	org, err := h.organizationUseCase.GetOrganization(orgID)
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Organization not found")
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return organization
+	httpUtils.RespondWithJSON(w, http.StatusOK, organizationResponse(org))
+}
+
+// UpdateOrganizationRequest represents the request body for updating an organization
+type UpdateOrganizationRequest struct {
+	Name string `json:"name" example:"Acme Inc."`
+}
+
+// UpdateOrganization godoc
+// @Summary Update the caller's organization
+// @Description Rename the organization. Only organization admins may do so.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param organization body UpdateOrganizationRequest true "Organization information"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=OrganizationResponse} "Organization updated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org [put]
+func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Update organization
+	org, err := h.organizationUseCase.UpdateOrganization(&usecase.UpdateOrganizationInput{
+		OrgID:     orgID,
+		Name:      req.Name,
+		UpdatedBy: userID,
+		Client:    clientInfo(r),
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Organization not found", "Only organization admins can update the organization")
+		return
+	}
+
+	// Return updated organization
+	httpUtils.RespondWithJSON(w, http.StatusOK, organizationResponse(org))
+}
+
+// ListMembers godoc
+// @Summary List organization members
+// @Description List the users of the caller's organization
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]MemberResponse} "Members retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of members"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/members [get]
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get members
+	users, err := h.organizationUseCase.ListMembers(orgID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	members := make([]MemberResponse, 0, len(users))
+	for _, user := range users {
+		members = append(members, memberResponse(user))
+	}
+
+	// Return members
+	httpUtils.RespondWithList(w, http.StatusOK, members, int64(len(members)))
+}
+
+// UpdateMemberRoleRequest represents the request body for changing a member's role
+type UpdateMemberRoleRequest struct {
+	Role domain.OrgRole `json:"role" example:"admin" enums:"admin,member"`
+}
+
+// UpdateMemberRole godoc
+// @Summary Change a member's role
+// @Description Promote or demote an organization member. Only organization admins may do so, and the organization must keep at least one admin.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Param role body UpdateMemberRoleRequest true "New role"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=MemberResponse} "Role updated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Member not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/members/{id}/role [put]
+func (h *OrganizationHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	// Get member ID from URL
+	vars := mux.Vars(r)
+	memberID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req UpdateMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Update role
+	member, err := h.organizationUseCase.UpdateMemberRole(&usecase.UpdateMemberRoleInput{
+		OrgID:     orgID,
+		MemberID:  memberID,
+		Role:      req.Role,
+		UpdatedBy: userID,
+		Client:    clientInfo(r),
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Member not found", "Only organization admins can change roles")
+		return
+	}
+
+	// Return updated member
+	httpUtils.RespondWithJSON(w, http.StatusOK, memberResponse(member))
+}
+
+// RemoveMember godoc
+// @Summary Remove a member from the organization
+// @Description Remove a user from the organization. The user is moved to a new personal organization. Only organization admins may do so.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Member not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /org/members/{id} [delete]
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	// Get member ID from URL
+	vars := mux.Vars(r)
+	memberID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Remove member
+	if err := h.organizationUseCase.RemoveMember(orgID, memberID, userID, clientInfo(r)); err != nil {
+		respondWithOrganizationError(w, err, "Member not found", "Only organization admins can remove members")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondWithOrganizationError maps organization use case errors to HTTP responses
+func respondWithOrganizationError(w http.ResponseWriter, err error, notFound string, forbidden string) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		httpUtils.RespondWithError(w, http.StatusNotFound, notFound)
+	case errors.Is(err, domain.ErrUnauthorized):
+		httpUtils.RespondWithError(w, http.StatusForbidden, forbidden)
+	case errors.Is(err, domain.ErrInvalidInput):
+		httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrDuplicateKey):
+		httpUtils.RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// organizationResponse converts a domain organization to its API representation
+func organizationResponse(org *domain.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        org.ID.Hex(),
+		Name:      org.Name,
+		CreatedBy: org.CreatedBy.Hex(),
+		CreatedAt: org.CreatedAt.Format(http.TimeFormat),
+		UpdatedAt: org.UpdatedAt.Format(http.TimeFormat),
+	}
+}
+
+// memberResponse converts a domain user to its member representation, leaving out private fields
+func memberResponse(user *domain.User) MemberResponse {
+	return MemberResponse{
+		ID:          user.ID.Hex(),
+		Username:    user.Username,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Role:        string(user.OrgRole),
+		Deactivated: user.IsDeactivated(),
+	}
+}
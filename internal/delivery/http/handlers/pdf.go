@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Layout of generated PDF documents: A4 pages of monospaced text, in points
+const (
+	pdfPageWidth   = 595
+	pdfPageHeight  = 842
+	pdfMargin      = 50
+	pdfFontSize    = 9
+	pdfHeadingSize = 12
+	pdfLineHeight  = 13
+	// pdfLineLength is the number of Courier characters that fit between the margins
+	pdfLineLength = (pdfPageWidth - 2*pdfMargin) * 10 / (pdfFontSize * 6)
+	// pdfPageLines is the number of lines per page, leaving room for the footer
+	pdfPageLines = (pdfPageHeight-2*pdfMargin)/pdfLineHeight - 2
+)
+
+// pdfHeadingPrefix marks the text lines rendered as headings
+const pdfHeadingPrefix = "# "
+
+// pdfTextEscaper escapes the characters with a meaning in PDF literal strings
+var pdfTextEscaper = strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+
+// writePDF renders lines of text as a PDF document with the given title, in the
+// standard Courier font so that columns line up. Lines starting with "# " are
+// headings. Long lines are wrapped, and every page is numbered.
+func writePDF(b *bytes.Buffer, title string, text string) {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if strings.HasPrefix(line, pdfHeadingPrefix) {
+			lines = append(lines, line)
+			continue
+		}
+		lines = append(lines, wrapPDFLine(line, pdfLineLength)...)
+	}
+
+	var pages [][]string
+	for len(lines) > pdfPageLines {
+		pages = append(pages, lines[:pdfPageLines])
+		lines = lines[pdfPageLines:]
+	}
+	pages = append(pages, lines)
+
+	// Objects 1 to 5 are the catalog, page tree, fonts and document info; each
+	// page is followed by its content stream
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(b, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (task-management-system) >>", pdfText(title)))
+
+	for i, page := range pages {
+		var content strings.Builder
+		y := pdfPageHeight - pdfMargin
+		for _, line := range page {
+			y -= pdfLineHeight
+			font, size := "F1", pdfFontSize
+			if heading, ok := strings.CutPrefix(line, pdfHeadingPrefix); ok {
+				font, size, line = "F2", pdfHeadingSize, heading
+			}
+			if line == "" {
+				continue
+			}
+			fmt.Fprintf(&content, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, size, pdfMargin, y, pdfText(line))
+		}
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d %d Td (%s) Tj ET\n", pdfFontSize, pdfMargin, pdfMargin-pdfLineHeight,
+			pdfText(fmt.Sprintf("%s - page %d of %d", title, i+1, len(pages))))
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, len(offsets)+2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(b, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+}
+
+// wrapPDFLine splits a line into lines of at most width characters, at spaces
+// where possible, keeping the line's indentation on continuation lines
+func wrapPDFLine(line string, width int) []string {
+	runes := []rune(line)
+	if len(runes) <= width {
+		return []string{line}
+	}
+
+	indent := len(runes) - len([]rune(strings.TrimLeft(line, " ")))
+	if indent > width/2 {
+		indent = 0
+	}
+
+	var lines []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > indent; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		rest := []rune(strings.TrimLeft(string(runes[cut:]), " "))
+		runes = append([]rune(strings.Repeat(" ", indent)), rest...)
+	}
+	return append(lines, string(runes))
+}
+
+// pdfText encodes text as the body of a PDF literal string in WinAnsiEncoding.
+// Characters outside Latin-1 are replaced with question marks.
+func pdfText(text string) string {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			encoded = append(encoded, ' ')
+		case r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff:
+			encoded = append(encoded, '?')
+		default:
+			encoded = append(encoded, byte(r))
+		}
+	}
+	return pdfTextEscaper.Replace(string(encoded))
+}
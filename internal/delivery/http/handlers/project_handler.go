@@ -0,0 +1,674 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// ProjectHandler handles HTTP requests for projects and their members
+type ProjectHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+}
+
+// NewProjectHandler creates a new project handler
+func NewProjectHandler(projectUseCase *usecase.ProjectUseCase) *ProjectHandler {
+	return &ProjectHandler{
+		projectUseCase: projectUseCase,
+	}
+}
+
+// ProjectMemberResponse represents a project member in API responses
+type ProjectMemberResponse struct {
+	UserID string `json:"user_id" example:"60f1a7c9e113d70001234567"`
+	Role   string `json:"role" example:"contributor" enums:"viewer,contributor,admin"`
+}
+
+// ProjectResponse represents a project in API responses
+type ProjectResponse struct {
+	ID          string                  `json:"id" example:"60f1a7c9e113d70001234700"`
+	Key         string                  `json:"key,omitempty" example:"WR"`
+	Name        string                  `json:"name" example:"Website relaunch"`
+	Description string                  `json:"description" example:"Everything for the new marketing site"`
+	Members     []ProjectMemberResponse `json:"members"`
+	WIPLimits   WIPLimits               `json:"wip_limits"`
+	CreatedBy   string                  `json:"created_by" example:"60f1a7c9e113d70001234567"`
+	CreatedAt   string                  `json:"created_at" example:"Sat, 08 Mar 2025 12:00:00 GMT"`
+	UpdatedAt   string                  `json:"updated_at" example:"Sat, 08 Mar 2025 12:00:00 GMT"`
+
+	// RequireAcceptance makes assignees accept or decline the tasks others assign them
+	RequireAcceptance bool `json:"require_acceptance" example:"false"`
+	// StrictLabels limits the tags of the project\'s tasks to its labels
+	StrictLabels bool `json:"strict_labels" example:"false"`
+}
+
+// ProjectRequest represents the request body for creating or updating a project
+type ProjectRequest struct {
+	Key         string `json:"key,omitempty" example:"WR"` // Prefix of the task keys, set on creation only; derived from the name when empty
+	Name        string `json:"name" example:"Website relaunch"`
+	Description string `json:"description,omitempty" example:"Everything for the new marketing site"`
+	// WIPLimits optionally caps the project's tasks in progress; replaces the current limits when set
+	WIPLimits *WIPLimits `json:"wip_limits,omitempty"`
+	// RequireAcceptance makes assignees accept or decline the tasks others assign them; left as is when omitted on update
+	RequireAcceptance *bool `json:"require_acceptance,omitempty" example:"true"`
+	// StrictLabels limits the tags of the project\'s tasks to its labels; left as is when omitted on update
+	StrictLabels *bool `json:"strict_labels,omitempty" example:"true"`
+}
+
+// WIPLimits represents a project's limits on its tasks in progress; zero limits are off
+type WIPLimits struct {
+	Project int    `json:"project" example:"10" minimum:"0"` // Tasks in progress in the project
+	PerUser int    `json:"per_user" example:"3" minimum:"0"` // Tasks in progress in the project assigned to any one user
+	Mode    string `json:"mode,omitempty" example:"reject" enums:"reject,warn"`
+}
+
+// ProjectWIPResponse represents a project's WIP limits and its tasks in progress
+type ProjectWIPResponse struct {
+	Limits     WIPLimits `json:"limits"`
+	InProgress int64     `json:"in_progress" example:"7"`
+	// PerUser counts the tasks in progress assigned to each user, by user ID
+	PerUser map[string]int64 `json:"per_user"`
+}
+
+// CreateProject godoc
+// @Summary Create a project
+// @Description Create a project in the organization. The creator becomes its admin. Its tasks are numbered with keys such as WR-1, using the project key.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param project body ProjectRequest true "Project information"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=ProjectResponse} "Project created successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 409 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project key taken"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects [post]
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create project
+	project, err := h.projectUseCase.CreateProject(&usecase.CreateProjectInput{
+		OrgID:             orgID,
+		Key:               req.Key,
+		Name:              req.Name,
+		Description:       req.Description,
+		WIPLimits:         requestedWIPLimits(req.WIPLimits),
+		RequireAcceptance: req.RequireAcceptance != nil && *req.RequireAcceptance,
+		StrictLabels:      req.StrictLabels != nil && *req.StrictLabels,
+		CreatedBy:         userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Unauthorized")
+		return
+	}
+
+	// Return created project
+	httpUtils.SetLocation(w, r, project.ID.Hex())
+	httpUtils.RespondWithJSON(w, http.StatusCreated, projectResponse(project))
+}
+
+// ListProjects godoc
+// @Summary List projects
+// @Description List the projects the caller is a member of. Organization admins see every project of the organization.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]ProjectResponse} "Projects retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of projects"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects [get]
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get projects
+	projects, err := h.projectUseCase.ListProjects(orgID, userID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	resp := make([]ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		resp = append(resp, projectResponse(project))
+	}
+
+	// Return projects
+	httpUtils.RespondWithList(w, http.StatusOK, resp, int64(len(resp)))
+}
+
+// GetProject godoc
+// @Summary Get a project
+// @Description Get a project the caller is a member of
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=ProjectResponse} "Project retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id} [get]
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get project
+	project, err := h.projectUseCase.GetProject(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Unauthorized")
+		return
+	}
+
+	// Return project
+	httpUtils.RespondWithJSON(w, http.StatusOK, projectResponse(project))
+}
+
+// UpdateProject godoc
+// @Summary Update a project
+// @Description Change a project's name, description, WIP limits, whether assignees must accept the tasks others assign them, or whether task tags are limited to the project's labels. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param project body ProjectRequest true "Updated project information"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=ProjectResponse} "Project updated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id} [put]
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Update project
+	project, err := h.projectUseCase.UpdateProject(&usecase.UpdateProjectInput{
+		OrgID:             orgID,
+		ID:                projectID,
+		Name:              req.Name,
+		Description:       req.Description,
+		WIPLimits:         requestedWIPLimits(req.WIPLimits),
+		RequireAcceptance: req.RequireAcceptance,
+		StrictLabels:      req.StrictLabels,
+		UpdatedBy:         userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can update the project")
+		return
+	}
+
+	// Return updated project
+	httpUtils.RespondWithJSON(w, http.StatusOK, projectResponse(project))
+}
+
+// GetProjectWIP godoc
+// @Summary Get a project's WIP
+// @Description Get the limits on a project's tasks in progress and how many are in progress, overall and per assignee. Putting a task in progress, or assigning one that is, beyond a limit is rejected or, in warn mode, answered with a warning on the task.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=ProjectWIPResponse} "WIP retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/wip [get]
+func (h *ProjectHandler) GetProjectWIP(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get WIP
+	wip, err := h.projectUseCase.GetProjectWIP(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Unauthorized")
+		return
+	}
+
+	// Return WIP
+	httpUtils.RespondWithJSON(w, http.StatusOK, ProjectWIPResponse{
+		Limits:     wipLimits(wip.Limits),
+		InProgress: wip.InProgress,
+		PerUser:    wip.PerUser,
+	})
+}
+
+// AutoAssignRule represents a project's auto-assignment rule
+type AutoAssignRule struct {
+	ID          string                    `json:"id,omitempty" example:"60f1a7c9e113d70001234950"`
+	Tag         string                    `json:"tag,omitempty" example:"backend"`
+	MinPriority int                       `json:"min_priority,omitempty" example:"3" minimum:"0" maximum:"5"`
+	Strategy    domain.AutoAssignStrategy `json:"strategy" example:"round_robin" enums:"round_robin,least_loaded"`
+	Candidates  []string                  `json:"candidates,omitempty"`
+}
+
+// AutoAssignRulesRequest represents the request body for replacing a project's auto-assignment rules
+type AutoAssignRulesRequest struct {
+	Rules []AutoAssignRule `json:"rules"`
+}
+
+// GetAutoAssignRules godoc
+// @Summary Get a project's auto-assignment rules
+// @Description Get the rules assigning new, unassigned tasks of a project the caller is a member of, in the order they are checked
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]AutoAssignRule} "Rules retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/auto-assign [get]
+func (h *ProjectHandler) GetAutoAssignRules(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get rules
+	rules, err := h.projectUseCase.GetAutoAssignRules(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Unauthorized")
+		return
+	}
+
+	// Return rules
+	httpUtils.RespondWithJSON(w, http.StatusOK, autoAssignRules(rules))
+}
+
+// SetAutoAssignRules godoc
+// @Summary Replace a project's auto-assignment rules
+// @Description Replace the rules assigning new, unassigned tasks of a project. The first rule a task matches, by tag and minimum priority, assigns it to one of its candidates, taken in turn (round_robin) or by fewest open tasks in the project (least_loaded). Rules without candidates pick from the project's contributors and admins. Replacing the rules restarts their turns. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param rules body AutoAssignRulesRequest true "Rules, in the order they are checked"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]AutoAssignRule} "Rules replaced successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/auto-assign [put]
+func (h *ProjectHandler) SetAutoAssignRules(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req AutoAssignRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	inputs := make([]usecase.AutoAssignRuleInput, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		inputs = append(inputs, usecase.AutoAssignRuleInput{
+			Tag:         rule.Tag,
+			MinPriority: rule.MinPriority,
+			Strategy:    rule.Strategy,
+			Candidates:  rule.Candidates,
+		})
+	}
+
+	// Replace rules
+	rules, err := h.projectUseCase.SetAutoAssignRules(&usecase.SetAutoAssignRulesInput{
+		OrgID:     orgID,
+		ProjectID: projectID,
+		Rules:     inputs,
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can change auto-assignment rules")
+		return
+	}
+
+	// Return rules
+	httpUtils.RespondWithJSON(w, http.StatusOK, autoAssignRules(rules))
+}
+
+// DeleteProject godoc
+// @Summary Delete a project
+// @Description Delete a project that has no tasks left. Only project admins may do so.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project still has tasks"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Delete project
+	if err := h.projectUseCase.DeleteProject(orgID, projectID, userID, clientInfo(r)); err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can delete the project")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetProjectMemberRequest represents the request body for adding a project member or changing their role
+type SetProjectMemberRequest struct {
+	Role domain.ProjectRole `json:"role" example:"contributor" enums:"viewer,contributor,admin"`
+}
+
+// SetProjectMember godoc
+// @Summary Add a project member or change their role
+// @Description Give a user of the organization a role in the project. Only project admins may do so, and the project always keeps at least one admin.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param userId path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Param member body SetProjectMemberRequest true "Project role"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=ProjectResponse} "Member updated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project or user not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/members/{userId} [put]
+func (h *ProjectHandler) SetProjectMember(w http.ResponseWriter, r *http.Request) {
+	// Get project and member IDs from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	memberID := vars["userId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req SetProjectMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Set member role
+	project, err := h.projectUseCase.SetProjectMember(&usecase.SetProjectMemberInput{
+		OrgID:     orgID,
+		ProjectID: projectID,
+		MemberID:  memberID,
+		Role:      req.Role,
+		UpdatedBy: userID,
+		Client:    clientInfo(r),
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project or user not found", "Only project admins can manage members")
+		return
+	}
+
+	// Return updated project
+	httpUtils.RespondWithJSON(w, http.StatusOK, projectResponse(project))
+}
+
+// RemoveProjectMember godoc
+// @Summary Remove a project member
+// @Description Remove a user from the project. Project admins may remove anyone and members may remove themselves; the project always keeps at least one admin.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param userId path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project or member not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/members/{userId} [delete]
+func (h *ProjectHandler) RemoveProjectMember(w http.ResponseWriter, r *http.Request) {
+	// Get project and member IDs from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	memberID := vars["userId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Remove member
+	if err := h.projectUseCase.RemoveProjectMember(orgID, projectID, memberID, userID, clientInfo(r)); err != nil {
+		respondWithOrganizationError(w, err, "Project or member not found", "Only project admins can remove other members")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// projectResponse converts a domain project to its API representation
+func projectResponse(project *domain.Project) ProjectResponse {
+	members := make([]ProjectMemberResponse, 0, len(project.Members))
+	for _, member := range project.Members {
+		members = append(members, ProjectMemberResponse{
+			UserID: member.UserID.Hex(),
+			Role:   string(member.Role),
+		})
+	}
+
+	return ProjectResponse{
+		ID:                project.ID.Hex(),
+		Key:               project.Key,
+		Name:              project.Name,
+		Description:       project.Description,
+		Members:           members,
+		WIPLimits:         wipLimits(project.WIPLimits),
+		RequireAcceptance: project.RequireAcceptance,
+		StrictLabels:      project.StrictLabels,
+		CreatedBy:         project.CreatedBy.Hex(),
+		CreatedAt:         project.CreatedAt.Format(http.TimeFormat),
+		UpdatedAt:         project.UpdatedAt.Format(http.TimeFormat),
+	}
+}
+
+// wipLimits converts domain WIP limits to their API representation
+func wipLimits(limits domain.WIPLimits) WIPLimits {
+	return WIPLimits{
+		Project: limits.Project,
+		PerUser: limits.PerUser,
+		Mode:    string(limits.Mode),
+	}
+}
+
+// requestedWIPLimits converts requested WIP limits to domain limits, or nil when none are given
+func requestedWIPLimits(l *WIPLimits) *domain.WIPLimits {
+	if l == nil {
+		return nil
+	}
+	return &domain.WIPLimits{
+		Project: l.Project,
+		PerUser: l.PerUser,
+		Mode:    domain.WIPLimitMode(l.Mode),
+	}
+}
+
+// autoAssignRules converts domain auto-assignment rules to their API representation
+func autoAssignRules(rules []domain.AutoAssignRule) []AutoAssignRule {
+	result := make([]AutoAssignRule, 0, len(rules))
+	for _, rule := range rules {
+		candidates := make([]string, 0, len(rule.Candidates))
+		for _, id := range rule.Candidates {
+			candidates = append(candidates, id.Hex())
+		}
+		result = append(result, AutoAssignRule{
+			ID:          rule.ID.Hex(),
+			Tag:         rule.Tag,
+			MinPriority: rule.MinPriority,
+			Strategy:    rule.Strategy,
+			Candidates:  candidates,
+		})
+	}
+	return result
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// ReminderHandler handles HTTP requests for task reminders
+type ReminderHandler struct {
+	reminderUseCase *usecase.ReminderUseCase
+}
+
+// NewReminderHandler creates a new reminder handler
+func NewReminderHandler(reminderUseCase *usecase.ReminderUseCase) *ReminderHandler {
+	return &ReminderHandler{
+		reminderUseCase: reminderUseCase,
+	}
+}
+
+// CreateReminderRequest represents the request body for setting a reminder.
+// Exactly one of remind_at and before_due_minutes must be set.
+type CreateReminderRequest struct {
+	RemindAt         time.Time `json:"remind_at,omitempty" example:"2025-03-10T09:00:00Z"`
+	BeforeDueMinutes *int      `json:"before_due_minutes,omitempty" example:"60" minimum:"0" maximum:"43200"`
+}
+
+// CreateReminder godoc
+// @Summary Set a reminder on a task
+// @Description Remind the authenticated user of an open task at a fixed time (remind_at), at most a year ahead, or a number of minutes before it is due (before_due_minutes), at most 30 days. Reminders relative to the due date move with it. Reminders are delivered over the channels the user routes due_soon notifications to. A task can have at most 10 reminders per user.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Param reminder body CreateReminderRequest true "When to remind"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=domain.TaskReminder} "Reminder set successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/reminders [post]
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Set reminder
+	reminder, err := h.reminderUseCase.CreateReminder(&usecase.CreateReminderInput{
+		OrgID:            orgID,
+		TaskID:           taskID,
+		UserID:           userID,
+		RemindAt:         req.RemindAt,
+		BeforeDueMinutes: req.BeforeDueMinutes,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "Forbidden")
+		return
+	}
+
+	// Return reminder
+	httpUtils.RespondWithJSON(w, http.StatusCreated, reminder)
+}
+
+// ListTaskReminders godoc
+// @Summary List the reminders of a task
+// @Description Get the authenticated user's reminders of a task, soonest first. Reminders relative to the due date of a task that no longer has one come last, without remind_at.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.TaskReminder} "Reminders retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of reminders"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/reminders [get]
+func (h *ReminderHandler) ListTaskReminders(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get reminders
+	reminders, err := h.reminderUseCase.ListTaskReminders(orgID, taskID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "Forbidden")
+		return
+	}
+
+	// Return reminders
+	httpUtils.RespondWithList(w, http.StatusOK, reminders, int64(len(reminders)))
+}
+
+// DeleteReminder godoc
+// @Summary Delete a reminder
+// @Description Delete one of the authenticated user's reminders of a task
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Param reminderId path string true "Reminder ID" example:"60f1a7c9e113d70001abcdf0"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid reminder ID"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task or reminder not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/reminders/{reminderId} [delete]
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	// Get task and reminder IDs from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	reminderID := vars["reminderId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Delete reminder
+	if err := h.reminderUseCase.DeleteReminder(orgID, taskID, reminderID, userID); err != nil {
+		respondWithOrganizationError(w, err, "Reminder not found", "Forbidden")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListReminders godoc
+// @Summary List upcoming reminders
+// @Description Get the authenticated user's upcoming reminders across tasks, soonest first
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.TaskReminder} "Reminders retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of reminders"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/reminders [get]
+func (h *ReminderHandler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get reminders
+	reminders, err := h.reminderUseCase.ListReminders(orgID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Forbidden")
+		return
+	}
+
+	// Return reminders
+	httpUtils.RespondWithList(w, http.StatusOK, reminders, int64(len(reminders)))
+}
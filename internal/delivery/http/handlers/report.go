@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+//go:embed templates/project_report.html.tmpl
+var projectReportHTMLText string
+
+//go:embed templates/project_report.txt.tmpl
+var projectReportPDFText string
+
+var (
+	projectReportHTMLTemplate = htmlTemplate.Must(htmlTemplate.New("project_report").Parse(projectReportHTMLText))
+	projectReportPDFTemplate  = textTemplate.Must(textTemplate.New("project_report").Parse(projectReportPDFText))
+)
+
+// Formats of printed dates and times in reports
+const (
+	reportDateFormat = "Mon Jan 2, 2006"
+	reportTimeFormat = "Mon Jan 2, 2006 15:04 MST"
+)
+
+// reportView is the data rendered by the project report templates
+type reportView struct {
+	Title         string
+	Description   string
+	Generated     string
+	Days          int
+	Total         int
+	ByStatus      []reportStatusView
+	Overdue       []reportTaskView
+	OverdueTotal  int
+	OverdueMore   int
+	Upcoming      []reportTaskView
+	UpcomingTotal int
+	UpcomingMore  int
+}
+
+// reportStatusView is a line of the report's status breakdown
+type reportStatusView struct {
+	Status  domain.TaskStatus
+	Count   int
+	Percent int
+}
+
+// reportTaskView is a task listed in the report
+type reportTaskView struct {
+	Key       string
+	Title     string
+	Due       string
+	Overdue   string // How long ago the task was due, for overdue tasks
+	Status    domain.TaskStatus
+	Priority  int
+	Assignees string
+}
+
+// newReportView prepares a project report for rendering, with dates in the
+// report's time zone
+func newReportView(report *usecase.ProjectReport) *reportView {
+	loc := report.Location
+	view := &reportView{
+		Title:         fmt.Sprintf("%s (%s) status report", report.Project.Name, report.Project.Key),
+		Description:   report.Project.Description,
+		Generated:     report.GeneratedAt.In(loc).Format(reportTimeFormat),
+		Days:          report.Days,
+		Total:         report.Total,
+		OverdueTotal:  report.OverdueTotal,
+		OverdueMore:   report.OverdueTotal - len(report.Overdue),
+		UpcomingTotal: report.UpcomingTotal,
+		UpcomingMore:  report.UpcomingTotal - len(report.Upcoming),
+	}
+
+	for _, count := range report.ByStatus {
+		percent := 0
+		if report.Total > 0 {
+			percent = (count.Count*100 + report.Total/2) / report.Total
+		}
+		view.ByStatus = append(view.ByStatus, reportStatusView{Status: count.Status, Count: count.Count, Percent: percent})
+	}
+
+	for _, task := range report.Overdue {
+		view.Overdue = append(view.Overdue, newReportTaskView(task, loc, report.GeneratedAt))
+	}
+	for _, task := range report.Upcoming {
+		view.Upcoming = append(view.Upcoming, newReportTaskView(task, loc, report.GeneratedAt))
+	}
+	return view
+}
+
+// newReportTaskView prepares a task for listing in a report
+func newReportTaskView(task *domain.Task, loc *time.Location, now time.Time) reportTaskView {
+	view := reportTaskView{
+		Key:      task.Key,
+		Title:    task.Title,
+		Due:      task.DueDate.In(loc).Format(reportDateFormat),
+		Status:   task.Status,
+		Priority: task.Priority,
+	}
+
+	if task.DueDate.Before(now) {
+		switch days := int(now.Sub(task.DueDate).Hours() / 24); days {
+		case 0:
+			view.Overdue = "today"
+		case 1:
+			view.Overdue = "1 day ago"
+		default:
+			view.Overdue = fmt.Sprintf("%d days ago", days)
+		}
+	}
+
+	names := make([]string, 0, len(task.AssignedTo))
+	if len(task.Assignees) > 0 {
+		for _, assignee := range task.Assignees {
+			names = append(names, assignee.Username)
+		}
+	} else {
+		for _, id := range task.AssignedTo {
+			names = append(names, id.Hex())
+		}
+	}
+	view.Assignees = strings.Join(names, ", ")
+
+	return view
+}
+
+// writeReportHTML renders a project report as a standalone HTML page, styled for printing
+func writeReportHTML(b *bytes.Buffer, report *usecase.ProjectReport) error {
+	return projectReportHTMLTemplate.Execute(b, newReportView(report))
+}
+
+// writeReportPDF renders a project report as a PDF document
+func writeReportPDF(b *bytes.Buffer, report *usecase.ProjectReport) error {
+	view := newReportView(report)
+
+	var text strings.Builder
+	if err := projectReportPDFTemplate.Execute(&text, view); err != nil {
+		return err
+	}
+
+	writePDF(b, view.Title, text.String())
+	return nil
+}
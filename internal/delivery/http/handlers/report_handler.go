@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/logger"
+	"task-management-system/internal/usecase"
+)
+
+// ReportHandler handles HTTP requests for printable project reports
+type ReportHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+	log            logger.Logger
+}
+
+// NewReportHandler creates a new report handler logging failures to the given logger
+func NewReportHandler(projectUseCase *usecase.ProjectUseCase, log logger.Logger) *ReportHandler {
+	return &ReportHandler{
+		projectUseCase: projectUseCase,
+		log:            log,
+	}
+}
+
+// GetProjectReport godoc
+// @Summary Get a project status report
+// @Description Get a printable summary of a project the caller is a member of, for status meetings: the number of tasks in each status, the open tasks that are overdue, and the open tasks due in the next days. Each list shows at most 100 tasks. Dates are in the caller's time zone. The report is an HTML page styled for printing, or a PDF document.
+// @Tags projects
+// @Produce html
+// @Produce application/pdf
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param format query string false "Report format" Enums(html, pdf) default(html)
+// @Param days query int false "Number of days ahead to list upcoming deadlines for" minimum(1) maximum(90) default(7)
+// @Success 200 {string} string "Project report"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/report [get]
+func (h *ReportHandler) GetProjectReport(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	projectID := mux.Vars(r)["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = "html"
+	}
+	if format != "html" && format != "pdf" {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "format must be html or pdf")
+		return
+	}
+
+	days := 0
+	if value := query.Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			httpUtils.RespondWithError(w, http.StatusBadRequest, "days must be a number")
+			return
+		}
+		days = parsed
+	}
+
+	// Build the report
+	report, err := h.projectUseCase.ProjectReport(orgID, projectID, userID, days)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Forbidden")
+		return
+	}
+
+	// Render it in full before answering, so a failure can still be reported
+	var body bytes.Buffer
+	contentType := "text/html; charset=utf-8"
+	if format == "pdf" {
+		contentType = "application/pdf"
+		err = writeReportPDF(&body, report)
+	} else {
+		err = writeReportHTML(&body, report)
+	}
+	if err != nil {
+		h.log.ErrorF("Failed to render report of project %s: %v", projectID, err)
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	filename := fmt.Sprintf("%s-report-%s.%s", report.Project.Key, report.GeneratedAt.In(report.Location).Format("20060102"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": filename}))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := body.WriteTo(w); err != nil {
+		h.log.WarnF("Failed to send report of project %s: %v", projectID, err)
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// SessionHandler handles HTTP requests for the authenticated user's sessions
+type SessionHandler struct {
+	authUseCase *usecase.AuthUseCase
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(authUseCase *usecase.AuthUseCase) *SessionHandler {
+	return &SessionHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+// SessionResponse represents a signed-in session in API responses
+type SessionResponse struct {
+	ID         string `json:"id" example:"60f1a7c9e113d70001234800"`
+	Device     string `json:"device" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_3) AppleWebKit/605.1.15"`
+	IP         string `json:"ip" example:"203.0.113.7"`
+	Current    bool   `json:"current" example:"true"`
+	CreatedAt  string `json:"created_at" example:"Sat, 08 Mar 2025 12:00:00 GMT"`
+	LastSeenAt string `json:"last_seen_at" example:"Sat, 08 Mar 2025 14:30:00 GMT"`
+	ExpiresAt  string `json:"expires_at" example:"Sun, 09 Mar 2025 12:00:00 GMT"`
+	// ImpersonatedBy is the organization admin acting as the user in the session
+	ImpersonatedBy string `json:"impersonated_by,omitempty" example:"60f1a7c9e113d70001234567"`
+}
+
+// ListSessions godoc
+// @Summary List sessions
+// @Description List the authenticated user's active sessions, most recently seen first. The session of the calling token is marked as current.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]SessionResponse} "Sessions retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of active sessions"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/sessions [get]
+func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get session ID from context (set by auth middleware)
+	sessionID, _ := r.Context().Value("sessionID").(string)
+
+	// Get sessions
+	sessions, err := h.authUseCase.ListSessions(userID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	resp := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, sessionResponse(session, sessionID))
+	}
+
+	// Return sessions
+	httpUtils.RespondWithList(w, http.StatusOK, resp, int64(len(resp)))
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Sign out one of the authenticated user's sessions. Its tokens stop working immediately; revoking the current session signs the caller out.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Session ID" example:"60f1a7c9e113d70001234800"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Session not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	// Get session ID from URL
+	vars := mux.Vars(r)
+	sessionID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Revoke session
+	if err := h.authUseCase.RevokeSession(userID, sessionID); err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Session not found")
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionResponse converts a domain session to its API representation
+func sessionResponse(session *domain.Session, currentID string) SessionResponse {
+	resp := SessionResponse{
+		ID:         session.ID.Hex(),
+		Device:     session.UserAgent,
+		IP:         session.IP,
+		Current:    session.ID.Hex() == currentID,
+		CreatedAt:  session.CreatedAt.Format(http.TimeFormat),
+		LastSeenAt: session.LastSeenAt.Format(http.TimeFormat),
+		ExpiresAt:  session.ExpiresAt.Format(http.TimeFormat),
+	}
+	if session.IsImpersonation() {
+		resp.ImpersonatedBy = session.ImpersonatorID.Hex()
+	}
+	return resp
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// SnoozeHandler handles HTTP requests for snoozed tasks
+type SnoozeHandler struct {
+	taskUseCase *usecase.TaskUseCase
+}
+
+// NewSnoozeHandler creates a new snooze handler
+func NewSnoozeHandler(taskUseCase *usecase.TaskUseCase) *SnoozeHandler {
+	return &SnoozeHandler{
+		taskUseCase: taskUseCase,
+	}
+}
+
+// SnoozeRequest represents the request body for snoozing a task
+type SnoozeRequest struct {
+	Until time.Time `json:"until" example:"2025-03-10T09:00:00Z"`
+}
+
+// SnoozeResponse represents a task snooze in API responses
+type SnoozeResponse struct {
+	TaskID string    `json:"task_id" example:"60f1a7c9e113d70001abcdef"`
+	Until  time.Time `json:"until" example:"2025-03-10T09:00:00Z"`
+}
+
+// SnoozeTask godoc
+// @Summary Snooze a task
+// @Description Hide a task from the authenticated user's own task list and daily digest until the given time, at most a year ahead. When the snooze ends the user is notified that the task is back. Snoozing a snoozed task moves the end of the snooze.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Param snooze body SnoozeRequest true "End of the snooze"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=SnoozeResponse} "Task snoozed successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/snooze [post]
+func (h *SnoozeHandler) SnoozeTask(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req SnoozeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Snooze task
+	snooze, err := h.taskUseCase.SnoozeTask(&usecase.SnoozeTaskInput{
+		OrgID:  orgID,
+		TaskID: taskID,
+		UserID: userID,
+		Until:  req.Until,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "Forbidden")
+		return
+	}
+
+	// Return snooze
+	httpUtils.RespondWithJSON(w, http.StatusOK, SnoozeResponse{
+		TaskID: snooze.TaskID.Hex(),
+		Until:  snooze.Until,
+	})
+}
+
+// UnsnoozeTask godoc
+// @Summary Unsnooze a task
+// @Description End the authenticated user's snooze of a task right away
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task is not snoozed"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/snooze [delete]
+func (h *SnoozeHandler) UnsnoozeTask(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Unsnooze task
+	if err := h.taskUseCase.UnsnoozeTask(orgID, taskID, userID); err != nil {
+		respondWithOrganizationError(w, err, "Task is not snoozed", "Forbidden")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSnoozedTasks godoc
+// @Summary Get snoozed tasks
+// @Description Get the tasks snoozed by the authenticated user, ending soonest first, each with the end of its snooze in snoozed_until
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.Task} "Snoozed tasks retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of snoozed tasks"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/snoozed [get]
+func (h *SnoozeHandler) GetSnoozedTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get snoozed tasks
+	tasks, err := h.taskUseCase.GetSnoozedTasks(orgID, userID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Return tasks
+	httpUtils.RespondWithList(w, http.StatusOK, tasks, int64(len(tasks)))
+}
@@ -0,0 +1,478 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// SprintHandler handles HTTP requests for project sprints and sprint planning
+type SprintHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+	taskUseCase    *usecase.TaskUseCase
+}
+
+// NewSprintHandler creates a new sprint handler
+func NewSprintHandler(projectUseCase *usecase.ProjectUseCase, taskUseCase *usecase.TaskUseCase) *SprintHandler {
+	return &SprintHandler{
+		projectUseCase: projectUseCase,
+		taskUseCase:    taskUseCase,
+	}
+}
+
+// SprintRequest represents the request body for creating a sprint
+type SprintRequest struct {
+	Name      string    `json:"name" example:"Sprint 12"`
+	Goal      string    `json:"goal,omitempty" example:"Launch the new checkout"`
+	StartDate time.Time `json:"start_date" example:"2025-03-03T09:00:00Z"`
+	EndDate   time.Time `json:"end_date" example:"2025-03-14T17:00:00Z"`
+}
+
+// SprintResponse represents a sprint in API responses
+type SprintResponse struct {
+	ID        string    `json:"id" example:"60f1a7c9e113d70001234900"`
+	ProjectID string    `json:"project_id" example:"60f1a7c9e113d70001234700"`
+	Name      string    `json:"name" example:"Sprint 12"`
+	Goal      string    `json:"goal,omitempty" example:"Launch the new checkout"`
+	StartDate time.Time `json:"start_date" example:"2025-03-03T09:00:00Z"`
+	EndDate   time.Time `json:"end_date" example:"2025-03-14T17:00:00Z"`
+	Closed    bool      `json:"closed" example:"false"`
+	ClosedAt  string    `json:"closed_at,omitempty" example:"Fri, 14 Mar 2025 17:00:00 GMT"`
+	CreatedBy string    `json:"created_by" example:"60f1a7c9e113d70001234567"`
+	CreatedAt string    `json:"created_at" example:"Sat, 01 Mar 2025 12:00:00 GMT"`
+	UpdatedAt string    `json:"updated_at" example:"Sat, 01 Mar 2025 12:00:00 GMT"`
+}
+
+// CloseSprintRequest represents the optional request body for closing a sprint
+type CloseSprintRequest struct {
+	// NextSprintID is the open sprint incomplete tasks roll forward to; the project's next open sprint when empty
+	NextSprintID string `json:"next_sprint_id,omitempty" example:"60f1a7c9e113d70001234901"`
+}
+
+// CloseSprintResponse summarizes a closed sprint
+type CloseSprintResponse struct {
+	Sprint       SprintResponse `json:"sprint"`
+	Completed    int64          `json:"completed" example:"8"`
+	RolledOver   int            `json:"rolled_over" example:"3"`
+	NextSprintID string         `json:"next_sprint_id,omitempty" example:"60f1a7c9e113d70001234901"`
+}
+
+// CreateSprint godoc
+// @Summary Create a sprint
+// @Description Create a sprint in a project. Only project admins may do so.
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Param sprint body SprintRequest true "Sprint information"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=SprintResponse} "Sprint created successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/sprints [post]
+func (h *SprintHandler) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req SprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create sprint
+	sprint, err := h.projectUseCase.CreateSprint(&usecase.CreateSprintInput{
+		OrgID:     orgID,
+		ProjectID: projectID,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Only project admins can create sprints")
+		return
+	}
+
+	// Return created sprint
+	httpUtils.RespondWithJSON(w, http.StatusCreated, sprintResponse(sprint))
+}
+
+// ListSprints godoc
+// @Summary List a project's sprints
+// @Description List the sprints of a project the caller is a member of, by start date
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Project ID" example:"60f1a7c9e113d70001234700"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]SprintResponse} "Sprints retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of sprints"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /projects/{id}/sprints [get]
+func (h *SprintHandler) ListSprints(w http.ResponseWriter, r *http.Request) {
+	// Get project ID from URL
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get sprints
+	sprints, err := h.projectUseCase.ListSprints(orgID, projectID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Project not found", "Unauthorized")
+		return
+	}
+
+	resp := make([]SprintResponse, 0, len(sprints))
+	for _, sprint := range sprints {
+		resp = append(resp, sprintResponse(sprint))
+	}
+
+	// Return sprints
+	httpUtils.RespondWithList(w, http.StatusOK, resp, int64(len(resp)))
+}
+
+// GetSprint godoc
+// @Summary Get a sprint
+// @Description Get a sprint of a project the caller is a member of
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Sprint ID" example:"60f1a7c9e113d70001234900"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=SprintResponse} "Sprint retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /sprints/{id} [get]
+func (h *SprintHandler) GetSprint(w http.ResponseWriter, r *http.Request) {
+	// Get sprint ID from URL
+	vars := mux.Vars(r)
+	sprintID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get sprint
+	sprint, err := h.projectUseCase.GetSprint(orgID, sprintID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Sprint not found", "Unauthorized")
+		return
+	}
+
+	// Return sprint
+	httpUtils.RespondWithJSON(w, http.StatusOK, sprintResponse(sprint))
+}
+
+// ListSprintTasks godoc
+// @Summary List a sprint's tasks
+// @Description List the tasks planned into a sprint, with the same list fields as the task list. Closed sprints list the tasks completed in them.
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Sprint ID" example:"60f1a7c9e113d70001234900"
+// @Param status query string false "Filter tasks by status" Enums(pending, in_progress, completed)
+// @Param limit query int false "Maximum number of tasks per page; defaults to pagination.default_page_size, and larger sizes are lowered to pagination.max_page_size"
+// @Param after query string false "List the page after this task ID"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.Task} "Tasks retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of tasks"
+// @Header 200 {integer} X-Page-Size "Page size applied; a page holding fewer tasks is the last one"
+// @Header 200 {boolean} X-Page-Size-Clamped "Set when the requested limit exceeded the maximum and was lowered to it"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid page"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /sprints/{id}/tasks [get]
+func (h *SprintHandler) ListSprintTasks(w http.ResponseWriter, r *http.Request) {
+	// Get sprint ID from URL
+	vars := mux.Vars(r)
+	sprintID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Check the sprint is visible before listing its tasks
+	if _, err := h.projectUseCase.GetSprint(orgID, sprintID, userID); err != nil {
+		respondWithOrganizationError(w, err, "Sprint not found", "Unauthorized")
+		return
+	}
+
+	// Get a page of tasks
+	query := r.URL.Query()
+	limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+	input := &usecase.ListTasksInput{
+		OrgID:    orgID,
+		UserID:   userID,
+		Status:   domain.TaskStatus(query.Get("status")),
+		SprintID: sprintID,
+		Limit:    limit,
+		After:    query.Get("after"),
+	}
+	tasks, page, err := h.taskUseCase.ListTasks(input)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Sprint not found", "Unauthorized")
+		return
+	}
+
+	// A page holds only some of the sprint's tasks
+	total, err := h.taskUseCase.CountTasks(input)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Sprint not found", "Unauthorized")
+		return
+	}
+
+	// Return tasks
+	httpUtils.RespondWithPage(w, http.StatusOK, tasks, total, page.Size, page.Clamped)
+}
+
+// AddSprintTask godoc
+// @Summary Add a task to a sprint
+// @Description Plan a task of the sprint's project into an open sprint, moving it out of any other sprint. Project contributors who may change the task may do so.
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Sprint ID" example:"60f1a7c9e113d70001234900"
+// @Param taskId path string true "Task ID" example:"60f1a7c9e113d70001234568"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Task added to the sprint"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint closed or task of another project"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint or task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /sprints/{id}/tasks/{taskId} [put]
+func (h *SprintHandler) AddSprintTask(w http.ResponseWriter, r *http.Request) {
+	// Get sprint and task IDs from URL
+	vars := mux.Vars(r)
+	sprintID := vars["id"]
+	taskID := vars["taskId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Add task
+	task, err := h.projectUseCase.AddSprintTask(&usecase.SprintTaskInput{
+		OrgID:    orgID,
+		SprintID: sprintID,
+		TaskID:   taskID,
+		UserID:   userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Sprint or task not found", "Only project contributors who can change the task can plan it")
+		return
+	}
+
+	// Return planned task
+	httpUtils.RespondWithJSON(w, http.StatusOK, task)
+}
+
+// RemoveSprintTask godoc
+// @Summary Remove a task from a sprint
+// @Description Take a task out of an open sprint, back to the project's backlog. Project contributors who may change the task may do so.
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Sprint ID" example:"60f1a7c9e113d70001234900"
+// @Param taskId path string true "Task ID" example:"60f1a7c9e113d70001234568"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint closed"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint not found or task not in the sprint"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /sprints/{id}/tasks/{taskId} [delete]
+func (h *SprintHandler) RemoveSprintTask(w http.ResponseWriter, r *http.Request) {
+	// Get sprint and task IDs from URL
+	vars := mux.Vars(r)
+	sprintID := vars["id"]
+	taskID := vars["taskId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Remove task
+	if err := h.projectUseCase.RemoveSprintTask(&usecase.SprintTaskInput{
+		OrgID:    orgID,
+		SprintID: sprintID,
+		TaskID:   taskID,
+		UserID:   userID,
+	}); err != nil {
+		respondWithOrganizationError(w, err, "Task not found in the sprint", "Only project contributors who can change the task can plan it")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CloseSprint godoc
+// @Summary Close a sprint
+// @Description Close an open sprint. Its incomplete tasks roll forward to the given sprint or, when none is given, to the project's next open sprint by start date; without one, they go back to the backlog. Completed tasks stay in the closed sprint. Only project admins may do so.
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Sprint ID" example:"60f1a7c9e113d70001234900"
+// @Param close body CloseSprintRequest false "Sprint to roll incomplete tasks forward to"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=CloseSprintResponse} "Sprint closed"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint already closed or invalid next sprint"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Sprint not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /sprints/{id}/close [post]
+func (h *SprintHandler) CloseSprint(w http.ResponseWriter, r *http.Request) {
+	// Get sprint ID from URL
+	vars := mux.Vars(r)
+	sprintID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse the optional request body
+	var req CloseSprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Close sprint
+	result, err := h.projectUseCase.CloseSprint(&usecase.CloseSprintInput{
+		OrgID:        orgID,
+		ID:           sprintID,
+		NextSprintID: req.NextSprintID,
+		ClosedBy:     userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Sprint not found", "Only project admins can close sprints")
+		return
+	}
+
+	// Return summary
+	httpUtils.RespondWithJSON(w, http.StatusOK, CloseSprintResponse{
+		Sprint:       sprintResponse(result.Sprint),
+		Completed:    result.Completed,
+		RolledOver:   result.RolledOver,
+		NextSprintID: result.NextSprintID,
+	})
+}
+
+// sprintResponse converts a domain sprint to its API representation
+func sprintResponse(sprint *domain.Sprint) SprintResponse {
+	resp := SprintResponse{
+		ID:        sprint.ID.Hex(),
+		ProjectID: sprint.ProjectID.Hex(),
+		Name:      sprint.Name,
+		Goal:      sprint.Goal,
+		StartDate: sprint.StartDate,
+		EndDate:   sprint.EndDate,
+		Closed:    sprint.IsClosed(),
+		CreatedBy: sprint.CreatedBy.Hex(),
+		CreatedAt: sprint.CreatedAt.Format(http.TimeFormat),
+		UpdatedAt: sprint.UpdatedAt.Format(http.TimeFormat),
+	}
+	if sprint.IsClosed() {
+		resp.ClosedAt = sprint.ClosedAt.Format(http.TimeFormat)
+	}
+	return resp
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// StarHandler handles HTTP requests for starred tasks
+type StarHandler struct {
+	starUseCase *usecase.StarUseCase
+}
+
+// NewStarHandler creates a new star handler
+func NewStarHandler(starUseCase *usecase.StarUseCase) *StarHandler {
+	return &StarHandler{
+		starUseCase: starUseCase,
+	}
+}
+
+// StarTask godoc
+// @Summary Star a task
+// @Description Star (pin) a task for the authenticated user. Starring an already starred task has no effect.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/star [post]
+func (h *StarHandler) StarTask(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Star task
+	err := h.starUseCase.StarTask(orgID, taskID, userID)
+	if err != nil {
+		// Handle different error types
+		switch err {
+		case domain.ErrNotFound:
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Task not found")
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnstarTask godoc
+// @Summary Unstar a task
+// @Description Remove the authenticated user's star from a task
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task is not starred"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/star [delete]
+func (h *StarHandler) UnstarTask(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Unstar task
+	err := h.starUseCase.UnstarTask(taskID, userID)
+	if err != nil {
+		// Handle different error types
+		switch err {
+		case domain.ErrNotFound:
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Task is not starred")
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetStarredTasks godoc
+// @Summary Get starred tasks
+// @Description Get the tasks starred by the authenticated user, most recently starred first
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.Task} "Starred tasks retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of starred tasks"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/starred [get]
+func (h *StarHandler) GetStarredTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get starred tasks
+	tasks, err := h.starUseCase.GetStarredTasks(orgID, userID)
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Return tasks
+	httpUtils.RespondWithList(w, http.StatusOK, tasks, int64(len(tasks)))
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"task-management-system/config"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// StatusSignatureHeader carries the HMAC-SHA256 of a status response body, as
+// sha256=<hex>, when a signing key is configured
+const StatusSignatureHeader = "X-Status-Signature"
+
+// StatusHandler handles the public status route
+type StatusHandler struct {
+	statusUseCase *usecase.StatusUseCase
+	maxAge        time.Duration
+	signingKey    []byte
+}
+
+// NewStatusHandler creates a new status handler
+func NewStatusHandler(statusUseCase *usecase.StatusUseCase, cfg config.StatusPageConfig) *StatusHandler {
+	return &StatusHandler{
+		statusUseCase: statusUseCase,
+		maxAge:        cfg.MaxAge,
+		signingKey:    []byte(cfg.SigningKey),
+	}
+}
+
+// GetStatus godoc
+// @Summary Get the service status
+// @Description Get the coarse state of the service for uptime monitors and status pages: ok, or degraded with 503 Service Unavailable when the database does not answer, along with the running version and uptime. No authentication is required, and no other detail is disclosed. Responses may be cached by anyone for the configured max-age, during which the same status is returned, and clients are rate limited separately from the rest of the API. When a signing key is configured, the X-Status-Signature header carries sha256= followed by the hex HMAC-SHA256 of the response body; checked_at lets verifiers reject stale statuses.
+// @Tags status
+// @Produce json
+// @Success 200 {object} httpUtils.ResponseWrapper{data=usecase.ServiceStatus} "Service is up"
+// @Header 200 {string} X-Status-Signature "HMAC-SHA256 of the body, when signing is configured"
+// @Failure 429 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Too many requests"
+// @Failure 503 {object} httpUtils.ResponseWrapper{data=usecase.ServiceStatus} "Service is degraded"
+// @Router /status [get]
+func (h *StatusHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	// Check the service
+	status := h.statusUseCase.GetStatus(time.Now())
+
+	code := http.StatusOK
+	if status.Status != usecase.ServiceStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	// The body is encoded up front so that the signature covers the exact bytes sent
+	body, err := json.Marshal(httpUtils.ResponseWrapper{Success: true, Data: status})
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if len(h.signingKey) > 0 {
+		mac := hmac.New(sha256.New, h.signingKey)
+		mac.Write(body)
+		w.Header().Set(StatusSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	if h.maxAge > 0 {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(h.maxAge.Seconds())))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
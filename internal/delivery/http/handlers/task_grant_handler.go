@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// TaskGrantHandler handles HTTP requests sharing tasks with individual users
+type TaskGrantHandler struct {
+	taskUseCase *usecase.TaskUseCase
+}
+
+// NewTaskGrantHandler creates a new task grant handler
+func NewTaskGrantHandler(taskUseCase *usecase.TaskUseCase) *TaskGrantHandler {
+	return &TaskGrantHandler{
+		taskUseCase: taskUseCase,
+	}
+}
+
+// GrantTaskAccessRequest represents the request body for sharing a task with a user
+type GrantTaskAccessRequest struct {
+	User   string            `json:"user" example:"jane.doe"` // User ID, username, or email
+	Access domain.TaskAccess `json:"access" example:"view" enums:"view,edit"`
+}
+
+// GrantAccess godoc
+// @Summary Share a task with a user
+// @Description Give a user of the organization view or edit access to a task, on top of what their project role or the rules for tasks outside projects allow. Users outside the task's project can be given access too. Edit access lets the user change the task but not assign or delete it. Sharing the task again with the same user replaces their access. A task can be shared with at most 50 users. Only the task's creator may share it.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param grant body GrantTaskAccessRequest true "User and access"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Task shared successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not the task's creator"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task or user not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/grants [post]
+func (h *TaskGrantHandler) GrantAccess(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req GrantTaskAccessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Share the task
+	task, err := h.taskUseCase.GrantTaskAccess(&usecase.GrantTaskAccessInput{
+		OrgID:     orgID,
+		TaskID:    taskID,
+		Grantee:   req.User,
+		Access:    req.Access,
+		GrantedBy: userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, notFoundMessage(err, "Task not found"), "Only the task's creator can share it")
+		return
+	}
+
+	// Return updated task
+	httpUtils.RespondWithJSON(w, http.StatusOK, task)
+}
+
+// RevokeAccess godoc
+// @Summary Stop sharing a task with a user
+// @Description Remove a user's access grant on a task. The user keeps the access their project role or the rules for tasks outside projects give them. Only the task's creator may do so.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param userId path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid user ID"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Not the task's creator"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task or grant not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/grants/{userId} [delete]
+func (h *TaskGrantHandler) RevokeAccess(w http.ResponseWriter, r *http.Request) {
+	// Get task and grantee IDs from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	granteeID := vars["userId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Revoke the grant
+	if err := h.taskUseCase.RevokeTaskAccess(orgID, taskID, granteeID, userID); err != nil {
+		respondWithOrganizationError(w, err, notFoundMessage(err, "Task not found"), "Only the task's creator can stop sharing it")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
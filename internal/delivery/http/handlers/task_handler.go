@@ -2,12 +2,16 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
 	httpUtils "task-management-system/internal/delivery/http/utils"
 	"task-management-system/internal/domain"
+	"task-management-system/internal/markdown"
 	"task-management-system/internal/usecase"
 )
 
@@ -29,22 +33,33 @@ type CreateTaskRequest struct {
 	Description string    `json:"description" example:"Create comprehensive Swagger documentation for the REST API"`
 	Priority    int       `json:"priority" example:"3" minimum:"1" maximum:"5"`
 	DueDate     time.Time `json:"due_date" example:"2025-03-15T15:00:00Z"`
+	ProjectID   string    `json:"project_id,omitempty" example:"60f1a7c9e113d70001234700"`
+	Tags        []string  `json:"tags,omitempty" example:"backend,docs"`
 }
 
 // CreateTask godoc
 // @Summary Create a new task
-// @Description Create a new task with the provided information
+// @Description Create a new task with the provided information. Adding a task to a project requires at least the contributor role in it. Unassigned project tasks are then assigned by the first of the project's auto-assignment rules they match. In projects with strict labels, tags must be labels of the project.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {token}"
 // @Param task body CreateTaskRequest true "Task information"
+// @Param render query string false "Set to html to add the description rendered from Markdown as description_html" Enums(html)
 // @Success 201 {object} httpUtils.ResponseWrapper{data=domain.Task} "Task created successfully"
 // @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
 // @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Insufficient project role"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Project not found"
 // @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
 // @Router /tasks [post]
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	renderHTML, ok := renderDescriptionHTML(r)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "render must be html")
+		return
+	}
+
 	var req CreateTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
@@ -58,6 +73,13 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Create task
 	task, err := h.taskUseCase.CreateTask(&usecase.CreateTaskInput{
 		Title:       req.Title,
@@ -65,13 +87,21 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		Priority:    req.Priority,
 		DueDate:     req.DueDate,
 		CreatedBy:   userID,
+		OrgID:       orgID,
+		ProjectID:   req.ProjectID,
+		Tags:        req.Tags,
+		Client:      clientInfo(r),
 	})
 
 	if err != nil {
 		// Handle different error types
-		switch err {
-		case domain.ErrInvalidInput:
-			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithInvalidInput(w, err)
+		case errors.Is(err, domain.ErrNotFound):
+			httpUtils.RespondWithError(w, http.StatusNotFound, "Project not found")
+		case errors.Is(err, domain.ErrUnauthorized):
+			httpUtils.RespondWithError(w, http.StatusForbidden, "You are not authorized to add tasks to this project")
 		default:
 			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		}
@@ -79,17 +109,22 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return created task
+	if renderHTML {
+		renderDescriptions(task)
+	}
+	httpUtils.SetLocation(w, r, task.ID.Hex())
 	httpUtils.RespondWithJSON(w, http.StatusCreated, task)
 }
 
 // GetTask godoc
-// @Summary Get task by ID
-// @Description Get a task by its ID
+// @Summary Get task by ID or key
+// @Description Get a task by its ID or, for project tasks, its key such as WR-42. Getting a task marks it as seen by the caller. Its links to other tasks carry the key, title and status of the linked tasks the caller can see.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {token}"
-// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param render query string false "Set to html to add the description rendered from Markdown as description_html" Enums(html)
 // @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Task retrieved successfully"
 // @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
 // @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
@@ -99,8 +134,28 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	taskID := vars["id"]
 
+	renderHTML, ok := renderDescriptionHTML(r)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "render must be html")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Get task
-	task, err := h.taskUseCase.GetTaskByID(taskID)
+	task, err := h.taskUseCase.GetTaskByID(orgID, taskID, userID)
 	if err != nil {
 		// Handle different error types
 		switch err {
@@ -113,6 +168,9 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return task
+	if renderHTML {
+		renderDescriptions(task)
+	}
 	httpUtils.RespondWithJSON(w, http.StatusOK, task)
 }
 
@@ -123,19 +181,22 @@ type UpdateTaskRequest struct {
 	Status      domain.TaskStatus `json:"status,omitempty" example:"in_progress" enums:"pending,in_progress,completed"`
 	Priority    int               `json:"priority,omitempty" example:"4" minimum:"1" maximum:"5"`
 	DueDate     time.Time         `json:"due_date,omitempty" example:"2025-04-01T15:00:00Z"`
+	// Tags replaces the task's tags when present; an empty list clears them
+	Tags []string `json:"tags,omitempty" example:"backend,docs"`
 }
 
 // UpdateTask godoc
 // @Summary Update a task
-// @Description Update an existing task
+// @Description Update an existing task. In projects with strict labels, tags the task does not have yet must be labels of the project.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {token}"
 // @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
 // @Param task body UpdateTaskRequest true "Updated task information"
+// @Param render query string false "Set to html to add the description rendered from Markdown as description_html" Enums(html)
 // @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Task updated successfully"
-// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input, or a status transition the workflow does not allow (reason INVALID_STATUS_TRANSITION)"
 // @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
 // @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
 // @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
@@ -146,6 +207,12 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	taskID := vars["id"]
 
+	renderHTML, ok := renderDescriptionHTML(r)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "render must be html")
+		return
+	}
+
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok {
@@ -153,6 +220,13 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Parse request body
 	var req UpdateTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -168,18 +242,21 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		Status:      req.Status,
 		Priority:    req.Priority,
 		DueDate:     req.DueDate,
+		Tags:        req.Tags,
 		UpdatedBy:   userID,
+		OrgID:       orgID,
+		Client:      clientInfo(r),
 	})
 
 	if err != nil {
 		// Handle different error types
-		switch err {
-		case domain.ErrNotFound:
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
 			httpUtils.RespondWithError(w, http.StatusNotFound, "Task not found")
-		case domain.ErrUnauthorized:
+		case errors.Is(err, domain.ErrUnauthorized):
 			httpUtils.RespondWithError(w, http.StatusForbidden, "You are not authorized to update this task")
-		case domain.ErrInvalidInput:
-			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithInvalidInput(w, err)
 		default:
 			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		}
@@ -187,6 +264,9 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return updated task
+	if renderHTML {
+		renderDescriptions(task)
+	}
 	httpUtils.RespondWithJSON(w, http.StatusOK, task)
 }
 
@@ -216,8 +296,15 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Delete task
-	err := h.taskUseCase.DeleteTask(taskID, userID)
+	err := h.taskUseCase.DeleteTask(orgID, taskID, userID, clientInfo(r))
 	if err != nil {
 		// Handle different error types
 		switch err {
@@ -237,18 +324,28 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 
 // AssignTaskRequest represents the request body for assigning a task
 type AssignTaskRequest struct {
-	AssigneeID string `json:"assignee_id" example:"60f1a7c9e113d7000fedcba9"`
+	AssigneeID string `json:"assignee_id,omitempty" example:"60f1a7c9e113d7000fedcba9"`
+	Assignee   string `json:"assignee,omitempty" example:"johndoe" description:"Username or email, used when assignee_id is omitted"`
+}
+
+// assigneeRef returns the assignee reference from the request, preferring the explicit ID
+func (r AssignTaskRequest) assigneeRef() string {
+	if r.AssigneeID != "" {
+		return r.AssigneeID
+	}
+	return r.Assignee
 }
 
 // AssignTask godoc
 // @Summary Assign a task to a user
-// @Description Assign a task to another user
+// @Description Add a user to the task's assignees. The assignee can be given by ID, username, or email. In projects that require acceptance, users assigned by someone else are listed in pending_assignments until they accept or decline, and the task only starts once they accept.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {token}"
 // @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
 // @Param assignment body AssignTaskRequest true "Assignment information"
+// @Param render query string false "Set to html to add the description rendered from Markdown as description_html" Enums(html)
 // @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Task assigned successfully"
 // @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
 // @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
@@ -261,6 +358,12 @@ func (h *TaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	taskID := vars["id"]
 
+	renderHTML, ok := renderDescriptionHTML(r)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "render must be html")
+		return
+	}
+
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok {
@@ -268,6 +371,13 @@ func (h *TaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Parse request body
 	var req AssignTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -278,17 +388,21 @@ func (h *TaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 	// Assign task
 	task, err := h.taskUseCase.AssignTask(&usecase.AssignTaskInput{
 		TaskID:     taskID,
-		AssigneeID: req.AssigneeID,
+		Assignee:   req.assigneeRef(),
 		AssignedBy: userID,
+		OrgID:      orgID,
+		Client:     clientInfo(r),
 	})
 
 	if err != nil {
 		// Handle different error types
-		switch err {
-		case domain.ErrNotFound:
-			httpUtils.RespondWithError(w, http.StatusNotFound, "Task or user not found")
-		case domain.ErrUnauthorized:
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			httpUtils.RespondWithError(w, http.StatusNotFound, notFoundMessage(err, "Task or user not found"))
+		case errors.Is(err, domain.ErrUnauthorized):
 			httpUtils.RespondWithError(w, http.StatusForbidden, "You are not authorized to assign this task")
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
 		default:
 			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		}
@@ -296,52 +410,398 @@ func (h *TaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return updated task
+	if renderHTML {
+		renderDescriptions(task)
+	}
+	httpUtils.RespondWithJSON(w, http.StatusOK, task)
+}
+
+// UnassignTask godoc
+// @Summary Remove an assignee from a task
+// @Description Remove a user from the task's assignees. The creator can remove anyone; assignees can remove themselves.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Param assignment body AssignTaskRequest true "Assignee to remove"
+// @Param render query string false "Set to html to add the description rendered from Markdown as description_html" Enums(html)
+// @Success 200 {object} httpUtils.ResponseWrapper{data=domain.Task} "Assignee removed successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found or user not assigned"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/unassign [post]
+func (h *TaskHandler) UnassignTask(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	renderHTML, ok := renderDescriptionHTML(r)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "render must be html")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req AssignTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Unassign task
+	task, err := h.taskUseCase.UnassignTask(&usecase.UnassignTaskInput{
+		TaskID:       taskID,
+		Assignee:     req.assigneeRef(),
+		UnassignedBy: userID,
+		OrgID:        orgID,
+		Client:       clientInfo(r),
+	})
+
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			httpUtils.RespondWithError(w, http.StatusNotFound, notFoundMessage(err, "Task not found or user not assigned"))
+		case errors.Is(err, domain.ErrUnauthorized):
+			httpUtils.RespondWithError(w, http.StatusForbidden, "You are not authorized to unassign this user")
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return updated task
+	if renderHTML {
+		renderDescriptions(task)
+	}
 	httpUtils.RespondWithJSON(w, http.StatusOK, task)
 }
 
 // ListTasks godoc
 // @Summary List tasks
-// @Description Get a list of tasks with optional status filter
+// @Description Get a list of tasks with optional status, project, sprint and milestone filters. Tasks of projects the caller is not a member of are left out. Descriptions are omitted from list results; fetch a single task for full details. With a limit, tasks are listed a page at a time in ID order; pass the last task's ID as after to get the next page. Tasks the caller created or is assigned to carry last_seen_at, and unread_changes when they changed since the caller last saw them.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {token}"
 // @Param status query string false "Filter tasks by status" Enums(pending, in_progress, completed)
+// @Param project_id query string false "Filter tasks by project"
+// @Param sprint_id query string false "Filter tasks by sprint, or none for tasks not planned into any sprint"
+// @Param milestone_id query string false "Filter tasks by milestone"
+// @Param tag query string false "Filter tasks by tag; requires a project, sprint or milestone filter"
+// @Param due_after query string false "Only tasks due at or after this time (RFC 3339)" example:"2025-03-01T00:00:00Z"
+// @Param due_before query string false "Only tasks due before this time (RFC 3339). Without a project, sprint or milestone filter, a range needs both bounds and may span at most queries.max_due_date_range days" example:"2025-04-01T00:00:00Z"
+// @Param limit query int false "Maximum number of tasks per page; defaults to pagination.default_page_size, and larger sizes are lowered to pagination.max_page_size"
+// @Param after query string false "List the page after this task ID"
 // @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.Task} "Tasks retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of matching tasks"
+// @Header 200 {integer} X-Page-Size "Page size applied; a page holding fewer tasks is the last one"
+// @Header 200 {boolean} X-Page-Size-Clamped "Set when the requested limit exceeded the maximum and was lowered to it"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid or too expensive filter, or invalid page"
 // @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
 // @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
 // @Router /tasks [get]
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
-	// Get status from query parameter
-	status := r.URL.Query().Get("status")
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
 
-	var input *usecase.ListTasksInput
-	if status != "" {
-		input = &usecase.ListTasksInput{
-			Status: domain.TaskStatus(status),
+	// Get filters from query parameters
+	query := r.URL.Query()
+	limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+	dueAfter, dueBefore, ok := parseDueRange(w, query)
+	if !ok {
+		return
+	}
+	input := &usecase.ListTasksInput{
+		OrgID:       orgID,
+		UserID:      userID,
+		Status:      domain.TaskStatus(query.Get("status")),
+		ProjectID:   query.Get("project_id"),
+		SprintID:    query.Get("sprint_id"),
+		MilestoneID: query.Get("milestone_id"),
+		Tag:         query.Get("tag"),
+		DueAfter:    dueAfter,
+		DueBefore:   dueBefore,
+		Limit:       limit,
+		After:       query.Get("after"),
+	}
+
+	// Get a page of tasks
+	tasks, page, err := h.taskUseCase.ListTasks(input)
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		}
+		return
 	}
 
-	// Get tasks
-	tasks, err := h.taskUseCase.ListTasks(input)
+	// A page holds only some of the matching tasks
+	total, err := h.taskUseCase.CountTasks(input)
 	if err != nil {
 		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Return tasks
-	httpUtils.RespondWithJSON(w, http.StatusOK, tasks)
+	httpUtils.RespondWithPage(w, http.StatusOK, tasks, total, page.Size, page.Clamped)
+}
+
+// CountResponse represents the response for count-only endpoints
+type CountResponse struct {
+	Count int64 `json:"count" example:"42"`
+}
+
+// ListTaskStatuses godoc
+// @Summary List task statuses and their transitions
+// @Description Describe the task status workflow: every status in workflow order with the statuses tasks can move to from it, or only the status given by from. Updates that make any other move fail with reason INVALID_STATUS_TRANSITION.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param from query string false "Only describe this status" Enums(pending, in_progress, completed)
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]usecase.TaskStatusInfo} "Statuses retrieved successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unknown status"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Router /tasks/statuses [get]
+func (h *TaskHandler) ListTaskStatuses(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.taskUseCase.TaskStatuses(domain.TaskStatus(r.URL.Query().Get("from")))
+	if err != nil {
+		httpUtils.RespondWithInvalidInput(w, err)
+		return
+	}
+
+	httpUtils.RespondWithJSON(w, http.StatusOK, statuses)
+}
+
+// CountTasks godoc
+// @Summary Count tasks
+// @Description Count tasks with the same filters as the task list, without returning them
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param status query string false "Filter tasks by status" Enums(pending, in_progress, completed)
+// @Param project_id query string false "Filter tasks by project"
+// @Param sprint_id query string false "Filter tasks by sprint, or none for tasks not planned into any sprint"
+// @Param milestone_id query string false "Filter tasks by milestone"
+// @Param tag query string false "Filter tasks by tag; requires a project, sprint or milestone filter"
+// @Param due_after query string false "Only tasks due at or after this time (RFC 3339)" example:"2025-03-01T00:00:00Z"
+// @Param due_before query string false "Only tasks due before this time (RFC 3339). Without a project, sprint or milestone filter, a range needs both bounds and may span at most queries.max_due_date_range days" example:"2025-04-01T00:00:00Z"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=CountResponse} "Tasks counted successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid or too expensive filter"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/count [get]
+func (h *TaskHandler) CountTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get filters from query parameters
+	query := r.URL.Query()
+	dueAfter, dueBefore, ok := parseDueRange(w, query)
+	if !ok {
+		return
+	}
+	input := &usecase.ListTasksInput{
+		OrgID:       orgID,
+		UserID:      userID,
+		Status:      domain.TaskStatus(query.Get("status")),
+		ProjectID:   query.Get("project_id"),
+		SprintID:    query.Get("sprint_id"),
+		MilestoneID: query.Get("milestone_id"),
+		Tag:         query.Get("tag"),
+		DueAfter:    dueAfter,
+		DueBefore:   dueBefore,
+	}
+
+	// Count tasks
+	count, err := h.taskUseCase.CountTasks(input)
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return count
+	httpUtils.RespondWithJSON(w, http.StatusOK, CountResponse{Count: count})
+}
+
+// SearchTasks godoc
+// @Summary Search tasks
+// @Description Full-text search over task titles and descriptions, most relevant first. Depending on the server configuration, matching is either word-based or fuzzy (typo tolerant).
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results (max 20)"
+// @Param render query string false "Set to html to add the description rendered from Markdown as description_html" Enums(html)
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.TaskSearchResult} "Search results retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Number of results returned"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/search [get]
+func (h *TaskHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+	renderHTML, ok := renderDescriptionHTML(r)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "render must be html")
+		return
+	}
+	// Search tasks
+	results, err := h.taskUseCase.SearchTasks(&usecase.SearchTasksInput{
+		OrgID:  orgID,
+		UserID: userID,
+		Query:  query.Get("q"),
+		Limit:  limit,
+	})
+	if err != nil {
+		// Handle different error types
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	// Return results
+	if renderHTML {
+		for _, result := range results {
+			renderDescriptions(result.Task)
+		}
+	}
+	httpUtils.RespondWithList(w, http.StatusOK, results, int64(len(results)))
+}
+
+// parseDueRange parses the optional due_after and due_before query parameters,
+// responding with an error and reporting false when one is invalid
+func parseDueRange(w http.ResponseWriter, query url.Values) (after time.Time, before time.Time, ok bool) {
+	after, err := parseTimeParam(query.Get("due_after"))
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid due_after time, expected RFC 3339")
+		return time.Time{}, time.Time{}, false
+	}
+
+	before, err = parseTimeParam(query.Get("due_before"))
+	if err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid due_before time, expected RFC 3339")
+		return time.Time{}, time.Time{}, false
+	}
+
+	return after, before, true
+}
+
+// notFoundMessage returns the detailed message of a wrapped not-found error, or the fallback
+// when the error is the bare domain.ErrNotFound
+func notFoundMessage(err error, fallback string) string {
+	if err == domain.ErrNotFound {
+		return fallback
+	}
+	return err.Error()
+}
+
+// renderDescriptionHTML reports whether the request asks for descriptions rendered
+// as HTML, and whether its render parameter is valid
+func renderDescriptionHTML(r *http.Request) (render bool, ok bool) {
+	switch r.URL.Query().Get("render") {
+	case "":
+		return false, true
+	case "html":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// renderDescriptions renders the Markdown descriptions of tasks to sanitized HTML
+func renderDescriptions(tasks ...*domain.Task) {
+	for _, task := range tasks {
+		if task != nil && task.Description != "" {
+			task.DescriptionHTML = markdown.Render(task.Description)
+		}
+	}
 }
 
 // GetUserTasks godoc
 // @Summary Get user's tasks
-// @Description Get tasks created by or assigned to a user
+// @Description Get tasks created by or assigned to a user. Users listing their own tasks do not see the ones they snoozed. Tasks the caller created or is assigned to carry last_seen_at, and unread_changes when they changed since the caller last saw them.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {token}"
 // @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Param render query string false "Set to html to add the description rendered from Markdown as description_html" Enums(html)
 // @Success 200 {object} httpUtils.ResponseWrapper{data=[]domain.Task} "Tasks retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of tasks"
 // @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
 // @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "User not found"
 // @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
@@ -351,13 +811,36 @@ func (h *TaskHandler) GetUserTasks(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["id"]
 
+	renderHTML, ok := renderDescriptionHTML(r)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "render must be html")
+		return
+	}
+
+	// Get viewer ID from context (set by auth middleware)
+	viewerID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	// Get tasks
-	tasks, err := h.taskUseCase.GetUserTasks(userID)
+	tasks, err := h.taskUseCase.GetUserTasks(orgID, userID, viewerID)
 	if err != nil {
 		httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Return tasks
-	httpUtils.RespondWithJSON(w, http.StatusOK, tasks)
+	if renderHTML {
+		renderDescriptions(tasks...)
+	}
+	httpUtils.RespondWithList(w, http.StatusOK, tasks, int64(len(tasks)))
 }
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/usecase"
+)
+
+// TaskLinkHandler handles HTTP requests for links between tasks
+type TaskLinkHandler struct {
+	taskUseCase *usecase.TaskUseCase
+}
+
+// NewTaskLinkHandler creates a new task link handler
+func NewTaskLinkHandler(taskUseCase *usecase.TaskUseCase) *TaskLinkHandler {
+	return &TaskLinkHandler{
+		taskUseCase: taskUseCase,
+	}
+}
+
+// CreateTaskLinkRequest represents the request body for linking a task to another
+type CreateTaskLinkRequest struct {
+	// TaskID is the task to link to; an ID or a key such as PROJ-123
+	TaskID string              `json:"task_id" example:"60f1a7c9e113d70001abcdf0"`
+	Type   domain.TaskLinkType `json:"type" example:"duplicates" enums:"relates_to,duplicates,duplicated_by,blocks,blocked_by"`
+}
+
+// CreateLink godoc
+// @Summary Link a task to another
+// @Description Link the task to another task of the organization the caller can see. The linked task gets the inverse link: duplicates and duplicated_by, and blocks and blocked_by, mirror each other, and relates_to mirrors itself. Two tasks are linked at most once, and a task has at most 100 links. The caller must be allowed to change the task.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param link body CreateTaskLinkRequest true "Task to link to and link type"
+// @Success 201 {object} httpUtils.ResponseWrapper{data=domain.Task} "Tasks linked successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid input or linked task not found"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 409 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Tasks are already linked"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/links [post]
+func (h *TaskLinkHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse request body
+	var req CreateTaskLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Link the tasks
+	task, err := h.taskUseCase.LinkTasks(&usecase.LinkTasksInput{
+		OrgID:        orgID,
+		TaskID:       taskID,
+		LinkedTaskID: req.TaskID,
+		Type:         req.Type,
+		UserID:       userID,
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "Forbidden")
+		return
+	}
+
+	// Return linked task
+	httpUtils.RespondWithJSON(w, http.StatusCreated, task)
+}
+
+// DeleteLink godoc
+// @Summary Remove a task link
+// @Description Remove the link between the task and another task, on both ends. The caller must be allowed to change the task.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Param linkedId path string true "Linked task ID" example:"60f1a7c9e113d70001abcdf0"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid linked task ID"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task or link not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/links/{linkedId} [delete]
+func (h *TaskLinkHandler) DeleteLink(w http.ResponseWriter, r *http.Request) {
+	// Get task and linked task IDs from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	linkedID := vars["linkedId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Unlink the tasks
+	if err := h.taskUseCase.UnlinkTasks(orgID, taskID, linkedID, userID); err != nil {
+		respondWithOrganizationError(w, err, notFoundMessage(err, "Task not found"), "Forbidden")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
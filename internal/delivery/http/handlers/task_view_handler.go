@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// TaskViewHandler handles HTTP requests about who saw the latest changes of tasks
+type TaskViewHandler struct {
+	taskUseCase *usecase.TaskUseCase
+}
+
+// NewTaskViewHandler creates a new task view handler
+func NewTaskViewHandler(taskUseCase *usecase.TaskUseCase) *TaskViewHandler {
+	return &TaskViewHandler{
+		taskUseCase: taskUseCase,
+	}
+}
+
+// MarkTaskSeen godoc
+// @Summary Mark a task as seen
+// @Description Record that the authenticated user saw the current state of a task, clearing unread_changes on it in their task lists. Getting a task, or changing it, does so as well.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Success 204 "No Content"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/seen [post]
+func (h *TaskViewHandler) MarkTaskSeen(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Mark the task as seen
+	if err := h.taskUseCase.MarkTaskSeen(orgID, taskID, userID); err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "Forbidden")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetReadReceipts godoc
+// @Summary Get the read receipts of a task
+// @Description Get when the creator and each assignee of a task last saw it, and whether it changed since. Users who never saw the task have no last_seen_at.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "Task ID or key" example:"60f1a7c9e113d70001abcdef"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=[]usecase.TaskReadReceipt} "Read receipts retrieved successfully"
+// @Header 200 {integer} X-Total-Count "Total number of read receipts"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /tasks/{id}/read-receipts [get]
+func (h *TaskViewHandler) GetReadReceipts(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get the read receipts
+	receipts, err := h.taskUseCase.ReadReceipts(orgID, taskID, userID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "Forbidden")
+		return
+	}
+
+	// Return the read receipts
+	httpUtils.RespondWithList(w, http.StatusOK, receipts, int64(len(receipts)))
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/usecase"
+)
+
+// TodayHandler handles HTTP requests for users' "My Day" view
+type TodayHandler struct {
+	todayUseCase *usecase.TodayUseCase
+}
+
+// NewTodayHandler creates a new today handler
+func NewTodayHandler(todayUseCase *usecase.TodayUseCase) *TodayHandler {
+	return &TodayHandler{
+		todayUseCase: todayUseCase,
+	}
+}
+
+// GetToday godoc
+// @Summary Get the user's day
+// @Description Get the authenticated user's "My Day": their open tasks that are overdue or due on the day, and the tasks they added to the day's plan. Each task is listed once. Days are in the user's time zone. Snoozed tasks are left out of the overdue and due today lists.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param date query string false "Day as YYYY-MM-DD; defaults to today" example:"2025-03-10"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=usecase.TodayView} "Day retrieved successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid date"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/today [get]
+func (h *TodayHandler) GetToday(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Build the day
+	view, err := h.todayUseCase.GetToday(orgID, userID, r.URL.Query().Get("date"), time.Now())
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Forbidden")
+		return
+	}
+
+	// Return the day
+	httpUtils.RespondWithJSON(w, http.StatusOK, view)
+}
+
+// GetCounters godoc
+// @Summary Get the user's badge counters
+// @Description Get in a single call the counts apps show as navigation badges: the authenticated user's open assigned tasks, those due today and overdue, and their unread notifications. Days are in the user's time zone. Snoozed tasks are left out of the due today and overdue counts, as in "My Day".
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=usecase.Counters} "Counters retrieved successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/counters [get]
+func (h *TodayHandler) GetCounters(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Count
+	counters, err := h.todayUseCase.GetCounters(orgID, userID, time.Now())
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Forbidden")
+		return
+	}
+
+	// Return the counters
+	httpUtils.RespondWithJSON(w, http.StatusOK, counters)
+}
+
+// AddToToday godoc
+// @Summary Add a task to the user's day
+// @Description Add a task to the authenticated user's plan for a day. Adding an already planned task has no effect. Plans are kept for 30 days.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param taskId path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Param date query string false "Day as YYYY-MM-DD; defaults to today" example:"2025-03-10"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid date"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/today/tasks/{taskId} [put]
+func (h *TodayHandler) AddToToday(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	taskID := mux.Vars(r)["taskId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Plan the task
+	if _, err := h.todayUseCase.AddToToday(orgID, userID, taskID, r.URL.Query().Get("date"), time.Now()); err != nil {
+		respondWithOrganizationError(w, err, "Task not found", "Forbidden")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveFromToday godoc
+// @Summary Remove a task from the user's day
+// @Description Remove a task from the authenticated user's plan for a day. Tasks that are overdue or due on the day stay listed.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param taskId path string true "Task ID" example:"60f1a7c9e113d70001abcdef"
+// @Param date query string false "Day as YYYY-MM-DD; defaults to today" example:"2025-03-10"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Invalid date"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Unauthorized"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Task not planned for the day"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=httpUtils.RespondErrorInfo} "Internal server error"
+// @Router /me/today/tasks/{taskId} [delete]
+func (h *TodayHandler) RemoveFromToday(w http.ResponseWriter, r *http.Request) {
+	// Get task ID from URL
+	taskID := mux.Vars(r)["taskId"]
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Unplan the task
+	if err := h.todayUseCase.RemoveFromToday(orgID, userID, taskID, r.URL.Query().Get("date"), time.Now()); err != nil {
+		respondWithOrganizationError(w, err, "Task not planned for the day", "Forbidden")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -29,8 +30,13 @@ type UserResponse struct {
 	Email     string `json:"email" example:"john.doe@example.com"`
 	FirstName string `json:"first_name,omitempty" example:"John"`
 	LastName  string `json:"last_name,omitempty" example:"Doe"`
+	Timezone  string `json:"timezone,omitempty" example:"Europe/Berlin"`
+	OrgID     string `json:"org_id" example:"60f1a7c9e113d70001234599"`
+	OrgRole   string `json:"org_role" example:"member" enums:"admin,member"`
 	CreatedAt string `json:"created_at" example:"Sat, 01 Mar 2025 12:00:00 GMT"`
 	UpdatedAt string `json:"updated_at" example:"Sat, 08 Mar 2025 15:00:00 GMT"`
+	// DeactivatedAt is set while the account is deactivated
+	DeactivatedAt string `json:"deactivated_at,omitempty" example:"Mon, 10 Mar 2025 09:00:00 GMT"`
 }
 
 // GetUser godoc
@@ -51,8 +57,15 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["id"]
 
-	// Get user
-	user, err := h.userUseCase.GetUserByID(userID)
+	// Get organization ID from context (set by auth middleware)
+	orgID, ok := r.Context().Value("orgID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Get user; users of other organizations are reported as not found
+	user, err := h.userUseCase.GetUserInOrg(orgID, userID)
 	if err != nil {
 		// Handle different error types
 		switch err {
@@ -65,15 +78,7 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create a response struct to avoid sending password
-	resp := UserResponse{
-		ID:        user.ID.Hex(),
-		Username:  user.Username,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		CreatedAt: user.CreatedAt.Format(http.TimeFormat),
-		UpdatedAt: user.UpdatedAt.Format(http.TimeFormat),
-	}
+	resp := userResponse(user)
 
 	// Return user
 	httpUtils.RespondWithJSON(w, http.StatusOK, resp)
@@ -84,12 +89,13 @@ type UpdateUserRequest struct {
 	Email     string `json:"email,omitempty" example:"new.email@example.com" format:"email"`
 	FirstName string `json:"first_name,omitempty" example:"John"`
 	LastName  string `json:"last_name,omitempty" example:"Doe"`
-	Password  string `json:"password,omitempty" example:"newsecurepassword123" minLength:"6"`
+	Timezone  string `json:"timezone,omitempty" example:"Europe/Berlin"`
+	Password  string `json:"password,omitempty" example:"newsecurepassword123" description:"Must satisfy the configured password policy"`
 }
 
 // UpdateUser godoc
 // @Summary Update user
-// @Description Update a user's profile
+// @Description Update a user's profile. Users may only update their own profile, and may not change their email or password with an impersonation token.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -99,7 +105,7 @@ type UpdateUserRequest struct {
 // @Success 200 {object} httpUtils.ResponseWrapper{data=UserResponse} "User updated successfully"
 // @Failure 400 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Invalid input"
 // @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Unauthorized"
-// @Failure 403 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Forbidden - cannot update another user's profile"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Forbidden - cannot make this change to the user's profile"
 // @Failure 404 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "User not found"
 // @Failure 409 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Email already in use"
 // @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
@@ -116,12 +122,6 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if the authenticated user is updating their own profile
-	if authenticatedUserID != userID {
-		httpUtils.RespondWithError(w, http.StatusForbidden, "You can only update your own profile")
-		return
-	}
-
 	// Parse request body
 	var req UpdateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -135,17 +135,22 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		Email:     req.Email,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		Timezone:  req.Timezone,
 		Password:  req.Password,
+		UpdatedBy: authenticatedUserID,
+		Client:    clientInfo(r),
 	})
 
 	if err != nil {
 		// Handle different error types
-		switch err {
-		case domain.ErrNotFound:
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
 			httpUtils.RespondWithError(w, http.StatusNotFound, "User not found")
-		case domain.ErrInvalidInput:
+		case errors.Is(err, domain.ErrUnauthorized):
+			httpUtils.RespondWithError(w, http.StatusForbidden, "You cannot make this change to the user's profile")
+		case errors.Is(err, domain.ErrInvalidInput):
 			httpUtils.RespondWithError(w, http.StatusBadRequest, err.Error())
-		case domain.ErrDuplicateKey:
+		case errors.Is(err, domain.ErrDuplicateKey):
 			httpUtils.RespondWithError(w, http.StatusConflict, "Email already in use")
 		default:
 			httpUtils.RespondWithError(w, http.StatusInternalServerError, "Internal server error")
@@ -154,15 +159,7 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create a response struct to avoid sending password
-	resp := UserResponse{
-		ID:        user.ID.Hex(),
-		Username:  user.Username,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		CreatedAt: user.CreatedAt.Format(http.TimeFormat),
-		UpdatedAt: user.UpdatedAt.Format(http.TimeFormat),
-	}
+	resp := userResponse(user)
 
 	// Return updated user
 	httpUtils.RespondWithJSON(w, http.StatusOK, resp)
@@ -202,16 +199,174 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create a response struct to avoid sending password
+	resp := userResponse(user)
+
+	// Return user
+	httpUtils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// DeleteUserRequest represents the request body for deleting a user's account
+type DeleteUserRequest struct {
+	// Policy decides what happens to the user's open tasks: block refuses the deletion while there are any
+	Policy string `json:"policy,omitempty" example:"reassign" enums:"block,unassign,reassign"`
+	// ReassignTo is the user ID, username, or email taking over the open tasks under the reassign policy
+	ReassignTo string `json:"reassign_to,omitempty" example:"janedoe"`
+}
+
+// DeleteUser godoc
+// @Summary Delete user
+// @Description Delete an account. Users may only delete their own account, and impersonation tokens cannot delete accounts. The policy decides what happens to the open tasks assigned to the user: block (the default) refuses the deletion while there are any, unassign removes the user from them, and reassign hands them over to reassign_to, who must be able to see them. The tasks are handed over in the same transaction as the deletion when transactions are enabled. Completed tasks keep pointing at the deleted account; deactivate the account instead to keep every reference valid.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Param policy body DeleteUserRequest false "Deletion policy"
+// @Success 204 "No Content"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Invalid input, or open tasks block the deletion"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Forbidden - cannot delete this account"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "User not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
+// @Router /users/{id} [delete]
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from URL
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	// Get authenticated user ID from context
+	authenticatedUserID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Parse the optional request body
+	var req DeleteUserRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httpUtils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	// Delete user
+	err := h.userUseCase.DeleteUser(&usecase.DeleteUserInput{
+		ID:         userID,
+		DeletedBy:  authenticatedUserID,
+		Policy:     domain.UserDeletionPolicy(req.Policy),
+		ReassignTo: req.ReassignTo,
+		Client:     clientInfo(r),
+	})
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "You cannot delete this account")
+		return
+	}
+
+	// Return success - no content
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate user
+// @Description Deactivate an account as an alternative to deleting it. The user is signed out everywhere and can no longer sign in or be assigned tasks, while their tasks and other records keep pointing at a valid account. Users may deactivate their own account, and organization admins the accounts of their organization; the last active admin cannot be deactivated.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=UserResponse} "User deactivated successfully"
+// @Failure 400 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "The user is the organization's last active admin"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "User not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
+// @Router /users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from URL
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	// Get authenticated user ID from context
+	authenticatedUserID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Deactivating someone else is organization administration
+	if userID != authenticatedUserID {
+		scopes, _ := r.Context().Value("scopes").([]domain.Scope)
+		if !domain.HasScope(scopes, domain.ScopeUsersAdmin) {
+			httpUtils.RespondWithError(w, http.StatusForbidden, "Token lacks the required scope "+string(domain.ScopeUsersAdmin))
+			return
+		}
+	}
+
+	// Deactivate user
+	user, err := h.userUseCase.DeactivateUser(userID, authenticatedUserID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "You are not allowed to deactivate this user")
+		return
+	}
+
+	// Return deactivated user
+	httpUtils.RespondWithJSON(w, http.StatusOK, userResponse(user))
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate user
+// @Description Let a deactivated account of the organization sign in again. Only organization admins may do so.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "User ID" example:"60f1a7c9e113d70001234567"
+// @Success 200 {object} httpUtils.ResponseWrapper{data=UserResponse} "User reactivated successfully"
+// @Failure 401 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Unauthorized"
+// @Failure 403 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Forbidden"
+// @Failure 404 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "User not found"
+// @Failure 500 {object} httpUtils.ResponseWrapper{error=ErrorInfo} "Internal server error"
+// @Router /users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from URL
+	vars := mux.Vars(r)
+	userID := vars["id"]
+
+	// Get authenticated user ID from context
+	authenticatedUserID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		httpUtils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Reactivate user
+	user, err := h.userUseCase.ReactivateUser(userID, authenticatedUserID)
+	if err != nil {
+		respondWithOrganizationError(w, err, "User not found", "Only organization admins can reactivate users")
+		return
+	}
+
+	// Return reactivated user
+	httpUtils.RespondWithJSON(w, http.StatusOK, userResponse(user))
+}
+
+// userResponse converts a domain user to its API representation, leaving out the password
+func userResponse(user *domain.User) UserResponse {
 	resp := UserResponse{
 		ID:        user.ID.Hex(),
 		Username:  user.Username,
 		Email:     user.Email,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
+		Timezone:  user.Timezone,
+		OrgID:     user.OrgID.Hex(),
+		OrgRole:   string(user.OrgRole),
 		CreatedAt: user.CreatedAt.Format(http.TimeFormat),
 		UpdatedAt: user.UpdatedAt.Format(http.TimeFormat),
 	}
-
-	// Return user
-	httpUtils.RespondWithJSON(w, http.StatusOK, resp)
+	if user.DeactivatedAt != nil {
+		resp.DeactivatedAt = user.DeactivatedAt.Format(http.TimeFormat)
+	}
+	return resp
 }
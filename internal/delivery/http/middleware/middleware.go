@@ -2,12 +2,18 @@ package middleware
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"net/http"
+	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
 	"task-management-system/internal/logger"
 	"task-management-system/internal/usecase"
 )
@@ -23,24 +29,36 @@ func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
 	return h
 }
 
-// Logger is a middleware that logs HTTP requests
-func Logger(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
+// Logger is a middleware that logs HTTP requests to the given logger
+func Logger(log logger.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
 
-		// Log the request
-		logger.InfoF("[HTTP] %s %s", r.Method, r.URL.Path)
+			// Log the request
+			target := requestTarget(r, log.Redactor())
+			log.InfoF("[HTTP] %s %s", r.Method, target)
 
-		// Create a response writer that captures the status code
-		rw := &responseWriter{w, http.StatusOK}
+			// Create a response writer that captures the status code
+			rw := &responseWriter{w, http.StatusOK}
 
-		// Call the next handler
-		next.ServeHTTP(rw, r)
+			// Call the next handler
+			next.ServeHTTP(rw, r)
 
-		// Log the response
-		duration := time.Since(start)
-		logger.InfoF("[HTTP] %s %s %d %s", r.Method, r.URL.Path, rw.status, duration)
-	})
+			// Log the response
+			duration := time.Since(start)
+			log.InfoF("[HTTP] %s %s %d %s", r.Method, target, rw.status, duration)
+		})
+	}
+}
+
+// requestTarget returns the path and query of a request for logging. The values of
+// sensitive query parameters, such as tokens, are masked unless redaction is disabled.
+func requestTarget(r *http.Request, redactor *logger.Redactor) string {
+	if r.URL.RawQuery == "" {
+		return r.URL.Path
+	}
+	return r.URL.Path + "?" + redactor.RedactQuery(r.URL.Query())
 }
 
 // responseWriter is a wrapper around http.ResponseWriter that captures the status code
@@ -55,36 +73,71 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// Auth is a middleware that authenticates requests
-func Auth(authUseCase *usecase.AuthUseCase) mux.MiddlewareFunc {
+// Unwrap returns the wrapped writer, so that http.ResponseController reaches the connection
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Auth is a middleware that authenticates requests by their bearer token. When
+// cookie-based authentication is enabled, requests without an Authorization header
+// may authenticate with the session cookie instead; those must carry the CSRF
+// token unless their method is safe. Requests made with an impersonation token are
+// logged to the given logger.
+func Auth(authUseCase *usecase.AuthUseCase, cookies *httpUtils.SessionCookies, log logger.Logger) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Get Authorization header
 			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
+
+			var tokenString string
+			switch {
+			case authHeader != "":
+				// Check if the Authorization header is in the correct format
+				parts := strings.Split(authHeader, " ")
+				if len(parts) != 2 || parts[0] != "Bearer" {
+					http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
+					return
+				}
+
+				// Extract token
+				tokenString = parts[1]
+			case cookies != nil && cookies.Session(r) != "":
+				// Browsers send cookies on cross-site requests; only the page holding the CSRF token can write
+				if !isSafeMethod(r.Method) && !cookies.ValidCSRF(r) {
+					http.Error(w, "Invalid or missing CSRF token", http.StatusForbidden)
+					return
+				}
+
+				tokenString = cookies.Session(r)
+			default:
 				http.Error(w, "Authorization header is required", http.StatusUnauthorized)
 				return
 			}
 
-			// Check if the Authorization header is in the correct format
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
+			// Validate token
+			claims, err := authUseCase.ParseToken(tokenString)
+			if err != nil {
+				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 				return
 			}
 
-			// Extract token
-			tokenString := parts[1]
-
-			// Validate token
-			userID, err := authUseCase.ValidateToken(tokenString)
-			if err != nil {
+			// Tokens issued before organizations existed carry no org and must be renewed
+			if claims.OrgID == "" {
 				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 				return
 			}
 
-			// Add user ID to context
-			ctx := context.WithValue(r.Context(), "userID", userID)
+			// Add user, organization, and session IDs to context
+			ctx := context.WithValue(r.Context(), "userID", claims.UserID)
+			ctx = context.WithValue(ctx, "orgID", claims.OrgID)
+			ctx = context.WithValue(ctx, "sessionID", claims.SessionID)
+			ctx = context.WithValue(ctx, "scopes", claims.Scopes)
+
+			// Requests made by an admin acting as the user are logged and carry the admin's ID
+			if claims.Actor != nil {
+				log.InfoF("[HTTP] %s %s as user %s impersonated by %s", r.Method, r.URL.Path, claims.UserID, claims.Actor.UserID)
+				ctx = context.WithValue(ctx, "impersonatorID", claims.Actor.UserID)
+			}
 
 			// Call the next handler with the updated context
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -92,23 +145,94 @@ func Auth(authUseCase *usecase.AuthUseCase) mux.MiddlewareFunc {
 	}
 }
 
-// CORS is a middleware that adds CORS headers to responses
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+// RequireScope is a middleware that rejects requests whose token does not grant a
+// scope. It must run after Auth.
+func RequireScope(scope domain.Scope) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, _ := r.Context().Value("scopes").([]domain.Scope)
+			if !domain.HasScope(scopes, scope) {
+				httpUtils.RespondWithError(w, http.StatusForbidden, "Token lacks the required scope "+string(scope))
+				return
+			}
 
-		// Handle preflight requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		// Call the next handler
-		next.ServeHTTP(w, r)
-	})
+// isSafeMethod reports whether an HTTP method is read-only
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// CORS is a middleware that adds CORS headers to responses. The allowed origins are
+// looked up on every request so they can change at runtime; "*" allows any origin.
+func CORS(allowedOrigins func() []string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Set CORS headers
+			if origin := allowedOrigin(allowedOrigins(), r.Header.Get("Origin")); origin != "" {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				// Browsers only send session cookies cross-origin to explicitly listed origins
+				if origin != "*" {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+			}
+			w.Header().Add("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+httpUtils.CSRFHeader)
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join([]string{httpUtils.TotalCountHeader, httpUtils.PageSizeHeader, httpUtils.PageSizeClampedHeader}, ", "))
+
+			// Handle preflight requests
+			if r.Method == "OPTIONS" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			// Call the next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RPCCORS is a middleware that adds the CORS headers gRPC-Web and Connect clients
+// need: they send protocol headers and read the status from the response headers
+func RPCCORS(allowedOrigins func() []string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Set CORS headers
+			if origin := allowedOrigin(allowedOrigins(), r.Header.Get("Origin")); origin != "" {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			w.Header().Add("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Grpc-Web, X-User-Agent, Grpc-Timeout, Connect-Protocol-Version, Connect-Timeout-Ms, X-Request-Id")
+			w.Header().Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin, X-Request-Id")
+
+			// Handle preflight requests
+			if r.Method == "OPTIONS" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			// Call the next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// allowedOrigin returns the Access-Control-Allow-Origin value for a request origin, or "" to allow none
+func allowedOrigin(allowed []string, origin string) string {
+	for _, o := range allowed {
+		if o == "*" {
+			return "*"
+		}
+		if origin != "" && strings.EqualFold(o, origin) {
+			return origin
+		}
+	}
+	return ""
 }
 
 // ContentType is a middleware that sets the Content-Type header
@@ -124,20 +248,172 @@ func ContentType(contentType string) Middleware {
 	}
 }
 
-// Recover is a middleware that recovers from panics
-func Recover(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				// Log the error
-				logger.ErrorF("Panic recovered: %v", err)
+// Recover is a middleware that recovers from panics, logging them to the given
+// logger with the request and stack, which also reports them if error reporting is enabled
+func Recover(log logger.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if err := recover(); err != nil {
+					// Log the error
+					log.Error("Panic recovered", map[string]interface{}{
+						"method": r.Method,
+						"path":   r.URL.Path,
+						"panic":  err,
+						"stack":  string(debug.Stack()),
+					})
 
-				// Return a 500 Internal Server Error
-				http.Error(w, "Internal server error", http.StatusInternalServerError)
+					// Return a 500 Internal Server Error
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+				}
+			}()
+
+			// Call the next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AdminToken is a middleware that only lets through requests carrying the admin
+// token as a bearer token
+func AdminToken(token string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				http.Error(w, "Invalid admin token", http.StatusUnauthorized)
+				return
 			}
-		}()
 
-		// Call the next handler
-		next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Timeout is a middleware that answers 503 Service Unavailable when a handler runs
+// longer than the timeout. The connection's write deadline is moved to match, so
+// routes may run longer than the server's write timeout; failures to move it are
+// logged to the given logger. Zero disables the timeout.
+func Timeout(timeout time.Duration, log logger.Logger) Middleware {
+	body, _ := json.Marshal(httpUtils.ResponseWrapper{
+		Error: &httpUtils.ErrorInfo{
+			Code:    http.StatusServiceUnavailable,
+			Message: "Request timed out",
+		},
 	})
+
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+
+		timeoutHandler := http.TimeoutHandler(next, timeout, string(body))
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Leave time to write the timeout response itself
+			if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + 5*time.Second)); err != nil {
+				log.WarnF("[HTTP] Failed to move the write deadline of %s %s: %v", r.Method, r.URL.Path, err)
+			}
+
+			// Used by the timeout response; handlers that finish set their own
+			w.Header().Set("Content-Type", "application/json")
+
+			timeoutHandler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CacheControl lets clients reuse successful responses of a read route for maxAge,
+// so polling clients spare the server. Responses are marked private, which keeps
+// proxies and other shared caches from storing one user's data for another, and
+// vary by the credentials. Failed responses are not cached.
+func CacheControl(maxAge time.Duration) Middleware {
+	value := "private, max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isSafeMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, value: value}, r)
+		})
+	}
+}
+
+// cacheControlWriter sets the cache headers once the status code is known
+type cacheControlWriter struct {
+	http.ResponseWriter
+	value       string
+	wroteHeader bool
+}
+
+// WriteHeader sets the cache headers for the status code before writing it
+func (cw *cacheControlWriter) WriteHeader(code int) {
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+		header := cw.Header()
+		if code == http.StatusOK {
+			header.Set("Cache-Control", cw.value)
+		} else {
+			header.Set("Cache-Control", "no-store")
+		}
+		// Origin too: the CORS headers depend on it, and Timeout replaces the Vary
+		// header CORS set with this one
+		addVary(header, "Authorization", "Cookie", "Origin")
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes the status code, if not written yet, before the body
+func (cw *cacheControlWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped writer, so that http.ResponseController reaches the connection
+func (cw *cacheControlWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// addVary adds the header names missing from the Vary header
+func addVary(header http.Header, names ...string) {
+	present := make(map[string]bool)
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			present[http.CanonicalHeaderKey(strings.TrimSpace(name))] = true
+		}
+	}
+	for _, name := range names {
+		if !present[name] {
+			header.Add("Vary", name)
+		}
+	}
+}
+
+// TransferDeadline is a middleware for routes streaming file contents, which
+// Timeout would buffer in memory. It moves the connection's read and write
+// deadlines to the timeout, so transfers may run longer than the server's read
+// and write timeouts; failures to move them are logged to the given logger. Zero
+// removes the deadlines.
+func TransferDeadline(timeout time.Duration, log logger.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var deadline time.Time
+			if timeout > 0 {
+				deadline = time.Now().Add(timeout)
+			}
+
+			rc := http.NewResponseController(w)
+			if err := rc.SetReadDeadline(deadline); err != nil {
+				log.WarnF("[HTTP] Failed to move the read deadline of %s %s: %v", r.Method, r.URL.Path, err)
+			}
+			if err := rc.SetWriteDeadline(deadline); err != nil {
+				log.WarnF("[HTTP] Failed to move the write deadline of %s %s: %v", r.Method, r.URL.Path, err)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
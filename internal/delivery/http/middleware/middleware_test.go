@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management-system/internal/logger"
+)
+
+// TestDeadlinesOutlastServerTimeoutBehindLogger checks that the deadlines set by
+// Timeout and TransferDeadline reach the connection through the writer Logger
+// wraps every response in, so that routes may run longer than the server's write
+// timeout.
+func TestDeadlinesOutlastServerTimeoutBehindLogger(t *testing.T) {
+	const writeTimeout = 100 * time.Millisecond
+
+	for name, deadline := range map[string]func(logger.Logger) Middleware{
+		"Timeout": func(log logger.Logger) Middleware {
+			return Timeout(5*time.Second, log)
+		},
+		"TransferDeadline": func(log logger.Logger) Middleware {
+			return TransferDeadline(5*time.Second, log)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var logs bytes.Buffer
+			log := logger.NewText(&logs, logger.LevelInfo)
+
+			// The handler only answers once the server's write timeout has passed
+			slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(3 * writeTimeout)
+				_, _ = w.Write([]byte("done"))
+			})
+
+			server := httptest.NewUnstartedServer(Chain(slow, deadline(log), Logger(log)))
+			server.Config.WriteTimeout = writeTimeout
+			server.Start()
+
+			resp, err := server.Client().Get(server.URL)
+			require.NoError(t, err, "the response must be written after the server's write timeout")
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "done", string(body))
+
+			// Close waits for the handler, and so for its logging, to finish
+			server.Close()
+			assert.NotContains(t, logs.String(), "Failed to move", "the deadlines must be moved")
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	httpUtils "task-management-system/internal/delivery/http/utils"
+)
+
+// maxRateLimitClients bounds the number of clients tracked before idle ones are evicted
+const maxRateLimitClients = 10000
+
+// RateLimit is a middleware that limits requests per client IP with a token bucket.
+// The limit is looked up on every request so it can change at runtime; a rate of
+// zero requests per minute disables limiting.
+func RateLimit(limit func() (perMinute int, burst int)) Middleware {
+	limiter := &rateLimiter{buckets: make(map[string]*bucket)}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			perMinute, burst := limit()
+			if perMinute <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ip, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				ip = r.RemoteAddr
+			}
+
+			if wait, ok := limiter.allow(ip, perMinute, burst, time.Now()); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				httpUtils.RespondWithError(w, http.StatusTooManyRequests, "Too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bucket holds the tokens left for one client
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimiter tracks a token bucket per client
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// allow takes a token from the client's bucket, refilled at perMinute tokens per
+// minute up to burst. When none is left it returns how long until the next one.
+func (l *rateLimiter) allow(client string, perMinute int, burst int, now time.Time) (time.Duration, bool) {
+	if burst <= 0 {
+		burst = perMinute
+	}
+	rate := float64(perMinute) / time.Minute.Seconds()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[client]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitClients {
+			l.evictIdle(now, float64(burst)/rate)
+		}
+		b = &bucket{tokens: float64(burst), updated: now}
+		l.buckets[client] = b
+	}
+
+	b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// evictIdle drops clients idle long enough for their bucket to be full again
+func (l *rateLimiter) evictIdle(now time.Time, refillSeconds float64) {
+	for client, b := range l.buckets {
+		if now.Sub(b.updated).Seconds() >= refillSeconds {
+			delete(l.buckets, client)
+		}
+	}
+}
@@ -1,59 +1,318 @@
 package routes
 
 import (
+	"expvar"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"task-management-system/config"
 	"task-management-system/internal/delivery/http/handlers"
 	"task-management-system/internal/delivery/http/middleware"
+	httpUtils "task-management-system/internal/delivery/http/utils"
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
 	"task-management-system/internal/usecase"
 )
 
-// NewRouter creates a new HTTP router
+// NewRouter creates a new HTTP router logging requests to the given logger
 func NewRouter(
+	cfg *config.Config,
+	log logger.Logger,
 	taskUseCase *usecase.TaskUseCase,
 	userUseCase *usecase.UserUseCase,
 	authUseCase *usecase.AuthUseCase,
+	starUseCase *usecase.StarUseCase,
+	todayUseCase *usecase.TodayUseCase,
+	notificationUseCase *usecase.NotificationUseCase,
+	organizationUseCase *usecase.OrganizationUseCase,
+	invitationUseCase *usecase.InvitationUseCase,
+	projectUseCase *usecase.ProjectUseCase,
+	auditUseCase *usecase.AuditUseCase,
+	attachmentUseCase *usecase.AttachmentUseCase,
+	avatarUseCase *usecase.AvatarUseCase,
+	exportUseCase *usecase.ExportUseCase,
+	mergeUseCase *usecase.MergeUseCase,
+	inboundHookUseCase *usecase.InboundHookUseCase,
+	calendarFeedUseCase *usecase.CalendarFeedUseCase,
+	reminderUseCase *usecase.ReminderUseCase,
+	statusUseCase *usecase.StatusUseCase,
+	runtimeSettings *config.RuntimeSettings,
 ) http.Handler {
 	// Create router
 	router := mux.NewRouter()
 
+	// Session cookies are nil unless cookie-based authentication is enabled
+	cookies := httpUtils.NewSessionCookies(cfg.Auth.Cookie)
+
 	// Create handlers
 	taskHandler := handlers.NewTaskHandler(taskUseCase)
 	userHandler := handlers.NewUserHandler(userUseCase)
-	authHandler := handlers.NewAuthHandler(authUseCase, userUseCase)
+	authHandler := handlers.NewAuthHandler(authUseCase, userUseCase, cookies)
+	starHandler := handlers.NewStarHandler(starUseCase)
+	snoozeHandler := handlers.NewSnoozeHandler(taskUseCase)
+	focusHandler := handlers.NewFocusHandler(taskUseCase)
+	assignmentHandler := handlers.NewAssignmentHandler(taskUseCase)
+	taskViewHandler := handlers.NewTaskViewHandler(taskUseCase)
+	taskLinkHandler := handlers.NewTaskLinkHandler(taskUseCase)
+	taskGrantHandler := handlers.NewTaskGrantHandler(taskUseCase)
+	todayHandler := handlers.NewTodayHandler(todayUseCase)
+	mergeHandler := handlers.NewMergeHandler(mergeUseCase)
+	inboundHookHandler := handlers.NewInboundHookHandler(inboundHookUseCase)
+	calendarFeedHandler := handlers.NewCalendarFeedHandler(calendarFeedUseCase)
+	reminderHandler := handlers.NewReminderHandler(reminderUseCase)
+	notificationHandler := handlers.NewNotificationHandler(notificationUseCase)
+	organizationHandler := handlers.NewOrganizationHandler(organizationUseCase)
+	invitationHandler := handlers.NewInvitationHandler(invitationUseCase, cookies)
+	projectHandler := handlers.NewProjectHandler(projectUseCase)
+	auditHandler := handlers.NewAuditHandler(auditUseCase)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentUseCase, log)
+	avatarHandler := handlers.NewAvatarHandler(avatarUseCase, log)
+	exportHandler := handlers.NewExportHandler(exportUseCase, log)
+	sprintHandler := handlers.NewSprintHandler(projectUseCase, taskUseCase)
+	milestoneHandler := handlers.NewMilestoneHandler(projectUseCase)
+	connectorHandler := handlers.NewConnectorHandler(projectUseCase)
+	labelHandler := handlers.NewLabelHandler(projectUseCase)
+	reportHandler := handlers.NewReportHandler(projectUseCase, log)
+	sessionHandler := handlers.NewSessionHandler(authUseCase)
+	impersonationHandler := handlers.NewImpersonationHandler(authUseCase)
+	loginHistoryHandler := handlers.NewLoginHistoryHandler(authUseCase)
+	adminHandler := handlers.NewAdminHandler(runtimeSettings, log)
+	statusHandler := handlers.NewStatusHandler(statusUseCase, cfg.Server.HTTP.Status)
 
 	// Apply global middlewares
-	router.Use(middleware.Recover)
-	router.Use(middleware.Logger)
-	router.Use(middleware.CORS)
+	router.Use(mux.MiddlewareFunc(middleware.Recover(log)))
+	router.Use(mux.MiddlewareFunc(middleware.Logger(log)))
+	router.Use(mux.MiddlewareFunc(middleware.CORS(func() []string {
+		return runtimeSettings.Get().CORSOrigins
+	})))
+	router.Use(mux.MiddlewareFunc(middleware.RateLimit(func() (int, int) {
+		limit := runtimeSettings.Get().RateLimit
+		return limit.RequestsPerMinute, limit.Burst
+	})))
 
 	// API routes
-	api := router.PathPrefix("/api/v1").Subrouter()
+	api := router.PathPrefix(cfg.Server.HTTP.BasePath).Subrouter()
+
+	// Handler timeouts are stricter for auth routes and longer for long-running routes
+	timeouts := cfg.Server.HTTP.Timeouts
 
 	// Auth routes (no authentication required)
 	auth := api.PathPrefix("/auth").Subrouter()
+	auth.Use(mux.MiddlewareFunc(middleware.Timeout(timeouts.Auth, log)))
 	auth.HandleFunc("/register", authHandler.Register).Methods("POST")
 	auth.HandleFunc("/login", authHandler.Login).Methods("POST")
 	auth.HandleFunc("/refresh-token", authHandler.RefreshToken).Methods("POST")
+	auth.HandleFunc("/invitations/accept", invitationHandler.AcceptInvitation).Methods("POST")
+	if cookies != nil {
+		auth.HandleFunc("/csrf-token", authHandler.CSRFToken).Methods("GET")
+	}
+
+	// Admin routes (admin token required; disabled when no token is configured)
+	if cfg.Admin.Token != "" {
+		admin := api.PathPrefix("/admin").Subrouter()
+		admin.Use(mux.MiddlewareFunc(middleware.Timeout(timeouts.Default, log)))
+		admin.Use(mux.MiddlewareFunc(middleware.AdminToken(cfg.Admin.Token)))
+		admin.HandleFunc("/config/reload", adminHandler.ReloadConfig).Methods("POST")
+		admin.HandleFunc("/log-level", adminHandler.GetLogLevels).Methods("GET")
+		admin.HandleFunc("/log-level", adminHandler.SetLogLevels).Methods("PUT")
+		admin.Handle("/metrics", expvar.Handler()).Methods("GET")
+		if cfg.App.Env == "development" {
+			admin.HandleFunc("/faults", adminHandler.GetFaults).Methods("GET")
+			admin.HandleFunc("/faults", adminHandler.SetFaults).Methods("PUT")
+		}
+	}
+
+	// Inbound hook routes (the secret token in the URL authenticates the request)
+	hooks := api.PathPrefix("/hooks").Subrouter()
+	hooks.Use(mux.MiddlewareFunc(middleware.Timeout(timeouts.Default, log)))
+	hooks.HandleFunc("/{token}", inboundHookHandler.ReceiveHook).Methods("POST")
+
+	// Calendar feed routes (the secret token in the URL authenticates the request)
+	feeds := api.PathPrefix("/feeds").Subrouter()
+	feeds.Use(mux.MiddlewareFunc(middleware.Timeout(timeouts.Default, log)))
+	feeds.HandleFunc("/{token}.ics", calendarFeedHandler.ServeCalendarFeed).Methods("GET")
+
+	// scoped requires the token to grant a scope before calling a handler
+	scoped := func(scope domain.Scope, handler http.HandlerFunc) http.Handler {
+		return middleware.RequireScope(scope)(handler)
+	}
+
+	// cached lets clients reuse the responses of a list or stat route for its
+	// configured max-age; see server.http.cache
+	cached := func(path string, handler http.Handler) http.Handler {
+		if maxAge := cfg.Server.HTTP.Cache.MaxAgeFor(path); maxAge > 0 {
+			return middleware.CacheControl(maxAge)(handler)
+		}
+		return handler
+	}
+
+	// Long-running routes that require authentication, such as exports and reports
+	longRunning := api.NewRoute().Subrouter()
+	longRunning.Use(mux.MiddlewareFunc(middleware.Timeout(timeouts.Long, log)))
+	longRunning.Use(middleware.Auth(authUseCase, cookies, log))
+	longRunning.Handle("/export", scoped(domain.ScopeUsersAdmin, exportHandler.Export)).Methods("GET")
+	longRunning.Handle("/import", scoped(domain.ScopeUsersAdmin, exportHandler.Import)).Methods("POST")
+
+	// Routes transferring file contents through the API, which are streamed rather than buffered
+	transfers := api.NewRoute().Subrouter()
+	transfers.Use(mux.MiddlewareFunc(middleware.TransferDeadline(timeouts.Long, log)))
+	transfers.Use(middleware.Auth(authUseCase, cookies, log))
+	transfers.Handle("/tasks/{id}/attachments/{attachmentId}/content", scoped(domain.ScopeTasksWrite, attachmentHandler.UploadAttachmentContent)).Methods("PUT")
+	transfers.Handle("/tasks/{id}/attachments/{attachmentId}/content", scoped(domain.ScopeTasksRead, attachmentHandler.DownloadAttachment)).Methods("GET")
+	transfers.Handle("/me/avatar/uploads/{uploadId}", scoped(domain.ScopeUsersWrite, avatarHandler.UploadAvatarContent)).Methods("PUT")
+	transfers.Handle("/users/{id}/avatar", scoped(domain.ScopeUsersRead, avatarHandler.GetAvatar)).Methods("GET")
 
 	// Routes that require authentication
 	authenticated := api.NewRoute().Subrouter()
-	authenticated.Use(middleware.Auth(authUseCase))
+	authenticated.Use(mux.MiddlewareFunc(middleware.Timeout(timeouts.Default, log)))
+	authenticated.Use(middleware.Auth(authUseCase, cookies, log))
+
+	// Sign out route
+	authenticated.HandleFunc("/auth/logout", authHandler.Logout).Methods("POST")
 
 	// User routes
-	authenticated.HandleFunc("/me", userHandler.GetProfile).Methods("GET")
-	authenticated.HandleFunc("/users/{id}", userHandler.GetUser).Methods("GET")
-	authenticated.HandleFunc("/users/{id}", userHandler.UpdateUser).Methods("PUT")
+	authenticated.Handle("/me", scoped(domain.ScopeUsersRead, userHandler.GetProfile)).Methods("GET")
+	authenticated.Handle("/users/{id}", scoped(domain.ScopeUsersRead, userHandler.GetUser)).Methods("GET")
+	authenticated.Handle("/users/{id}", scoped(domain.ScopeUsersWrite, userHandler.UpdateUser)).Methods("PUT")
+	authenticated.Handle("/users/{id}", scoped(domain.ScopeUsersWrite, userHandler.DeleteUser)).Methods("DELETE")
+	authenticated.Handle("/users/{id}/deactivate", scoped(domain.ScopeUsersWrite, userHandler.DeactivateUser)).Methods("POST")
+	authenticated.Handle("/users/{id}/reactivate", scoped(domain.ScopeUsersAdmin, userHandler.ReactivateUser)).Methods("POST")
+
+	// Avatar routes
+	authenticated.Handle("/me/avatar/uploads", scoped(domain.ScopeUsersWrite, avatarHandler.BeginAvatarUpload)).Methods("POST")
+	authenticated.Handle("/me/avatar/uploads/{uploadId}/complete", scoped(domain.ScopeUsersWrite, avatarHandler.CompleteAvatarUpload)).Methods("POST")
+	authenticated.Handle("/me/avatar", scoped(domain.ScopeUsersWrite, avatarHandler.DeleteAvatar)).Methods("DELETE")
+
+	// Organization routes
+	authenticated.Handle("/org", scoped(domain.ScopeUsersRead, organizationHandler.GetOrganization)).Methods("GET")
+	authenticated.Handle("/org", scoped(domain.ScopeUsersAdmin, organizationHandler.UpdateOrganization)).Methods("PUT")
+	authenticated.Handle("/org/members", cached("/org/members", scoped(domain.ScopeUsersRead, organizationHandler.ListMembers))).Methods("GET")
+	authenticated.Handle("/org/members/{id}/role", scoped(domain.ScopeUsersAdmin, organizationHandler.UpdateMemberRole)).Methods("PUT")
+	authenticated.Handle("/org/members/{id}", scoped(domain.ScopeUsersAdmin, organizationHandler.RemoveMember)).Methods("DELETE")
+	authenticated.Handle("/org/invitations", scoped(domain.ScopeUsersAdmin, invitationHandler.CreateInvitation)).Methods("POST")
+	authenticated.Handle("/org/invitations", cached("/org/invitations", scoped(domain.ScopeUsersAdmin, invitationHandler.ListInvitations))).Methods("GET")
+	authenticated.Handle("/org/invitations/{id}", scoped(domain.ScopeUsersAdmin, invitationHandler.RevokeInvitation)).Methods("DELETE")
+	authenticated.Handle("/org/audit-log", cached("/org/audit-log", scoped(domain.ScopeUsersAdmin, auditHandler.ListAuditEntries))).Methods("GET")
+	authenticated.Handle("/org/login-history", scoped(domain.ScopeUsersAdmin, loginHistoryHandler.ListLoginAttempts)).Methods("GET")
+
+	// Impersonation routes (disabled unless impersonation is enabled)
+	if cfg.Auth.Impersonation.Enabled {
+		authenticated.Handle("/org/members/{id}/impersonate", scoped(domain.ScopeUsersAdmin, impersonationHandler.ImpersonateMember)).Methods("POST")
+		authenticated.Handle("/org/impersonations/{id}", scoped(domain.ScopeUsersAdmin, impersonationHandler.RevokeImpersonation)).Methods("DELETE")
+	}
+
+	// Project routes
+	authenticated.Handle("/projects", scoped(domain.ScopeProjectsWrite, projectHandler.CreateProject)).Methods("POST")
+	authenticated.Handle("/projects", cached("/projects", scoped(domain.ScopeProjectsRead, projectHandler.ListProjects))).Methods("GET")
+	authenticated.Handle("/projects/{id}", scoped(domain.ScopeProjectsRead, projectHandler.GetProject)).Methods("GET")
+	authenticated.Handle("/projects/{id}", scoped(domain.ScopeProjectsWrite, projectHandler.UpdateProject)).Methods("PUT")
+	authenticated.Handle("/projects/{id}", scoped(domain.ScopeProjectsWrite, projectHandler.DeleteProject)).Methods("DELETE")
+	authenticated.Handle("/projects/{id}/wip", cached("/projects/{id}/wip", scoped(domain.ScopeProjectsRead, projectHandler.GetProjectWIP))).Methods("GET")
+	authenticated.Handle("/projects/{id}/auto-assign", scoped(domain.ScopeProjectsRead, projectHandler.GetAutoAssignRules)).Methods("GET")
+	authenticated.Handle("/projects/{id}/auto-assign", scoped(domain.ScopeProjectsWrite, projectHandler.SetAutoAssignRules)).Methods("PUT")
+	authenticated.Handle("/projects/{id}/connectors", scoped(domain.ScopeProjectsRead, connectorHandler.GetConnectors)).Methods("GET")
+	authenticated.Handle("/projects/{id}/connectors", scoped(domain.ScopeProjectsWrite, connectorHandler.SetConnectors)).Methods("PUT")
+	authenticated.Handle("/projects/{id}/report", scoped(domain.ScopeProjectsRead, reportHandler.GetProjectReport)).Methods("GET")
+	authenticated.Handle("/projects/{id}/labels", scoped(domain.ScopeProjectsRead, labelHandler.ListLabels)).Methods("GET")
+	authenticated.Handle("/projects/{id}/labels", scoped(domain.ScopeProjectsWrite, labelHandler.CreateLabel)).Methods("POST")
+	authenticated.Handle("/projects/{id}/labels/{name}", scoped(domain.ScopeProjectsWrite, labelHandler.UpdateLabel)).Methods("PUT")
+	authenticated.Handle("/projects/{id}/labels/{name}", scoped(domain.ScopeProjectsWrite, labelHandler.DeleteLabel)).Methods("DELETE")
+	authenticated.Handle("/projects/{id}/hooks", scoped(domain.ScopeProjectsWrite, inboundHookHandler.CreateHook)).Methods("POST")
+	authenticated.Handle("/projects/{id}/hooks", scoped(domain.ScopeProjectsRead, inboundHookHandler.ListHooks)).Methods("GET")
+	authenticated.Handle("/projects/{id}/hooks/{hookId}", scoped(domain.ScopeProjectsWrite, inboundHookHandler.DeleteHook)).Methods("DELETE")
+	authenticated.Handle("/projects/{id}/members/{userId}", scoped(domain.ScopeProjectsWrite, projectHandler.SetProjectMember)).Methods("PUT")
+	authenticated.Handle("/projects/{id}/members/{userId}", scoped(domain.ScopeProjectsWrite, projectHandler.RemoveProjectMember)).Methods("DELETE")
+
+	// Sprint routes
+	authenticated.Handle("/projects/{id}/sprints", scoped(domain.ScopeProjectsWrite, sprintHandler.CreateSprint)).Methods("POST")
+	authenticated.Handle("/projects/{id}/sprints", cached("/projects/{id}/sprints", scoped(domain.ScopeProjectsRead, sprintHandler.ListSprints))).Methods("GET")
+	authenticated.Handle("/sprints/{id}", scoped(domain.ScopeProjectsRead, sprintHandler.GetSprint)).Methods("GET")
+	authenticated.Handle("/sprints/{id}/close", scoped(domain.ScopeProjectsWrite, sprintHandler.CloseSprint)).Methods("POST")
+	authenticated.Handle("/sprints/{id}/tasks", cached("/sprints/{id}/tasks", scoped(domain.ScopeTasksRead, sprintHandler.ListSprintTasks))).Methods("GET")
+	authenticated.Handle("/sprints/{id}/tasks/{taskId}", scoped(domain.ScopeTasksWrite, sprintHandler.AddSprintTask)).Methods("PUT")
+	authenticated.Handle("/sprints/{id}/tasks/{taskId}", scoped(domain.ScopeTasksWrite, sprintHandler.RemoveSprintTask)).Methods("DELETE")
+
+	// Milestone routes
+	authenticated.Handle("/projects/{id}/milestones", scoped(domain.ScopeProjectsWrite, milestoneHandler.CreateMilestone)).Methods("POST")
+	authenticated.Handle("/projects/{id}/milestones", cached("/projects/{id}/milestones", scoped(domain.ScopeProjectsRead, milestoneHandler.ListMilestones))).Methods("GET")
+	authenticated.Handle("/milestones/{id}", scoped(domain.ScopeProjectsRead, milestoneHandler.GetMilestone)).Methods("GET")
+	authenticated.Handle("/milestones/{id}", scoped(domain.ScopeProjectsWrite, milestoneHandler.UpdateMilestone)).Methods("PUT")
+	authenticated.Handle("/milestones/{id}/tasks/{taskId}", scoped(domain.ScopeTasksWrite, milestoneHandler.AddMilestoneTask)).Methods("PUT")
+	authenticated.Handle("/milestones/{id}/tasks/{taskId}", scoped(domain.ScopeTasksWrite, milestoneHandler.RemoveMilestoneTask)).Methods("DELETE")
 
 	// Task routes
-	authenticated.HandleFunc("/tasks", taskHandler.CreateTask).Methods("POST")
-	authenticated.HandleFunc("/tasks", taskHandler.ListTasks).Methods("GET")
-	authenticated.HandleFunc("/tasks/{id}", taskHandler.GetTask).Methods("GET")
-	authenticated.HandleFunc("/tasks/{id}", taskHandler.UpdateTask).Methods("PUT")
-	authenticated.HandleFunc("/tasks/{id}", taskHandler.DeleteTask).Methods("DELETE")
-	authenticated.HandleFunc("/tasks/{id}/assign", taskHandler.AssignTask).Methods("POST")
-	authenticated.HandleFunc("/users/{id}/tasks", taskHandler.GetUserTasks).Methods("GET")
+	authenticated.Handle("/tasks", scoped(domain.ScopeTasksWrite, taskHandler.CreateTask)).Methods("POST")
+	authenticated.Handle("/tasks", cached("/tasks", scoped(domain.ScopeTasksRead, taskHandler.ListTasks))).Methods("GET")
+	authenticated.Handle("/tasks/count", cached("/tasks/count", scoped(domain.ScopeTasksRead, taskHandler.CountTasks))).Methods("GET")
+	authenticated.Handle("/tasks/search", cached("/tasks/search", scoped(domain.ScopeTasksRead, taskHandler.SearchTasks))).Methods("GET")
+	authenticated.Handle("/tasks/statuses", scoped(domain.ScopeTasksRead, taskHandler.ListTaskStatuses)).Methods("GET")
+	authenticated.Handle("/tasks/{id}", scoped(domain.ScopeTasksRead, taskHandler.GetTask)).Methods("GET")
+	authenticated.Handle("/tasks/{id}", scoped(domain.ScopeTasksWrite, taskHandler.UpdateTask)).Methods("PUT")
+	authenticated.Handle("/tasks/{id}", scoped(domain.ScopeTasksWrite, taskHandler.DeleteTask)).Methods("DELETE")
+	authenticated.Handle("/tasks/{id}/assign", scoped(domain.ScopeTasksWrite, taskHandler.AssignTask)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/unassign", scoped(domain.ScopeTasksWrite, taskHandler.UnassignTask)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/assignment/accept", scoped(domain.ScopeTasksWrite, assignmentHandler.AcceptAssignment)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/assignment/decline", scoped(domain.ScopeTasksWrite, assignmentHandler.DeclineAssignment)).Methods("POST")
+	authenticated.Handle("/me/assignment-requests", scoped(domain.ScopeTasksRead, assignmentHandler.ListAssignmentRequests)).Methods("GET")
+	authenticated.Handle("/tasks/{id}/seen", scoped(domain.ScopeTasksRead, taskViewHandler.MarkTaskSeen)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/read-receipts", scoped(domain.ScopeTasksRead, taskViewHandler.GetReadReceipts)).Methods("GET")
+	authenticated.Handle("/tasks/{id}/grants", scoped(domain.ScopeTasksWrite, taskGrantHandler.GrantAccess)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/grants/{userId}", scoped(domain.ScopeTasksWrite, taskGrantHandler.RevokeAccess)).Methods("DELETE")
+	authenticated.Handle("/tasks/{id}/links", scoped(domain.ScopeTasksWrite, taskLinkHandler.CreateLink)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/links/{linkedId}", scoped(domain.ScopeTasksWrite, taskLinkHandler.DeleteLink)).Methods("DELETE")
+	authenticated.Handle("/tasks/{id}/merge", scoped(domain.ScopeTasksWrite, mergeHandler.MergeTask)).Methods("POST")
+	authenticated.Handle("/users/{id}/tasks", cached("/users/{id}/tasks", scoped(domain.ScopeTasksRead, taskHandler.GetUserTasks))).Methods("GET")
+
+	// Attachment routes
+	authenticated.Handle("/tasks/{id}/attachments", scoped(domain.ScopeTasksWrite, attachmentHandler.CreateAttachment)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/attachments", cached("/tasks/{id}/attachments", scoped(domain.ScopeTasksRead, attachmentHandler.ListAttachments))).Methods("GET")
+	authenticated.Handle("/tasks/{id}/attachments/{attachmentId}/complete", scoped(domain.ScopeTasksWrite, attachmentHandler.CompleteAttachment)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/attachments/{attachmentId}", scoped(domain.ScopeTasksWrite, attachmentHandler.DeleteAttachment)).Methods("DELETE")
+
+	// Starred task routes
+	authenticated.Handle("/tasks/{id}/star", scoped(domain.ScopeTasksWrite, starHandler.StarTask)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/star", scoped(domain.ScopeTasksWrite, starHandler.UnstarTask)).Methods("DELETE")
+	authenticated.Handle("/me/starred", cached("/me/starred", scoped(domain.ScopeTasksRead, starHandler.GetStarredTasks))).Methods("GET")
+
+	// Snoozed task routes
+	authenticated.Handle("/tasks/{id}/snooze", scoped(domain.ScopeTasksWrite, snoozeHandler.SnoozeTask)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/snooze", scoped(domain.ScopeTasksWrite, snoozeHandler.UnsnoozeTask)).Methods("DELETE")
+	authenticated.Handle("/me/snoozed", cached("/me/snoozed", scoped(domain.ScopeTasksRead, snoozeHandler.GetSnoozedTasks))).Methods("GET")
+
+	// Task reminder routes
+	authenticated.Handle("/tasks/{id}/reminders", scoped(domain.ScopeTasksWrite, reminderHandler.CreateReminder)).Methods("POST")
+	authenticated.Handle("/tasks/{id}/reminders", scoped(domain.ScopeTasksRead, reminderHandler.ListTaskReminders)).Methods("GET")
+	authenticated.Handle("/tasks/{id}/reminders/{reminderId}", scoped(domain.ScopeTasksWrite, reminderHandler.DeleteReminder)).Methods("DELETE")
+	authenticated.Handle("/me/reminders", scoped(domain.ScopeTasksRead, reminderHandler.ListReminders)).Methods("GET")
+
+	// Focus routes
+	authenticated.Handle("/me/next-task", scoped(domain.ScopeTasksRead, focusHandler.GetNextTask)).Methods("GET")
+	authenticated.Handle("/me/today", cached("/me/today", scoped(domain.ScopeTasksRead, todayHandler.GetToday))).Methods("GET")
+	authenticated.Handle("/me/today/tasks/{taskId}", scoped(domain.ScopeTasksWrite, todayHandler.AddToToday)).Methods("PUT")
+	authenticated.Handle("/me/today/tasks/{taskId}", scoped(domain.ScopeTasksWrite, todayHandler.RemoveFromToday)).Methods("DELETE")
+	authenticated.Handle("/me/counters", cached("/me/counters", scoped(domain.ScopeTasksRead, todayHandler.GetCounters))).Methods("GET")
+
+	// Calendar feed management routes
+	authenticated.Handle("/me/calendar-feed", scoped(domain.ScopeUsersRead, calendarFeedHandler.GetCalendarFeed)).Methods("GET")
+	authenticated.Handle("/me/calendar-feed", scoped(domain.ScopeUsersWrite, calendarFeedHandler.RotateCalendarFeed)).Methods("POST")
+	authenticated.Handle("/me/calendar-feed", scoped(domain.ScopeUsersWrite, calendarFeedHandler.RevokeCalendarFeed)).Methods("DELETE")
+
+	// Notification routes
+	authenticated.Handle("/me/notifications", cached("/me/notifications", scoped(domain.ScopeUsersRead, notificationHandler.ListNotifications))).Methods("GET")
+	authenticated.Handle("/me/notifications/unread-count", cached("/me/notifications/unread-count", scoped(domain.ScopeUsersRead, notificationHandler.GetUnreadCount))).Methods("GET")
+	authenticated.Handle("/me/notifications/read-all", scoped(domain.ScopeUsersWrite, notificationHandler.MarkAllNotificationsRead)).Methods("POST")
+	authenticated.Handle("/me/notifications/{id}/read", scoped(domain.ScopeUsersWrite, notificationHandler.MarkNotificationRead)).Methods("POST")
+	authenticated.Handle("/me/notification-preferences", scoped(domain.ScopeUsersRead, notificationHandler.GetNotificationPreferences)).Methods("GET")
+	authenticated.Handle("/me/notification-preferences", scoped(domain.ScopeUsersWrite, notificationHandler.UpdateNotificationPreferences)).Methods("PUT")
+
+	// Session routes
+	authenticated.Handle("/me/sessions", scoped(domain.ScopeUsersRead, sessionHandler.ListSessions)).Methods("GET")
+	authenticated.Handle("/me/sessions/{id}", scoped(domain.ScopeUsersWrite, sessionHandler.RevokeSession)).Methods("DELETE")
+	authenticated.Handle("/me/logout-all", scoped(domain.ScopeUsersWrite, authHandler.LogoutAll)).Methods("POST")
+	authenticated.Handle("/me/login-history", scoped(domain.ScopeUsersRead, loginHistoryHandler.GetLoginHistory)).Methods("GET")
+
+	// Feature flag routes
+	authenticated.HandleFunc("/features", adminHandler.ListFeatures).Methods("GET")
 
 	// Health check route (no authentication required)
 	api.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -61,5 +320,13 @@ func NewRouter(
 		w.Write([]byte(`{"status":"ok"}`))
 	}).Methods("GET")
 
+	// Public status route (no authentication required; limited per client on top of the global rate limit)
+	status := api.PathPrefix("/status").Subrouter()
+	status.Use(mux.MiddlewareFunc(middleware.Timeout(timeouts.Default, log)))
+	status.Use(mux.MiddlewareFunc(middleware.RateLimit(func() (int, int) {
+		return cfg.Server.HTTP.Status.RequestsPerMinute, cfg.Server.HTTP.Status.Burst
+	})))
+	status.HandleFunc("", statusHandler.GetStatus).Methods("GET")
+
 	return router
 }
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
+
+	"google.golang.org/grpc"
 
 	"task-management-system/config"
+	"task-management-system/internal/delivery/grpc/connect"
+	"task-management-system/internal/delivery/grpc/grpcweb"
+	"task-management-system/internal/delivery/http/middleware"
 	"task-management-system/internal/delivery/http/routes"
 	"task-management-system/internal/logger"
 	"task-management-system/internal/usecase"
@@ -14,37 +18,99 @@ import (
 
 // Server represents HTTP server
 type Server struct {
-	server *http.Server
-	router http.Handler
-	cfg    *config.Config
+	server          *http.Server
+	router          http.Handler
+	cfg             *config.Config
+	log             logger.Logger
+	runtimeSettings *config.RuntimeSettings
 }
 
-// NewServer creates a new HTTP server
+// NewServer creates a new HTTP server logging to the given logger
 func NewServer(
 	cfg *config.Config,
+	log logger.Logger,
 	taskUseCase *usecase.TaskUseCase,
 	userUseCase *usecase.UserUseCase,
 	authUseCase *usecase.AuthUseCase,
+	starUseCase *usecase.StarUseCase,
+	todayUseCase *usecase.TodayUseCase,
+	notificationUseCase *usecase.NotificationUseCase,
+	organizationUseCase *usecase.OrganizationUseCase,
+	invitationUseCase *usecase.InvitationUseCase,
+	projectUseCase *usecase.ProjectUseCase,
+	auditUseCase *usecase.AuditUseCase,
+	attachmentUseCase *usecase.AttachmentUseCase,
+	avatarUseCase *usecase.AvatarUseCase,
+	exportUseCase *usecase.ExportUseCase,
+	mergeUseCase *usecase.MergeUseCase,
+	inboundHookUseCase *usecase.InboundHookUseCase,
+	calendarFeedUseCase *usecase.CalendarFeedUseCase,
+	reminderUseCase *usecase.ReminderUseCase,
+	statusUseCase *usecase.StatusUseCase,
+	runtimeSettings *config.RuntimeSettings,
 ) *Server {
 	// Create router
-	router := routes.NewRouter(taskUseCase, userUseCase, authUseCase)
+	router := routes.NewRouter(cfg, log, taskUseCase, userUseCase, authUseCase, starUseCase, todayUseCase, notificationUseCase, organizationUseCase, invitationUseCase, projectUseCase, auditUseCase, attachmentUseCase, avatarUseCase, exportUseCase, mergeUseCase, inboundHookUseCase, calendarFeedUseCase, reminderUseCase, statusUseCase, runtimeSettings)
 
 	// Create server
 	server := &http.Server{
-		Addr:         fmt.Sprintf(":%d", cfg.Server.HTTP.Port),
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Addr:              fmt.Sprintf(":%d", cfg.Server.HTTP.Port),
+		Handler:           router,
+		ReadTimeout:       cfg.Server.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.Server.HTTP.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.HTTP.MaxHeaderBytes,
 	}
 
 	return &Server{
-		server: server,
-		router: router,
-		cfg:    cfg,
+		server:          server,
+		router:          router,
+		cfg:             cfg,
+		log:             log,
+		runtimeSettings: runtimeSettings,
 	}
 }
 
+// EnableGRPCWeb serves the services of a gRPC server to browsers over gRPC-Web,
+// next to the REST API. Call it before Start.
+func (s *Server) EnableGRPCWeb(grpcServer *grpc.Server) {
+	s.serveRPC(grpcweb.NewHandler(grpcServer))
+	s.log.InfoF("gRPC-Web enabled on the HTTP port")
+}
+
+// EnableConnect serves the services of a gRPC server over the Connect protocol,
+// next to the REST API. Call it before Start.
+func (s *Server) EnableConnect(grpcServer *grpc.Server) {
+	s.serveRPC(connect.NewHandler(grpcServer))
+	s.log.InfoF("Connect protocol enabled on the HTTP port")
+}
+
+// rpcHandler serves the requests of an RPC protocol
+type rpcHandler interface {
+	http.Handler
+	Handles(r *http.Request) bool
+}
+
+// serveRPC routes the requests an RPC protocol handles to it, and the others to the current handler
+func (s *Server) serveRPC(rpc rpcHandler) {
+	handler := middleware.Chain(rpc,
+		middleware.RPCCORS(func() []string {
+			return s.runtimeSettings.Get().CORSOrigins
+		}),
+		middleware.Recover(s.log),
+	)
+
+	next := s.server.Handler
+	s.server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rpc.Handles(r) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // GetRouter returns the router
 func (s *Server) GetRouter() http.Handler {
 	return s.router
@@ -52,7 +118,7 @@ func (s *Server) GetRouter() http.Handler {
 
 // Start starts the HTTP server
 func (s *Server) Start() error {
-	logger.InfoF("Starting HTTP server on port %d", s.cfg.Server.HTTP.Port)
+	s.log.InfoF("Starting HTTP server on port %d", s.cfg.Server.HTTP.Port)
 	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		return err
 	}
@@ -61,6 +127,6 @@ func (s *Server) Start() error {
 
 // Stop stops the HTTP server
 func (s *Server) Stop(ctx context.Context) error {
-	logger.InfoF("Stopping HTTP server")
+	s.log.InfoF("Stopping HTTP server")
 	return s.server.Shutdown(ctx)
 }
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"task-management-system/config"
+	"task-management-system/internal/logger"
+)
+
+// StartupProbe answers on the HTTP port while the server is still starting,
+// such as while it waits for MongoDB. The health route reports "initializing"
+// with 503 Service Unavailable, as does every other route, so that probes and
+// load balancers hold traffic back until the server is ready.
+type StartupProbe struct {
+	server *http.Server
+	log    logger.Logger
+}
+
+// NewStartupProbe creates a startup probe on the configured HTTP port
+func NewStartupProbe(cfg *config.Config, log logger.Logger) *StartupProbe {
+	health := cfg.Server.HTTP.BasePath + "/health"
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		if r.URL.Path == health {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":"initializing"}`))
+			return
+		}
+		http.Error(w, "Server is starting", http.StatusServiceUnavailable)
+	})
+
+	return &StartupProbe{
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Server.HTTP.Port),
+			Handler:           handler,
+			ReadHeaderTimeout: cfg.Server.HTTP.ReadHeaderTimeout,
+		},
+		log: log,
+	}
+}
+
+// Start serves the startup probe in the background. Failing to listen is only
+// logged: the probe is a courtesy, and the server itself reports the error.
+func (p *StartupProbe) Start() {
+	go func() {
+		if err := p.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			p.log.WarnF("Startup probe stopped: %v", err)
+		}
+	}()
+}
+
+// Stop frees the HTTP port for the server; call it right before starting it
+func (p *StartupProbe) Stop(ctx context.Context) error {
+	return p.server.Shutdown(ctx)
+}
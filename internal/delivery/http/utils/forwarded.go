@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Headers set by reverse proxies and path-based ingress routing
+const (
+	ForwardedProtoHeader  = "X-Forwarded-Proto"
+	ForwardedHostHeader   = "X-Forwarded-Host"
+	ForwardedPrefixHeader = "X-Forwarded-Prefix"
+)
+
+// ExternalURL returns the absolute URL clients use to reach a path of this
+// service. Behind a reverse proxy the X-Forwarded-Proto, X-Forwarded-Host and
+// X-Forwarded-Prefix headers replace the scheme, host and path prefix the
+// service sees itself.
+func ExternalURL(r *http.Request, p string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := firstForwarded(r.Header.Get(ForwardedProtoHeader)); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	host := r.Host
+	if forwarded := firstForwarded(r.Header.Get(ForwardedHostHeader)); forwarded != "" {
+		host = forwarded
+	}
+
+	return scheme + "://" + host + ExternalPath(r, p)
+}
+
+// ExternalPath returns a path of this service prefixed with X-Forwarded-Prefix, if any
+func ExternalPath(r *http.Request, p string) string {
+	prefix := strings.TrimRight(firstForwarded(r.Header.Get(ForwardedPrefixHeader)), "/")
+	if prefix == "" || !strings.HasPrefix(prefix, "/") {
+		return p
+	}
+	return prefix + p
+}
+
+// SetLocation points the Location header of a created resource at the
+// request path followed by the resource ID, e.g. /api/v1/tasks/{id}
+func SetLocation(w http.ResponseWriter, r *http.Request, id string) {
+	w.Header().Set("Location", ExternalURL(r, path.Join(r.URL.Path, id)))
+}
+
+// firstForwarded returns the first of the comma-separated values proxies append
+// to a forwarded header; it was set by the proxy closest to the client
+func firstForwarded(value string) string {
+	first, _, _ := strings.Cut(value, ",")
+	return strings.TrimSpace(first)
+}
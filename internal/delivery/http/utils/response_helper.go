@@ -2,7 +2,22 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+
+	"task-management-system/internal/domain"
+)
+
+// Headers describing list responses
+const (
+	// TotalCountHeader carries the total number of items of a list response
+	TotalCountHeader = "X-Total-Count"
+	// PageSizeHeader carries the page size applied to a paged list response
+	PageSizeHeader = "X-Page-Size"
+	// PageSizeClampedHeader is set to true when the requested page size exceeded
+	// the maximum and was lowered to it
+	PageSizeClampedHeader = "X-Page-Size-Clamped"
 )
 
 // ResponseWrapper standardizes API responses
@@ -16,6 +31,15 @@ type ResponseWrapper struct {
 type ErrorInfo struct {
 	Code    int    `json:"code" example:"404"`
 	Message string `json:"message" example:"Resource not found"`
+	// Fields lists the invalid input fields of validation errors
+	Fields []domain.FieldError `json:"fields,omitempty"`
+	// Reason identifies errors clients may handle specially, e.g. INVALID_STATUS_TRANSITION
+	Reason string `json:"reason,omitempty" example:"INVALID_STATUS_TRANSITION"`
+	// CurrentStatus and RequestedStatus describe invalid status transitions,
+	// and AllowedStatuses lists where the task can move instead
+	CurrentStatus   domain.TaskStatus   `json:"current_status,omitempty" example:"in_progress"`
+	RequestedStatus domain.TaskStatus   `json:"requested_status,omitempty" example:"pending"`
+	AllowedStatuses []domain.TaskStatus `json:"allowed_statuses,omitempty"`
 }
 
 // RespondWithError sends an error response in a standardized format
@@ -34,6 +58,32 @@ func RespondWithError(w http.ResponseWriter, code int, message string) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// RespondWithInvalidInput sends a 400 error response for invalid input, listing
+// the invalid fields when the error is a domain.ValidationError and describing
+// the transition when it is a domain.StatusTransitionError
+func RespondWithInvalidInput(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	info := &ErrorInfo{
+		Code:    http.StatusBadRequest,
+		Message: err.Error(),
+	}
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		info.Fields = validation.Fields
+	}
+	var transition *domain.StatusTransitionError
+	if errors.As(err, &transition) {
+		info.Reason = domain.ReasonInvalidStatusTransition
+		info.CurrentStatus = transition.Current
+		info.RequestedStatus = transition.Requested
+		info.AllowedStatuses = transition.Current.Transitions()
+	}
+
+	json.NewEncoder(w).Encode(ResponseWrapper{Success: false, Error: info})
+}
+
 // RespondWithJSON sends a success response in a standardized format
 func RespondWithJSON(w http.ResponseWriter, code int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -47,6 +97,23 @@ func RespondWithJSON(w http.ResponseWriter, code int, data interface{}) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// RespondWithList sends a list in the standard success format and reports the
+// total number of items in the X-Total-Count header
+func RespondWithList(w http.ResponseWriter, code int, data interface{}, total int64) {
+	w.Header().Set(TotalCountHeader, strconv.FormatInt(total, 10))
+	RespondWithJSON(w, code, data)
+}
+
+// RespondWithPage sends a page of a list like RespondWithList, and also reports
+// the page size applied and whether the requested one was lowered to the maximum
+func RespondWithPage(w http.ResponseWriter, code int, data interface{}, total int64, pageSize int64, clamped bool) {
+	w.Header().Set(PageSizeHeader, strconv.FormatInt(pageSize, 10))
+	if clamped {
+		w.Header().Set(PageSizeClampedHeader, "true")
+	}
+	RespondWithList(w, code, data, total)
+}
+
 // RespondWithJSONDirect sends the raw data as JSON response (without the wrapper)
 // Useful for backward compatibility with existing clients
 func RespondWithJSONDirect(w http.ResponseWriter, code int, data interface{}) {
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"task-management-system/config"
+)
+
+// CSRFHeader carries the CSRF token on requests authenticated by the session cookie
+const CSRFHeader = "X-CSRF-Token"
+
+// SessionCookies writes and reads the cookies of cookie-based authentication. The
+// access token is kept in an HttpOnly cookie, out of reach of scripts. Requests
+// authenticated by it are protected from CSRF with a double-submit token: a
+// readable cookie whose value must be echoed in the X-CSRF-Token header.
+type SessionCookies struct {
+	name     string
+	csrfName string
+	domain   string
+	secure   bool
+	sameSite http.SameSite
+}
+
+// NewSessionCookies creates session cookies from the configuration, or returns nil
+// when cookie-based authentication is disabled
+func NewSessionCookies(cfg config.CookieConfig) *SessionCookies {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	sameSite := http.SameSiteLaxMode
+	switch strings.ToLower(cfg.SameSite) {
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+
+	return &SessionCookies{
+		name:     cfg.Name,
+		csrfName: cfg.Name + "_csrf",
+		domain:   cfg.Domain,
+		secure:   cfg.Secure,
+		sameSite: sameSite,
+	}
+}
+
+// SetSession stores an access token in the session cookie until it expires
+func (c *SessionCookies) SetSession(w http.ResponseWriter, token string, expiresAt time.Time) {
+	http.SetCookie(w, c.cookie(c.name, token, expiresAt, true))
+}
+
+// ClearSession removes the session and CSRF cookies
+func (c *SessionCookies) ClearSession(w http.ResponseWriter) {
+	http.SetCookie(w, c.cookie(c.name, "", time.Unix(0, 0), true))
+	http.SetCookie(w, c.cookie(c.csrfName, "", time.Unix(0, 0), false))
+}
+
+// Session returns the access token from the session cookie, or "" when there is none
+func (c *SessionCookies) Session(r *http.Request) string {
+	cookie, err := r.Cookie(c.name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// IssueCSRF generates a CSRF token and stores it in the readable CSRF cookie
+func (c *SessionCookies) IssueCSRF(w http.ResponseWriter) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	// Lives as long as the browser session; scripts must read it to send it back
+	http.SetCookie(w, c.cookie(c.csrfName, token, time.Time{}, false))
+	return token, nil
+}
+
+// ValidCSRF reports whether the request echoes the CSRF cookie in the X-CSRF-Token header
+func (c *SessionCookies) ValidCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(c.csrfName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	header := r.Header.Get(CSRFHeader)
+	return subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) == 1
+}
+
+// cookie builds a cookie with the configured scope and attributes
+func (c *SessionCookies) cookie(name string, value string, expires time.Time, httpOnly bool) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   c.domain,
+		Expires:  expires,
+		Secure:   c.secure,
+		HttpOnly: httpOnly,
+		SameSite: c.sameSite,
+	}
+	if value == "" {
+		cookie.MaxAge = -1
+	}
+	return cookie
+}
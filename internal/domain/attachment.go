@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttachmentStatus tracks whether an attachment's content has been uploaded
+type AttachmentStatus string
+
+const (
+	AttachmentStatusPending AttachmentStatus = "pending"
+	AttachmentStatusReady   AttachmentStatus = "ready"
+)
+
+// ThumbnailStatus tracks the thumbnail of an image attachment. Attachments that
+// are not images have none.
+type ThumbnailStatus string
+
+const (
+	ThumbnailStatusPending     ThumbnailStatus = "pending"
+	ThumbnailStatusReady       ThumbnailStatus = "ready"
+	ThumbnailStatusUnavailable ThumbnailStatus = "unavailable" // The image could not be decoded
+)
+
+// ScanStatus tracks the malware scan of an attachment. Attachments uploaded while
+// scanning is disabled have none.
+type ScanStatus string
+
+const (
+	ScanStatusPending     ScanStatus = "pending"
+	ScanStatusClean       ScanStatus = "clean"
+	ScanStatusQuarantined ScanStatus = "quarantined" // Infected or could not be scanned; cannot be downloaded
+)
+
+// Attachment is a file attached to a task. Its content is kept in a BlobStore.
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID       primitive.ObjectID `bson:"org_id" json:"org_id"`
+	TaskID      primitive.ObjectID `bson:"task_id" json:"task_id"`
+	Name        string             `bson:"name" json:"name"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	Size        int64              `bson:"size" json:"size"` // Bytes; as declared until the upload is complete
+	Status      AttachmentStatus   `bson:"status" json:"status"`
+	StorageKey  string             `bson:"storage_key" json:"-"`
+	UploadedBy  primitive.ObjectID `bson:"uploaded_by" json:"uploaded_by"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+
+	Thumbnail    ThumbnailStatus `bson:"thumbnail,omitempty" json:"thumbnail,omitempty"`
+	ThumbnailKey string          `bson:"thumbnail_key,omitempty" json:"-"`
+
+	Scan       ScanStatus `bson:"scan,omitempty" json:"scan,omitempty"`
+	ScanThreat string     `bson:"scan_threat,omitempty" json:"scan_threat,omitempty"` // Why the attachment was quarantined
+}
+
+// AttachmentRepository defines the interface for attachment data access
+type AttachmentRepository interface {
+	Create(attachment *Attachment) error
+	FindByID(id primitive.ObjectID) (*Attachment, error)
+	// FindByTask returns a task's attachments, oldest first
+	FindByTask(taskID primitive.ObjectID) ([]*Attachment, error)
+	// FindByOrg returns an organization's attachments, oldest first
+	FindByOrg(orgID primitive.ObjectID) ([]*Attachment, error)
+	// MarkReady records that the content of a pending attachment has been uploaded,
+	// with the status of its thumbnail and scan; empty when it gets none
+	MarkReady(id primitive.ObjectID, size int64, thumbnail ThumbnailStatus, scan ScanStatus) error
+	// FindPendingThumbnails returns up to limit attachments whose thumbnail is
+	// pending, oldest first. Attachments not yet scanned or quarantined are skipped.
+	FindPendingThumbnails(limit int64) ([]*Attachment, error)
+	// SetThumbnail records the status of an attachment's thumbnail and where it is stored
+	SetThumbnail(id primitive.ObjectID, status ThumbnailStatus, key string) error
+	// FindPendingScans returns up to limit attachments whose scan is pending, oldest first
+	FindPendingScans(limit int64) ([]*Attachment, error)
+	// MarkClean records that an attachment's scan found nothing
+	MarkClean(id primitive.ObjectID) error
+	// Quarantine records why an attachment was quarantined and where its content was moved
+	Quarantine(id primitive.ObjectID, threat string, key string) error
+	// MoveToTask moves all attachments of a task to another task, returning how many were moved
+	MoveToTask(from, to primitive.ObjectID) (int64, error)
+	Delete(id primitive.ObjectID) error
+}
+
+// ScanResult is the verdict of a malware scan
+type ScanResult struct {
+	Clean bool
+	// Threat names what was found, or why the scanner refused the file, e.g. for
+	// being too large
+	Threat string
+}
+
+// Scanner checks files for malware
+type Scanner interface {
+	// Scan scans a file. Errors mean the scan could not be made and may be retried.
+	Scan(content io.Reader) (*ScanResult, error)
+}
+
+// Thumbnailer scales images down into thumbnails
+type Thumbnailer interface {
+	// Supports reports whether images of the media type can be thumbnailed
+	Supports(contentType string) bool
+	// Thumbnail returns a thumbnail of an image and its media type
+	Thumbnail(image io.Reader) ([]byte, string, error)
+}
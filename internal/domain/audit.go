@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditAction identifies a privileged operation recorded in the audit log
+type AuditAction string
+
+const (
+	AuditOrganizationUpdated  AuditAction = "organization.updated"
+	AuditOrganizationImported AuditAction = "organization.imported"
+	AuditMemberRoleChanged    AuditAction = "organization.member_role_changed"
+	AuditMemberRemoved        AuditAction = "organization.member_removed"
+	AuditImpersonationStarted AuditAction = "user.impersonation_started"
+	AuditImpersonationRevoked AuditAction = "user.impersonation_revoked"
+	AuditInvitationCreated    AuditAction = "invitation.created"
+	AuditInvitationRevoked    AuditAction = "invitation.revoked"
+	AuditProjectDeleted       AuditAction = "project.deleted"
+	AuditProjectMemberRoleSet AuditAction = "project.member_role_set"
+	AuditProjectMemberRemoved AuditAction = "project.member_removed"
+	AuditUserUpdated          AuditAction = "user.updated"
+	AuditUserDeleted          AuditAction = "user.deleted"
+	AuditTaskCreated          AuditAction = "task.created"
+	AuditTaskUpdated          AuditAction = "task.updated"
+	AuditTaskDeleted          AuditAction = "task.deleted"
+	AuditTaskAssigned         AuditAction = "task.assigned"
+	AuditTaskUnassigned       AuditAction = "task.unassigned"
+)
+
+// AuditTargetType identifies the kind of object a privileged operation acted on
+type AuditTargetType string
+
+const (
+	AuditTargetOrganization AuditTargetType = "organization"
+	AuditTargetUser         AuditTargetType = "user"
+	AuditTargetInvitation   AuditTargetType = "invitation"
+	AuditTargetProject      AuditTargetType = "project"
+	AuditTargetTask         AuditTargetType = "task"
+)
+
+// AuditEntry records a privileged operation. Entries are append-only: once
+// written they are never updated or deleted.
+type AuditEntry struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID      primitive.ObjectID `bson:"org_id" json:"org_id"`
+	Action     AuditAction        `bson:"action" json:"action"`
+	ActorID    primitive.ObjectID `bson:"actor_id" json:"actor_id"`
+	TargetType AuditTargetType    `bson:"target_type" json:"target_type"`
+	TargetID   primitive.ObjectID `bson:"target_id" json:"target_id"`
+	Details    map[string]string  `bson:"details,omitempty" json:"details,omitempty"` // Action-specific context, e.g. the old and new role
+	// ImpersonatorID is the admin who made the operation while acting as the actor
+	ImpersonatorID primitive.ObjectID `bson:"impersonator_id,omitempty" json:"impersonator_id,omitempty"`
+	IP             string             `bson:"ip" json:"ip"`
+	UserAgent      string             `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// AuditFilter selects audit entries of an organization. Zero-valued fields match everything.
+type AuditFilter struct {
+	OrgID    primitive.ObjectID
+	ActorID  primitive.ObjectID
+	TargetID primitive.ObjectID
+	Action   AuditAction
+	From     time.Time
+	To       time.Time
+	Limit    int64
+}
+
+// AuditRepository defines the interface for audit log access. It can only append
+// and read entries.
+type AuditRepository interface {
+	Append(entry *AuditEntry) error
+	// Find returns the entries matching the filter, newest first
+	Find(filter AuditFilter) ([]*AuditEntry, error)
+}
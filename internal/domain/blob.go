@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+// BlobInfo describes stored content
+type BlobInfo struct {
+	Size        int64
+	ContentType string
+}
+
+// BlobStore stores the contents of files, such as attachments and avatars, by key
+type BlobStore interface {
+	// Put stores content under a key, replacing any content stored before. The size
+	// is the length of the content, or -1 when unknown, which not every store supports.
+	Put(key string, content io.Reader, size int64, contentType string) error
+	// Open returns the content stored under a key, or ErrNotFound
+	Open(key string) (io.ReadCloser, error)
+	// Stat describes the content stored under a key, or returns ErrNotFound
+	Stat(key string) (*BlobInfo, error)
+	// Delete removes the content stored under a key; deleting missing content is not an error
+	Delete(key string) error
+}
+
+// BlobPresigner is implemented by blob stores that clients can reach directly,
+// such as S3. Presigned URLs let clients transfer contents without them passing
+// through the API server.
+type BlobPresigner interface {
+	// PresignUpload returns a URL the content can be PUT to with the given content type
+	PresignUpload(key string, contentType string, expiry time.Duration) (string, error)
+	// PresignDownload returns a URL the content can be downloaded from, as a file with the given name
+	PresignDownload(key string, filename string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CalendarFeed is a user's iCalendar subscription URL, which lists their open
+// tasks with due dates. Each user has at most one; rotating it replaces the token.
+type CalendarFeed struct {
+	UserID primitive.ObjectID `bson:"_id" json:"user_id"`
+	OrgID  primitive.ObjectID `bson:"org_id" json:"org_id"`
+	// TokenHash is the SHA-256 of the feed's token; the token itself is only shown once
+	TokenHash     string     `bson:"token_hash" json:"-"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	LastFetchedAt *time.Time `bson:"last_fetched_at,omitempty" json:"last_fetched_at,omitempty"`
+}
+
+// CalendarFeedRepository defines the interface for calendar feed data access
+type CalendarFeedRepository interface {
+	// Save creates the user's feed or replaces the existing one
+	Save(feed *CalendarFeed) error
+	FindByUser(userID primitive.ObjectID) (*CalendarFeed, error)
+	FindByTokenHash(tokenHash string) (*CalendarFeed, error)
+	// Touch records that the feed was fetched at the given time
+	Touch(userID primitive.ObjectID, at time.Time) error
+	Delete(userID primitive.ObjectID) error
+}
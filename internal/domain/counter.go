@@ -0,0 +1,16 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// CounterRepository hands out sequence numbers, such as the numbers of a project's tasks
+type CounterRepository interface {
+	// Next atomically increments the named counter and returns its new value; the first value is 1
+	Next(name string) (int64, error)
+	// Raise raises the named counter to at least the value, so Next returns values above it
+	Raise(name string, value int64) error
+}
+
+// ProjectTaskCounter names the counter of a project's task numbers
+func ProjectTaskCounter(projectID primitive.ObjectID) string {
+	return "project_tasks:" + projectID.Hex()
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DayPlanDateLayout is the layout of the dates day plans are stored under
+const DayPlanDateLayout = "2006-01-02"
+
+// DayPlanItem is a task a user added to their plan for a day, in their "My Day" view
+type DayPlanItem struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID  primitive.ObjectID `bson:"org_id" json:"org_id"`
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TaskID primitive.ObjectID `bson:"task_id" json:"task_id"`
+	// Date is the day in the user's time zone, e.g. 2025-03-10
+	Date    string    `bson:"date" json:"date"`
+	AddedAt time.Time `bson:"added_at" json:"added_at"`
+}
+
+// DayPlanRepository defines the interface for day plan data access
+type DayPlanRepository interface {
+	// Add adds a task to a user's plan for a day; adding a planned task is a no-op
+	Add(item *DayPlanItem) error
+	// Remove removes a task from a user's plan for a day
+	Remove(userID primitive.ObjectID, date string, taskID primitive.ObjectID) error
+	// FindByDay returns the items of a user's plan for a day, in the order they were added
+	FindByDay(userID primitive.ObjectID, date string) ([]*DayPlanItem, error)
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeferredNotification is a notification held back from an external channel
+// during its recipient's quiet hours, to be sent in a summary once they end
+type DeferredNotification struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID       primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Channel      NotificationChannel `bson:"channel" json:"channel"`
+	Notification Notification        `bson:"notification" json:"notification"`
+	DeliverAt    time.Time           `bson:"deliver_at" json:"deliver_at"`
+	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// DeferredNotificationRepository defines the interface for deferred notification data access
+type DeferredNotificationRepository interface {
+	Create(deferred *DeferredNotification) error
+	// FindDueUsers finds up to limit users with deferred notifications due by the given time
+	FindDueUsers(now time.Time, limit int64) ([]primitive.ObjectID, error)
+	// FindDue finds a user's deferred notifications due by the given time, oldest first
+	FindDue(userID primitive.ObjectID, now time.Time) ([]*DeferredNotification, error)
+	// Postpone moves a user's deferred notifications due by the given time to a later one
+	Postpone(userID primitive.ObjectID, now time.Time, until time.Time) error
+	DeleteMany(ids []primitive.ObjectID) (int64, error)
+	// DeleteDueBefore deletes all deferred notifications that were due before the
+	// cutoff but never sent, and returns how many were removed
+	DeleteDueBefore(cutoff time.Time) (int64, error)
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Define domain error types
 var (
@@ -19,3 +23,64 @@ var (
 	// ErrInternalServer represents an internal server error
 	ErrInternalServer = errors.New("internal server error")
 )
+
+// FieldError describes why an input field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists the invalid fields of an input. It matches ErrInvalidInput
+// with errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error lists the fields and their problems
+func (e *ValidationError) Error() string {
+	problems := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		problems = append(problems, field.Field+" "+field.Message)
+	}
+	return ErrInvalidInput.Error() + ": " + strings.Join(problems, "; ")
+}
+
+// Is makes the error match ErrInvalidInput
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// ReasonInvalidStatusTransition identifies StatusTransitionErrors to API clients
+const ReasonInvalidStatusTransition = "INVALID_STATUS_TRANSITION"
+
+// StatusTransitionError reports that a task cannot move from its current
+// status to the requested one. It matches ErrInvalidInput with errors.Is.
+type StatusTransitionError struct {
+	Current   TaskStatus
+	Requested TaskStatus
+}
+
+// Error names both statuses
+func (e *StatusTransitionError) Error() string {
+	if !e.Requested.IsValid() {
+		return fmt.Sprintf("%s: unknown status %q", ErrInvalidInput, e.Requested)
+	}
+	return fmt.Sprintf("%s: tasks cannot move from %s to %s", ErrInvalidInput, e.Current, e.Requested)
+}
+
+// Is makes the error match ErrInvalidInput
+func (e *StatusTransitionError) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// BulkWriteError reports the items of a bulk write that failed. Items not listed
+// were written, unless the write ran in a transaction, which the failure aborts.
+type BulkWriteError struct {
+	// Failed maps the index of every failed item to why it failed, e.g. ErrDuplicateKey
+	Failed map[int]error
+}
+
+// Error counts the failed items
+func (e *BulkWriteError) Error() string {
+	return fmt.Sprintf("bulk write failed for %d items", len(e.Failed))
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventType identifies the kind of domain event
+type EventType string
+
+const (
+	EventTaskCreated       EventType = "task.created"
+	EventTaskUpdated       EventType = "task.updated"
+	EventTaskStatusChanged EventType = "task.status_changed"
+	EventTaskAssigned      EventType = "task.assigned"
+	EventTaskUnassigned    EventType = "task.unassigned"
+	EventTaskDeleted       EventType = "task.deleted"
+	EventTaskUnsnoozed     EventType = "task.unsnoozed"
+	EventTaskEscalated     EventType = "task.escalated"
+	EventTaskMerged        EventType = "task.merged"
+	// Assignments that await the assignee's answer, and the answers
+	EventTaskAssignmentRequested EventType = "task.assignment_requested"
+	EventTaskAssignmentAccepted  EventType = "task.assignment_accepted"
+	EventTaskAssignmentDeclined  EventType = "task.assignment_declined"
+	// EventTaskReminder is a reminder a user set on a task. It is delivered to
+	// that user only, and never published on the event bus.
+	EventTaskReminder EventType = "task.reminder"
+	// EventNotificationSummary is the message summarizing the notifications held
+	// back during a user's quiet hours. It is never published on the event bus.
+	EventNotificationSummary EventType = "notification.summary"
+
+	EventAttachmentQuarantined EventType = "attachment.quarantined"
+)
+
+// Event represents something that happened to an entity, emitted by the use cases
+type Event struct {
+	Type       EventType          `bson:"type" json:"type"`
+	ActorID    primitive.ObjectID `bson:"actor_id" json:"actor_id"`
+	SubjectID  primitive.ObjectID `bson:"subject_id,omitempty" json:"subject_id,omitempty"` // User affected by the event, e.g. the assignee
+	Task       *Task              `bson:"task,omitempty" json:"task,omitempty"`             // Task state after the change
+	Attachment *Attachment        `bson:"attachment,omitempty" json:"attachment,omitempty"` // For attachment events
+	OccurredAt time.Time          `bson:"occurred_at" json:"occurred_at"`
+}
+
+// Summary returns a short human-readable description of a task event for a
+// shared audience, such as a project's chat channel
+func (e *Event) Summary() string {
+	if e.Task == nil {
+		return string(e.Type)
+	}
+
+	switch e.Type {
+	case EventTaskCreated:
+		return fmt.Sprintf("%q was created", e.Task.Title)
+	case EventTaskAssigned:
+		return fmt.Sprintf("%q was assigned", e.Task.Title)
+	case EventTaskUnassigned:
+		return fmt.Sprintf("An assignee was removed from %q", e.Task.Title)
+	case EventTaskAssignmentRequested:
+		return fmt.Sprintf("%q was assigned, pending acceptance", e.Task.Title)
+	case EventTaskAssignmentAccepted:
+		return fmt.Sprintf("An assignment to %q was accepted", e.Task.Title)
+	case EventTaskAssignmentDeclined:
+		return fmt.Sprintf("An assignment to %q was declined", e.Task.Title)
+	case EventTaskStatusChanged:
+		return fmt.Sprintf("%q is now %s", e.Task.Title, e.Task.Status)
+	case EventTaskDeleted:
+		return fmt.Sprintf("%q was deleted", e.Task.Title)
+	case EventTaskUnsnoozed:
+		return fmt.Sprintf("%q is back from snooze", e.Task.Title)
+	case EventTaskMerged:
+		return fmt.Sprintf("%q was merged into another task", e.Task.Title)
+	case EventTaskEscalated:
+		return fmt.Sprintf("%q is overdue and was escalated", e.Task.Title)
+	case EventAttachmentQuarantined:
+		return fmt.Sprintf("A file attached to %q was quarantined by the malware scan", e.Task.Title)
+	default:
+		return fmt.Sprintf("%q was updated", e.Task.Title)
+	}
+}
+
+// EventPublisher defines the interface for publishing domain events
+type EventPublisher interface {
+	Publish(event *Event)
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExportFormat identifies documents in the export format
+const ExportFormat = "task-management-system/export"
+
+// ExportVersion is the version of the export format written by this release.
+// Imports accept every version up to it; fields are only ever added, so older
+// documents stay importable.
+const ExportVersion = 1
+
+// Export is an organization's data in the export format: a JSON document that
+// can be imported into another instance. References between records use the
+// exported IDs, which imports keep. Attachment contents are not included.
+type Export struct {
+	Format       string               `json:"format"`
+	Version      int                  `json:"version"`
+	ExportedAt   time.Time            `json:"exported_at"`
+	Organization ExportedOrganization `json:"organization"`
+	Users        []ExportedUser       `json:"users"`
+	Projects     []ExportedProject    `json:"projects"`
+	Tasks        []ExportedTask       `json:"tasks"`
+	Attachments  []ExportedAttachment `json:"attachments"`
+}
+
+// ExportedOrganization is an organization in the export format
+type ExportedOrganization struct {
+	ID        primitive.ObjectID `json:"id"`
+	Name      string             `json:"name"`
+	CreatedBy primitive.ObjectID `json:"created_by"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// ExportedUser is a user in the export format
+type ExportedUser struct {
+	ID        primitive.ObjectID `json:"id"`
+	Username  string             `json:"username"`
+	Email     string             `json:"email"`
+	FirstName string             `json:"first_name,omitempty"`
+	LastName  string             `json:"last_name,omitempty"`
+	Timezone  string             `json:"timezone,omitempty"`
+	OrgRole   OrgRole            `json:"org_role"`
+	// PasswordHash is only exported with credentials, by operators
+	PasswordHash      string    `json:"password_hash,omitempty"`
+	PasswordChangedAt time.Time `json:"password_changed_at,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ExportedProject is a project in the export format
+type ExportedProject struct {
+	ID          primitive.ObjectID `json:"id"`
+	Key         string             `json:"key,omitempty"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Members     []ProjectMember    `json:"members"`
+	CreatedBy   primitive.ObjectID `json:"created_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// ExportedTask is a task in the export format
+type ExportedTask struct {
+	ID          primitive.ObjectID   `json:"id"`
+	ProjectID   primitive.ObjectID   `json:"project_id,omitempty"`
+	Number      int64                `json:"number,omitempty"`
+	Key         string               `json:"key,omitempty"`
+	Title       string               `json:"title"`
+	Description string               `json:"description,omitempty"`
+	Status      TaskStatus           `json:"status"`
+	Priority    int                  `json:"priority"`
+	DueDate     time.Time            `json:"due_date"`
+	AssignedTo  []primitive.ObjectID `json:"assigned_to,omitempty"`
+	CreatedBy   primitive.ObjectID   `json:"created_by"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// ExportedAttachment is the metadata of a ready attachment in the export format.
+// The content stays in the blob store under the storage key; copy it along for
+// downloads to work after an import.
+type ExportedAttachment struct {
+	ID          primitive.ObjectID `json:"id"`
+	TaskID      primitive.ObjectID `json:"task_id"`
+	Name        string             `json:"name"`
+	ContentType string             `json:"content_type"`
+	Size        int64              `json:"size"`
+	StorageKey  string             `json:"storage_key"`
+	UploadedBy  primitive.ObjectID `json:"uploaded_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// ImportRepository stores imported records as they are, keeping their IDs and
+// timestamps. Each method skips the records that already exist, or that conflict
+// with an existing unique key, and returns how many it inserted.
+type ImportRepository interface {
+	InsertOrganization(org *Organization) (int64, error)
+	InsertUsers(users []*User) (int64, error)
+	InsertProjects(projects []*Project) (int64, error)
+	InsertTasks(tasks []*Task) (int64, error)
+	InsertAttachments(attachments []*Attachment) (int64, error)
+}
@@ -0,0 +1,7 @@
+package domain
+
+// Pinger checks that a service the application depends on, such as the
+// database, answers
+type Pinger interface {
+	Ping() error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Task fields an inbound hook's mapping can fill in
+const (
+	HookFieldTitle       = "title"
+	HookFieldDescription = "description"
+	HookFieldPriority    = "priority"
+	HookFieldDueDate     = "due_date"
+	HookFieldTags        = "tags"
+)
+
+// HookFields lists the task fields an inbound hook's mapping can fill in
+var HookFields = []string{HookFieldTitle, HookFieldDescription, HookFieldPriority, HookFieldDueDate, HookFieldTags}
+
+// InboundHook lets an external integration, such as Zapier or IFTTT, create
+// tasks in a project by posting JSON to a secret URL. Tasks are created on
+// behalf of the hook's creator.
+type InboundHook struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID     primitive.ObjectID `bson:"org_id" json:"org_id"`
+	ProjectID primitive.ObjectID `bson:"project_id" json:"project_id"`
+	Name      string             `bson:"name" json:"name"`
+	// TokenHash is the SHA-256 of the hook's token; the token itself is only shown once
+	TokenHash string             `bson:"token_hash" json:"-"`
+	CreatorID primitive.ObjectID `bson:"creator_id" json:"creator_id"` // User the tasks are created on behalf of
+	// Mapping holds a text/template per task field, rendered with the posted JSON;
+	// fields without one are read from the payload's field of the same name
+	Mapping    map[string]string  `bson:"mapping,omitempty" json:"mapping,omitempty"`
+	CreatedBy  primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastUsedAt *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+}
+
+// InboundHookRepository defines the interface for inbound hook data access
+type InboundHookRepository interface {
+	Create(hook *InboundHook) error
+	FindByID(id primitive.ObjectID) (*InboundHook, error)
+	FindByTokenHash(tokenHash string) (*InboundHook, error)
+	// FindByProject returns the hooks of a project, oldest first
+	FindByProject(projectID primitive.ObjectID) ([]*InboundHook, error)
+	// Touch records that the hook was used at the given time
+	Touch(id primitive.ObjectID, at time.Time) error
+	Delete(id primitive.ObjectID) error
+	// DeleteByProject deletes the hooks of a project
+	DeleteByProject(projectID primitive.ObjectID) error
+}
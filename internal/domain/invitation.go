@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InvitationStatus represents the state of an organization invitation
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	InvitationStatusExpired  InvitationStatus = "expired"
+)
+
+// Invitation invites an email address to join an organization
+type Invitation struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID      primitive.ObjectID `bson:"org_id" json:"org_id"`
+	Email      string             `bson:"email" json:"email"`
+	Role       OrgRole            `bson:"role" json:"role"`
+	InvitedBy  primitive.ObjectID `bson:"invited_by" json:"invited_by"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`
+	AcceptedAt *time.Time         `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	AcceptedBy primitive.ObjectID `bson:"accepted_by,omitempty" json:"accepted_by,omitempty"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Status reports the invitation's state at the given time
+func (i *Invitation) Status(now time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// InvitationRepository defines the interface for invitation data access
+type InvitationRepository interface {
+	Create(invitation *Invitation) error
+	FindByID(id primitive.ObjectID) (*Invitation, error)
+	// FindPending returns the organization's invitations that are neither accepted,
+	// revoked, nor expired at the given time, newest first
+	FindPending(orgID primitive.ObjectID, now time.Time) ([]*Invitation, error)
+	// FindPendingByEmail returns the organization's pending invitation for an email address
+	FindPendingByEmail(orgID primitive.ObjectID, email string, now time.Time) (*Invitation, error)
+	Update(invitation *Invitation) error
+	// DeleteFinishedBefore deletes all invitations accepted, revoked or expired
+	// before the cutoff and returns how many were removed
+	DeleteFinishedBefore(cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LoginFailureReason explains why a login attempt failed
+type LoginFailureReason string
+
+const (
+	LoginFailureUnknownUser   LoginFailureReason = "unknown_user"
+	LoginFailureWrongPassword LoginFailureReason = "wrong_password"
+	LoginFailureDeactivated   LoginFailureReason = "deactivated"
+)
+
+// LoginAttempt records one attempt to sign in, successful or not
+type LoginAttempt struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"` // Unset when no user matched the login
+	OrgID         primitive.ObjectID `bson:"org_id,omitempty" json:"org_id,omitempty"`
+	Login         string             `bson:"login" json:"login"` // Username or email as entered
+	Success       bool               `bson:"success" json:"success"`
+	FailureReason LoginFailureReason `bson:"failure_reason,omitempty" json:"failure_reason,omitempty"`
+	IP            string             `bson:"ip" json:"ip"`
+	UserAgent     string             `bson:"user_agent" json:"user_agent"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// LoginAttemptFilter selects login attempts. Zero-valued fields match everything.
+type LoginAttemptFilter struct {
+	OrgID   primitive.ObjectID
+	UserID  primitive.ObjectID
+	Success *bool
+	From    time.Time
+	To      time.Time
+	Limit   int64
+}
+
+// LoginAttemptRepository defines the interface for login history data access
+type LoginAttemptRepository interface {
+	Create(attempt *LoginAttempt) error
+	// Find returns the attempts matching the filter, newest first
+	Find(filter LoginAttemptFilter) ([]*LoginAttempt, error)
+	// DeleteCreatedBefore deletes all attempts made before the cutoff and returns how many were removed
+	DeleteCreatedBefore(cutoff time.Time) (int64, error)
+}
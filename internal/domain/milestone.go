@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Milestone groups tasks of a project toward a target date
+type Milestone struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID       primitive.ObjectID `bson:"org_id" json:"org_id"`
+	ProjectID   primitive.ObjectID `bson:"project_id" json:"project_id"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	TargetDate  time.Time          `bson:"target_date" json:"target_date"`
+	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// MilestoneProgress summarizes the tasks of a milestone
+type MilestoneProgress struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	// Overdue counts the incomplete tasks past their due date
+	Overdue int `json:"overdue"`
+	// Percent is the share of completed tasks, rounded down; 0 without tasks
+	Percent int `json:"percent"`
+	// AtRisk is set when incomplete tasks are overdue, or the target date has
+	// passed with tasks still incomplete
+	AtRisk bool `json:"at_risk"`
+}
+
+// Progress computes the progress of the milestone from its tasks at a given time
+func (m *Milestone) Progress(tasks []*Task, now time.Time) MilestoneProgress {
+	var progress MilestoneProgress
+	for _, task := range tasks {
+		progress.Total++
+		if task.Status == TaskStatusCompleted {
+			progress.Completed++
+			continue
+		}
+		if !task.DueDate.IsZero() && task.DueDate.Before(now) {
+			progress.Overdue++
+		}
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = progress.Completed * 100 / progress.Total
+	}
+	incomplete := progress.Completed < progress.Total
+	progress.AtRisk = progress.Overdue > 0 || incomplete && m.TargetDate.Before(now)
+	return progress
+}
+
+// MilestoneRepository defines the interface for milestone data access
+type MilestoneRepository interface {
+	FindByID(id primitive.ObjectID) (*Milestone, error)
+	// FindByProject returns all milestones of a project, sorted by target date
+	FindByProject(projectID primitive.ObjectID) ([]*Milestone, error)
+	Create(milestone *Milestone) error
+	Update(milestone *Milestone) error
+	// DeleteByProject deletes all milestones of a project
+	DeleteByProject(projectID primitive.ObjectID) error
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Notification represents an in-app notification delivered to a user
+type Notification struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"` // Recipient
+	Type      EventType          `bson:"type" json:"type"`
+	ActorID   primitive.ObjectID `bson:"actor_id" json:"actor_id"`
+	TaskID    primitive.ObjectID `bson:"task_id,omitempty" json:"task_id,omitempty"`
+	TaskTitle string             `bson:"task_title,omitempty" json:"task_title,omitempty"` // Kept so the notification still reads well after the task is deleted
+	Items     []string           `bson:"items,omitempty" json:"items,omitempty"`           // Summaries of the notifications a summary message batches
+	Read      bool               `bson:"read" json:"read"`
+	ReadAt    *time.Time         `bson:"read_at,omitempty" json:"read_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// Summary returns a short human-readable description of the notification
+func (n *Notification) Summary() string {
+	switch n.Type {
+	case EventTaskAssigned:
+		return fmt.Sprintf("You were assigned to %q", n.TaskTitle)
+	case EventTaskUnassigned:
+		return fmt.Sprintf("You were removed from %q", n.TaskTitle)
+	case EventTaskAssignmentRequested:
+		return fmt.Sprintf("You were assigned to %q; accept or decline the assignment", n.TaskTitle)
+	case EventTaskAssignmentAccepted:
+		return fmt.Sprintf("Your assignment of %q was accepted", n.TaskTitle)
+	case EventTaskAssignmentDeclined:
+		return fmt.Sprintf("Your assignment of %q was declined", n.TaskTitle)
+	case EventTaskStatusChanged:
+		return fmt.Sprintf("The status of %q changed", n.TaskTitle)
+	case EventTaskDeleted:
+		return fmt.Sprintf("%q was deleted", n.TaskTitle)
+	case EventTaskUnsnoozed:
+		return fmt.Sprintf("%q is back from snooze", n.TaskTitle)
+	case EventTaskMerged:
+		return fmt.Sprintf("%q was merged into another task", n.TaskTitle)
+	case EventTaskEscalated:
+		return fmt.Sprintf("%q is overdue and was escalated", n.TaskTitle)
+	case EventTaskReminder:
+		return fmt.Sprintf("Reminder: %q", n.TaskTitle)
+	case EventAttachmentQuarantined:
+		return fmt.Sprintf("A file you attached to %q was quarantined by the malware scan", n.TaskTitle)
+	case EventNotificationSummary:
+		return "While your notifications were paused"
+	default:
+		return fmt.Sprintf("%q was updated", n.TaskTitle)
+	}
+}
+
+// Body returns the summary followed by the batched items, one per line, for
+// channels that show more than a line
+func (n *Notification) Body() string {
+	if len(n.Items) == 0 {
+		return n.Summary()
+	}
+
+	var b strings.Builder
+	b.WriteString(n.Summary())
+	b.WriteString(":")
+	for _, item := range n.Items {
+		b.WriteString("\n- ")
+		b.WriteString(item)
+	}
+	return b.String()
+}
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	Create(notification *Notification) error
+	FindByUser(userID primitive.ObjectID, unreadOnly bool, limit int64) ([]*Notification, error)
+	FindByTypeSince(userID primitive.ObjectID, eventType EventType, since time.Time) ([]*Notification, error)
+	MarkRead(userID primitive.ObjectID, id primitive.ObjectID) error
+	MarkAllRead(userID primitive.ObjectID) (int64, error)
+	CountUnread(userID primitive.ObjectID) (int64, error)
+	DeleteCreatedBefore(cutoff time.Time) (int64, error)
+}
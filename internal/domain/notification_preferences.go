@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotificationChannel identifies a delivery channel for notifications
+type NotificationChannel string
+
+const (
+	ChannelInApp   NotificationChannel = "in_app"
+	ChannelEmail   NotificationChannel = "email"
+	ChannelSlack   NotificationChannel = "slack"
+	ChannelWebhook NotificationChannel = "webhook"
+	ChannelTeams   NotificationChannel = "teams"
+	ChannelDiscord NotificationChannel = "discord"
+)
+
+// NotificationCategory groups events that users can route as a unit
+type NotificationCategory string
+
+const (
+	CategoryAssignment   NotificationCategory = "assignment"
+	CategoryComment      NotificationCategory = "comment"
+	CategoryDueSoon      NotificationCategory = "due_soon"
+	CategoryStatusChange NotificationCategory = "status_change"
+	CategoryTaskUpdate   NotificationCategory = "task_update"
+)
+
+// ValidNotificationChannels lists all supported channels
+var ValidNotificationChannels = []NotificationChannel{ChannelInApp, ChannelEmail, ChannelSlack, ChannelWebhook}
+
+// ValidNotificationCategories lists all supported categories
+var ValidNotificationCategories = []NotificationCategory{CategoryAssignment, CategoryComment, CategoryDueSoon, CategoryStatusChange, CategoryTaskUpdate}
+
+// CategoryForEvent maps an event type to the preference category that controls it
+func CategoryForEvent(eventType EventType) NotificationCategory {
+	switch eventType {
+	case EventTaskAssigned, EventTaskUnassigned,
+		EventTaskAssignmentRequested, EventTaskAssignmentAccepted, EventTaskAssignmentDeclined:
+		return CategoryAssignment
+	case EventTaskStatusChanged:
+		return CategoryStatusChange
+	case EventTaskUnsnoozed, EventTaskEscalated, EventTaskReminder:
+		return CategoryDueSoon
+	default:
+		return CategoryTaskUpdate
+	}
+}
+
+// IsUrgent reports whether notifications of an event type are delivered right
+// away even during the recipient's quiet hours: escalations of overdue tasks,
+// and the reminders users set themselves
+func IsUrgent(eventType EventType) bool {
+	switch eventType {
+	case EventTaskEscalated, EventTaskReminder:
+		return true
+	default:
+		return false
+	}
+}
+
+// NotificationPreferences holds a user's routing of notification categories to channels
+type NotificationPreferences struct {
+	UserID          primitive.ObjectID                             `bson:"_id" json:"user_id"`
+	Channels        map[NotificationCategory][]NotificationChannel `bson:"channels" json:"channels"`
+	SlackWebhookURL string                                         `bson:"slack_webhook_url,omitempty" json:"slack_webhook_url,omitempty"`
+	WebhookURL      string                                         `bson:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+	DailyDigest     bool                                           `bson:"daily_digest" json:"daily_digest"`
+	DigestHour      int                                            `bson:"digest_hour" json:"digest_hour"`
+	LastDigestAt    time.Time                                      `bson:"last_digest_at,omitempty" json:"-"`
+	UpdatedAt       time.Time                                      `bson:"updated_at" json:"updated_at"`
+
+	// Periods in which notifications other than urgent ones are held back from
+	// external channels, and sent in one summary message per channel afterwards
+	QuietHours        QuietHours `bson:"quiet_hours" json:"quiet_hours"`
+	DoNotDisturbUntil *time.Time `bson:"do_not_disturb_until,omitempty" json:"do_not_disturb_until,omitempty"`
+}
+
+// QuietHours is a daily window, between two hours of the day in the user's time
+// zone, during which notifications are held back. A window whose start is after
+// its end spans midnight.
+type QuietHours struct {
+	Enabled bool `bson:"enabled" json:"enabled"`
+	Start   int  `bson:"start" json:"start" example:"22" minimum:"0" maximum:"23"`
+	End     int  `bson:"end" json:"end" example:"7" minimum:"0" maximum:"23"`
+}
+
+// contains reports whether the window covers the given hour of the day
+func (q QuietHours) contains(hour int) bool {
+	if q.Start < q.End {
+		return hour >= q.Start && hour < q.End
+	}
+	return hour >= q.Start || hour < q.End
+}
+
+// QuietUntil reports whether notifications are held back at the given time, for
+// a user in the given time zone, and until when. Do-not-disturb ending within
+// the quiet hours lasts until they end.
+func (p *NotificationPreferences) QuietUntil(now time.Time, loc *time.Location) (time.Time, bool) {
+	until := now
+	if p.DoNotDisturbUntil != nil && p.DoNotDisturbUntil.After(now) {
+		until = *p.DoNotDisturbUntil
+	}
+
+	if p.QuietHours.Enabled && p.QuietHours.Start != p.QuietHours.End {
+		local := until.In(loc)
+		if p.QuietHours.contains(local.Hour()) {
+			end := time.Date(local.Year(), local.Month(), local.Day(), p.QuietHours.End, 0, 0, 0, loc)
+			if !end.After(local) {
+				end = time.Date(local.Year(), local.Month(), local.Day()+1, p.QuietHours.End, 0, 0, 0, loc)
+			}
+			until = end
+		}
+	}
+
+	return until, until.After(now)
+}
+
+// DefaultDigestHour is the local hour at which daily digests are sent unless the user picks another
+const DefaultDigestHour = 8
+
+// DefaultNotificationPreferences returns the preferences used for users who never saved any:
+// every category is delivered in-app only
+func DefaultNotificationPreferences(userID primitive.ObjectID) *NotificationPreferences {
+	channels := make(map[NotificationCategory][]NotificationChannel, len(ValidNotificationCategories))
+	for _, category := range ValidNotificationCategories {
+		channels[category] = []NotificationChannel{ChannelInApp}
+	}
+
+	return &NotificationPreferences{
+		UserID:     userID,
+		Channels:   channels,
+		DigestHour: DefaultDigestHour,
+	}
+}
+
+// ChannelsFor returns the channels enabled for a category
+func (p *NotificationPreferences) ChannelsFor(category NotificationCategory) []NotificationChannel {
+	return p.Channels[category]
+}
+
+// NotificationPreferencesRepository defines the interface for notification preference data access
+type NotificationPreferencesRepository interface {
+	FindByUser(userID primitive.ObjectID) (*NotificationPreferences, error)
+	Save(prefs *NotificationPreferences) error
+	FindDigestSubscribers() ([]*NotificationPreferences, error)
+	MarkDigestSent(userID primitive.ObjectID, sentAt time.Time) error
+}
+
+// Notifier delivers notifications over an external channel
+type Notifier interface {
+	Channel() NotificationChannel
+	Notify(recipient *User, prefs *NotificationPreferences, notification *Notification) error
+}
+
+// ChatMessage is a notification posted to a chat channel shared by a project's members
+type ChatMessage struct {
+	Type      EventType
+	Summary   string
+	TaskTitle string
+	TaskKey   string // Empty for tasks outside projects with keys
+}
+
+// ChatPoster posts messages to a chat service's incoming webhook
+type ChatPoster interface {
+	Channel() NotificationChannel
+	Post(webhookURL string, message *ChatMessage) error
+}
+
+// EmailSender sends plain-text emails
+type EmailSender interface {
+	Send(to, subject, body string) error
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxMessage is a domain event stored for later delivery by the outbox relay
+type OutboxMessage struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Event       Event              `bson:"event" json:"event"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	DeliveredAt *time.Time         `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+}
+
+// OutboxRepository defines the interface for outbox data access
+type OutboxRepository interface {
+	Add(event *Event) error
+	FindPending(limit int64) ([]*OutboxMessage, error)
+	MarkDelivered(id primitive.ObjectID) error
+	DeleteDeliveredBefore(cutoff time.Time) (int64, error)
+}
+
+// TxRepositories are the repositories bound to a transaction of a unit of work
+type TxRepositories struct {
+	Tasks       TaskRepository
+	Outbox      OutboxRepository
+	Attachments AttachmentRepository
+	Stars       TaskStarRepository
+	Users       UserRepository
+}
+
+// UnitOfWork runs a set of writes atomically
+type UnitOfWork interface {
+	// Do runs fn in a transaction. Writes made through the repositories passed
+	// to fn are committed together, or not at all if fn returns an error.
+	Do(fn func(tasks TaskRepository, outbox OutboxRepository) error) error
+	// DoAll runs fn in a transaction like Do, for writes that also touch the data
+	// attached to tasks or their users
+	DoAll(fn func(repos *TxRepositories) error) error
+}
@@ -0,0 +1,307 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProjectRole is a user's permission level within a project
+type ProjectRole string
+
+const (
+	// ProjectRoleViewer can read the project's tasks
+	ProjectRoleViewer ProjectRole = "viewer"
+	// ProjectRoleContributor can also create, update, and assign tasks, and delete tasks they created
+	ProjectRoleContributor ProjectRole = "contributor"
+	// ProjectRoleAdmin can also delete any task and manage the project and its members
+	ProjectRoleAdmin ProjectRole = "admin"
+)
+
+// projectRoleRanks orders project roles by the permissions they grant
+var projectRoleRanks = map[ProjectRole]int{
+	ProjectRoleViewer:      1,
+	ProjectRoleContributor: 2,
+	ProjectRoleAdmin:       3,
+}
+
+// IsValid reports whether the role is a known project role
+func (r ProjectRole) IsValid() bool {
+	_, ok := projectRoleRanks[r]
+	return ok
+}
+
+// AtLeast reports whether the role grants every permission of the other role
+func (r ProjectRole) AtLeast(other ProjectRole) bool {
+	return projectRoleRanks[r] >= projectRoleRanks[other]
+}
+
+// ProjectMember grants a user a role in a project
+type ProjectMember struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Role   ProjectRole        `bson:"role" json:"role"`
+}
+
+// WIPLimitMode is how a project enforces its work-in-progress limits
+type WIPLimitMode string
+
+const (
+	// WIPLimitReject fails status changes and assignments that would exceed a limit
+	WIPLimitReject WIPLimitMode = "reject"
+	// WIPLimitWarn lets them through with a warning on the task
+	WIPLimitWarn WIPLimitMode = "warn"
+)
+
+// IsValid reports whether the mode is a known mode
+func (m WIPLimitMode) IsValid() bool {
+	return m == WIPLimitReject || m == WIPLimitWarn
+}
+
+// WIPLimits caps the number of a project's tasks in progress. Zero limits are off.
+type WIPLimits struct {
+	Project int          `bson:"project,omitempty" json:"project"`   // Tasks in progress in the project
+	PerUser int          `bson:"per_user,omitempty" json:"per_user"` // Tasks in progress in the project assigned to any one user
+	Mode    WIPLimitMode `bson:"mode,omitempty" json:"mode"`
+}
+
+// Enabled reports whether any limit is set
+func (l WIPLimits) Enabled() bool {
+	return l.Project > 0 || l.PerUser > 0
+}
+
+// AutoAssignStrategy is how an auto-assignment rule picks the assignee of a new task
+type AutoAssignStrategy string
+
+const (
+	// AutoAssignRoundRobin takes the candidates in turn
+	AutoAssignRoundRobin AutoAssignStrategy = "round_robin"
+	// AutoAssignLeastLoaded picks the candidate with the fewest open tasks in the project
+	AutoAssignLeastLoaded AutoAssignStrategy = "least_loaded"
+)
+
+// IsValid reports whether the strategy is a known strategy
+func (s AutoAssignStrategy) IsValid() bool {
+	return s == AutoAssignRoundRobin || s == AutoAssignLeastLoaded
+}
+
+// AutoAssignRule assigns new, unassigned tasks of a project that match it to one of its candidates
+type AutoAssignRule struct {
+	ID          primitive.ObjectID   `bson:"id" json:"id"`
+	Tag         string               `bson:"tag,omitempty" json:"tag,omitempty"`                   // Tag the task must carry; any task matches when empty
+	MinPriority int                  `bson:"min_priority,omitempty" json:"min_priority,omitempty"` // Lowest priority matched; any priority when zero
+	Strategy    AutoAssignStrategy   `bson:"strategy" json:"strategy"`
+	Candidates  []primitive.ObjectID `bson:"candidates,omitempty" json:"candidates,omitempty"` // Members to pick from; the project's contributors and admins when empty
+}
+
+// Matches reports whether the rule applies to a task
+func (r *AutoAssignRule) Matches(task *Task) bool {
+	if r.Tag != "" && !task.HasTag(r.Tag) {
+		return false
+	}
+	return task.Priority >= r.MinPriority
+}
+
+// AutoAssignCounter names the counter of a round-robin rule's turns
+func AutoAssignCounter(ruleID primitive.ObjectID) string {
+	return "auto_assign:" + ruleID.Hex()
+}
+
+// ProjectConnectorChannels lists the chat services a project can post its task events to
+var ProjectConnectorChannels = []NotificationChannel{ChannelSlack, ChannelTeams, ChannelDiscord}
+
+// ProjectConnector posts a project's task events to a chat channel through the
+// channel's incoming webhook
+type ProjectConnector struct {
+	ID         primitive.ObjectID     `bson:"id" json:"id"`
+	Channel    NotificationChannel    `bson:"channel" json:"channel"`
+	WebhookURL string                 `bson:"webhook_url" json:"webhook_url"`
+	Categories []NotificationCategory `bson:"categories,omitempty" json:"categories,omitempty"` // Events posted; all when empty
+}
+
+// Wants reports whether the connector posts events of a category
+func (c *ProjectConnector) Wants(category NotificationCategory) bool {
+	if len(c.Categories) == 0 {
+		return true
+	}
+	for _, wanted := range c.Categories {
+		if wanted == category {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectLabel is a tag the tasks of a project are meant to carry. Its name is
+// the tag itself.
+type ProjectLabel struct {
+	Name        string `bson:"name" json:"name"`
+	Color       string `bson:"color,omitempty" json:"color,omitempty"` // e.g. #d73a4a
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+}
+
+// Project groups an organization's tasks; access to them is governed by project membership
+type Project struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID             primitive.ObjectID `bson:"org_id" json:"org_id"`
+	Key               string             `bson:"key,omitempty" json:"key"` // Prefix of the project's task keys, e.g. PROJ
+	Name              string             `bson:"name" json:"name"`
+	Description       string             `bson:"description" json:"description"`
+	Members           []ProjectMember    `bson:"members" json:"members"`
+	WIPLimits         WIPLimits          `bson:"wip_limits" json:"wip_limits"`
+	RequireAcceptance bool               `bson:"require_acceptance,omitempty" json:"require_acceptance"` // Assignees accept or decline the tasks others assign them
+	AutoAssign        []AutoAssignRule   `bson:"auto_assign,omitempty" json:"auto_assign,omitempty"`     // Checked in order; the first rule matching a new task assigns it
+	Connectors        []ProjectConnector `bson:"connectors,omitempty" json:"-"`                          // Post task events to chat channels; webhook URLs are secret, so only admins see them
+	Labels            []ProjectLabel     `bson:"labels,omitempty" json:"labels,omitempty"`               // Tags the project's tasks are meant to carry, by name
+	StrictLabels      bool               `bson:"strict_labels,omitempty" json:"strict_labels"`           // Tasks can only be given tags that are labels of the project
+	CreatedBy         primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Label returns the project's label with the given name, or nil if it has none
+func (p *Project) Label(name string) *ProjectLabel {
+	for i := range p.Labels {
+		if p.Labels[i].Name == name {
+			return &p.Labels[i]
+		}
+	}
+	return nil
+}
+
+// AllowsTag reports whether the project's tasks can be given a tag: any tag
+// unless its labels are strict
+func (p *Project) AllowsTag(tag string) bool {
+	return !p.StrictLabels || p.Label(tag) != nil
+}
+
+// RoleOf returns the user's role in the project and whether they are a member
+func (p *Project) RoleOf(userID primitive.ObjectID) (ProjectRole, bool) {
+	for _, member := range p.Members {
+		if member.UserID == userID {
+			return member.Role, true
+		}
+	}
+	return "", false
+}
+
+// SetMember adds a member or changes an existing member's role
+func (p *Project) SetMember(userID primitive.ObjectID, role ProjectRole) {
+	for i, member := range p.Members {
+		if member.UserID == userID {
+			p.Members[i].Role = role
+			return
+		}
+	}
+	p.Members = append(p.Members, ProjectMember{UserID: userID, Role: role})
+}
+
+// RemoveMember removes a member and reports whether they were present
+func (p *Project) RemoveMember(userID primitive.ObjectID) bool {
+	for i, member := range p.Members {
+		if member.UserID == userID {
+			p.Members = append(p.Members[:i], p.Members[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AdminCount returns the number of project admins
+func (p *Project) AdminCount() int {
+	count := 0
+	for _, member := range p.Members {
+		if member.Role == ProjectRoleAdmin {
+			count++
+		}
+	}
+	return count
+}
+
+// projectKeyPattern matches project keys: an uppercase letter followed by 1 to 9
+// uppercase letters or digits
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+
+// ValidProjectKey reports whether a project key is well-formed
+func ValidProjectKey(key string) bool {
+	return projectKeyPattern.MatchString(key)
+}
+
+// DeriveProjectKey suggests a project key from a project name: the initials of
+// a name of several words, e.g. WR for "Website relaunch", or the first
+// letters of a single word, e.g. MOBI for "Mobile"
+func DeriveProjectKey(name string) string {
+	// Split on everything but ASCII letters and digits
+	words := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+
+	var key string
+	if len(words) > 1 {
+		for _, word := range words {
+			key += word[:1]
+		}
+	} else if len(words) == 1 {
+		key = words[0]
+		if len(key) > 4 {
+			key = key[:4]
+		}
+	}
+
+	// Keys start with a letter and have at least two characters
+	key = strings.TrimLeft(key, "0123456789")
+	if len(key) > 10 {
+		key = key[:10]
+	}
+	if !ValidProjectKey(key) {
+		return "PRJ"
+	}
+	return key
+}
+
+// ProjectKeyVariant returns the key followed by a number, e.g. WR2, shortened to
+// stay a valid key; used when the key is taken
+func ProjectKeyVariant(key string, n int) string {
+	suffix := strconv.Itoa(n)
+	if len(key)+len(suffix) > 10 {
+		key = key[:10-len(suffix)]
+	}
+	return key + suffix
+}
+
+// TaskKey returns the key of a project's task, e.g. PROJ-123
+func TaskKey(projectKey string, number int64) string {
+	return fmt.Sprintf("%s-%d", projectKey, number)
+}
+
+// ParseTaskKey splits a task key such as PROJ-123, in any case, into the project
+// key and the task number
+func ParseTaskKey(key string) (projectKey string, number int64, ok bool) {
+	projectKey, n, found := strings.Cut(strings.ToUpper(key), "-")
+	if !found || !ValidProjectKey(projectKey) {
+		return "", 0, false
+	}
+
+	number, err := strconv.ParseInt(n, 10, 64)
+	if err != nil || number < 1 {
+		return "", 0, false
+	}
+	return projectKey, number, true
+}
+
+// ProjectRepository defines the interface for project data access
+type ProjectRepository interface {
+	FindByID(id primitive.ObjectID) (*Project, error)
+	// FindByKey finds an organization's project by its key
+	FindByKey(orgID primitive.ObjectID, key string) (*Project, error)
+	// FindByOrg returns all projects of an organization, sorted by name
+	FindByOrg(orgID primitive.ObjectID) ([]*Project, error)
+	// FindByMember returns the organization's projects the user is a member of, sorted by name
+	FindByMember(orgID primitive.ObjectID, userID primitive.ObjectID) ([]*Project, error)
+	Create(project *Project) error
+	Update(project *Project) error
+	Delete(id primitive.ObjectID) error
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskReminder reminds one user of a task at a time they chose, either a fixed
+// time or a number of minutes before the task is due
+type TaskReminder struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID  primitive.ObjectID `bson:"org_id" json:"org_id"`
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TaskID primitive.ObjectID `bson:"task_id" json:"task_id"`
+	// RemindAt is when the reminder is sent. It is unset while a reminder relative
+	// to the due date belongs to a task that has none.
+	RemindAt *time.Time `bson:"remind_at,omitempty" json:"remind_at,omitempty"`
+	// BeforeDueMinutes ties the reminder to the task's due date when set: it is
+	// sent that many minutes before the task is due, and moves with the due date
+	BeforeDueMinutes *int      `bson:"before_due_minutes,omitempty" json:"before_due_minutes,omitempty"`
+	CreatedAt        time.Time `bson:"created_at" json:"created_at"`
+}
+
+// IsRelative reports whether the reminder follows the task's due date
+func (r *TaskReminder) IsRelative() bool {
+	return r.BeforeDueMinutes != nil
+}
+
+// Schedule sets when a reminder relative to the due date is sent; tasks without
+// a due date leave it unscheduled
+func (r *TaskReminder) Schedule(dueDate time.Time) {
+	if !r.IsRelative() {
+		return
+	}
+	if dueDate.IsZero() {
+		r.RemindAt = nil
+		return
+	}
+	at := dueDate.Add(-time.Duration(*r.BeforeDueMinutes) * time.Minute)
+	r.RemindAt = &at
+}
+
+// TaskReminderRepository defines the interface for task reminder data access
+type TaskReminderRepository interface {
+	Create(reminder *TaskReminder) error
+	// Delete deletes one of a user's reminders
+	Delete(userID, id primitive.ObjectID) error
+	// FindByUser returns a user's scheduled reminders, soonest first
+	FindByUser(userID primitive.ObjectID) ([]*TaskReminder, error)
+	// FindByTask returns a user's reminders of a task, soonest first and unscheduled ones last
+	FindByTask(userID, taskID primitive.ObjectID) ([]*TaskReminder, error)
+	// FindDue returns up to limit reminders due at the given time, oldest first
+	FindDue(now time.Time, limit int64) ([]*TaskReminder, error)
+	// Release deletes a due reminder unless it was rescheduled since it was
+	// loaded, and reports whether it was deleted
+	Release(reminder *TaskReminder) (bool, error)
+	// RescheduleTask moves the reminders relative to a task's due date to a new
+	// due date; a zero due date leaves them unscheduled
+	RescheduleTask(taskID primitive.ObjectID, dueDate time.Time) error
+	// DeleteByTask deletes all reminders of a task
+	DeleteByTask(taskID primitive.ObjectID) error
+}
@@ -0,0 +1,67 @@
+// Package repositorytest is a conformance suite for implementations of the
+// domain repositories. Every backend, whether MongoDB, another database or an
+// in-memory store, must pass it, so that the use cases behave the same on all
+// of them, down to the errors returned and the order of results:
+//
+//	func TestTaskRepository(t *testing.T) {
+//		repositorytest.Run(t, mongodb.NewTaskRepository(db, time.Second))
+//	}
+//
+// The repository must be set up as in production, e.g. with its unique indexes.
+// The suite creates its data in new organizations and under unique usernames and
+// emails, so it can share a database with other tests, and deletes what it
+// created when done. Filters passed to TaskRepository.FindAll and Count are
+// limited to equality on a single field.
+package repositorytest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Run runs the contract of every repository the given implementation
+// satisfies, currently domain.TaskRepository and domain.UserRepository, as
+// subtests of t. It fails the test when repo implements neither.
+func Run(t *testing.T, repo interface{}) {
+	t.Helper()
+
+	ran := false
+	if tasks, ok := repo.(domain.TaskRepository); ok {
+		t.Run("TaskRepository", func(t *testing.T) {
+			RunTaskRepository(t, tasks)
+		})
+		ran = true
+	}
+	if users, ok := repo.(domain.UserRepository); ok {
+		t.Run("UserRepository", func(t *testing.T) {
+			RunUserRepository(t, users)
+		})
+		ran = true
+	}
+
+	if !ran {
+		t.Fatalf("repositorytest: %T implements none of the repositories the suite covers", repo)
+	}
+}
+
+// now is the base time of the suite's data. Backends only need to keep times to
+// the millisecond, so it has no finer precision.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// unique returns a prefix no other run of the suite uses, for names that must be
+// unique across organizations
+func unique() string {
+	return "rt" + primitive.NewObjectID().Hex()
+}
+
+// idOrder reports whether a sorts before b in ID order
+func idOrder(a, b primitive.ObjectID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
@@ -0,0 +1,450 @@
+package repositorytest
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"task-management-system/internal/domain"
+)
+
+// RunTaskRepository runs the contract of domain.TaskRepository against repo.
+// Every subtest works in its own organization through ForOrg.
+func RunTaskRepository(t *testing.T, repo domain.TaskRepository) {
+	t.Run("CreateSetsDefaults", func(t *testing.T) { testTaskCreate(t, repo) })
+	t.Run("FindByIDNotFound", func(t *testing.T) { testTaskFindByIDNotFound(t, repo) })
+	t.Run("FindByKey", func(t *testing.T) { testTaskFindByKey(t, repo) })
+	t.Run("DuplicateKey", func(t *testing.T) { testTaskDuplicateKey(t, repo) })
+	t.Run("OrganizationScope", func(t *testing.T) { testTaskOrganizationScope(t, repo) })
+	t.Run("FindAllSortsByDueDate", func(t *testing.T) { testTaskFindAllSort(t, repo) })
+	t.Run("FindAllPages", func(t *testing.T) { testTaskFindAllPages(t, repo) })
+	t.Run("ListView", func(t *testing.T) { testTaskListView(t, repo) })
+	t.Run("Count", func(t *testing.T) { testTaskCount(t, repo) })
+	t.Run("Update", func(t *testing.T) { testTaskUpdate(t, repo) })
+	t.Run("RaisePriority", func(t *testing.T) { testTaskRaisePriority(t, repo) })
+	t.Run("Delete", func(t *testing.T) { testTaskDelete(t, repo) })
+	t.Run("CreateMany", func(t *testing.T) { testTaskCreateMany(t, repo) })
+	t.Run("DeleteMany", func(t *testing.T) { testTaskDeleteMany(t, repo) })
+	t.Run("FindByUser", func(t *testing.T) { testTaskFindByUser(t, repo) })
+	t.Run("FindByStatus", func(t *testing.T) { testTaskFindByStatus(t, repo) })
+}
+
+func testTaskCreate(t *testing.T, repo domain.TaskRepository) {
+	org := primitive.NewObjectID()
+	tasks := tasksIn(t, repo, org)
+
+	task := &domain.Task{Title: "Write the contract", Description: "All of it", Priority: 3, DueDate: now().Add(time.Hour)}
+	require.NoError(t, tasks.Create(task))
+
+	assert.False(t, task.ID.IsZero(), "Create must set the ID")
+	assert.Equal(t, org, task.OrgID, "a scoped repository must stamp its organization")
+	assert.Equal(t, domain.InitialTaskStatus, task.Status, "Create must default the status")
+	assert.False(t, task.CreatedAt.IsZero(), "Create must set created_at")
+	assert.False(t, task.UpdatedAt.IsZero(), "Create must set updated_at")
+
+	found, err := tasks.FindByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, found.ID)
+	assert.Equal(t, task.OrgID, found.OrgID)
+	assert.Equal(t, task.Title, found.Title)
+	assert.Equal(t, task.Description, found.Description)
+	assert.Equal(t, task.Status, found.Status)
+	assert.Equal(t, task.Priority, found.Priority)
+	assert.True(t, task.DueDate.Equal(found.DueDate), "due date %v was stored as %v", task.DueDate, found.DueDate)
+	assert.WithinDuration(t, task.CreatedAt, found.CreatedAt, time.Millisecond)
+
+	// A given ID is kept
+	id := primitive.NewObjectID()
+	require.NoError(t, tasks.Create(&domain.Task{ID: id, Title: "Given ID", Priority: 1}))
+	_, err = tasks.FindByID(id)
+	assert.NoError(t, err)
+}
+
+func testTaskFindByIDNotFound(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	_, err := tasks.FindByID(primitive.NewObjectID())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testTaskFindByKey(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	task := &domain.Task{Key: "RT-1", Number: 1, Title: "Keyed", Priority: 1}
+	require.NoError(t, tasks.Create(task))
+
+	found, err := tasks.FindByKey("RT-1")
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, found.ID)
+
+	_, err = tasks.FindByKey("RT-2")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testTaskDuplicateKey(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	require.NoError(t, tasks.Create(&domain.Task{Key: "RT-1", Title: "First", Priority: 1}))
+	err := tasks.Create(&domain.Task{Key: "RT-1", Title: "Second", Priority: 1})
+	assert.ErrorIs(t, err, domain.ErrDuplicateKey, "task keys are unique within an organization")
+
+	// Tasks without a key never clash
+	require.NoError(t, tasks.Create(&domain.Task{Title: "Unkeyed", Priority: 1}))
+	require.NoError(t, tasks.Create(&domain.Task{Title: "Unkeyed too", Priority: 1}))
+
+	// Other organizations can use the same key
+	assert.NoError(t, scopedTasks(t, repo).Create(&domain.Task{Key: "RT-1", Title: "Elsewhere", Priority: 1}))
+}
+
+func testTaskOrganizationScope(t *testing.T, repo domain.TaskRepository) {
+	mine, theirs := scopedTasks(t, repo), scopedTasks(t, repo)
+
+	task := &domain.Task{Key: "RT-1", Title: "Mine", Status: domain.TaskStatusPending, Priority: 1}
+	require.NoError(t, mine.Create(task))
+
+	_, err := theirs.FindByID(task.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "FindByID must not match tasks of other organizations")
+
+	_, err = theirs.FindByKey("RT-1")
+	assert.ErrorIs(t, err, domain.ErrNotFound, "FindByKey must not match tasks of other organizations")
+
+	found, err := theirs.FindAll(nil)
+	require.NoError(t, err)
+	assert.Empty(t, found, "FindAll must not match tasks of other organizations")
+
+	found, err = theirs.FindByStatus(domain.TaskStatusPending)
+	require.NoError(t, err)
+	assert.Empty(t, found, "FindByStatus must not match tasks of other organizations")
+
+	count, err := theirs.Count(nil)
+	require.NoError(t, err)
+	assert.Zero(t, count, "Count must not match tasks of other organizations")
+
+	task.Title = "Taken over"
+	assert.ErrorIs(t, theirs.Update(task), domain.ErrNotFound, "Update must not match tasks of other organizations")
+	assert.ErrorIs(t, theirs.Delete(task.ID), domain.ErrNotFound, "Delete must not match tasks of other organizations")
+
+	deleted, err := theirs.DeleteMany([]primitive.ObjectID{task.ID})
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "DeleteMany must not match tasks of other organizations")
+
+	kept, err := mine.FindByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Mine", kept.Title)
+}
+
+func testTaskFindAllSort(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	base := now()
+	for _, task := range []*domain.Task{
+		{Title: "Third", Priority: 1, DueDate: base.Add(3 * time.Hour)},
+		{Title: "First", Priority: 1, DueDate: base.Add(time.Hour)},
+		{Title: "Second", Priority: 1, DueDate: base.Add(2 * time.Hour), Status: domain.TaskStatusCompleted},
+	} {
+		require.NoError(t, tasks.Create(task))
+	}
+
+	found, err := tasks.FindAll(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"First", "Second", "Third"}, titles(found), "FindAll must sort by due date")
+
+	found, err = tasks.FindAll(map[string]interface{}{"status": domain.TaskStatusPending})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"First", "Third"}, titles(found), "FindAll must apply the filter")
+}
+
+func testTaskFindAllPages(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	// Due dates run against creation order, so that ID order differs from the default sort
+	base := now()
+	var created []*domain.Task
+	for i := 0; i < 5; i++ {
+		task := &domain.Task{Title: string(rune('A' + i)), Priority: 1, DueDate: base.Add(time.Duration(5-i) * time.Hour)}
+		require.NoError(t, tasks.Create(task))
+		created = append(created, task)
+	}
+	sort.Slice(created, func(i, j int) bool { return idOrder(created[i].ID, created[j].ID) })
+
+	var walked []*domain.Task
+	after := primitive.NilObjectID
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "paging must end")
+
+		page, err := tasks.FindAll(nil, domain.Page(after, 2))
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(page), 2, "a page must hold at most its size")
+		if len(page) == 0 {
+			break
+		}
+		walked = append(walked, page...)
+		after = page[len(page)-1].ID
+	}
+
+	assert.Equal(t, titles(created), titles(walked), "pages must walk all tasks in ID order")
+}
+
+func testTaskListView(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	task := &domain.Task{Title: "Long", Description: "A very long description", Priority: 1, CreatedBy: primitive.NewObjectID()}
+	require.NoError(t, tasks.Create(task))
+
+	for name, find := range map[string]func() ([]*domain.Task, error){
+		"FindAll":      func() ([]*domain.Task, error) { return tasks.FindAll(nil, domain.ListView()) },
+		"FindByUser":   func() ([]*domain.Task, error) { return tasks.FindByUser(task.CreatedBy, domain.ListView()) },
+		"FindByStatus": func() ([]*domain.Task, error) { return tasks.FindByStatus(task.Status, domain.ListView()) },
+	} {
+		found, err := find()
+		require.NoError(t, err, name)
+		require.Len(t, found, 1, name)
+		assert.Equal(t, "Long", found[0].Title, name)
+		assert.Empty(t, found[0].Description, "%s must leave the description out of list views", name)
+	}
+
+	found, err := tasks.FindAll(nil)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, task.Description, found[0].Description)
+}
+
+func testTaskCount(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	count, err := tasks.Count(nil)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	for _, status := range []domain.TaskStatus{domain.TaskStatusPending, domain.TaskStatusCompleted, domain.TaskStatusCompleted} {
+		require.NoError(t, tasks.Create(&domain.Task{Title: string(status), Status: status, Priority: 1}))
+	}
+
+	count, err = tasks.Count(nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+
+	count, err = tasks.Count(map[string]interface{}{"status": domain.TaskStatusCompleted})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+}
+
+func testTaskUpdate(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	task := &domain.Task{Title: "Before", Priority: 1, Tags: []string{"old"}, DueDate: now()}
+	require.NoError(t, tasks.Create(task))
+	createdAt := task.CreatedAt
+
+	assignee := primitive.NewObjectID()
+	task.Title = "After"
+	task.Description = "Changed"
+	task.Status = domain.TaskStatusInProgress
+	task.Priority = 4
+	task.DueDate = now().Add(24 * time.Hour)
+	task.AssignedTo = []primitive.ObjectID{assignee}
+	task.Tags = nil
+	require.NoError(t, tasks.Update(task))
+	assert.False(t, task.UpdatedAt.Before(createdAt), "Update must set updated_at")
+
+	found, err := tasks.FindByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "After", found.Title)
+	assert.Equal(t, "Changed", found.Description)
+	assert.Equal(t, domain.TaskStatusInProgress, found.Status)
+	assert.Equal(t, 4, found.Priority)
+	assert.True(t, task.DueDate.Equal(found.DueDate), "due date %v was stored as %v", task.DueDate, found.DueDate)
+	assert.Equal(t, []primitive.ObjectID{assignee}, found.AssignedTo)
+	assert.Empty(t, found.Tags, "Update must remove cleared tags")
+	assert.WithinDuration(t, createdAt, found.CreatedAt, time.Millisecond, "Update must keep created_at")
+
+	missing := &domain.Task{ID: primitive.NewObjectID(), Title: "Missing", Priority: 1}
+	assert.ErrorIs(t, tasks.Update(missing), domain.ErrNotFound)
+}
+
+func testTaskRaisePriority(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	task := &domain.Task{Title: "Old", Description: "Kept", Priority: 2}
+	require.NoError(t, tasks.Create(task))
+
+	agedAt := now()
+	raised, err := tasks.RaisePriority(task.ID, 4, agedAt)
+	require.NoError(t, err)
+	assert.Equal(t, 3, raised.Priority)
+	assert.Equal(t, 2, raised.OriginalPriority, "RaisePriority must keep the priority before aging")
+	require.NotNil(t, raised.AgedAt)
+	assert.True(t, agedAt.Equal(*raised.AgedAt), "aged at %v was stored as %v", agedAt, *raised.AgedAt)
+	assert.Equal(t, "Kept", raised.Description, "RaisePriority must return the whole task")
+
+	// Raising again keeps the priority before the first raise
+	raised, err = tasks.RaisePriority(task.ID, 4, agedAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 4, raised.Priority)
+	assert.Equal(t, 2, raised.OriginalPriority)
+
+	_, err = tasks.RaisePriority(task.ID, 4, agedAt.Add(2*time.Hour))
+	assert.ErrorIs(t, err, domain.ErrNotFound, "RaisePriority must not raise tasks at the maximum")
+
+	done := &domain.Task{Title: "Done", Status: domain.TaskStatusCompleted, Priority: 1}
+	require.NoError(t, tasks.Create(done))
+	_, err = tasks.RaisePriority(done.ID, 4, agedAt)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "RaisePriority must not raise completed tasks")
+
+	_, err = scopedTasks(t, repo).RaisePriority(task.ID, 5, agedAt)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "RaisePriority must not match tasks of other organizations")
+
+	found, err := tasks.FindByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, found.Priority)
+}
+
+func testTaskDelete(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	task := &domain.Task{Title: "Doomed", Priority: 1}
+	require.NoError(t, tasks.Create(task))
+
+	require.NoError(t, tasks.Delete(task.ID))
+	_, err := tasks.FindByID(task.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.ErrorIs(t, tasks.Delete(task.ID), domain.ErrNotFound, "deleting a deleted task must fail")
+}
+
+func testTaskCreateMany(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	require.NoError(t, tasks.CreateMany(nil))
+
+	batch := []*domain.Task{
+		{Key: "RT-1", Title: "One", Priority: 1},
+		{Key: "RT-2", Title: "Two", Priority: 1},
+	}
+	require.NoError(t, tasks.CreateMany(batch))
+	for _, task := range batch {
+		assert.False(t, task.ID.IsZero(), "CreateMany must set the IDs")
+		assert.False(t, task.OrgID.IsZero(), "CreateMany must stamp the organization")
+		assert.Equal(t, domain.InitialTaskStatus, task.Status, "CreateMany must default the status")
+		assert.False(t, task.CreatedAt.IsZero(), "CreateMany must set created_at")
+	}
+
+	// A failed task does not stop the others
+	err := tasks.CreateMany([]*domain.Task{
+		{Key: "RT-3", Title: "Three", Priority: 1},
+		{Key: "RT-1", Title: "One again", Priority: 1},
+		{Title: "Unkeyed", Priority: 1},
+	})
+	var bulkErr *domain.BulkWriteError
+	require.True(t, errors.As(err, &bulkErr), "CreateMany must report failed tasks with a *domain.BulkWriteError, got %v", err)
+	require.Len(t, bulkErr.Failed, 1)
+	assert.ErrorIs(t, bulkErr.Failed[1], domain.ErrDuplicateKey)
+
+	count, err := tasks.Count(nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, count)
+}
+
+func testTaskDeleteMany(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	deleted, err := tasks.DeleteMany(nil)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	var ids []primitive.ObjectID
+	for i := 0; i < 3; i++ {
+		task := &domain.Task{Title: "Batch", Priority: 1}
+		require.NoError(t, tasks.Create(task))
+		ids = append(ids, task.ID)
+	}
+
+	// Missing IDs are skipped and not counted
+	deleted, err = tasks.DeleteMany([]primitive.ObjectID{ids[0], ids[1], primitive.NewObjectID()})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, deleted)
+
+	found, err := tasks.FindAll(nil)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, ids[2], found[0].ID)
+}
+
+func testTaskFindByUser(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	user, other := primitive.NewObjectID(), primitive.NewObjectID()
+	base := now()
+	for _, task := range []*domain.Task{
+		{Title: "Assigned", Priority: 1, CreatedBy: other, AssignedTo: []primitive.ObjectID{other, user}, DueDate: base.Add(2 * time.Hour)},
+		{Title: "Someone else's", Priority: 1, CreatedBy: other, AssignedTo: []primitive.ObjectID{other}, DueDate: base},
+		{Title: "Created", Priority: 1, CreatedBy: user, DueDate: base.Add(time.Hour)},
+	} {
+		require.NoError(t, tasks.Create(task))
+	}
+
+	found, err := tasks.FindByUser(user)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Created", "Assigned"}, titles(found), "FindByUser must match created and assigned tasks, sorted by due date")
+}
+
+func testTaskFindByStatus(t *testing.T, repo domain.TaskRepository) {
+	tasks := scopedTasks(t, repo)
+
+	base := now()
+	for _, task := range []*domain.Task{
+		{Title: "Later", Status: domain.TaskStatusInProgress, Priority: 1, DueDate: base.Add(time.Hour)},
+		{Title: "Done", Status: domain.TaskStatusCompleted, Priority: 1, DueDate: base},
+		{Title: "Sooner", Status: domain.TaskStatusInProgress, Priority: 1, DueDate: base},
+	} {
+		require.NoError(t, tasks.Create(task))
+	}
+
+	found, err := tasks.FindByStatus(domain.TaskStatusInProgress)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Sooner", "Later"}, titles(found), "FindByStatus must sort by due date")
+}
+
+// scopedTasks returns the repository limited to a new organization, whose
+// tasks are deleted when the test ends
+func scopedTasks(t *testing.T, repo domain.TaskRepository) domain.TaskRepository {
+	t.Helper()
+	return tasksIn(t, repo, primitive.NewObjectID())
+}
+
+// tasksIn returns the repository limited to the organization, whose tasks are
+// deleted when the test ends
+func tasksIn(t *testing.T, repo domain.TaskRepository, orgID primitive.ObjectID) domain.TaskRepository {
+	t.Helper()
+
+	scoped := repo.ForOrg(orgID)
+	t.Cleanup(func() {
+		tasks, err := scoped.FindAll(nil)
+		if err != nil {
+			t.Errorf("repositorytest: failed to clean up tasks: %v", err)
+			return
+		}
+		ids := make([]primitive.ObjectID, 0, len(tasks))
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		if _, err := scoped.DeleteMany(ids); err != nil {
+			t.Errorf("repositorytest: failed to clean up tasks: %v", err)
+		}
+	})
+	return scoped
+}
+
+// titles lists the titles of tasks in order
+func titles(tasks []*domain.Task) []string {
+	list := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		list = append(list, task.Title)
+	}
+	return list
+}
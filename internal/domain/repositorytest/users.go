@@ -0,0 +1,313 @@
+package repositorytest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"task-management-system/internal/domain"
+)
+
+// RunUserRepository runs the contract of domain.UserRepository against repo.
+// Usernames and emails are unique across the repository, so every user the
+// suite creates has a name of its own.
+func RunUserRepository(t *testing.T, repo domain.UserRepository) {
+	t.Run("CreateAndFind", func(t *testing.T) { testUserCreateAndFind(t, repo) })
+	t.Run("NotFound", func(t *testing.T) { testUserNotFound(t, repo) })
+	t.Run("DuplicateKey", func(t *testing.T) { testUserDuplicateKey(t, repo) })
+	t.Run("FindByIDs", func(t *testing.T) { testUserFindByIDs(t, repo) })
+	t.Run("FindByOrgSortsByUsername", func(t *testing.T) { testUserFindByOrg(t, repo) })
+	t.Run("FindAndCount", func(t *testing.T) { testUserFindAndCount(t, repo) })
+	t.Run("Update", func(t *testing.T) { testUserUpdate(t, repo) })
+	t.Run("UpdateAvatar", func(t *testing.T) { testUserUpdateAvatar(t, repo) })
+	t.Run("IncrementTokenGeneration", func(t *testing.T) { testUserIncrementTokenGeneration(t, repo) })
+	t.Run("Delete", func(t *testing.T) { testUserDelete(t, repo) })
+}
+
+func testUserCreateAndFind(t *testing.T, repo domain.UserRepository) {
+	user := newUser(unique(), primitive.NewObjectID())
+	createUser(t, repo, user)
+
+	assert.False(t, user.ID.IsZero(), "Create must set the ID")
+	assert.False(t, user.CreatedAt.IsZero(), "Create must set created_at")
+	assert.False(t, user.UpdatedAt.IsZero(), "Create must set updated_at")
+
+	for name, find := range map[string]func() (*domain.User, error){
+		"FindByID":       func() (*domain.User, error) { return repo.FindByID(user.ID) },
+		"FindByIDInOrg":  func() (*domain.User, error) { return repo.FindByIDInOrg(user.OrgID, user.ID) },
+		"FindByEmail":    func() (*domain.User, error) { return repo.FindByEmail(user.Email) },
+		"FindByUsername": func() (*domain.User, error) { return repo.FindByUsername(user.Username) },
+	} {
+		found, err := find()
+		require.NoError(t, err, name)
+		assert.Equal(t, user.ID, found.ID, name)
+		assert.Equal(t, user.Username, found.Username, name)
+		assert.Equal(t, user.Email, found.Email, name)
+		assert.Equal(t, user.Password, found.Password, name)
+		assert.Equal(t, user.FirstName, found.FirstName, name)
+		assert.Equal(t, user.OrgID, found.OrgID, name)
+		assert.Equal(t, user.OrgRole, found.OrgRole, name)
+		assert.WithinDuration(t, user.CreatedAt, found.CreatedAt, time.Millisecond, name)
+	}
+}
+
+func testUserNotFound(t *testing.T, repo domain.UserRepository) {
+	name := unique()
+
+	_, err := repo.FindByID(primitive.NewObjectID())
+	assert.ErrorIs(t, err, domain.ErrNotFound, "FindByID")
+
+	// Users of other organizations are not found within an organization
+	user := newUser(unique(), primitive.NewObjectID())
+	createUser(t, repo, user)
+	_, err = repo.FindByIDInOrg(primitive.NewObjectID(), user.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "FindByIDInOrg")
+
+	_, err = repo.FindByEmail(name + "@example.com")
+	assert.ErrorIs(t, err, domain.ErrNotFound, "FindByEmail")
+
+	_, err = repo.FindByUsername(name)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "FindByUsername")
+}
+
+func testUserDuplicateKey(t *testing.T, repo domain.UserRepository) {
+	name := unique()
+	user := newUser(name, primitive.NewObjectID())
+	createUser(t, repo, user)
+
+	sameEmail := newUser(unique(), primitive.NewObjectID())
+	sameEmail.Email = user.Email
+	assert.ErrorIs(t, repo.Create(sameEmail), domain.ErrDuplicateKey, "emails must be unique")
+
+	sameUsername := newUser(unique(), primitive.NewObjectID())
+	sameUsername.Username = user.Username
+	assert.ErrorIs(t, repo.Create(sameUsername), domain.ErrDuplicateKey, "usernames must be unique")
+
+	// Changing an email to one that is taken fails too
+	other := newUser(unique(), primitive.NewObjectID())
+	createUser(t, repo, other)
+	other.Email = user.Email
+	assert.ErrorIs(t, repo.Update(other), domain.ErrDuplicateKey, "emails must stay unique")
+}
+
+func testUserFindByIDs(t *testing.T, repo domain.UserRepository) {
+	found, err := repo.FindByIDs(nil)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	org := primitive.NewObjectID()
+	first, second := newUser(unique(), org), newUser(unique(), org)
+	createUser(t, repo, first)
+	createUser(t, repo, second)
+
+	// IDs without a user are left out, in no particular order
+	found, err = repo.FindByIDs([]primitive.ObjectID{second.ID, primitive.NewObjectID(), first.ID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []primitive.ObjectID{first.ID, second.ID}, userIDs(found))
+}
+
+func testUserFindByOrg(t *testing.T, repo domain.UserRepository) {
+	org := primitive.NewObjectID()
+	prefix := unique()
+
+	found, err := repo.FindByOrg(org)
+	require.NoError(t, err)
+	assert.NotNil(t, found, "FindByOrg must return an empty list rather than nil")
+	assert.Empty(t, found)
+
+	for _, suffix := range []string{"c", "a", "b"} {
+		createUser(t, repo, newUser(prefix+suffix, org))
+	}
+	createUser(t, repo, newUser(prefix+"elsewhere", primitive.NewObjectID()))
+
+	found, err = repo.FindByOrg(org)
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "a", prefix + "b", prefix + "c"}, usernames(found), "FindByOrg must list the organization's members by username")
+}
+
+func testUserFindAndCount(t *testing.T, repo domain.UserRepository) {
+	org := primitive.NewObjectID()
+	prefix := unique()
+
+	found, err := repo.Find(domain.UserFilter{OrgID: org})
+	require.NoError(t, err)
+	assert.NotNil(t, found, "Find must return an empty list rather than nil")
+	assert.Empty(t, found)
+
+	names := map[string]string{"d": "Dana", "b": "Bob", "a": "Ann", "c": "Robin"}
+	for suffix, firstName := range names {
+		user := newUser(prefix+suffix, org)
+		user.FirstName = firstName
+		createUser(t, repo, user)
+	}
+	createUser(t, repo, newUser(prefix+"e", primitive.NewObjectID()))
+
+	found, err = repo.Find(domain.UserFilter{OrgID: org})
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "a", prefix + "b", prefix + "c", prefix + "d"}, usernames(found), "Find must list the organization's users by username")
+
+	// Skip and limit page through the same order, and do not change the count
+	page := domain.UserFilter{OrgID: org, Skip: 1, Limit: 2}
+	found, err = repo.Find(page)
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "b", prefix + "c"}, usernames(found))
+
+	count, err := repo.Count(page)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, count, "Count must ignore skip and limit")
+
+	// The query matches names ignoring case
+	query := domain.UserFilter{OrgID: org, Query: "OB"}
+	found, err = repo.Find(query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "b", prefix + "c"}, usernames(found), "Find must match names containing the query, ignoring case")
+
+	count, err = repo.Count(query)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	// And usernames and emails
+	found, err = repo.Find(domain.UserFilter{OrgID: org, Query: strings.ToUpper(prefix + "d@")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "d"}, usernames(found))
+
+	// Query characters are taken literally
+	found, err = repo.Find(domain.UserFilter{OrgID: org, Query: ".*"})
+	require.NoError(t, err)
+	assert.Empty(t, found, "Find must not interpret the query as a pattern")
+}
+
+func testUserUpdate(t *testing.T, repo domain.UserRepository) {
+	user := newUser(unique(), primitive.NewObjectID())
+	createUser(t, repo, user)
+	createdAt := user.CreatedAt
+
+	deactivatedAt := now()
+	user.Email = unique() + "@example.com"
+	user.FirstName = "Changed"
+	user.Timezone = "Europe/Berlin"
+	user.OrgRole = domain.OrgRoleAdmin
+	user.DeactivatedAt = &deactivatedAt
+	require.NoError(t, repo.Update(user))
+
+	found, err := repo.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, found.Email)
+	assert.Equal(t, "Changed", found.FirstName)
+	assert.Equal(t, "Europe/Berlin", found.Timezone)
+	assert.Equal(t, domain.OrgRoleAdmin, found.OrgRole)
+	require.NotNil(t, found.DeactivatedAt)
+	assert.True(t, deactivatedAt.Equal(*found.DeactivatedAt))
+	assert.WithinDuration(t, createdAt, found.CreatedAt, time.Millisecond, "Update must keep created_at")
+
+	// An empty password keeps the stored one, and a nil deactivation reactivates
+	password := found.Password
+	user.Password = ""
+	user.DeactivatedAt = nil
+	require.NoError(t, repo.Update(user))
+
+	found, err = repo.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, password, found.Password, "Update must keep the password when none is given")
+	assert.Nil(t, found.DeactivatedAt, "Update must clear the deactivation")
+
+	missing := newUser(unique(), user.OrgID)
+	missing.ID = primitive.NewObjectID()
+	assert.ErrorIs(t, repo.Update(missing), domain.ErrNotFound)
+}
+
+func testUserUpdateAvatar(t *testing.T, repo domain.UserRepository) {
+	user := newUser(unique(), primitive.NewObjectID())
+	createUser(t, repo, user)
+
+	require.NoError(t, repo.UpdateAvatar(user.ID, "avatars/key"))
+	found, err := repo.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "avatars/key", found.AvatarKey)
+	assert.False(t, found.AvatarUpdatedAt.IsZero(), "UpdateAvatar must set avatar_updated_at")
+
+	require.NoError(t, repo.UpdateAvatar(user.ID, ""))
+	found, err = repo.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, found.AvatarKey, "an empty key must remove the avatar")
+	assert.True(t, found.AvatarUpdatedAt.IsZero(), "an empty key must remove the avatar")
+
+	assert.ErrorIs(t, repo.UpdateAvatar(primitive.NewObjectID(), "avatars/key"), domain.ErrNotFound)
+}
+
+func testUserIncrementTokenGeneration(t *testing.T, repo domain.UserRepository) {
+	user := newUser(unique(), primitive.NewObjectID())
+	createUser(t, repo, user)
+
+	require.NoError(t, repo.IncrementTokenGeneration(user.ID))
+	require.NoError(t, repo.IncrementTokenGeneration(user.ID))
+
+	found, err := repo.FindByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.TokenGeneration)
+
+	assert.ErrorIs(t, repo.IncrementTokenGeneration(primitive.NewObjectID()), domain.ErrNotFound)
+}
+
+func testUserDelete(t *testing.T, repo domain.UserRepository) {
+	user := newUser(unique(), primitive.NewObjectID())
+	require.NoError(t, repo.Create(user))
+
+	require.NoError(t, repo.Delete(user.ID))
+	_, err := repo.FindByID(user.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.ErrorIs(t, repo.Delete(user.ID), domain.ErrNotFound, "deleting a deleted user must fail")
+
+	// The username and email are free again
+	createUser(t, repo, newUser(user.Username, user.OrgID))
+}
+
+// newUser returns a member of the organization with the given username and a
+// matching email
+func newUser(username string, orgID primitive.ObjectID) *domain.User {
+	return &domain.User{
+		Username:  username,
+		Email:     username + "@example.com",
+		Password:  "hash",
+		FirstName: "Test",
+		LastName:  "User",
+		OrgID:     orgID,
+		OrgRole:   domain.OrgRoleMember,
+	}
+}
+
+// createUser creates the user and deletes it when the test ends
+func createUser(t *testing.T, repo domain.UserRepository, user *domain.User) {
+	t.Helper()
+
+	require.NoError(t, repo.Create(user))
+	t.Cleanup(func() {
+		if err := repo.Delete(user.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("repositorytest: failed to clean up user %s: %v", user.Username, err)
+		}
+	})
+}
+
+// userIDs lists the IDs of users in order
+func userIDs(users []*domain.User) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+// usernames lists the usernames of users in order
+func usernames(users []*domain.User) []string {
+	list := make([]string, 0, len(users))
+	for _, user := range users {
+		list = append(list, user.Username)
+	}
+	return list
+}
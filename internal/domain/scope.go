@@ -0,0 +1,65 @@
+package domain
+
+// Scope grants a token access to one group of API operations. Signing in with a
+// narrower set of scopes gives integrations only the access they need.
+type Scope string
+
+// Scope values
+const (
+	ScopeTasksRead     Scope = "tasks:read"
+	ScopeTasksWrite    Scope = "tasks:write"
+	ScopeProjectsRead  Scope = "projects:read"
+	ScopeProjectsWrite Scope = "projects:write"
+	ScopeUsersRead     Scope = "users:read"
+	ScopeUsersWrite    Scope = "users:write"
+	// ScopeUsersAdmin covers organization administration: members, invitations and audit logs
+	ScopeUsersAdmin Scope = "users:admin"
+)
+
+// AllScopes lists every scope
+var AllScopes = []Scope{
+	ScopeTasksRead,
+	ScopeTasksWrite,
+	ScopeProjectsRead,
+	ScopeProjectsWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeUsersAdmin,
+}
+
+// IsValid checks if the scope is known
+func (s Scope) IsValid() bool {
+	for _, scope := range AllScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopesFor returns the scopes a user may be granted; only organization admins
+// may administer users
+func ScopesFor(user *User) []Scope {
+	scopes := make([]Scope, 0, len(AllScopes))
+	for _, scope := range AllScopes {
+		if scope == ScopeUsersAdmin && !user.IsOrgAdmin() {
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// HasScope reports whether the granted scopes include a scope. Tokens issued
+// before scopes existed carry none and keep full access until they expire.
+func HasScope(granted []Scope, scope Scope) bool {
+	if len(granted) == 0 {
+		return true
+	}
+	for _, s := range granted {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// TaskSearchResult is a task matched by a search together with its relevance score
+type TaskSearchResult struct {
+	Task  *Task   `json:"task"`
+	Score float64 `json:"score"`
+}
+
+// TaskSearcher defines the interface for full-text task search within an organization.
+// Results are ordered by descending relevance.
+type TaskSearcher interface {
+	Search(orgID primitive.ObjectID, query string, limit int64) ([]*TaskSearchResult, error)
+}
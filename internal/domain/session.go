@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session is a signed-in device. Every access token belongs to a session and
+// stops working once the session is revoked or expires.
+type Session struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	UserAgent  string             `bson:"user_agent" json:"user_agent"` // Device the session was started from
+	IP         string             `bson:"ip" json:"ip"`                 // Address the session was started from
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastSeenAt time.Time          `bson:"last_seen_at" json:"last_seen_at"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	// Scopes limits the session's tokens to the scopes requested at sign-in; empty grants all the user's scopes
+	Scopes []Scope `bson:"scopes,omitempty" json:"scopes,omitempty"`
+	// ImpersonatorID is the organization admin acting as the user in an impersonation session
+	ImpersonatorID primitive.ObjectID `bson:"impersonator_id,omitempty" json:"impersonator_id,omitempty"`
+}
+
+// IsActive reports whether the session can still be used at the given time
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// IsImpersonation reports whether the session was started by an admin acting as the user
+func (s *Session) IsImpersonation() bool {
+	return !s.ImpersonatorID.IsZero()
+}
+
+// SessionRepository defines the interface for session data access
+type SessionRepository interface {
+	Create(session *Session) error
+	FindByID(id primitive.ObjectID) (*Session, error)
+	// FindActiveByUser returns the user's sessions that are neither revoked nor expired, most recently seen first
+	FindActiveByUser(userID primitive.ObjectID, now time.Time) ([]*Session, error)
+	Update(session *Session) error
+	// Touch records that the session was used at the given time
+	Touch(id primitive.ObjectID, at time.Time) error
+}
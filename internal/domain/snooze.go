@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskSnooze hides a task from one user's own task list and reminders until a given time
+type TaskSnooze struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID     primitive.ObjectID `bson:"org_id" json:"org_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TaskID    primitive.ObjectID `bson:"task_id" json:"task_id"`
+	Until     time.Time          `bson:"until" json:"until"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// TaskSnoozeRepository defines the interface for task snooze data access
+type TaskSnoozeRepository interface {
+	// Snooze snoozes a task for a user, or moves the end of an existing snooze
+	Snooze(snooze *TaskSnooze) error
+	Unsnooze(userID, taskID primitive.ObjectID) error
+	// FindActive returns a user's snoozes that have not ended at the given time, ending soonest first
+	FindActive(userID primitive.ObjectID, now time.Time) ([]*TaskSnooze, error)
+	// FindEnded returns up to limit snoozes that have ended at the given time
+	FindEnded(now time.Time, limit int64) ([]*TaskSnooze, error)
+	// Release deletes an ended snooze unless it was moved since it was loaded,
+	// and reports whether it was deleted
+	Release(snooze *TaskSnooze) (bool, error)
+}
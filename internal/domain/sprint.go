@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Sprint is a time box of a project; tasks are planned into it by setting their sprint
+type Sprint struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrgID     primitive.ObjectID `bson:"org_id" json:"org_id"`
+	ProjectID primitive.ObjectID `bson:"project_id" json:"project_id"`
+	Name      string             `bson:"name" json:"name"`
+	Goal      string             `bson:"goal,omitempty" json:"goal,omitempty"`
+	StartDate time.Time          `bson:"start_date" json:"start_date"`
+	EndDate   time.Time          `bson:"end_date" json:"end_date"`
+	// ClosedAt is set once the sprint is closed; closed sprints take no more tasks
+	ClosedAt  *time.Time         `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+	CreatedBy primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// IsClosed reports whether the sprint has been closed
+func (s *Sprint) IsClosed() bool {
+	return s.ClosedAt != nil
+}
+
+// SprintRepository defines the interface for sprint data access
+type SprintRepository interface {
+	FindByID(id primitive.ObjectID) (*Sprint, error)
+	// FindByProject returns all sprints of a project, sorted by start date
+	FindByProject(projectID primitive.ObjectID) ([]*Sprint, error)
+	Create(sprint *Sprint) error
+	Update(sprint *Sprint) error
+	// DeleteByProject deletes all sprints of a project
+	DeleteByProject(projectID primitive.ObjectID) error
+}
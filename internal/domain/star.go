@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskStar represents a user's star (pin) on a task
+type TaskStar struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TaskID    primitive.ObjectID `bson:"task_id" json:"task_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// TaskStarRepository defines the interface for task star data access
+type TaskStarRepository interface {
+	Star(userID, taskID primitive.ObjectID) error
+	Unstar(userID, taskID primitive.ObjectID) error
+	FindTaskIDsByUser(userID primitive.ObjectID) ([]primitive.ObjectID, error)
+	// MoveToTask moves all stars of a task to another task; users who starred both
+	// keep a single star. It returns how many stars were moved.
+	MoveToTask(from, to primitive.ObjectID) (int64, error)
+}
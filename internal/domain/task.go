@@ -14,27 +14,243 @@ const (
 	TaskStatusCompleted  TaskStatus = "completed"
 )
 
+// InitialTaskStatus is the status new tasks start in
+const InitialTaskStatus = TaskStatusPending
+
+// taskStatusTransitions lists the statuses each status can move to, in
+// workflow order. Completed tasks can be reopened if they need more work.
+var taskStatusTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusPending:    {TaskStatusInProgress, TaskStatusCompleted},
+	TaskStatusInProgress: {TaskStatusCompleted},
+	TaskStatusCompleted:  {TaskStatusInProgress},
+}
+
+// TaskStatuses lists all task statuses in workflow order
+func TaskStatuses() []TaskStatus {
+	return []TaskStatus{TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted}
+}
+
+// IsValid reports whether the status is a known task status
+func (s TaskStatus) IsValid() bool {
+	_, ok := taskStatusTransitions[s]
+	return ok
+}
+
+// Transitions returns the statuses a task can move to from this one
+func (s TaskStatus) Transitions() []TaskStatus {
+	return append([]TaskStatus{}, taskStatusTransitions[s]...)
+}
+
+// CheckTransition returns a StatusTransitionError unless a task in this status
+// can move to the next one. Keeping the current status is not a transition and
+// is always allowed.
+func (s TaskStatus) CheckTransition(next TaskStatus) error {
+	if next == s && s.IsValid() {
+		return nil
+	}
+	for _, allowed := range taskStatusTransitions[s] {
+		if allowed == next {
+			return nil
+		}
+	}
+	return &StatusTransitionError{Current: s, Requested: next}
+}
+
 // Task represents a task entity
 type Task struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Title       string             `bson:"title" json:"title" validate:"required"`
-	Description string             `bson:"description" json:"description"`
-	Status      TaskStatus         `bson:"status" json:"status"`
-	Priority    int                `bson:"priority" json:"priority" validate:"min=1,max=5"`
-	DueDate     time.Time          `bson:"due_date" json:"due_date"`
-	AssignedTo  primitive.ObjectID `bson:"assigned_to,omitempty" json:"assigned_to,omitempty"`
-	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	OrgID       primitive.ObjectID   `bson:"org_id" json:"org_id"`
+	ProjectID   primitive.ObjectID   `bson:"project_id,omitempty" json:"project_id,omitempty"`
+	SprintID    primitive.ObjectID   `bson:"sprint_id,omitempty" json:"sprint_id,omitempty"`       // Sprint of the project the task is planned into
+	MilestoneID primitive.ObjectID   `bson:"milestone_id,omitempty" json:"milestone_id,omitempty"` // Milestone of the project the task counts toward
+	Number      int64                `bson:"number,omitempty" json:"number,omitempty"`             // Sequential number within the project
+	Key         string               `bson:"key,omitempty" json:"key,omitempty"`                   // Project key and number, e.g. PROJ-123
+	Title       string               `bson:"title" json:"title" validate:"required"`
+	Description string               `bson:"description" json:"description"`
+	Status      TaskStatus           `bson:"status" json:"status"`
+	Priority    int                  `bson:"priority" json:"priority" validate:"min=1,max=5"`
+	DueDate     time.Time            `bson:"due_date" json:"due_date"`
+	AssignedTo  []primitive.ObjectID `bson:"assigned_to,omitempty" json:"assigned_to,omitempty"`
+	Tags        []string             `bson:"tags,omitempty" json:"tags,omitempty"`
+	CreatedBy   primitive.ObjectID   `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
+	EscalatedAt *time.Time           `bson:"escalated_at,omitempty" json:"escalated_at,omitempty"` // When the task was escalated as overdue; cleared once it is completed or rescheduled
+	MergedInto  primitive.ObjectID   `bson:"merged_into,omitempty" json:"merged_into,omitempty"`   // Task this duplicate was merged into; set when it is closed by a merge
+
+	// Priority aging: the priority the task had before aging first raised it, and when it last did.
+	// Both are cleared when someone sets another priority.
+	OriginalPriority int        `bson:"original_priority,omitempty" json:"original_priority,omitempty"`
+	AgedAt           *time.Time `bson:"aged_at,omitempty" json:"aged_at,omitempty"`
+
+	// Assignments awaiting the assignee's answer, in projects that require assignees to accept
+	PendingAssignments []PendingAssignment `bson:"pending_assignments,omitempty" json:"pending_assignments,omitempty"`
+
+	// Typed links to other tasks, such as duplicates; mirrored on the linked tasks
+	Links []TaskLink `bson:"links,omitempty" json:"links,omitempty"`
+
+	// Users the creator gave access to the task beyond the usual rules
+	Grants []TaskGrant `bson:"grants,omitempty" json:"grants,omitempty"`
+
+	// Resolved user references for responses; never persisted
+	Creator   *UserRef   `bson:"-" json:"creator,omitempty"`
+	Assignees []*UserRef `bson:"-" json:"assignees,omitempty"`
+
+	// Description rendered from Markdown to sanitized HTML, on request; never persisted
+	DescriptionHTML string `bson:"-" json:"description_html,omitempty"`
+
+	// End of the viewer's snooze, in lists of snoozed tasks; never persisted
+	SnoozedUntil *time.Time `bson:"-" json:"snoozed_until,omitempty"`
+
+	// When the viewer last saw the task, and whether it changed since, in lists of
+	// the tasks they created or are assigned to; never persisted
+	LastSeenAt    *time.Time `bson:"-" json:"last_seen_at,omitempty"`
+	UnreadChanges bool       `bson:"-" json:"unread_changes,omitempty"`
+
+	// Warnings about the change just made, such as an exceeded WIP limit; never persisted
+	Warnings []string `bson:"-" json:"warnings,omitempty"`
+}
+
+// UserRef is a lightweight reference to a user, embedded in task responses
+// so clients don't need a lookup per ID
+type UserRef struct {
+	ID       primitive.ObjectID `json:"id"`
+	Username string             `json:"username"`
+}
+
+// IsAssignedTo reports whether the given user is one of the task's assignees
+func (t *Task) IsAssignedTo(userID primitive.ObjectID) bool {
+	for _, id := range t.AssignedTo {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether the task carries the given tag
+func (t *Task) HasTag(tag string) bool {
+	for _, have := range t.Tags {
+		if have == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddAssignee adds a user to the task's assignees, ignoring duplicates
+func (t *Task) AddAssignee(userID primitive.ObjectID) {
+	if !t.IsAssignedTo(userID) {
+		t.AssignedTo = append(t.AssignedTo, userID)
+	}
+}
+
+// RemoveAssignee removes a user from the task's assignees and reports whether it was present
+func (t *Task) RemoveAssignee(userID primitive.ObjectID) bool {
+	for i, id := range t.AssignedTo {
+		if id == userID {
+			t.AssignedTo = append(t.AssignedTo[:i], t.AssignedTo[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// PendingAssignment is an assignment the assignee has yet to accept or decline.
+// The assignee is on the task meanwhile; declining restores the assignees the
+// task had before.
+type PendingAssignment struct {
+	UserID            primitive.ObjectID   `bson:"user_id" json:"user_id"`
+	AssignedBy        primitive.ObjectID   `bson:"assigned_by" json:"assigned_by"`
+	PreviousAssignees []primitive.ObjectID `bson:"previous_assignees,omitempty" json:"previous_assignees,omitempty"`
+	AssignedAt        time.Time            `bson:"assigned_at" json:"assigned_at"`
+}
+
+// PendingAssignmentOf returns the user's pending assignment to the task, or nil if there is none
+func (t *Task) PendingAssignmentOf(userID primitive.ObjectID) *PendingAssignment {
+	for i := range t.PendingAssignments {
+		if t.PendingAssignments[i].UserID == userID {
+			return &t.PendingAssignments[i]
+		}
+	}
+	return nil
+}
+
+// RemovePendingAssignment removes the user's pending assignment and reports whether it was present
+func (t *Task) RemovePendingAssignment(userID primitive.ObjectID) bool {
+	for i, pending := range t.PendingAssignments {
+		if pending.UserID == userID {
+			t.PendingAssignments = append(t.PendingAssignments[:i], t.PendingAssignments[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// TaskQuery holds optional settings for task list queries
+type TaskQuery struct {
+	// ListView loads only the fields needed to show tasks in a list,
+	// leaving out large free-text fields such as the description
+	ListView bool
+	// PageSize, when positive, limits FindAll to that many tasks in ID order,
+	// starting after the task with ID After. Walking pages this way stays fast
+	// however many tasks there are.
+	PageSize int64
+	After    primitive.ObjectID
+}
+
+// TaskQueryOption configures a task list query
+type TaskQueryOption func(*TaskQuery)
+
+// ListView limits a query to list-view fields
+func ListView() TaskQueryOption {
+	return func(q *TaskQuery) {
+		q.ListView = true
+	}
+}
+
+// Page limits a FindAll query to the next size tasks in ID order after the given
+// task ID; the zero ID starts at the first task
+func Page(after primitive.ObjectID, size int64) TaskQueryOption {
+	return func(q *TaskQuery) {
+		q.After = after
+		q.PageSize = size
+	}
+}
+
+// NewTaskQuery applies the given options to an empty query
+func NewTaskQuery(opts ...TaskQueryOption) TaskQuery {
+	var q TaskQuery
+	for _, opt := range opts {
+		opt(&q)
+	}
+	return q
 }
 
 // TaskRepository defines the interface for task data access
 type TaskRepository interface {
+	// ForOrg returns a repository limited to one organization: queries only match
+	// its tasks and created tasks are stamped with it
+	ForOrg(orgID primitive.ObjectID) TaskRepository
+
 	FindByID(id primitive.ObjectID) (*Task, error)
-	FindAll(filter map[string]interface{}) ([]*Task, error)
+	// FindByKey finds a task by its key, e.g. PROJ-123
+	FindByKey(key string) (*Task, error)
+	FindAll(filter map[string]interface{}, opts ...TaskQueryOption) ([]*Task, error)
+	Count(filter map[string]interface{}) (int64, error)
 	Create(task *Task) error
+	// CreateMany creates several tasks with a single bulk write
+	CreateMany(tasks []*Task) error
 	Update(task *Task) error
+	// RaisePriority raises the priority of an open task below maxPriority by one
+	// and marks it aged at the given time, without touching its other fields. The
+	// priority the task had before it was first raised is kept. It returns the
+	// updated task, or ErrNotFound when the task is missing, completed or already
+	// at maxPriority.
+	RaisePriority(id primitive.ObjectID, maxPriority int, agedAt time.Time) (*Task, error)
 	Delete(id primitive.ObjectID) error
-	FindByUser(userID primitive.ObjectID) ([]*Task, error)
-	FindByStatus(status TaskStatus) ([]*Task, error)
+	// DeleteMany deletes several tasks with a single bulk write and returns how many were deleted
+	DeleteMany(ids []primitive.ObjectID) (int64, error)
+	FindByUser(userID primitive.ObjectID, opts ...TaskQueryOption) ([]*Task, error)
+	FindByStatus(status TaskStatus, opts ...TaskQueryOption) ([]*Task, error)
 }
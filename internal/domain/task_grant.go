@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskAccess is the access a task grant gives
+type TaskAccess string
+
+const (
+	// TaskAccessView lets the user see the task
+	TaskAccessView TaskAccess = "view"
+	// TaskAccessEdit lets the user see and change the task
+	TaskAccessEdit TaskAccess = "edit"
+)
+
+// IsValid reports whether the access is a known task access
+func (a TaskAccess) IsValid() bool {
+	return a == TaskAccessView || a == TaskAccessEdit
+}
+
+// TaskGrant gives a user access to a task beyond what their project role or the
+// rules for tasks outside projects allow. Grants never take access away, and do
+// not let the user assign or delete the task.
+type TaskGrant struct {
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Access    TaskAccess         `bson:"access" json:"access"`
+	GrantedBy primitive.ObjectID `bson:"granted_by" json:"granted_by"`
+	GrantedAt time.Time          `bson:"granted_at" json:"granted_at"`
+}
+
+// GrantOf returns the user's grant on the task, or nil if they have none
+func (t *Task) GrantOf(userID primitive.ObjectID) *TaskGrant {
+	for i := range t.Grants {
+		if t.Grants[i].UserID == userID {
+			return &t.Grants[i]
+		}
+	}
+	return nil
+}
+
+// RemoveGrant removes the user's grant and reports whether it was present
+func (t *Task) RemoveGrant(userID primitive.ObjectID) bool {
+	for i, grant := range t.Grants {
+		if grant.UserID == userID {
+			t.Grants = append(t.Grants[:i], t.Grants[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
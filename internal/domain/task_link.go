@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskLinkType is the kind of relation a task link expresses, as seen from the
+// task that carries it
+type TaskLinkType string
+
+const (
+	TaskLinkRelatesTo    TaskLinkType = "relates_to"
+	TaskLinkDuplicates   TaskLinkType = "duplicates"
+	TaskLinkDuplicatedBy TaskLinkType = "duplicated_by"
+	TaskLinkBlocks       TaskLinkType = "blocks"
+	TaskLinkBlockedBy    TaskLinkType = "blocked_by"
+)
+
+// taskLinkInverses maps each link type to the type of its mirror on the linked task
+var taskLinkInverses = map[TaskLinkType]TaskLinkType{
+	TaskLinkRelatesTo:    TaskLinkRelatesTo,
+	TaskLinkDuplicates:   TaskLinkDuplicatedBy,
+	TaskLinkDuplicatedBy: TaskLinkDuplicates,
+	TaskLinkBlocks:       TaskLinkBlockedBy,
+	TaskLinkBlockedBy:    TaskLinkBlocks,
+}
+
+// TaskLinkTypes lists all task link types
+func TaskLinkTypes() []TaskLinkType {
+	return []TaskLinkType{TaskLinkRelatesTo, TaskLinkDuplicates, TaskLinkDuplicatedBy, TaskLinkBlocks, TaskLinkBlockedBy}
+}
+
+// IsValid reports whether the type is a known task link type
+func (t TaskLinkType) IsValid() bool {
+	_, ok := taskLinkInverses[t]
+	return ok
+}
+
+// Inverse returns the type of the link's mirror on the linked task, e.g.
+// duplicated_by for duplicates
+func (t TaskLinkType) Inverse() TaskLinkType {
+	return taskLinkInverses[t]
+}
+
+// TaskLink is a typed relation from a task to another task of the same
+// organization. Links are mirrored: the linked task carries the inverse link.
+// Two tasks are linked at most once.
+type TaskLink struct {
+	Type      TaskLinkType       `bson:"type" json:"type"`
+	TaskID    primitive.ObjectID `bson:"task_id" json:"task_id"`
+	CreatedBy primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+
+	// The linked task's key, title and status, in task details for viewers who
+	// can see it; never persisted
+	Key    string     `bson:"-" json:"key,omitempty"`
+	Title  string     `bson:"-" json:"title,omitempty"`
+	Status TaskStatus `bson:"-" json:"status,omitempty"`
+}
+
+// LinkTo returns the task's link to another task, or nil if they are not linked
+func (t *Task) LinkTo(taskID primitive.ObjectID) *TaskLink {
+	for i := range t.Links {
+		if t.Links[i].TaskID == taskID {
+			return &t.Links[i]
+		}
+	}
+	return nil
+}
+
+// RemoveLink removes the task's link to another task and reports whether it was present
+func (t *Task) RemoveLink(taskID primitive.ObjectID) bool {
+	for i, link := range t.Links {
+		if link.TaskID == taskID {
+			t.Links = append(t.Links[:i], t.Links[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskView records when a user last viewed a task, or changed it themselves
+type TaskView struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TaskID primitive.ObjectID `bson:"task_id" json:"task_id"`
+	SeenAt time.Time          `bson:"seen_at" json:"seen_at"`
+}
+
+// TaskViewRepository defines the interface for task view data access
+type TaskViewRepository interface {
+	// MarkSeen records that a user saw a task as of the given time; times before
+	// the one already recorded are ignored
+	MarkSeen(userID, taskID primitive.ObjectID, at time.Time) error
+	// FindSeen returns when the user last saw each of the given tasks they saw
+	FindSeen(userID primitive.ObjectID, taskIDs []primitive.ObjectID) (map[primitive.ObjectID]time.Time, error)
+	// FindByTask returns when each user who saw the task last did
+	FindByTask(taskID primitive.ObjectID) (map[primitive.ObjectID]time.Time, error)
+}
@@ -11,19 +11,115 @@ type User struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Username  string             `bson:"username" json:"username" validate:"required,min=3,max=50"`
 	Email     string             `bson:"email" json:"email" validate:"required,email"`
-	Password  string             `bson:"password" json:"-" validate:"required,min=6"`
+	Password  string             `bson:"password" json:"-" validate:"required"`
 	FirstName string             `bson:"first_name,omitempty" json:"first_name,omitempty"`
 	LastName  string             `bson:"last_name,omitempty" json:"last_name,omitempty"`
+	Timezone  string             `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	OrgID     primitive.ObjectID `bson:"org_id" json:"org_id"`
+	OrgRole   OrgRole            `bson:"org_role" json:"org_role"`
 	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+	// PasswordChangedAt is when the password was last set; unset for users created before it was tracked
+	PasswordChangedAt time.Time `bson:"password_changed_at,omitempty" json:"-"`
+	// AvatarKey is the storage key of the user's avatar, if they uploaded one
+	AvatarKey string `bson:"avatar_key,omitempty" json:"-"`
+	// AvatarUpdatedAt is when the avatar last changed; clients can use it to refresh cached avatars
+	AvatarUpdatedAt time.Time `bson:"avatar_updated_at,omitempty" json:"avatar_updated_at,omitempty"`
+	// TokenGeneration is embedded in every issued token; bumping it invalidates all of the user's tokens
+	TokenGeneration int `bson:"token_generation,omitempty" json:"-"`
+	// DeactivatedAt is set while the account is deactivated: it cannot sign in or be
+	// assigned tasks, but tasks and other records keep pointing at it
+	DeactivatedAt *time.Time `bson:"deactivated_at,omitempty" json:"deactivated_at,omitempty"`
+}
+
+// Location returns the user's preferred time zone, falling back to UTC
+// when none is set or the stored name is unknown
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsOrgAdmin reports whether the user administers their organization
+func (u *User) IsOrgAdmin() bool {
+	return u.OrgRole == OrgRoleAdmin
+}
+
+// IsDeactivated reports whether the account is deactivated
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
+
+// UserDeletionPolicy decides what happens to the open tasks assigned to a deleted user
+type UserDeletionPolicy string
+
+const (
+	// UserDeletionBlock refuses the deletion while the user has open tasks
+	UserDeletionBlock UserDeletionPolicy = "block"
+	// UserDeletionUnassign removes the user from their open tasks
+	UserDeletionUnassign UserDeletionPolicy = "unassign"
+	// UserDeletionReassign hands the user's open tasks over to another user
+	UserDeletionReassign UserDeletionPolicy = "reassign"
+)
+
+// IsValid checks if the deletion policy is valid
+func (p UserDeletionPolicy) IsValid() bool {
+	switch p {
+	case UserDeletionBlock, UserDeletionUnassign, UserDeletionReassign:
+		return true
+	}
+	return false
+}
+
+// UserFilter selects a page of an organization's users
+type UserFilter struct {
+	OrgID primitive.ObjectID
+	// Query matches users whose username, email or name contains it, ignoring case
+	Query string
+	Skip  int64
+	Limit int64
 }
 
 // UserRepository defines the interface for user data access
 type UserRepository interface {
 	FindByID(id primitive.ObjectID) (*User, error)
+	// FindByIDInOrg finds a user of the organization by ID; users of other
+	// organizations are not found
+	FindByIDInOrg(orgID primitive.ObjectID, id primitive.ObjectID) (*User, error)
+	// FindByIDs finds the users with the given IDs with a single query, in no
+	// particular order; IDs without a user are left out
+	FindByIDs(ids []primitive.ObjectID) ([]*User, error)
 	FindByEmail(email string) (*User, error)
 	FindByUsername(username string) (*User, error)
+	FindByOrg(orgID primitive.ObjectID) ([]*User, error)
+	// Find returns the users matching the filter, ordered by username
+	Find(filter UserFilter) ([]*User, error)
+	// Count returns the number of users matching the filter, ignoring its skip and limit
+	Count(filter UserFilter) (int64, error)
 	Create(user *User) error
 	Update(user *User) error
+	// UpdateAvatar sets the storage key of the user's avatar, or removes the avatar when the key is empty
+	UpdateAvatar(id primitive.ObjectID, key string) error
+	// IncrementTokenGeneration bumps the user's token generation, invalidating every token issued before
+	IncrementTokenGeneration(id primitive.ObjectID) error
 	Delete(id primitive.ObjectID) error
 }
+
+// BreachedPasswordChecker reports whether a password is known from data breaches
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordHasher hashes passwords and verifies them against stored hashes
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the hash, and whether a matching
+	// hash should be replaced because it uses an outdated algorithm or parameters
+	Verify(hash string, password string) (ok bool, rehash bool)
+}
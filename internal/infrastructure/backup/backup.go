@@ -0,0 +1,341 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FormatVersion identifies the archive layout
+const FormatVersion = 1
+
+// manifestName is the archive entry holding the manifest. It is written last
+// and acts as the consistency marker: an archive without it is incomplete.
+const manifestName = "manifest.json"
+
+// importBatchSize is the number of documents inserted per batch on import
+const importBatchSize = 500
+
+// Manifest describes the contents of a backup archive
+type Manifest struct {
+	FormatVersion int                  `json:"format_version"`
+	Database      string               `json:"database"`
+	StartedAt     time.Time            `json:"started_at"`
+	CompletedAt   time.Time            `json:"completed_at"`
+	Collections   []CollectionManifest `json:"collections"`
+}
+
+// CollectionManifest describes one exported collection
+type CollectionManifest struct {
+	Name      string `json:"name"`
+	Documents int64  `json:"documents"`
+	SHA256    string `json:"sha256"`
+}
+
+// entryName returns the archive entry name of a collection
+func entryName(collection string) string {
+	return collection + ".jsonl"
+}
+
+// Export writes all collections of the database to w as a gzip-compressed tar
+// archive. Each collection is stored as canonical Extended JSON, one document
+// per line, so BSON types survive the round trip. Indexes are not exported;
+// the servers recreate them on startup.
+func Export(ctx context.Context, db *mongo.Database, w io.Writer) (*Manifest, error) {
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		Database:      db.Name(),
+		StartedAt:     time.Now().UTC(),
+	}
+
+	names, err := db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, name := range names {
+		if strings.HasPrefix(name, "system.") {
+			continue
+		}
+
+		entry, err := exportCollection(ctx, db.Collection(name), tw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export collection %s: %w", name, err)
+		}
+		manifest.Collections = append(manifest.Collections, *entry)
+	}
+
+	manifest.CompletedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeEntry(tw, manifestName, data); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// exportCollection spools a collection to a temporary file, since tar entries
+// need their size up front, and then copies it into the archive
+func exportCollection(ctx context.Context, collection *mongo.Collection, tw *tar.Writer) (*CollectionManifest, error) {
+	tmp, err := os.CreateTemp("", "backup-*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hash := sha256.New()
+	out := bufio.NewWriter(io.MultiWriter(tmp, hash))
+
+	var count int64
+	for cursor.Next(ctx) {
+		line, err := bson.MarshalExtJSON(cursor.Current, true, false)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(line)
+		out.WriteByte('\n')
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	if err := out.Flush(); err != nil {
+		return nil, err
+	}
+
+	info, err := tmp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	name := collection.Name()
+	header := &tar.Header{
+		Name:    entryName(name),
+		Mode:    0o644,
+		Size:    info.Size(),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(tw, tmp); err != nil {
+		return nil, err
+	}
+
+	return &CollectionManifest{
+		Name:      name,
+		Documents: count,
+		SHA256:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// writeEntry adds an in-memory file to the archive
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ImportOptions controls how an archive is restored
+type ImportOptions struct {
+	// Drop removes existing documents from each restored collection first.
+	// Without it, the import refuses to write into non-empty collections.
+	Drop bool
+}
+
+// Import restores an archive written by Export. The whole archive is read and
+// verified against its manifest before anything is written to the database.
+func Import(ctx context.Context, db *mongo.Database, r io.Reader, opts ImportOptions) (*Manifest, error) {
+	dir, err := os.MkdirTemp("", "restore-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest, files, err := unpack(r, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse to mix restored data with existing documents unless asked to replace them
+	for _, entry := range manifest.Collections {
+		collection := db.Collection(entry.Name)
+		if opts.Drop {
+			if _, err := collection.DeleteMany(ctx, bson.M{}); err != nil {
+				return nil, fmt.Errorf("failed to clear collection %s: %w", entry.Name, err)
+			}
+			continue
+		}
+
+		count, err := collection.EstimatedDocumentCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("collection %s is not empty; use drop to replace its contents", entry.Name)
+		}
+	}
+
+	for _, entry := range manifest.Collections {
+		if err := importCollection(ctx, db.Collection(entry.Name), files[entry.Name]); err != nil {
+			return nil, fmt.Errorf("failed to import collection %s: %w", entry.Name, err)
+		}
+	}
+
+	return manifest, nil
+}
+
+// unpack extracts the archive into dir and verifies every collection against the manifest
+func unpack(r io.Reader, dir string) (*Manifest, map[string]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	hashes := make(map[string]string)
+	var manifest *Manifest
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if header.Name == manifestName {
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+			continue
+		}
+
+		name := strings.TrimSuffix(header.Name, ".jsonl")
+		if name == header.Name || strings.ContainsAny(name, `/\`) {
+			return nil, nil, fmt.Errorf("unexpected archive entry %q", header.Name)
+		}
+
+		f, err := os.CreateTemp(dir, "*.jsonl")
+		if err != nil {
+			return nil, nil, err
+		}
+		hash := sha256.New()
+		_, err = io.Copy(io.MultiWriter(f, hash), tr)
+		f.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files[name] = f.Name()
+		hashes[name] = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	if manifest == nil {
+		return nil, nil, errors.New("archive has no manifest; the backup is incomplete")
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, nil, fmt.Errorf("unsupported archive format version %d", manifest.FormatVersion)
+	}
+
+	for _, entry := range manifest.Collections {
+		hash, ok := hashes[entry.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("archive is missing collection %s", entry.Name)
+		}
+		if hash != entry.SHA256 {
+			return nil, nil, fmt.Errorf("checksum mismatch for collection %s", entry.Name)
+		}
+	}
+
+	return manifest, files, nil
+}
+
+// importCollection inserts the documents of one extracted collection file in batches
+func importCollection(ctx context.Context, collection *mongo.Collection, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // documents are at most 16MB
+
+	batch := make([]interface{}, 0, importBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := collection.InsertMany(ctx, batch)
+		batch = batch[:0]
+		return err
+	}
+
+	for scanner.Scan() {
+		var doc bson.D
+		if err := bson.UnmarshalExtJSON(scanner.Bytes(), true, &doc); err != nil {
+			return err
+		}
+
+		batch = append(batch, doc)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return flush()
+}
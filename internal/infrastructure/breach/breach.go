@@ -0,0 +1,29 @@
+package breach
+
+import (
+	"fmt"
+
+	"task-management-system/config"
+	"task-management-system/internal/domain"
+)
+
+// Breached password checkers selectable in the configuration
+const (
+	CheckerPwnedPasswords = "hibp"
+	CheckerList           = "list"
+)
+
+// NewCheckerFromConfig creates the configured breached password checker, or returns
+// nil when the check is disabled
+func NewCheckerFromConfig(cfg config.PasswordConfig) (domain.BreachedPasswordChecker, error) {
+	switch cfg.BreachCheck {
+	case "":
+		return nil, nil
+	case CheckerPwnedPasswords:
+		return NewPwnedPasswordsChecker(cfg.BreachTimeout), nil
+	case CheckerList:
+		return LoadListChecker(cfg.BreachList)
+	default:
+		return nil, fmt.Errorf("unknown breached password check %q", cfg.BreachCheck)
+	}
+}
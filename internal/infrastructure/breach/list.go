@@ -0,0 +1,47 @@
+package breach
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ListChecker checks passwords against a local list of known breached or common
+// passwords, for deployments that cannot reach the Pwned Passwords API
+type ListChecker struct {
+	passwords map[string]struct{}
+}
+
+// LoadListChecker loads a list checker from a file holding one password per line.
+// Matching is case-insensitive.
+func LoadListChecker(path string) (*ListChecker, error) {
+	if path == "" {
+		return nil, fmt.Errorf("a password list file is required for the %s breached password check", CheckerList)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	checker := &ListChecker{passwords: make(map[string]struct{})}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if password := strings.TrimSpace(scanner.Text()); password != "" {
+			checker.passwords[strings.ToLower(password)] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read password list %s: %w", path, err)
+	}
+
+	return checker, nil
+}
+
+// IsBreached reports whether the password is on the list
+func (c *ListChecker) IsBreached(password string) (bool, error) {
+	_, ok := c.passwords[strings.ToLower(password)]
+	return ok, nil
+}
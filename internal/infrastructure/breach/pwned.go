@@ -0,0 +1,65 @@
+package breach
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultPwnedPasswordsURL is the Pwned Passwords range API of HaveIBeenPwned
+const DefaultPwnedPasswordsURL = "https://api.pwnedpasswords.com/range/"
+
+// PwnedPasswordsChecker checks passwords against the HaveIBeenPwned Pwned Passwords
+// API. It uses the k-anonymity range endpoint: only the first five characters of
+// the password's SHA-1 hash are sent, and the match is made locally.
+type PwnedPasswordsChecker struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewPwnedPasswordsChecker creates a new Pwned Passwords checker
+func NewPwnedPasswordsChecker(timeout time.Duration) *PwnedPasswordsChecker {
+	return &PwnedPasswordsChecker{
+		client:  &http.Client{Timeout: timeout},
+		baseURL: DefaultPwnedPasswordsURL,
+	}
+}
+
+// IsBreached reports whether the password appears in the Pwned Passwords corpus
+func (c *PwnedPasswordsChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides the real size of the response from observers
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	// Each line is "<hash suffix>:<times seen>"; padding lines are seen zero times
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if ok && candidate == suffix && count != "0" {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
@@ -0,0 +1,208 @@
+// Package errorreport sends error-level log entries to error tracking services
+package errorreport
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"task-management-system/config"
+	"task-management-system/internal/logger"
+)
+
+const (
+	// sentryQueueSize is how many reports wait to be sent; more are dropped
+	sentryQueueSize = 100
+	// sentryTimeout bounds each request to Sentry
+	sentryTimeout = 10 * time.Second
+)
+
+// sentryLevels maps log levels to Sentry's
+var sentryLevels = map[logger.Level]string{
+	logger.LevelError: "error",
+	logger.LevelFatal: "fatal",
+}
+
+// SentryReporter sends reports to Sentry's store API in the background,
+// tagged with the application's environment and version
+type SentryReporter struct {
+	storeURL   string
+	auth       string
+	app        config.AppConfig
+	serverName string
+	client     *http.Client
+	queue      chan *logger.Report
+	pending    sync.WaitGroup
+	log        logger.Logger
+}
+
+// NewFromConfig creates the configured reporter, or returns nil when error reporting is disabled
+func NewFromConfig(cfg config.ErrorReportingConfig, app config.AppConfig, log logger.Logger) (logger.Reporter, error) {
+	if cfg.SentryDSN == "" {
+		return nil, nil
+	}
+	return NewSentryReporter(cfg.SentryDSN, app, log)
+}
+
+// NewSentryReporter creates a reporter for the project of a Sentry DSN, such
+// as "https://key@o1.ingest.sentry.io/42", and starts sending reports. Reports
+// that cannot be sent are logged to the given logger.
+func NewSentryReporter(dsn string, app config.AppConfig, log logger.Logger) (*SentryReporter, error) {
+	storeURL, key, err := parseSentryDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	serverName, _ := os.Hostname()
+	r := &SentryReporter{
+		storeURL:   storeURL,
+		auth:       fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s/%s, sentry_key=%s", app.Name, app.Version, key),
+		app:        app,
+		serverName: serverName,
+		client:     &http.Client{Timeout: sentryTimeout},
+		queue:      make(chan *logger.Report, sentryQueueSize),
+		log:        log,
+	}
+	go r.run()
+	return r, nil
+}
+
+// parseSentryDSN returns the store endpoint and the public key of a DSN
+func parseSentryDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User == nil {
+		return "", "", fmt.Errorf("invalid Sentry DSN: must look like https://key@host/project")
+	}
+
+	key := u.User.Username()
+	path := strings.Trim(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	prefix, projectID := "", path
+	if i >= 0 {
+		prefix, projectID = "/"+path[:i], path[i+1:]
+	}
+	if key == "" || projectID == "" {
+		return "", "", fmt.Errorf("invalid Sentry DSN: must look like https://key@host/project")
+	}
+
+	return fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, prefix, projectID), key, nil
+}
+
+// Report queues a report to be sent; it is dropped when the queue is full
+func (r *SentryReporter) Report(report *logger.Report) {
+	r.pending.Add(1)
+	select {
+	case r.queue <- report:
+	default:
+		r.pending.Done()
+	}
+}
+
+// Flush waits up to the timeout for the queued reports to be sent
+func (r *SentryReporter) Flush(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		r.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// run sends queued reports one at a time
+func (r *SentryReporter) run() {
+	for report := range r.queue {
+		if err := r.send(report); err != nil {
+			// Logged below error level, so a failing Sentry is not reported to itself
+			r.log.WarnF("Failed to report error to Sentry: %v", err)
+		}
+		r.pending.Done()
+	}
+}
+
+// send posts a report to Sentry as an event
+func (r *SentryReporter) send(report *logger.Report) error {
+	body, err := json.Marshal(r.event(report))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// event builds the Sentry event of a report. Recovered panics are sent as
+// exceptions with their stack; other fields become extra data, except request
+// and method identifiers, which become tags to search by.
+func (r *SentryReporter) event(report *logger.Report) map[string]interface{} {
+	tags := map[string]string{}
+	extra := map[string]interface{}{}
+	for key, value := range report.Fields {
+		switch key {
+		case "request_id", "method":
+			tags[key] = fmt.Sprint(value)
+		default:
+			extra[key] = fmt.Sprint(value)
+		}
+	}
+
+	event := map[string]interface{}{
+		"event_id":    newEventID(),
+		"timestamp":   report.Time.UTC().Format(time.RFC3339),
+		"level":       sentryLevels[report.Level],
+		"platform":    "go",
+		"logger":      r.app.Name,
+		"server_name": r.serverName,
+		"environment": r.app.Env,
+		"release":     r.app.Version,
+		"culprit":     report.Caller,
+		"message":     map[string]string{"formatted": report.Message},
+		"tags":        tags,
+		"extra":       extra,
+	}
+
+	if panicValue, ok := report.Fields["panic"]; ok {
+		event["exception"] = map[string]interface{}{
+			"values": []map[string]interface{}{{
+				"type":      "panic",
+				"value":     fmt.Sprint(panicValue),
+				"mechanism": map[string]interface{}{"type": "recover", "handled": true},
+			}},
+		}
+	}
+
+	return event
+}
+
+// newEventID returns a random event ID in Sentry's 32 hex digit format
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
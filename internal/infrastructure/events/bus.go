@@ -0,0 +1,47 @@
+package events
+
+import (
+	"sync"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+)
+
+// Handler processes a published domain event
+type Handler func(event *domain.Event) error
+
+// Bus is a synchronous in-process event bus
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+	log      logger.Logger
+}
+
+// NewBus creates a new event bus logging handler failures to the given logger
+func NewBus(log logger.Logger) *Bus {
+	return &Bus{log: log}
+}
+
+// Subscribe registers a handler that receives every published event
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish delivers an event to all subscribers. Handler errors are logged
+// and never propagated, so a failing subscriber cannot break the write that
+// produced the event.
+func (b *Bus) Publish(event *domain.Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(event); err != nil {
+			b.log.ErrorF("Event handler failed for %s: %v", event.Type, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+)
+
+// defaultRelayBatchSize is used when no positive batch size is configured
+const defaultRelayBatchSize = 100
+
+// Relay delivers events recorded in the outbox to a publisher. Delivery is
+// at-least-once: an event may be published again if the relay stops between
+// publishing it and marking it delivered.
+type Relay struct {
+	outbox    domain.OutboxRepository
+	publisher domain.EventPublisher
+	batchSize int64
+	log       logger.Logger
+}
+
+// NewRelay creates a new outbox relay logging its progress to the given logger
+func NewRelay(outbox domain.OutboxRepository, publisher domain.EventPublisher, batchSize int64, log logger.Logger) *Relay {
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatchSize
+	}
+
+	return &Relay{
+		outbox:    outbox,
+		publisher: publisher,
+		batchSize: batchSize,
+		log:       log,
+	}
+}
+
+// Run delivers pending events in the order they were recorded, until none are left
+func (r *Relay) Run() error {
+	for {
+		messages, err := r.outbox.FindPending(r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			r.publisher.Publish(&message.Event)
+
+			if err := r.outbox.MarkDelivered(message.ID); err != nil {
+				return err
+			}
+		}
+
+		if int64(len(messages)) < r.batchSize {
+			return nil
+		}
+
+		r.log.DebugF("Outbox relay delivered a full batch of %d events, continuing", len(messages))
+	}
+}
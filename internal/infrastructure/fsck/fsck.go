@@ -0,0 +1,301 @@
+// Package fsck finds, and optionally repairs, references to documents that no longer exist
+package fsck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Repair actions taken on a dangling reference
+const (
+	// RepairNone marks references that cannot be repaired safely, such as a task's creator
+	RepairNone = "none"
+	// RepairUnset removes an optional reference from its document
+	RepairUnset = "unset"
+	// RepairPull removes a dangling ID, or the array element holding it, from an array
+	RepairPull = "pull"
+	// RepairDelete deletes a document that is meaningless without the referenced one
+	RepairDelete = "delete"
+)
+
+// reference describes a field of a collection that points at documents of another
+type reference struct {
+	collection string
+	// field is the dotted path of the ObjectID; any step may be an array
+	field  string
+	target string
+	repair string
+	// note names a field of the referencing document worth reporting, e.g. a storage key
+	note string
+}
+
+// name identifies the check of a reference in reports, e.g. tasks.assigned_to
+func (r reference) name() string {
+	return r.collection + "." + r.field
+}
+
+// references lists every reference checked, in the order they are checked
+var references = []reference{
+	{collection: "tasks", field: "created_by", target: "users", repair: RepairNone},
+	{collection: "tasks", field: "assigned_to", target: "users", repair: RepairPull},
+	{collection: "tasks", field: "project_id", target: "projects", repair: RepairNone},
+	{collection: "tasks", field: "sprint_id", target: "sprints", repair: RepairUnset},
+	{collection: "tasks", field: "milestone_id", target: "milestones", repair: RepairUnset},
+	{collection: "tasks", field: "merged_into", target: "tasks", repair: RepairUnset},
+	{collection: "projects", field: "members.user_id", target: "users", repair: RepairPull},
+	{collection: "attachments", field: "task_id", target: "tasks", repair: RepairDelete, note: "storage_key"},
+	{collection: "task_stars", field: "task_id", target: "tasks", repair: RepairDelete},
+	{collection: "task_stars", field: "user_id", target: "users", repair: RepairDelete},
+	{collection: "task_snoozes", field: "task_id", target: "tasks", repair: RepairDelete},
+	{collection: "task_snoozes", field: "user_id", target: "users", repair: RepairDelete},
+	{collection: "day_plans", field: "task_id", target: "tasks", repair: RepairDelete},
+	{collection: "day_plans", field: "user_id", target: "users", repair: RepairDelete},
+	{collection: "inbound_hooks", field: "project_id", target: "projects", repair: RepairDelete},
+	{collection: "inbound_hooks", field: "creator_id", target: "users", repair: RepairDelete},
+	{collection: "calendar_feeds", field: "_id", target: "users", repair: RepairDelete},
+	{collection: "notifications", field: "user_id", target: "users", repair: RepairDelete},
+}
+
+// Options control a check
+type Options struct {
+	// Repair applies the repair action of every dangling reference found
+	Repair bool
+}
+
+// Finding is one dangling reference
+type Finding struct {
+	Check      string `json:"check"`
+	Collection string `json:"collection"`
+	ID         string `json:"id"`
+	Field      string `json:"field"`
+	Missing    string `json:"missing"`
+	Target     string `json:"target"`
+	Repair     string `json:"repair"`
+	Repaired   bool   `json:"repaired"`
+	// Note carries context for manual follow-up, e.g. the storage key of an orphaned attachment's blob
+	Note string `json:"note,omitempty"`
+}
+
+// CheckSummary counts the findings of one reference
+type CheckSummary struct {
+	Check    string `json:"check"`
+	Scanned  int64  `json:"scanned"`
+	Dangling int    `json:"dangling"`
+	Repaired int    `json:"repaired"`
+}
+
+// Report describes the outcome of a check
+type Report struct {
+	Database    string         `json:"database"`
+	StartedAt   time.Time      `json:"started_at"`
+	CompletedAt time.Time      `json:"completed_at"`
+	Repair      bool           `json:"repair"`
+	Checks      []CheckSummary `json:"checks"`
+	Findings    []Finding      `json:"findings"`
+}
+
+// Unrepaired returns the number of findings left as they were
+func (r *Report) Unrepaired() int {
+	unrepaired := 0
+	for _, finding := range r.Findings {
+		if !finding.Repaired {
+			unrepaired++
+		}
+	}
+	return unrepaired
+}
+
+// Check scans the database for references to documents that no longer exist and,
+// if the options say so, repairs them. The IDs of every referenced collection are
+// loaded into memory first, so the database should not be written meanwhile;
+// references created during the scan may be reported as dangling.
+func Check(ctx context.Context, db *mongo.Database, opts Options) (*Report, error) {
+	report := &Report{
+		Database:  db.Name(),
+		StartedAt: time.Now().UTC(),
+		Repair:    opts.Repair,
+		Findings:  []Finding{},
+	}
+
+	existing := make(map[string]map[primitive.ObjectID]bool)
+	for _, ref := range references {
+		if _, ok := existing[ref.target]; ok {
+			continue
+		}
+		ids, err := loadIDs(ctx, db.Collection(ref.target))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", ref.target, err)
+		}
+		existing[ref.target] = ids
+	}
+
+	for _, ref := range references {
+		summary, findings, err := checkReference(ctx, db.Collection(ref.collection), ref, existing[ref.target], opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", ref.name(), err)
+		}
+		report.Checks = append(report.Checks, summary)
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	report.CompletedAt = time.Now().UTC()
+	return report, nil
+}
+
+// loadIDs returns the IDs of every document of a collection
+func loadIDs(ctx context.Context, collection *mongo.Collection) (map[primitive.ObjectID]bool, error) {
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := make(map[primitive.ObjectID]bool)
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids[doc.ID] = true
+	}
+	return ids, cursor.Err()
+}
+
+// checkReference reports, and optionally repairs, the dangling references of one field
+func checkReference(ctx context.Context, collection *mongo.Collection, ref reference, existing map[primitive.ObjectID]bool, opts Options) (CheckSummary, []Finding, error) {
+	summary := CheckSummary{Check: ref.name()}
+	root := strings.SplitN(ref.field, ".", 2)[0]
+
+	projection := bson.M{root: 1}
+	if ref.note != "" {
+		projection[ref.note] = 1
+	}
+
+	// Documents without the field hold no reference
+	cursor, err := collection.Find(ctx, bson.M{ref.field: bson.M{"$exists": true}}, options.Find().SetProjection(projection))
+	if err != nil {
+		return summary, nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var findings []Finding
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return summary, nil, err
+		}
+		summary.Scanned++
+
+		id, _ := doc["_id"].(primitive.ObjectID)
+		note, _ := doc[ref.note].(string)
+		for _, missing := range objectIDs(doc, strings.Split(ref.field, ".")) {
+			// The zero ID stands for no reference
+			if missing.IsZero() || existing[missing] {
+				continue
+			}
+			findings = append(findings, Finding{
+				Check:      ref.name(),
+				Collection: collection.Name(),
+				ID:         id.Hex(),
+				Field:      ref.field,
+				Missing:    missing.Hex(),
+				Target:     ref.target,
+				Repair:     ref.repair,
+				Note:       note,
+			})
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return summary, nil, err
+	}
+
+	summary.Dangling = len(findings)
+	if !opts.Repair {
+		return summary, findings, nil
+	}
+
+	for i := range findings {
+		repaired, err := repair(ctx, collection, ref, &findings[i])
+		if err != nil {
+			return summary, nil, fmt.Errorf("failed to repair %s of %s: %w", ref.field, findings[i].ID, err)
+		}
+		findings[i].Repaired = repaired
+		if repaired {
+			summary.Repaired++
+		}
+	}
+
+	return summary, findings, nil
+}
+
+// objectIDs collects the ObjectIDs found at a path of a document, descending into arrays
+func objectIDs(value interface{}, path []string) []primitive.ObjectID {
+	switch v := value.(type) {
+	case primitive.ObjectID:
+		if len(path) == 0 {
+			return []primitive.ObjectID{v}
+		}
+	case primitive.A:
+		var ids []primitive.ObjectID
+		for _, element := range v {
+			ids = append(ids, objectIDs(element, path)...)
+		}
+		return ids
+	case bson.M:
+		if len(path) > 0 {
+			return objectIDs(v[path[0]], path[1:])
+		}
+	case bson.D:
+		for _, element := range v {
+			if len(path) > 0 && element.Key == path[0] {
+				return objectIDs(element.Value, path[1:])
+			}
+		}
+	}
+	return nil
+}
+
+// repair applies the repair action of a reference to a finding. A document already
+// deleted by the repair of another of its findings counts as repaired.
+func repair(ctx context.Context, collection *mongo.Collection, ref reference, finding *Finding) (bool, error) {
+	id, err := primitive.ObjectIDFromHex(finding.ID)
+	if err != nil {
+		return false, err
+	}
+	missing, err := primitive.ObjectIDFromHex(finding.Missing)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": id}
+	switch ref.repair {
+	case RepairUnset:
+		_, err = collection.UpdateOne(ctx, filter, bson.M{"$unset": bson.M{ref.field: ""}})
+	case RepairPull:
+		// members.user_id pulls the members holding the ID; assigned_to pulls the ID itself
+		var pull bson.M
+		if head, rest, nested := strings.Cut(ref.field, "."); nested {
+			pull = bson.M{head: bson.M{rest: missing}}
+		} else {
+			pull = bson.M{ref.field: missing}
+		}
+		_, err = collection.UpdateOne(ctx, filter, bson.M{"$pull": pull})
+	case RepairDelete:
+		_, err = collection.DeleteOne(ctx, filter)
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package hashing
+
+import (
+	"fmt"
+	"strings"
+
+	"task-management-system/config"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hashing algorithms selectable in the configuration
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// Hasher hashes new passwords with the configured algorithm and verifies hashes of
+// every supported algorithm, so the algorithm or its cost can be changed without
+// invalidating stored passwords. Hashes not matching the current configuration are
+// reported for rehashing.
+type Hasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2     Argon2Params
+}
+
+// NewHasher creates a password hasher from the configuration. Unset parameters use defaults.
+func NewHasher(cfg config.HashingConfig) (*Hasher, error) {
+	h := &Hasher{
+		algorithm:  cfg.Algorithm,
+		bcryptCost: cfg.BcryptCost,
+		argon2: Argon2Params{
+			Time:    uint32(cfg.Argon2Time),
+			Memory:  uint32(cfg.Argon2Memory),
+			Threads: uint8(cfg.Argon2Threads),
+		},
+	}
+
+	if h.algorithm == "" {
+		h.algorithm = AlgorithmBcrypt
+	}
+	if h.algorithm != AlgorithmBcrypt && h.algorithm != AlgorithmArgon2id {
+		return nil, fmt.Errorf("unknown password hashing algorithm %q", cfg.Algorithm)
+	}
+
+	if h.bcryptCost == 0 {
+		h.bcryptCost = bcrypt.DefaultCost
+	}
+	if h.bcryptCost < bcrypt.MinCost || h.bcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	h.argon2 = h.argon2.withDefaults()
+
+	return h, nil
+}
+
+// Hash hashes a password with the configured algorithm
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.algorithm == AlgorithmArgon2id {
+		return hashArgon2id(password, h.argon2)
+	}
+
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedBytes), nil
+}
+
+// Verify checks a password against a hash of any supported algorithm
+func (h *Hasher) Verify(hash string, password string) (bool, bool) {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		params, ok := verifyArgon2id(hash, password)
+		if !ok {
+			return false, false
+		}
+		return true, h.algorithm != AlgorithmArgon2id || params != h.argon2
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, h.algorithm != AlgorithmBcrypt || err != nil || cost != h.bcryptCost
+}
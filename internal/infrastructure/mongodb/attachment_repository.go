@@ -0,0 +1,239 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type attachmentRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+	base       context.Context // parent of every operation's context; carries the session inside a unit of work
+}
+
+// attachmentIndexes are the indexes of the attachments collection
+var attachmentIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: 1}},
+	},
+	{
+		Keys:    bson.D{{Key: "thumbnail", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"thumbnail": domain.ThumbnailStatusPending}),
+	},
+	{
+		Keys:    bson.D{{Key: "scan", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"scan": domain.ScanStatusPending}),
+	},
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *mongo.Database, timeout time.Duration) domain.AttachmentRepository {
+	collection := newCollection(db, "attachments")
+
+	return &attachmentRepository{
+		collection: collection,
+		timeout:    timeout,
+		base:       context.Background(),
+	}
+}
+
+// Create creates a new attachment
+func (r *attachmentRepository) Create(attachment *domain.Attachment) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	if attachment.ID.IsZero() {
+		attachment.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	attachment.CreatedAt = now
+	attachment.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, attachment)
+	return err
+}
+
+// FindByID finds an attachment by its ID
+func (r *attachmentRepository) FindByID(id primitive.ObjectID) (*domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	var attachment domain.Attachment
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&attachment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// FindByTask returns a task's attachments, oldest first
+func (r *attachmentRepository) FindByTask(taskID primitive.ObjectID) ([]*domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"task_id": taskID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attachments := []*domain.Attachment{}
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// FindByOrg returns an organization's attachments, oldest first
+func (r *attachmentRepository) FindByOrg(orgID primitive.ObjectID) ([]*domain.Attachment, error) {
+	return r.findOldest(bson.M{"org_id": orgID}, 0)
+}
+
+// MarkReady records the uploaded size of an attachment and marks it ready
+func (r *attachmentRepository) MarkReady(id primitive.ObjectID, size int64, thumbnail domain.ThumbnailStatus, scan domain.ScanStatus) error {
+	set := bson.M{
+		"status":     domain.AttachmentStatusReady,
+		"size":       size,
+		"updated_at": time.Now(),
+	}
+	if thumbnail != "" {
+		set["thumbnail"] = thumbnail
+	}
+	if scan != "" {
+		set["scan"] = scan
+	}
+
+	return r.update(id, bson.M{"$set": set})
+}
+
+// FindPendingThumbnails returns up to limit attachments whose thumbnail is
+// pending, oldest first, skipping those not yet scanned or quarantined
+func (r *attachmentRepository) FindPendingThumbnails(limit int64) ([]*domain.Attachment, error) {
+	return r.findOldest(bson.M{
+		"thumbnail": domain.ThumbnailStatusPending,
+		"scan":      bson.M{"$nin": []domain.ScanStatus{domain.ScanStatusPending, domain.ScanStatusQuarantined}},
+	}, limit)
+}
+
+// SetThumbnail records the status of an attachment's thumbnail and where it is stored
+func (r *attachmentRepository) SetThumbnail(id primitive.ObjectID, status domain.ThumbnailStatus, key string) error {
+	return r.update(id, bson.M{
+		"$set": bson.M{
+			"thumbnail":     status,
+			"thumbnail_key": key,
+		},
+	})
+}
+
+// FindPendingScans returns up to limit attachments whose scan is pending, oldest first
+func (r *attachmentRepository) FindPendingScans(limit int64) ([]*domain.Attachment, error) {
+	return r.findOldest(bson.M{"scan": domain.ScanStatusPending}, limit)
+}
+
+// MarkClean records that an attachment's scan found nothing
+func (r *attachmentRepository) MarkClean(id primitive.ObjectID) error {
+	return r.update(id, bson.M{
+		"$set": bson.M{"scan": domain.ScanStatusClean},
+	})
+}
+
+// Quarantine records why an attachment was quarantined and where its content was
+// moved. Quarantined attachments get no thumbnail.
+func (r *attachmentRepository) Quarantine(id primitive.ObjectID, threat string, key string) error {
+	return r.update(id, bson.M{
+		"$set": bson.M{
+			"scan":        domain.ScanStatusQuarantined,
+			"scan_threat": threat,
+			"storage_key": key,
+			"updated_at":  time.Now(),
+		},
+		"$unset": bson.M{"thumbnail": ""},
+	})
+}
+
+// findOldest returns up to limit attachments matching a filter, oldest first
+func (r *attachmentRepository) findOldest(filter bson.M, limit int64) ([]*domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attachments := []*domain.Attachment{}
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// update applies an update to an attachment
+func (r *attachmentRepository) update(id primitive.ObjectID, update bson.M) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// MoveToTask moves all attachments of a task to another task
+func (r *attachmentRepository) MoveToTask(from, to primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"task_id": from},
+		bson.M{"$set": bson.M{"task_id": to, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// Delete deletes an attachment by its ID
+func (r *attachmentRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type auditRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// auditIndexes are the indexes of the audit_log collection
+var auditIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "actor_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+}
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(db *mongo.Database, timeout time.Duration) domain.AuditRepository {
+	collection := newCollection(db, "audit_log")
+
+	return &auditRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Append adds an entry to the audit log
+func (r *auditRepository) Append(entry *domain.AuditEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created time
+	entry.CreatedAt = time.Now()
+
+	// If ID is not set, set it to a new ObjectID
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// Find finds the audit entries matching the filter, newest first
+func (r *auditRepository) Find(filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	query := bson.M{"org_id": filter.OrgID}
+	if !filter.ActorID.IsZero() {
+		query["actor_id"] = filter.ActorID
+	}
+	if !filter.TargetID.IsZero() {
+		query["target_id"] = filter.TargetID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		createdAt := bson.M{}
+		if !filter.From.IsZero() {
+			createdAt["$gte"] = filter.From
+		}
+		if !filter.To.IsZero() {
+			createdAt["$lt"] = filter.To
+		}
+		query["created_at"] = createdAt
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*domain.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,102 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type calendarFeedRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// calendarFeedIndexes are the indexes of the calendar_feeds collection
+var calendarFeedIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+}
+
+// NewCalendarFeedRepository creates a new calendar feed repository
+func NewCalendarFeedRepository(db *mongo.Database, timeout time.Duration) domain.CalendarFeedRepository {
+	collection := newCollection(db, "calendar_feeds")
+
+	return &calendarFeedRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Save creates the user's calendar feed or replaces the existing one
+func (r *calendarFeedRepository) Save(feed *domain.CalendarFeed) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	feed.CreatedAt = time.Now()
+	feed.LastFetchedAt = nil
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": feed.UserID}, feed, options.Replace().SetUpsert(true))
+	return err
+}
+
+// FindByUser finds the calendar feed of a user
+func (r *calendarFeedRepository) FindByUser(userID primitive.ObjectID) (*domain.CalendarFeed, error) {
+	return r.findOne(bson.M{"_id": userID})
+}
+
+// FindByTokenHash finds a calendar feed by the hash of its token
+func (r *calendarFeedRepository) FindByTokenHash(tokenHash string) (*domain.CalendarFeed, error) {
+	return r.findOne(bson.M{"token_hash": tokenHash})
+}
+
+// findOne finds the calendar feed matching a filter
+func (r *calendarFeedRepository) findOne(filter bson.M) (*domain.CalendarFeed, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var feed domain.CalendarFeed
+	err := r.collection.FindOne(ctx, filter).Decode(&feed)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+// Touch records that a user's calendar feed was fetched at the given time
+func (r *calendarFeedRepository) Touch(userID primitive.ObjectID, at time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"last_fetched_at": at}})
+	return err
+}
+
+// Delete deletes the calendar feed of a user
+func (r *calendarFeedRepository) Delete(userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -2,31 +2,111 @@ package mongodb
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	"task-management-system/config"
+	"task-management-system/internal/logger"
 )
 
-// NewClient creates a new MongoDB client connection
-func NewClient(uri string, timeout time.Duration) (*mongo.Client, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+// NewClient creates a new MongoDB client connection with the configured pool
+// size, read preference, write concern and retryable writes. Its commands are
+// recorded by the monitor, if not nil.
+func NewClient(cfg config.MongoDBConfig, monitor *CommandMonitor) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	opts, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if monitor != nil {
+		opts.SetMonitor(monitor.Monitor())
+	}
+
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Ping the database to verify connection
 	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		client.Disconnect(context.Background())
 		return nil, err
 	}
 
 	return client, nil
 }
 
+// Connect creates a client like NewClient, retrying with exponential backoff
+// as configured while MongoDB cannot be reached, such as when both are started
+// together. Failed attempts are logged.
+func Connect(cfg config.MongoDBConfig, monitor *CommandMonitor, log logger.Logger) (*mongo.Client, error) {
+	// Invalid options fail the same way every time
+	if _, err := clientOptions(cfg); err != nil {
+		return nil, err
+	}
+
+	backoff := cfg.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		client, err := NewClient(cfg, monitor)
+		if err == nil {
+			return client, nil
+		}
+		if attempt > cfg.ConnectRetries {
+			return nil, err
+		}
+
+		log.WarnF("Failed to connect to MongoDB (attempt %d of %d), retrying in %s: %v", attempt, cfg.ConnectRetries+1, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, cfg.ConnectMaxBackoff)
+	}
+}
+
+// clientOptions returns the client options of the configuration. Settings left
+// unset keep the values of the URI, or the driver's defaults.
+func clientOptions(cfg config.MongoDBConfig) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(cfg.URI).SetRetryWrites(cfg.RetryWrites)
+
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
+		pref, err := readpref.New(mode)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetReadPreference(pref)
+	}
+
+	switch cfg.WriteConcern {
+	case "":
+	case "majority":
+		opts.SetWriteConcern(writeconcern.Majority())
+	default:
+		w, err := strconv.Atoi(cfg.WriteConcern)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetWriteConcern(&writeconcern.WriteConcern{W: w})
+	}
+
+	return opts, nil
+}
+
 // GetDatabase returns a database instance
 func GetDatabase(client *mongo.Client, dbName string) *mongo.Database {
 	return client.Database(dbName)
@@ -0,0 +1,59 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type counterRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// NewCounterRepository creates a new counter repository
+func NewCounterRepository(db *mongo.Database, timeout time.Duration) domain.CounterRepository {
+	return &counterRepository{
+		collection: newCollection(db, "counters"),
+		timeout:    timeout,
+	}
+}
+
+// Next increments a counter with a single atomic upsert, so concurrent callers
+// never get the same value
+func (r *counterRepository) Next(name string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": name},
+		bson.M{"$inc": bson.M{"seq": int64(1)}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.Seq, nil
+}
+
+// Raise raises a counter to at least the value, creating it if needed
+func (r *counterRepository) Raise(name string, value int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": name},
+		bson.M{"$max": bson.M{"seq": value}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,107 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// dayPlanRetention is how long items of past day plans are kept
+const dayPlanRetention = 30 * 24 * time.Hour
+
+type dayPlanRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+	base       context.Context // parent of every operation's context; carries the session inside a unit of work
+}
+
+// dayPlanIndexes are the indexes of the day_plans collection
+var dayPlanIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: 1}, {Key: "task_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "added_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(dayPlanRetention / time.Second)),
+	},
+}
+
+// NewDayPlanRepository creates a new day plan repository. Plan items are deleted
+// by MongoDB 30 days after they were added.
+func NewDayPlanRepository(db *mongo.Database, timeout time.Duration) domain.DayPlanRepository {
+	collection := newCollection(db, "day_plans")
+
+	return &dayPlanRepository{
+		collection: collection,
+		timeout:    timeout,
+		base:       context.Background(),
+	}
+}
+
+// Add adds a task to a user's plan for a day, keeping when it was first added
+func (r *dayPlanRepository) Add(item *domain.DayPlanItem) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	if item.AddedAt.IsZero() {
+		item.AddedAt = time.Now()
+	}
+
+	filter := bson.M{"user_id": item.UserID, "date": item.Date, "task_id": item.TaskID}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"org_id":   item.OrgID,
+			"user_id":  item.UserID,
+			"task_id":  item.TaskID,
+			"date":     item.Date,
+			"added_at": item.AddedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// Remove removes a task from a user's plan for a day
+func (r *dayPlanRepository) Remove(userID primitive.ObjectID, date string, taskID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "date": date, "task_id": taskID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindByDay returns the items of a user's plan for a day, first added first
+func (r *dayPlanRepository) FindByDay(userID primitive.ObjectID, date string) ([]*domain.DayPlanItem, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "added_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID, "date": date}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	items := []*domain.DayPlanItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,143 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type deferredNotificationRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// deferredNotificationIndexes are the indexes of the deferred_notifications collection
+var deferredNotificationIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "deliver_at", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "deliver_at", Value: 1}},
+	},
+}
+
+// NewDeferredNotificationRepository creates a new deferred notification repository
+func NewDeferredNotificationRepository(db *mongo.Database, timeout time.Duration) domain.DeferredNotificationRepository {
+	collection := newCollection(db, "deferred_notifications")
+
+	return &deferredNotificationRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Create stores a new deferred notification
+func (r *deferredNotificationRepository) Create(deferred *domain.DeferredNotification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	if deferred.ID.IsZero() {
+		deferred.ID = primitive.NewObjectID()
+	}
+	deferred.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, deferred)
+	return err
+}
+
+// FindDueUsers finds up to limit users with due deferred notifications, those
+// waiting longest first
+func (r *deferredNotificationRepository) FindDueUsers(now time.Time, limit int64) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deliver_at": bson.M{"$lte": now}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$user_id", "due": bson.M{"$min": "$deliver_at"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "due", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		UserID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	users := make([]primitive.ObjectID, 0, len(groups))
+	for _, group := range groups {
+		users = append(users, group.UserID)
+	}
+	return users, nil
+}
+
+// FindDue finds a user's due deferred notifications, oldest first
+func (r *deferredNotificationRepository) FindDue(userID primitive.ObjectID, now time.Time) ([]*domain.DeferredNotification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID, "deliver_at": bson.M{"$lte": now}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deferred := []*domain.DeferredNotification{}
+	if err := cursor.All(ctx, &deferred); err != nil {
+		return nil, err
+	}
+
+	return deferred, nil
+}
+
+// Postpone moves a user's due deferred notifications to a later time
+func (r *deferredNotificationRepository) Postpone(userID primitive.ObjectID, now time.Time, until time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "deliver_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"deliver_at": until}},
+	)
+	return err
+}
+
+// DeleteMany deletes deferred notifications by ID and returns how many were deleted
+func (r *deferredNotificationRepository) DeleteMany(ids []primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// DeleteDueBefore deletes all deferred notifications due before the cutoff and returns how many were removed
+func (r *deferredNotificationRepository) DeleteDueBefore(cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"deliver_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,166 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"task-management-system/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInjectedFault is the error of operations failed on purpose by an injected fault
+var ErrInjectedFault = errors.New("injected fault")
+
+// faultSettings holds the runtime settings faults are read from once injection is enabled
+var faultSettings atomic.Pointer[config.RuntimeSettings]
+
+// InjectFaults makes repository operations suffer the faults of the runtime
+// settings as they change, to test how clients handle a slow or failing
+// database. It is meant for development only; until it is called, no fault is
+// injected whatever the settings.
+func InjectFaults(runtime *config.RuntimeSettings) {
+	faultSettings.Store(runtime)
+}
+
+// injectFault delays an operation and fails it as the fault configured for it
+// says, if any. The delay ends early when the operation times out.
+func injectFault(ctx context.Context, collection string, operation string) error {
+	runtime := faultSettings.Load()
+	if runtime == nil {
+		return nil
+	}
+
+	fault, ok := runtime.Get().FaultFor(collection, operation)
+	if !ok {
+		return nil
+	}
+
+	if fault.LatencyMS > 0 {
+		timer := time.NewTimer(time.Duration(fault.LatencyMS) * time.Millisecond)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	if fault.ErrorRate > 0 && rand.Float64() < fault.ErrorRate {
+		return fmt.Errorf("%w: %s on %s", ErrInjectedFault, operation, collection)
+	}
+	return nil
+}
+
+// faultCollection is a collection of a repository whose operations suffer the
+// injected faults. Other methods are those of the collection.
+type faultCollection struct {
+	*mongo.Collection
+}
+
+// newCollection returns a collection of the database for a repository
+func newCollection(db *mongo.Database, name string) faultCollection {
+	return faultCollection{Collection: db.Collection(name)}
+}
+
+// Find finds documents, after any fault injected into finds
+func (c faultCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	if err := injectFault(ctx, c.Name(), "find"); err != nil {
+		return nil, err
+	}
+	return c.Collection.Find(ctx, filter, opts...)
+}
+
+// FindOne finds a document, after any fault injected into finds
+func (c faultCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	if err := injectFault(ctx, c.Name(), "find"); err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return c.Collection.FindOne(ctx, filter, opts...)
+}
+
+// FindOneAndUpdate updates a document, after any fault injected into updates
+func (c faultCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	if err := injectFault(ctx, c.Name(), "update"); err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return c.Collection.FindOneAndUpdate(ctx, filter, update, opts...)
+}
+
+// InsertOne inserts a document, after any fault injected into inserts
+func (c faultCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	if err := injectFault(ctx, c.Name(), "insert"); err != nil {
+		return nil, err
+	}
+	return c.Collection.InsertOne(ctx, document, opts...)
+}
+
+// InsertMany inserts documents, after any fault injected into inserts
+func (c faultCollection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	if err := injectFault(ctx, c.Name(), "insert"); err != nil {
+		return nil, err
+	}
+	return c.Collection.InsertMany(ctx, documents, opts...)
+}
+
+// UpdateOne updates a document, after any fault injected into updates
+func (c faultCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if err := injectFault(ctx, c.Name(), "update"); err != nil {
+		return nil, err
+	}
+	return c.Collection.UpdateOne(ctx, filter, update, opts...)
+}
+
+// UpdateMany updates documents, after any fault injected into updates
+func (c faultCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if err := injectFault(ctx, c.Name(), "update"); err != nil {
+		return nil, err
+	}
+	return c.Collection.UpdateMany(ctx, filter, update, opts...)
+}
+
+// ReplaceOne replaces a document, after any fault injected into updates
+func (c faultCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	if err := injectFault(ctx, c.Name(), "update"); err != nil {
+		return nil, err
+	}
+	return c.Collection.ReplaceOne(ctx, filter, replacement, opts...)
+}
+
+// DeleteOne deletes a document, after any fault injected into deletes
+func (c faultCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	if err := injectFault(ctx, c.Name(), "delete"); err != nil {
+		return nil, err
+	}
+	return c.Collection.DeleteOne(ctx, filter, opts...)
+}
+
+// DeleteMany deletes documents, after any fault injected into deletes
+func (c faultCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	if err := injectFault(ctx, c.Name(), "delete"); err != nil {
+		return nil, err
+	}
+	return c.Collection.DeleteMany(ctx, filter, opts...)
+}
+
+// Aggregate runs a pipeline, after any fault injected into aggregations
+func (c faultCollection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	if err := injectFault(ctx, c.Name(), "aggregate"); err != nil {
+		return nil, err
+	}
+	return c.Collection.Aggregate(ctx, pipeline, opts...)
+}
+
+// CountDocuments counts documents, after any fault injected into counts
+func (c faultCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	if err := injectFault(ctx, c.Name(), "count"); err != nil {
+		return 0, err
+	}
+	return c.Collection.CountDocuments(ctx, filter, opts...)
+}
@@ -0,0 +1,102 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// importBatchSize is the number of records inserted per batch on import
+const importBatchSize = 500
+
+type importRepository struct {
+	db      *mongo.Database
+	timeout time.Duration
+}
+
+// NewImportRepository creates a new import repository
+func NewImportRepository(db *mongo.Database, timeout time.Duration) domain.ImportRepository {
+	return &importRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// InsertOrganization inserts an organization unless it exists
+func (r *importRepository) InsertOrganization(org *domain.Organization) (int64, error) {
+	return r.insert("organizations", []interface{}{org})
+}
+
+// InsertUsers inserts the users that do not exist
+func (r *importRepository) InsertUsers(users []*domain.User) (int64, error) {
+	return r.insert("users", documents(users))
+}
+
+// InsertProjects inserts the projects that do not exist
+func (r *importRepository) InsertProjects(projects []*domain.Project) (int64, error) {
+	return r.insert("projects", documents(projects))
+}
+
+// InsertTasks inserts the tasks that do not exist
+func (r *importRepository) InsertTasks(tasks []*domain.Task) (int64, error) {
+	return r.insert("tasks", documents(tasks))
+}
+
+// InsertAttachments inserts the attachments that do not exist
+func (r *importRepository) InsertAttachments(attachments []*domain.Attachment) (int64, error) {
+	return r.insert("attachments", documents(attachments))
+}
+
+// insert inserts documents into a collection in unordered batches, so documents
+// rejected as duplicates are skipped without stopping the others
+func (r *importRepository) insert(collection string, docs []interface{}) (int64, error) {
+	var inserted int64
+	for start := 0; start < len(docs); start += importBatchSize {
+		batch := docs[start:min(start+importBatchSize, len(docs))]
+
+		ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+		_, err := r.db.Collection(collection).InsertMany(ctx, batch, options.InsertMany().SetOrdered(false))
+		cancel()
+
+		skipped, err := duplicates(err)
+		if err != nil {
+			return inserted, err
+		}
+		inserted += int64(len(batch) - skipped)
+	}
+
+	return inserted, nil
+}
+
+// duplicates returns how many writes of a bulk insert were rejected for a
+// duplicate key, or the error when others failed
+func duplicates(err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return 0, err
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != 11000 {
+			return 0, err
+		}
+	}
+	return len(bulkErr.WriteErrors), nil
+}
+
+// documents converts records into the documents InsertMany takes
+func documents[T any](records []*T) []interface{} {
+	docs := make([]interface{}, len(records))
+	for i, record := range records {
+		docs[i] = record
+	}
+	return docs
+}
@@ -0,0 +1,138 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type inboundHookRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// inboundHookIndexes are the indexes of the inbound_hooks collection
+var inboundHookIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	{
+		Keys: bson.D{{Key: "project_id", Value: 1}, {Key: "_id", Value: 1}},
+	},
+}
+
+// NewInboundHookRepository creates a new inbound hook repository
+func NewInboundHookRepository(db *mongo.Database, timeout time.Duration) domain.InboundHookRepository {
+	collection := newCollection(db, "inbound_hooks")
+
+	return &inboundHookRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Create creates a new inbound hook
+func (r *inboundHookRepository) Create(hook *domain.InboundHook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	hook.CreatedAt = time.Now()
+
+	// If ID is not set, set it to a new ObjectID
+	if hook.ID.IsZero() {
+		hook.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, hook)
+	return err
+}
+
+// FindByID finds an inbound hook by its ID
+func (r *inboundHookRepository) FindByID(id primitive.ObjectID) (*domain.InboundHook, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+// FindByTokenHash finds an inbound hook by the hash of its token
+func (r *inboundHookRepository) FindByTokenHash(tokenHash string) (*domain.InboundHook, error) {
+	return r.findOne(bson.M{"token_hash": tokenHash})
+}
+
+// findOne finds the inbound hook matching a filter
+func (r *inboundHookRepository) findOne(filter bson.M) (*domain.InboundHook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var hook domain.InboundHook
+	err := r.collection.FindOne(ctx, filter).Decode(&hook)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &hook, nil
+}
+
+// FindByProject finds all inbound hooks of a project, oldest first
+func (r *inboundHookRepository) FindByProject(projectID primitive.ObjectID) ([]*domain.InboundHook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"project_id": projectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hooks := []*domain.InboundHook{}
+	if err := cursor.All(ctx, &hooks); err != nil {
+		return nil, err
+	}
+
+	return hooks, nil
+}
+
+// Touch records that an inbound hook was used at the given time
+func (r *inboundHookRepository) Touch(id primitive.ObjectID, at time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": at}})
+	return err
+}
+
+// Delete deletes an inbound hook
+func (r *inboundHookRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByProject deletes all inbound hooks of a project
+func (r *inboundHookRepository) DeleteByProject(projectID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
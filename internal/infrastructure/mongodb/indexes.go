@@ -0,0 +1,166 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"task-management-system/config"
+	"task-management-system/internal/logger"
+)
+
+// collectionIndexes are the indexes declared for a collection
+type collectionIndexes struct {
+	Collection string
+	Indexes    []mongo.IndexModel
+}
+
+// declaredIndexes lists the indexes of every collection the repositories query.
+// The text index of the tasks collection is declared only for the text search
+// engine; Atlas Search indexes are managed outside of the database.
+func declaredIndexes(searchEngine string) []collectionIndexes {
+	tasks := taskIndexes
+	if searchEngine == "" || searchEngine == SearchEngineText {
+		tasks = append(append([]mongo.IndexModel{}, taskIndexes...), taskTextIndexes...)
+	}
+
+	return []collectionIndexes{
+		{Collection: "attachments", Indexes: attachmentIndexes},
+		{Collection: "audit_log", Indexes: auditIndexes},
+		{Collection: "calendar_feeds", Indexes: calendarFeedIndexes},
+		{Collection: "day_plans", Indexes: dayPlanIndexes},
+		{Collection: "deferred_notifications", Indexes: deferredNotificationIndexes},
+		{Collection: "inbound_hooks", Indexes: inboundHookIndexes},
+		{Collection: "invitations", Indexes: invitationIndexes},
+		{Collection: "login_attempts", Indexes: loginAttemptIndexes},
+		{Collection: "milestones", Indexes: milestoneIndexes},
+		{Collection: "notification_preferences", Indexes: notificationPreferencesIndexes},
+		{Collection: "notifications", Indexes: notificationIndexes},
+		{Collection: "outbox", Indexes: outboxIndexes},
+		{Collection: "projects", Indexes: projectIndexes},
+		{Collection: "sessions", Indexes: sessionIndexes},
+		{Collection: "sprints", Indexes: sprintIndexes},
+		{Collection: "tasks", Indexes: tasks},
+		{Collection: "task_reminders", Indexes: taskReminderIndexes},
+		{Collection: "task_snoozes", Indexes: taskSnoozeIndexes},
+		{Collection: "task_stars", Indexes: taskStarIndexes},
+		{Collection: "task_views", Indexes: taskViewIndexes},
+		{Collection: "users", Indexes: userIndexes},
+	}
+}
+
+// EnsureIndexes creates the declared indexes that are missing and reports
+// existing indexes that are no longer declared, dropping them when configured
+// to. Every outcome is logged. Failures are logged and skipped unless strict
+// mode is on, in which case the first one is returned.
+func EnsureIndexes(db *mongo.Database, cfg config.MongoDBConfig, searchEngine string, log logger.Logger) error {
+	created, dropped := 0, 0
+
+	for _, declared := range declaredIndexes(searchEngine) {
+		c, d, err := ensureCollectionIndexes(db.Collection(declared.Collection), declared.Indexes, cfg, log)
+		created, dropped = created+c, dropped+d
+		if err != nil {
+			if cfg.Indexes.Strict {
+				return err
+			}
+			log.ErrorF("%v", err)
+		}
+	}
+
+	log.InfoF("Indexes ensured: %d created, %d dropped", created, dropped)
+	return nil
+}
+
+// ensureCollectionIndexes ensures the indexes of a single collection and returns
+// how many were created and dropped
+func ensureCollectionIndexes(collection *mongo.Collection, declared []mongo.IndexModel, cfg config.MongoDBConfig, log logger.Logger) (int, int, error) {
+	existing, err := existingIndexNames(collection, cfg.Timeout)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list indexes of %s: %w", collection.Name(), err)
+	}
+
+	created, dropped := 0, 0
+	wanted := make(map[string]bool, len(declared))
+
+	for _, model := range declared {
+		name, err := indexName(model)
+		if err != nil {
+			return created, dropped, fmt.Errorf("invalid index declared on %s: %w", collection.Name(), err)
+		}
+		wanted[name] = true
+		if existing[name] {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		_, err = collection.Indexes().CreateOne(ctx, model)
+		cancel()
+		if err != nil {
+			return created, dropped, fmt.Errorf("failed to create index %s on %s: %w", name, collection.Name(), err)
+		}
+		log.InfoF("Created index %s on %s", name, collection.Name())
+		created++
+	}
+
+	for name := range existing {
+		if name == "_id_" || wanted[name] {
+			continue
+		}
+		if !cfg.Indexes.DropObsolete {
+			log.WarnF("Index %s on %s is no longer declared; enable database.mongodb.indexes.drop_obsolete to drop it", name, collection.Name())
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		_, err := collection.Indexes().DropOne(ctx, name)
+		cancel()
+		if err != nil {
+			return created, dropped, fmt.Errorf("failed to drop obsolete index %s on %s: %w", name, collection.Name(), err)
+		}
+		log.InfoF("Dropped obsolete index %s on %s", name, collection.Name())
+		dropped++
+	}
+
+	return created, dropped, nil
+}
+
+// existingIndexNames returns the names of the indexes of a collection; a
+// collection that does not exist yet has none
+func existingIndexNames(collection *mongo.Collection, timeout time.Duration) (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	specs, err := collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		existing[spec.Name] = true
+	}
+	return existing, nil
+}
+
+// indexName returns the name of an index: the one set in its options, or the
+// one MongoDB generates from its keys, such as "org_id_1_created_at_-1"
+func indexName(model mongo.IndexModel) (string, error) {
+	if model.Options != nil && model.Options.Name != nil {
+		return *model.Options.Name, nil
+	}
+
+	keys, ok := model.Keys.(bson.D)
+	if !ok {
+		return "", fmt.Errorf("index keys must be a bson.D to derive a name, got %T", model.Keys)
+	}
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
+	}
+	return strings.Join(parts, "_"), nil
+}
@@ -0,0 +1,171 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type invitationRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// invitationIndexes are the indexes of the invitations collection
+var invitationIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "email", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+}
+
+// NewInvitationRepository creates a new invitation repository
+func NewInvitationRepository(db *mongo.Database, timeout time.Duration) domain.InvitationRepository {
+	collection := newCollection(db, "invitations")
+
+	return &invitationRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// pendingFilter matches invitations that can still be accepted at the given time
+func pendingFilter(orgID primitive.ObjectID, now time.Time) bson.M {
+	return bson.M{
+		"org_id":      orgID,
+		"accepted_at": bson.M{"$exists": false},
+		"revoked_at":  bson.M{"$exists": false},
+		"expires_at":  bson.M{"$gt": now},
+	}
+}
+
+// Create creates a new invitation
+func (r *invitationRepository) Create(invitation *domain.Invitation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created time
+	invitation.CreatedAt = time.Now()
+
+	// If ID is not set, set it to a new ObjectID
+	if invitation.ID.IsZero() {
+		invitation.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, invitation)
+	return err
+}
+
+// FindByID finds an invitation by its ID
+func (r *invitationRepository) FindByID(id primitive.ObjectID) (*domain.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var invitation domain.Invitation
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&invitation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// FindPending finds an organization's outstanding invitations, newest first
+func (r *invitationRepository) FindPending(orgID primitive.ObjectID, now time.Time) ([]*domain.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, pendingFilter(orgID, now), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	invitations := []*domain.Invitation{}
+	if err := cursor.All(ctx, &invitations); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// FindPendingByEmail finds an organization's outstanding invitation for an email address
+func (r *invitationRepository) FindPendingByEmail(orgID primitive.ObjectID, email string, now time.Time) (*domain.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := pendingFilter(orgID, now)
+	filter["email"] = email
+
+	var invitation domain.Invitation
+	err := r.collection.FindOne(ctx, filter).Decode(&invitation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// Update records an invitation's acceptance or revocation
+func (r *invitationRepository) Update(invitation *domain.Invitation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	set := bson.M{}
+	if invitation.AcceptedAt != nil {
+		set["accepted_at"] = invitation.AcceptedAt
+		set["accepted_by"] = invitation.AcceptedBy
+	}
+	if invitation.RevokedAt != nil {
+		set["revoked_at"] = invitation.RevokedAt
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": invitation.ID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteFinishedBefore deletes all invitations accepted, revoked or expired before
+// the cutoff and returns how many were removed. Invitations are accepted before they
+// expire, so one that expired before the cutoff is finished whatever else happened.
+func (r *invitationRepository) DeleteFinishedBefore(cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"accepted_at": bson.M{"$lt": cutoff}},
+		{"revoked_at": bson.M{"$lt": cutoff}},
+		{"expires_at": bson.M{"$lt": cutoff}},
+	}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,113 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loginAttemptRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// loginAttemptIndexes are the indexes of the login_attempts collection
+var loginAttemptIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+}
+
+// NewLoginAttemptRepository creates a new login history repository
+func NewLoginAttemptRepository(db *mongo.Database, timeout time.Duration) domain.LoginAttemptRepository {
+	collection := newCollection(db, "login_attempts")
+
+	return &loginAttemptRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Create records a login attempt
+func (r *loginAttemptRepository) Create(attempt *domain.LoginAttempt) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created time
+	attempt.CreatedAt = time.Now()
+
+	// If ID is not set, set it to a new ObjectID
+	if attempt.ID.IsZero() {
+		attempt.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, attempt)
+	return err
+}
+
+// Find finds the login attempts matching the filter, newest first
+func (r *loginAttemptRepository) Find(filter domain.LoginAttemptFilter) ([]*domain.LoginAttempt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	query := bson.M{}
+	if !filter.OrgID.IsZero() {
+		query["org_id"] = filter.OrgID
+	}
+	if !filter.UserID.IsZero() {
+		query["user_id"] = filter.UserID
+	}
+	if filter.Success != nil {
+		query["success"] = *filter.Success
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		createdAt := bson.M{}
+		if !filter.From.IsZero() {
+			createdAt["$gte"] = filter.From
+		}
+		if !filter.To.IsZero() {
+			createdAt["$lt"] = filter.To
+		}
+		query["created_at"] = createdAt
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attempts := []*domain.LoginAttempt{}
+	if err := cursor.All(ctx, &attempts); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// DeleteCreatedBefore deletes all login attempts made before the cutoff and returns how many were removed
+func (r *loginAttemptRepository) DeleteCreatedBefore(cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"created_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
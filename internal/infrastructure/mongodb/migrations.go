@@ -0,0 +1,321 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+)
+
+// migration represents a single, idempotent schema or data change
+type migration struct {
+	ID  string
+	Run func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrations lists all migrations in the order they must be applied.
+// Never reorder or remove entries; append new ones at the end.
+var migrations = []migration{
+	{ID: "0001_task_assignees_array", Run: migrateTaskAssigneesToArray},
+	{ID: "0002_default_organization", Run: migrateToDefaultOrganization},
+	{ID: "0003_project_task_keys", Run: migrateProjectTaskKeys},
+	{ID: "0004_collection_validators", Run: migrateCollectionValidators},
+}
+
+// RunMigrations applies all pending migrations and records them in the migrations
+// collection, logging each migration it applies
+func RunMigrations(db *mongo.Database, timeout time.Duration, log logger.Logger) error {
+	applied := db.Collection("migrations")
+
+	for _, m := range migrations {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+		// Skip migrations that have already been applied
+		count, err := applied.CountDocuments(ctx, bson.M{"_id": m.ID})
+		if err != nil {
+			cancel()
+			return fmt.Errorf("failed to check migration %s: %w", m.ID, err)
+		}
+		if count > 0 {
+			cancel()
+			continue
+		}
+
+		log.InfoF("Applying migration %s", m.ID)
+		if err := m.Run(ctx, db); err != nil {
+			cancel()
+			return fmt.Errorf("migration %s failed: %w", m.ID, err)
+		}
+
+		_, err = applied.InsertOne(ctx, bson.M{"_id": m.ID, "applied_at": time.Now()})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", m.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateTaskAssigneesToArray converts the legacy single assigned_to ObjectID into an array
+func migrateTaskAssigneesToArray(ctx context.Context, db *mongo.Database) error {
+	tasks := db.Collection("tasks")
+
+	// Unassigned tasks were stored with a zero ObjectID
+	_, err := tasks.UpdateMany(ctx,
+		bson.M{"assigned_to": primitive.NilObjectID},
+		bson.M{"$set": bson.M{"assigned_to": bson.A{}}},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Wrap remaining scalar values in a single-element array
+	_, err = tasks.UpdateMany(ctx,
+		bson.M{"assigned_to": bson.M{"$type": "objectId"}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"assigned_to": bson.A{"$assigned_to"}}}}},
+	)
+	return err
+}
+
+// migrateToDefaultOrganization moves all users and tasks that predate multi-tenancy
+// into a single default organization. The earliest registered user becomes its admin.
+func migrateToDefaultOrganization(ctx context.Context, db *mongo.Database) error {
+	users := db.Collection("users")
+	tasks := db.Collection("tasks")
+
+	withoutOrg := bson.M{"org_id": bson.M{"$exists": false}}
+	count, err := users.CountDocuments(ctx, withoutOrg)
+	if err != nil {
+		return err
+	}
+	taskCount, err := tasks.CountDocuments(ctx, withoutOrg)
+	if err != nil {
+		return err
+	}
+	if count == 0 && taskCount == 0 {
+		return nil
+	}
+
+	// The first user to register owns the default organization
+	var owner struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err = users.FindOne(ctx, withoutOrg, options.FindOne().SetSort(bson.D{{Key: "created_at", Value: 1}})).Decode(&owner)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	now := time.Now()
+	orgID := primitive.NewObjectID()
+	_, err = db.Collection("organizations").InsertOne(ctx, bson.M{
+		"_id":        orgID,
+		"name":       "Default organization",
+		"created_by": owner.ID,
+		"created_at": now,
+		"updated_at": now,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = users.UpdateMany(ctx, withoutOrg, bson.M{"$set": bson.M{"org_id": orgID, "org_role": "member"}})
+	if err != nil {
+		return err
+	}
+	if !owner.ID.IsZero() {
+		_, err = users.UpdateOne(ctx, bson.M{"_id": owner.ID}, bson.M{"$set": bson.M{"org_role": "admin"}})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tasks.UpdateMany(ctx, withoutOrg, bson.M{"$set": bson.M{"org_id": orgID}})
+	return err
+}
+
+// migrateProjectTaskKeys gives existing projects a key derived from their name and
+// numbers their tasks in creation order, continuing the counters from there
+func migrateProjectTaskKeys(ctx context.Context, db *mongo.Database) error {
+	projects := db.Collection("projects")
+	tasks := db.Collection("tasks")
+	counters := db.Collection("counters")
+
+	cursor, err := projects.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	var all []domain.Project
+	if err := cursor.All(ctx, &all); err != nil {
+		return err
+	}
+
+	// Keys already taken, per organization
+	taken := make(map[primitive.ObjectID]map[string]bool)
+	for _, project := range all {
+		if taken[project.OrgID] == nil {
+			taken[project.OrgID] = make(map[string]bool)
+		}
+		if project.Key != "" {
+			taken[project.OrgID][project.Key] = true
+		}
+	}
+
+	for _, project := range all {
+		if project.Key == "" {
+			project.Key = uniqueProjectKey(domain.DeriveProjectKey(project.Name), taken[project.OrgID])
+			taken[project.OrgID][project.Key] = true
+
+			_, err := projects.UpdateOne(ctx, bson.M{"_id": project.ID}, bson.M{"$set": bson.M{"key": project.Key}})
+			if err != nil {
+				return err
+			}
+		}
+
+		cursor, err := tasks.Find(ctx,
+			bson.M{"project_id": project.ID, "key": bson.M{"$exists": false}},
+			options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetProjection(bson.M{"_id": 1}),
+		)
+		if err != nil {
+			return err
+		}
+		var unnumbered []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.All(ctx, &unnumbered); err != nil {
+			return err
+		}
+		if len(unnumbered) == 0 {
+			continue
+		}
+
+		// Reserve the numbers, then hand them out in creation order
+		var counter struct {
+			Seq int64 `bson:"seq"`
+		}
+		err = counters.FindOneAndUpdate(ctx,
+			bson.M{"_id": domain.ProjectTaskCounter(project.ID)},
+			bson.M{"$inc": bson.M{"seq": int64(len(unnumbered))}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&counter)
+		if err != nil {
+			return err
+		}
+
+		first := counter.Seq - int64(len(unnumbered)) + 1
+		models := make([]mongo.WriteModel, 0, len(unnumbered))
+		for i, task := range unnumbered {
+			number := first + int64(i)
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": task.ID}).
+				SetUpdate(bson.M{"$set": bson.M{"number": number, "key": domain.TaskKey(project.Key, number)}}))
+		}
+		if _, err := tasks.BulkWrite(ctx, models); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uniqueProjectKey returns the first variant of the key that is not taken
+func uniqueProjectKey(key string, taken map[string]bool) string {
+	candidate := key
+	for n := 2; taken[candidate]; n++ {
+		candidate = domain.ProjectKeyVariant(key, n)
+	}
+	return candidate
+}
+
+// collectionValidators are the $jsonSchema validators of the collections whose
+// shape the rest of the code relies on most. They check types and enums only
+// where every writer agrees; the usecases validate the rest.
+var collectionValidators = map[string]bson.M{
+	"tasks": {
+		"bsonType": "object",
+		"required": bson.A{"org_id", "title", "status", "priority", "created_by"},
+		"properties": bson.M{
+			"org_id":      bson.M{"bsonType": "objectId"},
+			"project_id":  bson.M{"bsonType": "objectId"},
+			"title":       bson.M{"bsonType": "string", "minLength": 1},
+			"description": bson.M{"bsonType": "string"},
+			"status": bson.M{"enum": bson.A{
+				string(domain.TaskStatusPending),
+				string(domain.TaskStatusInProgress),
+				string(domain.TaskStatusCompleted),
+			}},
+			"priority":    bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 1, "maximum": 5},
+			"due_date":    bson.M{"bsonType": "date"},
+			"assigned_to": bson.M{"bsonType": "array", "items": bson.M{"bsonType": "objectId"}},
+			"tags":        bson.M{"bsonType": "array", "items": bson.M{"bsonType": "string"}},
+			"created_by":  bson.M{"bsonType": "objectId"},
+			"created_at":  bson.M{"bsonType": "date"},
+			"updated_at":  bson.M{"bsonType": "date"},
+		},
+	},
+	"users": {
+		"bsonType": "object",
+		"required": bson.A{"username", "email", "password", "org_id", "org_role"},
+		"properties": bson.M{
+			"username": bson.M{"bsonType": "string", "minLength": 1},
+			"email":    bson.M{"bsonType": "string", "minLength": 1},
+			"password": bson.M{"bsonType": "string"},
+			"org_id":   bson.M{"bsonType": "objectId"},
+			"org_role": bson.M{"enum": bson.A{
+				string(domain.OrgRoleAdmin),
+				string(domain.OrgRoleMember),
+			}},
+			"created_at": bson.M{"bsonType": "date"},
+			"updated_at": bson.M{"bsonType": "date"},
+		},
+	},
+}
+
+// migrateCollectionValidators installs the collection validators, creating the
+// collections that do not exist yet. Validation is moderate: documents that
+// are already invalid can still be updated, so that they can be repaired.
+func migrateCollectionValidators(ctx context.Context, db *mongo.Database) error {
+	existing, err := db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	for name, schema := range collectionValidators {
+		validator := bson.M{"$jsonSchema": schema}
+
+		if !exists[name] {
+			opts := options.CreateCollection().
+				SetValidator(validator).
+				SetValidationLevel("moderate").
+				SetValidationAction("error")
+			if err := db.CreateCollection(ctx, name, opts); err != nil {
+				return fmt.Errorf("failed to create %s: %w", name, err)
+			}
+			continue
+		}
+
+		err := db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: name},
+			{Key: "validator", Value: validator},
+			{Key: "validationLevel", Value: "moderate"},
+			{Key: "validationAction", Value: "error"},
+		}).Err()
+		if err != nil {
+			return fmt.Errorf("failed to install the validator of %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type milestoneRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// milestoneIndexes are the indexes of the milestones collection
+var milestoneIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "project_id", Value: 1}, {Key: "target_date", Value: 1}},
+	},
+}
+
+// NewMilestoneRepository creates a new milestone repository
+func NewMilestoneRepository(db *mongo.Database, timeout time.Duration) domain.MilestoneRepository {
+	collection := newCollection(db, "milestones")
+
+	return &milestoneRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// FindByID finds a milestone by its ID
+func (r *milestoneRepository) FindByID(id primitive.ObjectID) (*domain.Milestone, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var milestone domain.Milestone
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&milestone)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &milestone, nil
+}
+
+// FindByProject finds all milestones of a project, sorted by target date
+func (r *milestoneRepository) FindByProject(projectID primitive.ObjectID) ([]*domain.Milestone, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "target_date", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"project_id": projectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	milestones := []*domain.Milestone{}
+	if err := cursor.All(ctx, &milestones); err != nil {
+		return nil, err
+	}
+
+	return milestones, nil
+}
+
+// Create creates a new milestone
+func (r *milestoneRepository) Create(milestone *domain.Milestone) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created and updated times
+	now := time.Now()
+	milestone.CreatedAt = now
+	milestone.UpdatedAt = now
+
+	// If ID is not set, set it to a new ObjectID
+	if milestone.ID.IsZero() {
+		milestone.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, milestone)
+	return err
+}
+
+// Update updates an existing milestone
+func (r *milestoneRepository) Update(milestone *domain.Milestone) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Update the updated time
+	milestone.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": milestone.ID},
+		bson.M{"$set": bson.M{
+			"name":        milestone.Name,
+			"description": milestone.Description,
+			"target_date": milestone.TargetDate,
+			"updated_at":  milestone.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByProject deletes all milestones of a project
+func (r *milestoneRepository) DeleteByProject(projectID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
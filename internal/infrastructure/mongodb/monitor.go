@@ -0,0 +1,212 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+
+	"task-management-system/internal/logger"
+)
+
+// commandLatencyBuckets are the upper bounds, in milliseconds, of the command latency histogram buckets
+var commandLatencyBuckets = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// Command metrics are shared: expvar variables can only be published once per process
+var (
+	commandCounts    = expvar.NewMap("mongodb_commands_total")
+	commandLatencies = expvar.NewMap("mongodb_command_duration_ms")
+)
+
+// CommandMonitor records the latency of every command the client sends, by
+// collection and command, and logs commands slower than its threshold. The
+// histograms are published as expvar variables, served by the admin metrics route.
+type CommandMonitor struct {
+	slowThreshold time.Duration
+	log           logger.Logger
+	started       sync.Map // of commandKey to *startedCommand
+}
+
+// commandKey identifies a command in flight
+type commandKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// startedCommand is what is remembered of a command until it finishes
+type startedCommand struct {
+	collection string
+	// query is the filter or pipeline of reads, logged should they be slow
+	query bson.Raw
+}
+
+// NewCommandMonitor creates a command monitor logging commands slower than the
+// threshold to the given logger; zero disables the log
+func NewCommandMonitor(slowThreshold time.Duration, log logger.Logger) *CommandMonitor {
+	return &CommandMonitor{
+		slowThreshold: slowThreshold,
+		log:           log,
+	}
+}
+
+// Monitor returns the driver's command monitor, to be set on the client options
+func (m *CommandMonitor) Monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started:   m.commandStarted,
+		Succeeded: m.commandSucceeded,
+		Failed:    m.commandFailed,
+	}
+}
+
+// commandStarted remembers the collection of a command. Commands without one,
+// such as handshakes and pings, are not recorded.
+func (m *CommandMonitor) commandStarted(_ context.Context, e *event.CommandStartedEvent) {
+	collection := commandCollection(e.CommandName, e.Command)
+	if collection == "" {
+		return
+	}
+
+	command := &startedCommand{collection: collection}
+	for _, field := range []string{"filter", "pipeline", "query"} {
+		if value, err := e.Command.LookupErr(field); err == nil {
+			command.query = value.Value
+			break
+		}
+	}
+	m.started.Store(commandKey{e.ConnectionID, e.RequestID}, command)
+}
+
+// commandSucceeded records a finished command
+func (m *CommandMonitor) commandSucceeded(_ context.Context, e *event.CommandSucceededEvent) {
+	m.finished(e.CommandFinishedEvent, "ok")
+}
+
+// commandFailed records a failed command
+func (m *CommandMonitor) commandFailed(_ context.Context, e *event.CommandFailedEvent) {
+	m.finished(e.CommandFinishedEvent, "failed")
+}
+
+// finished records the outcome and latency of a command, logging it if it was slow
+func (m *CommandMonitor) finished(e event.CommandFinishedEvent, outcome string) {
+	value, ok := m.started.LoadAndDelete(commandKey{e.ConnectionID, e.RequestID})
+	if !ok {
+		return
+	}
+	command := value.(*startedCommand)
+
+	// Keys look like "tasks find"
+	name := command.collection + " " + e.CommandName
+	commandCounts.Add(name+" "+outcome, 1)
+	commandHistogram(name).observe(float64(e.Duration) / float64(time.Millisecond))
+
+	if m.slowThreshold <= 0 || e.Duration < m.slowThreshold {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"collection":  command.collection,
+		"operation":   e.CommandName,
+		"duration_ms": e.Duration.Milliseconds(),
+	}
+	if command.query != nil {
+		fields["filter"] = command.query.String()
+	}
+	m.log.Warn("Slow MongoDB query", fields)
+}
+
+// commandCollection returns the collection a command operates on, or "" for
+// commands not on a collection
+func commandCollection(commandName string, command bson.Raw) string {
+	field := commandName
+	if commandName == "getMore" {
+		field = "collection"
+	}
+
+	value, err := command.LookupErr(field)
+	if err != nil {
+		return ""
+	}
+	collection, ok := value.StringValueOK()
+	if !ok {
+		return ""
+	}
+	return collection
+}
+
+// commandHistogramsMu guards the creation of histograms
+var commandHistogramsMu sync.Mutex
+
+// commandHistogram returns the latency histogram of a command, creating it on first use
+func commandHistogram(name string) *histogram {
+	if h, ok := commandLatencies.Get(name).(*histogram); ok {
+		return h
+	}
+
+	commandHistogramsMu.Lock()
+	defer commandHistogramsMu.Unlock()
+
+	if h, ok := commandLatencies.Get(name).(*histogram); ok {
+		return h
+	}
+	h := newHistogram(commandLatencyBuckets)
+	commandLatencies.Set(name, h)
+	return h
+}
+
+// histogram counts observations in cumulative buckets, like Prometheus
+// histograms, and is published as an expvar variable
+type histogram struct {
+	bounds []float64
+	counts []atomic.Int64 // per bucket, the last one counting observations above every bound
+	sum    expvar.Float
+}
+
+// newHistogram creates a histogram with the given ascending bucket bounds
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{
+		bounds: bounds,
+		counts: make([]atomic.Int64, len(bounds)+1),
+	}
+}
+
+// observe counts a value
+func (h *histogram) observe(value float64) {
+	i := 0
+	for i < len(h.bounds) && value > h.bounds[i] {
+		i++
+	}
+	h.counts[i].Add(1)
+	h.sum.Add(value)
+}
+
+// String returns the histogram as JSON: the cumulative count of each bucket by
+// its upper bound, the total count and the sum of the observed values
+func (h *histogram) String() string {
+	buckets := make(map[string]int64, len(h.counts))
+	var cumulative int64
+	for i := range h.counts {
+		cumulative += h.counts[i].Load()
+		bound := "+Inf"
+		if i < len(h.bounds) {
+			bound = strconv.FormatFloat(h.bounds[i], 'f', -1, 64)
+		}
+		buckets[bound] = cumulative
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"buckets": buckets,
+		"count":   cumulative,
+		"sum":     h.sum.Value(),
+	})
+	if err != nil {
+		return fmt.Sprintf("%q", err.Error())
+	}
+	return string(data)
+}
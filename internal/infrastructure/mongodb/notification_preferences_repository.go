@@ -0,0 +1,112 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type notificationPreferencesRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// notificationPreferencesIndexes are the indexes of the notification_preferences
+// collection. Documents are keyed by user ID; the digest job scans opted-in users.
+var notificationPreferencesIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "daily_digest", Value: 1}},
+	},
+}
+
+// NewNotificationPreferencesRepository creates a new notification preferences repository
+func NewNotificationPreferencesRepository(db *mongo.Database, timeout time.Duration) domain.NotificationPreferencesRepository {
+	collection := newCollection(db, "notification_preferences")
+
+	return &notificationPreferencesRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// FindByUser finds the preferences of a user
+func (r *notificationPreferencesRepository) FindByUser(userID primitive.ObjectID) (*domain.NotificationPreferences, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var prefs domain.NotificationPreferences
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&prefs)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+// Save creates or replaces the preferences of a user
+func (r *notificationPreferencesRepository) Save(prefs *domain.NotificationPreferences) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Update the updated time
+	prefs.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": prefs.UserID},
+		prefs,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// FindDigestSubscribers finds the preferences of all users who opted into the daily digest
+func (r *notificationPreferencesRepository) FindDigestSubscribers() ([]*domain.NotificationPreferences, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"daily_digest": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var prefs []*domain.NotificationPreferences
+	if err := cursor.All(ctx, &prefs); err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+// MarkDigestSent records when the last daily digest was sent to a user.
+// Only that field is touched so concurrent preference edits are not overwritten.
+func (r *notificationPreferencesRepository) MarkDigestSent(userID primitive.ObjectID, sentAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"last_digest_at": sentAt}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
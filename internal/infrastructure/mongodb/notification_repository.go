@@ -0,0 +1,169 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type notificationRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// notificationIndexes are the indexes of the notifications collection
+var notificationIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "read", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+	{
+		Keys: bson.D{{Key: "created_at", Value: 1}},
+	},
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *mongo.Database, timeout time.Duration) domain.NotificationRepository {
+	collection := newCollection(db, "notifications")
+
+	return &notificationRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Create creates a new notification
+func (r *notificationRepository) Create(notification *domain.Notification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created time
+	notification.CreatedAt = time.Now()
+
+	// If ID is not set, set it to a new ObjectID
+	if notification.ID.IsZero() {
+		notification.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, notification)
+	return err
+}
+
+// FindByUser finds a user's notifications, newest first
+func (r *notificationRepository) FindByUser(userID primitive.ObjectID, unreadOnly bool, limit int64) ([]*domain.Notification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID}
+	if unreadOnly {
+		filter["read"] = false
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []*domain.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// FindByTypeSince finds a user's notifications of one type created after the given time, oldest first
+func (r *notificationRepository) FindByTypeSince(userID primitive.ObjectID, eventType domain.EventType, since time.Time) ([]*domain.Notification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id":    userID,
+		"type":       eventType,
+		"created_at": bson.M{"$gt": since},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []*domain.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// MarkRead marks a single notification as read
+func (r *notificationRepository) MarkRead(userID primitive.ObjectID, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "user_id": userID},
+		bson.M{"$set": bson.M{"read": true, "read_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// MarkAllRead marks all of a user's unread notifications as read and returns how many were updated
+func (r *notificationRepository) MarkAllRead(userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"user_id": userID, "read": false},
+		bson.M{"$set": bson.M{"read": true, "read_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// CountUnread counts a user's unread notifications
+func (r *notificationRepository) CountUnread(userID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID, "read": false})
+}
+
+// DeleteCreatedBefore deletes all notifications created before the cutoff and returns how many were removed
+func (r *notificationRepository) DeleteCreatedBefore(cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"created_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,89 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type organizationRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *mongo.Database, timeout time.Duration) domain.OrganizationRepository {
+	return &organizationRepository{
+		collection: newCollection(db, "organizations"),
+		timeout:    timeout,
+	}
+}
+
+// FindByID finds an organization by its ID
+func (r *organizationRepository) FindByID(id primitive.ObjectID) (*domain.Organization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var org domain.Organization
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&org)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+// Create creates a new organization
+func (r *organizationRepository) Create(org *domain.Organization) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created and updated times
+	now := time.Now()
+	org.CreatedAt = now
+	org.UpdatedAt = now
+
+	// If ID is not set, set it to a new ObjectID
+	if org.ID.IsZero() {
+		org.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, org)
+	return err
+}
+
+// Update updates an existing organization
+func (r *organizationRepository) Update(org *domain.Organization) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Update the updated time
+	org.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": org.ID},
+		bson.M{"$set": bson.M{
+			"name":       org.Name,
+			"updated_at": org.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+	base       context.Context // parent of every operation's context; carries the session inside a unit of work
+}
+
+// outboxIndexes are the indexes of the outbox collection
+var outboxIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "delivered_at", Value: 1}, {Key: "created_at", Value: 1}},
+	},
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *mongo.Database, timeout time.Duration) domain.OutboxRepository {
+	collection := newCollection(db, "outbox")
+
+	return &outboxRepository{
+		collection: collection,
+		timeout:    timeout,
+		base:       context.Background(),
+	}
+}
+
+// Add stores an event for delivery
+func (r *outboxRepository) Add(event *domain.Event) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	message := &domain.OutboxMessage{
+		ID:        primitive.NewObjectID(),
+		Event:     *event,
+		CreatedAt: time.Now(),
+	}
+
+	_, err := r.collection.InsertOne(ctx, message)
+	return err
+}
+
+// FindPending finds undelivered messages, oldest first
+func (r *outboxRepository) FindPending(limit int64) ([]*domain.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(limit)
+
+	// A null match also covers documents without the field
+	cursor, err := r.collection.Find(ctx, bson.M{"delivered_at": nil}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []*domain.OutboxMessage{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// MarkDelivered records that a message has been delivered
+func (r *outboxRepository) MarkDelivered(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"delivered_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteDeliveredBefore deletes messages delivered before the cutoff and returns how many were removed.
+// Undelivered messages are never deleted.
+func (r *outboxRepository) DeleteDeliveredBefore(cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"delivered_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,33 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// pinger implements domain.Pinger for MongoDB
+type pinger struct {
+	client  *mongo.Client
+	timeout time.Duration
+}
+
+// NewPinger creates a pinger checking that the primary of a client's deployment answers
+func NewPinger(client *mongo.Client, timeout time.Duration) domain.Pinger {
+	return &pinger{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// Ping pings the primary
+func (p *pinger) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	return p.client.Ping(ctx, readpref.Primary())
+}
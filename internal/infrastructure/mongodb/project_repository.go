@@ -0,0 +1,182 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type projectRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// projectIndexes are the indexes of the projects collection
+var projectIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "name", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "members.user_id", Value: 1}},
+	},
+	{
+		// Project keys are unique within an organization
+		Keys:    bson.D{{Key: "org_id", Value: 1}, {Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"key": bson.M{"$exists": true}}),
+	},
+}
+
+// NewProjectRepository creates a new project repository
+func NewProjectRepository(db *mongo.Database, timeout time.Duration) domain.ProjectRepository {
+	collection := newCollection(db, "projects")
+
+	return &projectRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// FindByID finds a project by its ID
+func (r *projectRepository) FindByID(id primitive.ObjectID) (*domain.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var project domain.Project
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&project)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// FindByKey finds an organization's project by its key
+func (r *projectRepository) FindByKey(orgID primitive.ObjectID, key string) (*domain.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var project domain.Project
+	err := r.collection.FindOne(ctx, bson.M{"org_id": orgID, "key": key}).Decode(&project)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// FindByOrg finds all projects of an organization, sorted by name
+func (r *projectRepository) FindByOrg(orgID primitive.ObjectID) ([]*domain.Project, error) {
+	return r.find(bson.M{"org_id": orgID})
+}
+
+// FindByMember finds the organization's projects a user is a member of, sorted by name
+func (r *projectRepository) FindByMember(orgID primitive.ObjectID, userID primitive.ObjectID) ([]*domain.Project, error) {
+	return r.find(bson.M{"org_id": orgID, "members.user_id": userID})
+}
+
+// find finds the projects matching a filter, sorted by name
+func (r *projectRepository) find(filter bson.M) ([]*domain.Project, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	projects := []*domain.Project{}
+	if err := cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Create creates a new project
+func (r *projectRepository) Create(project *domain.Project) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created and updated times
+	now := time.Now()
+	project.CreatedAt = now
+	project.UpdatedAt = now
+
+	// If ID is not set, set it to a new ObjectID
+	if project.ID.IsZero() {
+		project.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, project)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateKey
+	}
+	return err
+}
+
+// Update updates an existing project, including its members
+func (r *projectRepository) Update(project *domain.Project) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Update the updated time
+	project.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": project.ID},
+		bson.M{"$set": bson.M{
+			"name":               project.Name,
+			"description":        project.Description,
+			"members":            project.Members,
+			"wip_limits":         project.WIPLimits,
+			"require_acceptance": project.RequireAcceptance,
+			"auto_assign":        project.AutoAssign,
+			"connectors":         project.Connectors,
+			"labels":             project.Labels,
+			"strict_labels":      project.StrictLabels,
+			"updated_at":         project.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a project by its ID
+func (r *projectRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sessionRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// sessionIndexes are the indexes of the sessions collection; expired sessions are removed by MongoDB
+var sessionIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}},
+	},
+	{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	},
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *mongo.Database, timeout time.Duration) domain.SessionRepository {
+	collection := newCollection(db, "sessions")
+
+	return &sessionRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Create creates a new session
+func (r *sessionRepository) Create(session *domain.Session) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created and last seen times
+	now := time.Now()
+	session.CreatedAt = now
+	session.LastSeenAt = now
+
+	// If ID is not set, set it to a new ObjectID
+	if session.ID.IsZero() {
+		session.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, session)
+	return err
+}
+
+// FindByID finds a session by its ID
+func (r *sessionRepository) FindByID(id primitive.ObjectID) (*domain.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var session domain.Session
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// FindActiveByUser finds a user's sessions that are neither revoked nor expired, most recently seen first
+func (r *sessionRepository) FindActiveByUser(userID primitive.ObjectID, now time.Time) ([]*domain.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": now},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []*domain.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Update updates a session's expiry, last seen, and revocation times
+func (r *sessionRepository) Update(session *domain.Session) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{
+		"last_seen_at": session.LastSeenAt,
+		"expires_at":   session.ExpiresAt,
+	}}
+	if session.RevokedAt != nil {
+		update["$set"].(bson.M)["revoked_at"] = session.RevokedAt
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": session.ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Touch records that a session was used at the given time
+func (r *sessionRepository) Touch(id primitive.ObjectID, at time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_seen_at": at}})
+	return err
+}
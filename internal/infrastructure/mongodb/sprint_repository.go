@@ -0,0 +1,132 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sprintRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// sprintIndexes are the indexes of the sprints collection
+var sprintIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "project_id", Value: 1}, {Key: "start_date", Value: 1}},
+	},
+}
+
+// NewSprintRepository creates a new sprint repository
+func NewSprintRepository(db *mongo.Database, timeout time.Duration) domain.SprintRepository {
+	collection := newCollection(db, "sprints")
+
+	return &sprintRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// FindByID finds a sprint by its ID
+func (r *sprintRepository) FindByID(id primitive.ObjectID) (*domain.Sprint, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var sprint domain.Sprint
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&sprint)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &sprint, nil
+}
+
+// FindByProject finds all sprints of a project, sorted by start date
+func (r *sprintRepository) FindByProject(projectID primitive.ObjectID) ([]*domain.Sprint, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"project_id": projectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sprints := []*domain.Sprint{}
+	if err := cursor.All(ctx, &sprints); err != nil {
+		return nil, err
+	}
+
+	return sprints, nil
+}
+
+// Create creates a new sprint
+func (r *sprintRepository) Create(sprint *domain.Sprint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Set created and updated times
+	now := time.Now()
+	sprint.CreatedAt = now
+	sprint.UpdatedAt = now
+
+	// If ID is not set, set it to a new ObjectID
+	if sprint.ID.IsZero() {
+		sprint.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, sprint)
+	return err
+}
+
+// Update updates an existing sprint
+func (r *sprintRepository) Update(sprint *domain.Sprint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Update the updated time
+	sprint.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": sprint.ID},
+		bson.M{"$set": bson.M{
+			"name":       sprint.Name,
+			"goal":       sprint.Goal,
+			"start_date": sprint.StartDate,
+			"end_date":   sprint.EndDate,
+			"closed_at":  sprint.ClosedAt,
+			"updated_at": sprint.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByProject deletes all sprints of a project
+func (r *sprintRepository) DeleteByProject(projectID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
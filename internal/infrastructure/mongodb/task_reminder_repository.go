@@ -0,0 +1,165 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type taskReminderRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// taskReminderIndexes are the indexes of the task_reminders collection
+var taskReminderIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "task_id", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "task_id", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "remind_at", Value: 1}},
+	},
+}
+
+// NewTaskReminderRepository creates a new task reminder repository
+func NewTaskReminderRepository(db *mongo.Database, timeout time.Duration) domain.TaskReminderRepository {
+	collection := newCollection(db, "task_reminders")
+
+	return &taskReminderRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Create stores a new reminder
+func (r *taskReminderRepository) Create(reminder *domain.TaskReminder) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	if reminder.ID.IsZero() {
+		reminder.ID = primitive.NewObjectID()
+	}
+	reminder.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, reminder)
+	return err
+}
+
+// Delete deletes one of a user's reminders
+func (r *taskReminderRepository) Delete(userID, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindByUser finds a user's scheduled reminders, soonest first
+func (r *taskReminderRepository) FindByUser(userID primitive.ObjectID) ([]*domain.TaskReminder, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "remind_at", Value: 1}})
+	return r.find(bson.M{"user_id": userID, "remind_at": bson.M{"$exists": true}}, opts)
+}
+
+// FindByTask finds a user's reminders of a task, soonest first; unscheduled
+// reminders sort last
+func (r *taskReminderRepository) FindByTask(userID, taskID primitive.ObjectID) ([]*domain.TaskReminder, error) {
+	reminders, err := r.find(bson.M{"user_id": userID, "task_id": taskID}, options.Find().SetSort(bson.D{{Key: "remind_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing fields sort first in MongoDB
+	scheduled := make([]*domain.TaskReminder, 0, len(reminders))
+	var unscheduled []*domain.TaskReminder
+	for _, reminder := range reminders {
+		if reminder.RemindAt == nil {
+			unscheduled = append(unscheduled, reminder)
+			continue
+		}
+		scheduled = append(scheduled, reminder)
+	}
+	return append(scheduled, unscheduled...), nil
+}
+
+// FindDue finds up to limit reminders that are due, oldest first
+func (r *taskReminderRepository) FindDue(now time.Time, limit int64) ([]*domain.TaskReminder, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "remind_at", Value: 1}}).SetLimit(limit)
+	return r.find(bson.M{"remind_at": bson.M{"$lte": now}}, opts)
+}
+
+// find finds the reminders matching a filter
+func (r *taskReminderRepository) find(filter bson.M, opts *options.FindOptions) ([]*domain.TaskReminder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	reminders := []*domain.TaskReminder{}
+	if err := cursor.All(ctx, &reminders); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// Release deletes a due reminder, unless it was rescheduled since it was loaded
+func (r *taskReminderRepository) Release(reminder *domain.TaskReminder) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": reminder.ID, "remind_at": reminder.RemindAt})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// RescheduleTask moves the reminders relative to a task's due date, computing
+// their times in the database so that each keeps its own offset
+func (r *taskReminderRepository) RescheduleTask(taskID primitive.ObjectID, dueDate time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"task_id": taskID, "before_due_minutes": bson.M{"$exists": true}}
+
+	var update interface{} = bson.M{"$unset": bson.M{"remind_at": ""}}
+	if !dueDate.IsZero() {
+		update = mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"remind_at": bson.M{"$subtract": bson.A{dueDate, bson.M{"$multiply": bson.A{"$before_due_minutes", 60 * 1000}}}},
+		}}}}
+	}
+
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// DeleteByTask deletes all reminders of a task
+func (r *taskReminderRepository) DeleteByTask(taskID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"task_id": taskID})
+	return err
+}
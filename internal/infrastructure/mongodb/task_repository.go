@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"task-management-system/internal/domain"
@@ -13,54 +14,124 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// taskListViewProjection leaves large free-text fields out of list views
+var taskListViewProjection = bson.M{"description": 0}
+
 type taskRepository struct {
-	collection *mongo.Collection
+	collection faultCollection
 	timeout    time.Duration
+	base       context.Context // parent of every operation's context; carries the session inside a unit of work
+	orgID      primitive.ObjectID
+}
+
+// taskIndexes are the indexes of the tasks collection
+var taskIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "due_date", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "project_id", Value: 1}},
+	},
+	{
+		Keys:    bson.D{{Key: "sprint_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	},
+	{
+		Keys:    bson.D{{Key: "milestone_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	},
+	{
+		// Task keys are unique within an organization
+		Keys:    bson.D{{Key: "org_id", Value: 1}, {Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"key": bson.M{"$exists": true}}),
+	},
+	{
+		Keys: bson.D{{Key: "created_by", Value: 1}},
+	},
+	{
+		// Each user's assigned tasks, with the fields their badge counters filter on
+		Keys: bson.D{{Key: "assigned_to", Value: 1}, {Key: "org_id", Value: 1}, {Key: "status", Value: 1}, {Key: "due_date", Value: 1}},
+	},
+	{
+		// Assignments awaiting each user's answer
+		Keys:    bson.D{{Key: "pending_assignments.user_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	},
+	{
+		// Tasks each user was granted access to
+		Keys:    bson.D{{Key: "grants.user_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	},
+	{
+		// Links pointing to each task, cleaned up when it is deleted
+		Keys:    bson.D{{Key: "links.task_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	},
+	{
+		Keys: bson.D{{Key: "status", Value: 1}},
+	},
+	{
+		Keys: bson.D{{Key: "due_date", Value: 1}},
+	},
 }
 
-// NewTaskRepository creates a new task repository
+// NewTaskRepository creates a new task repository. Slow queries are logged by
+// the client's command monitor.
 func NewTaskRepository(db *mongo.Database, timeout time.Duration) domain.TaskRepository {
-	collection := db.Collection("tasks")
+	collection := newCollection(db, "tasks")
 
-	// Create indexes
-	indexModel := []mongo.IndexModel{
-		{
-			Keys: bson.D{{Key: "created_by", Value: 1}},
-		},
-		{
-			Keys: bson.D{{Key: "assigned_to", Value: 1}},
-		},
-		{
-			Keys: bson.D{{Key: "status", Value: 1}},
-		},
-		{
-			Keys: bson.D{{Key: "due_date", Value: 1}},
-		},
+	return &taskRepository{
+		collection: collection,
+		timeout:    timeout,
+		base:       context.Background(),
 	}
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+// ForOrg returns a copy of the repository scoped to an organization
+func (r *taskRepository) ForOrg(orgID primitive.ObjectID) domain.TaskRepository {
+	scoped := *r
+	scoped.orgID = orgID
+	return &scoped
+}
 
-	_, err := collection.Indexes().CreateMany(ctx, indexModel)
-	if err != nil {
-		// Log error but continue - indexes are for performance, not functionality
-		// In production, you might want to handle this differently
-		// log.Printf("Error creating indexes: %v", err)
+// scope restricts a filter to the repository's organization, if any
+func (r *taskRepository) scope(filter bson.M) bson.M {
+	if r.orgID.IsZero() {
+		return filter
 	}
 
-	return &taskRepository{
-		collection: collection,
-		timeout:    timeout,
+	scoped := make(bson.M, len(filter)+1)
+	for key, value := range filter {
+		scoped[key] = value
 	}
+	scoped["org_id"] = r.orgID
+	return scoped
 }
 
 // FindByID finds a task by its ID
 func (r *taskRepository) FindByID(id primitive.ObjectID) (*domain.Task, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	var task domain.Task
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&task)
+	err := r.collection.FindOne(ctx, r.scope(bson.M{"_id": id})).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// FindByKey finds a task by its key, e.g. PROJ-123
+func (r *taskRepository) FindByKey(key string) (*domain.Task, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	var task domain.Task
+	err := r.collection.FindOne(ctx, r.scope(bson.M{"key": key})).Decode(&task)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.ErrNotFound
@@ -72,8 +143,8 @@ func (r *taskRepository) FindByID(id primitive.ObjectID) (*domain.Task, error) {
 }
 
 // FindAll finds all tasks matching the filter
-func (r *taskRepository) FindAll(filter map[string]interface{}) ([]*domain.Task, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+func (r *taskRepository) FindAll(filter map[string]interface{}, queryOpts ...domain.TaskQueryOption) ([]*domain.Task, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	filterBson := bson.M{}
@@ -81,8 +152,22 @@ func (r *taskRepository) FindAll(filter map[string]interface{}) ([]*domain.Task,
 		filterBson = bson.M(filter)
 	}
 
-	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}})
-	cursor, err := r.collection.Find(ctx, filterBson, opts)
+	opts := findOptions(queryOpts).SetSort(bson.D{{Key: "due_date", Value: 1}})
+
+	// Pages are walked in ID order
+	if query := domain.NewTaskQuery(queryOpts...); query.PageSize > 0 {
+		paged := make(bson.M, len(filterBson)+1)
+		for key, value := range filterBson {
+			paged[key] = value
+		}
+		if !query.After.IsZero() {
+			paged["_id"] = bson.M{"$gt": query.After}
+		}
+		filterBson = paged
+		opts.SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(query.PageSize)
+	}
+
+	cursor, err := r.collection.Find(ctx, r.scope(filterBson), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -96,9 +181,22 @@ func (r *taskRepository) FindAll(filter map[string]interface{}) ([]*domain.Task,
 	return tasks, nil
 }
 
+// Count counts the tasks matching the filter without loading them
+func (r *taskRepository) Count(filter map[string]interface{}) (int64, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	filterBson := bson.M{}
+	if filter != nil {
+		filterBson = bson.M(filter)
+	}
+
+	return r.collection.CountDocuments(ctx, r.scope(filterBson))
+}
+
 // Create creates a new task
 func (r *taskRepository) Create(task *domain.Task) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	// Set created and updated times
@@ -111,39 +209,138 @@ func (r *taskRepository) Create(task *domain.Task) error {
 		task.ID = primitive.NewObjectID()
 	}
 
+	// Tasks created through a scoped repository belong to its organization
+	if !r.orgID.IsZero() {
+		task.OrgID = r.orgID
+	}
+
 	// Default status to pending if not set
 	if task.Status == "" {
-		task.Status = domain.TaskStatusPending
+		task.Status = domain.InitialTaskStatus
 	}
 
 	_, err := r.collection.InsertOne(ctx, task)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateKey
+	}
 	return err
 }
 
+// CreateMany creates several tasks with a single unordered bulk write, so a task
+// that fails does not stop the others. The tasks that failed are reported with a
+// *domain.BulkWriteError.
+func (r *taskRepository) CreateMany(tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	documents := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		// Set the same defaults as Create
+		task.CreatedAt = now
+		task.UpdatedAt = now
+		if task.ID.IsZero() {
+			task.ID = primitive.NewObjectID()
+		}
+		if !r.orgID.IsZero() {
+			task.OrgID = r.orgID
+		}
+		if task.Status == "" {
+			task.Status = domain.InitialTaskStatus
+		}
+		documents = append(documents, task)
+	}
+
+	_, err := r.collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	return bulkWriteError(err)
+}
+
 // Update updates an existing task
 func (r *taskRepository) Update(task *domain.Task) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	// Update the updated time
 	task.UpdatedAt = time.Now()
 
+	// Store an empty list rather than null when the task has no assignees
+	assignedTo := task.AssignedTo
+	if assignedTo == nil {
+		assignedTo = []primitive.ObjectID{}
+	}
+
 	// Create an update document
-	update := bson.M{
-		"$set": bson.M{
-			"title":       task.Title,
-			"description": task.Description,
-			"status":      task.Status,
-			"priority":    task.Priority,
-			"due_date":    task.DueDate,
-			"assigned_to": task.AssignedTo,
-			"updated_at":  task.UpdatedAt,
-		},
+	set := bson.M{
+		"title":       task.Title,
+		"description": task.Description,
+		"status":      task.Status,
+		"priority":    task.Priority,
+		"due_date":    task.DueDate,
+		"assigned_to": assignedTo,
+		"updated_at":  task.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+
+	// Tasks taken out of their sprint or milestone have the field removed; tasks in no sprint form the project's backlog
+	unset := bson.M{}
+	if task.SprintID.IsZero() {
+		unset["sprint_id"] = ""
+	} else {
+		set["sprint_id"] = task.SprintID
+	}
+	if task.MilestoneID.IsZero() {
+		unset["milestone_id"] = ""
+	} else {
+		set["milestone_id"] = task.MilestoneID
+	}
+	if len(task.Tags) == 0 {
+		unset["tags"] = ""
+	} else {
+		set["tags"] = task.Tags
+	}
+	if task.MergedInto.IsZero() {
+		unset["merged_into"] = ""
+	} else {
+		set["merged_into"] = task.MergedInto
+	}
+	if len(task.PendingAssignments) == 0 {
+		unset["pending_assignments"] = ""
+	} else {
+		set["pending_assignments"] = task.PendingAssignments
+	}
+	if len(task.Grants) == 0 {
+		unset["grants"] = ""
+	} else {
+		set["grants"] = task.Grants
+	}
+	if len(task.Links) == 0 {
+		unset["links"] = ""
+	} else {
+		set["links"] = task.Links
+	}
+	if task.AgedAt == nil {
+		unset["original_priority"] = ""
+		unset["aged_at"] = ""
+	} else {
+		set["original_priority"] = task.OriginalPriority
+		set["aged_at"] = task.AgedAt
+	}
+	if task.EscalatedAt == nil {
+		unset["escalated_at"] = ""
+	} else {
+		set["escalated_at"] = task.EscalatedAt
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
 	}
 
 	result, err := r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": task.ID},
+		r.scope(bson.M{"_id": task.ID}),
 		update,
 	)
 	if err != nil {
@@ -157,12 +354,46 @@ func (r *taskRepository) Update(task *domain.Task) error {
 	return nil
 }
 
+// RaisePriority raises an open task's priority by one with a single atomic
+// update, so that concurrent edits of its other fields are kept
+func (r *taskRepository) RaisePriority(id primitive.ObjectID, maxPriority int, agedAt time.Time) (*domain.Task, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	filter := r.scope(bson.M{
+		"_id":      id,
+		"status":   bson.M{"$ne": domain.TaskStatusCompleted},
+		"priority": bson.M{"$lt": maxPriority},
+	})
+
+	// An update pipeline, so that the original priority can be read from the stored task
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"original_priority": bson.M{"$ifNull": bson.A{"$original_priority", "$priority"}},
+			"priority":          bson.M{"$add": bson.A{"$priority", 1}},
+			"aged_at":           agedAt,
+			"updated_at":        time.Now(),
+		}}},
+	}
+
+	var task domain.Task
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &task, nil
+}
+
 // Delete deletes a task by its ID
 func (r *taskRepository) Delete(id primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
-	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	result, err := r.collection.DeleteOne(ctx, r.scope(bson.M{"_id": id}))
 	if err != nil {
 		return err
 	}
@@ -174,11 +405,29 @@ func (r *taskRepository) Delete(id primitive.ObjectID) error {
 	return nil
 }
 
-// FindByUser finds tasks by user ID (either created by or assigned to)
-func (r *taskRepository) FindByUser(userID primitive.ObjectID) ([]*domain.Task, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+// DeleteMany deletes several tasks with a single bulk write
+func (r *taskRepository) DeleteMany(ids []primitive.ObjectID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, r.scope(bson.M{"_id": bson.M{"$in": ids}}))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// FindByUser finds tasks by user ID (either created by or among the assignees)
+func (r *taskRepository) FindByUser(userID primitive.ObjectID, queryOpts ...domain.TaskQueryOption) ([]*domain.Task, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
+	// assigned_to is an array, so an equality match hits any element
 	filter := bson.M{
 		"$or": []bson.M{
 			{"created_by": userID},
@@ -186,8 +435,8 @@ func (r *taskRepository) FindByUser(userID primitive.ObjectID) ([]*domain.Task,
 		},
 	}
 
-	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}})
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	opts := findOptions(queryOpts).SetSort(bson.D{{Key: "due_date", Value: 1}})
+	cursor, err := r.collection.Find(ctx, r.scope(filter), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -202,14 +451,14 @@ func (r *taskRepository) FindByUser(userID primitive.ObjectID) ([]*domain.Task,
 }
 
 // FindByStatus finds tasks by status
-func (r *taskRepository) FindByStatus(status domain.TaskStatus) ([]*domain.Task, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+func (r *taskRepository) FindByStatus(status domain.TaskStatus, queryOpts ...domain.TaskQueryOption) ([]*domain.Task, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	filter := bson.M{"status": status}
 
-	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}})
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	opts := findOptions(queryOpts).SetSort(bson.D{{Key: "due_date", Value: 1}})
+	cursor, err := r.collection.Find(ctx, r.scope(filter), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -222,3 +471,32 @@ func (r *taskRepository) FindByStatus(status domain.TaskStatus) ([]*domain.Task,
 
 	return tasks, nil
 }
+
+// findOptions translates task query options into MongoDB find options
+func findOptions(queryOpts []domain.TaskQueryOption) *options.FindOptions {
+	opts := options.Find()
+	if domain.NewTaskQuery(queryOpts...).ListView {
+		opts.SetProjection(taskListViewProjection)
+	}
+	return opts
+}
+
+// bulkWriteError translates the write errors of a bulk write into a
+// *domain.BulkWriteError. Other errors, such as a write concern or network
+// error, leave unknown which items were written and are returned as they are.
+func bulkWriteError(err error) error {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return err
+	}
+
+	failed := make(map[int]error, len(bulkErr.WriteErrors))
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code == 11000 {
+			failed[writeErr.Index] = domain.ErrDuplicateKey
+			continue
+		}
+		failed[writeErr.Index] = fmt.Errorf("%w: %s", domain.ErrInternalServer, writeErr.Message)
+	}
+	return &domain.BulkWriteError{Failed: failed}
+}
@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// scoredTask decodes a task document together with the score added by a search
+type scoredTask struct {
+	domain.Task `bson:",inline"`
+	Score       float64 `bson:"score"`
+}
+
+// toSearchResults converts decoded documents into search results
+func toSearchResults(docs []scoredTask) []*domain.TaskSearchResult {
+	results := make([]*domain.TaskSearchResult, 0, len(docs))
+	for i := range docs {
+		task := docs[i].Task
+		results = append(results, &domain.TaskSearchResult{Task: &task, Score: docs[i].Score})
+	}
+	return results
+}
+
+type taskTextSearcher struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// taskTextIndexes are the indexes of the tasks collection used by the text search
+// engine; without them, searches fail
+var taskTextIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().
+			SetName("tasks_text").
+			SetWeights(bson.D{{Key: "title", Value: 3}, {Key: "description", Value: 1}}),
+	},
+}
+
+// NewTaskTextSearcher creates a task searcher backed by a MongoDB text index.
+// It works on any MongoDB deployment and matches whole words only.
+func NewTaskTextSearcher(db *mongo.Database, timeout time.Duration) domain.TaskSearcher {
+	collection := newCollection(db, "tasks")
+
+	return &taskTextSearcher{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Search finds tasks whose title or description contain the query terms
+func (s *taskTextSearcher) Search(orgID primitive.ObjectID, query string, limit int64) ([]*domain.TaskSearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}}).
+		SetLimit(limit)
+
+	filter := bson.M{"$text": bson.M{"$search": query}, "org_id": orgID}
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []scoredTask
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	return toSearchResults(docs), nil
+}
+
+type taskAtlasSearcher struct {
+	collection faultCollection
+	index      string
+	timeout    time.Duration
+}
+
+// NewTaskAtlasSearcher creates a task searcher backed by MongoDB Atlas Search.
+// The named search index must be defined in Atlas on the tasks collection and
+// cover the title and description fields, plus org_id as an objectId field;
+// it cannot be created from here.
+func NewTaskAtlasSearcher(db *mongo.Database, index string, timeout time.Duration) domain.TaskSearcher {
+	return &taskAtlasSearcher{
+		collection: newCollection(db, "tasks"),
+		index:      index,
+		timeout:    timeout,
+	}
+}
+
+// Search finds tasks using fuzzy matching, so small typos still match.
+// Title matches are boosted over description matches.
+func (s *taskAtlasSearcher) Search(orgID primitive.ObjectID, query string, limit int64) ([]*domain.TaskSearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	fuzzy := bson.M{"maxEdits": 2, "prefixLength": 1}
+	pipeline := mongo.Pipeline{
+		{{Key: "$search", Value: bson.M{
+			"index": s.index,
+			"compound": bson.M{
+				"should": bson.A{
+					bson.M{"text": bson.M{
+						"query": query,
+						"path":  "title",
+						"fuzzy": fuzzy,
+						"score": bson.M{"boost": bson.M{"value": 3}},
+					}},
+					bson.M{"text": bson.M{
+						"query": query,
+						"path":  "description",
+						"fuzzy": fuzzy,
+					}},
+				},
+				"minimumShouldMatch": 1,
+				"filter": bson.A{
+					bson.M{"equals": bson.M{"path": "org_id", "value": orgID}},
+				},
+			},
+		}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "searchScore"}}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []scoredTask
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	return toSearchResults(docs), nil
+}
+
+// Search engines selectable through configuration
+const (
+	SearchEngineText  = "text"
+	SearchEngineAtlas = "atlas"
+)
+
+// NewTaskSearcher creates the task searcher for the configured engine.
+// An empty engine selects the text index implementation.
+func NewTaskSearcher(db *mongo.Database, engine string, atlasIndex string, timeout time.Duration) (domain.TaskSearcher, error) {
+	switch engine {
+	case "", SearchEngineText:
+		return NewTaskTextSearcher(db, timeout), nil
+	case SearchEngineAtlas:
+		if atlasIndex == "" {
+			return nil, errors.New("an Atlas Search index name is required for the atlas search engine")
+		}
+		return NewTaskAtlasSearcher(db, atlasIndex, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown search engine %q", engine)
+	}
+}
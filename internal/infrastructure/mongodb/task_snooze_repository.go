@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type taskSnoozeRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// taskSnoozeIndexes are the indexes of the task_snoozes collection
+var taskSnoozeIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "task_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	{
+		Keys: bson.D{{Key: "until", Value: 1}},
+	},
+}
+
+// NewTaskSnoozeRepository creates a new task snooze repository
+func NewTaskSnoozeRepository(db *mongo.Database, timeout time.Duration) domain.TaskSnoozeRepository {
+	collection := newCollection(db, "task_snoozes")
+
+	return &taskSnoozeRepository{
+		collection: collection,
+		timeout:    timeout,
+	}
+}
+
+// Snooze snoozes a task for a user; snoozing it again moves the end of the snooze
+func (r *taskSnoozeRepository) Snooze(snooze *domain.TaskSnooze) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user_id": snooze.UserID, "task_id": snooze.TaskID}
+	update := bson.M{
+		"$set": bson.M{
+			"until": snooze.Until,
+		},
+		"$setOnInsert": bson.M{
+			"org_id":     snooze.OrgID,
+			"user_id":    snooze.UserID,
+			"task_id":    snooze.TaskID,
+			"created_at": time.Now(),
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	return r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(snooze)
+}
+
+// Unsnooze removes a user's snooze from a task
+func (r *taskSnoozeRepository) Unsnooze(userID, taskID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "task_id": taskID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindActive finds a user's snoozes that have not ended yet, ending soonest first
+func (r *taskSnoozeRepository) FindActive(userID primitive.ObjectID, now time.Time) ([]*domain.TaskSnooze, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "until", Value: 1}})
+	return r.find(bson.M{"user_id": userID, "until": bson.M{"$gt": now}}, opts)
+}
+
+// FindEnded finds up to limit snoozes that have ended, oldest first
+func (r *taskSnoozeRepository) FindEnded(now time.Time, limit int64) ([]*domain.TaskSnooze, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "until", Value: 1}}).SetLimit(limit)
+	return r.find(bson.M{"until": bson.M{"$lte": now}}, opts)
+}
+
+// find finds the snoozes matching a filter
+func (r *taskSnoozeRepository) find(filter bson.M, opts *options.FindOptions) ([]*domain.TaskSnooze, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	snoozes := []*domain.TaskSnooze{}
+	if err := cursor.All(ctx, &snoozes); err != nil {
+		return nil, err
+	}
+
+	return snoozes, nil
+}
+
+// Release deletes an ended snooze, unless the user snoozed the task again since it was loaded
+func (r *taskSnoozeRepository) Release(snooze *domain.TaskSnooze) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": snooze.ID, "until": snooze.Until})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
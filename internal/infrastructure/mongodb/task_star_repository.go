@@ -0,0 +1,138 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type taskStarRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+	base       context.Context // parent of every operation's context; carries the session inside a unit of work
+}
+
+// taskStarIndexes are the indexes of the task_stars collection
+var taskStarIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "task_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+}
+
+// NewTaskStarRepository creates a new task star repository
+func NewTaskStarRepository(db *mongo.Database, timeout time.Duration) domain.TaskStarRepository {
+	collection := newCollection(db, "task_stars")
+
+	return &taskStarRepository{
+		collection: collection,
+		timeout:    timeout,
+		base:       context.Background(),
+	}
+}
+
+// Star stars a task for a user; starring an already starred task is a no-op
+func (r *taskStarRepository) Star(userID, taskID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID, "task_id": taskID}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"user_id":    userID,
+			"task_id":    taskID,
+			"created_at": time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// Unstar removes a user's star from a task
+func (r *taskStarRepository) Unstar(userID, taskID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "task_id": taskID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindTaskIDsByUser returns the IDs of all tasks starred by a user, most recently starred first
+func (r *taskStarRepository) FindTaskIDsByUser(userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stars []*domain.TaskStar
+	if err := cursor.All(ctx, &stars); err != nil {
+		return nil, err
+	}
+
+	taskIDs := make([]primitive.ObjectID, 0, len(stars))
+	for _, star := range stars {
+		taskIDs = append(taskIDs, star.TaskID)
+	}
+
+	return taskIDs, nil
+}
+
+// MoveToTask moves all stars of a task to another task, keeping when each user starred it
+func (r *taskStarRepository) MoveToTask(from, to primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"task_id": from})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var stars []*domain.TaskStar
+	if err := cursor.All(ctx, &stars); err != nil {
+		return 0, err
+	}
+
+	// Users who already starred the target keep their star on it
+	for _, star := range stars {
+		_, err := r.collection.UpdateOne(
+			ctx,
+			bson.M{"user_id": star.UserID, "task_id": to},
+			bson.M{"$setOnInsert": bson.M{
+				"user_id":    star.UserID,
+				"task_id":    to,
+				"created_at": star.CreatedAt,
+			}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"task_id": from}); err != nil {
+		return 0, err
+	}
+
+	return int64(len(stars)), nil
+}
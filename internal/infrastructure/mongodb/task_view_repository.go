@@ -0,0 +1,92 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type taskViewRepository struct {
+	collection faultCollection
+	timeout    time.Duration
+}
+
+// taskViewIndexes are the indexes of the task_views collection
+var taskViewIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "task_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	{
+		Keys: bson.D{{Key: "task_id", Value: 1}},
+	},
+}
+
+// NewTaskViewRepository creates a new task view repository
+func NewTaskViewRepository(db *mongo.Database, timeout time.Duration) domain.TaskViewRepository {
+	return &taskViewRepository{
+		collection: newCollection(db, "task_views"),
+		timeout:    timeout,
+	}
+}
+
+// MarkSeen records that a user saw a task as of the given time, keeping the later time
+func (r *taskViewRepository) MarkSeen(userID, taskID primitive.ObjectID, at time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"user_id": userID, "task_id": taskID},
+		bson.M{"$max": bson.M{"seen_at": at}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// FindSeen returns when the user last saw each of the given tasks they saw
+func (r *taskViewRepository) FindSeen(userID primitive.ObjectID, taskIDs []primitive.ObjectID) (map[primitive.ObjectID]time.Time, error) {
+	if len(taskIDs) == 0 {
+		return map[primitive.ObjectID]time.Time{}, nil
+	}
+
+	return r.find(bson.M{"user_id": userID, "task_id": bson.M{"$in": taskIDs}}, func(view *domain.TaskView) primitive.ObjectID {
+		return view.TaskID
+	})
+}
+
+// FindByTask returns when each user who saw the task last did
+func (r *taskViewRepository) FindByTask(taskID primitive.ObjectID) (map[primitive.ObjectID]time.Time, error) {
+	return r.find(bson.M{"task_id": taskID}, func(view *domain.TaskView) primitive.ObjectID {
+		return view.UserID
+	})
+}
+
+// find returns the seen times of the views matching a filter, by the given key
+func (r *taskViewRepository) find(filter bson.M, key func(*domain.TaskView) primitive.ObjectID) (map[primitive.ObjectID]time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var views []*domain.TaskView
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[primitive.ObjectID]time.Time, len(views))
+	for _, view := range views {
+		seen[key(view)] = view.SeenAt
+	}
+	return seen, nil
+}
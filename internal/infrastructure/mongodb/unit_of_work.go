@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type unitOfWork struct {
+	client  *mongo.Client
+	db      *mongo.Database
+	timeout time.Duration
+}
+
+// NewUnitOfWork creates a unit of work backed by MongoDB transactions.
+// Transactions require a replica set or sharded cluster.
+func NewUnitOfWork(client *mongo.Client, db *mongo.Database, timeout time.Duration) domain.UnitOfWork {
+	return &unitOfWork{
+		client:  client,
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// Do runs fn in a transaction with repositories bound to the session.
+// The driver may retry fn on transient transaction errors.
+func (u *unitOfWork) Do(fn func(tasks domain.TaskRepository, outbox domain.OutboxRepository) error) error {
+	return u.DoAll(func(repos *domain.TxRepositories) error {
+		return fn(repos.Tasks, repos.Outbox)
+	})
+}
+
+// DoAll runs fn in a transaction with all repositories bound to the session.
+// The driver may retry fn on transient transaction errors.
+func (u *unitOfWork) DoAll(fn func(repos *domain.TxRepositories) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+	defer cancel()
+
+	session, err := u.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(&domain.TxRepositories{
+			Tasks:       &taskRepository{collection: newCollection(u.db, "tasks"), timeout: u.timeout, base: sc},
+			Outbox:      &outboxRepository{collection: newCollection(u.db, "outbox"), timeout: u.timeout, base: sc},
+			Attachments: &attachmentRepository{collection: newCollection(u.db, "attachments"), timeout: u.timeout, base: sc},
+			Stars:       &taskStarRepository{collection: newCollection(u.db, "task_stars"), timeout: u.timeout, base: sc},
+			Users:       &userRepository{collection: newCollection(u.db, "users"), timeout: u.timeout, base: sc},
+		})
+	})
+	return err
+}
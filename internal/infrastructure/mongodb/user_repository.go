@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"errors"
+	"regexp"
 	"time"
 
 	"task-management-system/internal/domain"
@@ -14,45 +15,40 @@ import (
 )
 
 type userRepository struct {
-	collection *mongo.Collection
+	collection faultCollection
 	timeout    time.Duration
+	base       context.Context // parent of every operation's context; carries the session inside a unit of work
+}
+
+// userIndexes are the indexes of the users collection
+var userIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	},
+	{
+		Keys: bson.D{{Key: "org_id", Value: 1}},
+	},
 }
 
 // NewUserRepository creates a new user repository
 func NewUserRepository(db *mongo.Database, timeout time.Duration) domain.UserRepository {
-	collection := db.Collection("users")
-
-	// Create indexes
-	indexModel := []mongo.IndexModel{
-		{
-			Keys:    bson.D{{Key: "email", Value: 1}},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys:    bson.D{{Key: "username", Value: 1}},
-			Options: options.Index().SetUnique(true),
-		},
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	_, err := collection.Indexes().CreateMany(ctx, indexModel)
-	if err != nil {
-		// Log error but continue - indexes are for performance, not functionality
-		// In production, you might want to handle this differently
-		// log.Printf("Error creating indexes: %v", err)
-	}
+	collection := newCollection(db, "users")
 
 	return &userRepository{
 		collection: collection,
 		timeout:    timeout,
+		base:       context.Background(),
 	}
 }
 
 // FindByID finds a user by its ID
 func (r *userRepository) FindByID(id primitive.ObjectID) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	var user domain.User
@@ -67,9 +63,49 @@ func (r *userRepository) FindByID(id primitive.ObjectID) (*domain.User, error) {
 	return &user, nil
 }
 
+// FindByIDInOrg finds a user of the organization by its ID
+func (r *userRepository) FindByIDInOrg(orgID primitive.ObjectID, id primitive.ObjectID) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	var user domain.User
+	err := r.collection.FindOne(ctx, bson.M{"_id": id, "org_id": orgID}).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// FindByIDs finds the users with the given IDs
+func (r *userRepository) FindByIDs(ids []primitive.ObjectID) ([]*domain.User, error) {
+	users := []*domain.User{}
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // FindByEmail finds a user by email
 func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	var user domain.User
@@ -86,7 +122,7 @@ func (r *userRepository) FindByEmail(email string) (*domain.User, error) {
 
 // FindByUsername finds a user by username
 func (r *userRepository) FindByUsername(username string) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	var user domain.User
@@ -101,9 +137,79 @@ func (r *userRepository) FindByUsername(username string) (*domain.User, error) {
 	return &user, nil
 }
 
+// FindByOrg finds all members of an organization, ordered by username
+func (r *userRepository) FindByOrg(orgID primitive.ObjectID) ([]*domain.User, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "username", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"org_id": orgID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []*domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Find finds a page of the users matching the filter, ordered by username
+func (r *userRepository) Find(filter domain.UserFilter) ([]*domain.User, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "username", Value: 1}})
+	if filter.Skip > 0 {
+		opts.SetSkip(filter.Skip)
+	}
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, userFilterQuery(filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []*domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Count counts the users matching the filter
+func (r *userRepository) Count(filter domain.UserFilter) (int64, error) {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	return r.collection.CountDocuments(ctx, userFilterQuery(filter))
+}
+
+// userFilterQuery builds the MongoDB query for a user filter
+func userFilterQuery(filter domain.UserFilter) bson.M {
+	query := bson.M{"org_id": filter.OrgID}
+	if filter.Query != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Query), Options: "i"}
+		query["$or"] = bson.A{
+			bson.M{"username": pattern},
+			bson.M{"email": pattern},
+			bson.M{"first_name": pattern},
+			bson.M{"last_name": pattern},
+		}
+	}
+	return query
+}
+
 // Create creates a new user
 func (r *userRepository) Create(user *domain.User) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	// Check if user with the same email or username already exists
@@ -136,7 +242,7 @@ func (r *userRepository) Create(user *domain.User) error {
 
 // Update updates an existing user
 func (r *userRepository) Update(user *domain.User) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	// Update the updated time
@@ -148,6 +254,9 @@ func (r *userRepository) Update(user *domain.User) error {
 			"email":      user.Email,
 			"first_name": user.FirstName,
 			"last_name":  user.LastName,
+			"timezone":   user.Timezone,
+			"org_id":     user.OrgID,
+			"org_role":   user.OrgRole,
 			"updated_at": user.UpdatedAt,
 		},
 	}
@@ -157,6 +266,16 @@ func (r *userRepository) Update(user *domain.User) error {
 		update["$set"].(bson.M)["password"] = user.Password
 	}
 
+	if !user.PasswordChangedAt.IsZero() {
+		update["$set"].(bson.M)["password_changed_at"] = user.PasswordChangedAt
+	}
+
+	if user.DeactivatedAt != nil {
+		update["$set"].(bson.M)["deactivated_at"] = user.DeactivatedAt
+	} else {
+		update["$unset"] = bson.M{"deactivated_at": ""}
+	}
+
 	result, err := r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": user.ID},
@@ -176,9 +295,59 @@ func (r *userRepository) Update(user *domain.User) error {
 	return nil
 }
 
+// UpdateAvatar sets or, given an empty key, removes a user's avatar
+func (r *userRepository) UpdateAvatar(id primitive.ObjectID, key string) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"avatar_key": key, "avatar_updated_at": now, "updated_at": now}}
+	if key == "" {
+		update = bson.M{
+			"$unset": bson.M{"avatar_key": "", "avatar_updated_at": ""},
+			"$set":   bson.M{"updated_at": now},
+		}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// IncrementTokenGeneration atomically bumps a user's token generation
+func (r *userRepository) IncrementTokenGeneration(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$inc": bson.M{"token_generation": 1},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 // Delete deletes a user by its ID
 func (r *userRepository) Delete(id primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	ctx, cancel := context.WithTimeout(r.base, r.timeout)
 	defer cancel()
 
 	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
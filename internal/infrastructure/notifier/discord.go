@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"net/http"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// DiscordNotifier posts project task events to Discord channel webhooks
+type DiscordNotifier struct {
+	client *http.Client
+}
+
+// NewDiscordNotifier creates a new Discord notifier
+func NewDiscordNotifier(timeout time.Duration) *DiscordNotifier {
+	return &DiscordNotifier{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Channel returns the channel served by this notifier
+func (n *DiscordNotifier) Channel() domain.NotificationChannel {
+	return domain.ChannelDiscord
+}
+
+// Post posts a project's task event to a Discord webhook. Mentions are
+// disabled, so task titles cannot ping the channel.
+func (n *DiscordNotifier) Post(webhookURL string, message *domain.ChatMessage) error {
+	return postJSON(n.client, webhookURL, map[string]interface{}{
+		"content":          chatText(message),
+		"allowed_mentions": map[string]interface{}{"parse": []string{}},
+	})
+}
@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"task-management-system/internal/domain"
+)
+
+// EmailNotifier sends notifications by email over SMTP
+type EmailNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewEmailNotifier creates a new SMTP email notifier
+func NewEmailNotifier(host string, port int, username, password, from string) *EmailNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &EmailNotifier{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Channel returns the channel served by this notifier
+func (n *EmailNotifier) Channel() domain.NotificationChannel {
+	return domain.ChannelEmail
+}
+
+// Notify emails the notification to the recipient, with its summary as subject
+func (n *EmailNotifier) Notify(recipient *domain.User, prefs *domain.NotificationPreferences, notification *domain.Notification) error {
+	return n.Send(recipient.Email, notification.Summary(), notification.Body())
+}
+
+// Send sends a plain-text email
+func (n *EmailNotifier) Send(to, subject, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{to}, []byte(msg.String()))
+}
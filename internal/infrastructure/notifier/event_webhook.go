@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// EventWebhook posts every domain event as JSON to a fixed set of URLs,
+// for integrations that consume the raw event stream
+type EventWebhook struct {
+	client *http.Client
+	urls   []string
+}
+
+// NewEventWebhook creates a new event webhook
+func NewEventWebhook(urls []string, timeout time.Duration) *EventWebhook {
+	return &EventWebhook{
+		client: &http.Client{Timeout: timeout},
+		urls:   urls,
+	}
+}
+
+// HandleEvent posts the event to all URLs. It is meant to be subscribed to the event bus.
+func (w *EventWebhook) HandleEvent(event *domain.Event) error {
+	var errs []error
+	for _, url := range w.urls {
+		if err := postJSON(w.client, url, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", url, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"fmt"
+
+	"task-management-system/config"
+	"task-management-system/internal/domain"
+)
+
+// NewFromConfig creates the external notifiers enabled by the configuration.
+// Webhook and Slack delivery need no server-side setup; email requires an SMTP host.
+func NewFromConfig(cfg config.NotificationsConfig) []domain.Notifier {
+	notifiers := []domain.Notifier{
+		NewWebhookNotifier(cfg.Timeout),
+		NewSlackNotifier(cfg.Timeout),
+	}
+
+	if email := NewEmailFromConfig(cfg.Email); email != nil {
+		notifiers = append(notifiers, email)
+	}
+
+	return notifiers
+}
+
+// NewChatPostersFromConfig creates the notifiers posting project task events to
+// chat channels. They only need the channel's incoming webhook URL.
+func NewChatPostersFromConfig(cfg config.NotificationsConfig) []domain.ChatPoster {
+	return []domain.ChatPoster{
+		NewSlackNotifier(cfg.Timeout),
+		NewTeamsNotifier(cfg.Timeout),
+		NewDiscordNotifier(cfg.Timeout),
+	}
+}
+
+// chatText formats a chat message as a single line, led by the task key if any
+func chatText(message *domain.ChatMessage) string {
+	if message.TaskKey == "" {
+		return message.Summary
+	}
+	return fmt.Sprintf("[%s] %s", message.TaskKey, message.Summary)
+}
+
+// NewEmailFromConfig creates the email notifier, or returns nil when no SMTP host is configured
+func NewEmailFromConfig(cfg config.EmailConfig) *EmailNotifier {
+	if cfg.SMTPHost == "" {
+		return nil
+	}
+
+	return NewEmailNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.Username, cfg.Password, cfg.From)
+}
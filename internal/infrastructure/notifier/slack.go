@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// SlackNotifier posts notifications to the recipient's Slack incoming webhook,
+// and project task events to the webhooks of project connectors
+type SlackNotifier struct {
+	client *http.Client
+}
+
+// NewSlackNotifier creates a new Slack notifier
+func NewSlackNotifier(timeout time.Duration) *SlackNotifier {
+	return &SlackNotifier{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Channel returns the channel served by this notifier
+func (n *SlackNotifier) Channel() domain.NotificationChannel {
+	return domain.ChannelSlack
+}
+
+// Notify posts the notification to Slack
+func (n *SlackNotifier) Notify(recipient *domain.User, prefs *domain.NotificationPreferences, notification *domain.Notification) error {
+	if prefs.SlackWebhookURL == "" {
+		return errors.New("no Slack webhook URL configured")
+	}
+
+	return postJSON(n.client, prefs.SlackWebhookURL, map[string]string{
+		"text": notification.Body(),
+	})
+}
+
+// Post posts a project's task event to a Slack incoming webhook
+func (n *SlackNotifier) Post(webhookURL string, message *domain.ChatMessage) error {
+	return postJSON(n.client, webhookURL, map[string]string{
+		"text": chatText(message),
+	})
+}
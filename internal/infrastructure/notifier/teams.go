@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"net/http"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// adaptiveCardContentType marks a Teams message attachment as an Adaptive Card
+const adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+// TeamsNotifier posts project task events as Adaptive Cards to Microsoft Teams
+// incoming webhooks, including those of Workflows
+type TeamsNotifier struct {
+	client *http.Client
+}
+
+// NewTeamsNotifier creates a new Microsoft Teams notifier
+func NewTeamsNotifier(timeout time.Duration) *TeamsNotifier {
+	return &TeamsNotifier{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Channel returns the channel served by this notifier
+func (n *TeamsNotifier) Channel() domain.NotificationChannel {
+	return domain.ChannelTeams
+}
+
+// Post posts a project's task event to a Teams incoming webhook
+func (n *TeamsNotifier) Post(webhookURL string, message *domain.ChatMessage) error {
+	facts := []map[string]string{
+		{"title": "Event", "value": string(message.Type)},
+	}
+	if message.TaskKey != "" {
+		facts = append(facts, map[string]string{"title": "Task", "value": message.TaskKey})
+	}
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body": []interface{}{
+			map[string]interface{}{
+				"type":   "TextBlock",
+				"text":   message.Summary,
+				"weight": "Bolder",
+				"wrap":   true,
+			},
+			map[string]interface{}{
+				"type":  "FactSet",
+				"facts": facts,
+			},
+		},
+	}
+
+	return postJSON(n.client, webhookURL, map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": adaptiveCardContentType,
+				"content":     card,
+			},
+		},
+	})
+}
@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// WebhookPayload is the JSON body posted to user-configured webhooks
+type WebhookPayload struct {
+	Type      domain.EventType `json:"type"`
+	Summary   string           `json:"summary"`
+	TaskID    string           `json:"task_id,omitempty"`
+	TaskTitle string           `json:"task_title,omitempty"`
+	Items     []string         `json:"items,omitempty"` // Summaries of the notifications held back during quiet hours, for notification.summary
+	ActorID   string           `json:"actor_id"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// WebhookNotifier posts notifications as JSON to the recipient's webhook URL
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier
+func NewWebhookNotifier(timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Channel returns the channel served by this notifier
+func (n *WebhookNotifier) Channel() domain.NotificationChannel {
+	return domain.ChannelWebhook
+}
+
+// Notify posts the notification to the recipient's webhook
+func (n *WebhookNotifier) Notify(recipient *domain.User, prefs *domain.NotificationPreferences, notification *domain.Notification) error {
+	if prefs.WebhookURL == "" {
+		return errors.New("no webhook URL configured")
+	}
+
+	payload := WebhookPayload{
+		Type:      notification.Type,
+		Summary:   notification.Summary(),
+		TaskTitle: notification.TaskTitle,
+		Items:     notification.Items,
+		ActorID:   notification.ActorID.Hex(),
+		CreatedAt: notification.CreatedAt,
+	}
+	if !notification.TaskID.IsZero() {
+		payload.TaskID = notification.TaskID.Hex()
+	}
+
+	return postJSON(n.client, prefs.WebhookURL, payload)
+}
+
+// postJSON posts a JSON body and treats any non-2xx status as an error
+func postJSON(client *http.Client, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
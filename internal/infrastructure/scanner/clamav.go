@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// clamAVChunkSize is the size of the chunks files are streamed to clamd in
+const clamAVChunkSize = 64 << 10
+
+// ClamAV scans files with a clamd daemon, streaming them with the INSTREAM
+// command. Files larger than clamd's StreamMaxLength are refused by clamd and
+// reported as not clean.
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV creates a scanner using the clamd daemon at a tcp:// or unix:// address
+func NewClamAV(address string, timeout time.Duration) (*ClamAV, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clamd address %q", address)
+	}
+
+	switch u.Scheme {
+	case "tcp":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid clamd address %q", address)
+		}
+		return &ClamAV{network: "tcp", address: u.Host, timeout: timeout}, nil
+	case "unix":
+		if u.Path == "" {
+			return nil, fmt.Errorf("invalid clamd address %q", address)
+		}
+		return &ClamAV{network: "unix", address: u.Path, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("clamd address %q must use tcp:// or unix://", address)
+	}
+}
+
+// Scan streams a file to clamd and returns its verdict
+func (c *ClamAV) Scan(content io.Reader) (*domain.ScanResult, error) {
+	conn, err := net.DialTimeout(c.network, c.address, c.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.timeout))
+
+	// clamd stops reading and replies with an error once the stream is too
+	// large, so a failed write may still be followed by a reply
+	writeErr := c.stream(conn, content)
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		if writeErr != nil {
+			return nil, fmt.Errorf("stream to clamd: %w", writeErr)
+		}
+		return nil, fmt.Errorf("read clamd reply: %w", err)
+	}
+
+	return parseClamAVReply(strings.TrimSuffix(reply, "\x00"))
+}
+
+// stream sends the INSTREAM command and the file, in length-prefixed chunks
+// ending with an empty one
+func (c *ClamAV) stream(conn net.Conn, content io.Reader) error {
+	w := bufio.NewWriterSize(conn, clamAVChunkSize+4)
+	if _, err := w.WriteString("zINSTREAM\x00"); err != nil {
+		return err
+	}
+
+	buf := make([]byte, clamAVChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf)
+		if n > 0 {
+			if err := binary.Write(w, binary.BigEndian, uint32(n)); err != nil {
+				return err
+			}
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read file: %w", err)
+		}
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint32(0)); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// parseClamAVReply turns clamd's reply, such as "stream: OK" or
+// "stream: Eicar-Signature FOUND", into a verdict
+func parseClamAVReply(reply string) (*domain.ScanResult, error) {
+	switch {
+	case strings.HasSuffix(reply, " OK"):
+		return &domain.ScanResult{Clean: true}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		threat := strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND")
+		return &domain.ScanResult{Threat: threat}, nil
+	case strings.Contains(reply, "size limit exceeded"):
+		return &domain.ScanResult{Threat: "too large to scan"}, nil
+	default:
+		return nil, fmt.Errorf("unexpected clamd reply %q", reply)
+	}
+}
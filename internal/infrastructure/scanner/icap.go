@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// icapResponseHeader is the HTTP response the file is wrapped in for RESPMOD
+const icapResponseHeader = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+
+// ICAP scans files with an ICAP antivirus service (RFC 3507), such as c-icap or
+// a commercial gateway. Files are sent as the body of an HTTP response to be
+// modified; the service answers 204 No Content when it leaves them as they are,
+// and replaces them when it blocks them.
+type ICAP struct {
+	url     *url.URL
+	timeout time.Duration
+}
+
+// NewICAP creates a scanner using the RESPMOD service at an icap:// URL
+func NewICAP(serviceURL string, timeout time.Duration) (*ICAP, error) {
+	u, err := url.Parse(serviceURL)
+	if err != nil || u.Scheme != "icap" || u.Host == "" {
+		return nil, fmt.Errorf("invalid ICAP URL %q", serviceURL)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), "1344")
+	}
+
+	return &ICAP{url: u, timeout: timeout}, nil
+}
+
+// Scan sends a file to the ICAP service and returns its verdict
+func (c *ICAP) Scan(content io.Reader) (*domain.ScanResult, error) {
+	conn, err := net.DialTimeout("tcp", c.url.Host, c.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("connect to ICAP service: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.timeout))
+
+	if err := c.send(conn, content); err != nil {
+		return nil, err
+	}
+
+	r := textproto.NewReader(bufio.NewReader(conn))
+	status, err := r.ReadLine()
+	if err != nil {
+		return nil, fmt.Errorf("read ICAP response: %w", err)
+	}
+	header, err := r.ReadMIMEHeader()
+	if err != nil {
+		return nil, fmt.Errorf("read ICAP response: %w", err)
+	}
+
+	code := 0
+	if fields := strings.Fields(status); len(fields) >= 2 && strings.HasPrefix(fields[0], "ICAP/") {
+		code, _ = strconv.Atoi(fields[1])
+	}
+	switch code {
+	case 204:
+		return &domain.ScanResult{Clean: true}, nil
+	case 200:
+		return &domain.ScanResult{Threat: icapThreat(header)}, nil
+	default:
+		return nil, fmt.Errorf("unexpected ICAP response %q", status)
+	}
+}
+
+// send writes a RESPMOD request carrying the file as a chunked response body
+func (c *ICAP) send(conn net.Conn, content io.Reader) error {
+	w := bufio.NewWriter(conn)
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", c.url.String())
+	fmt.Fprintf(w, "Host: %s\r\n", c.url.Host)
+	fmt.Fprintf(w, "Allow: 204\r\n")
+	fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(icapResponseHeader))
+	w.WriteString(icapResponseHeader)
+
+	body := httputil.NewChunkedWriter(w)
+	if _, err := io.Copy(body, content); err != nil {
+		return fmt.Errorf("send file to ICAP service: %w", err)
+	}
+	if err := body.Close(); err != nil {
+		return fmt.Errorf("send file to ICAP service: %w", err)
+	}
+	w.WriteString("\r\n")
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("send file to ICAP service: %w", err)
+	}
+	return nil
+}
+
+// icapThreat names the threat an ICAP service blocked a file for, from the
+// headers services commonly report it in
+func icapThreat(header textproto.MIMEHeader) string {
+	// e.g. "Type=0; Resolution=2; Threat=Eicar-Signature;"
+	if found := header.Get("X-Infection-Found"); found != "" {
+		for _, field := range strings.Split(found, ";") {
+			if name, value, ok := strings.Cut(strings.TrimSpace(field), "="); ok && strings.EqualFold(name, "Threat") {
+				return value
+			}
+		}
+	}
+	if virus := header.Get("X-Virus-ID"); virus != "" {
+		return virus
+	}
+	if violations := header.Get("X-Violations-Found"); violations != "" {
+		return violations
+	}
+	return "blocked by the ICAP service"
+}
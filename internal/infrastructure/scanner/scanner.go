@@ -0,0 +1,29 @@
+// Package scanner provides the malware scanners attachment uploads are checked with
+package scanner
+
+import (
+	"fmt"
+
+	"task-management-system/config"
+	"task-management-system/internal/domain"
+)
+
+// Scanning backends selectable in the configuration
+const (
+	BackendClamAV = "clamav"
+	BackendICAP   = "icap"
+)
+
+// NewFromConfig creates the configured scanner, or returns nil when scanning is disabled
+func NewFromConfig(cfg config.ScanningConfig) (domain.Scanner, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case BackendClamAV:
+		return NewClamAV(cfg.ClamAVAddress, cfg.Timeout)
+	case BackendICAP:
+		return NewICAP(cfg.ICAPURL, cfg.Timeout)
+	default:
+		return nil, fmt.Errorf("unknown scanning backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"task-management-system/internal/logger"
+)
+
+// Job is a unit of periodic background work
+type Job func() error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// Scheduler runs jobs at fixed intervals in background goroutines
+type Scheduler struct {
+	entries []entry
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	log     logger.Logger
+}
+
+// New creates a new scheduler logging job failures to the given logger
+func New(log logger.Logger) *Scheduler {
+	return &Scheduler{
+		stop: make(chan struct{}),
+		log:  log,
+	}
+}
+
+// Every registers a job that runs once at start and then every interval.
+// Jobs must be registered before Start is called. A job with a non-positive
+// interval is disabled.
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	if interval <= 0 {
+		s.log.InfoF("Scheduled job %s disabled: no interval configured", name)
+		return
+	}
+
+	s.entries = append(s.entries, entry{name: name, interval: interval, job: job})
+}
+
+// Start launches all registered jobs
+func (s *Scheduler) Start() {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.run(e)
+	}
+}
+
+// Stop signals all jobs to stop and waits for running ones to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// run executes a job on its interval until the scheduler is stopped
+func (s *Scheduler) run(e entry) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.job(); err != nil {
+			s.log.ErrorF("Scheduled job %s failed: %v", e.name, err)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
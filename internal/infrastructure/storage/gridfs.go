@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"task-management-system/internal/domain"
+)
+
+// gridFSBucket is the GridFS bucket holding the contents, in the blobs.files and blobs.chunks collections
+const gridFSBucket = "blobs"
+
+// GridFSStore stores contents in MongoDB GridFS, with the key as file ID.
+// Transfers are not bounded by the timeout, which applies to the other operations.
+type GridFSStore struct {
+	bucket  *gridfs.Bucket
+	timeout time.Duration
+}
+
+// NewGridFSStore creates a blob store on a GridFS bucket of the database
+func NewGridFSStore(db *mongo.Database, timeout time.Duration) (*GridFSStore, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName(gridFSBucket))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GridFS bucket: %w", err)
+	}
+
+	return &GridFSStore{
+		bucket:  bucket,
+		timeout: timeout,
+	}, nil
+}
+
+// Put stores content under a key, replacing any content stored before
+func (s *GridFSStore) Put(key string, content io.Reader, size int64, contentType string) error {
+	if err := s.Delete(key); err != nil {
+		return err
+	}
+
+	opts := options.GridFSUpload().SetMetadata(bson.M{"content_type": contentType})
+	return s.bucket.UploadFromStreamWithID(key, key, content, opts)
+}
+
+// Open returns the content stored under a key
+func (s *GridFSStore) Open(key string) (io.ReadCloser, error) {
+	stream, err := s.bucket.OpenDownloadStream(key)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Stat describes the content stored under a key
+func (s *GridFSStore) Stat(key string) (*domain.BlobInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var file struct {
+		Length   int64 `bson:"length"`
+		Metadata struct {
+			ContentType string `bson:"content_type"`
+		} `bson:"metadata"`
+	}
+	err := s.bucket.GetFilesCollection().FindOne(ctx, bson.M{"_id": key}).Decode(&file)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &domain.BlobInfo{Size: file.Length, ContentType: file.Metadata.ContentType}, nil
+}
+
+// Delete removes the content stored under a key
+func (s *GridFSStore) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	if err := s.bucket.DeleteContext(ctx, key); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,480 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management-system/config"
+	"task-management-system/internal/domain"
+)
+
+// unsignedPayload is used as payload hash, so contents are streamed without being hashed first
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Store stores contents in an S3 bucket, or a bucket of an S3-compatible store
+// such as MinIO. Requests are signed with AWS Signature Version 4. Transfers are
+// not bounded by the timeout, which applies to the other operations.
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	timeout   time.Duration
+	client    *http.Client
+}
+
+// NewS3Store creates a blob store on an S3 bucket
+func NewS3Store(cfg config.S3Config, timeout time.Duration) (*S3Store, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+
+	u, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+
+	return &S3Store{
+		endpoint:  u,
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKeyID,
+		secretKey: cfg.SecretAccessKey,
+		pathStyle: cfg.PathStyle,
+		timeout:   timeout,
+		client:    &http.Client{},
+	}, nil
+}
+
+// Put stores content of the given size under a key. The size must be known.
+func (s *S3Store) Put(key string, content io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		return fmt.Errorf("s3 uploads require the content size")
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(key).String(), content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// PutStream stores content of unknown size under a key, reading it as it is
+// uploaded. Content larger than a part is sent in a multipart upload, which is
+// aborted if reading or sending a part fails.
+func (s *S3Store) PutStream(key string, content io.Reader, contentType string) error {
+	buf := make([]byte, partSize)
+	n, err := io.ReadFull(content, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// Small enough for a single request
+		return s.Put(key, bytes.NewReader(buf[:n]), int64(n), contentType)
+	}
+	if err != nil {
+		return err
+	}
+
+	uploadID, err := s.createMultipartUpload(key, contentType)
+	if err != nil {
+		return err
+	}
+
+	var parts []completedPart
+	for number := 1; n > 0; number++ {
+		etag, err := s.uploadPart(key, uploadID, number, buf[:n])
+		if err != nil {
+			return s.abortMultipartUpload(key, uploadID, err)
+		}
+		parts = append(parts, completedPart{PartNumber: number, ETag: etag})
+
+		n, err = io.ReadFull(content, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return s.abortMultipartUpload(key, uploadID, err)
+		}
+	}
+
+	if err := s.completeMultipartUpload(key, uploadID, parts); err != nil {
+		return s.abortMultipartUpload(key, uploadID, err)
+	}
+	return nil
+}
+
+// Open returns the content stored under a key
+func (s *S3Store) Open(key string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Stat describes the content stored under a key
+func (s *S3Store) Stat(key string) (*domain.BlobInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return &domain.BlobInfo{Size: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}, nil
+}
+
+// Delete removes the content stored under a key
+func (s *S3Store) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err == domain.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// PresignUpload returns a URL the content can be PUT to. The upload must use the given content type.
+func (s *S3Store) PresignUpload(key string, contentType string, expiry time.Duration) (string, error) {
+	return s.presign(http.MethodPut, key, url.Values{}, http.Header{"Content-Type": {contentType}}, expiry), nil
+}
+
+// PresignDownload returns a URL the content can be downloaded from as a file with the given name
+func (s *S3Store) PresignDownload(key string, filename string, expiry time.Duration) (string, error) {
+	query := url.Values{}
+	if filename != "" {
+		query.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	return s.presign(http.MethodGet, key, query, nil, expiry), nil
+}
+
+// Multipart uploads; see
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/mpuoverview.html
+
+// partSize is the size of the parts of multipart uploads. S3 requires every part
+// but the last to be at least 5 MiB, and allows 10,000 parts.
+const partSize = 16 << 20
+
+// completedPart identifies an uploaded part when completing a multipart upload
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// createMultipartUpload starts a multipart upload to a key and returns its ID
+func (s *S3Store) createMultipartUpload(key string, contentType string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.uploadURL(key, url.Values{"uploads": {""}}), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("s3 multipart upload of %s: %w", key, err)
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("s3 multipart upload of %s: no upload ID", key)
+	}
+	return result.UploadID, nil
+}
+
+// uploadPart sends one part of a multipart upload and returns its ETag
+func (s *S3Store) uploadPart(key string, uploadID string, number int, data []byte) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+	req, err := http.NewRequest(http.MethodPut, s.uploadURL(key, query), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = int64(len(data))
+
+	resp, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// completeMultipartUpload assembles the uploaded parts into the object
+func (s *S3Store) completeMultipartUpload(key string, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.uploadURL(key, url.Values{"uploadId": {uploadID}}), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 may report a failure in the body of a 200 response
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("s3 multipart upload of %s: %w", key, err)
+	}
+	if result.XMLName.Local == "Error" {
+		return fmt.Errorf("s3 multipart upload of %s: %s: %s", key, result.Code, result.Message)
+	}
+	return nil
+}
+
+// abortMultipartUpload discards the parts of a failed multipart upload and returns the failure
+func (s *S3Store) abortMultipartUpload(key string, uploadID string, cause error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.uploadURL(key, url.Values{"uploadId": {uploadID}}), nil)
+	if err == nil {
+		var resp *http.Response
+		if resp, err = s.do(req); err == nil {
+			resp.Body.Close()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w (aborting the upload failed too: %v)", cause, err)
+	}
+	return cause
+}
+
+// uploadURL returns the URL of an object with the query of a multipart upload request
+func (s *S3Store) uploadURL(key string, query url.Values) string {
+	u := s.objectURL(key)
+	u.RawQuery = canonicalQuery(query)
+	return u.String()
+}
+
+// do signs and sends a request, turning error responses into errors. Missing
+// objects are domain.ErrNotFound.
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	now := time.Now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signed := signedHeaders(req.URL.Host, req.Header)
+	signature := s.signature(now, req.Method, req.URL, signed, req.Header)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.accessKey, s.scope(now), strings.Join(signed, ";"), signature))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, domain.ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// presign returns a URL for a request signed in its query, valid for the expiry
+func (s *S3Store) presign(method string, key string, query url.Values, header http.Header, expiry time.Duration) string {
+	now := time.Now().UTC()
+	u := s.objectURL(key)
+	if header == nil {
+		header = http.Header{}
+	}
+
+	signed := signedHeaders(u.Host, header)
+	query.Set("X-Amz-Algorithm", signingAlgorithm)
+	query.Set("X-Amz-Credential", s.accessKey+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry/time.Second)))
+	query.Set("X-Amz-SignedHeaders", strings.Join(signed, ";"))
+	u.RawQuery = canonicalQuery(query)
+
+	signature := s.signature(now, method, u, signed, header)
+	u.RawQuery += "&X-Amz-Signature=" + signature
+	return u.String()
+}
+
+// objectURL returns the URL of an object, addressing the bucket in the path or the host name
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	path := "/" + key
+	if s.pathStyle {
+		path = "/" + s.bucket + path
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = u.Path + path
+	u.RawPath = uriEncode(u.Path, false)
+	return &u
+}
+
+// AWS Signature Version 4 signing; see
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// scope returns the credential scope of a signature made at the given time
+func (s *S3Store) scope(t time.Time) string {
+	return t.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// signature signs a request made at the given time. The payload is not signed.
+func (s *S3Store) signature(t time.Time, method string, u *url.URL, signed []string, header http.Header) string {
+	var canonicalHeaders strings.Builder
+	for _, name := range signed {
+		value := u.Host
+		if name != "host" {
+			value = strings.TrimSpace(header.Get(name))
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		uriEncode(u.Path, false),
+		canonicalQuery(u.Query()),
+		canonicalHeaders.String(),
+		strings.Join(signed, ";"),
+		unsignedPayload,
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		t.Format(amzDateFormat),
+		s.scope(t),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), t.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// signedHeaders returns the lowercase names of the headers to sign: the host and
+// every header set on the request, sorted
+func signedHeaders(host string, header http.Header) []string {
+	signed := []string{"host"}
+	for name := range header {
+		signed = append(signed, strings.ToLower(name))
+	}
+	sort.Strings(signed)
+	return signed
+}
+
+// canonicalQuery encodes query parameters sorted by name, as signatures expect
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var params []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent-encodes every byte except unreserved characters, and slashes
+// unless encodeSlash is set
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
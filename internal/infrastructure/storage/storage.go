@@ -0,0 +1,31 @@
+// Package storage provides the blob stores holding attachment and avatar contents
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"task-management-system/config"
+	"task-management-system/internal/domain"
+)
+
+// Storage backends selectable in the configuration
+const (
+	BackendGridFS = "gridfs"
+	BackendS3     = "s3"
+)
+
+// NewFromConfig creates the configured blob store. GridFS stores contents in the
+// database; S3 stores also implement domain.BlobPresigner.
+func NewFromConfig(cfg config.StorageConfig, db *mongo.Database, timeout time.Duration) (domain.BlobStore, error) {
+	switch cfg.Backend {
+	case BackendGridFS:
+		return NewGridFSStore(db, timeout)
+	case BackendS3:
+		return NewS3Store(cfg.S3, timeout)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
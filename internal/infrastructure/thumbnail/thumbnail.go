@@ -0,0 +1,115 @@
+// Package thumbnail scales attachment images down into thumbnails
+package thumbnail
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime"
+)
+
+// maxPixels bounds the size of decoded images, so a small file declaring huge
+// dimensions cannot exhaust memory
+const maxPixels = 40_000_000
+
+// jpegQuality is the quality JPEG thumbnails are encoded with
+const jpegQuality = 80
+
+// Thumbnailer scales PNG, JPEG and GIF images down so that their longer side is
+// at most the configured size. Only the first frame of animated GIFs is kept.
+// JPEG images get JPEG thumbnails and the others PNG thumbnails.
+type Thumbnailer struct {
+	size int
+}
+
+// New creates a thumbnailer producing thumbnails of at most size pixels on their longer side
+func New(size int) *Thumbnailer {
+	return &Thumbnailer{size: size}
+}
+
+// Supports reports whether images of the media type can be thumbnailed
+func (t *Thumbnailer) Supports(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch mediaType {
+	case "image/png", "image/jpeg", "image/gif":
+		return true
+	default:
+		return false
+	}
+}
+
+// Thumbnail returns a thumbnail of an image and its media type. Images already
+// small enough are re-encoded at their size.
+func (t *Thumbnailer) Thumbnail(r io.Reader) ([]byte, string, error) {
+	// Check the dimensions before decoding the whole image
+	var header bytes.Buffer
+	cfg, format, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return nil, "", fmt.Errorf("image of %dx%d pixels is too large to thumbnail", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image: %w", err)
+	}
+
+	thumb := scale(src, t.size)
+
+	var out bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&out, thumb, &jpeg.Options{Quality: jpegQuality})
+		return out.Bytes(), "image/jpeg", err
+	}
+	err = png.Encode(&out, thumb)
+	return out.Bytes(), "image/png", err
+}
+
+// scale shrinks an image so its longer side is at most size pixels, averaging the
+// source pixels covered by each thumbnail pixel. Images are never enlarged.
+func scale(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	tw, th := w, h
+	if w >= h && w > size {
+		tw, th = size, max(1, h*size/w)
+	} else if h > w && h > size {
+		tw, th = max(1, w*size/h), size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := b.Min.Y+y*h/th, b.Min.Y+(y+1)*h/th
+		for x := 0; x < tw; x++ {
+			x0, x1 := b.Min.X+x*w/tw, b.Min.X+(x+1)*w/tw
+
+			// Colors are alpha-premultiplied, as image.RGBA expects
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(bl / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+
+	return dst
+}
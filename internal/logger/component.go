@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Components of the application that can log at their own level
+const (
+	ComponentHTTP    = "http"
+	ComponentGRPC    = "grpc"
+	ComponentMongoDB = "mongodb"
+	ComponentJobs    = "jobs"
+)
+
+// Components lists the components that can log at their own level
+var Components = []string{ComponentHTTP, ComponentGRPC, ComponentMongoDB, ComponentJobs}
+
+// inheritLevel marks a component without its own level
+const inheritLevel = -1
+
+// componentLevels holds the level of every component, or inheritLevel
+var componentLevels = func() map[string]*atomic.Int32 {
+	levels := make(map[string]*atomic.Int32, len(Components))
+	for _, name := range Components {
+		levels[name] = new(atomic.Int32)
+		levels[name].Store(inheritLevel)
+	}
+	return levels
+}()
+
+// SetComponentLevel sets the minimum level a component logs at, regardless of
+// the level of the logger it writes to
+func SetComponentLevel(component string, level Level) error {
+	l, ok := componentLevels[component]
+	if !ok {
+		return fmt.Errorf("unknown log component %q", component)
+	}
+	l.Store(int32(level))
+	return nil
+}
+
+// ResetComponentLevel makes a component log at the level of the logger it writes to again
+func ResetComponentLevel(component string) error {
+	l, ok := componentLevels[component]
+	if !ok {
+		return fmt.Errorf("unknown log component %q", component)
+	}
+	l.Store(inheritLevel)
+	return nil
+}
+
+// ComponentLevels returns the components that have their own level, with it
+func ComponentLevels() map[string]Level {
+	levels := make(map[string]Level)
+	for name, l := range componentLevels {
+		if level := l.Load(); level != inheritLevel {
+			levels[name] = Level(level)
+		}
+	}
+	return levels
+}
+
+// IsComponent reports whether a name is one of the known components
+func IsComponent(name string) bool {
+	_, ok := componentLevels[name]
+	return ok
+}
+
+// componentLogger logs for one component at the component's level when it has
+// one, and at the level of the logger it writes to otherwise
+type componentLogger struct {
+	base  *leveled
+	level *atomic.Int32
+}
+
+// WithComponent returns a logger writing to log on behalf of a component, so
+// that SetComponentLevel applies to it. Loggers not created by this package,
+// and unknown components, are returned unchanged.
+func WithComponent(log Logger, component string) Logger {
+	level, ok := componentLevels[component]
+	if !ok {
+		return log
+	}
+	base, ok := log.(interface{ core() *leveled })
+	if !ok {
+		return log
+	}
+	return &componentLogger{base: base.core(), level: level}
+}
+
+// core returns the leveled logger that filters and writes entries
+func (l *leveled) core() *leveled {
+	return l
+}
+
+// Level returns the component's level, or the base logger's when it has none
+func (c *componentLogger) Level() Level {
+	if level := c.level.Load(); level != inheritLevel {
+		return Level(level)
+	}
+	return c.base.Level()
+}
+
+// SetLevel sets the component's own level
+func (c *componentLogger) SetLevel(level Level) {
+	c.level.Store(int32(level))
+}
+
+// SetRedactor sets the redactor of the base logger
+func (c *componentLogger) SetRedactor(redactor *Redactor) {
+	c.base.SetRedactor(redactor)
+}
+
+// Redactor returns the redactor of the base logger
+func (c *componentLogger) Redactor() *Redactor {
+	return c.base.Redactor()
+}
+
+// Debug logs a message at debug level
+func (c *componentLogger) Debug(msg string, fields map[string]interface{}) {
+	c.base.logAt(c.Level(), LevelDebug, msg, fields)
+}
+
+// Info logs a message at info level
+func (c *componentLogger) Info(msg string, fields map[string]interface{}) {
+	c.base.logAt(c.Level(), LevelInfo, msg, fields)
+}
+
+// Warn logs a message at warn level
+func (c *componentLogger) Warn(msg string, fields map[string]interface{}) {
+	c.base.logAt(c.Level(), LevelWarn, msg, fields)
+}
+
+// Error logs a message at error level
+func (c *componentLogger) Error(msg string, fields map[string]interface{}) {
+	c.base.logAt(c.Level(), LevelError, msg, fields)
+}
+
+// Fatal logs a message at fatal level and terminates the program
+func (c *componentLogger) Fatal(msg string, fields map[string]interface{}) {
+	c.base.logAt(c.Level(), LevelFatal, msg, fields)
+}
+
+// DebugF logs a debug message with formatted string
+func (c *componentLogger) DebugF(format string, args ...interface{}) {
+	c.base.logAt(c.Level(), LevelDebug, fmt.Sprintf(format, args...), nil)
+}
+
+// InfoF logs an info message with formatted string
+func (c *componentLogger) InfoF(format string, args ...interface{}) {
+	c.base.logAt(c.Level(), LevelInfo, fmt.Sprintf(format, args...), nil)
+}
+
+// WarnF logs a warning message with formatted string
+func (c *componentLogger) WarnF(format string, args ...interface{}) {
+	c.base.logAt(c.Level(), LevelWarn, fmt.Sprintf(format, args...), nil)
+}
+
+// ErrorF logs an error message with formatted string
+func (c *componentLogger) ErrorF(format string, args ...interface{}) {
+	c.base.logAt(c.Level(), LevelError, fmt.Sprintf(format, args...), nil)
+}
+
+// FatalF logs a fatal message with formatted string and terminates the program
+func (c *componentLogger) FatalF(format string, args ...interface{}) {
+	c.base.logAt(c.Level(), LevelFatal, fmt.Sprintf(format, args...), nil)
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"runtime"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,65 +34,118 @@ var levelNames = map[Level]string{
 	LevelFatal: "FATAL",
 }
 
-// Logger represents a simple structured logger
-type Logger struct {
-	level  Level
-	writer io.Writer
+// String returns the level's name, e.g. "INFO"
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
 }
 
-// New creates a new logger instance with the specified minimum level
-func New(level Level) *Logger {
-	return &Logger{
-		level:  level,
-		writer: os.Stdout,
+// ParseLevel parses a level name such as "debug" or "WARN"
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
 	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
 }
 
-// SetWriter sets the writer where logs will be written to
-func (l *Logger) SetWriter(writer io.Writer) {
-	l.writer = writer
+// Logger writes leveled, structured log messages, masking sensitive data with its
+// redactor. Servers and other components built with their dependencies take a
+// Logger; the package-level functions log through the default one.
+type Logger interface {
+	Debug(msg string, fields map[string]interface{})
+	Info(msg string, fields map[string]interface{})
+	Warn(msg string, fields map[string]interface{})
+	Error(msg string, fields map[string]interface{})
+	// Fatal logs a message and terminates the program
+	Fatal(msg string, fields map[string]interface{})
+
+	DebugF(format string, args ...interface{})
+	InfoF(format string, args ...interface{})
+	WarnF(format string, args ...interface{})
+	ErrorF(format string, args ...interface{})
+	// FatalF logs a formatted message and terminates the program
+	FatalF(format string, args ...interface{})
+
+	// Level returns the minimum level logged
+	Level() Level
+	// SetLevel sets the minimum level logged; safe while other goroutines log
+	SetLevel(level Level)
+	// SetRedactor sets the redactor applied to messages and fields; nil disables redaction
+	SetRedactor(redactor *Redactor)
+	// Redactor returns the redactor in use, which is nil when redaction is disabled
+	Redactor() *Redactor
+}
+
+// output writes a log entry that passed the level check, already redacted
+type output interface {
+	write(level Level, msg string, fields map[string]interface{})
+}
+
+// leveled implements Logger on top of an output: it filters messages by level,
+// redacts them, and terminates the program after fatal ones
+type leveled struct {
+	level    atomic.Int32 // Can change while other goroutines log
+	redactor atomic.Pointer[Redactor]
+	out      output
+}
+
+// newLeveled creates a leveled logger writing to out, redacting sensitive data
+// with the built-in rules until another redactor is set
+func newLeveled(level Level, out output) *leveled {
+	l := &leveled{out: out}
+	l.level.Store(int32(level))
+	l.redactor.Store(defaultRedactor)
+	return l
+}
+
+// Level returns the minimum log level
+func (l *leveled) Level() Level {
+	return Level(l.level.Load())
 }
 
 // SetLevel sets the minimum log level
-func (l *Logger) SetLevel(level Level) {
-	l.level = level
+func (l *leveled) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// SetRedactor sets the redactor applied to messages and fields; nil disables redaction
+func (l *leveled) SetRedactor(redactor *Redactor) {
+	l.redactor.Store(redactor)
+}
+
+// Redactor returns the redactor in use
+func (l *leveled) Redactor() *Redactor {
+	return l.redactor.Load()
 }
 
 // log writes a log message with the specified level and fields
-func (l *Logger) log(level Level, msg string, fields map[string]interface{}) {
-	if level < l.level {
-		return
-	}
+func (l *leveled) log(level Level, msg string, fields map[string]interface{}) {
+	l.logAt(l.Level(), level, msg, fields)
+}
 
-	now := time.Now().Format(time.RFC3339)
-	levelName := levelNames[level]
-
-	// Get caller information
-	_, file, line, ok := runtime.Caller(2)
-	caller := "unknown"
-	if ok {
-		// Extract just the file name without the full path
-		for i := len(file) - 1; i > 0; i-- {
-			if file[i] == '/' {
-				file = file[i+1:]
-				break
-			}
-		}
-		caller = fmt.Sprintf("%s:%d", file, line)
+// logAt writes a log message with the specified level and fields if the level
+// is at least the given minimum
+func (l *leveled) logAt(minLevel Level, level Level, msg string, fields map[string]interface{}) {
+	if level < minLevel {
+		return
 	}
 
-	// Format the log message with basic fields
-	logEntry := fmt.Sprintf("[%s] [%s] [%s] %s", now, levelName, caller, msg)
-
-	// Add additional fields if present
+	// Mask sensitive data before it reaches the output
+	redactor := l.redactor.Load()
+	var redacted map[string]interface{}
 	if len(fields) > 0 {
-		logEntry += " "
+		redacted = make(map[string]interface{}, len(fields))
 		for k, v := range fields {
-			logEntry += fmt.Sprintf("%s=%v ", k, v)
+			redacted[k] = redactor.RedactField(k, v)
 		}
 	}
-
-	fmt.Fprintln(l.writer, logEntry)
+	msg = redactor.Redact(msg)
+	l.out.write(level, msg, redacted)
+	report(level, msg, redacted)
 
 	// For fatal logs, terminate the program
 	if level == LevelFatal {
@@ -99,121 +154,219 @@ func (l *Logger) log(level Level, msg string, fields map[string]interface{}) {
 }
 
 // Debug logs a message at debug level
-func (l *Logger) Debug(msg string, fields map[string]interface{}) {
+func (l *leveled) Debug(msg string, fields map[string]interface{}) {
 	l.log(LevelDebug, msg, fields)
 }
 
 // Info logs a message at info level
-func (l *Logger) Info(msg string, fields map[string]interface{}) {
+func (l *leveled) Info(msg string, fields map[string]interface{}) {
 	l.log(LevelInfo, msg, fields)
 }
 
 // Warn logs a message at warn level
-func (l *Logger) Warn(msg string, fields map[string]interface{}) {
+func (l *leveled) Warn(msg string, fields map[string]interface{}) {
 	l.log(LevelWarn, msg, fields)
 }
 
 // Error logs a message at error level
-func (l *Logger) Error(msg string, fields map[string]interface{}) {
+func (l *leveled) Error(msg string, fields map[string]interface{}) {
 	l.log(LevelError, msg, fields)
 }
 
 // Fatal logs a message at fatal level and terminates the program
-func (l *Logger) Fatal(msg string, fields map[string]interface{}) {
+func (l *leveled) Fatal(msg string, fields map[string]interface{}) {
 	l.log(LevelFatal, msg, fields)
 }
 
 // DebugF logs a debug message with formatted string
-func (l *Logger) DebugF(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	l.Debug(msg, nil)
+func (l *leveled) DebugF(format string, args ...interface{}) {
+	l.log(LevelDebug, fmt.Sprintf(format, args...), nil)
 }
 
 // InfoF logs an info message with formatted string
-func (l *Logger) InfoF(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	l.Info(msg, nil)
+func (l *leveled) InfoF(format string, args ...interface{}) {
+	l.log(LevelInfo, fmt.Sprintf(format, args...), nil)
 }
 
 // WarnF logs a warning message with formatted string
-func (l *Logger) WarnF(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	l.Warn(msg, nil)
+func (l *leveled) WarnF(format string, args ...interface{}) {
+	l.log(LevelWarn, fmt.Sprintf(format, args...), nil)
 }
 
 // ErrorF logs an error message with formatted string
-func (l *Logger) ErrorF(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	l.Error(msg, nil)
+func (l *leveled) ErrorF(format string, args ...interface{}) {
+	l.log(LevelError, fmt.Sprintf(format, args...), nil)
 }
 
 // FatalF logs a fatal message with formatted string and terminates the program
-func (l *Logger) FatalF(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	l.Fatal(msg, nil)
+func (l *leveled) FatalF(format string, args ...interface{}) {
+	l.log(LevelFatal, fmt.Sprintf(format, args...), nil)
 }
 
-// Global default logger instance
-var defaultLogger = New(LevelInfo)
+// TextLogger writes log messages as plain text lines, the built-in format
+type TextLogger struct {
+	*leveled
+	writer atomic.Pointer[io.Writer]
+}
+
+// New creates a text logger writing to standard output with the specified
+// minimum level. It redacts sensitive data with the built-in rules until
+// another redactor is set.
+func New(level Level) *TextLogger {
+	return NewText(os.Stdout, level)
+}
+
+// NewText creates a text logger writing to the given writer
+func NewText(writer io.Writer, level Level) *TextLogger {
+	l := &TextLogger{}
+	l.leveled = newLeveled(level, l)
+	l.SetWriter(writer)
+	return l
+}
+
+// SetWriter sets the writer where logs will be written to
+func (l *TextLogger) SetWriter(writer io.Writer) {
+	l.writer.Store(&writer)
+}
+
+// write formats a log entry as a single line
+func (l *TextLogger) write(level Level, msg string, fields map[string]interface{}) {
+	now := time.Now().Format(time.RFC3339)
+
+	logEntry := fmt.Sprintf("[%s] [%s] [%s] %s", now, level, caller(), msg)
+
+	// Add additional fields if present
+	if len(fields) > 0 {
+		logEntry += " "
+		for k, v := range fields {
+			logEntry += fmt.Sprintf("%s=%v ", k, v)
+		}
+	}
+
+	fmt.Fprintln(*l.writer.Load(), logEntry)
+}
+
+// caller returns the file and line of the code that logged
+func caller() string {
+	frame, ok := callerFrame()
+	if !ok {
+		return "unknown"
+	}
+	file := frame.File
+	if i := strings.LastIndex(file, "/"); i >= 0 {
+		file = file[i+1:]
+	}
+	return fmt.Sprintf("%s:%d", file, frame.Line)
+}
+
+// callerFrame returns the stack frame of the code that logged, i.e. the first
+// one outside this package
+func callerFrame() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "/internal/logger.") {
+			return frame, true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// defaultRedactor applies the built-in redaction rules
+var defaultRedactor, _ = NewRedactor(nil)
+
+// defaultLogger is the logger used by the package-level functions
+var defaultLogger atomic.Pointer[Logger]
+
+func init() {
+	SetDefault(New(LevelInfo))
+}
+
+// Default returns the default logger
+func Default() Logger {
+	return *defaultLogger.Load()
+}
+
+// SetDefault replaces the default logger. The new logger keeps its own level
+// and redactor.
+func SetDefault(l Logger) {
+	defaultLogger.Store(&l)
+}
 
 // SetDefaultLevel sets the log level for the default logger
 func SetDefaultLevel(level Level) {
-	defaultLogger.SetLevel(level)
+	Default().SetLevel(level)
+}
+
+// SetDefaultRedactor sets the redactor for the default logger; nil disables redaction
+func SetDefaultRedactor(redactor *Redactor) {
+	Default().SetRedactor(redactor)
+}
+
+// DefaultRedactor returns the redactor of the default logger, which is nil when redaction is disabled
+func DefaultRedactor() *Redactor {
+	return Default().Redactor()
 }
 
-// SetDefaultWriter sets the writer for the default logger
+// SetDefaultWriter sets the writer for the default logger, if it writes text
 func SetDefaultWriter(writer io.Writer) {
-	defaultLogger.SetWriter(writer)
+	if text, ok := Default().(*TextLogger); ok {
+		text.SetWriter(writer)
+	}
 }
 
 // Global logging functions
 
 // DebugF logs a formatted debug message using the default logger
 func DebugF(format string, args ...interface{}) {
-	defaultLogger.DebugF(format, args...)
+	Default().DebugF(format, args...)
 }
 
 // InfoF logs a formatted info message using the default logger
 func InfoF(format string, args ...interface{}) {
-	defaultLogger.InfoF(format, args...)
+	Default().InfoF(format, args...)
 }
 
 // WarnF logs a formatted warning message using the default logger
 func WarnF(format string, args ...interface{}) {
-	defaultLogger.WarnF(format, args...)
+	Default().WarnF(format, args...)
 }
 
 // ErrorF logs a formatted error message using the default logger
 func ErrorF(format string, args ...interface{}) {
-	defaultLogger.ErrorF(format, args...)
+	Default().ErrorF(format, args...)
 }
 
 // FatalF logs a formatted fatal message using the default logger and terminates the program
 func FatalF(format string, args ...interface{}) {
-	defaultLogger.FatalF(format, args...)
+	Default().FatalF(format, args...)
 }
 
 // Debug logs a message at debug level using the default logger
 func Debug(msg string, fields map[string]interface{}) {
-	defaultLogger.Debug(msg, fields)
+	Default().Debug(msg, fields)
 }
 
 // Info logs a message at info level using the default logger
 func Info(msg string, fields map[string]interface{}) {
-	defaultLogger.Info(msg, fields)
+	Default().Info(msg, fields)
 }
 
 // Warn logs a message at warn level using the default logger
 func Warn(msg string, fields map[string]interface{}) {
-	defaultLogger.Warn(msg, fields)
+	Default().Warn(msg, fields)
 }
 
 // Error logs a message at error level using the default logger
 func Error(msg string, fields map[string]interface{}) {
-	defaultLogger.Error(msg, fields)
+	Default().Error(msg, fields)
 }
 
 // Fatal logs a message at fatal level using the default logger and terminates the program
 func Fatal(msg string, fields map[string]interface{}) {
-	defaultLogger.Fatal(msg, fields)
+	Default().Fatal(msg, fields)
 }
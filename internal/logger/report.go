@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// reportFlushTimeout bounds how long a fatal log waits for pending reports
+const reportFlushTimeout = 5 * time.Second
+
+// Report is an error-level log entry sent to an error tracking service
+type Report struct {
+	Level   Level
+	Message string
+	// Fields are the entry's fields; panics recovered by the servers carry "panic" and "stack"
+	Fields map[string]interface{}
+	// Caller is the file and line of the code that logged
+	Caller string
+	Time   time.Time
+}
+
+// Reporter sends error-level log entries to an error tracking service such as
+// Sentry. Report must not block; Flush waits for the reports sent so far.
+type Reporter interface {
+	Report(report *Report)
+	Flush(timeout time.Duration)
+}
+
+// reporter receives the error-level entries of every logger, if set
+var reporter atomic.Pointer[Reporter]
+
+// SetReporter sends the error and fatal entries of every logger, after
+// redaction, to the given reporter; nil stops reporting
+func SetReporter(r Reporter) {
+	if r == nil {
+		reporter.Store(nil)
+		return
+	}
+	reporter.Store(&r)
+}
+
+// report hands an entry to the reporter, if any
+func report(level Level, msg string, fields map[string]interface{}) {
+	r := reporter.Load()
+	if r == nil || level < LevelError {
+		return
+	}
+
+	(*r).Report(&Report{
+		Level:   level,
+		Message: msg,
+		Fields:  fields,
+		Caller:  caller(),
+		Time:    time.Now(),
+	})
+
+	// The program is about to exit, so give the report a chance to be sent
+	if level == LevelFatal {
+		(*r).Flush(reportFlushTimeout)
+	}
+}
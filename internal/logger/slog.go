@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// SlogLogger logs through a log/slog handler, so that entries can be written as
+// JSON or handed to any slog backend
+type SlogLogger struct {
+	*leveled
+	handler slog.Handler
+}
+
+// NewSlog creates a logger writing through the given slog handler. Levels are
+// filtered before the handler sees an entry; the handler's own level should
+// allow debug messages so that SetLevel can lower the level later.
+func NewSlog(handler slog.Handler, level Level) *SlogLogger {
+	l := &SlogLogger{handler: handler}
+	l.leveled = newLeveled(level, l)
+	return l
+}
+
+// NewSlogText creates a slog logger writing text, or JSON when json is set, to the given writer
+func NewSlogText(writer io.Writer, level Level, json bool) *SlogLogger {
+	options := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if json {
+		return NewSlog(slog.NewJSONHandler(writer, options), level)
+	}
+	return NewSlog(slog.NewTextHandler(writer, options), level)
+}
+
+// slogLevels maps levels to slog's; slog has no fatal level, so fatal
+// messages are logged above errors
+var slogLevels = map[Level]slog.Level{
+	LevelDebug: slog.LevelDebug,
+	LevelInfo:  slog.LevelInfo,
+	LevelWarn:  slog.LevelWarn,
+	LevelError: slog.LevelError,
+	LevelFatal: slog.LevelError + 4,
+}
+
+// write hands a log entry to the slog handler. The entry's source is added as
+// an attribute, since the handler cannot skip this package's frames itself.
+func (l *SlogLogger) write(level Level, msg string, fields map[string]interface{}) {
+	record := slog.NewRecord(time.Now(), slogLevels[level], msg, 0)
+	record.AddAttrs(slog.String(slog.SourceKey, caller()))
+	for k, v := range fields {
+		record.AddAttrs(slog.Any(k, v))
+	}
+	_ = l.handler.Handle(context.Background(), record)
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"io"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ZapLogger logs through a zap core
+type ZapLogger struct {
+	*leveled
+	zap *zap.Logger
+}
+
+// NewZap creates a logger writing through the given zap logger. Levels are
+// filtered before zap sees an entry; the zap logger's own level should allow
+// debug messages so that SetLevel can lower the level later.
+func NewZap(z *zap.Logger, level Level) *ZapLogger {
+	// The leveled logger terminates the program after fatal messages, so zap
+	// must only write them
+	l := &ZapLogger{zap: z.WithOptions(zap.WithFatalHook(zapcore.WriteThenNoop))}
+	l.leveled = newLeveled(level, l)
+	return l
+}
+
+// NewZapWriter creates a zap logger writing console text, or JSON when json is set, to the given writer
+func NewZapWriter(writer io.Writer, level Level, json bool) *ZapLogger {
+	config := zap.NewProductionEncoderConfig()
+	config.EncodeTime = zapcore.RFC3339TimeEncoder
+	encoder := zapcore.NewConsoleEncoder(config)
+	if json {
+		encoder = zapcore.NewJSONEncoder(config)
+	}
+	core := zapcore.NewCore(encoder, zapcore.AddSync(writer), zapcore.DebugLevel)
+	return NewZap(zap.New(core, zap.AddCaller()), level)
+}
+
+// zapLevels maps levels to zap's
+var zapLevels = map[Level]zapcore.Level{
+	LevelDebug: zapcore.DebugLevel,
+	LevelInfo:  zapcore.InfoLevel,
+	LevelWarn:  zapcore.WarnLevel,
+	LevelError: zapcore.ErrorLevel,
+	LevelFatal: zapcore.FatalLevel,
+}
+
+// write hands a log entry to zap, attributed to the code that logged
+func (l *ZapLogger) write(level Level, msg string, fields map[string]interface{}) {
+	entry := l.zap.Check(zapLevels[level], msg)
+	if entry == nil {
+		return
+	}
+	if frame, ok := callerFrame(); ok {
+		entry.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+	}
+
+	zapFields := make([]zap.Field, 0, len(fields))
+	for k, v := range fields {
+		zapFields = append(zapFields, zap.Any(k, v))
+	}
+	entry.Write(zapFields...)
+}
@@ -0,0 +1,465 @@
+// Package markdown renders the Markdown of task descriptions to safe HTML.
+//
+// It supports the commonly used subset of CommonMark: paragraphs, ATX headings,
+// emphasis, strikethrough, code spans and blocks, block quotes, lists, horizontal
+// rules, links and images. Output is safe by construction: all text is escaped,
+// raw HTML is never passed through, and links and images only keep http, https,
+// mailto (links only) and relative URLs.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	rulePattern       = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	listItemPattern   = regexp.MustCompile(`^ {0,3}([-*+]|\d{1,9}[.)])[ \t]+(.*)$`)
+	fencePattern      = regexp.MustCompile("^ {0,3}(```+|~~~+)[ \\t]*([^`\\s]*)")
+	entityPattern     = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	languageCharacter = regexp.MustCompile(`[^A-Za-z0-9_+-]`)
+)
+
+// Render converts Markdown to HTML
+func Render(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(src, "\n"))
+	return b.String()
+}
+
+// renderBlocks renders a sequence of lines as block elements
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case fencePattern.MatchString(line):
+			i = renderFence(b, lines, i)
+
+		case strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t"):
+			i = renderIndentedCode(b, lines, i)
+
+		case headingPattern.MatchString(strings.TrimLeft(line, " ")):
+			m := headingPattern.FindStringSubmatch(strings.TrimLeft(line, " "))
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+
+		case rulePattern.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(strings.TrimLeft(line, " "), ">"):
+			i = renderQuote(b, lines, i)
+
+		case listItemPattern.MatchString(line):
+			i = renderList(b, lines, i)
+
+		default:
+			i = renderParagraph(b, lines, i)
+		}
+	}
+}
+
+// renderFence renders a fenced code block starting at line i and returns the line after it
+func renderFence(b *strings.Builder, lines []string, i int) int {
+	m := fencePattern.FindStringSubmatch(lines[i])
+	fence := m[1]
+	language := languageCharacter.ReplaceAllString(m[2], "")
+
+	var code []string
+	i++
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimLeft(lines[i], " "), fence) {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+
+	b.WriteString("<pre><code")
+	if language != "" {
+		b.WriteString(` class="language-` + language + `"`)
+	}
+	b.WriteString(">")
+	writeCode(b, code)
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// renderIndentedCode renders a code block indented by four spaces or a tab
+func renderIndentedCode(b *strings.Builder, lines []string, i int) int {
+	var code []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "    "):
+			code = append(code, line[4:])
+		case strings.HasPrefix(line, "\t"):
+			code = append(code, line[1:])
+		case strings.TrimSpace(line) == "":
+			code = append(code, "")
+		default:
+			return finishIndentedCode(b, code, i)
+		}
+	}
+	return finishIndentedCode(b, code, i)
+}
+
+// finishIndentedCode writes an indented code block without its trailing blank lines
+func finishIndentedCode(b *strings.Builder, code []string, i int) int {
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	b.WriteString("<pre><code>")
+	writeCode(b, code)
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// writeCode writes escaped code lines
+func writeCode(b *strings.Builder, code []string) {
+	for _, line := range code {
+		b.WriteString(html.EscapeString(line))
+		b.WriteString("\n")
+	}
+}
+
+// renderQuote renders a block quote starting at line i
+func renderQuote(b *strings.Builder, lines []string, i int) int {
+	var quoted []string
+	for ; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " ")
+		if !strings.HasPrefix(line, ">") {
+			break
+		}
+		line = strings.TrimPrefix(line, ">")
+		quoted = append(quoted, strings.TrimPrefix(line, " "))
+	}
+
+	b.WriteString("<blockquote>\n")
+	renderBlocks(b, quoted)
+	b.WriteString("</blockquote>\n")
+	return i
+}
+
+// renderList renders the consecutive items of a bullet or ordered list starting at line i.
+// Indented lines continue the previous item.
+func renderList(b *strings.Builder, lines []string, i int) int {
+	first := listItemPattern.FindStringSubmatch(lines[i])
+	ordered := first[1][0] >= '0' && first[1][0] <= '9'
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	b.WriteString("<" + tag + ">\n")
+
+	var item []string
+	flush := func() {
+		if item != nil {
+			b.WriteString("<li>" + renderLines(item) + "</li>\n")
+		}
+	}
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if m := listItemPattern.FindStringSubmatch(line); m != nil && !rulePattern.MatchString(line) {
+			if (m[1][0] >= '0' && m[1][0] <= '9') != ordered {
+				break
+			}
+			flush()
+			item = []string{m[2]}
+			continue
+		}
+		if strings.TrimSpace(line) == "" || !(strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			break
+		}
+		item = append(item, strings.TrimSpace(line))
+	}
+	flush()
+
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// renderParagraph renders a paragraph starting at line i, ending at a blank line or another block
+func renderParagraph(b *strings.Builder, lines []string, i int) int {
+	var paragraph []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if len(paragraph) > 0 && startsBlock(line) {
+			break
+		}
+		paragraph = append(paragraph, strings.TrimLeft(line, " \t"))
+	}
+
+	b.WriteString("<p>" + renderLines(paragraph) + "</p>\n")
+	return i
+}
+
+// startsBlock reports whether a line ends a paragraph
+func startsBlock(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return strings.TrimSpace(line) == "" ||
+		fencePattern.MatchString(line) ||
+		headingPattern.MatchString(trimmed) ||
+		rulePattern.MatchString(line) ||
+		strings.HasPrefix(trimmed, ">") ||
+		listItemPattern.MatchString(line)
+}
+
+// renderLines renders the lines of a paragraph or list item. Lines ending in two
+// spaces or a backslash end with a line break.
+func renderLines(lines []string) string {
+	var b strings.Builder
+	for n, line := range lines {
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\")
+		line = strings.TrimRight(line, " \t")
+		if hardBreak {
+			line = strings.TrimSuffix(line, "\\")
+		}
+
+		b.WriteString(renderInline(line))
+		if n < len(lines)-1 {
+			if hardBreak {
+				b.WriteString("<br>")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// renderInline renders the inline elements of a line
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		rest := s[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_{}[]()#+-.!~>|", s[i+1]) >= 0:
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if code, n, ok := codeSpan(rest); ok {
+				b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i += n
+				continue
+			}
+
+		case c == '!' && strings.HasPrefix(rest, "!["):
+			if text, url, title, n, ok := link(rest[1:]); ok {
+				if src, ok := safeURL(url, false); ok {
+					b.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(plainText(text)) + `"`)
+					if title != "" {
+						b.WriteString(` title="` + html.EscapeString(title) + `"`)
+					}
+					b.WriteString(">")
+				} else {
+					b.WriteString(html.EscapeString(plainText(text)))
+				}
+				i += n + 1
+				continue
+			}
+
+		case c == '[':
+			if text, url, title, n, ok := link(rest); ok {
+				if href, ok := safeURL(url, true); ok {
+					b.WriteString(`<a href="` + html.EscapeString(href) + `"`)
+					if title != "" {
+						b.WriteString(` title="` + html.EscapeString(title) + `"`)
+					}
+					b.WriteString(` rel="nofollow noopener noreferrer">` + renderInline(text) + "</a>")
+				} else {
+					b.WriteString(renderInline(text))
+				}
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n, ok := delimited(rest, rest[:2]); ok {
+				b.WriteString("<strong>" + renderInline(inner) + "</strong>")
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if inner, n, ok := delimited(rest, "~~"); ok {
+				b.WriteString("<del>" + renderInline(inner) + "</del>")
+				i += n
+				continue
+			}
+
+		case c == '*' || (c == '_' && (i == 0 || !isWordByte(s[i-1]))):
+			if inner, n, ok := delimited(rest, rest[:1]); ok && (c == '*' || i+n == len(s) || !isWordByte(s[i+n])) {
+				b.WriteString("<em>" + renderInline(inner) + "</em>")
+				i += n
+				continue
+			}
+
+		case c == '&':
+			// Keep entity references, which are text, and escape other ampersands
+			if entity := entityPattern.FindString(rest); entity != "" {
+				b.WriteString(entity)
+				i += len(entity)
+				continue
+			}
+		}
+
+		b.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+// codeSpan parses a code span at the start of s and returns its content and length
+func codeSpan(s string) (string, int, bool) {
+	ticks := len(s) - len(strings.TrimLeft(s, "`"))
+	fence := s[:ticks]
+
+	for start := ticks; start < len(s); {
+		end := strings.Index(s[start:], fence)
+		if end < 0 {
+			return "", 0, false
+		}
+		end += start
+
+		// The closing run must be exactly as long as the opening run
+		after := end + ticks
+		if after < len(s) && s[after] == '`' {
+			start = after + len(s[after:]) - len(strings.TrimLeft(s[after:], "`"))
+			continue
+		}
+
+		code := s[ticks:end]
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+			code = code[1 : len(code)-1]
+		}
+		return code, after, true
+	}
+	return "", 0, false
+}
+
+// delimited parses text enclosed in a delimiter at the start of s, such as **bold**
+func delimited(s string, delimiter string) (string, int, bool) {
+	end := strings.Index(s[len(delimiter):], delimiter)
+	if end <= 0 {
+		return "", 0, false
+	}
+
+	inner := s[len(delimiter) : len(delimiter)+end]
+	if strings.TrimSpace(inner) != inner {
+		return "", 0, false
+	}
+	return inner, len(delimiter) + end + len(delimiter), true
+}
+
+// link parses a link such as [text](url "title") at the start of s
+func link(s string) (text string, url string, title string, n int, ok bool) {
+	// Find the closing bracket, allowing nested brackets
+	depth := 0
+	closing := -1
+	for i := 0; i < len(s) && closing < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closing = i
+			}
+		}
+	}
+	if closing < 0 || closing+1 >= len(s) || s[closing+1] != '(' {
+		return "", "", "", 0, false
+	}
+
+	// Find the closing parenthesis, allowing balanced parentheses in the URL
+	depth = 0
+	end := -1
+	for i := closing + 1; i < len(s) && end < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return "", "", "", 0, false
+	}
+
+	dest := strings.TrimSpace(s[closing+2 : end])
+	if strings.HasPrefix(dest, "<") {
+		if close := strings.IndexByte(dest, '>'); close > 0 {
+			url, title = dest[1:close], strings.TrimSpace(dest[close+1:])
+		}
+	} else if space := strings.IndexAny(dest, " \t"); space >= 0 {
+		url, title = dest[:space], strings.TrimSpace(dest[space:])
+	} else {
+		url = dest
+	}
+	if len(title) >= 2 && (title[0] == '"' || title[0] == '\'') && title[len(title)-1] == title[0] {
+		title = title[1 : len(title)-1]
+	} else {
+		title = ""
+	}
+
+	return s[1:closing], url, title, end + 1, true
+}
+
+// safeURL returns the URL when its scheme is allowed: http, https and relative
+// URLs, and mailto for links
+func safeURL(url string, allowMailto bool) (string, bool) {
+	url = strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(url))
+	url = html.UnescapeString(url)
+
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 || strings.ContainsAny(url[:colon], "/?#") {
+		return url, true
+	}
+
+	switch strings.ToLower(strings.TrimSpace(url[:colon])) {
+	case "http", "https":
+		return url, true
+	case "mailto":
+		return url, allowMailto
+	default:
+		return "", false
+	}
+}
+
+// plainText strips Markdown formatting characters from link text, for alt attributes
+func plainText(s string) string {
+	return strings.NewReplacer("*", "", "_", "", "`", "", "~", "").Replace(s)
+}
+
+// isWordByte reports whether a byte is an ASCII letter or digit
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AgingPolicy is the rule deciding which open tasks have their priority raised.
+// Zero periods are off.
+type AgingPolicy struct {
+	// IdleAfter is how long a task may go without changes before its priority is raised
+	IdleAfter time.Duration
+	// PendingAfter is how long a task may stay pending before its priority is
+	// raised, and then again after each further such period
+	PendingAfter time.Duration
+	// MaxPriority is the highest priority aging raises tasks to
+	MaxPriority int
+}
+
+// AgingUseCase raises the priority of open tasks that were left idle or pending
+// for long, so that old tasks don't rot at a low priority
+type AgingUseCase struct {
+	tasks  *TaskUseCase
+	policy AgingPolicy
+}
+
+// NewAgingUseCase creates a new aging use case. Tasks are written through the
+// task use case, so that their events go through its outbox.
+func NewAgingUseCase(tasks *TaskUseCase, policy AgingPolicy) *AgingUseCase {
+	return &AgingUseCase{
+		tasks:  tasks,
+		policy: policy,
+	}
+}
+
+// AgeTasks raises by one the priority of up to limit open tasks below the
+// policy's maximum that nobody changed for the idle period, or that stayed
+// pending for the pending period since they were created or last aged. The
+// priority the task had before aging first raised it is kept, and a
+// task.updated event is emitted for each task. It returns the number of tasks
+// aged.
+func (uc *AgingUseCase) AgeTasks(now time.Time, limit int64) (int, error) {
+	var rules []interface{}
+	if uc.policy.IdleAfter > 0 {
+		// Aging updates the task, so an idle task is raised again after another idle period
+		rules = append(rules, map[string]interface{}{
+			"updated_at": map[string]interface{}{"$lt": now.Add(-uc.policy.IdleAfter)},
+		})
+	}
+	if uc.policy.PendingAfter > 0 {
+		cutoff := now.Add(-uc.policy.PendingAfter)
+		rules = append(rules, map[string]interface{}{
+			"status":     domain.TaskStatusPending,
+			"created_at": map[string]interface{}{"$lt": cutoff},
+			"aged_at":    map[string]interface{}{"$not": map[string]interface{}{"$gte": cutoff}},
+		})
+	}
+	if len(rules) == 0 {
+		return 0, nil
+	}
+
+	tasks, err := uc.tasks.taskRepo.FindAll(map[string]interface{}{
+		"status":   map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+		"priority": map[string]interface{}{"$lt": uc.policy.MaxPriority},
+		"$or":      rules,
+	}, domain.Page(primitive.NilObjectID, limit))
+	if err != nil {
+		return 0, err
+	}
+
+	aged := 0
+	for _, task := range tasks {
+		err := uc.tasks.save(task.OrgID, func(repo domain.TaskRepository) error {
+			raised, err := repo.RaisePriority(task.ID, uc.policy.MaxPriority, now)
+			if err != nil {
+				return err
+			}
+			*task = *raised
+			return nil
+		}, taskEvent(domain.EventTaskUpdated, primitive.NilObjectID, primitive.NilObjectID, task))
+		// Completed, deleted or raised to the maximum since it was loaded
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return aged, err
+		}
+		aged++
+	}
+
+	return aged, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// requiresAcceptance reports whether assignments to the task await the
+// assignee's answer, which is up to the task's project
+func (uc *TaskUseCase) requiresAcceptance(task *domain.Task) (bool, error) {
+	if task.ProjectID.IsZero() {
+		return false, nil
+	}
+
+	project, err := uc.policy.projectRepo.FindByID(task.ProjectID)
+	if err != nil {
+		return false, err
+	}
+	return project.RequireAcceptance, nil
+}
+
+// AcceptAssignment accepts the user's pending assignment to a task. A task that
+// was not started yet moves to in progress, within the project's WIP limits.
+func (uc *TaskUseCase) AcceptAssignment(orgID string, taskID string, userID string) (*domain.Task, error) {
+	org, user, task, pending, err := uc.pendingAssignment(orgID, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.RemovePendingAssignment(user.ID)
+	if task.Status == domain.TaskStatusPending {
+		if err := uc.checkWIP(task, task.AssignedTo, true); err != nil {
+			return nil, err
+		}
+		task.Status = domain.TaskStatusInProgress
+	}
+
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		return repo.Update(task)
+	}, taskEvent(domain.EventTaskAssignmentAccepted, user.ID, pending.AssignedBy, task))
+	if err != nil {
+		return nil, err
+	}
+
+	uc.enricher.enrich(task)
+
+	return task, nil
+}
+
+// DeclineAssignment declines the user's pending assignment to a task. The user
+// is removed from the task and the assignees it had before the assignment are
+// restored, so a task handed over to the user returns to whoever had it. Former
+// assignees who were deleted or deactivated since are not restored.
+func (uc *TaskUseCase) DeclineAssignment(orgID string, taskID string, userID string) (*domain.Task, error) {
+	org, user, task, pending, err := uc.pendingAssignment(orgID, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.RemovePendingAssignment(user.ID)
+	task.RemoveAssignee(user.ID)
+	for _, previous := range pending.PreviousAssignees {
+		if task.IsAssignedTo(previous) {
+			continue
+		}
+		assignee, err := uc.userRepo.FindByID(previous)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if assignee.OrgID == org && !assignee.IsDeactivated() {
+			task.AddAssignee(previous)
+		}
+	}
+
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		return repo.Update(task)
+	}, taskEvent(domain.EventTaskAssignmentDeclined, user.ID, pending.AssignedBy, task))
+	if err != nil {
+		return nil, err
+	}
+
+	uc.enricher.enrich(task)
+
+	return task, nil
+}
+
+// pendingAssignment loads the acting user, a task they can see, and their
+// pending assignment to it
+func (uc *TaskUseCase) pendingAssignment(orgID string, taskID string, userID string) (primitive.ObjectID, *domain.User, *domain.Task, domain.PendingAssignment, error) {
+	var none domain.PendingAssignment
+
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return org, nil, nil, none, err
+	}
+
+	taskObjID, userObjID, err := parseStarIDs(taskID, userID)
+	if err != nil {
+		return org, nil, nil, none, err
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return org, nil, nil, none, err
+	}
+
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskObjID)
+	if err != nil {
+		return org, nil, nil, none, err
+	}
+	if err := uc.policy.Can(user, ActionRead, task); err != nil {
+		return org, nil, nil, none, err
+	}
+
+	pending := task.PendingAssignmentOf(user.ID)
+	if pending == nil {
+		return org, nil, nil, none, fmt.Errorf("%w: no assignment of this task awaits your answer", domain.ErrNotFound)
+	}
+
+	return org, user, task, *pending, nil
+}
+
+// ListAssignmentRequests retrieves the tasks of the organization whose
+// assignment to the user awaits their answer
+func (uc *TaskUseCase) ListAssignmentRequests(orgID string, userID string) ([]*domain.Task, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(org).FindAll(map[string]interface{}{
+		"pending_assignments.user_id": user.ID,
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+
+	uc.flagUnread(user.ID, tasks)
+	uc.enricher.enrich(tasks...)
+
+	return tasks, nil
+}
@@ -0,0 +1,547 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxAttachmentNameLength is the maximum length of attachment file names, in characters
+const maxAttachmentNameLength = 255
+
+// UploadLimits bounds the files users upload
+type UploadLimits struct {
+	MaxSize   int64         // Bytes
+	URLExpiry time.Duration // How long presigned upload and download URLs are valid
+}
+
+// AttachmentUseCase handles business logic related to task attachments.
+//
+// Attachments are uploaded in two steps. Creating an attachment records it as
+// pending and, when the blob store supports it, returns a presigned URL the client
+// uploads the content to directly. Completing the attachment then checks the
+// uploaded content and marks it ready. Without presigned URLs, the content is
+// uploaded through the API, which completes the attachment at once.
+//
+// Ready images the thumbnailer supports get a pending thumbnail, generated in the
+// background by GenerateThumbnails. With a scanner, ready attachments cannot be
+// downloaded until ScanAttachments has found them clean.
+type AttachmentUseCase struct {
+	attachmentRepo domain.AttachmentRepository
+	taskRepo       domain.TaskRepository
+	store          domain.BlobStore
+	thumbnailer    domain.Thumbnailer
+	scanner        domain.Scanner
+	events         domain.EventPublisher
+	policy         *Policy
+	limits         UploadLimits
+	log            logger.Logger
+}
+
+// NewAttachmentUseCase creates a new attachment use case. A nil thumbnailer
+// disables thumbnails, and a nil scanner malware scanning.
+func NewAttachmentUseCase(
+	attachmentRepo domain.AttachmentRepository,
+	taskRepo domain.TaskRepository,
+	store domain.BlobStore,
+	thumbnailer domain.Thumbnailer,
+	scanner domain.Scanner,
+	events domain.EventPublisher,
+	policy *Policy,
+	limits UploadLimits,
+	log logger.Logger,
+) *AttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		store:          store,
+		thumbnailer:    thumbnailer,
+		scanner:        scanner,
+		events:         events,
+		policy:         policy,
+		limits:         limits,
+		log:            log,
+	}
+}
+
+// CreateAttachmentInput represents input data for attaching a file to a task
+type CreateAttachmentInput struct {
+	OrgID       string
+	TaskID      string // Task ID or key
+	UserID      string
+	Name        string
+	ContentType string // Defaults to application/octet-stream
+	Size        int64  // Bytes
+}
+
+// AttachmentUpload is a pending attachment and where its content is uploaded to
+type AttachmentUpload struct {
+	Attachment *domain.Attachment
+	// UploadURL is a presigned URL the content is PUT to, with the attachment's
+	// content type, until ExpiresAt. It is empty when the content is uploaded
+	// through the API instead.
+	UploadURL string
+	ExpiresAt time.Time
+}
+
+// CreateAttachment records a pending attachment of a task the user may write
+func (uc *AttachmentUseCase) CreateAttachment(input *CreateAttachmentInput) (*AttachmentUpload, error) {
+	name, err := attachmentName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, err := uploadContentType(input.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Size <= 0 || input.Size > uc.limits.MaxSize {
+		return nil, fmt.Errorf("%w: size must be between 1 and %d bytes", domain.ErrInvalidInput, uc.limits.MaxSize)
+	}
+
+	user, task, err := uc.authorizeTask(input.OrgID, input.TaskID, input.UserID, ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &domain.Attachment{
+		ID:          primitive.NewObjectID(),
+		OrgID:       task.OrgID,
+		TaskID:      task.ID,
+		Name:        name,
+		ContentType: contentType,
+		Size:        input.Size,
+		Status:      domain.AttachmentStatusPending,
+		UploadedBy:  user.ID,
+	}
+	attachment.StorageKey = fmt.Sprintf("attachments/%s/%s/%s", task.OrgID.Hex(), task.ID.Hex(), attachment.ID.Hex())
+
+	if err := uc.attachmentRepo.Create(attachment); err != nil {
+		return nil, err
+	}
+
+	upload := &AttachmentUpload{Attachment: attachment}
+	if presigner, ok := uc.store.(domain.BlobPresigner); ok {
+		url, err := presigner.PresignUpload(attachment.StorageKey, attachment.ContentType, uc.limits.URLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		upload.UploadURL = url
+		upload.ExpiresAt = time.Now().Add(uc.limits.URLExpiry)
+	}
+
+	return upload, nil
+}
+
+// UploadAttachmentContent stores the content of a pending attachment uploaded
+// through the API and marks it ready. Content beyond the declared size is refused.
+func (uc *AttachmentUseCase) UploadAttachmentContent(orgID string, taskID string, attachmentID string, userID string, content io.Reader) (*domain.Attachment, error) {
+	if _, ok := uc.store.(domain.BlobPresigner); ok {
+		return nil, errPresignedUploads
+	}
+
+	attachment, err := uc.pendingAttachment(orgID, taskID, attachmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingReader{r: io.LimitReader(content, attachment.Size+1)}
+	if err := uc.store.Put(attachment.StorageKey, counter, attachment.Size, attachment.ContentType); err != nil {
+		return nil, err
+	}
+	if counter.n != attachment.Size {
+		if err := uc.store.Delete(attachment.StorageKey); err != nil {
+			uc.log.WarnF("Failed to delete attachment content %s: %v", attachment.StorageKey, err)
+		}
+		return nil, fmt.Errorf("%w: the content must be exactly the declared %d bytes", domain.ErrInvalidInput, attachment.Size)
+	}
+
+	return uc.markReady(attachment, counter.n)
+}
+
+// CompleteAttachment marks a pending attachment ready once its content has been
+// uploaded to its presigned URL. Completing a ready attachment has no effect.
+func (uc *AttachmentUseCase) CompleteAttachment(orgID string, taskID string, attachmentID string, userID string) (*domain.Attachment, error) {
+	attachment, err := uc.pendingAttachment(orgID, taskID, attachmentID, userID)
+	if errors.Is(err, errAttachmentReady) {
+		return attachment, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := uc.store.Stat(attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: the content has not been uploaded", domain.ErrInvalidInput)
+		}
+		return nil, err
+	}
+
+	// Presigned uploads cannot enforce the size, so check it now
+	if info.Size > uc.limits.MaxSize {
+		if err := uc.store.Delete(attachment.StorageKey); err != nil {
+			uc.log.WarnF("Failed to delete oversized attachment content %s: %v", attachment.StorageKey, err)
+		}
+		return nil, fmt.Errorf("%w: the content is larger than %d bytes", domain.ErrInvalidInput, uc.limits.MaxSize)
+	}
+
+	return uc.markReady(attachment, info.Size)
+}
+
+// ListAttachments lists the attachments of a task the user may read, oldest first
+func (uc *AttachmentUseCase) ListAttachments(orgID string, taskID string, userID string) ([]*domain.Attachment, error) {
+	_, task, err := uc.authorizeTask(orgID, taskID, userID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.attachmentRepo.FindByTask(task.ID)
+}
+
+// AttachmentVariantThumbnail selects the thumbnail of an image attachment for download
+const AttachmentVariantThumbnail = "thumb"
+
+// AttachmentDownload is where to download an attachment's content from: either a
+// presigned URL, or the content itself, which the caller must close
+type AttachmentDownload struct {
+	Attachment *domain.Attachment
+	URL        string
+	Content    io.ReadCloser
+	// Name, ContentType and Size describe the downloaded file, which for thumbnails
+	// is not the attachment's
+	Name        string
+	ContentType string
+	Size        int64
+}
+
+// DownloadAttachment returns where to download the content of a ready attachment
+// from. The variant is empty for the content itself, or AttachmentVariantThumbnail
+// for its thumbnail, which is not found until it has been generated. Attachments
+// being scanned or quarantined cannot be downloaded.
+func (uc *AttachmentUseCase) DownloadAttachment(orgID string, taskID string, attachmentID string, userID string, variant string) (*AttachmentDownload, error) {
+	if variant != "" && variant != AttachmentVariantThumbnail {
+		return nil, fmt.Errorf("%w: unknown variant %q", domain.ErrInvalidInput, variant)
+	}
+
+	attachment, err := uc.findAttachment(orgID, taskID, attachmentID, userID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.Status != domain.AttachmentStatusReady {
+		return nil, domain.ErrNotFound
+	}
+	switch attachment.Scan {
+	case domain.ScanStatusPending:
+		return nil, errAttachmentScanning
+	case domain.ScanStatusQuarantined:
+		return nil, errAttachmentQuarantined
+	}
+
+	download := &AttachmentDownload{
+		Attachment:  attachment,
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+	}
+	key := attachment.StorageKey
+	if variant == AttachmentVariantThumbnail {
+		if attachment.Thumbnail != domain.ThumbnailStatusReady {
+			return nil, domain.ErrNotFound
+		}
+		key = attachment.ThumbnailKey
+		download.Name = "thumbnail-" + attachment.Name
+	}
+
+	if presigner, ok := uc.store.(domain.BlobPresigner); ok {
+		download.URL, err = presigner.PresignDownload(key, download.Name, uc.limits.URLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		return download, nil
+	}
+
+	if variant == AttachmentVariantThumbnail {
+		info, err := uc.store.Stat(key)
+		if err != nil {
+			return nil, err
+		}
+		download.ContentType, download.Size = info.ContentType, info.Size
+	}
+	if download.Content, err = uc.store.Open(key); err != nil {
+		return nil, err
+	}
+
+	return download, nil
+}
+
+// GenerateThumbnails generates up to limit pending thumbnails and returns how many
+// it generated. Images that cannot be decoded are marked unavailable; storage
+// errors are returned and the thumbnail is retried on the next run.
+func (uc *AttachmentUseCase) GenerateThumbnails(limit int64) (int, error) {
+	if uc.thumbnailer == nil {
+		return 0, nil
+	}
+
+	attachments, err := uc.attachmentRepo.FindPendingThumbnails(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, attachment := range attachments {
+		ok, err := uc.generateThumbnail(attachment)
+		if err != nil {
+			return generated, err
+		}
+		if ok {
+			generated++
+		}
+	}
+
+	return generated, nil
+}
+
+// generateThumbnail generates and stores the thumbnail of an attachment, and
+// reports whether the image could be thumbnailed
+func (uc *AttachmentUseCase) generateThumbnail(attachment *domain.Attachment) (bool, error) {
+	content, err := uc.store.Open(attachment.StorageKey)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Deleted since it was found
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	thumb, contentType, err := uc.thumbnailer.Thumbnail(content)
+	content.Close()
+	if err != nil {
+		uc.log.WarnF("Failed to thumbnail attachment %s: %v", attachment.ID.Hex(), err)
+		return false, uc.attachmentRepo.SetThumbnail(attachment.ID, domain.ThumbnailStatusUnavailable, "")
+	}
+
+	key := attachment.StorageKey + "/thumbnail"
+	if err := uc.store.Put(key, bytes.NewReader(thumb), int64(len(thumb)), contentType); err != nil {
+		return false, err
+	}
+	if err := uc.attachmentRepo.SetThumbnail(attachment.ID, domain.ThumbnailStatusReady, key); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, uc.store.Delete(key)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteAttachment deletes an attachment of a task the user may write, with its content
+func (uc *AttachmentUseCase) DeleteAttachment(orgID string, taskID string, attachmentID string, userID string) error {
+	attachment, err := uc.findAttachment(orgID, taskID, attachmentID, userID, ActionWrite)
+	if err != nil {
+		return err
+	}
+
+	return uc.delete(attachment)
+}
+
+// HandleEvent deletes the attachments of deleted tasks
+func (uc *AttachmentUseCase) HandleEvent(event *domain.Event) error {
+	if event.Type != domain.EventTaskDeleted || event.Task == nil {
+		return nil
+	}
+
+	attachments, err := uc.attachmentRepo.FindByTask(event.Task.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := uc.delete(attachment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// delete deletes an attachment's content and thumbnail, then the attachment
+func (uc *AttachmentUseCase) delete(attachment *domain.Attachment) error {
+	if err := uc.store.Delete(attachment.StorageKey); err != nil {
+		return err
+	}
+	if attachment.ThumbnailKey != "" {
+		if err := uc.store.Delete(attachment.ThumbnailKey); err != nil {
+			return err
+		}
+	}
+
+	return uc.attachmentRepo.Delete(attachment.ID)
+}
+
+// errPresignedUploads refuses uploads through the API when the blob store takes
+// them directly, so large files never pass through the API server
+var errPresignedUploads = fmt.Errorf("%w: upload the content to the presigned upload URL", domain.ErrInvalidInput)
+
+// errAttachmentScanning refuses downloads of attachments not scanned yet
+var errAttachmentScanning = fmt.Errorf("%w: the attachment is being scanned for malware; try again shortly", domain.ErrInvalidInput)
+
+// errAttachmentQuarantined refuses downloads of quarantined attachments
+var errAttachmentQuarantined = fmt.Errorf("%w: the attachment was quarantined by the malware scan", domain.ErrInvalidInput)
+
+// errAttachmentReady reports that an attachment expected to be pending is ready
+var errAttachmentReady = fmt.Errorf("%w: the attachment has already been uploaded", domain.ErrInvalidInput)
+
+// pendingAttachment finds an attachment the user may upload the content of. Only
+// the uploader may, while the attachment is pending; for ready attachments it
+// returns the attachment and errAttachmentReady.
+func (uc *AttachmentUseCase) pendingAttachment(orgID string, taskID string, attachmentID string, userID string) (*domain.Attachment, error) {
+	attachment, err := uc.findAttachment(orgID, taskID, attachmentID, userID, ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment.UploadedBy.Hex() != userID {
+		return nil, domain.ErrUnauthorized
+	}
+	if attachment.Status == domain.AttachmentStatusReady {
+		return attachment, errAttachmentReady
+	}
+
+	return attachment, nil
+}
+
+// markReady records an attachment's uploaded size and marks it ready. Images the
+// thumbnailer supports get a pending thumbnail, and with a scanner every
+// attachment a pending scan.
+func (uc *AttachmentUseCase) markReady(attachment *domain.Attachment, size int64) (*domain.Attachment, error) {
+	var thumbnail domain.ThumbnailStatus
+	if uc.thumbnailer != nil && uc.thumbnailer.Supports(attachment.ContentType) {
+		thumbnail = domain.ThumbnailStatusPending
+	}
+	var scan domain.ScanStatus
+	if uc.scanner != nil {
+		scan = domain.ScanStatusPending
+	}
+	if err := uc.attachmentRepo.MarkReady(attachment.ID, size, thumbnail, scan); err != nil {
+		return nil, err
+	}
+
+	attachment.Size = size
+	attachment.Status = domain.AttachmentStatusReady
+	attachment.Thumbnail = thumbnail
+	attachment.Scan = scan
+	attachment.UpdatedAt = time.Now()
+	return attachment, nil
+}
+
+// findAttachment finds an attachment of a task the user may perform the action on
+func (uc *AttachmentUseCase) findAttachment(orgID string, taskID string, attachmentID string, userID string, action Action) (*domain.Attachment, error) {
+	attachmentObjID, err := primitive.ObjectIDFromHex(attachmentID)
+	if err != nil {
+		return nil, errors.New("invalid attachment ID format")
+	}
+
+	_, task, err := uc.authorizeTask(orgID, taskID, userID, action)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := uc.attachmentRepo.FindByID(attachmentObjID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != task.ID {
+		return nil, domain.ErrNotFound
+	}
+
+	return attachment, nil
+}
+
+// authorizeTask loads the acting user and a task of their organization, given by
+// ID or key, and checks they may perform the action on it
+func (uc *AttachmentUseCase) authorizeTask(orgID string, taskID string, userID string, action Action) (*domain.User, *domain.Task, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task, err := findTask(uc.taskRepo.ForOrg(org), taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.policy.Can(user, action, task); err != nil {
+		return nil, nil, err
+	}
+
+	return user, task, nil
+}
+
+// attachmentName cleans up an uploaded file name: directories and control
+// characters are dropped
+func attachmentName(name string) (string, error) {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+
+	if name == "" || name == "." || name == ".." {
+		return "", fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(name) > maxAttachmentNameLength {
+		return "", fmt.Errorf("%w: name must be at most %d characters", domain.ErrInvalidInput, maxAttachmentNameLength)
+	}
+
+	return name, nil
+}
+
+// uploadContentType validates the media type of an upload, defaulting to binary data
+func uploadContentType(contentType string) (string, error) {
+	if contentType == "" {
+		return "application/octet-stream", nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.Contains(mediaType, "/") {
+		return "", fmt.Errorf("%w: content_type must be a media type such as image/png", domain.ErrInvalidInput)
+	}
+	return mime.FormatMediaType(mediaType, params), nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ScanAttachments scans up to limit attachments awaiting their malware scan and
+// returns how many it quarantined. Clean attachments become downloadable.
+// Attachments found infected, or refused by the scanner, are moved to quarantine
+// and their uploader is notified. Scanner errors are returned and the attachment
+// is scanned again on the next run.
+func (uc *AttachmentUseCase) ScanAttachments(limit int64) (int, error) {
+	if uc.scanner == nil {
+		return 0, nil
+	}
+
+	attachments, err := uc.attachmentRepo.FindPendingScans(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	quarantined := 0
+	for _, attachment := range attachments {
+		result, err := uc.scan(attachment)
+		if err != nil {
+			return quarantined, err
+		}
+		if result == nil {
+			// Deleted since it was found
+			continue
+		}
+
+		if result.Clean {
+			if err := uc.attachmentRepo.MarkClean(attachment.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return quarantined, err
+			}
+			continue
+		}
+
+		uc.log.WarnF("Quarantining attachment %s of task %s: %s", attachment.ID.Hex(), attachment.TaskID.Hex(), result.Threat)
+		if err := uc.quarantine(attachment, result.Threat); err != nil {
+			return quarantined, err
+		}
+		quarantined++
+	}
+
+	return quarantined, nil
+}
+
+// scan scans the content of an attachment. It returns nil when the content is gone.
+func (uc *AttachmentUseCase) scan(attachment *domain.Attachment) (*domain.ScanResult, error) {
+	content, err := uc.store.Open(attachment.StorageKey)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	return uc.scanner.Scan(content)
+}
+
+// quarantine moves an attachment's content under the quarantine/ prefix, where
+// it is kept for review until the attachment is deleted, and notifies the uploader
+func (uc *AttachmentUseCase) quarantine(attachment *domain.Attachment, threat string) error {
+	key := "quarantine/" + attachment.StorageKey
+
+	content, err := uc.store.Open(attachment.StorageKey)
+	if err != nil {
+		return err
+	}
+	err = uc.store.Put(key, content, attachment.Size, attachment.ContentType)
+	content.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := uc.attachmentRepo.Quarantine(attachment.ID, threat, key); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return uc.store.Delete(key)
+		}
+		return err
+	}
+	if err := uc.store.Delete(attachment.StorageKey); err != nil {
+		uc.log.WarnF("Failed to delete quarantined attachment content %s: %v", attachment.StorageKey, err)
+	}
+
+	attachment.Scan = domain.ScanStatusQuarantined
+	attachment.ScanThreat = threat
+	attachment.StorageKey = key
+	attachment.Thumbnail = ""
+	uc.notifyQuarantined(attachment)
+	return nil
+}
+
+// notifyQuarantined publishes the quarantine of an attachment, which notifies its uploader
+func (uc *AttachmentUseCase) notifyQuarantined(attachment *domain.Attachment) {
+	if uc.events == nil {
+		return
+	}
+
+	task, err := uc.taskRepo.ForOrg(attachment.OrgID).FindByID(attachment.TaskID)
+	if err != nil {
+		uc.log.WarnF("Failed to load task %s of quarantined attachment %s: %v", attachment.TaskID.Hex(), attachment.ID.Hex(), err)
+		return
+	}
+
+	uc.events.Publish(&domain.Event{
+		Type:       domain.EventAttachmentQuarantined,
+		ActorID:    primitive.NilObjectID, // The scan, not a user
+		SubjectID:  attachment.UploadedBy,
+		Task:       task,
+		Attachment: attachment,
+		OccurredAt: time.Now(),
+	})
+}
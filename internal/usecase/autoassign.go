@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"fmt"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxAutoAssignRules caps the number of auto-assignment rules of a project
+const maxAutoAssignRules = 20
+
+// AutoAssignRuleInput represents one requested auto-assignment rule
+type AutoAssignRuleInput struct {
+	Tag         string
+	MinPriority int
+	Strategy    domain.AutoAssignStrategy // Round robin when empty
+	Candidates  []string                  // User IDs; the project's contributors and admins when empty
+}
+
+// SetAutoAssignRulesInput represents input data for replacing a project's auto-assignment rules
+type SetAutoAssignRulesInput struct {
+	OrgID     string
+	ProjectID string
+	Rules     []AutoAssignRuleInput
+	UpdatedBy string
+}
+
+// GetAutoAssignRules retrieves the auto-assignment rules of a project the user is a member of
+func (uc *ProjectUseCase) GetAutoAssignRules(orgID string, projectID string, userID string) ([]domain.AutoAssignRule, error) {
+	_, project, err := uc.authorize(orgID, projectID, userID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.AutoAssign, nil
+}
+
+// SetAutoAssignRules replaces the auto-assignment rules of a project, which restarts
+// their round-robin turns. Explicit candidates must be contributors or admins of the
+// project. Only project admins may do so.
+func (uc *ProjectUseCase) SetAutoAssignRules(input *SetAutoAssignRulesInput) ([]domain.AutoAssignRule, error) {
+	_, project, err := uc.authorize(input.OrgID, input.ProjectID, input.UpdatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.Rules) > maxAutoAssignRules {
+		return nil, fmt.Errorf("%w: a project has at most %d auto-assignment rules", domain.ErrInvalidInput, maxAutoAssignRules)
+	}
+
+	rules := make([]domain.AutoAssignRule, 0, len(input.Rules))
+	for _, requested := range input.Rules {
+		rule, err := newAutoAssignRule(project, requested)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	project.AutoAssign = rules
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return project.AutoAssign, nil
+}
+
+// newAutoAssignRule validates a requested rule of a project
+func newAutoAssignRule(project *domain.Project, input AutoAssignRuleInput) (domain.AutoAssignRule, error) {
+	rule := domain.AutoAssignRule{
+		ID:          primitive.NewObjectID(),
+		MinPriority: input.MinPriority,
+		Strategy:    input.Strategy,
+	}
+
+	if rule.Strategy == "" {
+		rule.Strategy = domain.AutoAssignRoundRobin
+	}
+	if !rule.Strategy.IsValid() {
+		return rule, fmt.Errorf("%w: unknown auto-assignment strategy %q", domain.ErrInvalidInput, rule.Strategy)
+	}
+
+	if rule.MinPriority < 0 || rule.MinPriority > 5 {
+		return rule, fmt.Errorf("%w: min_priority must be between 0 and 5", domain.ErrInvalidInput)
+	}
+
+	tags, err := normalizeTags([]string{input.Tag})
+	if err != nil {
+		return rule, err
+	}
+	if len(tags) > 0 {
+		rule.Tag = tags[0]
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(input.Candidates))
+	for _, candidate := range input.Candidates {
+		id, err := primitive.ObjectIDFromHex(candidate)
+		if err != nil {
+			return rule, fmt.Errorf("%w: invalid candidate ID %q", domain.ErrInvalidInput, candidate)
+		}
+		if role, ok := project.RoleOf(id); !ok || !role.AtLeast(domain.ProjectRoleContributor) {
+			return rule, fmt.Errorf("%w: candidate %s is not a contributor of the project", domain.ErrInvalidInput, candidate)
+		}
+		if !seen[id] {
+			seen[id] = true
+			rule.Candidates = append(rule.Candidates, id)
+		}
+	}
+
+	return rule, nil
+}
+
+// autoAssign assigns a new, unassigned project task by the first of the project's
+// auto-assignment rules it matches that has a candidate left. Candidates who are no
+// longer contributors of the project, or whose accounts are deactivated, are skipped.
+func (uc *TaskUseCase) autoAssign(task *domain.Task) error {
+	if task.ProjectID.IsZero() || len(task.AssignedTo) > 0 {
+		return nil
+	}
+
+	project, err := uc.policy.projectRepo.FindByID(task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	for i := range project.AutoAssign {
+		rule := &project.AutoAssign[i]
+		if !rule.Matches(task) {
+			continue
+		}
+
+		candidates, err := uc.activeUsers(autoAssignCandidates(project, rule))
+		if err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		assignee, err := uc.pickAssignee(project, rule, candidates)
+		if err != nil {
+			return err
+		}
+
+		task.AddAssignee(assignee)
+		err = uc.save(task.OrgID, func(repo domain.TaskRepository) error {
+			return repo.Update(task)
+		}, taskEvent(domain.EventTaskAssigned, primitive.NilObjectID, assignee, task))
+		if err != nil {
+			task.RemoveAssignee(assignee)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// autoAssignCandidates lists the users a rule may assign: its candidates that are
+// still contributors or admins of the project, or all of them
+func autoAssignCandidates(project *domain.Project, rule *domain.AutoAssignRule) []primitive.ObjectID {
+	var candidates []primitive.ObjectID
+	if len(rule.Candidates) == 0 {
+		for _, member := range project.Members {
+			if member.Role.AtLeast(domain.ProjectRoleContributor) {
+				candidates = append(candidates, member.UserID)
+			}
+		}
+		return candidates
+	}
+
+	for _, id := range rule.Candidates {
+		if role, ok := project.RoleOf(id); ok && role.AtLeast(domain.ProjectRoleContributor) {
+			candidates = append(candidates, id)
+		}
+	}
+	return candidates
+}
+
+// activeUsers drops the deactivated and deleted users from a list of user IDs,
+// keeping the order of the others
+func (uc *TaskUseCase) activeUsers(ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	users, err := uc.userRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	usable := make(map[primitive.ObjectID]bool, len(users))
+	for _, user := range users {
+		usable[user.ID] = !user.IsDeactivated()
+	}
+
+	active := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if usable[id] {
+			active = append(active, id)
+		}
+	}
+	return active, nil
+}
+
+// pickAssignee picks one of the candidates by the rule's strategy. Round-robin
+// turns are counted per rule; least-loaded ties go to the earlier candidate.
+func (uc *TaskUseCase) pickAssignee(project *domain.Project, rule *domain.AutoAssignRule, candidates []primitive.ObjectID) (primitive.ObjectID, error) {
+	if rule.Strategy == domain.AutoAssignLeastLoaded {
+		var best primitive.ObjectID
+		fewest := int64(-1)
+		for _, id := range candidates {
+			open, err := uc.taskRepo.ForOrg(project.OrgID).Count(map[string]interface{}{
+				"project_id":  project.ID,
+				"assigned_to": id,
+				"status":      map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+			})
+			if err != nil {
+				return primitive.NilObjectID, err
+			}
+			if fewest < 0 || open < fewest {
+				best, fewest = id, open
+			}
+		}
+		return best, nil
+	}
+
+	turn, err := uc.counters.Next(domain.AutoAssignCounter(rule.ID))
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return candidates[(turn-1)%int64(len(candidates))], nil
+}
@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// avatarContentTypes are the image types accepted as avatars. SVG is left out as
+// it can carry scripts.
+var avatarContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// AvatarUseCase handles business logic related to user avatars. Avatars are
+// uploaded like attachments: an upload is begun, its content is uploaded to a
+// presigned URL or through the API, and completing it makes it the user's avatar.
+type AvatarUseCase struct {
+	userRepo domain.UserRepository
+	store    domain.BlobStore
+	limits   UploadLimits
+	log      logger.Logger
+}
+
+// NewAvatarUseCase creates a new avatar use case
+func NewAvatarUseCase(userRepo domain.UserRepository, store domain.BlobStore, limits UploadLimits, log logger.Logger) *AvatarUseCase {
+	return &AvatarUseCase{
+		userRepo: userRepo,
+		store:    store,
+		limits:   limits,
+		log:      log,
+	}
+}
+
+// AvatarUpload is where the content of a new avatar is uploaded to
+type AvatarUpload struct {
+	UploadID    string
+	ContentType string
+	// UploadURL is a presigned URL the content is PUT to, with the content type,
+	// until ExpiresAt. It is empty when the content is uploaded through the API instead.
+	UploadURL string
+	ExpiresAt time.Time
+}
+
+// BeginAvatarUpload starts uploading a new avatar of the given image type and size
+func (uc *AvatarUseCase) BeginAvatarUpload(userID string, contentType string, size int64) (*AvatarUpload, error) {
+	contentType, err := avatarContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 || size > uc.limits.MaxSize {
+		return nil, fmt.Errorf("%w: size must be between 1 and %d bytes", domain.ErrInvalidInput, uc.limits.MaxSize)
+	}
+
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &AvatarUpload{
+		UploadID:    primitive.NewObjectID().Hex(),
+		ContentType: contentType,
+	}
+	if presigner, ok := uc.store.(domain.BlobPresigner); ok {
+		url, err := presigner.PresignUpload(avatarKey(user.ID, upload.UploadID), contentType, uc.limits.URLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		upload.UploadURL = url
+		upload.ExpiresAt = time.Now().Add(uc.limits.URLExpiry)
+	}
+
+	return upload, nil
+}
+
+// UploadAvatarContent stores an avatar uploaded through the API and makes it the user's avatar
+func (uc *AvatarUseCase) UploadAvatarContent(userID string, uploadID string, contentType string, content io.Reader) (*domain.User, error) {
+	if _, ok := uc.store.(domain.BlobPresigner); ok {
+		return nil, errPresignedUploads
+	}
+
+	contentType, err := avatarContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	user, key, err := uc.findUpload(userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingReader{r: io.LimitReader(content, uc.limits.MaxSize+1)}
+	if err := uc.store.Put(key, counter, -1, contentType); err != nil {
+		return nil, err
+	}
+	if counter.n == 0 || counter.n > uc.limits.MaxSize {
+		if err := uc.store.Delete(key); err != nil {
+			uc.log.WarnF("Failed to delete avatar content %s: %v", key, err)
+		}
+		return nil, fmt.Errorf("%w: the avatar must be between 1 and %d bytes", domain.ErrInvalidInput, uc.limits.MaxSize)
+	}
+
+	return uc.setAvatar(user, key)
+}
+
+// CompleteAvatarUpload makes an avatar uploaded to its presigned URL the user's
+// avatar. Completing the current avatar's upload has no effect.
+func (uc *AvatarUseCase) CompleteAvatarUpload(userID string, uploadID string) (*domain.User, error) {
+	user, key, err := uc.findUpload(userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AvatarKey == key {
+		return user, nil
+	}
+
+	info, err := uc.store.Stat(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: the avatar has not been uploaded", domain.ErrInvalidInput)
+		}
+		return nil, err
+	}
+
+	// Presigned uploads cannot enforce the size, so check it now
+	if info.Size > uc.limits.MaxSize {
+		if err := uc.store.Delete(key); err != nil {
+			uc.log.WarnF("Failed to delete oversized avatar content %s: %v", key, err)
+		}
+		return nil, fmt.Errorf("%w: the avatar is larger than %d bytes", domain.ErrInvalidInput, uc.limits.MaxSize)
+	}
+
+	return uc.setAvatar(user, key)
+}
+
+// DeleteAvatar removes the user's avatar
+func (uc *AvatarUseCase) DeleteAvatar(userID string) error {
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return err
+	}
+	if user.AvatarKey == "" {
+		return domain.ErrNotFound
+	}
+
+	if err := uc.userRepo.UpdateAvatar(user.ID, ""); err != nil {
+		return err
+	}
+	return uc.store.Delete(user.AvatarKey)
+}
+
+// AvatarDownload is where to download an avatar from: either a presigned URL, or
+// the content itself, which the caller must close
+type AvatarDownload struct {
+	URL         string
+	Content     io.ReadCloser
+	ContentType string
+	UpdatedAt   time.Time
+}
+
+// GetAvatar returns where to download the avatar of a user of the organization from
+func (uc *AvatarUseCase) GetAvatar(orgID string, userID string) (*AvatarDownload, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.OrgID != org || user.AvatarKey == "" {
+		return nil, domain.ErrNotFound
+	}
+
+	download := &AvatarDownload{UpdatedAt: user.AvatarUpdatedAt}
+	if presigner, ok := uc.store.(domain.BlobPresigner); ok {
+		download.URL, err = presigner.PresignDownload(user.AvatarKey, "", uc.limits.URLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		return download, nil
+	}
+
+	info, err := uc.store.Stat(user.AvatarKey)
+	if err != nil {
+		return nil, err
+	}
+	download.ContentType = info.ContentType
+	if download.Content, err = uc.store.Open(user.AvatarKey); err != nil {
+		return nil, err
+	}
+
+	return download, nil
+}
+
+// setAvatar makes the content under a key the user's avatar, deleting the previous one
+func (uc *AvatarUseCase) setAvatar(user *domain.User, key string) (*domain.User, error) {
+	if err := uc.userRepo.UpdateAvatar(user.ID, key); err != nil {
+		return nil, err
+	}
+
+	if previous := user.AvatarKey; previous != "" && previous != key {
+		if err := uc.store.Delete(previous); err != nil {
+			uc.log.WarnF("Failed to delete previous avatar content %s: %v", previous, err)
+		}
+	}
+
+	user.AvatarKey = key
+	user.AvatarUpdatedAt = time.Now()
+	return user, nil
+}
+
+// findUpload finds the user uploading an avatar and the storage key of the upload
+func (uc *AvatarUseCase) findUpload(userID string, uploadID string) (*domain.User, string, error) {
+	if !primitive.IsValidObjectID(uploadID) {
+		return nil, "", domain.ErrNotFound
+	}
+
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, avatarKey(user.ID, uploadID), nil
+}
+
+// findUser finds a user by ID
+func (uc *AvatarUseCase) findUser(userID string) (*domain.User, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	return uc.userRepo.FindByID(userObjID)
+}
+
+// avatarKey returns the storage key of an avatar upload
+func avatarKey(userID primitive.ObjectID, uploadID string) string {
+	return fmt.Sprintf("avatars/%s/%s", userID.Hex(), uploadID)
+}
+
+// avatarContentType validates the image type of an avatar
+func avatarContentType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !avatarContentTypes[mediaType] {
+		return "", fmt.Errorf("%w: content_type must be image/png, image/jpeg, image/gif or image/webp", domain.ErrInvalidInput)
+	}
+	return mediaType, nil
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// calendarFeedLookback is how long tasks stay in calendar feeds after they were due
+const calendarFeedLookback = 30 * 24 * time.Hour
+
+// CalendarFeedOutput is a user's calendar feed together with its new token
+type CalendarFeedOutput struct {
+	Feed  *domain.CalendarFeed
+	Token string // Only returned when the feed is created or rotated
+}
+
+// CalendarFeedContent is what a calendar feed lists
+type CalendarFeedContent struct {
+	User  *domain.User   // Owner of the feed, whose time zone dates are shown in
+	Tasks []*domain.Task // Open tasks assigned to the owner, by due date
+}
+
+// CalendarFeedUseCase handles business logic related to users' calendar feeds
+type CalendarFeedUseCase struct {
+	feedRepo domain.CalendarFeedRepository
+	taskRepo domain.TaskRepository
+	policy   *Policy
+	log      logger.Logger
+}
+
+// NewCalendarFeedUseCase creates a new calendar feed use case
+func NewCalendarFeedUseCase(feedRepo domain.CalendarFeedRepository, taskRepo domain.TaskRepository, policy *Policy, log logger.Logger) *CalendarFeedUseCase {
+	return &CalendarFeedUseCase{
+		feedRepo: feedRepo,
+		taskRepo: taskRepo,
+		policy:   policy,
+		log:      log,
+	}
+}
+
+// GetFeed retrieves the user's calendar feed, without its token
+func (uc *CalendarFeedUseCase) GetFeed(orgID string, userID string) (*domain.CalendarFeed, error) {
+	user, err := uc.actor(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.feedRepo.FindByUser(user.ID)
+}
+
+// RotateFeed creates the user's calendar feed, or replaces its token so that
+// the old URL stops working
+func (uc *CalendarFeedUseCase) RotateFeed(orgID string, userID string) (*CalendarFeedOutput, error) {
+	user, err := uc.actor(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &domain.CalendarFeed{
+		UserID:    user.ID,
+		OrgID:     user.OrgID,
+		TokenHash: hashSecretToken(token),
+	}
+	if err := uc.feedRepo.Save(feed); err != nil {
+		return nil, err
+	}
+
+	return &CalendarFeedOutput{Feed: feed, Token: token}, nil
+}
+
+// RevokeFeed deletes the user's calendar feed, after which its URL stops working
+func (uc *CalendarFeedUseCase) RevokeFeed(orgID string, userID string) error {
+	user, err := uc.actor(orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.feedRepo.Delete(user.ID)
+}
+
+// FeedContent loads what the calendar feed with the given token lists: the open
+// tasks assigned to its owner that are due from a month ago on. Unknown tokens
+// and feeds of deactivated users are reported as not found.
+func (uc *CalendarFeedUseCase) FeedContent(token string, now time.Time) (*CalendarFeedContent, error) {
+	if token == "" {
+		return nil, domain.ErrNotFound
+	}
+
+	feed, err := uc.feedRepo.FindByTokenHash(hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.policy.Actor(feed.OrgID, feed.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if user.IsDeactivated() {
+		return nil, domain.ErrNotFound
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(user.OrgID).FindAll(map[string]interface{}{
+		"assigned_to": user.ID,
+		"status":      map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+		"due_date":    map[string]interface{}{"$gte": now.Add(-calendarFeedLookback)},
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+	if tasks, err = uc.policy.FilterVisible(user, tasks); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].DueDate.Before(tasks[j].DueDate)
+	})
+
+	// Calendar apps poll feeds, so a failure to record the fetch is not worth failing it for
+	if err := uc.feedRepo.Touch(user.ID, now); err != nil {
+		uc.log.WarnF("Failed to record fetch of calendar feed of user %s: %v", user.ID.Hex(), err)
+	}
+
+	return &CalendarFeedContent{User: user, Tasks: tasks}, nil
+}
+
+// actor parses the organization and user IDs and loads the acting user
+func (uc *CalendarFeedUseCase) actor(orgID string, userID string) (*domain.User, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	return uc.policy.Actor(org, id)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxProjectConnectors caps the number of chat connectors of a project
+const maxProjectConnectors = 10
+
+// ProjectConnectorInput represents one requested chat connector
+type ProjectConnectorInput struct {
+	Channel    domain.NotificationChannel
+	WebhookURL string
+	Categories []domain.NotificationCategory // All categories when empty
+}
+
+// SetProjectConnectorsInput represents input data for replacing a project's chat connectors
+type SetProjectConnectorsInput struct {
+	OrgID      string
+	ProjectID  string
+	Connectors []ProjectConnectorInput
+	UpdatedBy  string
+}
+
+// GetProjectConnectors retrieves the chat connectors of a project. Their webhook
+// URLs are secrets, so only project admins may do so.
+func (uc *ProjectUseCase) GetProjectConnectors(orgID string, projectID string, userID string) ([]domain.ProjectConnector, error) {
+	_, project, err := uc.authorize(orgID, projectID, userID, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.Connectors, nil
+}
+
+// SetProjectConnectors replaces the chat connectors of a project. Only project admins may do so.
+func (uc *ProjectUseCase) SetProjectConnectors(input *SetProjectConnectorsInput) ([]domain.ProjectConnector, error) {
+	_, project, err := uc.authorize(input.OrgID, input.ProjectID, input.UpdatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.Connectors) > maxProjectConnectors {
+		return nil, fmt.Errorf("%w: a project has at most %d connectors", domain.ErrInvalidInput, maxProjectConnectors)
+	}
+
+	connectors := make([]domain.ProjectConnector, 0, len(input.Connectors))
+	for _, requested := range input.Connectors {
+		connector, err := newProjectConnector(requested)
+		if err != nil {
+			return nil, err
+		}
+		connectors = append(connectors, connector)
+	}
+
+	project.Connectors = connectors
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return project.Connectors, nil
+}
+
+// newProjectConnector validates a requested connector
+func newProjectConnector(input ProjectConnectorInput) (domain.ProjectConnector, error) {
+	if !isConnectorChannel(input.Channel) {
+		return domain.ProjectConnector{}, fmt.Errorf("%w: connector channel must be one of %v", domain.ErrInvalidInput, domain.ProjectConnectorChannels)
+	}
+
+	webhookURL, err := url.Parse(input.WebhookURL)
+	if err != nil || webhookURL.Scheme != "https" || webhookURL.Host == "" {
+		return domain.ProjectConnector{}, fmt.Errorf("%w: connector webhook URL must be an https URL", domain.ErrInvalidInput)
+	}
+
+	for _, category := range input.Categories {
+		if !isValidCategory(category) {
+			return domain.ProjectConnector{}, fmt.Errorf("%w: unknown notification category %q", domain.ErrInvalidInput, category)
+		}
+	}
+
+	return domain.ProjectConnector{
+		ID:         primitive.NewObjectID(),
+		Channel:    input.Channel,
+		WebhookURL: input.WebhookURL,
+		Categories: input.Categories,
+	}, nil
+}
+
+// isConnectorChannel checks whether project connectors can post to a channel
+func isConnectorChannel(channel domain.NotificationChannel) bool {
+	for _, c := range domain.ProjectConnectorChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// ConnectorUseCase posts the task events of projects to their chat connectors
+type ConnectorUseCase struct {
+	projectRepo domain.ProjectRepository
+	posters     map[domain.NotificationChannel]domain.ChatPoster
+	log         logger.Logger
+}
+
+// NewConnectorUseCase creates a new connector use case. Connectors are served by
+// the poster of their channel; connectors of other channels are skipped.
+func NewConnectorUseCase(projectRepo domain.ProjectRepository, log logger.Logger, posters ...domain.ChatPoster) *ConnectorUseCase {
+	byChannel := make(map[domain.NotificationChannel]domain.ChatPoster, len(posters))
+	for _, poster := range posters {
+		byChannel[poster.Channel()] = poster
+	}
+
+	return &ConnectorUseCase{
+		projectRepo: projectRepo,
+		posters:     byChannel,
+		log:         log,
+	}
+}
+
+// HandleEvent posts a project task event to the project's connectors that want
+// its category. Failing connectors are logged so they don't block the others.
+// It is meant to be subscribed to the event bus.
+func (uc *ConnectorUseCase) HandleEvent(event *domain.Event) error {
+	if event.Task == nil || event.Task.ProjectID.IsZero() {
+		return nil
+	}
+
+	project, err := uc.projectRepo.FindByID(event.Task.ProjectID)
+	if err != nil {
+		return err
+	}
+	if len(project.Connectors) == 0 {
+		return nil
+	}
+
+	category := domain.CategoryForEvent(event.Type)
+	message := &domain.ChatMessage{
+		Type:      event.Type,
+		Summary:   event.Summary(),
+		TaskTitle: event.Task.Title,
+		TaskKey:   event.Task.Key,
+	}
+	for _, connector := range project.Connectors {
+		if !connector.Wants(category) {
+			continue
+		}
+
+		poster, ok := uc.posters[connector.Channel]
+		if !ok {
+			continue
+		}
+		if err := poster.Post(connector.WebhookURL, message); err != nil {
+			uc.log.ErrorF("Failed to post %s to %s connector %s of project %s: %v", event.Type, connector.Channel, connector.ID.Hex(), project.ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+
+	"task-management-system/internal/domain"
+)
+
+// ContentPolicy limits and sanitizes user-written task content before it is stored
+type ContentPolicy struct {
+	MaxTitleLength       int // Characters; zero means no limit
+	MaxDescriptionLength int // Characters; zero means no limit
+	// Sanitize strips HTML tags and unsafe link schemes, so web clients that
+	// render the content as HTML or Markdown cannot be made to run scripts
+	Sanitize bool
+}
+
+// clean sanitizes the title and description being set, leaving nil ones alone,
+// and checks their lengths. Every invalid field is reported at once.
+func (p ContentPolicy) clean(title *string, description *string) error {
+	var invalid []domain.FieldError
+	check := func(field string, value *string, max int) {
+		if value == nil {
+			return
+		}
+		if p.Sanitize {
+			*value = sanitizeContent(*value)
+		}
+		if max > 0 && utf8.RuneCountInString(*value) > max {
+			invalid = append(invalid, domain.FieldError{
+				Field:   field,
+				Message: fmt.Sprintf("must be at most %d characters", max),
+			})
+		}
+	}
+
+	check(TaskFieldTitle, title, p.MaxTitleLength)
+	check(TaskFieldDescription, description, p.MaxDescriptionLength)
+
+	if len(invalid) > 0 {
+		return &domain.ValidationError{Fields: invalid}
+	}
+	return nil
+}
+
+// Limits on the tags of a task
+const (
+	maxTaskTags      = 20
+	maxTaskTagLength = 50
+)
+
+// normalizeTags trims and lowercases task tags, dropping empty and repeated
+// ones, and checks their number and lengths
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTaskTagLength {
+			return nil, fmt.Errorf("%w: tags must be at most %d characters", domain.ErrInvalidInput, maxTaskTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTaskTags {
+		return nil, fmt.Errorf("%w: a task has at most %d tags", domain.ErrInvalidInput, maxTaskTags)
+	}
+	return normalized, nil
+}
+
+// unsafeElements are HTML elements whose content is dropped along with their tags
+var unsafeElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "object": true,
+	"embed": true, "applet": true, "noscript": true, "noembed": true, "noframes": true,
+	"template": true, "textarea": true, "title": true, "xmp": true, "svg": true, "math": true,
+}
+
+// unsafeLinkPattern matches the scheme of Markdown link destinations, inline as in
+// [text](url) or in references as in [ref]: url, with the colon possibly written as
+// an HTML entity
+var unsafeLinkPattern = regexp.MustCompile(`(?i)(\]\(|\]:)([\s<]*)([a-z\s\x00-\x1f]+)(:|&colon;|&#0*58;|&#x0*3a;)`)
+
+// unsafeSchemes are link schemes that run code or embed content when followed
+var unsafeSchemes = map[string]bool{"javascript": true, "vbscript": true, "data": true}
+
+// sanitizeContent strips HTML tags, comments and the content of script-like
+// elements, and removes unsafe schemes from Markdown links. Plain text and
+// Markdown are otherwise kept as written, including HTML entities.
+func sanitizeContent(s string) string {
+	// Stripping tags can join the text around them into new tags, as in
+	// <<b>script>, so strip until nothing changes
+	for {
+		stripped := stripHTML(s)
+		if stripped == s {
+			break
+		}
+		s = stripped
+	}
+
+	return unsafeLinkPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := unsafeLinkPattern.FindStringSubmatch(match)
+		scheme := strings.ToLower(strings.Map(func(r rune) rune {
+			if r <= ' ' {
+				return -1
+			}
+			return r
+		}, parts[3]))
+		if !unsafeSchemes[scheme] {
+			return match
+		}
+		return parts[1] + parts[2]
+	})
+}
+
+// stripHTML removes HTML markup from s, keeping its text
+func stripHTML(s string) string {
+	if !strings.Contains(s, "<") {
+		return s
+	}
+
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	skip := 0
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// The input has been read; a trailing partial tag is dropped
+			return b.String()
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(z.Raw())
+			}
+		case html.StartTagToken:
+			if name, _ := z.TagName(); unsafeElements[string(name)] {
+				skip++
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); unsafeElements[string(name)] && skip > 0 {
+				skip--
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Counters are the counts apps show as badges in their navigation. They match
+// the lengths of the lists they stand for without loading any of them.
+type Counters struct {
+	// AssignedOpen counts the open tasks assigned to the user
+	AssignedOpen int64 `json:"assigned_open" example:"12"`
+	// DueToday and Overdue count the open tasks assigned to the user that are
+	// due on the current day in their time zone, or before it, leaving out
+	// snoozed tasks as "My Day" does
+	DueToday int64 `json:"due_today" example:"2"`
+	Overdue  int64 `json:"overdue" example:"1"`
+	// UnreadNotifications counts the user's unread notifications
+	UnreadNotifications int64 `json:"unread_notifications" example:"5"`
+}
+
+// GetCounters counts the user's open, due today and overdue tasks and their
+// unread notifications. Every count is a single indexed count query.
+func (uc *TodayUseCase) GetCounters(orgID string, userID string, now time.Time) (*Counters, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := uc.policy.VisibleFilter(user)
+	if err != nil {
+		return nil, err
+	}
+
+	snoozed, err := uc.snoozedTasks(user.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	snoozedIDs := make([]primitive.ObjectID, 0, len(snoozed))
+	for id := range snoozed {
+		snoozedIDs = append(snoozedIDs, id)
+	}
+
+	// filter matches the user's visible open tasks, along with the given conditions
+	filter := func(conditions map[string]interface{}) map[string]interface{} {
+		f := map[string]interface{}{
+			"assigned_to": user.ID,
+			"status":      map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+		}
+		for key, value := range visible {
+			f[key] = value
+		}
+		for key, value := range conditions {
+			f[key] = value
+		}
+		return f
+	}
+
+	startOfDay, err := planDay(user, "", now)
+	if err != nil {
+		return nil, err
+	}
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	tasks := uc.taskRepo.ForOrg(org)
+	counters := &Counters{}
+	if counters.AssignedOpen, err = tasks.Count(filter(nil)); err != nil {
+		return nil, err
+	}
+	// Tasks without a due date are stored with the zero time
+	if counters.DueToday, err = tasks.Count(filter(map[string]interface{}{
+		"_id":      map[string]interface{}{"$nin": snoozedIDs},
+		"due_date": map[string]interface{}{"$gte": startOfDay, "$lt": endOfDay},
+	})); err != nil {
+		return nil, err
+	}
+	if counters.Overdue, err = tasks.Count(filter(map[string]interface{}{
+		"_id":      map[string]interface{}{"$nin": snoozedIDs},
+		"due_date": map[string]interface{}{"$gt": time.Time{}, "$lt": startOfDay},
+	})); err != nil {
+		return nil, err
+	}
+	if counters.UnreadNotifications, err = uc.notificationRepo.CountUnread(user.ID); err != nil {
+		return nil, err
+	}
+
+	return counters, nil
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	_ "embed"
+	"strings"
+	"text/template"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:embed templates/daily_digest.tmpl
+var dailyDigestTemplateText string
+
+var dailyDigestTemplate = template.Must(template.New("daily_digest").Parse(dailyDigestTemplateText))
+
+// DigestUseCase builds and sends the daily task digest email
+type DigestUseCase struct {
+	taskRepo         domain.TaskRepository
+	userRepo         domain.UserRepository
+	notificationRepo domain.NotificationRepository
+	preferencesRepo  domain.NotificationPreferencesRepository
+	snoozes          domain.TaskSnoozeRepository
+	sender           domain.EmailSender
+	log              logger.Logger
+}
+
+// NewDigestUseCase creates a new digest use case. Tasks a user has snoozed are
+// left out of their digest; snoozes may be nil.
+func NewDigestUseCase(
+	taskRepo domain.TaskRepository,
+	userRepo domain.UserRepository,
+	notificationRepo domain.NotificationRepository,
+	preferencesRepo domain.NotificationPreferencesRepository,
+	snoozes domain.TaskSnoozeRepository,
+	sender domain.EmailSender,
+	log logger.Logger,
+) *DigestUseCase {
+	return &DigestUseCase{
+		taskRepo:         taskRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		preferencesRepo:  preferencesRepo,
+		snoozes:          snoozes,
+		sender:           sender,
+		log:              log,
+	}
+}
+
+// digestItem is a single task line in the digest
+type digestItem struct {
+	Title  string
+	Due    string
+	Status domain.TaskStatus
+}
+
+// digestData is the data rendered by the digest template
+type digestData struct {
+	Name          string
+	Date          string
+	Overdue       []digestItem
+	DueToday      []digestItem
+	NewlyAssigned []digestItem
+}
+
+// isEmpty reports whether the digest has nothing to tell the user
+func (d *digestData) isEmpty() bool {
+	return len(d.Overdue) == 0 && len(d.DueToday) == 0 && len(d.NewlyAssigned) == 0
+}
+
+// SendDueDigests sends the daily digest to every opted-in user whose local digest
+// hour has been reached and who has not received a digest yet on their local date.
+// Failures for individual users are logged and do not stop the run. It returns the
+// number of digests sent.
+func (uc *DigestUseCase) SendDueDigests(now time.Time) (int, error) {
+	subscribers, err := uc.preferencesRepo.FindDigestSubscribers()
+	if err != nil {
+		return 0, err
+	}
+
+	// Load every subscriber at once rather than one query each
+	ids := make([]primitive.ObjectID, 0, len(subscribers))
+	for _, prefs := range subscribers {
+		ids = append(ids, prefs.UserID)
+	}
+	found, err := uc.userRepo.FindByIDs(ids)
+	if err != nil {
+		return 0, err
+	}
+	users := make(map[primitive.ObjectID]*domain.User, len(found))
+	for _, user := range found {
+		users[user.ID] = user
+	}
+
+	sent := 0
+	for _, prefs := range subscribers {
+		user, ok := users[prefs.UserID]
+		if !ok {
+			uc.log.ErrorF("Failed to send daily digest to user %s: %v", prefs.UserID.Hex(), domain.ErrNotFound)
+			continue
+		}
+
+		delivered, err := uc.sendDigest(user, prefs, now)
+		if err != nil {
+			uc.log.ErrorF("Failed to send daily digest to user %s: %v", prefs.UserID.Hex(), err)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendDigest sends one user's digest if it is due. Users with nothing to report
+// are marked as done for the day without an email.
+func (uc *DigestUseCase) sendDigest(user *domain.User, prefs *domain.NotificationPreferences, now time.Time) (bool, error) {
+	// Everything is evaluated in the user's own time zone
+	loc := user.Location()
+	localNow := now.In(loc)
+	if localNow.Hour() < prefs.DigestHour {
+		return false, nil
+	}
+
+	today := localNow.Format("2006-01-02")
+	if !prefs.LastDigestAt.IsZero() && prefs.LastDigestAt.In(loc).Format("2006-01-02") == today {
+		return false, nil
+	}
+
+	data, err := uc.buildDigest(user, prefs, localNow)
+	if err != nil {
+		return false, err
+	}
+
+	delivered := false
+	if !data.isEmpty() {
+		var body strings.Builder
+		if err := dailyDigestTemplate.Execute(&body, data); err != nil {
+			return false, err
+		}
+
+		if err := uc.sender.Send(user.Email, "Your daily task digest for "+data.Date, body.String()); err != nil {
+			return false, err
+		}
+		delivered = true
+	}
+
+	return delivered, uc.preferencesRepo.MarkDigestSent(user.ID, now)
+}
+
+// buildDigest collects the open tasks that are overdue or due today, plus the
+// tasks assigned to the user since their previous digest
+func (uc *DigestUseCase) buildDigest(user *domain.User, prefs *domain.NotificationPreferences, localNow time.Time) (*digestData, error) {
+	loc := localNow.Location()
+	startOfDay := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	data := &digestData{
+		Name: user.Username,
+		Date: localNow.Format("Monday, January 2, 2006"),
+	}
+	if user.FirstName != "" {
+		data.Name = user.FirstName
+	}
+
+	// Tasks without a due date are stored with the zero time and are excluded
+	tasks, err := uc.taskRepo.FindAll(map[string]interface{}{
+		"assigned_to": user.ID,
+		"status":      map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+		"due_date":    map[string]interface{}{"$gt": time.Time{}, "$lt": endOfDay},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snoozed, err := uc.snoozedTasks(user.ID, localNow)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		if snoozed[task.ID] {
+			continue
+		}
+		item := digestItem{
+			Title:  task.Title,
+			Due:    task.DueDate.In(loc).Format("Jan 2 15:04"),
+			Status: task.Status,
+		}
+		if task.DueDate.Before(startOfDay) {
+			data.Overdue = append(data.Overdue, item)
+		} else {
+			data.DueToday = append(data.DueToday, item)
+		}
+	}
+
+	// Assignments are taken from the in-app notifications recorded since the previous digest
+	since := prefs.LastDigestAt
+	if since.IsZero() {
+		since = localNow.Add(-24 * time.Hour)
+	}
+
+	assigned, err := uc.notificationRepo.FindByTypeSince(user.ID, domain.EventTaskAssigned, since)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(assigned))
+	for _, notification := range assigned {
+		if seen[notification.TaskID] || snoozed[notification.TaskID] {
+			continue
+		}
+		seen[notification.TaskID] = true
+		data.NewlyAssigned = append(data.NewlyAssigned, digestItem{Title: notification.TaskTitle})
+	}
+
+	return data, nil
+}
+
+// snoozedTasks returns the IDs of the tasks the user has snoozed at the given time
+func (uc *DigestUseCase) snoozedTasks(userID primitive.ObjectID, now time.Time) (map[primitive.ObjectID]bool, error) {
+	snoozed := map[primitive.ObjectID]bool{}
+	if uc.snoozes == nil {
+		return snoozed, nil
+	}
+
+	snoozes, err := uc.snoozes.FindActive(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, snooze := range snoozes {
+		snoozed[snooze.TaskID] = true
+	}
+	return snoozed, nil
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usernames of recently resolved users are cached for enrichment. Entries expire
+// quickly, so that a renamed user shows up under the new name soon after.
+const (
+	userRefCacheSize = 1000
+	userRefCacheTTL  = time.Minute
+)
+
+// taskEnricher fills in the creator and assignee references of tasks
+type taskEnricher struct {
+	userRepo domain.UserRepository
+	cache    *userRefCache
+	log      logger.Logger
+}
+
+// newTaskEnricher creates a task enricher with its own cache of usernames
+func newTaskEnricher(userRepo domain.UserRepository, log logger.Logger) taskEnricher {
+	return taskEnricher{
+		userRepo: userRepo,
+		cache:    newUserRefCache(userRefCacheSize, userRefCacheTTL),
+		log:      log,
+	}
+}
+
+// enrich resolves the users referenced by the given tasks. Users not in the
+// cache are loaded with a single query, however many tasks there are. Display
+// names are a convenience, so users that cannot be loaded are referenced by ID
+// only instead of failing the request.
+func (e taskEnricher) enrich(tasks ...*domain.Task) {
+	refs := make(map[primitive.ObjectID]*domain.UserRef)
+	var missing []primitive.ObjectID
+
+	want := func(id primitive.ObjectID) {
+		if _, ok := refs[id]; ok {
+			return
+		}
+		if username, ok := e.cache.get(id); ok {
+			refs[id] = &domain.UserRef{ID: id, Username: username}
+			return
+		}
+		refs[id] = &domain.UserRef{ID: id}
+		missing = append(missing, id)
+	}
+
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		if !task.CreatedBy.IsZero() {
+			want(task.CreatedBy)
+		}
+		for _, assigneeID := range task.AssignedTo {
+			want(assigneeID)
+		}
+	}
+
+	if len(missing) > 0 {
+		users, err := e.userRepo.FindByIDs(missing)
+		if err != nil {
+			e.log.WarnF("Failed to resolve %d users for task enrichment: %v", len(missing), err)
+		}
+		for _, user := range users {
+			refs[user.ID].Username = user.Username
+			e.cache.put(user.ID, user.Username)
+		}
+	}
+
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+
+		if !task.CreatedBy.IsZero() {
+			task.Creator = refs[task.CreatedBy]
+		}
+
+		task.Assignees = make([]*domain.UserRef, 0, len(task.AssignedTo))
+		for _, assigneeID := range task.AssignedTo {
+			task.Assignees = append(task.Assignees, refs[assigneeID])
+		}
+	}
+}
+
+// userRefCache remembers the usernames of recently resolved users for a while,
+// evicting the least recently used once full. It is safe for concurrent use;
+// a nil cache remembers nothing.
+type userRefCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // of *userRefEntry, most recently used first
+	entries map[primitive.ObjectID]*list.Element
+}
+
+// userRefEntry is a cached username
+type userRefEntry struct {
+	id       primitive.ObjectID
+	username string
+	expires  time.Time
+}
+
+// newUserRefCache creates a cache holding up to size usernames for ttl each
+func newUserRefCache(size int, ttl time.Duration) *userRefCache {
+	return &userRefCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[primitive.ObjectID]*list.Element, size),
+	}
+}
+
+// get returns the cached username of a user, if it has not expired
+func (c *userRefCache) get(id primitive.ObjectID) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[id]
+	if !ok {
+		return "", false
+	}
+	entry := element.Value.(*userRefEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, id)
+		return "", false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.username, true
+}
+
+// put caches the username of a user, evicting the least recently used one when full
+func (c *userRefCache) put(id primitive.ObjectID, username string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttl)
+	if element, ok := c.entries[id]; ok {
+		entry := element.Value.(*userRefEntry)
+		entry.username, entry.expires = username, expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[id] = c.order.PushFront(&userRefEntry{id: id, username: username, expires: expires})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*userRefEntry).id)
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EscalationPolicy is the rule deciding which overdue tasks are escalated and who hears about it
+type EscalationPolicy struct {
+	// MinPriority is the lowest priority escalated
+	MinPriority int
+	// Margin is how long past its due date an open task is escalated
+	Margin time.Duration
+	// NotifyProjectAdmin also notifies an admin of the task's project, besides its creator
+	NotifyProjectAdmin bool
+}
+
+// EscalationUseCase escalates open high-priority tasks that are well past their due date
+type EscalationUseCase struct {
+	taskRepo    domain.TaskRepository
+	projectRepo domain.ProjectRepository
+	events      domain.EventPublisher
+	policy      EscalationPolicy
+	log         logger.Logger
+}
+
+// NewEscalationUseCase creates a new escalation use case
+func NewEscalationUseCase(
+	taskRepo domain.TaskRepository,
+	projectRepo domain.ProjectRepository,
+	events domain.EventPublisher,
+	policy EscalationPolicy,
+	log logger.Logger,
+) *EscalationUseCase {
+	return &EscalationUseCase{
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		events:      events,
+		policy:      policy,
+		log:         log,
+	}
+}
+
+// EscalateOverdue flags up to limit open tasks of at least the policy's priority
+// whose due date passed more than the margin before now, notifying their creator
+// and, if the policy says so, an admin of their project. Each task is escalated
+// once until it is rescheduled. It returns the number of tasks escalated.
+func (uc *EscalationUseCase) EscalateOverdue(now time.Time, limit int64) (int, error) {
+	// Tasks without a due date are stored with the zero time and are excluded
+	tasks, err := uc.taskRepo.FindAll(map[string]interface{}{
+		"status":       map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+		"priority":     map[string]interface{}{"$gte": uc.policy.MinPriority},
+		"due_date":     map[string]interface{}{"$gt": time.Time{}, "$lt": now.Add(-uc.policy.Margin)},
+		"escalated_at": map[string]interface{}{"$exists": false},
+	}, domain.Page(primitive.NilObjectID, limit))
+	if err != nil {
+		return 0, err
+	}
+
+	admins := make(map[primitive.ObjectID]primitive.ObjectID)
+	escalated := 0
+	for _, task := range tasks {
+		escalatedAt := now
+		task.EscalatedAt = &escalatedAt
+		if err := uc.taskRepo.ForOrg(task.OrgID).Update(task); err != nil {
+			return escalated, err
+		}
+		escalated++
+
+		var adminID primitive.ObjectID
+		if uc.policy.NotifyProjectAdmin && !task.ProjectID.IsZero() {
+			id, ok := admins[task.ProjectID]
+			if !ok {
+				if id, err = uc.projectAdmin(task.ProjectID); err != nil {
+					uc.log.ErrorF("Failed to find an admin of project %s to escalate task %s to: %v", task.ProjectID.Hex(), task.ID.Hex(), err)
+				}
+				admins[task.ProjectID] = id
+			}
+			adminID = id
+		}
+
+		if uc.events != nil {
+			uc.events.Publish(taskEvent(domain.EventTaskEscalated, primitive.NilObjectID, adminID, task))
+		}
+	}
+
+	return escalated, nil
+}
+
+// projectAdmin returns the first admin of a project, or the zero ID if it has none
+func (uc *EscalationUseCase) projectAdmin(projectID primitive.ObjectID) (primitive.ObjectID, error) {
+	project, err := uc.projectRepo.FindByID(projectID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return primitive.NilObjectID, nil
+		}
+		return primitive.NilObjectID, err
+	}
+
+	for _, member := range project.Members {
+		if member.Role == domain.ProjectRoleAdmin {
+			return member.UserID, nil
+		}
+	}
+	return primitive.NilObjectID, nil
+}
@@ -0,0 +1,538 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// exportPageSize is the number of tasks loaded per query when exporting
+const exportPageSize = 500
+
+// ExportUseCase moves an organization's data between instances in the export
+// format. Imports keep the exported IDs and timestamps and skip records that
+// already exist, so an interrupted import can simply be run again.
+type ExportUseCase struct {
+	orgRepo        domain.OrganizationRepository
+	userRepo       domain.UserRepository
+	projectRepo    domain.ProjectRepository
+	taskRepo       domain.TaskRepository
+	attachmentRepo domain.AttachmentRepository
+	counterRepo    domain.CounterRepository
+	importRepo     domain.ImportRepository
+	audit          auditLog
+	log            logger.Logger
+}
+
+// NewExportUseCase creates a new export use case
+func NewExportUseCase(
+	orgRepo domain.OrganizationRepository,
+	userRepo domain.UserRepository,
+	projectRepo domain.ProjectRepository,
+	taskRepo domain.TaskRepository,
+	attachmentRepo domain.AttachmentRepository,
+	counterRepo domain.CounterRepository,
+	importRepo domain.ImportRepository,
+	auditRepo domain.AuditRepository,
+	log logger.Logger,
+) *ExportUseCase {
+	return &ExportUseCase{
+		orgRepo:        orgRepo,
+		userRepo:       userRepo,
+		projectRepo:    projectRepo,
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		counterRepo:    counterRepo,
+		importRepo:     importRepo,
+		audit:          auditLog{repo: auditRepo, log: log},
+		log:            log,
+	}
+}
+
+// TransferOptions controls what exports include and imports accept
+type TransferOptions struct {
+	// Credentials includes password hashes, so users can sign in after the move.
+	// Only operators exporting and importing from the command line set it.
+	Credentials bool
+}
+
+// Export exports the organization of an organization admin, without credentials
+func (uc *ExportUseCase) Export(orgID string, userID string) (*domain.Export, error) {
+	admin, err := requireOrgAdmin(uc.userRepo, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.ExportOrganization(admin.OrgID, TransferOptions{})
+}
+
+// ExportOrganization exports an organization: its users, projects, tasks and the
+// metadata of its ready attachments
+func (uc *ExportUseCase) ExportOrganization(orgID primitive.ObjectID, opts TransferOptions) (*domain.Export, error) {
+	org, err := uc.orgRepo.FindByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &domain.Export{
+		Format:     domain.ExportFormat,
+		Version:    domain.ExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Organization: domain.ExportedOrganization{
+			ID:        org.ID,
+			Name:      org.Name,
+			CreatedBy: org.CreatedBy,
+			CreatedAt: org.CreatedAt,
+		},
+		Users:       []domain.ExportedUser{},
+		Projects:    []domain.ExportedProject{},
+		Tasks:       []domain.ExportedTask{},
+		Attachments: []domain.ExportedAttachment{},
+	}
+
+	users, err := uc.userRepo.FindByOrg(org.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		exported := domain.ExportedUser{
+			ID:        user.ID,
+			Username:  user.Username,
+			Email:     user.Email,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Timezone:  user.Timezone,
+			OrgRole:   user.OrgRole,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		}
+		if opts.Credentials {
+			exported.PasswordHash = user.Password
+			exported.PasswordChangedAt = user.PasswordChangedAt
+		}
+		export.Users = append(export.Users, exported)
+	}
+
+	projects, err := uc.projectRepo.FindByOrg(org.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		export.Projects = append(export.Projects, domain.ExportedProject{
+			ID:          project.ID,
+			Key:         project.Key,
+			Name:        project.Name,
+			Description: project.Description,
+			Members:     project.Members,
+			CreatedBy:   project.CreatedBy,
+			CreatedAt:   project.CreatedAt,
+			UpdatedAt:   project.UpdatedAt,
+		})
+	}
+
+	taskRepo := uc.taskRepo.ForOrg(org.ID)
+	for after := primitive.NilObjectID; ; {
+		tasks, err := taskRepo.FindAll(nil, domain.Page(after, exportPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			export.Tasks = append(export.Tasks, domain.ExportedTask{
+				ID:          task.ID,
+				ProjectID:   task.ProjectID,
+				Number:      task.Number,
+				Key:         task.Key,
+				Title:       task.Title,
+				Description: task.Description,
+				Status:      task.Status,
+				Priority:    task.Priority,
+				DueDate:     task.DueDate,
+				AssignedTo:  task.AssignedTo,
+				CreatedBy:   task.CreatedBy,
+				CreatedAt:   task.CreatedAt,
+				UpdatedAt:   task.UpdatedAt,
+			})
+		}
+		if len(tasks) < exportPageSize {
+			break
+		}
+		after = tasks[len(tasks)-1].ID
+	}
+
+	attachments, err := uc.attachmentRepo.FindByOrg(org.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		// Pending uploads have no content yet, and quarantined ones must not travel
+		if attachment.Status != domain.AttachmentStatusReady || attachment.Scan == domain.ScanStatusQuarantined {
+			continue
+		}
+		export.Attachments = append(export.Attachments, domain.ExportedAttachment{
+			ID:          attachment.ID,
+			TaskID:      attachment.TaskID,
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			StorageKey:  attachment.StorageKey,
+			UploadedBy:  attachment.UploadedBy,
+			CreatedAt:   attachment.CreatedAt,
+			UpdatedAt:   attachment.UpdatedAt,
+		})
+	}
+
+	return export, nil
+}
+
+// ImportCount is how many records of a kind an import inserted, and how many it
+// skipped as already present
+type ImportCount struct {
+	Imported int64 `json:"imported"`
+	Skipped  int64 `json:"skipped"`
+}
+
+// ImportSummary reports what an import did
+type ImportSummary struct {
+	OrgID       primitive.ObjectID `json:"org_id"`
+	Users       ImportCount        `json:"users"`
+	Projects    ImportCount        `json:"projects"`
+	Tasks       ImportCount        `json:"tasks"`
+	Attachments ImportCount        `json:"attachments"`
+}
+
+// ImportInput represents input data for an import by an organization admin
+type ImportInput struct {
+	OrgID  string
+	UserID string
+	Data   *domain.Export
+	Client ClientInfo
+}
+
+// Import imports an export into the organization of an organization admin.
+// Credentials are not imported: imported users cannot sign in until an operator
+// imports them with credentials.
+func (uc *ExportUseCase) Import(input *ImportInput) (*ImportSummary, error) {
+	admin, err := requireOrgAdmin(uc.userRepo, input.OrgID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := uc.ImportOrganization(admin.OrgID, input.Data, TransferOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      admin.OrgID,
+		Action:     domain.AuditOrganizationImported,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetOrganization,
+		TargetID:   admin.OrgID,
+		Details: map[string]string{
+			"source":      input.Data.Organization.ID.Hex(),
+			"users":       strconv.FormatInt(summary.Users.Imported, 10),
+			"projects":    strconv.FormatInt(summary.Projects.Imported, 10),
+			"tasks":       strconv.FormatInt(summary.Tasks.Imported, 10),
+			"attachments": strconv.FormatInt(summary.Attachments.Imported, 10),
+		},
+	}, input.Client)
+
+	return summary, nil
+}
+
+// ImportOrganization imports an export into an organization. With the zero ID it
+// imports into the exported organization, creating it when it does not exist.
+//
+// Users are matched by ID, then by email: exported users that already belong to
+// the organization are kept as they are, and references to them are rewritten.
+// The import is refused, before anything is written, when a user belongs to
+// another organization or a project key is taken.
+func (uc *ExportUseCase) ImportOrganization(orgID primitive.ObjectID, data *domain.Export, opts TransferOptions) (*ImportSummary, error) {
+	if err := validateExport(data); err != nil {
+		return nil, err
+	}
+
+	if orgID.IsZero() {
+		orgID = data.Organization.ID
+		if _, err := uc.importRepo.InsertOrganization(&domain.Organization{
+			ID:        orgID,
+			Name:      data.Organization.Name,
+			CreatedBy: data.Organization.CreatedBy,
+			CreatedAt: data.Organization.CreatedAt,
+			UpdatedAt: data.Organization.CreatedAt,
+		}); err != nil {
+			return nil, err
+		}
+	} else if _, err := uc.orgRepo.FindByID(orgID); err != nil {
+		return nil, err
+	}
+
+	users, userIDs, err := uc.importedUsers(orgID, data.Users, opts)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := uc.importedProjects(orgID, data.Projects, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	tasks, counters := importedTasks(orgID, data.Tasks, userIDs)
+	attachments, err := importedAttachments(orgID, data.Attachments, data.Tasks, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ImportSummary{OrgID: orgID}
+	if summary.Users.Imported, err = uc.importRepo.InsertUsers(users); err != nil {
+		return nil, err
+	}
+	if summary.Projects.Imported, err = uc.importRepo.InsertProjects(projects); err != nil {
+		return nil, err
+	}
+	if summary.Tasks.Imported, err = uc.importRepo.InsertTasks(tasks); err != nil {
+		return nil, err
+	}
+	if summary.Attachments.Imported, err = uc.importRepo.InsertAttachments(attachments); err != nil {
+		return nil, err
+	}
+	summary.Users.Skipped = int64(len(data.Users)) - summary.Users.Imported
+	summary.Projects.Skipped = int64(len(data.Projects)) - summary.Projects.Imported
+	summary.Tasks.Skipped = int64(len(data.Tasks)) - summary.Tasks.Imported
+	summary.Attachments.Skipped = int64(len(data.Attachments)) - summary.Attachments.Imported
+
+	// New tasks must not reuse the numbers of imported ones
+	for projectID, number := range counters {
+		if err := uc.counterRepo.Raise(domain.ProjectTaskCounter(projectID), number); err != nil {
+			return nil, err
+		}
+	}
+
+	return summary, nil
+}
+
+// importedUsers returns the users to insert, and the IDs the exported user IDs
+// map to. Users already in the organization are mapped to, not inserted.
+func (uc *ExportUseCase) importedUsers(orgID primitive.ObjectID, exported []domain.ExportedUser, opts TransferOptions) ([]*domain.User, map[primitive.ObjectID]primitive.ObjectID, error) {
+	users := []*domain.User{}
+	ids := make(map[primitive.ObjectID]primitive.ObjectID, len(exported))
+
+	// Users kept their IDs when exported from this deployment; look them all up at once
+	exportedIDs := make([]primitive.ObjectID, 0, len(exported))
+	for _, e := range exported {
+		exportedIDs = append(exportedIDs, e.ID)
+	}
+	found, err := uc.userRepo.FindByIDs(exportedIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[primitive.ObjectID]*domain.User, len(found))
+	for _, user := range found {
+		byID[user.ID] = user
+	}
+
+	for _, e := range exported {
+		var err error
+		existing, ok := byID[e.ID]
+		if !ok {
+			existing, err = uc.userRepo.FindByEmail(e.Email)
+		}
+		switch {
+		case err == nil && existing.OrgID == orgID:
+			ids[e.ID] = existing.ID
+			continue
+		case err == nil:
+			return nil, nil, fmt.Errorf("%w: user %s belongs to another organization", domain.ErrDuplicateKey, e.Email)
+		case !errors.Is(err, domain.ErrNotFound):
+			return nil, nil, err
+		}
+
+		if _, err := uc.userRepo.FindByUsername(e.Username); err == nil {
+			return nil, nil, fmt.Errorf("%w: username %s is taken", domain.ErrDuplicateKey, e.Username)
+		} else if !errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, err
+		}
+
+		user := &domain.User{
+			ID:        e.ID,
+			Username:  e.Username,
+			Email:     e.Email,
+			FirstName: e.FirstName,
+			LastName:  e.LastName,
+			Timezone:  e.Timezone,
+			OrgID:     orgID,
+			OrgRole:   e.OrgRole,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		}
+		if opts.Credentials {
+			user.Password = e.PasswordHash
+			user.PasswordChangedAt = e.PasswordChangedAt
+		}
+		ids[e.ID] = e.ID
+		users = append(users, user)
+	}
+
+	return users, ids, nil
+}
+
+// importedProjects returns the projects to insert, with their members mapped
+func (uc *ExportUseCase) importedProjects(orgID primitive.ObjectID, exported []domain.ExportedProject, userIDs map[primitive.ObjectID]primitive.ObjectID) ([]*domain.Project, error) {
+	projects := []*domain.Project{}
+
+	for _, e := range exported {
+		existing, err := uc.projectRepo.FindByID(e.ID)
+		switch {
+		case err == nil && existing.OrgID == orgID:
+			continue
+		case err == nil:
+			return nil, fmt.Errorf("%w: project %s belongs to another organization", domain.ErrDuplicateKey, e.ID.Hex())
+		case !errors.Is(err, domain.ErrNotFound):
+			return nil, err
+		}
+
+		if e.Key != "" {
+			if _, err := uc.projectRepo.FindByKey(orgID, e.Key); err == nil {
+				return nil, fmt.Errorf("%w: project key %s is taken", domain.ErrDuplicateKey, e.Key)
+			} else if !errors.Is(err, domain.ErrNotFound) {
+				return nil, err
+			}
+		}
+
+		members := make([]domain.ProjectMember, 0, len(e.Members))
+		for _, member := range e.Members {
+			members = append(members, domain.ProjectMember{UserID: mapID(userIDs, member.UserID), Role: member.Role})
+		}
+		projects = append(projects, &domain.Project{
+			ID:          e.ID,
+			OrgID:       orgID,
+			Key:         e.Key,
+			Name:        e.Name,
+			Description: e.Description,
+			Members:     members,
+			CreatedBy:   mapID(userIDs, e.CreatedBy),
+			CreatedAt:   e.CreatedAt,
+			UpdatedAt:   e.UpdatedAt,
+		})
+	}
+
+	return projects, nil
+}
+
+// importedTasks returns the tasks to insert, with their users mapped, and the
+// highest task number of each project
+func importedTasks(orgID primitive.ObjectID, exported []domain.ExportedTask, userIDs map[primitive.ObjectID]primitive.ObjectID) ([]*domain.Task, map[primitive.ObjectID]int64) {
+	tasks := make([]*domain.Task, 0, len(exported))
+	counters := map[primitive.ObjectID]int64{}
+
+	for _, e := range exported {
+		var assignees []primitive.ObjectID
+		for _, id := range e.AssignedTo {
+			assignees = append(assignees, mapID(userIDs, id))
+		}
+		tasks = append(tasks, &domain.Task{
+			ID:          e.ID,
+			OrgID:       orgID,
+			ProjectID:   e.ProjectID,
+			Number:      e.Number,
+			Key:         e.Key,
+			Title:       e.Title,
+			Description: e.Description,
+			Status:      e.Status,
+			Priority:    e.Priority,
+			DueDate:     e.DueDate,
+			AssignedTo:  assignees,
+			CreatedBy:   mapID(userIDs, e.CreatedBy),
+			CreatedAt:   e.CreatedAt,
+			UpdatedAt:   e.UpdatedAt,
+		})
+
+		if !e.ProjectID.IsZero() && e.Number > counters[e.ProjectID] {
+			counters[e.ProjectID] = e.Number
+		}
+	}
+
+	return tasks, counters
+}
+
+// importedAttachments returns the attachments to insert, which must belong to exported tasks
+func importedAttachments(orgID primitive.ObjectID, exported []domain.ExportedAttachment, tasks []domain.ExportedTask, userIDs map[primitive.ObjectID]primitive.ObjectID) ([]*domain.Attachment, error) {
+	taskIDs := make(map[primitive.ObjectID]bool, len(tasks))
+	for _, task := range tasks {
+		taskIDs[task.ID] = true
+	}
+
+	attachments := make([]*domain.Attachment, 0, len(exported))
+	for _, e := range exported {
+		if !taskIDs[e.TaskID] {
+			return nil, fmt.Errorf("%w: attachment %s belongs to a task missing from the export", domain.ErrInvalidInput, e.ID.Hex())
+		}
+		attachments = append(attachments, &domain.Attachment{
+			ID:          e.ID,
+			OrgID:       orgID,
+			TaskID:      e.TaskID,
+			Name:        e.Name,
+			ContentType: e.ContentType,
+			Size:        e.Size,
+			Status:      domain.AttachmentStatusReady,
+			StorageKey:  e.StorageKey,
+			UploadedBy:  mapID(userIDs, e.UploadedBy),
+			CreatedAt:   e.CreatedAt,
+			UpdatedAt:   e.UpdatedAt,
+		})
+	}
+
+	return attachments, nil
+}
+
+// validateExport checks that a document is an export this release can import
+func validateExport(data *domain.Export) error {
+	if data == nil || data.Format != domain.ExportFormat {
+		return fmt.Errorf("%w: not an export document", domain.ErrInvalidInput)
+	}
+	if data.Version < 1 || data.Version > domain.ExportVersion {
+		return fmt.Errorf("%w: unsupported export version %d; this release reads versions up to %d", domain.ErrInvalidInput, data.Version, domain.ExportVersion)
+	}
+	if data.Organization.ID.IsZero() {
+		return fmt.Errorf("%w: the organization ID is missing", domain.ErrInvalidInput)
+	}
+
+	for _, user := range data.Users {
+		if user.ID.IsZero() || user.Username == "" || user.Email == "" {
+			return fmt.Errorf("%w: users need an ID, username and email", domain.ErrInvalidInput)
+		}
+	}
+	for _, project := range data.Projects {
+		if project.ID.IsZero() || project.Name == "" {
+			return fmt.Errorf("%w: projects need an ID and name", domain.ErrInvalidInput)
+		}
+	}
+	for _, task := range data.Tasks {
+		if task.ID.IsZero() || task.Title == "" {
+			return fmt.Errorf("%w: tasks need an ID and title", domain.ErrInvalidInput)
+		}
+		if task.Status != "" && !task.Status.IsValid() {
+			return fmt.Errorf("%w: task %s has unknown status %q", domain.ErrInvalidInput, task.ID.Hex(), task.Status)
+		}
+	}
+	for _, attachment := range data.Attachments {
+		if attachment.ID.IsZero() || attachment.StorageKey == "" {
+			return fmt.Errorf("%w: attachments need an ID and storage key", domain.ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
+
+// mapID returns the ID an exported user ID maps to. Users missing from the
+// export, such as former members, keep their ID.
+func mapID(ids map[primitive.ObjectID]primitive.ObjectID, id primitive.ObjectID) primitive.ObjectID {
+	if mapped, ok := ids[id]; ok {
+		return mapped
+	}
+	return id
+}
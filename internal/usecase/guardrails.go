@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// defaultMaxDueDateRange is the widest due date range listed without narrower
+// criteria when the guardrails set none
+const defaultMaxDueDateRange = 366 * 24 * time.Hour
+
+// QueryGuardrails bounds the task list queries that would otherwise scan a large
+// part of an organization's tasks
+type QueryGuardrails struct {
+	// MaxDueDateRange is the widest due date range listed without a project,
+	// sprint or milestone filter; zero uses the default of a year
+	MaxDueDateRange time.Duration
+}
+
+// check rejects list filters that are too expensive on their own. Due date
+// ranges must be closed and no wider than the maximum, and tags, which are not
+// indexed, can only be matched within a project, sprint or milestone. Either is
+// allowed without bounds once the query is narrowed to one of them.
+func (g QueryGuardrails) check(input *ListTasksInput) error {
+	if input.ProjectID != "" || input.MilestoneID != "" || (input.SprintID != "" && input.SprintID != SprintNone) {
+		return nil
+	}
+
+	if input.Tag != "" {
+		return fmt.Errorf("%w: filtering by tag requires a project, sprint or milestone filter", domain.ErrInvalidInput)
+	}
+
+	if input.DueAfter.IsZero() && input.DueBefore.IsZero() {
+		return nil
+	}
+	if input.DueAfter.IsZero() || input.DueBefore.IsZero() {
+		return fmt.Errorf("%w: due date ranges need both due_after and due_before unless filtered by project, sprint or milestone", domain.ErrInvalidInput)
+	}
+
+	maxRange := g.MaxDueDateRange
+	if maxRange <= 0 {
+		maxRange = defaultMaxDueDateRange
+	}
+	if input.DueBefore.Sub(input.DueAfter) > maxRange {
+		return fmt.Errorf("%w: due date ranges may span at most %d days unless filtered by project, sprint or milestone", domain.ErrInvalidInput, int(maxRange/(24*time.Hour)))
+	}
+	return nil
+}
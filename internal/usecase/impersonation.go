@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxImpersonationReasonLength caps the reason recorded for an impersonation
+const maxImpersonationReasonLength = 500
+
+// errImpersonationDisabled is returned when impersonation is not enabled in the configuration
+var errImpersonationDisabled = fmt.Errorf("%w: impersonation is disabled", domain.ErrInvalidInput)
+
+// ImpersonateInput represents an organization admin's request for a token acting as a member
+type ImpersonateInput struct {
+	OrgID    string
+	UserID   string // The admin
+	MemberID string // The member to act as
+	// Reason is recorded in the audit log, e.g. the support ticket being debugged
+	Reason string
+	// Scopes optionally narrows the token to fewer scopes than the member may be granted
+	Scopes []string
+	Client ClientInfo
+}
+
+// ImpersonationOutput represents an impersonation token and the session to revoke to end it
+type ImpersonationOutput struct {
+	LoginOutput
+	SessionID string `json:"session_id"`
+}
+
+// Impersonate starts a session acting as a member of the organization and issues
+// its token. Only organization admins may do so, not while impersonating, and only
+// when impersonation is enabled. The token names the admin in its act claim, lasts
+// the configured impersonation expiry and cannot be refreshed. It stops working
+// once the session is revoked, the member signs out everywhere or the admin loses
+// the admin role.
+func (uc *AuthUseCase) Impersonate(input *ImpersonateInput) (*ImpersonationOutput, error) {
+	if !uc.tokens.Impersonation {
+		return nil, errImpersonationDisabled
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required", domain.ErrInvalidInput)
+	}
+	if len(reason) > maxImpersonationReasonLength {
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", domain.ErrInvalidInput, maxImpersonationReasonLength)
+	}
+
+	scopes, err := parseScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	// An impersonation token must not start another, which would hide the admin behind it
+	if input.Client.impersonating() {
+		return nil, domain.ErrUnauthorized
+	}
+
+	admin, err := requireOrgAdmin(uc.userRepo, input.OrgID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberID, err := primitive.ObjectIDFromHex(input.MemberID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	if memberID == admin.ID {
+		return nil, fmt.Errorf("%w: admins cannot impersonate themselves", domain.ErrInvalidInput)
+	}
+
+	member, err := uc.userRepo.FindByID(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.OrgID != admin.OrgID {
+		return nil, domain.ErrNotFound
+	}
+
+	session := &domain.Session{
+		UserID:         member.ID,
+		Scopes:         scopes,
+		UserAgent:      input.Client.UserAgent,
+		IP:             input.Client.IP,
+		ExpiresAt:      time.Now().Add(uc.tokens.ImpersonationExpiry),
+		ImpersonatorID: admin.ID,
+	}
+	if err := uc.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	output, err := uc.issueToken(member, session)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.WarnF("User %s started impersonating user %s in session %s: %s", admin.ID.Hex(), member.ID.Hex(), session.ID.Hex(), reason)
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      admin.OrgID,
+		Action:     domain.AuditImpersonationStarted,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   member.ID,
+		Details: map[string]string{
+			"session_id": session.ID.Hex(),
+			"reason":     reason,
+			"expires_at": output.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	}, input.Client)
+
+	return &ImpersonationOutput{LoginOutput: *output, SessionID: session.ID.Hex()}, nil
+}
+
+// RevokeImpersonationInput represents an organization admin's request to end an impersonation
+type RevokeImpersonationInput struct {
+	OrgID     string
+	UserID    string // The admin
+	SessionID string
+	Client    ClientInfo
+}
+
+// RevokeImpersonation ends an impersonation of a member of the organization; its
+// token stops working immediately. Any organization admin may end it, not only
+// the one who started it. Impersonations that have already ended are not found.
+func (uc *AuthUseCase) RevokeImpersonation(input *RevokeImpersonationInput) error {
+	admin, err := requireOrgAdmin(uc.userRepo, input.OrgID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	id, err := primitive.ObjectIDFromHex(input.SessionID)
+	if err != nil {
+		return errors.New("invalid session ID format")
+	}
+
+	session, err := uc.sessionRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !session.IsImpersonation() || !session.IsActive(now) {
+		return domain.ErrNotFound
+	}
+
+	member, err := uc.userRepo.FindByID(session.UserID)
+	if err != nil {
+		return err
+	}
+	if member.OrgID != admin.OrgID {
+		return domain.ErrNotFound
+	}
+
+	session.RevokedAt = &now
+	if err := uc.sessionRepo.Update(session); err != nil {
+		return err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      admin.OrgID,
+		Action:     domain.AuditImpersonationRevoked,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   member.ID,
+		Details: map[string]string{
+			"session_id":      session.ID.Hex(),
+			"impersonator_id": session.ImpersonatorID.Hex(),
+		},
+	}, input.Client)
+
+	return nil
+}
+
+// verifyImpersonation checks that a token names the admin of its session in its act
+// claim and that the impersonation may go on: impersonation is still enabled and the
+// admin is still an admin of the user's organization. Other tokens pass unless they
+// carry an act claim.
+func (uc *AuthUseCase) verifyImpersonation(claims *Claims, session *domain.Session, user *domain.User) error {
+	if !session.IsImpersonation() {
+		if claims.Actor != nil {
+			return errors.New("invalid token")
+		}
+		return nil
+	}
+
+	if claims.Actor == nil || claims.Actor.UserID != session.ImpersonatorID.Hex() {
+		return errors.New("invalid token")
+	}
+	if !uc.tokens.Impersonation {
+		return errors.New("impersonation has been disabled")
+	}
+
+	admin, err := uc.userRepo.FindByID(session.ImpersonatorID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if err != nil || authorizeOrganization(admin, user.OrgID, ActionAdminister) != nil {
+		return errors.New("impersonation has ended")
+	}
+
+	return nil
+}
+
+// impersonatorID returns the admin acting as the user of a session, or "" for the user's own sessions
+func impersonatorID(session *domain.Session) string {
+	if !session.IsImpersonation() {
+		return ""
+	}
+	return session.ImpersonatorID.Hex()
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Limits of inbound hooks
+const (
+	maxInboundHooks       = 20   // per project
+	maxHookTemplateLength = 2000 // characters per mapping template
+)
+
+// defaultHookPriority is the priority of tasks whose payload names none
+const defaultHookPriority = 3
+
+// defaultHookMapping reads each task field from the payload field of the same name
+var defaultHookMapping = map[string]string{
+	domain.HookFieldTitle:       "{{.title}}",
+	domain.HookFieldDescription: "{{.description}}",
+	domain.HookFieldPriority:    "{{.priority}}",
+	domain.HookFieldDueDate:     "{{.due_date}}",
+	domain.HookFieldTags:        "{{join .tags}}",
+}
+
+// hookTemplateFuncs are the functions available to mapping templates
+var hookTemplateFuncs = template.FuncMap{
+	// join turns a list, such as the labels of a payload, into comma-separated tags
+	"join": func(value interface{}) string {
+		switch v := value.(type) {
+		case []interface{}:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			return strings.Join(parts, ",")
+		case nil:
+			return ""
+		default:
+			return fmt.Sprint(v)
+		}
+	},
+}
+
+// InboundHookUseCase handles business logic related to inbound hooks, which create
+// tasks from JSON posted by external integrations
+type InboundHookUseCase struct {
+	hookRepo    domain.InboundHookRepository
+	userRepo    domain.UserRepository
+	taskUseCase *TaskUseCase
+	policy      *Policy
+	log         logger.Logger
+}
+
+// NewInboundHookUseCase creates a new inbound hook use case
+func NewInboundHookUseCase(hookRepo domain.InboundHookRepository, userRepo domain.UserRepository, taskUseCase *TaskUseCase, policy *Policy, log logger.Logger) *InboundHookUseCase {
+	return &InboundHookUseCase{
+		hookRepo:    hookRepo,
+		userRepo:    userRepo,
+		taskUseCase: taskUseCase,
+		policy:      policy,
+		log:         log,
+	}
+}
+
+// CreateInboundHookInput represents input data for inbound hook creation
+type CreateInboundHookInput struct {
+	OrgID     string
+	ProjectID string
+	Name      string
+	CreatorID string            // User the tasks are created on behalf of; the hook's creator when empty
+	Mapping   map[string]string // Templates per task field; see domain.InboundHook
+	CreatedBy string
+}
+
+// CreateInboundHookOutput is a new inbound hook together with its token
+type CreateInboundHookOutput struct {
+	Hook  *domain.InboundHook
+	Token string // Only returned here; the hook stores its hash
+}
+
+// CreateHook creates an inbound hook for a project. Only project admins may do
+// so, and tasks can only be created on behalf of contributors and admins.
+func (uc *InboundHookUseCase) CreateHook(input *CreateInboundHookInput) (*CreateInboundHookOutput, error) {
+	admin, project, err := uc.authorizeProject(input.OrgID, input.ProjectID, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+
+	if err := validateHookMapping(input.Mapping); err != nil {
+		return nil, err
+	}
+
+	// The creator must be able to add tasks to the project
+	creator := admin
+	if input.CreatorID != "" && input.CreatorID != admin.ID.Hex() {
+		creatorID, err := primitive.ObjectIDFromHex(input.CreatorID)
+		if err != nil {
+			return nil, errors.New("invalid creator ID format")
+		}
+		if creator, err = uc.userRepo.FindByID(creatorID); err != nil || creator.OrgID != project.OrgID {
+			return nil, fmt.Errorf("%w: creator not found", domain.ErrInvalidInput)
+		}
+		if creator.IsDeactivated() {
+			return nil, fmt.Errorf("%w: creator is deactivated", domain.ErrInvalidInput)
+		}
+	}
+	if _, err := uc.policy.AuthorizeProject(creator, project.ID, domain.ProjectRoleContributor); err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			return nil, fmt.Errorf("%w: creator must be a contributor or admin of the project", domain.ErrInvalidInput)
+		}
+		return nil, err
+	}
+
+	hooks, err := uc.hookRepo.FindByProject(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(hooks) >= maxInboundHooks {
+		return nil, fmt.Errorf("%w: a project has at most %d inbound hooks", domain.ErrInvalidInput, maxInboundHooks)
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &domain.InboundHook{
+		OrgID:     project.OrgID,
+		ProjectID: project.ID,
+		Name:      name,
+		TokenHash: hashSecretToken(token),
+		CreatorID: creator.ID,
+		Mapping:   input.Mapping,
+		CreatedBy: admin.ID,
+	}
+	if err := uc.hookRepo.Create(hook); err != nil {
+		return nil, err
+	}
+
+	return &CreateInboundHookOutput{Hook: hook, Token: token}, nil
+}
+
+// ListHooks retrieves the inbound hooks of a project. Only project admins may do so.
+func (uc *InboundHookUseCase) ListHooks(orgID string, projectID string, userID string) ([]*domain.InboundHook, error) {
+	_, project, err := uc.authorizeProject(orgID, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.hookRepo.FindByProject(project.ID)
+}
+
+// DeleteHook deletes an inbound hook of a project, after which its token stops
+// working. Only project admins may do so.
+func (uc *InboundHookUseCase) DeleteHook(orgID string, projectID string, hookID string, userID string) error {
+	_, project, err := uc.authorizeProject(orgID, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	id, err := primitive.ObjectIDFromHex(hookID)
+	if err != nil {
+		return errors.New("invalid hook ID format")
+	}
+
+	hook, err := uc.hookRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if hook.ProjectID != project.ID {
+		return domain.ErrNotFound
+	}
+
+	return uc.hookRepo.Delete(hook.ID)
+}
+
+// Receive creates a task from a payload posted to an inbound hook. Unknown
+// tokens and hooks of deactivated creators are reported as not found.
+func (uc *InboundHookUseCase) Receive(token string, payload map[string]interface{}) (*domain.Task, error) {
+	if token == "" {
+		return nil, domain.ErrNotFound
+	}
+
+	hook, err := uc.hookRepo.FindByTokenHash(hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := uc.policy.Actor(hook.OrgID, hook.CreatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if creator.IsDeactivated() {
+		return nil, domain.ErrNotFound
+	}
+
+	fields, err := renderHookFields(hook.Mapping, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &CreateTaskInput{
+		Title:       fields[domain.HookFieldTitle],
+		Description: fields[domain.HookFieldDescription],
+		Priority:    defaultHookPriority,
+		CreatedBy:   creator.ID.Hex(),
+		OrgID:       hook.OrgID.Hex(),
+		ProjectID:   hook.ProjectID.Hex(),
+	}
+	if input.Title == "" {
+		return nil, fmt.Errorf("%w: the payload has no title", domain.ErrInvalidInput)
+	}
+	if priority := fields[domain.HookFieldPriority]; priority != "" {
+		if input.Priority, err = strconv.Atoi(priority); err != nil || input.Priority < 1 || input.Priority > 5 {
+			return nil, fmt.Errorf("%w: priority must be a number between 1 and 5", domain.ErrInvalidInput)
+		}
+	}
+	if dueDate := fields[domain.HookFieldDueDate]; dueDate != "" {
+		if input.DueDate, err = time.Parse(time.RFC3339, dueDate); err != nil {
+			return nil, fmt.Errorf("%w: due date must be formatted as RFC 3339", domain.ErrInvalidInput)
+		}
+	}
+	for _, tag := range strings.Split(fields[domain.HookFieldTags], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			input.Tags = append(input.Tags, tag)
+		}
+	}
+
+	task, err := uc.taskUseCase.CreateTask(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// The task is created even when the hook's use cannot be recorded
+	if err := uc.hookRepo.Touch(hook.ID, time.Now()); err != nil {
+		uc.log.WarnF("Failed to record use of inbound hook %s: %v", hook.ID.Hex(), err)
+	}
+
+	return task, nil
+}
+
+// authorizeProject loads the acting user and a project they administer
+func (uc *InboundHookUseCase) authorizeProject(orgID string, projectID string, userID string) (*domain.User, *domain.Project, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, errors.New("invalid user ID format")
+	}
+
+	projectObjID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, nil, errors.New("invalid project ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project, err := uc.policy.AuthorizeProject(user, projectObjID, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, project, nil
+}
+
+// validateHookMapping checks that a mapping only fills in known task fields
+// with templates that parse
+func validateHookMapping(mapping map[string]string) error {
+	for field, text := range mapping {
+		if _, ok := defaultHookMapping[field]; !ok {
+			return fmt.Errorf("%w: unknown mapping field %q; must be one of %s", domain.ErrInvalidInput, field, strings.Join(domain.HookFields, ", "))
+		}
+		if len(text) > maxHookTemplateLength {
+			return fmt.Errorf("%w: the %s mapping may be at most %d characters", domain.ErrInvalidInput, field, maxHookTemplateLength)
+		}
+		if _, err := parseHookTemplate(field, text); err != nil {
+			return fmt.Errorf("%w: invalid %s mapping: %v", domain.ErrInvalidInput, field, err)
+		}
+	}
+	return nil
+}
+
+// renderHookFields renders every task field of a hook's mapping with a payload.
+// Payload fields that are missing render empty.
+func renderHookFields(mapping map[string]string, payload map[string]interface{}) (map[string]string, error) {
+	fields := make(map[string]string, len(defaultHookMapping))
+	for _, field := range domain.HookFields {
+		text, ok := mapping[field]
+		if !ok {
+			text = defaultHookMapping[field]
+		}
+
+		tmpl, err := parseHookTemplate(field, text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s mapping: %v", domain.ErrInvalidInput, field, err)
+		}
+
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, payload); err != nil {
+			return nil, fmt.Errorf("%w: cannot map %s: %v", domain.ErrInvalidInput, field, err)
+		}
+		fields[field] = strings.TrimSpace(strings.ReplaceAll(out.String(), "<no value>", ""))
+	}
+	return fields, nil
+}
+
+// parseHookTemplate parses the mapping template of a task field
+func parseHookTemplate(field string, text string) (*template.Template, error) {
+	return template.New(field).Funcs(hookTemplateFuncs).Option("missingkey=zero").Parse(text)
+}
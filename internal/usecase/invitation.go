@@ -0,0 +1,457 @@
+package usecase
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:embed templates/invitation.tmpl
+var invitationTemplateText string
+
+var invitationTemplate = template.Must(template.New("invitation").Parse(invitationTemplateText))
+
+// invitationAudience marks signed invitation tokens so they are never mistaken for access tokens
+const invitationAudience = "invitation"
+
+// DefaultInvitationExpiry is how long an invitation can be accepted when no expiry is configured
+const DefaultInvitationExpiry = 7 * 24 * time.Hour
+
+// errInvitationInvalid is returned for tokens that are malformed, expired, revoked, or already used
+var errInvitationInvalid = fmt.Errorf("%w: invitation is invalid or no longer valid", domain.ErrInvalidInput)
+
+// InvitationClaims represents the claims of a signed invitation token
+type InvitationClaims struct {
+	InvitationID string `json:"invitation_id"`
+	jwt.RegisteredClaims
+}
+
+// InvitationConfig configures invitation tokens and emails
+type InvitationConfig struct {
+	// Expiry is how long an invitation can be accepted
+	Expiry time.Duration
+	// AcceptURL is the page that accepts invitations; the token is appended as the "token" query parameter
+	AcceptURL string
+	// AppName is the product name used in invitation emails
+	AppName string
+}
+
+// InvitationUseCase handles inviting users to organizations by email
+type InvitationUseCase struct {
+	invitationRepo domain.InvitationRepository
+	orgRepo        domain.OrganizationRepository
+	userRepo       domain.UserRepository
+	userUseCase    *UserUseCase
+	authUseCase    *AuthUseCase
+	sender         domain.EmailSender
+	cfg            InvitationConfig
+	audit          auditLog
+	log            logger.Logger
+}
+
+// NewInvitationUseCase creates a new invitation use case. The email sender may be
+// nil, in which case invitations are only delivered through the returned token.
+// Created and revoked invitations are recorded in the audit log.
+func NewInvitationUseCase(
+	invitationRepo domain.InvitationRepository,
+	orgRepo domain.OrganizationRepository,
+	userRepo domain.UserRepository,
+	auditRepo domain.AuditRepository,
+	userUseCase *UserUseCase,
+	authUseCase *AuthUseCase,
+	sender domain.EmailSender,
+	cfg InvitationConfig,
+	log logger.Logger,
+) *InvitationUseCase {
+	if cfg.Expiry <= 0 {
+		cfg.Expiry = DefaultInvitationExpiry
+	}
+
+	return &InvitationUseCase{
+		invitationRepo: invitationRepo,
+		orgRepo:        orgRepo,
+		userRepo:       userRepo,
+		userUseCase:    userUseCase,
+		authUseCase:    authUseCase,
+		sender:         sender,
+		cfg:            cfg,
+		audit:          auditLog{repo: auditRepo, log: log},
+		log:            log,
+	}
+}
+
+// CreateInvitationInput represents input data for inviting a user
+type CreateInvitationInput struct {
+	OrgID     string
+	Email     string
+	Role      domain.OrgRole // defaults to member
+	InvitedBy string
+	Client    ClientInfo
+}
+
+// CreateInvitationOutput is a new invitation together with its signed token
+type CreateInvitationOutput struct {
+	Invitation *domain.Invitation
+	Token      string
+	AcceptURL  string
+}
+
+// CreateInvitation invites an email address to the organization. Only organization
+// admins may invite. Inviting an address that already has a pending invitation
+// revokes the old one, so only the latest token works.
+func (uc *InvitationUseCase) CreateInvitation(input *CreateInvitationInput) (*CreateInvitationOutput, error) {
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if !isValidEmail(email) {
+		return nil, fmt.Errorf("%w: invalid email format", domain.ErrInvalidInput)
+	}
+
+	role := input.Role
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	if role != domain.OrgRoleAdmin && role != domain.OrgRoleMember {
+		return nil, fmt.Errorf("%w: role must be %q or %q", domain.ErrInvalidInput, domain.OrgRoleAdmin, domain.OrgRoleMember)
+	}
+
+	inviter, err := requireOrgAdmin(uc.userRepo, input.OrgID, input.InvitedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Existing members need no invitation
+	existing, err := uc.userRepo.FindByEmail(email)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.OrgID == inviter.OrgID {
+		return nil, fmt.Errorf("%w: %s is already a member of the organization", domain.ErrDuplicateKey, email)
+	}
+
+	now := time.Now()
+	previous, err := uc.invitationRepo.FindPendingByEmail(inviter.OrgID, email, now)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if previous != nil {
+		previous.RevokedAt = &now
+		if err := uc.invitationRepo.Update(previous); err != nil {
+			return nil, err
+		}
+	}
+
+	invitation := &domain.Invitation{
+		ID:        primitive.NewObjectID(),
+		OrgID:     inviter.OrgID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: inviter.ID,
+		ExpiresAt: now.Add(uc.cfg.Expiry),
+	}
+	if err := uc.invitationRepo.Create(invitation); err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      inviter.OrgID,
+		Action:     domain.AuditInvitationCreated,
+		ActorID:    inviter.ID,
+		TargetType: domain.AuditTargetInvitation,
+		TargetID:   invitation.ID,
+		Details:    map[string]string{"email": email, "role": string(role)},
+	}, input.Client)
+
+	token, err := uc.signToken(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CreateInvitationOutput{
+		Invitation: invitation,
+		Token:      token,
+		AcceptURL:  uc.acceptURL(token),
+	}
+
+	// The invitation stays valid when the email cannot be sent; the admin can share the token instead
+	if uc.sender != nil {
+		if err := uc.sendInvitation(inviter, output); err != nil {
+			uc.log.ErrorF("Failed to send invitation %s to %s: %v", invitation.ID.Hex(), email, err)
+		}
+	}
+
+	return output, nil
+}
+
+// ListInvitations lists the organization's pending invitations, newest first
+func (uc *InvitationUseCase) ListInvitations(orgID string) ([]*domain.Invitation, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.invitationRepo.FindPending(org, time.Now())
+}
+
+// RevokeInvitation revokes a pending invitation. Only organization admins may do so.
+func (uc *InvitationUseCase) RevokeInvitation(orgID string, invitationID string, revokedBy string, client ClientInfo) error {
+	admin, err := requireOrgAdmin(uc.userRepo, orgID, revokedBy)
+	if err != nil {
+		return err
+	}
+
+	id, err := primitive.ObjectIDFromHex(invitationID)
+	if err != nil {
+		return errors.New("invalid invitation ID format")
+	}
+
+	invitation, err := uc.invitationRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if invitation.OrgID != admin.OrgID {
+		return domain.ErrNotFound
+	}
+
+	now := time.Now()
+	if invitation.Status(now) != domain.InvitationStatusPending {
+		return fmt.Errorf("%w: invitation is already %s", domain.ErrInvalidInput, invitation.Status(now))
+	}
+
+	invitation.RevokedAt = &now
+	if err := uc.invitationRepo.Update(invitation); err != nil {
+		return err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      admin.OrgID,
+		Action:     domain.AuditInvitationRevoked,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetInvitation,
+		TargetID:   invitation.ID,
+		Details:    map[string]string{"email": invitation.Email},
+	}, client)
+
+	return nil
+}
+
+// AcceptInvitationInput represents input data for accepting an invitation. When an
+// account with the invited email exists, Password must be its password; otherwise
+// a new account is registered from Username, Password, and the optional names.
+type AcceptInvitationInput struct {
+	Token     string
+	Username  string
+	Password  string
+	FirstName string
+	LastName  string
+	Client    ClientInfo
+}
+
+// AcceptInvitation joins the invited organization, signing in an existing account or
+// registering a new one, and returns an access token scoped to the organization
+func (uc *InvitationUseCase) AcceptInvitation(input *AcceptInvitationInput) (*LoginOutput, error) {
+	invitation, err := uc.parseToken(input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if invitation.Status(now) != domain.InvitationStatusPending {
+		return nil, errInvitationInvalid
+	}
+
+	// The organization may have been removed since the invitation was sent
+	if _, err := uc.orgRepo.FindByID(invitation.OrgID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, errInvitationInvalid
+		}
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByEmail(invitation.Email)
+	switch {
+	case err == nil:
+		user, err = uc.joinExisting(user, invitation, input.Password)
+	case errors.Is(err, domain.ErrNotFound):
+		user, err = uc.registerInvited(invitation, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.AcceptedAt = &now
+	invitation.AcceptedBy = user.ID
+	if err := uc.invitationRepo.Update(invitation); err != nil {
+		return nil, err
+	}
+
+	return uc.authUseCase.startSession(user, input.Client, nil)
+}
+
+// joinExisting moves an existing account into the invited organization after checking its password
+func (uc *InvitationUseCase) joinExisting(user *domain.User, invitation *domain.Invitation, password string) (*domain.User, error) {
+	if !uc.authUseCase.checkPassword(user, password) {
+		return nil, fmt.Errorf("%w: invalid login credentials", domain.ErrUnauthorized)
+	}
+
+	if user.OrgID == invitation.OrgID {
+		return user, nil
+	}
+
+	// Leaving must not strand the previous organization without an admin
+	if user.IsOrgAdmin() {
+		members, err := uc.userRepo.FindByOrg(user.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) > 1 {
+			if err := ensureOtherOrgAdmin(uc.userRepo, user); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	user.OrgID = invitation.OrgID
+	user.OrgRole = invitation.Role
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Tokens issued for the previous organization stop working; the session
+	// started for the invitation gets a token of the new generation
+	if err := uc.userRepo.IncrementTokenGeneration(user.ID); err != nil {
+		return nil, err
+	}
+	user.TokenGeneration++
+
+	return user, nil
+}
+
+// registerInvited creates a new account for the invited email directly in the organization
+func (uc *InvitationUseCase) registerInvited(invitation *domain.Invitation, input *AcceptInvitationInput) (*domain.User, error) {
+	user, err := uc.userUseCase.newUser(&RegisterUserInput{
+		Username:  input.Username,
+		Email:     invitation.Email,
+		Password:  input.Password,
+		FirstName: input.FirstName,
+		LastName:  input.LastName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user.OrgID = invitation.OrgID
+	user.OrgRole = invitation.Role
+	if err := uc.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// signToken signs a token identifying the invitation, valid until the invitation expires
+func (uc *InvitationUseCase) signToken(invitation *domain.Invitation) (string, error) {
+	claims := &InvitationClaims{
+		InvitationID: invitation.ID.Hex(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{invitationAudience},
+			ExpiresAt: jwt.NewNumericDate(invitation.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(invitation.CreatedAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(uc.authUseCase.tokens.Secret))
+}
+
+// parseToken verifies a signed invitation token and loads its invitation
+func (uc *InvitationUseCase) parseToken(tokenString string) (*domain.Invitation, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &InvitationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		// Validate the signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return []byte(uc.authUseCase.tokens.Secret), nil
+	})
+	if err != nil {
+		return nil, errInvitationInvalid
+	}
+
+	claims, ok := token.Claims.(*InvitationClaims)
+	if !ok || !token.Valid || !claims.VerifyAudience(invitationAudience, true) {
+		return nil, errInvitationInvalid
+	}
+
+	id, err := primitive.ObjectIDFromHex(claims.InvitationID)
+	if err != nil {
+		return nil, errInvitationInvalid
+	}
+
+	invitation, err := uc.invitationRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, errInvitationInvalid
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// acceptURL builds the link that accepts an invitation, or returns "" when none is configured
+func (uc *InvitationUseCase) acceptURL(token string) string {
+	if uc.cfg.AcceptURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(uc.cfg.AcceptURL)
+	if err != nil {
+		uc.log.ErrorF("Invalid invitation accept URL %q: %v", uc.cfg.AcceptURL, err)
+		return ""
+	}
+
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// sendInvitation emails the invitation link to the invited address
+func (uc *InvitationUseCase) sendInvitation(inviter *domain.User, output *CreateInvitationOutput) error {
+	if output.AcceptURL == "" {
+		return errors.New("no accept URL is configured")
+	}
+
+	org, err := uc.orgRepo.FindByID(output.Invitation.OrgID)
+	if err != nil {
+		return err
+	}
+
+	inviterName := inviter.Username
+	if inviter.FirstName != "" {
+		inviterName = strings.TrimSpace(inviter.FirstName + " " + inviter.LastName)
+	}
+
+	var body strings.Builder
+	err = invitationTemplate.Execute(&body, map[string]string{
+		"Inviter":      inviterName,
+		"Organization": org.Name,
+		"AppName":      uc.cfg.AppName,
+		"AcceptURL":    output.AcceptURL,
+		"ExpiresAt":    output.Invitation.ExpiresAt.UTC().Format("January 2, 2006 15:04 MST"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.sender.Send(output.Invitation.Email, "You're invited to join "+org.Name, body.String())
+}
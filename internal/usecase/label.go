@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"task-management-system/internal/domain"
+)
+
+// Limits on the labels of a project
+const (
+	maxProjectLabels          = 100
+	maxLabelDescriptionLength = 200
+)
+
+// labelColorPattern matches label colors: a hex RGB color such as #d73a4a
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ProjectLabelInput represents a requested project label
+type ProjectLabelInput struct {
+	OrgID       string
+	ProjectID   string
+	Name        string // Normalized like task tags; identifies the label
+	Color       string
+	Description string
+	UpdatedBy   string
+}
+
+// ListProjectLabels retrieves the labels of a project the user is a member of, in the order they were created
+func (uc *ProjectUseCase) ListProjectLabels(orgID string, projectID string, userID string) ([]domain.ProjectLabel, error) {
+	_, project, err := uc.authorize(orgID, projectID, userID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Labels == nil {
+		return []domain.ProjectLabel{}, nil
+	}
+	return project.Labels, nil
+}
+
+// CreateProjectLabel adds a label to a project. Only project admins may do so.
+func (uc *ProjectUseCase) CreateProjectLabel(input *ProjectLabelInput) (*domain.ProjectLabel, error) {
+	label, err := newProjectLabel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	_, project, err := uc.authorize(input.OrgID, input.ProjectID, input.UpdatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Label(label.Name) != nil {
+		return nil, fmt.Errorf("%w: the project already has a label %q", domain.ErrDuplicateKey, label.Name)
+	}
+	if len(project.Labels) >= maxProjectLabels {
+		return nil, fmt.Errorf("%w: a project has at most %d labels", domain.ErrInvalidInput, maxProjectLabels)
+	}
+
+	project.Labels = append(project.Labels, label)
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+// UpdateProjectLabel changes the color and description of a project's label.
+// Labels cannot be renamed, since tasks carry them by name. Only project admins
+// may do so.
+func (uc *ProjectUseCase) UpdateProjectLabel(input *ProjectLabelInput) (*domain.ProjectLabel, error) {
+	requested, err := newProjectLabel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	_, project, err := uc.authorize(input.OrgID, input.ProjectID, input.UpdatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	label := project.Label(requested.Name)
+	if label == nil {
+		return nil, fmt.Errorf("%w: label %q not found", domain.ErrNotFound, requested.Name)
+	}
+	label.Color = requested.Color
+	label.Description = requested.Description
+
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return label, nil
+}
+
+// DeleteProjectLabel removes a label from a project. Tasks keep the tag, but
+// with strict labels it can no longer be given to tasks. Only project admins
+// may do so.
+func (uc *ProjectUseCase) DeleteProjectLabel(orgID string, projectID string, name string, userID string) error {
+	tags, err := normalizeTags([]string{name})
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("%w: label name is required", domain.ErrInvalidInput)
+	}
+
+	_, project, err := uc.authorize(orgID, projectID, userID, domain.ProjectRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	for i, label := range project.Labels {
+		if label.Name == tags[0] {
+			project.Labels = append(project.Labels[:i], project.Labels[i+1:]...)
+			return uc.projectRepo.Update(project)
+		}
+	}
+	return fmt.Errorf("%w: label %q not found", domain.ErrNotFound, tags[0])
+}
+
+// newProjectLabel validates a requested label, normalizing its name like a task tag
+func newProjectLabel(input *ProjectLabelInput) (domain.ProjectLabel, error) {
+	tags, err := normalizeTags([]string{input.Name})
+	if err != nil {
+		return domain.ProjectLabel{}, err
+	}
+	if len(tags) == 0 {
+		return domain.ProjectLabel{}, fmt.Errorf("%w: label name is required", domain.ErrInvalidInput)
+	}
+
+	if input.Color != "" && !labelColorPattern.MatchString(input.Color) {
+		return domain.ProjectLabel{}, fmt.Errorf("%w: label color must be a hex color such as #d73a4a", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(input.Description) > maxLabelDescriptionLength {
+		return domain.ProjectLabel{}, fmt.Errorf("%w: label descriptions must be at most %d characters", domain.ErrInvalidInput, maxLabelDescriptionLength)
+	}
+
+	return domain.ProjectLabel{
+		Name:        tags[0],
+		Color:       input.Color,
+		Description: input.Description,
+	}, nil
+}
+
+// checkProjectTags returns an error unless the project allows every given tag
+// its task does not carry yet. Tasks keep the tags they had before the project's
+// labels became strict.
+func checkProjectTags(project *domain.Project, task *domain.Task, tags []string) error {
+	for _, tag := range tags {
+		if task != nil && task.HasTag(tag) {
+			continue
+		}
+		if !project.AllowsTag(tag) {
+			return fmt.Errorf("%w: tag %q is not a label of project %s", domain.ErrInvalidInput, tag, project.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MergeUseCase folds duplicate tasks into the task they duplicate
+type MergeUseCase struct {
+	taskRepo       domain.TaskRepository
+	attachmentRepo domain.AttachmentRepository
+	starRepo       domain.TaskStarRepository
+	events         domain.EventPublisher
+	uow            domain.UnitOfWork
+	policy         *Policy
+	enricher       taskEnricher
+	log            logger.Logger
+}
+
+// NewMergeUseCase creates a new merge use case. With a unit of work, each merge
+// is written in a single transaction and its events go through the outbox;
+// without one, the writes are made one after another. Events may be nil.
+func NewMergeUseCase(
+	taskRepo domain.TaskRepository,
+	attachmentRepo domain.AttachmentRepository,
+	starRepo domain.TaskStarRepository,
+	events domain.EventPublisher,
+	uow domain.UnitOfWork,
+	policy *Policy,
+	log logger.Logger,
+) *MergeUseCase {
+	return &MergeUseCase{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		starRepo:       starRepo,
+		events:         events,
+		uow:            uow,
+		policy:         policy,
+		enricher:       newTaskEnricher(policy.userRepo, log),
+		log:            log,
+	}
+}
+
+// MergeTasksInput represents a request to merge a duplicate task into another
+type MergeTasksInput struct {
+	OrgID    string
+	TargetID string // Task kept; an ID or a key such as PROJ-123
+	SourceID string // Duplicate folded into the target; an ID or a key
+	UserID   string
+}
+
+// MergeResult is the outcome of a merge
+type MergeResult struct {
+	Target      *domain.Task
+	Source      *domain.Task
+	Attachments int64 // Attachments moved to the target
+	Stars       int64 // Stars moved to the target
+}
+
+// MergeTasks folds a duplicate task into a target task of the same organization:
+// the duplicate's attachments and stars move to the target, and the duplicate is
+// completed with a pointer to the target. The user must be allowed to change both.
+func (uc *MergeUseCase) MergeTasks(input *MergeTasksInput) (*MergeResult, error) {
+	org, err := parseOrgID(input.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := primitive.ObjectIDFromHex(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := uc.taskRepo.ForOrg(org)
+	target, err := findTask(tasks, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := findTask(tasks, input.SourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.ID == target.ID {
+		return nil, fmt.Errorf("%w: a task cannot be merged into itself", domain.ErrInvalidInput)
+	}
+	if !source.MergedInto.IsZero() {
+		return nil, fmt.Errorf("%w: task was already merged into another task", domain.ErrInvalidInput)
+	}
+	if !target.MergedInto.IsZero() {
+		return nil, fmt.Errorf("%w: cannot merge into a task that was merged into another task", domain.ErrInvalidInput)
+	}
+
+	for _, task := range []*domain.Task{target, source} {
+		if err := uc.policy.Can(user, ActionWrite, task); err != nil {
+			return nil, err
+		}
+	}
+
+	// Close the duplicate
+	source.Status = domain.TaskStatusCompleted
+	source.MergedInto = target.ID
+	source.EscalatedAt = nil
+
+	result := &MergeResult{Target: target, Source: source}
+	write := func(tasks domain.TaskRepository, attachments domain.AttachmentRepository, stars domain.TaskStarRepository) error {
+		movedAttachments, err := attachments.MoveToTask(source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+		movedStars, err := stars.MoveToTask(source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+		result.Attachments, result.Stars = movedAttachments, movedStars
+
+		if err := tasks.Update(source); err != nil {
+			return err
+		}
+		// Touch the target, which gained the duplicate's data
+		return tasks.Update(target)
+	}
+
+	events := []*domain.Event{
+		taskEvent(domain.EventTaskMerged, userID, primitive.NilObjectID, source),
+		taskEvent(domain.EventTaskUpdated, userID, primitive.NilObjectID, target),
+	}
+
+	if uc.uow != nil {
+		err = uc.uow.DoAll(func(repos *domain.TxRepositories) error {
+			if err := write(repos.Tasks.ForOrg(org), repos.Attachments, repos.Stars); err != nil {
+				return err
+			}
+			for _, event := range events {
+				if err := repos.Outbox.Add(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := write(tasks, uc.attachmentRepo, uc.starRepo); err != nil {
+			return nil, err
+		}
+		if uc.events != nil {
+			for _, event := range events {
+				uc.events.Publish(event)
+			}
+		}
+	}
+
+	uc.enricher.enrich(target, source)
+
+	return result, nil
+}
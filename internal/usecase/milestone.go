@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MilestoneWithProgress is a milestone with the progress of its tasks
+type MilestoneWithProgress struct {
+	*domain.Milestone
+	Progress domain.MilestoneProgress `json:"progress"`
+}
+
+// CreateMilestoneInput represents input data for milestone creation
+type CreateMilestoneInput struct {
+	OrgID       string
+	ProjectID   string
+	Name        string
+	Description string
+	TargetDate  time.Time
+	CreatedBy   string
+}
+
+// CreateMilestone creates a milestone in a project. Only project admins may do so.
+func (uc *ProjectUseCase) CreateMilestone(input *CreateMilestoneInput) (*MilestoneWithProgress, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: milestone name is required", domain.ErrInvalidInput)
+	}
+	if input.TargetDate.IsZero() {
+		return nil, fmt.Errorf("%w: milestone target date is required", domain.ErrInvalidInput)
+	}
+
+	creator, project, err := uc.authorize(input.OrgID, input.ProjectID, input.CreatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	milestone := &domain.Milestone{
+		OrgID:       project.OrgID,
+		ProjectID:   project.ID,
+		Name:        name,
+		Description: input.Description,
+		TargetDate:  input.TargetDate,
+		CreatedBy:   creator.ID,
+	}
+	if err := uc.milestoneRepo.Create(milestone); err != nil {
+		return nil, err
+	}
+
+	return &MilestoneWithProgress{Milestone: milestone, Progress: milestone.Progress(nil, time.Now())}, nil
+}
+
+// ListMilestones lists the milestones of a project the user is a member of, by
+// target date, with their progress
+func (uc *ProjectUseCase) ListMilestones(orgID string, projectID string, userID string) ([]*MilestoneWithProgress, error) {
+	_, project, err := uc.authorize(orgID, projectID, userID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones, err := uc.milestoneRepo.FindByProject(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the tasks of every milestone at once
+	ids := make([]primitive.ObjectID, 0, len(milestones))
+	for _, milestone := range milestones {
+		ids = append(ids, milestone.ID)
+	}
+	tasks, err := uc.taskRepo.ForOrg(project.OrgID).FindAll(map[string]interface{}{
+		"milestone_id": map[string]interface{}{"$in": ids},
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+
+	byMilestone := make(map[primitive.ObjectID][]*domain.Task, len(milestones))
+	for _, task := range tasks {
+		byMilestone[task.MilestoneID] = append(byMilestone[task.MilestoneID], task)
+	}
+
+	now := time.Now()
+	result := make([]*MilestoneWithProgress, 0, len(milestones))
+	for _, milestone := range milestones {
+		result = append(result, &MilestoneWithProgress{
+			Milestone: milestone,
+			Progress:  milestone.Progress(byMilestone[milestone.ID], now),
+		})
+	}
+	return result, nil
+}
+
+// GetMilestone retrieves a milestone of a project the user is a member of, with its progress
+func (uc *ProjectUseCase) GetMilestone(orgID string, id string, userID string) (*MilestoneWithProgress, error) {
+	_, milestone, err := uc.authorizeMilestone(orgID, id, userID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.withProgress(milestone)
+}
+
+// UpdateMilestoneInput represents input data for a milestone update
+type UpdateMilestoneInput struct {
+	OrgID       string
+	ID          string
+	Name        string
+	Description string
+	TargetDate  time.Time
+	UpdatedBy   string
+}
+
+// UpdateMilestone changes a milestone's name, description or target date. Only
+// project admins may do so.
+func (uc *ProjectUseCase) UpdateMilestone(input *UpdateMilestoneInput) (*MilestoneWithProgress, error) {
+	_, milestone, err := uc.authorizeMilestone(input.OrgID, input.ID, input.UpdatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		milestone.Name = name
+	}
+	if input.Description != "" {
+		milestone.Description = input.Description
+	}
+	if !input.TargetDate.IsZero() {
+		milestone.TargetDate = input.TargetDate
+	}
+
+	if err := uc.milestoneRepo.Update(milestone); err != nil {
+		return nil, err
+	}
+
+	return uc.withProgress(milestone)
+}
+
+// MilestoneTaskInput represents a request to add a task to a milestone or remove it
+type MilestoneTaskInput struct {
+	OrgID       string
+	MilestoneID string
+	TaskID      string
+	UserID      string
+}
+
+// AddMilestoneTask adds a task of the milestone's project to the milestone,
+// moving it out of any other milestone. Project contributors who may change the
+// task may do so.
+func (uc *ProjectUseCase) AddMilestoneTask(input *MilestoneTaskInput) (*domain.Task, error) {
+	user, milestone, task, err := uc.milestoneTask(input)
+	if err != nil {
+		return nil, err
+	}
+	if task.ProjectID != milestone.ProjectID {
+		return nil, fmt.Errorf("%w: task is not in the milestone's project", domain.ErrInvalidInput)
+	}
+	if err := uc.policy.Can(user, ActionWrite, task); err != nil {
+		return nil, err
+	}
+
+	task.MilestoneID = milestone.ID
+	if err := uc.taskRepo.ForOrg(milestone.OrgID).Update(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// RemoveMilestoneTask removes a task from a milestone. Project contributors who
+// may change the task may do so.
+func (uc *ProjectUseCase) RemoveMilestoneTask(input *MilestoneTaskInput) error {
+	user, milestone, task, err := uc.milestoneTask(input)
+	if err != nil {
+		return err
+	}
+	if task.MilestoneID != milestone.ID {
+		return domain.ErrNotFound
+	}
+	if err := uc.policy.Can(user, ActionWrite, task); err != nil {
+		return err
+	}
+
+	task.MilestoneID = primitive.NilObjectID
+	return uc.taskRepo.ForOrg(milestone.OrgID).Update(task)
+}
+
+// milestoneTask loads the acting contributor, a milestone and a task of the organization
+func (uc *ProjectUseCase) milestoneTask(input *MilestoneTaskInput) (*domain.User, *domain.Milestone, *domain.Task, error) {
+	user, milestone, err := uc.authorizeMilestone(input.OrgID, input.MilestoneID, input.UserID, domain.ProjectRoleContributor)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	taskID, err := primitive.ObjectIDFromHex(input.TaskID)
+	if err != nil {
+		return nil, nil, nil, errors.New("invalid task ID format")
+	}
+
+	task, err := uc.taskRepo.ForOrg(milestone.OrgID).FindByID(taskID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return user, milestone, task, nil
+}
+
+// withProgress computes the progress of a milestone from its tasks
+func (uc *ProjectUseCase) withProgress(milestone *domain.Milestone) (*MilestoneWithProgress, error) {
+	tasks, err := uc.taskRepo.ForOrg(milestone.OrgID).FindAll(map[string]interface{}{
+		"milestone_id": milestone.ID,
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+
+	return &MilestoneWithProgress{Milestone: milestone, Progress: milestone.Progress(tasks, time.Now())}, nil
+}
+
+// authorizeMilestone loads the acting user and a milestone, checking the user holds
+// at least the given role in its project. Milestones of other organizations are not found.
+func (uc *ProjectUseCase) authorizeMilestone(orgID string, milestoneID string, userID string, minimum domain.ProjectRole) (*domain.User, *domain.Milestone, error) {
+	user, err := uc.actor(orgID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(milestoneID)
+	if err != nil {
+		return nil, nil, errors.New("invalid milestone ID format")
+	}
+
+	milestone, err := uc.milestoneRepo.FindByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if milestone.OrgID != user.OrgID {
+		return nil, nil, domain.ErrNotFound
+	}
+
+	if _, err := uc.policy.AuthorizeProject(user, milestone.ProjectID, minimum); err != nil {
+		return nil, nil, err
+	}
+
+	return user, milestone, nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Weights of the signals that rank a user's open tasks for NextTask
+const (
+	nextTaskPriorityWeight   = 10 // per priority level, 1 to 5
+	nextTaskOverdueScore     = 30
+	nextTaskDueTodayScore    = 20 // due within a day
+	nextTaskDueSoonScore     = 10 // due within three days
+	nextTaskDueThisWeekScore = 5  // due within a week
+	nextTaskEscalatedScore   = 25 // escalated as overdue, i.e. past its SLA
+	nextTaskStartedScore     = 5  // already in progress, so finishing it comes first
+)
+
+// Reasons a task was recommended
+const (
+	NextTaskReasonPriority  = "high_priority"
+	NextTaskReasonOverdue   = "overdue"
+	NextTaskReasonDueSoon   = "due_soon"
+	NextTaskReasonEscalated = "escalated"
+	NextTaskReasonStarted   = "in_progress"
+)
+
+// TaskRecommendation is the task a user should work on next, with why
+type TaskRecommendation struct {
+	Task    *domain.Task `json:"task"`
+	Score   int          `json:"score" example:"75"`
+	Reasons []string     `json:"reasons" example:"overdue,high_priority"`
+}
+
+// NextTask recommends the single most relevant open task assigned to the user,
+// weighing its priority, how soon it is due and whether it was escalated past
+// its SLA. Snoozed tasks are skipped. Ties go to the task due first, then the
+// oldest. It returns nil when the user has no open tasks.
+func (uc *TaskUseCase) NextTask(orgID string, userID string, now time.Time) (*TaskRecommendation, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(org).FindAll(map[string]interface{}{
+		"assigned_to": user.ID,
+		"status":      map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+
+	if tasks, err = uc.policy.FilterVisible(user, tasks); err != nil {
+		return nil, err
+	}
+	if tasks, err = uc.withoutSnoozed(user.ID, tasks); err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	recommendations := make([]*TaskRecommendation, 0, len(tasks))
+	for _, task := range tasks {
+		recommendations = append(recommendations, scoreNextTask(task, now))
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if dueA, dueB := dueOrNever(a.Task), dueOrNever(b.Task); !dueA.Equal(dueB) {
+			return dueA.Before(dueB)
+		}
+		return a.Task.ID.Timestamp().Before(b.Task.ID.Timestamp())
+	})
+
+	next := recommendations[0]
+	uc.enricher.enrich(next.Task)
+
+	return next, nil
+}
+
+// scoreNextTask scores how urgently a task should be worked on
+func scoreNextTask(task *domain.Task, now time.Time) *TaskRecommendation {
+	rec := &TaskRecommendation{Task: task, Reasons: []string{}}
+
+	rec.Score += task.Priority * nextTaskPriorityWeight
+	if task.Priority >= 4 {
+		rec.Reasons = append(rec.Reasons, NextTaskReasonPriority)
+	}
+
+	// Tasks without a due date are stored with the zero time
+	if !task.DueDate.IsZero() {
+		switch untilDue := task.DueDate.Sub(now); {
+		case untilDue < 0:
+			rec.Score += nextTaskOverdueScore
+			rec.Reasons = append(rec.Reasons, NextTaskReasonOverdue)
+		case untilDue <= 24*time.Hour:
+			rec.Score += nextTaskDueTodayScore
+			rec.Reasons = append(rec.Reasons, NextTaskReasonDueSoon)
+		case untilDue <= 3*24*time.Hour:
+			rec.Score += nextTaskDueSoonScore
+			rec.Reasons = append(rec.Reasons, NextTaskReasonDueSoon)
+		case untilDue <= 7*24*time.Hour:
+			rec.Score += nextTaskDueThisWeekScore
+		}
+	}
+
+	if task.EscalatedAt != nil {
+		rec.Score += nextTaskEscalatedScore
+		rec.Reasons = append(rec.Reasons, NextTaskReasonEscalated)
+	}
+
+	if task.Status == domain.TaskStatusInProgress {
+		rec.Score += nextTaskStartedScore
+		rec.Reasons = append(rec.Reasons, NextTaskReasonStarted)
+	}
+
+	return rec
+}
+
+// dueOrNever returns a task's due date, or the far future for tasks without one
+func dueOrNever(task *domain.Task) time.Time {
+	if task.DueDate.IsZero() {
+		return time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	}
+	return task.DueDate
+}
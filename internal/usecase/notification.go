@@ -0,0 +1,422 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultNotificationLimit caps the number of notifications returned in one listing
+const defaultNotificationLimit = 50
+
+// Bounds of quiet hours and the summaries sent after them
+const (
+	maxDoNotDisturbMinutes = 7 * 24 * 60
+	maxSummaryItems        = 50
+)
+
+// NotificationUseCase handles notifications and their delivery across channels
+type NotificationUseCase struct {
+	notificationRepo domain.NotificationRepository
+	preferencesRepo  domain.NotificationPreferencesRepository
+	deferredRepo     domain.DeferredNotificationRepository
+	userRepo         domain.UserRepository
+	notifiers        map[domain.NotificationChannel]domain.Notifier
+	log              logger.Logger
+}
+
+// NewNotificationUseCase creates a new notification use case. In-app delivery is
+// always available; other channels are served by the given notifiers. Without a
+// deferred notification repository, quiet hours are not observed.
+func NewNotificationUseCase(
+	notificationRepo domain.NotificationRepository,
+	preferencesRepo domain.NotificationPreferencesRepository,
+	deferredRepo domain.DeferredNotificationRepository,
+	userRepo domain.UserRepository,
+	log logger.Logger,
+	notifiers ...domain.Notifier,
+) *NotificationUseCase {
+	byChannel := make(map[domain.NotificationChannel]domain.Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		byChannel[notifier.Channel()] = notifier
+	}
+
+	return &NotificationUseCase{
+		notificationRepo: notificationRepo,
+		preferencesRepo:  preferencesRepo,
+		deferredRepo:     deferredRepo,
+		userRepo:         userRepo,
+		notifiers:        byChannel,
+		log:              log,
+	}
+}
+
+// HandleEvent dispatches notifications for the users affected by a domain event,
+// routing each one to the channels the recipient enabled for the event's category.
+// It is meant to be subscribed to the event bus.
+func (uc *NotificationUseCase) HandleEvent(event *domain.Event) error {
+	if event.Task == nil {
+		return nil
+	}
+
+	category := domain.CategoryForEvent(event.Type)
+	for _, recipientID := range notificationRecipients(event) {
+		notification := &domain.Notification{
+			UserID:    recipientID,
+			Type:      event.Type,
+			ActorID:   event.ActorID,
+			TaskID:    event.Task.ID,
+			TaskTitle: event.Task.Title,
+		}
+
+		if err := uc.dispatch(notification, category); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remind delivers a reminder of a task to a user over the channels they route
+// due-soon notifications to
+func (uc *NotificationUseCase) Remind(userID primitive.ObjectID, task *domain.Task) error {
+	notification := &domain.Notification{
+		UserID:    userID,
+		Type:      domain.EventTaskReminder,
+		ActorID:   userID,
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+	}
+
+	return uc.dispatch(notification, domain.CategoryForEvent(domain.EventTaskReminder))
+}
+
+// dispatch delivers a notification over the recipient's preferred channels.
+// Failures on external channels are logged so they don't block other channels.
+// During the recipient's quiet hours, notifications that are not urgent are held
+// back from external channels; in-app notifications don't disturb and are
+// delivered right away.
+func (uc *NotificationUseCase) dispatch(notification *domain.Notification, category domain.NotificationCategory) error {
+	prefs, err := uc.preferencesFor(notification.UserID)
+	if err != nil {
+		return err
+	}
+
+	var recipient *domain.User
+	var quietUntil *time.Time
+	for _, channel := range prefs.ChannelsFor(category) {
+		if channel == domain.ChannelInApp {
+			if err := uc.notificationRepo.Create(notification); err != nil {
+				return err
+			}
+			continue
+		}
+
+		notifier, ok := uc.notifiers[channel]
+		if !ok {
+			uc.log.WarnF("No notifier configured for channel %s", channel)
+			continue
+		}
+
+		// Load the recipient lazily, only when an external channel needs it
+		if recipient == nil {
+			recipient, err = uc.userRepo.FindByID(notification.UserID)
+			if err != nil {
+				return err
+			}
+
+			if uc.deferredRepo != nil && !domain.IsUrgent(notification.Type) {
+				if until, quiet := prefs.QuietUntil(time.Now(), recipient.Location()); quiet {
+					quietUntil = &until
+				}
+			}
+		}
+
+		if quietUntil != nil {
+			if err := uc.deferredRepo.Create(&domain.DeferredNotification{
+				UserID:       recipient.ID,
+				Channel:      channel,
+				Notification: *notification,
+				DeliverAt:    *quietUntil,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := notifier.Notify(recipient, prefs, notification); err != nil {
+			uc.log.ErrorF("Failed to deliver %s notification to user %s: %v", channel, notification.UserID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// preferencesFor loads a user's preferences, falling back to the defaults
+func (uc *NotificationUseCase) preferencesFor(userID primitive.ObjectID) (*domain.NotificationPreferences, error) {
+	prefs, err := uc.preferencesRepo.FindByUser(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// GetPreferences retrieves a user's notification preferences
+func (uc *NotificationUseCase) GetPreferences(userID string) (*domain.NotificationPreferences, error) {
+	// Convert ID from string to ObjectID
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	return uc.preferencesFor(userObjID)
+}
+
+// UpdatePreferencesInput represents input data for updating notification preferences
+type UpdatePreferencesInput struct {
+	UserID          string
+	Channels        map[domain.NotificationCategory][]domain.NotificationChannel
+	SlackWebhookURL *string
+	WebhookURL      *string
+	DailyDigest     *bool
+	DigestHour      *int
+	QuietHours      *domain.QuietHours
+	// DoNotDisturbMinutes pauses notifications for that many minutes from now; zero resumes them
+	DoNotDisturbMinutes *int
+}
+
+// UpdatePreferences updates a user's notification preferences. Categories that are
+// not present in the input keep their current channels.
+func (uc *NotificationUseCase) UpdatePreferences(input *UpdatePreferencesInput) (*domain.NotificationPreferences, error) {
+	// Convert ID from string to ObjectID
+	userID, err := primitive.ObjectIDFromHex(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	prefs, err := uc.preferencesFor(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate and apply channel routing
+	for category, channels := range input.Channels {
+		if !isValidCategory(category) {
+			return nil, fmt.Errorf("%w: unknown notification category %q", domain.ErrInvalidInput, category)
+		}
+		for _, channel := range channels {
+			if !isValidChannel(channel) {
+				return nil, fmt.Errorf("%w: unknown notification channel %q", domain.ErrInvalidInput, channel)
+			}
+		}
+		prefs.Channels[category] = channels
+	}
+
+	if input.SlackWebhookURL != nil {
+		prefs.SlackWebhookURL = *input.SlackWebhookURL
+	}
+	if input.WebhookURL != nil {
+		prefs.WebhookURL = *input.WebhookURL
+	}
+	if input.DailyDigest != nil {
+		prefs.DailyDigest = *input.DailyDigest
+	}
+	if input.DigestHour != nil {
+		if *input.DigestHour < 0 || *input.DigestHour > 23 {
+			return nil, fmt.Errorf("%w: digest_hour must be between 0 and 23", domain.ErrInvalidInput)
+		}
+		prefs.DigestHour = *input.DigestHour
+	}
+	if input.QuietHours != nil {
+		quiet := *input.QuietHours
+		if quiet.Start < 0 || quiet.Start > 23 || quiet.End < 0 || quiet.End > 23 {
+			return nil, fmt.Errorf("%w: quiet hours must start and end between 0 and 23", domain.ErrInvalidInput)
+		}
+		if quiet.Enabled && quiet.Start == quiet.End {
+			return nil, fmt.Errorf("%w: quiet hours must start and end at different hours", domain.ErrInvalidInput)
+		}
+		prefs.QuietHours = quiet
+	}
+	if input.DoNotDisturbMinutes != nil {
+		minutes := *input.DoNotDisturbMinutes
+		if minutes < 0 || minutes > maxDoNotDisturbMinutes {
+			return nil, fmt.Errorf("%w: do_not_disturb_minutes must be between 0 and %d", domain.ErrInvalidInput, maxDoNotDisturbMinutes)
+		}
+		prefs.DoNotDisturbUntil = nil
+		if minutes > 0 {
+			until := time.Now().Add(time.Duration(minutes) * time.Minute)
+			prefs.DoNotDisturbUntil = &until
+		}
+	}
+
+	// External channels need somewhere to deliver to
+	for _, channels := range prefs.Channels {
+		for _, channel := range channels {
+			if channel == domain.ChannelSlack && prefs.SlackWebhookURL == "" {
+				return nil, fmt.Errorf("%w: slack_webhook_url is required to use the slack channel", domain.ErrInvalidInput)
+			}
+			if channel == domain.ChannelWebhook && prefs.WebhookURL == "" {
+				return nil, fmt.Errorf("%w: webhook_url is required to use the webhook channel", domain.ErrInvalidInput)
+			}
+		}
+	}
+
+	if err := uc.preferencesRepo.Save(prefs); err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+// ListNotificationsInput represents filtering options for notification listing
+type ListNotificationsInput struct {
+	UserID     string
+	UnreadOnly bool
+	Limit      int64
+}
+
+// ListNotificationsOutput represents a page of notifications plus the unread total
+type ListNotificationsOutput struct {
+	Notifications []*domain.Notification `json:"notifications"`
+	UnreadCount   int64                  `json:"unread_count"`
+}
+
+// ListNotifications lists a user's notifications, newest first
+func (uc *NotificationUseCase) ListNotifications(input *ListNotificationsInput) (*ListNotificationsOutput, error) {
+	// Convert ID from string to ObjectID
+	userID, err := primitive.ObjectIDFromHex(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	limit := input.Limit
+	if limit <= 0 || limit > defaultNotificationLimit {
+		limit = defaultNotificationLimit
+	}
+
+	notifications, err := uc.notificationRepo.FindByUser(userID, input.UnreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := uc.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListNotificationsOutput{
+		Notifications: notifications,
+		UnreadCount:   unread,
+	}, nil
+}
+
+// CountUnread returns the number of unread notifications for a user
+func (uc *NotificationUseCase) CountUnread(userID string) (int64, error) {
+	// Convert ID from string to ObjectID
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID format")
+	}
+
+	return uc.notificationRepo.CountUnread(userObjID)
+}
+
+// MarkRead marks one of the user's notifications as read
+func (uc *NotificationUseCase) MarkRead(userID string, notificationID string) error {
+	// Convert IDs from string to ObjectID
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	notificationObjID, err := primitive.ObjectIDFromHex(notificationID)
+	if err != nil {
+		return errors.New("invalid notification ID format")
+	}
+
+	return uc.notificationRepo.MarkRead(userObjID, notificationObjID)
+}
+
+// MarkAllRead marks all of the user's notifications as read
+func (uc *NotificationUseCase) MarkAllRead(userID string) (int64, error) {
+	// Convert ID from string to ObjectID
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user ID format")
+	}
+
+	return uc.notificationRepo.MarkAllRead(userObjID)
+}
+
+// isValidCategory checks whether a notification category is supported
+func isValidCategory(category domain.NotificationCategory) bool {
+	for _, c := range domain.ValidNotificationCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidChannel checks whether a notification channel is supported
+func isValidChannel(channel domain.NotificationChannel) bool {
+	for _, c := range domain.ValidNotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// notificationRecipients determines who should be notified about an event.
+// The actor is never notified about their own action.
+func notificationRecipients(event *domain.Event) []primitive.ObjectID {
+	var candidates []primitive.ObjectID
+
+	switch event.Type {
+	case domain.EventTaskAssigned, domain.EventTaskUnassigned, domain.EventTaskAssignmentRequested:
+		// Only the user who was (un)assigned
+		candidates = []primitive.ObjectID{event.SubjectID}
+	case domain.EventTaskAssignmentAccepted, domain.EventTaskAssignmentDeclined:
+		// The user who made the assignment
+		candidates = []primitive.ObjectID{event.SubjectID}
+	case domain.EventTaskUpdated, domain.EventTaskStatusChanged:
+		// The creator and everyone working on the task
+		candidates = append([]primitive.ObjectID{event.Task.CreatedBy}, event.Task.AssignedTo...)
+	case domain.EventTaskDeleted:
+		// Assignees lose a task they were working on
+		candidates = event.Task.AssignedTo
+	case domain.EventTaskMerged:
+		// The creator and everyone working on the duplicate that was closed
+		candidates = append([]primitive.ObjectID{event.Task.CreatedBy}, event.Task.AssignedTo...)
+	case domain.EventAttachmentQuarantined:
+		// The uploader, whose file can no longer be downloaded
+		candidates = []primitive.ObjectID{event.SubjectID}
+	case domain.EventTaskUnsnoozed:
+		// The user who snoozed the task
+		candidates = []primitive.ObjectID{event.SubjectID}
+	case domain.EventTaskEscalated:
+		// The creator, and the project admin asked to follow up, if any
+		candidates = []primitive.ObjectID{event.Task.CreatedBy, event.SubjectID}
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(candidates))
+	recipients := make([]primitive.ObjectID, 0, len(candidates))
+	for _, id := range candidates {
+		if id.IsZero() || id == event.ActorID || seen[id] {
+			continue
+		}
+		seen[id] = true
+		recipients = append(recipients, id)
+	}
+
+	return recipients
+}
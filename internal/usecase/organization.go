@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OrganizationUseCase handles business logic related to organizations and their members
+type OrganizationUseCase struct {
+	orgRepo  domain.OrganizationRepository
+	userRepo domain.UserRepository
+	audit    auditLog
+	log      logger.Logger
+}
+
+// NewOrganizationUseCase creates a new organization use case. Administrative changes
+// are recorded in the audit log.
+func NewOrganizationUseCase(orgRepo domain.OrganizationRepository, userRepo domain.UserRepository, auditRepo domain.AuditRepository, log logger.Logger) *OrganizationUseCase {
+	return &OrganizationUseCase{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		audit:    auditLog{repo: auditRepo, log: log},
+		log:      log,
+	}
+}
+
+// GetOrganization retrieves an organization by ID
+func (uc *OrganizationUseCase) GetOrganization(orgID string) (*domain.Organization, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.orgRepo.FindByID(org)
+}
+
+// UpdateOrganizationInput represents input data for an organization update
+type UpdateOrganizationInput struct {
+	OrgID     string
+	Name      string
+	UpdatedBy string
+	Client    ClientInfo
+}
+
+// UpdateOrganization renames an organization. Only organization admins may do so.
+func (uc *OrganizationUseCase) UpdateOrganization(input *UpdateOrganizationInput) (*domain.Organization, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: organization name is required", domain.ErrInvalidInput)
+	}
+
+	admin, err := requireOrgAdmin(uc.userRepo, input.OrgID, input.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := uc.orgRepo.FindByID(admin.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := org.Name
+	org.Name = name
+	if err := uc.orgRepo.Update(org); err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      org.ID,
+		Action:     domain.AuditOrganizationUpdated,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetOrganization,
+		TargetID:   org.ID,
+		Details:    map[string]string{"from": previous, "to": name},
+	}, input.Client)
+
+	return org, nil
+}
+
+// ListMembers lists the users of an organization
+func (uc *OrganizationUseCase) ListMembers(orgID string) ([]*domain.User, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.userRepo.FindByOrg(org)
+}
+
+// UpdateMemberRoleInput represents input data for changing a member's role
+type UpdateMemberRoleInput struct {
+	OrgID     string
+	MemberID  string
+	Role      domain.OrgRole
+	UpdatedBy string
+	Client    ClientInfo
+}
+
+// UpdateMemberRole changes a member's role. Only organization admins may do so,
+// and the organization always keeps at least one admin.
+func (uc *OrganizationUseCase) UpdateMemberRole(input *UpdateMemberRoleInput) (*domain.User, error) {
+	if input.Role != domain.OrgRoleAdmin && input.Role != domain.OrgRoleMember {
+		return nil, fmt.Errorf("%w: role must be %q or %q", domain.ErrInvalidInput, domain.OrgRoleAdmin, domain.OrgRoleMember)
+	}
+
+	admin, err := requireOrgAdmin(uc.userRepo, input.OrgID, input.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := uc.findMember(admin.OrgID, input.MemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.OrgRole == input.Role {
+		return member, nil
+	}
+
+	if member.IsOrgAdmin() {
+		if err := ensureOtherOrgAdmin(uc.userRepo, member); err != nil {
+			return nil, err
+		}
+	}
+
+	previous := member.OrgRole
+	member.OrgRole = input.Role
+	if err := uc.userRepo.Update(member); err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      admin.OrgID,
+		Action:     domain.AuditMemberRoleChanged,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   member.ID,
+		Details:    map[string]string{"from": string(previous), "to": string(input.Role)},
+	}, input.Client)
+
+	return member, nil
+}
+
+// RemoveMember removes a user from an organization. Only organization admins may
+// do so. The removed user is moved to a new personal organization of their own,
+// so they can still sign in; tasks they created stay with the old organization.
+// Their tokens are revoked, so they must sign in again.
+func (uc *OrganizationUseCase) RemoveMember(orgID string, memberID string, removedBy string, client ClientInfo) error {
+	admin, err := requireOrgAdmin(uc.userRepo, orgID, removedBy)
+	if err != nil {
+		return err
+	}
+
+	member, err := uc.findMember(admin.OrgID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.IsOrgAdmin() {
+		if err := ensureOtherOrgAdmin(uc.userRepo, member); err != nil {
+			return err
+		}
+	}
+
+	personal := &domain.Organization{
+		Name:      member.Username + "'s organization",
+		CreatedBy: member.ID,
+	}
+	if err := uc.orgRepo.Create(personal); err != nil {
+		return err
+	}
+
+	member.OrgID = personal.ID
+	member.OrgRole = domain.OrgRoleAdmin
+	if err := uc.userRepo.Update(member); err != nil {
+		return err
+	}
+
+	// Tokens issued for the old organization stop working
+	if err := uc.userRepo.IncrementTokenGeneration(member.ID); err != nil {
+		return err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      admin.OrgID,
+		Action:     domain.AuditMemberRemoved,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   member.ID,
+	}, client)
+
+	return nil
+}
+
+// requireOrgAdmin loads the acting user and checks they administer the organization
+func requireOrgAdmin(userRepo domain.UserRepository, orgID string, userID string) (*domain.User, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOrganization(user, org, ActionAdminister); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// findMember retrieves a user of the organization, treating users of other organizations as not found
+func (uc *OrganizationUseCase) findMember(org primitive.ObjectID, memberID string) (*domain.User, error) {
+	id, err := primitive.ObjectIDFromHex(memberID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	member, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.OrgID != org {
+		return nil, domain.ErrNotFound
+	}
+
+	return member, nil
+}
+
+// ensureOtherOrgAdmin fails when the given admin is their organization's last active one
+func ensureOtherOrgAdmin(userRepo domain.UserRepository, admin *domain.User) error {
+	members, err := userRepo.FindByOrg(admin.OrgID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if member.ID != admin.ID && member.IsOrgAdmin() && !member.IsDeactivated() {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: an organization must keep at least one admin", domain.ErrInvalidInput)
+}
@@ -0,0 +1,41 @@
+package usecase
+
+// Page sizes used when the page limits set none
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// PageLimits bounds the page sizes of task and user listings
+type PageLimits struct {
+	Default int64 // Page size when none is requested
+	Max     int64 // Larger requested page sizes are lowered to it
+}
+
+// PageInfo describes the page a listing returned
+type PageInfo struct {
+	// Size is the page size applied; a page holding fewer items is the last one
+	Size int64
+	// Clamped reports that a larger page size was requested and lowered to the maximum
+	Clamped bool
+}
+
+// page returns the page to list for a requested page size; zero or less requests the default
+func (l PageLimits) page(requested int64) PageInfo {
+	limit := l.Max
+	if limit <= 0 {
+		limit = maxPageSize
+	}
+	size := l.Default
+	if size <= 0 {
+		size = min(defaultPageSize, limit)
+	}
+
+	switch {
+	case requested > limit:
+		return PageInfo{Size: limit, Clamped: true}
+	case requested > 0:
+		return PageInfo{Size: requested}
+	}
+	return PageInfo{Size: size}
+}
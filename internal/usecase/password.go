@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+)
+
+// DefaultPasswordMinLength is the minimum password length used when none is configured
+const DefaultPasswordMinLength = 6
+
+// PasswordPolicy holds the rules every new password must satisfy. It is enforced
+// wherever a password is set: registration, invitation acceptance and profile updates.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// Banned lists passwords that are refused regardless of the other rules, compared case-insensitively
+	Banned []string
+	// MaxAge is how long a password stays valid before it must be changed; zero never expires
+	MaxAge time.Duration
+	// Breached optionally refuses passwords known from data breaches
+	Breached domain.BreachedPasswordChecker
+
+	// log receives the failures of the breached password check; it is set by the use case enforcing the policy
+	log logger.Logger
+}
+
+// Validate checks a new password against the policy
+func (p PasswordPolicy) Validate(password string) error {
+	minLength := p.MinLength
+	if minLength <= 0 {
+		minLength = DefaultPasswordMinLength
+	}
+	if len([]rune(password)) < minLength {
+		return fmt.Errorf("%w: password must be at least %d characters long", domain.ErrInvalidInput, minLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return fmt.Errorf("%w: password must contain an uppercase letter", domain.ErrInvalidInput)
+	case p.RequireLower && !hasLower:
+		return fmt.Errorf("%w: password must contain a lowercase letter", domain.ErrInvalidInput)
+	case p.RequireDigit && !hasDigit:
+		return fmt.Errorf("%w: password must contain a digit", domain.ErrInvalidInput)
+	case p.RequireSymbol && !hasSymbol:
+		return fmt.Errorf("%w: password must contain a symbol", domain.ErrInvalidInput)
+	}
+
+	for _, banned := range p.Banned {
+		if strings.EqualFold(password, banned) {
+			return fmt.Errorf("%w: password is too common", domain.ErrInvalidInput)
+		}
+	}
+
+	// Checked last as it may call an external service. An unavailable checker must
+	// not block sign-ups, so its failures are logged and the password is accepted.
+	if p.Breached != nil {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			p.log.WarnF("Breached password check failed, accepting password: %v", err)
+		} else if breached {
+			return fmt.Errorf("%w: password has appeared in a data breach, please choose another", domain.ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
+
+// Expired reports whether the user's password is older than the policy allows.
+// Passwords set before their change time was tracked count from the user's creation.
+func (p PasswordPolicy) Expired(user *domain.User, now time.Time) bool {
+	if p.MaxAge <= 0 {
+		return false
+	}
+
+	changedAt := user.PasswordChangedAt
+	if changedAt.IsZero() {
+		changedAt = user.CreatedAt
+	}
+	return now.Sub(changedAt) > p.MaxAge
+}
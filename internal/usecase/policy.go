@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Action is an operation that is subject to authorization
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionWrite  Action = "write"
+	ActionAssign Action = "assign"
+	ActionDelete Action = "delete"
+	// ActionAdminister covers managing a project or organization: its settings,
+	// members and everything else only its admins may do
+	ActionAdminister Action = "administer"
+)
+
+// Policy is the single place that decides who may do what. Every operation,
+// whether it arrives over HTTP or gRPC or runs in a background job, is checked
+// here by the use cases through Can.
+//
+// Tasks in a project follow the caller's project role: viewers may read, contributors
+// may also write and assign and delete tasks they created, and admins may do
+// everything. Organization admins act as admins of every project. Non-members cannot
+// see a project's tasks at all. Tasks outside any project keep the original rules:
+// everyone in the organization may read them, creator and assignees may write, and
+// only the creator may assign or delete.
+//
+// On top of these rules, the creator of a task may grant other users of the
+// organization view or edit access to it, including users outside its project.
+// Grants only ever add read and write access.
+//
+// Projects may be read by their viewers, written to by contributors and deleted
+// or administered by admins. Organizations may be read by their members and
+// administered by organization admins only. User accounts may be read within
+// their organization and changed or deleted by their owner only; organization
+// admins administer them, deactivating and reactivating them.
+type Policy struct {
+	userRepo    domain.UserRepository
+	projectRepo domain.ProjectRepository
+}
+
+// NewPolicy creates a new authorization policy
+func NewPolicy(userRepo domain.UserRepository, projectRepo domain.ProjectRepository) *Policy {
+	return &Policy{
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+// Can checks whether the user may perform the action on a resource, which is a
+// *domain.Task, *domain.Project, *domain.Organization or *domain.User. It returns
+// domain.ErrNotFound when the user may not even see the resource, and
+// domain.ErrUnauthorized when they may see it but not perform the action.
+func (p *Policy) Can(user *domain.User, action Action, resource interface{}) error {
+	switch r := resource.(type) {
+	case *domain.Task:
+		return p.authorizeTask(user, r, action)
+	case *domain.Project:
+		return p.authorizeProject(user, r, action)
+	case *domain.Organization:
+		return authorizeOrganization(user, r.ID, action)
+	case *domain.User:
+		return authorizeAccount(user, r, action)
+	default:
+		return fmt.Errorf("unknown resource type %T", resource)
+	}
+}
+
+// Actor loads the user acting within an organization. Unknown users and users of
+// other organizations are unauthorized.
+func (p *Policy) Actor(org primitive.ObjectID, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := p.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if user.OrgID != org {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return user, nil
+}
+
+// ProjectRole returns the user's effective role in a project and whether they have one
+func (p *Policy) ProjectRole(user *domain.User, project *domain.Project) (domain.ProjectRole, bool) {
+	if project.OrgID != user.OrgID {
+		return "", false
+	}
+	if user.IsOrgAdmin() {
+		return domain.ProjectRoleAdmin, true
+	}
+	return project.RoleOf(user.ID)
+}
+
+// AuthorizeProject loads a project of the user's organization and checks the user
+// holds at least the given role in it. Projects the user cannot see are not found.
+func (p *Policy) AuthorizeProject(user *domain.User, projectID primitive.ObjectID, minimum domain.ProjectRole) (*domain.Project, error) {
+	project, err := p.projectRepo.FindByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.requireRole(user, project, minimum); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// requireRole checks the user holds at least the given role in a project
+func (p *Policy) requireRole(user *domain.User, project *domain.Project, minimum domain.ProjectRole) error {
+	role, ok := p.ProjectRole(user, project)
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if !role.AtLeast(minimum) {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+// authorizeProject applies the rules for projects as a whole
+func (p *Policy) authorizeProject(user *domain.User, project *domain.Project, action Action) error {
+	switch action {
+	case ActionRead:
+		return p.requireRole(user, project, domain.ProjectRoleViewer)
+	case ActionWrite, ActionAssign:
+		return p.requireRole(user, project, domain.ProjectRoleContributor)
+	case ActionDelete, ActionAdminister:
+		return p.requireRole(user, project, domain.ProjectRoleAdmin)
+	default:
+		return fmt.Errorf("unknown project action %q", action)
+	}
+}
+
+// authorizeTask applies the rules for tasks
+func (p *Policy) authorizeTask(user *domain.User, task *domain.Task, action Action) error {
+	if task.ProjectID.IsZero() {
+		return authorizeUnscoped(user, task, action)
+	}
+
+	grant := task.GrantOf(user.ID)
+	project, err := p.AuthorizeProject(user, task.ProjectID, domain.ProjectRoleViewer)
+	if errors.Is(err, domain.ErrNotFound) && grant != nil && task.OrgID == user.OrgID {
+		return authorizeGrant(grant, action)
+	}
+	if err != nil {
+		return err
+	}
+
+	role, _ := p.ProjectRole(user, project)
+	switch action {
+	case ActionRead:
+		return nil
+	case ActionWrite, ActionAssign:
+		if role.AtLeast(domain.ProjectRoleContributor) {
+			return nil
+		}
+		if action == ActionWrite && grant != nil && grant.Access == domain.TaskAccessEdit {
+			return nil
+		}
+	case ActionDelete:
+		if role.AtLeast(domain.ProjectRoleAdmin) ||
+			(role.AtLeast(domain.ProjectRoleContributor) && task.CreatedBy == user.ID) {
+			return nil
+		}
+	case ActionAdminister:
+	default:
+		return fmt.Errorf("unknown task action %q", action)
+	}
+
+	return domain.ErrUnauthorized
+}
+
+// authorizeUnscoped applies the rules for tasks that belong to no project
+func authorizeUnscoped(user *domain.User, task *domain.Task, action Action) error {
+	switch action {
+	case ActionRead:
+		return nil
+	case ActionWrite:
+		if task.CreatedBy == user.ID || task.IsAssignedTo(user.ID) {
+			return nil
+		}
+		if grant := task.GrantOf(user.ID); grant != nil && grant.Access == domain.TaskAccessEdit {
+			return nil
+		}
+	case ActionAssign, ActionDelete:
+		if task.CreatedBy == user.ID {
+			return nil
+		}
+	case ActionAdminister:
+	default:
+		return fmt.Errorf("unknown task action %q", action)
+	}
+
+	return domain.ErrUnauthorized
+}
+
+// authorizeGrant applies a user's grant on a task of a project they are not a member of
+func authorizeGrant(grant *domain.TaskGrant, action Action) error {
+	switch action {
+	case ActionRead:
+		return nil
+	case ActionWrite:
+		if grant.Access == domain.TaskAccessEdit {
+			return nil
+		}
+	case ActionAssign, ActionDelete, ActionAdminister:
+	default:
+		return fmt.Errorf("unknown task action %q", action)
+	}
+
+	return domain.ErrUnauthorized
+}
+
+// authorizeOrganization applies the rules for an organization as a whole. Users of
+// other organizations are unauthorized rather than not found, as they are when
+// they act within an organization that is not theirs.
+func authorizeOrganization(user *domain.User, org primitive.ObjectID, action Action) error {
+	if user.OrgID != org {
+		return domain.ErrUnauthorized
+	}
+
+	switch action {
+	case ActionRead:
+		return nil
+	case ActionWrite, ActionAssign, ActionDelete, ActionAdminister:
+		if user.IsOrgAdmin() {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unknown organization action %q", action)
+	}
+
+	return domain.ErrUnauthorized
+}
+
+// authorizeAccount applies the rules for user accounts: users change and delete
+// their own account only, and organization admins administer every account of
+// their organization
+func authorizeAccount(user *domain.User, account *domain.User, action Action) error {
+	if account.OrgID != user.OrgID {
+		return domain.ErrNotFound
+	}
+
+	switch action {
+	case ActionRead:
+		return nil
+	case ActionWrite, ActionDelete:
+		if account.ID == user.ID {
+			return nil
+		}
+		return domain.ErrUnauthorized
+	case ActionAssign, ActionAdminister:
+		return authorizeOrganization(user, account.OrgID, ActionAdminister)
+	default:
+		return fmt.Errorf("unknown account action %q", action)
+	}
+}
+
+// VisibleFilter returns a task filter that matches only the tasks the user may read,
+// including those they were granted access to, or nil when the user may read every
+// task of their organization
+func (p *Policy) VisibleFilter(user *domain.User) (map[string]interface{}, error) {
+	if user.IsOrgAdmin() {
+		return nil, nil
+	}
+
+	projectIDs, err := p.memberProjectIDs(user)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(projectIDs))
+	for id := range projectIDs {
+		ids = append(ids, id)
+	}
+
+	return map[string]interface{}{
+		"$or": []map[string]interface{}{
+			{"project_id": map[string]interface{}{"$exists": false}},
+			{"project_id": map[string]interface{}{"$in": ids}},
+			{"grants.user_id": user.ID},
+		},
+	}, nil
+}
+
+// FilterVisible drops the tasks the user may not read, keeping the order of the rest
+func (p *Policy) FilterVisible(user *domain.User, tasks []*domain.Task) ([]*domain.Task, error) {
+	if user.IsOrgAdmin() {
+		return tasks, nil
+	}
+
+	projectIDs, err := p.memberProjectIDs(user)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.ProjectID.IsZero() || projectIDs[task.ProjectID] || task.GrantOf(user.ID) != nil {
+			visible = append(visible, task)
+		}
+	}
+
+	return visible, nil
+}
+
+// memberProjectIDs returns the IDs of the projects the user is a member of
+func (p *Policy) memberProjectIDs(user *domain.User) (map[primitive.ObjectID]bool, error) {
+	projects, err := p.projectRepo.FindByMember(user.OrgID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[primitive.ObjectID]bool, len(projects))
+	for _, project := range projects {
+		ids[project.ID] = true
+	}
+
+	return ids, nil
+}
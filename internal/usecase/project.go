@@ -0,0 +1,411 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProjectUseCase handles business logic related to projects, their members, sprints and milestones
+type ProjectUseCase struct {
+	projectRepo   domain.ProjectRepository
+	taskRepo      domain.TaskRepository
+	userRepo      domain.UserRepository
+	sprintRepo    domain.SprintRepository
+	milestoneRepo domain.MilestoneRepository
+	hookRepo      domain.InboundHookRepository
+	policy        *Policy
+	audit         auditLog
+	enricher      taskEnricher
+	log           logger.Logger
+}
+
+// NewProjectUseCase creates a new project use case. Project deletions and member
+// changes are recorded in the audit log.
+func NewProjectUseCase(
+	projectRepo domain.ProjectRepository,
+	taskRepo domain.TaskRepository,
+	userRepo domain.UserRepository,
+	sprintRepo domain.SprintRepository,
+	milestoneRepo domain.MilestoneRepository,
+	hookRepo domain.InboundHookRepository,
+	auditRepo domain.AuditRepository,
+	policy *Policy,
+	log logger.Logger,
+) *ProjectUseCase {
+	return &ProjectUseCase{
+		projectRepo:   projectRepo,
+		taskRepo:      taskRepo,
+		userRepo:      userRepo,
+		sprintRepo:    sprintRepo,
+		milestoneRepo: milestoneRepo,
+		hookRepo:      hookRepo,
+		policy:        policy,
+		audit:         auditLog{repo: auditRepo, log: log},
+		enricher:      newTaskEnricher(userRepo, log),
+		log:           log,
+	}
+}
+
+// CreateProjectInput represents input data for project creation
+type CreateProjectInput struct {
+	OrgID             string
+	Key               string // Prefix of the task keys, e.g. PROJ; derived from the name when empty
+	Name              string
+	Description       string
+	WIPLimits         *domain.WIPLimits // Optional limits on the tasks in progress
+	RequireAcceptance bool              // Assignees accept or decline the tasks others assign them
+	StrictLabels      bool              // Tasks can only be given tags that are labels of the project
+	CreatedBy         string
+}
+
+// CreateProject creates a project in the organization. The creator becomes its admin.
+func (uc *ProjectUseCase) CreateProject(input *CreateProjectInput) (*domain.Project, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: project name is required", domain.ErrInvalidInput)
+	}
+
+	var limits domain.WIPLimits
+	if input.WIPLimits != nil {
+		limits = *input.WIPLimits
+		if err := validateWIPLimits(&limits); err != nil {
+			return nil, err
+		}
+	}
+
+	creator, err := uc.actor(input.OrgID, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := uc.projectKey(creator.OrgID, strings.ToUpper(strings.TrimSpace(input.Key)), name)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &domain.Project{
+		OrgID:             creator.OrgID,
+		Key:               key,
+		Name:              name,
+		Description:       input.Description,
+		Members:           []domain.ProjectMember{{UserID: creator.ID, Role: domain.ProjectRoleAdmin}},
+		WIPLimits:         limits,
+		RequireAcceptance: input.RequireAcceptance,
+		StrictLabels:      input.StrictLabels,
+		CreatedBy:         creator.ID,
+	}
+	if err := uc.projectRepo.Create(project); err != nil {
+		if errors.Is(err, domain.ErrDuplicateKey) {
+			return nil, fmt.Errorf("%w: project key %s is taken", domain.ErrDuplicateKey, key)
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// maxProjectKeyVariants bounds the search for a free derived project key
+const maxProjectKeyVariants = 100
+
+// projectKey validates a requested project key or, when none is given, derives
+// one from the name that no other project of the organization uses
+func (uc *ProjectUseCase) projectKey(org primitive.ObjectID, requested string, name string) (string, error) {
+	if requested != "" {
+		if !domain.ValidProjectKey(requested) {
+			return "", fmt.Errorf("%w: project key must be 2 to 10 letters or digits, starting with a letter", domain.ErrInvalidInput)
+		}
+		return requested, nil
+	}
+
+	derived := domain.DeriveProjectKey(name)
+	candidate := derived
+	for n := 2; n <= maxProjectKeyVariants; n++ {
+		_, err := uc.projectRepo.FindByKey(org, candidate)
+		if errors.Is(err, domain.ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidate = domain.ProjectKeyVariant(derived, n)
+	}
+
+	return "", fmt.Errorf("%w: choose a project key", domain.ErrInvalidInput)
+}
+
+// ListProjects lists the projects a user is a member of. Organization admins see every project.
+func (uc *ProjectUseCase) ListProjects(orgID string, userID string) ([]*domain.Project, error) {
+	user, err := uc.actor(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsOrgAdmin() {
+		return uc.projectRepo.FindByOrg(user.OrgID)
+	}
+	return uc.projectRepo.FindByMember(user.OrgID, user.ID)
+}
+
+// GetProject retrieves a project the user is a member of
+func (uc *ProjectUseCase) GetProject(orgID string, id string, userID string) (*domain.Project, error) {
+	_, project, err := uc.authorize(orgID, id, userID, domain.ProjectRoleViewer)
+	return project, err
+}
+
+// UpdateProjectInput represents input data for a project update
+type UpdateProjectInput struct {
+	OrgID             string
+	ID                string
+	Name              string
+	Description       string
+	WIPLimits         *domain.WIPLimits // Replaces the limits when set
+	RequireAcceptance *bool             // Turns acceptance of assignments on or off when set; pending assignments stay pending
+	StrictLabels      *bool             // Turns strict labels on or off when set; tasks keep the tags they have
+	UpdatedBy         string
+}
+
+// UpdateProject changes a project's name, description, WIP limits, whether
+// assignments must be accepted, or whether labels are strict. Only project
+// admins may do so.
+func (uc *ProjectUseCase) UpdateProject(input *UpdateProjectInput) (*domain.Project, error) {
+	_, project, err := uc.authorize(input.OrgID, input.ID, input.UpdatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		project.Name = name
+	}
+
+	if input.Description != "" {
+		project.Description = input.Description
+	}
+
+	if input.WIPLimits != nil {
+		limits := *input.WIPLimits
+		if err := validateWIPLimits(&limits); err != nil {
+			return nil, err
+		}
+		project.WIPLimits = limits
+	}
+
+	if input.RequireAcceptance != nil {
+		project.RequireAcceptance = *input.RequireAcceptance
+	}
+
+	if input.StrictLabels != nil {
+		project.StrictLabels = *input.StrictLabels
+	}
+
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// DeleteProject deletes a project. Only project admins may do so, and only once
+// the project has no tasks left.
+func (uc *ProjectUseCase) DeleteProject(orgID string, id string, userID string, client ClientInfo) error {
+	admin, project, err := uc.authorize(orgID, id, userID, domain.ProjectRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	count, err := uc.taskRepo.ForOrg(project.OrgID).Count(map[string]interface{}{"project_id": project.ID})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: project still has %d tasks", domain.ErrInvalidInput, count)
+	}
+
+	if err := uc.projectRepo.Delete(project.ID); err != nil {
+		return err
+	}
+	if err := uc.sprintRepo.DeleteByProject(project.ID); err != nil {
+		return err
+	}
+	if err := uc.milestoneRepo.DeleteByProject(project.ID); err != nil {
+		return err
+	}
+	if err := uc.hookRepo.DeleteByProject(project.ID); err != nil {
+		return err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      project.OrgID,
+		Action:     domain.AuditProjectDeleted,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetProject,
+		TargetID:   project.ID,
+		Details:    map[string]string{"name": project.Name},
+	}, client)
+
+	return nil
+}
+
+// SetProjectMemberInput represents input data for adding a project member or changing their role
+type SetProjectMemberInput struct {
+	OrgID     string
+	ProjectID string
+	MemberID  string
+	Role      domain.ProjectRole
+	UpdatedBy string
+	Client    ClientInfo
+}
+
+// SetProjectMember adds a user of the organization to a project or changes their
+// role. Only project admins may do so, and the project always keeps at least one admin.
+func (uc *ProjectUseCase) SetProjectMember(input *SetProjectMemberInput) (*domain.Project, error) {
+	if !input.Role.IsValid() {
+		return nil, fmt.Errorf("%w: role must be %q, %q, or %q", domain.ErrInvalidInput,
+			domain.ProjectRoleViewer, domain.ProjectRoleContributor, domain.ProjectRoleAdmin)
+	}
+
+	admin, project, err := uc.authorize(input.OrgID, input.ProjectID, input.UpdatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := uc.findMember(project.OrgID, input.MemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, _ := project.RoleOf(member.ID)
+	if previous == domain.ProjectRoleAdmin && input.Role != domain.ProjectRoleAdmin {
+		if err := ensureOtherProjectAdmin(project); err != nil {
+			return nil, err
+		}
+	}
+
+	project.SetMember(member.ID, input.Role)
+	if err := uc.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      project.OrgID,
+		Action:     domain.AuditProjectMemberRoleSet,
+		ActorID:    admin.ID,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   member.ID,
+		Details:    map[string]string{"project_id": project.ID.Hex(), "from": string(previous), "to": string(input.Role)},
+	}, input.Client)
+
+	return project, nil
+}
+
+// RemoveProjectMember removes a user from a project. Project admins may remove
+// anyone and members may remove themselves; the project always keeps at least one admin.
+func (uc *ProjectUseCase) RemoveProjectMember(orgID string, projectID string, memberID string, removedBy string, client ClientInfo) error {
+	minimum := domain.ProjectRoleAdmin
+	if memberID == removedBy {
+		minimum = domain.ProjectRoleViewer
+	}
+
+	actor, project, err := uc.authorize(orgID, projectID, removedBy, minimum)
+	if err != nil {
+		return err
+	}
+
+	id, err := primitive.ObjectIDFromHex(memberID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	role, ok := project.RoleOf(id)
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if role == domain.ProjectRoleAdmin {
+		if err := ensureOtherProjectAdmin(project); err != nil {
+			return err
+		}
+	}
+
+	project.RemoveMember(id)
+	if err := uc.projectRepo.Update(project); err != nil {
+		return err
+	}
+
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      project.OrgID,
+		Action:     domain.AuditProjectMemberRemoved,
+		ActorID:    actor.ID,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   id,
+		Details:    map[string]string{"project_id": project.ID.Hex(), "role": string(role)},
+	}, client)
+
+	return nil
+}
+
+// actor parses the organization and user IDs and loads the acting user
+func (uc *ProjectUseCase) actor(orgID string, userID string) (*domain.User, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	return uc.policy.Actor(org, id)
+}
+
+// authorize loads the acting user and a project, checking the user holds at least the given role in it
+func (uc *ProjectUseCase) authorize(orgID string, projectID string, userID string, minimum domain.ProjectRole) (*domain.User, *domain.Project, error) {
+	user, err := uc.actor(orgID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, nil, errors.New("invalid project ID format")
+	}
+
+	project, err := uc.policy.AuthorizeProject(user, id, minimum)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, project, nil
+}
+
+// findMember retrieves a user of the organization, treating users of other organizations as not found
+func (uc *ProjectUseCase) findMember(org primitive.ObjectID, memberID string) (*domain.User, error) {
+	id, err := primitive.ObjectIDFromHex(memberID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	member, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.OrgID != org {
+		return nil, domain.ErrNotFound
+	}
+
+	return member, nil
+}
+
+// ensureOtherProjectAdmin fails when the project has only one admin left
+func ensureOtherProjectAdmin(project *domain.Project) error {
+	if project.AdminCount() > 1 {
+		return nil
+	}
+	return fmt.Errorf("%w: a project must keep at least one admin", domain.ErrInvalidInput)
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SendDeferredSummaries sends the notifications held back during quiet hours that
+// have ended, for up to limit users: one summary message per channel, listing the
+// notifications it batches. Users who went quiet again since are postponed to the
+// end of their new quiet period. It returns the number of summaries sent.
+func (uc *NotificationUseCase) SendDeferredSummaries(now time.Time, limit int64) (int, error) {
+	if uc.deferredRepo == nil {
+		return 0, nil
+	}
+
+	users, err := uc.deferredRepo.FindDueUsers(now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, userID := range users {
+		delivered, err := uc.sendDeferred(userID, now)
+		sent += delivered
+		if err != nil {
+			uc.log.ErrorF("Failed to send deferred notifications to user %s: %v", userID.Hex(), err)
+		}
+	}
+
+	return sent, nil
+}
+
+// sendDeferred sends a user's due deferred notifications and returns the number
+// of summaries sent
+func (uc *NotificationUseCase) sendDeferred(userID primitive.ObjectID, now time.Time) (int, error) {
+	deferred, err := uc.deferredRepo.FindDue(userID, now)
+	if err != nil || len(deferred) == 0 {
+		return 0, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(deferred))
+	for _, d := range deferred {
+		ids = append(ids, d.ID)
+	}
+
+	recipient, err := uc.userRepo.FindByID(userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Deleted since; nobody is left to tell
+		_, err = uc.deferredRepo.DeleteMany(ids)
+		return 0, err
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	prefs, err := uc.preferencesFor(userID)
+	if err != nil {
+		return 0, err
+	}
+	if until, quiet := prefs.QuietUntil(now, recipient.Location()); quiet {
+		return 0, uc.deferredRepo.Postpone(userID, now, until)
+	}
+
+	// Remove them first, so a failing channel cannot have them sent over and over
+	if _, err := uc.deferredRepo.DeleteMany(ids); err != nil {
+		return 0, err
+	}
+
+	byChannel := make(map[domain.NotificationChannel][]string)
+	var channels []domain.NotificationChannel
+	for _, d := range deferred {
+		if _, ok := byChannel[d.Channel]; !ok {
+			channels = append(channels, d.Channel)
+		}
+		byChannel[d.Channel] = append(byChannel[d.Channel], d.Notification.Summary())
+	}
+
+	sent := 0
+	for _, channel := range channels {
+		notifier, ok := uc.notifiers[channel]
+		if !ok {
+			uc.log.WarnF("No notifier configured for channel %s", channel)
+			continue
+		}
+
+		items := byChannel[channel]
+		if len(items) > maxSummaryItems {
+			items = append(items[:maxSummaryItems-1], fmt.Sprintf("and %d more", len(items)-maxSummaryItems+1))
+		}
+
+		summary := &domain.Notification{
+			UserID:    userID,
+			Type:      domain.EventNotificationSummary,
+			Items:     items,
+			CreatedAt: now,
+		}
+		if err := notifier.Notify(recipient, prefs, summary); err != nil {
+			uc.log.ErrorF("Failed to deliver %s notification summary to user %s: %v", channel, userID.Hex(), err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bounds of the reminders a user can set
+const (
+	maxRemindersPerTask = 10
+	maxReminderLead     = 365 * 24 * time.Hour
+	maxBeforeDueMinutes = 30 * 24 * 60
+)
+
+// ReminderUseCase handles the reminders users set on tasks
+type ReminderUseCase struct {
+	reminderRepo  domain.TaskReminderRepository
+	taskRepo      domain.TaskRepository
+	notifications *NotificationUseCase
+	policy        *Policy
+	log           logger.Logger
+}
+
+// NewReminderUseCase creates a new reminder use case. Reminders are delivered
+// through the notification use case.
+func NewReminderUseCase(reminderRepo domain.TaskReminderRepository, taskRepo domain.TaskRepository, notifications *NotificationUseCase, policy *Policy, log logger.Logger) *ReminderUseCase {
+	return &ReminderUseCase{
+		reminderRepo:  reminderRepo,
+		taskRepo:      taskRepo,
+		notifications: notifications,
+		policy:        policy,
+		log:           log,
+	}
+}
+
+// CreateReminderInput represents a user's request to be reminded of a task,
+// either at a fixed time or a number of minutes before the task is due
+type CreateReminderInput struct {
+	OrgID            string
+	TaskID           string
+	UserID           string
+	RemindAt         time.Time
+	BeforeDueMinutes *int
+}
+
+// CreateReminder sets a reminder on an open task the user can see. Reminders
+// relative to the due date need a task with one, and follow it when it moves.
+func (uc *ReminderUseCase) CreateReminder(input *CreateReminderInput) (*domain.TaskReminder, error) {
+	relative := input.BeforeDueMinutes != nil
+	if relative == !input.RemindAt.IsZero() {
+		return nil, fmt.Errorf("%w: set either remind_at or before_due_minutes", domain.ErrInvalidInput)
+	}
+	if relative && (*input.BeforeDueMinutes < 0 || *input.BeforeDueMinutes > maxBeforeDueMinutes) {
+		return nil, fmt.Errorf("%w: before_due_minutes must be between 0 and %d", domain.ErrInvalidInput, maxBeforeDueMinutes)
+	}
+
+	user, task, err := uc.target(input.OrgID, input.TaskID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status == domain.TaskStatusCompleted {
+		return nil, fmt.Errorf("%w: completed tasks cannot have reminders", domain.ErrInvalidInput)
+	}
+	if relative && task.DueDate.IsZero() {
+		return nil, fmt.Errorf("%w: the task has no due date to remind before", domain.ErrInvalidInput)
+	}
+
+	reminder := &domain.TaskReminder{
+		OrgID:            user.OrgID,
+		UserID:           user.ID,
+		TaskID:           task.ID,
+		BeforeDueMinutes: input.BeforeDueMinutes,
+	}
+	if relative {
+		reminder.Schedule(task.DueDate)
+	} else {
+		remindAt := input.RemindAt
+		reminder.RemindAt = &remindAt
+	}
+
+	now := time.Now()
+	if !reminder.RemindAt.After(now) {
+		return nil, fmt.Errorf("%w: reminders must be in the future", domain.ErrInvalidInput)
+	}
+	if reminder.RemindAt.After(now.Add(maxReminderLead)) {
+		return nil, fmt.Errorf("%w: reminders can be set at most a year ahead", domain.ErrInvalidInput)
+	}
+
+	existing, err := uc.reminderRepo.FindByTask(user.ID, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxRemindersPerTask {
+		return nil, fmt.Errorf("%w: a task can have at most %d reminders", domain.ErrInvalidInput, maxRemindersPerTask)
+	}
+
+	if err := uc.reminderRepo.Create(reminder); err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+// ListTaskReminders retrieves the user's reminders of a task they can see, soonest first
+func (uc *ReminderUseCase) ListTaskReminders(orgID string, taskID string, userID string) ([]*domain.TaskReminder, error) {
+	user, task, err := uc.target(orgID, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.reminderRepo.FindByTask(user.ID, task.ID)
+}
+
+// ListReminders retrieves the user's upcoming reminders in their organization, soonest first
+func (uc *ReminderUseCase) ListReminders(orgID string, userID string) ([]*domain.TaskReminder, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders, err := uc.reminderRepo.FindByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	inOrg := make([]*domain.TaskReminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.OrgID == org {
+			inOrg = append(inOrg, reminder)
+		}
+	}
+	return inOrg, nil
+}
+
+// DeleteReminder deletes one of the user's reminders of a task
+func (uc *ReminderUseCase) DeleteReminder(orgID string, taskID string, reminderID string, userID string) error {
+	reminderObjID, err := primitive.ObjectIDFromHex(reminderID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid reminder ID format", domain.ErrInvalidInput)
+	}
+
+	user, task, err := uc.target(orgID, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	reminders, err := uc.reminderRepo.FindByTask(user.ID, task.ID)
+	if err != nil {
+		return err
+	}
+	for _, reminder := range reminders {
+		if reminder.ID == reminderObjID {
+			return uc.reminderRepo.Delete(user.ID, reminder.ID)
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// target loads the acting user and a task of the organization they can see
+func (uc *ReminderUseCase) target(orgID string, taskID string, userID string) (*domain.User, *domain.Task, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskObjID, userObjID, err := parseStarIDs(taskID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskObjID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.policy.Can(user, ActionRead, task); err != nil {
+		return nil, nil, err
+	}
+
+	return user, task, nil
+}
+
+// SendDueReminders delivers up to limit reminders whose time has come, each over
+// the channels its user routes due-soon notifications to. Reminders of tasks that
+// were completed, deleted or can no longer be seen by their user end silently.
+// It returns the number of reminders sent.
+func (uc *ReminderUseCase) SendDueReminders(now time.Time, limit int64) (int, error) {
+	due, err := uc.reminderRepo.FindDue(now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range due {
+		task, err := uc.taskRepo.ForOrg(reminder.OrgID).FindByID(reminder.TaskID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			uc.log.ErrorF("Failed to load task %s of reminder %s: %v", reminder.TaskID.Hex(), reminder.ID.Hex(), err)
+			continue
+		}
+
+		ok, err := uc.reminderRepo.Release(reminder)
+		if err != nil {
+			return sent, err
+		}
+		// Rescheduled since it was loaded
+		if !ok || task == nil || task.Status == domain.TaskStatusCompleted {
+			continue
+		}
+
+		user, err := uc.policy.Actor(reminder.OrgID, reminder.UserID)
+		if err == nil {
+			err = uc.policy.Can(user, ActionRead, task)
+		}
+		if err != nil {
+			uc.log.DebugF("Dropped reminder %s of task %s: %v", reminder.ID.Hex(), task.ID.Hex(), err)
+			continue
+		}
+
+		if err := uc.notifications.Remind(user.ID, task); err != nil {
+			uc.log.ErrorF("Failed to send reminder %s of task %s: %v", reminder.ID.Hex(), task.ID.Hex(), err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// HandleEvent moves the reminders relative to a task's due date along with it,
+// and deletes the reminders of deleted tasks. It is meant to be subscribed to
+// the event bus.
+func (uc *ReminderUseCase) HandleEvent(event *domain.Event) error {
+	if event.Task == nil {
+		return nil
+	}
+
+	switch event.Type {
+	case domain.EventTaskUpdated, domain.EventTaskStatusChanged:
+		return uc.reminderRepo.RescheduleTask(event.Task.ID, event.Task.DueDate)
+	case domain.EventTaskDeleted:
+		return uc.reminderRepo.DeleteByTask(event.Task.ID)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"task-management-system/internal/domain"
+)
+
+// Bounds of project reports
+const (
+	defaultReportDays = 7
+	maxReportDays     = 90
+	// maxReportTasks is the number of tasks listed per section; the rest are only counted
+	maxReportTasks = 100
+)
+
+// ProjectReport summarizes the state of a project's tasks, for status meetings
+type ProjectReport struct {
+	Project     *domain.Project
+	GeneratedAt time.Time
+	Location    *time.Location // Time zone of the user the report is for
+	Days        int            // Number of days ahead upcoming deadlines are listed for
+
+	Total    int
+	ByStatus []ReportStatusCount // Every status in workflow order
+
+	// Open tasks past their due date, most overdue first, and open tasks due
+	// within the report's days, soonest first. At most maxReportTasks are listed
+	// each; the totals count them all.
+	Overdue       []*domain.Task
+	OverdueTotal  int
+	Upcoming      []*domain.Task
+	UpcomingTotal int
+}
+
+// ReportStatusCount is the number of tasks in one status
+type ReportStatusCount struct {
+	Status domain.TaskStatus
+	Count  int
+}
+
+// ProjectReport summarizes the tasks of a project the user is a member of: the
+// number of tasks in each status, the open tasks that are overdue, and the open
+// tasks due within the given number of days, 7 when zero. Times are in the
+// user's time zone.
+func (uc *ProjectUseCase) ProjectReport(orgID string, projectID string, userID string, days int) (*ProjectReport, error) {
+	if days == 0 {
+		days = defaultReportDays
+	}
+	if days < 1 || days > maxReportDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", domain.ErrInvalidInput, maxReportDays)
+	}
+
+	user, project, err := uc.authorize(orgID, projectID, userID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(project.OrgID).FindAll(map[string]interface{}{
+		"project_id": project.ID,
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	horizon := now.AddDate(0, 0, days)
+	report := &ProjectReport{
+		Project:     project,
+		GeneratedAt: now,
+		Location:    user.Location(),
+		Days:        days,
+		Total:       len(tasks),
+	}
+
+	counts := make(map[domain.TaskStatus]int)
+	var overdue, upcoming []*domain.Task
+	for _, task := range tasks {
+		counts[task.Status]++
+		if task.Status == domain.TaskStatusCompleted || task.DueDate.IsZero() {
+			continue
+		}
+		switch {
+		case task.DueDate.Before(now):
+			overdue = append(overdue, task)
+		case task.DueDate.Before(horizon):
+			upcoming = append(upcoming, task)
+		}
+	}
+	for _, status := range domain.TaskStatuses() {
+		report.ByStatus = append(report.ByStatus, ReportStatusCount{Status: status, Count: counts[status]})
+	}
+
+	byDueDate := func(tasks []*domain.Task) {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].DueDate.Before(tasks[j].DueDate)
+		})
+	}
+	byDueDate(overdue)
+	byDueDate(upcoming)
+
+	report.OverdueTotal, report.UpcomingTotal = len(overdue), len(upcoming)
+	report.Overdue = overdue[:min(len(overdue), maxReportTasks)]
+	report.Upcoming = upcoming[:min(len(upcoming), maxReportTasks)]
+	uc.enricher.enrich(append(append([]*domain.Task{}, report.Overdue...), report.Upcoming...)...)
+
+	return report, nil
+}
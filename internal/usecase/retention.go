@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+)
+
+// RetentionPolicy sets how long each kind of expiring data is kept.
+// A zero duration keeps that data forever.
+type RetentionPolicy struct {
+	Notifications   time.Duration
+	DeliveredEvents time.Duration
+	LoginAttempts   time.Duration
+	// Invitations is how long invitations are kept once accepted, revoked or expired
+	Invitations time.Duration
+	// DeferredNotifications is how long deferred notifications are kept past
+	// their delivery time when they could not be sent
+	DeferredNotifications time.Duration
+}
+
+// RetentionUseCase purges data that has outlived its retention period
+type RetentionUseCase struct {
+	notificationRepo domain.NotificationRepository
+	outboxRepo       domain.OutboxRepository
+	loginAttemptRepo domain.LoginAttemptRepository
+	invitationRepo   domain.InvitationRepository
+	deferredRepo     domain.DeferredNotificationRepository
+	policy           RetentionPolicy
+	log              logger.Logger
+}
+
+// NewRetentionUseCase creates a new retention use case
+func NewRetentionUseCase(
+	notificationRepo domain.NotificationRepository,
+	outboxRepo domain.OutboxRepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
+	invitationRepo domain.InvitationRepository,
+	deferredRepo domain.DeferredNotificationRepository,
+	policy RetentionPolicy,
+	log logger.Logger,
+) *RetentionUseCase {
+	return &RetentionUseCase{
+		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		invitationRepo:   invitationRepo,
+		deferredRepo:     deferredRepo,
+		policy:           policy,
+		log:              log,
+	}
+}
+
+// Purge deletes all data older than its retention period as of now
+func (uc *RetentionUseCase) Purge(now time.Time) error {
+	purges := []struct {
+		retention    time.Duration
+		what         string
+		deleteBefore func(cutoff time.Time) (int64, error)
+	}{
+		{uc.policy.Notifications, "expired notifications", uc.notificationRepo.DeleteCreatedBefore},
+		{uc.policy.DeliveredEvents, "delivered outbox events", uc.outboxRepo.DeleteDeliveredBefore},
+		{uc.policy.LoginAttempts, "old login attempts", uc.loginAttemptRepo.DeleteCreatedBefore},
+		{uc.policy.Invitations, "finished invitations", uc.invitationRepo.DeleteFinishedBefore},
+		{uc.policy.DeferredNotifications, "undeliverable deferred notifications", uc.deferredRepo.DeleteDueBefore},
+	}
+
+	for _, purge := range purges {
+		if purge.retention <= 0 {
+			continue
+		}
+
+		deleted, err := purge.deleteBefore(now.Add(-purge.retention))
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			uc.log.InfoF("Purged %d %s", deleted, purge.what)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxSnoozeDuration bounds how far ahead a task can be snoozed
+const maxSnoozeDuration = 365 * 24 * time.Hour
+
+// errSnoozeUnavailable is returned when the use case was created without snoozes
+var errSnoozeUnavailable = errors.New("snoozing is not available")
+
+// SnoozeTaskInput represents a user's request to snooze a task
+type SnoozeTaskInput struct {
+	OrgID  string
+	TaskID string
+	UserID string
+	Until  time.Time
+}
+
+// SnoozeTask hides an open task the user can see from their own task list and
+// daily digest until the given time, when it comes back with a notification.
+// Snoozing a snoozed task moves the end of the snooze.
+func (uc *TaskUseCase) SnoozeTask(input *SnoozeTaskInput) (*domain.TaskSnooze, error) {
+	if uc.snoozes == nil {
+		return nil, errSnoozeUnavailable
+	}
+
+	now := time.Now()
+	if !input.Until.After(now) {
+		return nil, fmt.Errorf("%w: snooze must end in the future", domain.ErrInvalidInput)
+	}
+	if input.Until.After(now.Add(maxSnoozeDuration)) {
+		return nil, fmt.Errorf("%w: tasks can be snoozed for at most a year", domain.ErrInvalidInput)
+	}
+
+	user, task, err := uc.snoozeTarget(input.OrgID, input.TaskID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status == domain.TaskStatusCompleted {
+		return nil, fmt.Errorf("%w: completed tasks cannot be snoozed", domain.ErrInvalidInput)
+	}
+
+	snooze := &domain.TaskSnooze{
+		OrgID:  user.OrgID,
+		UserID: user.ID,
+		TaskID: task.ID,
+		Until:  input.Until,
+	}
+	if err := uc.snoozes.Snooze(snooze); err != nil {
+		return nil, err
+	}
+
+	return snooze, nil
+}
+
+// UnsnoozeTask ends the user's snooze of a task right away
+func (uc *TaskUseCase) UnsnoozeTask(orgID string, taskID string, userID string) error {
+	if uc.snoozes == nil {
+		return errSnoozeUnavailable
+	}
+
+	user, task, err := uc.snoozeTarget(orgID, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.snoozes.Unsnooze(user.ID, task.ID)
+}
+
+// snoozeTarget loads the acting user and a task of the organization they can see
+func (uc *TaskUseCase) snoozeTarget(orgID string, taskID string, userID string) (*domain.User, *domain.Task, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskObjID, userObjID, err := parseStarIDs(taskID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskObjID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.policy.Can(user, ActionRead, task); err != nil {
+		return nil, nil, err
+	}
+
+	return user, task, nil
+}
+
+// GetSnoozedTasks retrieves the organization's tasks the user has snoozed, ending
+// soonest first, with the end of each snooze
+func (uc *TaskUseCase) GetSnoozedTasks(orgID string, userID string) ([]*domain.Task, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.snoozes == nil {
+		return []*domain.Task{}, nil
+	}
+
+	snoozes, err := uc.snoozes.FindActive(user.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(snoozes) == 0 {
+		return []*domain.Task{}, nil
+	}
+
+	taskIDs := make([]primitive.ObjectID, 0, len(snoozes))
+	for _, snooze := range snoozes {
+		taskIDs = append(taskIDs, snooze.TaskID)
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(org).FindAll(map[string]interface{}{
+		"_id": map[string]interface{}{"$in": taskIDs},
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+
+	// Snoozes of tasks the user can no longer see are skipped
+	tasks, err = uc.policy.FilterVisible(user, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore snooze order; snoozes of tasks that no longer exist are skipped
+	byID := make(map[primitive.ObjectID]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	snoozed := make([]*domain.Task, 0, len(tasks))
+	for _, snooze := range snoozes {
+		if task, ok := byID[snooze.TaskID]; ok {
+			until := snooze.Until
+			task.SnoozedUntil = &until
+			snoozed = append(snoozed, task)
+		}
+	}
+
+	uc.enricher.enrich(snoozed...)
+
+	return snoozed, nil
+}
+
+// withoutSnoozed leaves the tasks a user has snoozed out of a list
+func (uc *TaskUseCase) withoutSnoozed(userID primitive.ObjectID, tasks []*domain.Task) ([]*domain.Task, error) {
+	if uc.snoozes == nil || len(tasks) == 0 {
+		return tasks, nil
+	}
+
+	snoozes, err := uc.snoozes.FindActive(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(snoozes) == 0 {
+		return tasks, nil
+	}
+
+	snoozed := make(map[primitive.ObjectID]bool, len(snoozes))
+	for _, snooze := range snoozes {
+		snoozed[snooze.TaskID] = true
+	}
+
+	visible := make([]*domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !snoozed[task.ID] {
+			visible = append(visible, task)
+		}
+	}
+	return visible, nil
+}
+
+// ReleaseSnoozes ends up to limit snoozes whose time has come, notifying each
+// user that their task is back. Snoozes of deleted tasks end silently. It
+// returns the number of snoozes ended.
+func (uc *TaskUseCase) ReleaseSnoozes(now time.Time, limit int64) (int, error) {
+	if uc.snoozes == nil {
+		return 0, nil
+	}
+
+	ended, err := uc.snoozes.FindEnded(now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, snooze := range ended {
+		task, err := uc.taskRepo.ForOrg(snooze.OrgID).FindByID(snooze.TaskID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			uc.log.ErrorF("Failed to load snoozed task %s: %v", snooze.TaskID.Hex(), err)
+			continue
+		}
+
+		ok, err := uc.snoozes.Release(snooze)
+		if err != nil {
+			return released, err
+		}
+		// Snoozed again since it was loaded
+		if !ok {
+			continue
+		}
+		released++
+
+		if task != nil && uc.events != nil {
+			uc.events.Publish(taskEvent(domain.EventTaskUnsnoozed, primitive.NilObjectID, snooze.UserID, task))
+		}
+	}
+
+	return released, nil
+}
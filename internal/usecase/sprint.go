@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateSprintInput represents input data for sprint creation
+type CreateSprintInput struct {
+	OrgID     string
+	ProjectID string
+	Name      string
+	Goal      string
+	StartDate time.Time
+	EndDate   time.Time
+	CreatedBy string
+}
+
+// CreateSprint creates a sprint in a project. Only project admins may do so.
+func (uc *ProjectUseCase) CreateSprint(input *CreateSprintInput) (*domain.Sprint, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: sprint name is required", domain.ErrInvalidInput)
+	}
+	if input.StartDate.IsZero() || input.EndDate.IsZero() {
+		return nil, fmt.Errorf("%w: sprint start and end dates are required", domain.ErrInvalidInput)
+	}
+	if !input.EndDate.After(input.StartDate) {
+		return nil, fmt.Errorf("%w: sprint must end after it starts", domain.ErrInvalidInput)
+	}
+
+	creator, project, err := uc.authorize(input.OrgID, input.ProjectID, input.CreatedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint := &domain.Sprint{
+		OrgID:     project.OrgID,
+		ProjectID: project.ID,
+		Name:      name,
+		Goal:      strings.TrimSpace(input.Goal),
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+		CreatedBy: creator.ID,
+	}
+	if err := uc.sprintRepo.Create(sprint); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+// ListSprints lists the sprints of a project the user is a member of, by start date
+func (uc *ProjectUseCase) ListSprints(orgID string, projectID string, userID string) ([]*domain.Sprint, error) {
+	_, project, err := uc.authorize(orgID, projectID, userID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.sprintRepo.FindByProject(project.ID)
+}
+
+// GetSprint retrieves a sprint of a project the user is a member of
+func (uc *ProjectUseCase) GetSprint(orgID string, id string, userID string) (*domain.Sprint, error) {
+	_, sprint, err := uc.authorizeSprint(orgID, id, userID, domain.ProjectRoleViewer)
+	return sprint, err
+}
+
+// SprintTaskInput represents a request to plan a task into a sprint or take it out
+type SprintTaskInput struct {
+	OrgID    string
+	SprintID string
+	TaskID   string
+	UserID   string
+}
+
+// AddSprintTask plans a task of the sprint's project into an open sprint, moving
+// it out of any other sprint. Project contributors who may change the task may do so.
+func (uc *ProjectUseCase) AddSprintTask(input *SprintTaskInput) (*domain.Task, error) {
+	user, sprint, task, err := uc.sprintTask(input)
+	if err != nil {
+		return nil, err
+	}
+	if task.ProjectID != sprint.ProjectID {
+		return nil, fmt.Errorf("%w: task is not in the sprint's project", domain.ErrInvalidInput)
+	}
+	if err := uc.policy.Can(user, ActionWrite, task); err != nil {
+		return nil, err
+	}
+
+	task.SprintID = sprint.ID
+	if err := uc.taskRepo.ForOrg(sprint.OrgID).Update(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// RemoveSprintTask takes a task out of an open sprint, back to the project's
+// backlog. Project contributors who may change the task may do so.
+func (uc *ProjectUseCase) RemoveSprintTask(input *SprintTaskInput) error {
+	user, sprint, task, err := uc.sprintTask(input)
+	if err != nil {
+		return err
+	}
+	if task.SprintID != sprint.ID {
+		return domain.ErrNotFound
+	}
+	if err := uc.policy.Can(user, ActionWrite, task); err != nil {
+		return err
+	}
+
+	task.SprintID = primitive.NilObjectID
+	return uc.taskRepo.ForOrg(sprint.OrgID).Update(task)
+}
+
+// sprintTask loads the acting contributor, an open sprint and a task of the organization
+func (uc *ProjectUseCase) sprintTask(input *SprintTaskInput) (*domain.User, *domain.Sprint, *domain.Task, error) {
+	user, sprint, err := uc.authorizeSprint(input.OrgID, input.SprintID, input.UserID, domain.ProjectRoleContributor)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if sprint.IsClosed() {
+		return nil, nil, nil, fmt.Errorf("%w: sprint is closed", domain.ErrInvalidInput)
+	}
+
+	taskID, err := primitive.ObjectIDFromHex(input.TaskID)
+	if err != nil {
+		return nil, nil, nil, errors.New("invalid task ID format")
+	}
+
+	task, err := uc.taskRepo.ForOrg(sprint.OrgID).FindByID(taskID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return user, sprint, task, nil
+}
+
+// CloseSprintInput represents input data for closing a sprint
+type CloseSprintInput struct {
+	OrgID string
+	ID    string
+	// NextSprintID is the open sprint of the same project that incomplete tasks
+	// roll forward to; when empty, the project's next open sprint is used
+	NextSprintID string
+	ClosedBy     string
+}
+
+// CloseSprintOutput summarizes a closed sprint
+type CloseSprintOutput struct {
+	Sprint     *domain.Sprint `json:"sprint"`
+	Completed  int64          `json:"completed"`   // Completed tasks left in the sprint
+	RolledOver int            `json:"rolled_over"` // Incomplete tasks moved out of the sprint
+	// NextSprintID is the sprint the incomplete tasks moved to; empty when they went back to the backlog
+	NextSprintID string `json:"next_sprint_id,omitempty"`
+}
+
+// CloseSprint closes an open sprint and rolls its incomplete tasks forward to the
+// given sprint or, when none is given, to the project's next open sprint by start
+// date. Without an open sprint to roll to, they go back to the backlog. Completed
+// tasks stay in the closed sprint. Only project admins may close sprints.
+func (uc *ProjectUseCase) CloseSprint(input *CloseSprintInput) (*CloseSprintOutput, error) {
+	_, sprint, err := uc.authorizeSprint(input.OrgID, input.ID, input.ClosedBy, domain.ProjectRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.IsClosed() {
+		return nil, fmt.Errorf("%w: sprint is already closed", domain.ErrInvalidInput)
+	}
+
+	next, err := uc.nextSprint(sprint, input.NextSprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := uc.taskRepo.ForOrg(sprint.OrgID)
+	incomplete, err := repo.FindAll(map[string]interface{}{
+		"sprint_id": sprint.ID,
+		"status":    map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CloseSprintOutput{Sprint: sprint}
+	if next != nil {
+		output.NextSprintID = next.ID.Hex()
+	}
+
+	// Tasks are moved before the sprint is closed, so that closing can be retried after a failure
+	for _, task := range incomplete {
+		task.SprintID = primitive.NilObjectID
+		if next != nil {
+			task.SprintID = next.ID
+		}
+		if err := repo.Update(task); err != nil {
+			return nil, err
+		}
+		output.RolledOver++
+	}
+
+	if output.Completed, err = repo.Count(map[string]interface{}{"sprint_id": sprint.ID}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sprint.ClosedAt = &now
+	if err := uc.sprintRepo.Update(sprint); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// nextSprint returns the open sprint a closing sprint's incomplete tasks roll
+// forward to: the requested one or the project's next open sprint, if any
+func (uc *ProjectUseCase) nextSprint(closing *domain.Sprint, requested string) (*domain.Sprint, error) {
+	if requested != "" {
+		id, err := primitive.ObjectIDFromHex(requested)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid next sprint ID format", domain.ErrInvalidInput)
+		}
+
+		next, err := uc.sprintRepo.FindByID(id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: next sprint not found", domain.ErrInvalidInput)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if next.ProjectID != closing.ProjectID {
+			return nil, fmt.Errorf("%w: next sprint is not a sprint of the project", domain.ErrInvalidInput)
+		}
+		if next.ID == closing.ID || next.IsClosed() {
+			return nil, fmt.Errorf("%w: next sprint must be another open sprint", domain.ErrInvalidInput)
+		}
+		return next, nil
+	}
+
+	sprints, err := uc.sprintRepo.FindByProject(closing.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sprint := range sprints {
+		if sprint.ID != closing.ID && !sprint.IsClosed() && !sprint.StartDate.Before(closing.StartDate) {
+			return sprint, nil
+		}
+	}
+	return nil, nil
+}
+
+// authorizeSprint loads the acting user and a sprint, checking the user holds at
+// least the given role in its project. Sprints of other organizations are not found.
+func (uc *ProjectUseCase) authorizeSprint(orgID string, sprintID string, userID string, minimum domain.ProjectRole) (*domain.User, *domain.Sprint, error) {
+	user, err := uc.actor(orgID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(sprintID)
+	if err != nil {
+		return nil, nil, errors.New("invalid sprint ID format")
+	}
+
+	sprint, err := uc.sprintRepo.FindByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sprint.OrgID != user.OrgID {
+		return nil, nil, domain.ErrNotFound
+	}
+
+	if _, err := uc.policy.AuthorizeProject(user, sprint.ProjectID, minimum); err != nil {
+		return nil, nil, err
+	}
+
+	return user, sprint, nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"errors"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StarUseCase handles business logic related to starred tasks
+type StarUseCase struct {
+	starRepo domain.TaskStarRepository
+	taskRepo domain.TaskRepository
+	policy   *Policy
+	enricher taskEnricher
+	log      logger.Logger
+}
+
+// NewStarUseCase creates a new star use case
+func NewStarUseCase(starRepo domain.TaskStarRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, policy *Policy, log logger.Logger) *StarUseCase {
+	return &StarUseCase{
+		starRepo: starRepo,
+		taskRepo: taskRepo,
+		policy:   policy,
+		enricher: newTaskEnricher(userRepo, log),
+		log:      log,
+	}
+}
+
+// StarTask stars a task of the user's organization
+func (uc *StarUseCase) StarTask(orgID string, taskID string, userID string) error {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return err
+	}
+
+	taskObjID, userObjID, err := parseStarIDs(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return err
+	}
+
+	// Verify that the task exists within the organization and the user can see it
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskObjID)
+	if err != nil {
+		return err
+	}
+	if err := uc.policy.Can(user, ActionRead, task); err != nil {
+		return err
+	}
+
+	return uc.starRepo.Star(userObjID, taskObjID)
+}
+
+// UnstarTask removes a user's star from a task
+func (uc *StarUseCase) UnstarTask(taskID string, userID string) error {
+	taskObjID, userObjID, err := parseStarIDs(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.starRepo.Unstar(userObjID, taskObjID)
+}
+
+// GetStarredTasks retrieves the organization's tasks starred by a user, most recently starred first
+func (uc *StarUseCase) GetStarredTasks(orgID string, userID string) ([]*domain.Task, error) {
+	// Convert IDs from string to ObjectID
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskIDs, err := uc.starRepo.FindTaskIDsByUser(userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(taskIDs) == 0 {
+		return []*domain.Task{}, nil
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(org).FindAll(map[string]interface{}{
+		"_id": map[string]interface{}{"$in": taskIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Stars on tasks the user can no longer see are skipped
+	tasks, err = uc.policy.FilterVisible(user, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore star order; stars on tasks that no longer exist are skipped
+	byID := make(map[primitive.ObjectID]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	starred := make([]*domain.Task, 0, len(tasks))
+	for _, id := range taskIDs {
+		if task, ok := byID[id]; ok {
+			starred = append(starred, task)
+		}
+	}
+
+	uc.enricher.enrich(starred...)
+
+	return starred, nil
+}
+
+// parseStarIDs converts task and user IDs from string to ObjectID
+func parseStarIDs(taskID string, userID string) (primitive.ObjectID, primitive.ObjectID, error) {
+	taskObjID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, errors.New("invalid task ID format")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, errors.New("invalid user ID format")
+	}
+
+	return taskObjID, userObjID, nil
+}
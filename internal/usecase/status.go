@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+)
+
+// Coarse states of the service
+const (
+	ServiceStatusOK       = "ok"
+	ServiceStatusDegraded = "degraded"
+)
+
+// ServiceStatus is the public state of the service, for uptime monitors and
+// status pages. It holds nothing that is not safe to show anyone.
+type ServiceStatus struct {
+	// Status is ok, or degraded when the database does not answer
+	Status        string    `json:"status" example:"ok"`
+	Version       string    `json:"version" example:"1.0.0"`
+	UptimeSeconds int64     `json:"uptime_seconds" example:"86400"`
+	CheckedAt     time.Time `json:"checked_at" example:"2025-03-10T09:00:00Z"`
+}
+
+// StatusUseCase reports the public state of the service
+type StatusUseCase struct {
+	version   string
+	startedAt time.Time
+	database  domain.Pinger
+	reuseFor  time.Duration
+	log       logger.Logger
+
+	mu   sync.Mutex
+	last *ServiceStatus
+}
+
+// NewStatusUseCase creates a new status use case for the running version. The
+// database is checked at most once per reuse period, however often the status
+// is asked for; zero checks it every time.
+func NewStatusUseCase(version string, database domain.Pinger, reuseFor time.Duration, log logger.Logger) *StatusUseCase {
+	return &StatusUseCase{
+		version:   version,
+		startedAt: time.Now(),
+		database:  database,
+		reuseFor:  reuseFor,
+		log:       log,
+	}
+}
+
+// GetStatus returns the state of the service, reusing the last one while it is recent
+func (uc *StatusUseCase) GetStatus(now time.Time) ServiceStatus {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.last != nil && now.Sub(uc.last.CheckedAt) < uc.reuseFor {
+		return *uc.last
+	}
+
+	status := ServiceStatus{
+		Status:        ServiceStatusOK,
+		Version:       uc.version,
+		UptimeSeconds: int64(now.Sub(uc.startedAt).Seconds()),
+		CheckedAt:     now.UTC(),
+	}
+	// The cause stays in the logs; the status is public
+	if err := uc.database.Ping(); err != nil {
+		uc.log.WarnF("Status check failed to ping the database: %v", err)
+		status.Status = ServiceStatusDegraded
+	}
+
+	uc.last = &status
+	return status
+}
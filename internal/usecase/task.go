@@ -2,9 +2,12 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -13,16 +16,145 @@ import (
 type TaskUseCase struct {
 	taskRepo domain.TaskRepository
 	userRepo domain.UserRepository
+	searcher domain.TaskSearcher
+	counters domain.CounterRepository
+	snoozes  domain.TaskSnoozeRepository
+	views    domain.TaskViewRepository
+	audit    auditLog
+	events   domain.EventPublisher
+	uow      domain.UnitOfWork
+	policy   *Policy
+	content  ContentPolicy
+	pages    PageLimits
+	guards   QueryGuardrails
+	enricher taskEnricher
+	log      logger.Logger
 }
 
-// NewTaskUseCase creates a new task use case
-func NewTaskUseCase(taskRepo domain.TaskRepository, userRepo domain.UserRepository) *TaskUseCase {
+// NewTaskUseCase creates a new task use case. When a unit of work is given, task
+// writes and their events are stored atomically through the outbox; otherwise
+// events go straight to the event publisher after each write. Both may be nil.
+// Every task access is authorized by the policy. Project tasks are numbered
+// with the counters. Titles and descriptions are cleaned by the content policy.
+// Snoozed tasks are left out of their users' own task lists; snoozes may be nil.
+// Tasks that changed since their creator or an assignee last saw them are
+// flagged as unread in that user's lists; views may be nil.
+// Task lists are paged within the page limits, and expensive list filters are
+// bounded by the guardrails. Changes made while impersonating are recorded in the
+// audit log.
+func NewTaskUseCase(
+	taskRepo domain.TaskRepository,
+	userRepo domain.UserRepository,
+	searcher domain.TaskSearcher,
+	counters domain.CounterRepository,
+	snoozes domain.TaskSnoozeRepository,
+	views domain.TaskViewRepository,
+	auditRepo domain.AuditRepository,
+	events domain.EventPublisher,
+	uow domain.UnitOfWork,
+	policy *Policy,
+	content ContentPolicy,
+	pages PageLimits,
+	guards QueryGuardrails,
+	log logger.Logger,
+) *TaskUseCase {
 	return &TaskUseCase{
 		taskRepo: taskRepo,
 		userRepo: userRepo,
+		searcher: searcher,
+		counters: counters,
+		snoozes:  snoozes,
+		views:    views,
+		audit:    auditLog{repo: auditRepo, log: log},
+		events:   events,
+		uow:      uow,
+		policy:   policy,
+		content:  content,
+		pages:    pages,
+		guards:   guards,
+		enricher: newTaskEnricher(userRepo, log),
+		log:      log,
 	}
 }
 
+// taskEvent builds a task event
+func taskEvent(eventType domain.EventType, actorID primitive.ObjectID, subjectID primitive.ObjectID, task *domain.Task) *domain.Event {
+	return &domain.Event{
+		Type:       eventType,
+		ActorID:    actorID,
+		SubjectID:  subjectID,
+		Task:       task,
+		OccurredAt: time.Now(),
+	}
+}
+
+// auditTask records a change to a task made while impersonating
+func (uc *TaskUseCase) auditTask(action domain.AuditAction, actorID primitive.ObjectID, task *domain.Task, details map[string]string, client ClientInfo) {
+	uc.audit.recordImpersonated(&domain.AuditEntry{
+		OrgID:      task.OrgID,
+		Action:     action,
+		ActorID:    actorID,
+		TargetType: domain.AuditTargetTask,
+		TargetID:   task.ID,
+		Details:    details,
+	}, client)
+}
+
+// parseOrgID converts an organization ID from string to ObjectID
+func parseOrgID(orgID string) (primitive.ObjectID, error) {
+	org, err := primitive.ObjectIDFromHex(orgID)
+	if err != nil {
+		return primitive.NilObjectID, errors.New("invalid organization ID format")
+	}
+	return org, nil
+}
+
+// save applies a task write within an organization and emits the events
+// describing it. With a unit of work, the events are recorded in the outbox in
+// the same transaction, so they are never lost nor emitted for a write that did
+// not commit. Users who change a task have seen the change, so it does not show
+// as unread to them.
+func (uc *TaskUseCase) save(org primitive.ObjectID, write func(repo domain.TaskRepository) error, events ...*domain.Event) error {
+	if err := uc.apply(org, write, events...); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if event.Task != nil && event.Type != domain.EventTaskDeleted {
+			uc.markSeen(event.ActorID, event.Task.ID, event.Task.UpdatedAt)
+		}
+	}
+	return nil
+}
+
+// apply applies a task write and emits its events, through the unit of work if any
+func (uc *TaskUseCase) apply(org primitive.ObjectID, write func(repo domain.TaskRepository) error, events ...*domain.Event) error {
+	if uc.uow != nil {
+		return uc.uow.Do(func(tasks domain.TaskRepository, outbox domain.OutboxRepository) error {
+			if err := write(tasks.ForOrg(org)); err != nil {
+				return err
+			}
+			for _, event := range events {
+				if err := outbox.Add(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	if err := write(uc.taskRepo.ForOrg(org)); err != nil {
+		return err
+	}
+
+	if uc.events != nil {
+		for _, event := range events {
+			uc.events.Publish(event)
+		}
+	}
+	return nil
+}
+
 // CreateTaskInput represents input data for task creation
 type CreateTaskInput struct {
 	Title       string
@@ -30,12 +162,48 @@ type CreateTaskInput struct {
 	Priority    int
 	DueDate     time.Time
 	CreatedBy   string // User ID as string
+	OrgID       string // Organization of the creator
+	ProjectID   string // Optional project; the creator must be a contributor
+	Tags        []string
+	Client      ClientInfo
 }
 
-// CreateTask creates a new task
+// CreateTask creates a new task. Unassigned project tasks are then assigned by
+// the project's auto-assignment rules, if any match.
 func (uc *TaskUseCase) CreateTask(input *CreateTaskInput) (*domain.Task, error) {
+	task, err := uc.newTask(input, uc.userRepo.FindByID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save to repository
+	err = uc.save(task.OrgID, func(repo domain.TaskRepository) error {
+		return repo.Create(task)
+	}, taskEvent(domain.EventTaskCreated, task.CreatedBy, primitive.NilObjectID, task))
+	if err != nil {
+		return nil, err
+	}
+	uc.auditTask(domain.AuditTaskCreated, task.CreatedBy, task, nil, input.Client)
+
+	// The task is created even when auto-assignment fails
+	if err := uc.autoAssign(task); err != nil {
+		uc.log.ErrorF("Failed to auto-assign task %s: %v", task.ID.Hex(), err)
+	}
+
+	uc.enricher.enrich(task)
+
+	return task, nil
+}
+
+// newTask validates and authorizes task creation input and builds the task to create.
+// Creators are looked up with findUser.
+func (uc *TaskUseCase) newTask(input *CreateTaskInput, findUser func(id primitive.ObjectID) (*domain.User, error)) (*domain.Task, error) {
 	// Validate input
-	if input.Title == "" {
+	title, description := input.Title, input.Description
+	if err := uc.content.clean(&title, &description); err != nil {
+		return nil, err
+	}
+	if title == "" {
 		return nil, domain.ErrInvalidInput
 	}
 
@@ -44,57 +212,126 @@ func (uc *TaskUseCase) CreateTask(input *CreateTaskInput) (*domain.Task, error)
 		return nil, errors.New("priority must be between 1 and 5")
 	}
 
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert creator ID from string to ObjectID
 	creatorID, err := primitive.ObjectIDFromHex(input.CreatedBy)
 	if err != nil {
 		return nil, errors.New("invalid creator ID format")
 	}
 
-	// Verify that creator exists
-	_, err = uc.userRepo.FindByID(creatorID)
+	org, err := parseOrgID(input.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that creator exists and belongs to the organization
+	creator, err := findUser(creatorID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, errors.New("creator user not found")
 		}
 		return nil, err
 	}
+	if creator.OrgID != org {
+		return nil, errors.New("creator user not found")
+	}
+
+	// Verify that the creator may add tasks to the project, if any
+	var project *domain.Project
+	if input.ProjectID != "" {
+		projectID, err := primitive.ObjectIDFromHex(input.ProjectID)
+		if err != nil {
+			return nil, errors.New("invalid project ID format")
+		}
+		if project, err = uc.policy.AuthorizeProject(creator, projectID, domain.ProjectRoleContributor); err != nil {
+			return nil, err
+		}
+		if err := checkProjectTags(project, nil, tags); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create the task
 	task := &domain.Task{
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      domain.TaskStatusPending,
+		Title:       title,
+		Description: description,
+		Status:      domain.InitialTaskStatus,
 		Priority:    input.Priority,
 		DueDate:     input.DueDate,
+		Tags:        tags,
 		CreatedBy:   creatorID,
+		OrgID:       org,
 	}
 
-	// Save to repository
-	err = uc.taskRepo.Create(task)
-	if err != nil {
-		return nil, err
+	// Number project tasks; a number is skipped when the task is not saved after all
+	if project != nil {
+		task.ProjectID = project.ID
+		if project.Key != "" {
+			task.Number, err = uc.counters.Next(domain.ProjectTaskCounter(project.ID))
+			if err != nil {
+				return nil, err
+			}
+			task.Key = domain.TaskKey(project.Key, task.Number)
+		}
 	}
 
 	return task, nil
 }
 
-// GetTaskByID retrieves a task by its ID
-func (uc *TaskUseCase) GetTaskByID(id string) (*domain.Task, error) {
-	// Convert ID from string to ObjectID
-	taskID, err := primitive.ObjectIDFromHex(id)
+// GetTaskByID retrieves a task of the organization by its ID or its key, e.g.
+// PROJ-123, on behalf of a user. Its links carry the linked tasks' keys,
+// titles and statuses.
+func (uc *TaskUseCase) GetTaskByID(orgID string, id string, userID string) (*domain.Task, error) {
+	// Convert IDs from string to ObjectID
+	org, err := parseOrgID(orgID)
 	if err != nil {
-		return nil, errors.New("invalid task ID format")
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	viewer, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Retrieve the task
-	task, err := uc.taskRepo.FindByID(taskID)
+	task, err := findTask(uc.taskRepo.ForOrg(org), id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := uc.policy.Can(viewer, ActionRead, task); err != nil {
+		return nil, err
+	}
+
+	uc.markSeen(viewer.ID, task.ID, time.Now())
+	uc.resolveLinks(viewer, task)
+	uc.enricher.enrich(task)
+
 	return task, nil
 }
 
+// findTask finds a task by its ID or its key, with a repository limited to an organization
+func findTask(taskRepo domain.TaskRepository, id string) (*domain.Task, error) {
+	if taskID, err := primitive.ObjectIDFromHex(id); err == nil {
+		return taskRepo.FindByID(taskID)
+	}
+
+	projectKey, number, ok := domain.ParseTaskKey(id)
+	if !ok {
+		return nil, errors.New("invalid task ID format")
+	}
+	return taskRepo.FindByKey(domain.TaskKey(projectKey, number))
+}
+
 // UpdateTaskInput represents input data for task update
 type UpdateTaskInput struct {
 	ID          string
@@ -103,28 +340,96 @@ type UpdateTaskInput struct {
 	Status      domain.TaskStatus
 	Priority    int
 	DueDate     time.Time
-	UpdatedBy   string // User ID as string
+	Tags        []string // Replaces the tags; an empty list clears them
+	UpdatedBy   string   // User ID as string
+	OrgID       string   // Organization of the updater
+	// Fields optionally lists the fields to update. Listed fields are set even to
+	// their zero value, which clears the description and due date. Without it only
+	// the fields with non-zero values are updated.
+	Fields []string
+	Client ClientInfo
+}
+
+// Task fields that can be listed in UpdateTaskInput.Fields
+const (
+	TaskFieldTitle       = "title"
+	TaskFieldDescription = "description"
+	TaskFieldStatus      = "status"
+	TaskFieldPriority    = "priority"
+	TaskFieldDueDate     = "due_date"
+	TaskFieldTags        = "tags"
+)
+
+// updatedFields reports which task fields an update sets
+type updatedFields struct {
+	listed map[string]bool
+}
+
+// newUpdatedFields validates the fields listed in an update; nil lists none
+func newUpdatedFields(fields []string) (*updatedFields, error) {
+	if len(fields) == 0 {
+		return &updatedFields{}, nil
+	}
+
+	listed := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		switch field {
+		case TaskFieldTitle, TaskFieldDescription, TaskFieldStatus, TaskFieldPriority, TaskFieldDueDate, TaskFieldTags:
+			listed[field] = true
+		default:
+			return nil, fmt.Errorf("%w: field %q cannot be updated", domain.ErrInvalidInput, field)
+		}
+	}
+	return &updatedFields{listed: listed}, nil
+}
+
+// has reports whether a field is updated: it is listed or, when no fields are
+// listed, its new value is non-zero
+func (f *updatedFields) has(field string, nonZero bool) bool {
+	if f.listed == nil {
+		return nonZero
+	}
+	return f.listed[field]
 }
 
 // UpdateTask updates an existing task
 func (uc *TaskUseCase) UpdateTask(input *UpdateTaskInput) (*domain.Task, error) {
-	// Convert ID from string to ObjectID
+	// Convert IDs from string to ObjectID
+	org, err := parseOrgID(input.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
 	taskID, err := primitive.ObjectIDFromHex(input.ID)
 	if err != nil {
 		return nil, errors.New("invalid task ID format")
 	}
 
 	// Retrieve the existing task
-	task, err := uc.taskRepo.FindByID(taskID)
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := newUpdatedFields(input.Fields)
 	if err != nil {
 		return nil, err
 	}
 
 	// Validate priority if provided
-	if input.Priority != 0 && (input.Priority < 1 || input.Priority > 5) {
+	if fields.has(TaskFieldPriority, input.Priority != 0) && (input.Priority < 1 || input.Priority > 5) {
 		return nil, errors.New("priority must be between 1 and 5")
 	}
 
+	// Without a field list, tags are only replaced when a list is provided
+	var tags []string
+	updateTags := fields.has(TaskFieldTags, input.Tags != nil)
+	if updateTags {
+		if tags, err = normalizeTags(input.Tags); err != nil {
+			return nil, err
+		}
+	}
+
 	// Convert updater ID from string to ObjectID
 	updaterID, err := primitive.ObjectIDFromHex(input.UpdatedBy)
 	if err != nil {
@@ -132,49 +437,124 @@ func (uc *TaskUseCase) UpdateTask(input *UpdateTaskInput) (*domain.Task, error)
 	}
 
 	// Verify that updater exists and is authorized
-	// (either the creator or assigned to the task)
-	if task.CreatedBy != updaterID && task.AssignedTo != updaterID {
-		return nil, domain.ErrUnauthorized
+	updater, err := uc.policy.Actor(org, updaterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.policy.Can(updater, ActionWrite, task); err != nil {
+		return nil, err
+	}
+
+	// Projects with strict labels only allow their labels as new tags
+	if updateTags && !task.ProjectID.IsZero() {
+		project, err := uc.policy.projectRepo.FindByID(task.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkProjectTags(project, task, tags); err != nil {
+			return nil, err
+		}
+	}
+
+	// Clean the content being set
+	var title, description *string
+	if fields.has(TaskFieldTitle, input.Title != "") {
+		title = new(string)
+		*title = input.Title
+	}
+	if fields.has(TaskFieldDescription, input.Description != "") {
+		description = new(string)
+		*description = input.Description
+	}
+	if err := uc.content.clean(title, description); err != nil {
+		return nil, err
 	}
 
 	// Update task fields if provided
-	if input.Title != "" {
-		task.Title = input.Title
+	if title != nil {
+		if *title == "" {
+			return nil, fmt.Errorf("%w: title cannot be cleared", domain.ErrInvalidInput)
+		}
+		task.Title = *title
 	}
 
-	if input.Description != "" {
-		task.Description = input.Description
+	if description != nil {
+		task.Description = *description
 	}
 
-	if input.Status != "" {
-		// Validate status transition
-		if !isValidStatusTransition(task.Status, input.Status) {
-			return nil, errors.New("invalid status transition")
+	statusChanged := false
+	if fields.has(TaskFieldStatus, input.Status != "") {
+		if input.Status == "" {
+			return nil, fmt.Errorf("%w: status cannot be cleared", domain.ErrInvalidInput)
+		}
+
+		if err := task.Status.CheckTransition(input.Status); err != nil {
+			return nil, err
+		}
+		statusChanged = task.Status != input.Status
+		if statusChanged && input.Status == domain.TaskStatusInProgress {
+			if err := uc.checkWIP(task, task.AssignedTo, true); err != nil {
+				return nil, err
+			}
 		}
 		task.Status = input.Status
 	}
 
-	if input.Priority != 0 {
+	if fields.has(TaskFieldPriority, input.Priority != 0) {
+		// A priority set by hand ends any aging of the previous one
+		if input.Priority != task.Priority {
+			task.OriginalPriority = 0
+			task.AgedAt = nil
+		}
 		task.Priority = input.Priority
 	}
 
-	// Only update due date if a non-zero time is provided
-	if !input.DueDate.IsZero() {
+	// Without a field list, only update due date if a non-zero time is provided
+	if fields.has(TaskFieldDueDate, !input.DueDate.IsZero()) {
+		// Rescheduled tasks may be escalated again once the new due date has passed
+		if !task.DueDate.Equal(input.DueDate) {
+			task.EscalatedAt = nil
+		}
 		task.DueDate = input.DueDate
 	}
 
+	if updateTags {
+		task.Tags = tags
+	}
+
+	// Completed tasks are no longer escalated
+	if task.Status == domain.TaskStatusCompleted {
+		task.EscalatedAt = nil
+	}
+
+	eventType := domain.EventTaskUpdated
+	if statusChanged {
+		eventType = domain.EventTaskStatusChanged
+	}
+
 	// Save to repository
-	err = uc.taskRepo.Update(task)
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		return repo.Update(task)
+	}, taskEvent(eventType, updaterID, primitive.NilObjectID, task))
 	if err != nil {
 		return nil, err
 	}
+	uc.auditTask(domain.AuditTaskUpdated, updaterID, task, nil, input.Client)
+
+	uc.enricher.enrich(task)
 
 	return task, nil
 }
 
-// DeleteTask deletes a task by ID
-func (uc *TaskUseCase) DeleteTask(id string, userID string) error {
+// DeleteTask deletes a task of the organization by ID, along with the links
+// other tasks have to it
+func (uc *TaskUseCase) DeleteTask(orgID string, id string, userID string, client ClientInfo) error {
 	// Convert IDs from string to ObjectID
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return err
+	}
+
 	taskID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return errors.New("invalid task ID format")
@@ -186,38 +566,56 @@ func (uc *TaskUseCase) DeleteTask(id string, userID string) error {
 	}
 
 	// Retrieve the task to check authorization
-	task, err := uc.taskRepo.FindByID(taskID)
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskID)
 	if err != nil {
 		return err
 	}
 
-	// Only the creator can delete a task
-	if task.CreatedBy != userObjID {
-		return domain.ErrUnauthorized
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return err
+	}
+	if err := uc.policy.Can(user, ActionDelete, task); err != nil {
+		return err
 	}
 
 	// Delete from repository
-	return uc.taskRepo.Delete(taskID)
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		if err := repo.Delete(taskID); err != nil {
+			return err
+		}
+		return unlinkDeleted(repo, task)
+	}, taskEvent(domain.EventTaskDeleted, userObjID, primitive.NilObjectID, task))
+	if err != nil {
+		return err
+	}
+
+	uc.auditTask(domain.AuditTaskDeleted, userObjID, task, map[string]string{"title": task.Title}, client)
+	return nil
 }
 
 // AssignTaskInput represents input data for task assignment
 type AssignTaskInput struct {
 	TaskID     string
-	AssigneeID string
+	Assignee   string // User ID, username, or email
 	AssignedBy string
+	OrgID      string
+	Client     ClientInfo
 }
 
-// AssignTask assigns a task to a user
+// AssignTask adds a user to the task's assignees. In projects that require
+// acceptance, users assigned by someone else must accept or decline the
+// assignment; it stays pending meanwhile and does not start the task.
 func (uc *TaskUseCase) AssignTask(input *AssignTaskInput) (*domain.Task, error) {
 	// Convert IDs from string to ObjectID
-	taskID, err := primitive.ObjectIDFromHex(input.TaskID)
+	org, err := parseOrgID(input.OrgID)
 	if err != nil {
-		return nil, errors.New("invalid task ID format")
+		return nil, err
 	}
 
-	assigneeID, err := primitive.ObjectIDFromHex(input.AssigneeID)
+	taskID, err := primitive.ObjectIDFromHex(input.TaskID)
 	if err != nil {
-		return nil, errors.New("invalid assignee ID format")
+		return nil, errors.New("invalid task ID format")
 	}
 
 	assignerID, err := primitive.ObjectIDFromHex(input.AssignedBy)
@@ -226,89 +624,498 @@ func (uc *TaskUseCase) AssignTask(input *AssignTaskInput) (*domain.Task, error)
 	}
 
 	// Retrieve the task
-	task, err := uc.taskRepo.FindByID(taskID)
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Only the creator can assign a task
-	if task.CreatedBy != assignerID {
-		return nil, domain.ErrUnauthorized
+	assigner, err := uc.policy.Actor(org, assignerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.policy.Can(assigner, ActionAssign, task); err != nil {
+		return nil, err
 	}
 
-	// Verify that assignee exists
-	_, err = uc.userRepo.FindByID(assigneeID)
+	// Resolve the assignee by ID, username, or email
+	assignee, err := uc.resolveUser(org, input.Assignee)
 	if err != nil {
+		return nil, err
+	}
+	if assignee.IsDeactivated() {
+		return nil, fmt.Errorf("%w: user %s is deactivated", domain.ErrInvalidInput, assignee.Username)
+	}
+
+	// Project tasks can only be assigned to users who can see them
+	if err := uc.policy.Can(assignee, ActionRead, task); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			return nil, errors.New("assignee user not found")
+			return nil, fmt.Errorf("%w: assignee is not a member of the task's project", domain.ErrInvalidInput)
 		}
 		return nil, err
 	}
 
-	// Assign the task
-	task.AssignedTo = assigneeID
+	// Add the assignee (assigning the same user twice is a no-op)
+	added := !task.IsAssignedTo(assignee.ID)
+	pending := task.PendingAssignmentOf(assignee.ID) != nil
+	if added && assignee.ID != assignerID {
+		if pending, err = uc.requiresAcceptance(task); err != nil {
+			return nil, err
+		}
+	}
+	if added && pending {
+		task.PendingAssignments = append(task.PendingAssignments, domain.PendingAssignment{
+			UserID:            assignee.ID,
+			AssignedBy:        assignerID,
+			PreviousAssignees: append([]primitive.ObjectID{}, task.AssignedTo...),
+			AssignedAt:        time.Now(),
+		})
+	}
+	task.AddAssignee(assignee.ID)
 
-	// If task is pending, move it to in progress
-	if task.Status == domain.TaskStatusPending {
+	// If task is pending, move it to in progress, within the project's WIP limits
+	eventType := domain.EventTaskAssigned
+	switch {
+	case pending:
+		// The task starts once the assignee accepts
+		eventType = domain.EventTaskAssignmentRequested
+	case task.Status == domain.TaskStatusPending:
+		if err := uc.checkWIP(task, task.AssignedTo, true); err != nil {
+			return nil, err
+		}
 		task.Status = domain.TaskStatusInProgress
+	case task.Status == domain.TaskStatusInProgress && added:
+		if err := uc.checkWIP(task, []primitive.ObjectID{assignee.ID}, false); err != nil {
+			return nil, err
+		}
 	}
 
 	// Save to repository
-	err = uc.taskRepo.Update(task)
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		return repo.Update(task)
+	}, taskEvent(eventType, assignerID, assignee.ID, task))
 	if err != nil {
 		return nil, err
 	}
+	uc.auditTask(domain.AuditTaskAssigned, assignerID, task, map[string]string{"assignee_id": assignee.ID.Hex()}, input.Client)
+
+	uc.enricher.enrich(task)
 
 	return task, nil
 }
 
-// GetUserTasks retrieves all tasks for a specific user (created by or assigned to)
-func (uc *TaskUseCase) GetUserTasks(userID string) ([]*domain.Task, error) {
-	// Convert ID from string to ObjectID
+// UnassignTaskInput represents input data for removing an assignee from a task
+type UnassignTaskInput struct {
+	TaskID       string
+	Assignee     string // User ID, username, or email
+	UnassignedBy string
+	OrgID        string
+	Client       ClientInfo
+}
+
+// UnassignTask removes a user from the task's assignees
+func (uc *TaskUseCase) UnassignTask(input *UnassignTaskInput) (*domain.Task, error) {
+	// Convert IDs from string to ObjectID
+	org, err := parseOrgID(input.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskID, err := primitive.ObjectIDFromHex(input.TaskID)
+	if err != nil {
+		return nil, errors.New("invalid task ID format")
+	}
+
+	unassignerID, err := primitive.ObjectIDFromHex(input.UnassignedBy)
+	if err != nil {
+		return nil, errors.New("invalid unassigner ID format")
+	}
+
+	// Retrieve the task
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	unassigner, err := uc.policy.Actor(org, unassignerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.policy.Can(unassigner, ActionRead, task); err != nil {
+		return nil, err
+	}
+
+	// Resolve the assignee by ID, username, or email
+	assignee, err := uc.resolveUser(org, input.Assignee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users who may assign the task can remove anyone; assignees can remove themselves
+	if assignee.ID != unassignerID {
+		if err := uc.policy.Can(unassigner, ActionAssign, task); err != nil {
+			return nil, err
+		}
+	}
+
+	// Remove the assignee, along with their pending assignment if any
+	if !task.RemoveAssignee(assignee.ID) {
+		return nil, domain.ErrNotFound
+	}
+	task.RemovePendingAssignment(assignee.ID)
+
+	// Save to repository
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		return repo.Update(task)
+	}, taskEvent(domain.EventTaskUnassigned, unassignerID, assignee.ID, task))
+	if err != nil {
+		return nil, err
+	}
+	uc.auditTask(domain.AuditTaskUnassigned, unassignerID, task, map[string]string{"assignee_id": assignee.ID.Hex()}, input.Client)
+
+	uc.enricher.enrich(task)
+
+	return task, nil
+}
+
+// GetUserTasks retrieves all tasks of the organization for a specific user (created by or assigned to),
+// limited to the tasks the viewer may read. Users listing their own tasks do not see the ones they snoozed.
+func (uc *TaskUseCase) GetUserTasks(orgID string, userID string, viewerID string) ([]*domain.Task, error) {
+	// Convert IDs from string to ObjectID
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, errors.New("invalid user ID format")
 	}
 
+	viewerObjID, err := primitive.ObjectIDFromHex(viewerID)
+	if err != nil {
+		return nil, errors.New("invalid viewer ID format")
+	}
+
+	viewer, err := uc.policy.Actor(org, viewerObjID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Retrieve the tasks
-	tasks, err := uc.taskRepo.FindByUser(userObjID)
+	tasks, err := uc.taskRepo.ForOrg(org).FindByUser(userObjID)
 	if err != nil {
 		return nil, err
 	}
 
+	tasks, err = uc.policy.FilterVisible(viewer, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users' own task lists leave out the tasks they snoozed
+	if viewerObjID == userObjID {
+		if tasks, err = uc.withoutSnoozed(viewerObjID, tasks); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.flagUnread(viewer.ID, tasks)
+	uc.enricher.enrich(tasks...)
+
 	return tasks, nil
 }
 
 // ListTasksInput represents filtering options for task listing
 type ListTasksInput struct {
-	Status domain.TaskStatus
+	OrgID       string
+	UserID      string // Only tasks this user may read are listed
+	Status      domain.TaskStatus
+	ProjectID   string
+	SprintID    string // A sprint's ID, or "none" for tasks not planned into any sprint
+	MilestoneID string
+	Tag         string
+	// DueAfter and DueBefore, when set, limit the list to tasks due in that range
+	DueAfter  time.Time
+	DueBefore time.Time
+	// Limit is the page size, within the page limits; tasks are listed in ID order,
+	// starting after the task with ID After
+	Limit int64
+	After string
 }
 
-// ListTasks lists tasks with optional filtering
-func (uc *TaskUseCase) ListTasks(input *ListTasksInput) ([]*domain.Task, error) {
-	// If status filter is provided, use it
-	if input != nil && input.Status != "" {
-		return uc.taskRepo.FindByStatus(input.Status)
+// SprintNone filters task lists to the tasks not planned into any sprint, i.e. the backlog
+const SprintNone = "none"
+
+// ListTasks lists a page of the organization's tasks with optional filtering. Only
+// list-view fields are loaded, so descriptions are left empty; use GetTaskByID for
+// full details. The page size is the default unless another is requested; sizes
+// above the maximum are lowered to it, which the returned page info reports.
+func (uc *TaskUseCase) ListTasks(input *ListTasksInput) ([]*domain.Task, PageInfo, error) {
+	org, filter, err := uc.listFilter(input)
+	if err != nil {
+		return nil, PageInfo{}, err
 	}
 
-	// Otherwise return all tasks
-	return uc.taskRepo.FindAll(nil)
+	after := primitive.NilObjectID
+	if input.After != "" {
+		if after, err = primitive.ObjectIDFromHex(input.After); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%w: invalid after task ID format", domain.ErrInvalidInput)
+		}
+	}
+
+	page := uc.pages.page(input.Limit)
+	tasks, err := uc.taskRepo.ForOrg(org).FindAll(filter, domain.ListView(), domain.Page(after, page.Size))
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	if viewerID, err := primitive.ObjectIDFromHex(input.UserID); err == nil {
+		uc.flagUnread(viewerID, tasks)
+	}
+	uc.enricher.enrich(tasks...)
+
+	return tasks, page, nil
+}
+
+// CountTasks counts tasks with the same filtering as ListTasks
+func (uc *TaskUseCase) CountTasks(input *ListTasksInput) (int64, error) {
+	org, filter, err := uc.listFilter(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return uc.taskRepo.ForOrg(org).Count(filter)
+}
+
+// Exports send tasks in chunks of the default size unless another is requested
+const (
+	defaultExportChunkSize = 100
+	maxExportChunkSize     = 500
+)
+
+// ExportTasks walks the organization's tasks with the same filtering as ListTasks
+// and passes them to send in chunks of up to chunkSize full tasks, in ID order, so
+// that no more than one chunk is held in memory. It stops at the first error send returns.
+func (uc *TaskUseCase) ExportTasks(input *ListTasksInput, chunkSize int64, send func([]*domain.Task) error) error {
+	if chunkSize <= 0 {
+		chunkSize = defaultExportChunkSize
+	}
+	if chunkSize > maxExportChunkSize {
+		return fmt.Errorf("%w: chunk size must not exceed %d", domain.ErrInvalidInput, maxExportChunkSize)
+	}
+
+	org, filter, err := uc.listFilter(input)
+	if err != nil {
+		return err
+	}
+
+	repo := uc.taskRepo.ForOrg(org)
+	after := primitive.NilObjectID
+	for {
+		tasks, err := repo.FindAll(filter, domain.Page(after, chunkSize))
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		uc.enricher.enrich(tasks...)
+		if err := send(tasks); err != nil {
+			return err
+		}
+
+		if int64(len(tasks)) < chunkSize {
+			return nil
+		}
+		after = tasks[len(tasks)-1].ID
+	}
 }
 
-// Helper function to validate status transitions
-func isValidStatusTransition(current domain.TaskStatus, new domain.TaskStatus) bool {
-	// Define valid transitions
-	switch current {
-	case domain.TaskStatusPending:
-		// Pending can move to in progress or completed
-		return new == domain.TaskStatusInProgress || new == domain.TaskStatusCompleted
-	case domain.TaskStatusInProgress:
-		// In progress can move to completed only
-		return new == domain.TaskStatusCompleted
-	case domain.TaskStatusCompleted:
-		// Completed can move back to in progress (if revisions needed)
-		return new == domain.TaskStatusInProgress
+// listFilter builds the task filter shared by ListTasks and CountTasks, rejecting
+// filters the guardrails deem too expensive
+func (uc *TaskUseCase) listFilter(input *ListTasksInput) (primitive.ObjectID, map[string]interface{}, error) {
+	org, err := parseOrgID(input.OrgID)
+	if err != nil {
+		return primitive.NilObjectID, nil, err
+	}
+
+	if !input.DueAfter.IsZero() && !input.DueBefore.IsZero() && input.DueBefore.Before(input.DueAfter) {
+		return primitive.NilObjectID, nil, fmt.Errorf("%w: due_before must not be before due_after", domain.ErrInvalidInput)
+	}
+	if err := uc.guards.check(input); err != nil {
+		return primitive.NilObjectID, nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(input.UserID)
+	if err != nil {
+		return primitive.NilObjectID, nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return primitive.NilObjectID, nil, err
+	}
+
+	filter, err := uc.policy.VisibleFilter(user)
+	if err != nil {
+		return primitive.NilObjectID, nil, err
+	}
+	if filter == nil {
+		filter = map[string]interface{}{}
+	}
+
+	if input.Status != "" {
+		filter["status"] = input.Status
+	}
+
+	if input.ProjectID != "" {
+		projectID, err := primitive.ObjectIDFromHex(input.ProjectID)
+		if err != nil {
+			return primitive.NilObjectID, nil, fmt.Errorf("%w: invalid project ID format", domain.ErrInvalidInput)
+		}
+		filter["project_id"] = projectID
+	}
+
+	switch input.SprintID {
+	case "":
+	case SprintNone:
+		filter["sprint_id"] = map[string]interface{}{"$exists": false}
 	default:
-		return false
+		sprintID, err := primitive.ObjectIDFromHex(input.SprintID)
+		if err != nil {
+			return primitive.NilObjectID, nil, fmt.Errorf("%w: invalid sprint ID format", domain.ErrInvalidInput)
+		}
+		filter["sprint_id"] = sprintID
+	}
+
+	if input.MilestoneID != "" {
+		milestoneID, err := primitive.ObjectIDFromHex(input.MilestoneID)
+		if err != nil {
+			return primitive.NilObjectID, nil, fmt.Errorf("%w: invalid milestone ID format", domain.ErrInvalidInput)
+		}
+		filter["milestone_id"] = milestoneID
+	}
+
+	if input.Tag != "" {
+		filter["tags"] = strings.ToLower(strings.TrimSpace(input.Tag))
+	}
+
+	if !input.DueAfter.IsZero() || !input.DueBefore.IsZero() {
+		due := map[string]interface{}{}
+		if !input.DueAfter.IsZero() {
+			due["$gte"] = input.DueAfter
+		}
+		if !input.DueBefore.IsZero() {
+			due["$lt"] = input.DueBefore
+		}
+		filter["due_date"] = due
+	}
+
+	return org, filter, nil
+}
+
+// defaultSearchLimit caps the number of results returned by a task search
+const defaultSearchLimit = 20
+
+// SearchTasksInput represents a full-text task search
+type SearchTasksInput struct {
+	OrgID  string
+	UserID string // Only tasks this user may read are returned
+	Query  string
+	Limit  int64
+}
+
+// SearchTasks searches the organization's task titles and descriptions, most relevant first
+func (uc *TaskUseCase) SearchTasks(input *SearchTasksInput) ([]*domain.TaskSearchResult, error) {
+	org, err := parseOrgID(input.OrgID)
+	if err != nil {
+		return nil, err
 	}
+
+	userObjID, err := primitive.ObjectIDFromHex(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := strings.TrimSpace(input.Query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is required", domain.ErrInvalidInput)
+	}
+
+	limit := input.Limit
+	if limit <= 0 || limit > defaultSearchLimit {
+		limit = defaultSearchLimit
+	}
+
+	results, err := uc.searcher.Search(org, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(results))
+	for _, result := range results {
+		tasks = append(tasks, result.Task)
+	}
+
+	// Drop matches in projects the user cannot see
+	visible, err := uc.policy.FilterVisible(user, tasks)
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) < len(tasks) {
+		readable := make(map[primitive.ObjectID]bool, len(visible))
+		for _, task := range visible {
+			readable[task.ID] = true
+		}
+
+		filtered := make([]*domain.TaskSearchResult, 0, len(visible))
+		for _, result := range results {
+			if readable[result.Task.ID] {
+				filtered = append(filtered, result)
+			}
+		}
+		results = filtered
+	}
+
+	uc.enricher.enrich(visible...)
+
+	return results, nil
+}
+
+// resolveUser finds a user of the organization by ObjectID hex, email, or username, in that order
+func (uc *TaskUseCase) resolveUser(org primitive.ObjectID, ref string) (*domain.User, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("%w: assignee is required", domain.ErrInvalidInput)
+	}
+
+	var user *domain.User
+	var err error
+
+	if id, idErr := primitive.ObjectIDFromHex(ref); idErr == nil {
+		user, err = uc.userRepo.FindByID(id)
+	} else if isValidEmail(ref) {
+		user, err = uc.userRepo.FindByEmail(ref)
+	} else {
+		user, err = uc.userRepo.FindByUsername(ref)
+	}
+
+	// Users of other organizations are indistinguishable from missing ones
+	if err == nil && user.OrgID != org {
+		err = domain.ErrNotFound
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no user with ID, username, or email %q", domain.ErrNotFound, ref)
+		}
+		return nil, err
+	}
+
+	return user, nil
 }
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxTaskBatchSize caps the number of tasks created or deleted in one batch
+const maxTaskBatchSize = 500
+
+// TaskBatchResult is the outcome of one item of a batch operation
+type TaskBatchResult struct {
+	// Task is the created or deleted task; nil when the item failed before it was found
+	Task *domain.Task
+	// Err is why the item failed, or nil when it succeeded
+	Err error
+}
+
+// BatchCreateTasks creates several tasks of an organization at once, for importers
+// and automation. Every item is validated and authorized on its own; the valid ones
+// are then stored with a single bulk write, in which tasks the store rejects fail on
+// their own. The results are in the order of the inputs.
+func (uc *TaskUseCase) BatchCreateTasks(orgID string, inputs []*CreateTaskInput) ([]*TaskBatchResult, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputs) > maxTaskBatchSize {
+		return nil, fmt.Errorf("%w: a batch holds at most %d tasks", domain.ErrInvalidInput, maxTaskBatchSize)
+	}
+
+	// Batches usually come from few creators; look each up once
+	users := make(map[primitive.ObjectID]*domain.User)
+	findUser := func(id primitive.ObjectID) (*domain.User, error) {
+		if user, ok := users[id]; ok {
+			return user, nil
+		}
+		user, err := uc.userRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		users[id] = user
+		return user, nil
+	}
+
+	results := make([]*TaskBatchResult, len(inputs))
+	var tasks []*domain.Task
+	var events []*domain.Event
+	// positions maps every task to the index of its input
+	var positions []int
+	for i, input := range inputs {
+		// Every task goes to the caller's organization
+		scoped := *input
+		scoped.OrgID = orgID
+
+		task, err := uc.newTask(&scoped, findUser)
+		results[i] = &TaskBatchResult{Task: task, Err: err}
+		if err != nil {
+			continue
+		}
+
+		tasks = append(tasks, task)
+		events = append(events, taskEvent(domain.EventTaskCreated, task.CreatedBy, primitive.NilObjectID, task))
+		positions = append(positions, i)
+	}
+
+	if len(tasks) == 0 {
+		return results, nil
+	}
+
+	failed, err := uc.createMany(org, tasks, events)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, task := range tasks {
+		if err, ok := failed[i]; ok {
+			results[positions[i]] = &TaskBatchResult{Task: task, Err: err}
+			continue
+		}
+		uc.enricher.enrich(task)
+	}
+
+	return results, nil
+}
+
+// createMany stores tasks, each with its creation event, with a single bulk write.
+// Tasks the store rejects, e.g. for a duplicate key, are returned by index with
+// their reason; the others are stored and their events emitted.
+func (uc *TaskUseCase) createMany(org primitive.ObjectID, tasks []*domain.Task, events []*domain.Event) (map[int]error, error) {
+	failed := make(map[int]error)
+
+	if uc.uow == nil {
+		err := uc.taskRepo.ForOrg(org).CreateMany(tasks)
+		var bulkErr *domain.BulkWriteError
+		if err != nil && !errors.As(err, &bulkErr) {
+			return nil, err
+		}
+		if bulkErr != nil {
+			failed = bulkErr.Failed
+		}
+
+		if uc.events != nil {
+			for i, event := range events {
+				if _, ok := failed[i]; !ok {
+					uc.events.Publish(event)
+				}
+			}
+		}
+		return failed, nil
+	}
+
+	// A failed write aborts the transaction and with it the others, so they are
+	// written again without the failed ones. Every round fails at least one more
+	// task or succeeds, so this ends.
+	for {
+		var pending []*domain.Task
+		var pendingEvents []*domain.Event
+		var indexes []int
+		for i, task := range tasks {
+			if _, ok := failed[i]; !ok {
+				pending = append(pending, task)
+				pendingEvents = append(pendingEvents, events[i])
+				indexes = append(indexes, i)
+			}
+		}
+		if len(pending) == 0 {
+			return failed, nil
+		}
+
+		err := uc.save(org, func(repo domain.TaskRepository) error {
+			return repo.CreateMany(pending)
+		}, pendingEvents...)
+		var bulkErr *domain.BulkWriteError
+		if !errors.As(err, &bulkErr) {
+			return failed, err
+		}
+		for i, reason := range bulkErr.Failed {
+			failed[indexes[i]] = reason
+		}
+	}
+}
+
+// BatchDeleteTasks deletes several tasks of an organization at once on behalf of a
+// user. Every task is authorized on its own; the permitted ones are then deleted
+// with a single bulk write. The results are in the order of the IDs.
+func (uc *TaskUseCase) BatchDeleteTasks(orgID string, ids []string, userID string) ([]*TaskBatchResult, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) > maxTaskBatchSize {
+		return nil, fmt.Errorf("%w: a batch holds at most %d tasks", domain.ErrInvalidInput, maxTaskBatchSize)
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the IDs, then load all their tasks with one query
+	results := make([]*TaskBatchResult, len(ids))
+	taskIDs := make([]primitive.ObjectID, 0, len(ids))
+	for i, id := range ids {
+		taskID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			results[i] = &TaskBatchResult{Err: fmt.Errorf("%w: invalid task ID format", domain.ErrInvalidInput)}
+			continue
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	found, err := uc.taskRepo.ForOrg(org).FindAll(map[string]interface{}{
+		"_id": map[string]interface{}{"$in": taskIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[string]*domain.Task, len(found))
+	for _, task := range found {
+		tasks[task.ID.Hex()] = task
+	}
+
+	// Authorize every task; the same ID listed twice is deleted once
+	var deleted []primitive.ObjectID
+	var events []*domain.Event
+	seen := make(map[string]bool)
+	for i, id := range ids {
+		if results[i] != nil {
+			continue
+		}
+
+		task, ok := tasks[id]
+		if !ok {
+			results[i] = &TaskBatchResult{Err: domain.ErrNotFound}
+			continue
+		}
+
+		if err := uc.policy.Can(user, ActionDelete, task); err != nil {
+			results[i] = &TaskBatchResult{Task: task, Err: err}
+			continue
+		}
+
+		results[i] = &TaskBatchResult{Task: task}
+		if !seen[id] {
+			seen[id] = true
+			deleted = append(deleted, task.ID)
+			events = append(events, taskEvent(domain.EventTaskDeleted, userObjID, primitive.NilObjectID, task))
+		}
+	}
+
+	if len(deleted) == 0 {
+		return results, nil
+	}
+
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		_, err := repo.DeleteMany(deleted)
+		return err
+	}, events...)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxTaskLinks is the number of links a task can have
+const maxTaskLinks = 100
+
+// LinkTasksInput represents a request to link a task to another
+type LinkTasksInput struct {
+	OrgID        string
+	TaskID       string // Task the link is added to; an ID or a key such as PROJ-123
+	LinkedTaskID string // Task it links to; an ID or a key
+	Type         domain.TaskLinkType
+	UserID       string
+}
+
+// LinkTasks links a task the user can change to another task of the organization
+// they can see. The linked task gets the inverse link, so a task marked as
+// duplicating another shows up there as duplicated by it. Two tasks are linked at
+// most once; the link must be removed to change its type.
+func (uc *TaskUseCase) LinkTasks(input *LinkTasksInput) (*domain.Task, error) {
+	if !input.Type.IsValid() {
+		return nil, fmt.Errorf("%w: invalid link type %q", domain.ErrInvalidInput, input.Type)
+	}
+
+	org, err := parseOrgID(input.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(input.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := uc.taskRepo.ForOrg(org)
+	task, err := findTask(tasks, input.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.policy.Can(user, ActionWrite, task); err != nil {
+		return nil, err
+	}
+
+	linked, err := findTask(tasks, input.LinkedTaskID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("%w: linked task not found", domain.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.policy.Can(user, ActionRead, linked); err != nil {
+		return nil, fmt.Errorf("%w: linked task not found", domain.ErrInvalidInput)
+	}
+
+	if linked.ID == task.ID {
+		return nil, fmt.Errorf("%w: a task cannot be linked to itself", domain.ErrInvalidInput)
+	}
+	if existing := task.LinkTo(linked.ID); existing != nil {
+		return nil, fmt.Errorf("%w: the tasks are already linked as %s", domain.ErrDuplicateKey, existing.Type)
+	}
+	if len(task.Links) >= maxTaskLinks || len(linked.Links) >= maxTaskLinks {
+		return nil, fmt.Errorf("%w: a task can have at most %d links", domain.ErrInvalidInput, maxTaskLinks)
+	}
+
+	now := time.Now()
+	task.Links = append(task.Links, domain.TaskLink{
+		Type:      input.Type,
+		TaskID:    linked.ID,
+		CreatedBy: user.ID,
+		CreatedAt: now,
+	})
+	// A mirror left behind by an earlier partial write is replaced
+	linked.RemoveLink(task.ID)
+	linked.Links = append(linked.Links, domain.TaskLink{
+		Type:      input.Type.Inverse(),
+		TaskID:    task.ID,
+		CreatedBy: user.ID,
+		CreatedAt: now,
+	})
+
+	err = uc.save(org, func(repo domain.TaskRepository) error {
+		if err := repo.Update(task); err != nil {
+			return err
+		}
+		return repo.Update(linked)
+	},
+		taskEvent(domain.EventTaskUpdated, user.ID, primitive.NilObjectID, task),
+		taskEvent(domain.EventTaskUpdated, user.ID, primitive.NilObjectID, linked),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.resolveLinks(user, task)
+	uc.enricher.enrich(task)
+
+	return task, nil
+}
+
+// UnlinkTasks removes the link between a task the user can change and another
+// task, on both ends. Links to tasks that were deleted since can be removed too.
+func (uc *TaskUseCase) UnlinkTasks(orgID string, taskID string, linkedTaskID string, userID string) error {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	linkedObjID, err := primitive.ObjectIDFromHex(linkedTaskID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid linked task ID format", domain.ErrInvalidInput)
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return err
+	}
+
+	tasks := uc.taskRepo.ForOrg(org)
+	task, err := findTask(tasks, taskID)
+	if err != nil {
+		return err
+	}
+	if err := uc.policy.Can(user, ActionWrite, task); err != nil {
+		return err
+	}
+
+	if !task.RemoveLink(linkedObjID) {
+		return fmt.Errorf("%w: the tasks are not linked", domain.ErrNotFound)
+	}
+
+	linked, err := tasks.FindByID(linkedObjID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	events := []*domain.Event{taskEvent(domain.EventTaskUpdated, user.ID, primitive.NilObjectID, task)}
+	if linked != nil && linked.RemoveLink(task.ID) {
+		events = append(events, taskEvent(domain.EventTaskUpdated, user.ID, primitive.NilObjectID, linked))
+	} else {
+		linked = nil
+	}
+
+	return uc.save(org, func(repo domain.TaskRepository) error {
+		if err := repo.Update(task); err != nil {
+			return err
+		}
+		if linked == nil {
+			return nil
+		}
+		return repo.Update(linked)
+	}, events...)
+}
+
+// resolveLinks fills in the key, title and status of the tasks a task links to,
+// for those the viewer can see. Links to tasks that no longer exist are left out.
+func (uc *TaskUseCase) resolveLinks(viewer *domain.User, task *domain.Task) {
+	if len(task.Links) == 0 {
+		return
+	}
+
+	ids := make([]primitive.ObjectID, len(task.Links))
+	for i, link := range task.Links {
+		ids[i] = link.TaskID
+	}
+
+	linked, err := uc.taskRepo.ForOrg(task.OrgID).FindAll(map[string]interface{}{
+		"_id": map[string]interface{}{"$in": ids},
+	}, domain.ListView())
+	if err != nil {
+		uc.log.ErrorF("Failed to resolve the links of task %s: %v", task.ID.Hex(), err)
+		return
+	}
+
+	byID := make(map[primitive.ObjectID]*domain.Task, len(linked))
+	for _, other := range linked {
+		byID[other.ID] = other
+	}
+
+	links := make([]domain.TaskLink, 0, len(task.Links))
+	for _, link := range task.Links {
+		other, ok := byID[link.TaskID]
+		if !ok {
+			continue
+		}
+		if uc.policy.Can(viewer, ActionRead, other) == nil {
+			link.Key, link.Title, link.Status = other.Key, other.Title, other.Status
+		}
+		links = append(links, link)
+	}
+	task.Links = links
+}
+
+// unlinkDeleted removes the links other tasks have to a task being deleted
+func unlinkDeleted(repo domain.TaskRepository, task *domain.Task) error {
+	if len(task.Links) == 0 {
+		return nil
+	}
+
+	linked, err := repo.FindAll(map[string]interface{}{
+		"links.task_id": task.ID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, other := range linked {
+		other.RemoveLink(task.ID)
+		if err := repo.Update(other); err != nil {
+			return err
+		}
+	}
+	return nil
+}
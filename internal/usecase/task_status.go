@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"fmt"
+
+	"task-management-system/internal/domain"
+)
+
+// TaskStatusInfo describes a task status and the statuses tasks can move to from it
+type TaskStatusInfo struct {
+	Status      domain.TaskStatus   `json:"status" example:"pending"`
+	Initial     bool                `json:"initial" example:"true"` // New tasks start in this status
+	Transitions []domain.TaskStatus `json:"transitions" example:"in_progress,completed"`
+}
+
+// TaskStatuses describes the task status workflow, so that clients can offer
+// only the moves UpdateTask accepts. It lists every status in workflow order,
+// or only the given one when from is set.
+func (uc *TaskUseCase) TaskStatuses(from domain.TaskStatus) ([]TaskStatusInfo, error) {
+	statuses := domain.TaskStatuses()
+	if from != "" {
+		if !from.IsValid() {
+			return nil, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidInput, from)
+		}
+		statuses = []domain.TaskStatus{from}
+	}
+
+	infos := make([]TaskStatusInfo, 0, len(statuses))
+	for _, status := range statuses {
+		infos = append(infos, TaskStatusInfo{
+			Status:      status,
+			Initial:     status == domain.InitialTaskStatus,
+			Transitions: status.Transitions(),
+		})
+	}
+	return infos, nil
+}
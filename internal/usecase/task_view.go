@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskReadReceipt tells when a user following a task, its creator or one of its
+// assignees, last saw it and whether it changed since
+type TaskReadReceipt struct {
+	User          *domain.UserRef `json:"user"`
+	LastSeenAt    *time.Time      `json:"last_seen_at,omitempty"` // Unset if they never saw the task
+	UnreadChanges bool            `json:"unread_changes"`
+}
+
+// markSeen records that a user saw a task as of the given time. Read state is a
+// convenience, so failing to record it is only logged.
+func (uc *TaskUseCase) markSeen(userID primitive.ObjectID, taskID primitive.ObjectID, at time.Time) {
+	if uc.views == nil || userID.IsZero() {
+		return
+	}
+	if err := uc.views.MarkSeen(userID, taskID, at); err != nil {
+		uc.log.WarnF("Failed to record that user %s saw task %s: %v", userID.Hex(), taskID.Hex(), err)
+	}
+}
+
+// unreadChanges reports whether a task changed since a user last saw it. Tasks
+// count as seen by their creator when created, and as unread by others until
+// they first see them.
+func unreadChanges(task *domain.Task, userID primitive.ObjectID, seenAt *time.Time) bool {
+	if seenAt == nil {
+		return task.CreatedBy != userID || task.UpdatedAt.After(task.CreatedAt)
+	}
+	return task.UpdatedAt.After(*seenAt)
+}
+
+// flagUnread sets the viewer's read state on the listed tasks they created or are
+// assigned to. Failing to load it is only logged.
+func (uc *TaskUseCase) flagUnread(viewerID primitive.ObjectID, tasks []*domain.Task) {
+	if uc.views == nil || len(tasks) == 0 {
+		return
+	}
+
+	var followed []primitive.ObjectID
+	for _, task := range tasks {
+		if task.CreatedBy == viewerID || task.IsAssignedTo(viewerID) {
+			followed = append(followed, task.ID)
+		}
+	}
+	if len(followed) == 0 {
+		return
+	}
+
+	seen, err := uc.views.FindSeen(viewerID, followed)
+	if err != nil {
+		uc.log.WarnF("Failed to load the read state of %d tasks for user %s: %v", len(followed), viewerID.Hex(), err)
+		return
+	}
+
+	for _, task := range tasks {
+		if task.CreatedBy != viewerID && !task.IsAssignedTo(viewerID) {
+			continue
+		}
+		if seenAt, ok := seen[task.ID]; ok {
+			task.LastSeenAt = &seenAt
+		}
+		task.UnreadChanges = unreadChanges(task, viewerID, task.LastSeenAt)
+	}
+}
+
+// MarkTaskSeen records that the user saw a task they can read, clearing its
+// unread changes in their lists
+func (uc *TaskUseCase) MarkTaskSeen(orgID string, taskID string, userID string) error {
+	user, task, err := uc.readableTask(orgID, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if uc.views == nil {
+		return nil
+	}
+	return uc.views.MarkSeen(user.ID, task.ID, time.Now())
+}
+
+// ReadReceipts tells when the creator and each assignee of a task the user can
+// read last saw it, and whether it changed since
+func (uc *TaskUseCase) ReadReceipts(orgID string, taskID string, userID string) ([]TaskReadReceipt, error) {
+	_, task, err := uc.readableTask(orgID, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[primitive.ObjectID]time.Time{}
+	if uc.views != nil {
+		if seen, err = uc.views.FindByTask(task.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.enricher.enrich(task)
+
+	followers := append([]*domain.UserRef{task.Creator}, task.Assignees...)
+	receipts := make([]TaskReadReceipt, 0, len(followers))
+	listed := make(map[primitive.ObjectID]bool, len(followers))
+	for _, follower := range followers {
+		if follower == nil || listed[follower.ID] {
+			continue
+		}
+		listed[follower.ID] = true
+
+		receipt := TaskReadReceipt{User: follower}
+		if seenAt, ok := seen[follower.ID]; ok {
+			receipt.LastSeenAt = &seenAt
+		}
+		receipt.UnreadChanges = unreadChanges(task, follower.ID, receipt.LastSeenAt)
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+// readableTask loads the acting user and a task of the organization they can read
+func (uc *TaskUseCase) readableTask(orgID string, taskID string, userID string) (*domain.User, *domain.Task, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task, err := findTask(uc.taskRepo.ForOrg(org), taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.policy.Can(user, ActionRead, task); err != nil {
+		return nil, nil, err
+	}
+
+	return user, task, nil
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TodayView is a user's "My Day": the open tasks assigned to them that are
+// overdue or due on the day, and the tasks they planned for it. Each task is
+// listed once, in the first of the lists it belongs to.
+type TodayView struct {
+	// Date is the day in the user's time zone
+	Date     string         `json:"date" example:"2025-03-10"`
+	Overdue  []*domain.Task `json:"overdue"`
+	DueToday []*domain.Task `json:"due_today"`
+	// Planned are the tasks the user added to the day, in the order added;
+	// completed ones stay listed so the day's progress shows
+	Planned []*domain.Task `json:"planned"`
+}
+
+// TodayUseCase handles business logic related to users' day plans
+type TodayUseCase struct {
+	planRepo         domain.DayPlanRepository
+	taskRepo         domain.TaskRepository
+	notificationRepo domain.NotificationRepository
+	snoozes          domain.TaskSnoozeRepository
+	policy           *Policy
+	enricher         taskEnricher
+	log              logger.Logger
+}
+
+// NewTodayUseCase creates a new today use case. Tasks a user has snoozed are
+// left out of their overdue and due today lists; snoozes may be nil. Unread
+// notifications are counted for the user's badge counters.
+func NewTodayUseCase(planRepo domain.DayPlanRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, notificationRepo domain.NotificationRepository, snoozes domain.TaskSnoozeRepository, policy *Policy, log logger.Logger) *TodayUseCase {
+	return &TodayUseCase{
+		planRepo:         planRepo,
+		taskRepo:         taskRepo,
+		notificationRepo: notificationRepo,
+		snoozes:          snoozes,
+		policy:           policy,
+		enricher:         newTaskEnricher(userRepo, log),
+		log:              log,
+	}
+}
+
+// GetToday builds a user's view of a day. An empty date is the current day in
+// the user's time zone.
+func (uc *TodayUseCase) GetToday(orgID string, userID string, date string, now time.Time) (*TodayView, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := planDay(user, date, now)
+	if err != nil {
+		return nil, err
+	}
+	startOfDay := day
+	endOfDay := day.AddDate(0, 0, 1)
+
+	// Tasks without a due date are stored with the zero time
+	due, err := uc.taskRepo.ForOrg(org).FindAll(map[string]interface{}{
+		"assigned_to": user.ID,
+		"status":      map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+		"due_date":    map[string]interface{}{"$gt": time.Time{}, "$lt": endOfDay},
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+	if due, err = uc.policy.FilterVisible(user, due); err != nil {
+		return nil, err
+	}
+
+	snoozed, err := uc.snoozedTasks(user.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &TodayView{
+		Date:     day.Format(domain.DayPlanDateLayout),
+		Overdue:  []*domain.Task{},
+		DueToday: []*domain.Task{},
+		Planned:  []*domain.Task{},
+	}
+	listed := make(map[primitive.ObjectID]bool, len(due))
+	for _, task := range due {
+		if snoozed[task.ID] {
+			continue
+		}
+		listed[task.ID] = true
+		if task.DueDate.Before(startOfDay) {
+			view.Overdue = append(view.Overdue, task)
+		} else {
+			view.DueToday = append(view.DueToday, task)
+		}
+	}
+
+	planned, err := uc.plannedTasks(org, user, view.Date)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range planned {
+		if !listed[task.ID] {
+			view.Planned = append(view.Planned, task)
+		}
+	}
+
+	uc.enricher.enrich(view.Overdue...)
+	uc.enricher.enrich(view.DueToday...)
+	uc.enricher.enrich(view.Planned...)
+
+	return view, nil
+}
+
+// AddToToday adds a task the user can see to their plan for a day. An empty
+// date is the current day in the user's time zone. It returns the planned day.
+func (uc *TodayUseCase) AddToToday(orgID string, userID string, taskID string, date string, now time.Time) (string, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return "", err
+	}
+
+	taskObjID, userObjID, err := parseStarIDs(taskID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return "", err
+	}
+
+	day, err := planDay(user, date, now)
+	if err != nil {
+		return "", err
+	}
+
+	// Verify that the task exists within the organization and the user can see it
+	task, err := uc.taskRepo.ForOrg(org).FindByID(taskObjID)
+	if err != nil {
+		return "", err
+	}
+	if err := uc.policy.Can(user, ActionRead, task); err != nil {
+		return "", err
+	}
+
+	item := &domain.DayPlanItem{
+		OrgID:   org,
+		UserID:  user.ID,
+		TaskID:  task.ID,
+		Date:    day.Format(domain.DayPlanDateLayout),
+		AddedAt: now,
+	}
+	if err := uc.planRepo.Add(item); err != nil {
+		return "", err
+	}
+
+	return item.Date, nil
+}
+
+// RemoveFromToday removes a task from the user's plan for a day. An empty date
+// is the current day in the user's time zone.
+func (uc *TodayUseCase) RemoveFromToday(orgID string, userID string, taskID string, date string, now time.Time) error {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return err
+	}
+
+	taskObjID, userObjID, err := parseStarIDs(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.policy.Actor(org, userObjID)
+	if err != nil {
+		return err
+	}
+
+	day, err := planDay(user, date, now)
+	if err != nil {
+		return err
+	}
+
+	return uc.planRepo.Remove(user.ID, day.Format(domain.DayPlanDateLayout), taskObjID)
+}
+
+// plannedTasks returns the tasks of the user's plan for a day that still exist
+// and that they can see, in the order they were added
+func (uc *TodayUseCase) plannedTasks(org primitive.ObjectID, user *domain.User, date string) ([]*domain.Task, error) {
+	items, err := uc.planRepo.FindByDay(user.ID, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return []*domain.Task{}, nil
+	}
+
+	taskIDs := make([]primitive.ObjectID, 0, len(items))
+	for _, item := range items {
+		taskIDs = append(taskIDs, item.TaskID)
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(org).FindAll(map[string]interface{}{
+		"_id": map[string]interface{}{"$in": taskIDs},
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+	if tasks, err = uc.policy.FilterVisible(user, tasks); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[primitive.ObjectID]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	planned := make([]*domain.Task, 0, len(tasks))
+	for _, id := range taskIDs {
+		if task, ok := byID[id]; ok {
+			planned = append(planned, task)
+		}
+	}
+	return planned, nil
+}
+
+// snoozedTasks returns the IDs of the tasks the user has snoozed at the given time
+func (uc *TodayUseCase) snoozedTasks(userID primitive.ObjectID, now time.Time) (map[primitive.ObjectID]bool, error) {
+	snoozed := map[primitive.ObjectID]bool{}
+	if uc.snoozes == nil {
+		return snoozed, nil
+	}
+
+	snoozes, err := uc.snoozes.FindActive(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, snooze := range snoozes {
+		snoozed[snooze.TaskID] = true
+	}
+	return snoozed, nil
+}
+
+// planDay returns the start of a day in the user's time zone, given as a date
+// like 2025-03-10, or the current day when empty
+func planDay(user *domain.User, date string, now time.Time) (time.Time, error) {
+	loc := user.Location()
+	if date == "" {
+		local := now.In(loc)
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc), nil
+	}
+
+	day, err := time.ParseInLocation(domain.DayPlanDateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: date must be formatted as YYYY-MM-DD", domain.ErrInvalidInput)
+	}
+	return day, nil
+}
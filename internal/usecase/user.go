@@ -2,24 +2,67 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"task-management-system/internal/domain"
+	"task-management-system/internal/logger"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"golang.org/x/crypto/bcrypt"
 )
 
+// errDeactivated is returned when a deactivated account tries to sign in
+var errDeactivated = fmt.Errorf("%w: account is deactivated", domain.ErrUnauthorized)
+
 // UserUseCase handles business logic related to users
 type UserUseCase struct {
-	userRepo domain.UserRepository
+	userRepo  domain.UserRepository
+	orgRepo   domain.OrganizationRepository
+	taskRepo  domain.TaskRepository
+	events    domain.EventPublisher
+	uow       domain.UnitOfWork
+	policy    *Policy
+	passwords PasswordPolicy
+	hasher    domain.PasswordHasher
+	pages     PageLimits
+	audit     auditLog
+	log       logger.Logger
 }
 
-// NewUserUseCase creates a new user use case
-func NewUserUseCase(userRepo domain.UserRepository) *UserUseCase {
+// NewUserUseCase creates a new user use case. Every password set through it must satisfy the policy.
+// With a unit of work, a deleted user's tasks are handed over in the same transaction that
+// deletes the user; without one, the writes are made one after another. Events may be nil.
+// User lists are paged within the page limits. Account updates and deletions are recorded
+// in the audit log.
+func NewUserUseCase(
+	userRepo domain.UserRepository,
+	orgRepo domain.OrganizationRepository,
+	taskRepo domain.TaskRepository,
+	auditRepo domain.AuditRepository,
+	events domain.EventPublisher,
+	uow domain.UnitOfWork,
+	policy *Policy,
+	passwords PasswordPolicy,
+	hasher domain.PasswordHasher,
+	pages PageLimits,
+	log logger.Logger,
+) *UserUseCase {
+	passwords.log = log
 	return &UserUseCase{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		orgRepo:   orgRepo,
+		taskRepo:  taskRepo,
+		events:    events,
+		uow:       uow,
+		policy:    policy,
+		passwords: passwords,
+		hasher:    hasher,
+		pages:     pages,
+		audit:     auditLog{repo: auditRepo, log: log},
+		log:       log,
 	}
 }
 
@@ -30,51 +73,160 @@ type RegisterUserInput struct {
 	Password  string
 	FirstName string
 	LastName  string
+	// OrganizationName names the organization created for the new user.
+	// Defaults to "<username>'s organization".
+	OrganizationName string
 }
 
-// RegisterUser registers a new user
+// RegisterUser registers a new user together with a new organization they administer.
+// The user is removed again if the organization cannot be created.
 func (uc *UserUseCase) RegisterUser(input *RegisterUserInput) (*domain.User, error) {
+	user, err := uc.newUser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make the user admin of a fresh organization
+	user.OrgID = primitive.NewObjectID()
+	user.OrgRole = domain.OrgRoleAdmin
+
+	// Save to repository
+	err = uc.userRepo.Create(user)
+	if err != nil {
+		return nil, err
+	}
+
+	orgName := strings.TrimSpace(input.OrganizationName)
+	if orgName == "" {
+		orgName = user.Username + "'s organization"
+	}
+	err = uc.orgRepo.Create(&domain.Organization{
+		ID:        user.OrgID,
+		Name:      orgName,
+		CreatedBy: user.ID,
+	})
+	if err != nil {
+		// Without its organization the user could neither sign in nor register again
+		if deleteErr := uc.userRepo.Delete(user.ID); deleteErr != nil {
+			uc.log.ErrorF("Failed to remove user %s left without an organization: %v", user.ID.Hex(), deleteErr)
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// newUser validates registration input and builds the user to create, without an organization
+func (uc *UserUseCase) newUser(input *RegisterUserInput) (*domain.User, error) {
 	// Validate input
 	if err := validateUserInput(input); err != nil {
 		return nil, err
 	}
 
+	if err := uc.passwords.Validate(input.Password); err != nil {
+		return nil, err
+	}
+
 	// Check if user with the same email already exists
 	existingUser, err := uc.userRepo.FindByEmail(input.Email)
 	if err == nil && existingUser != nil {
-		return nil, errors.New("email already registered")
+		return nil, fmt.Errorf("%w: email already registered", domain.ErrDuplicateKey)
 	}
 
 	// Check if user with the same username already exists
 	existingUser, err = uc.userRepo.FindByUsername(input.Username)
 	if err == nil && existingUser != nil {
-		return nil, errors.New("username already taken")
+		return nil, fmt.Errorf("%w: username already taken", domain.ErrDuplicateKey)
 	}
 
 	// Hash the password
-	hashedPassword, err := hashPassword(input.Password)
+	hashedPassword, err := uc.hasher.Hash(input.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create the user
-	user := &domain.User{
-		Username:  input.Username,
-		Email:     input.Email,
-		Password:  hashedPassword,
-		FirstName: input.FirstName,
-		LastName:  input.LastName,
+	return &domain.User{
+		ID:                primitive.NewObjectID(),
+		Username:          input.Username,
+		Email:             input.Email,
+		Password:          hashedPassword,
+		FirstName:         input.FirstName,
+		LastName:          input.LastName,
+		PasswordChangedAt: time.Now(),
+	}, nil
+}
+
+// GetUserInOrg retrieves a user by ID, treating users of other organizations as not found
+func (uc *UserUseCase) GetUserInOrg(orgID string, id string) (*domain.User, error) {
+	org, err := parseOrgID(orgID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Save to repository
-	err = uc.userRepo.Create(user)
+	user, err := uc.GetUserByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if user.OrgID != org {
+		return nil, domain.ErrNotFound
+	}
 
 	return user, nil
 }
 
+// ListUsersInput represents input data for listing an organization's users
+type ListUsersInput struct {
+	OrgID       string
+	RequestedBy string
+	// Query optionally matches users whose username, email or name contains it
+	Query    string
+	Page     int64
+	PageSize int64
+}
+
+// ListUsersResult is a page of users
+type ListUsersResult struct {
+	Users []*domain.User
+	Total int64 // Number of matching users on all pages
+	Page  PageInfo
+}
+
+// ListUsers lists a page of an organization's users, ordered by username, with the
+// total number of matching users. Only organization admins may list users. The page
+// size is the default unless another is requested; sizes above the maximum are
+// lowered to it, which the result reports.
+func (uc *UserUseCase) ListUsers(input *ListUsersInput) (*ListUsersResult, error) {
+	admin, err := requireOrgAdmin(uc.userRepo, input.OrgID, input.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	number := input.Page
+	if number <= 0 {
+		number = 1
+	}
+	page := uc.pages.page(input.PageSize)
+
+	filter := domain.UserFilter{
+		OrgID: admin.OrgID,
+		Query: strings.TrimSpace(input.Query),
+		Skip:  (number - 1) * page.Size,
+		Limit: page.Size,
+	}
+
+	users, err := uc.userRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.userRepo.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUsersResult{Users: users, Total: total, Page: page}, nil
+}
+
 // GetUserByID retrieves a user by ID
 func (uc *UserUseCase) GetUserByID(id string) (*domain.User, error) {
 	// Convert ID from string to ObjectID
@@ -130,25 +282,28 @@ type UpdateUserInput struct {
 	Email     string
 	FirstName string
 	LastName  string
+	Timezone  string
 	Password  string
+	UpdatedBy string
+	Client    ClientInfo
 }
 
-// UpdateUser updates user information
+// UpdateUser updates user information. Users may only update their own profile,
+// and may not change their email or password while being impersonated.
 func (uc *UserUseCase) UpdateUser(input *UpdateUserInput) (*domain.User, error) {
-	// Convert ID from string to ObjectID
-	userID, err := primitive.ObjectIDFromHex(input.ID)
+	user, err := uc.manageableUser(input.ID, input.UpdatedBy, ActionWrite)
 	if err != nil {
-		return nil, errors.New("invalid user ID format")
+		return nil, err
 	}
+	userID := user.ID
 
-	// Retrieve the existing user
-	user, err := uc.userRepo.FindByID(userID)
-	if err != nil {
-		return nil, err
+	changesEmail := input.Email != "" && input.Email != user.Email
+	if (changesEmail || input.Password != "") && input.Client.impersonating() {
+		return nil, errImpersonating
 	}
 
 	// Validate and update email if provided
-	if input.Email != "" && input.Email != user.Email {
+	if changesEmail {
 		if !isValidEmail(input.Email) {
 			return nil, errors.New("invalid email format")
 		}
@@ -172,19 +327,28 @@ func (uc *UserUseCase) UpdateUser(input *UpdateUserInput) (*domain.User, error)
 		user.LastName = input.LastName
 	}
 
+	// Validate and update time zone if provided
+	if input.Timezone != "" {
+		if _, err := time.LoadLocation(input.Timezone); err != nil {
+			return nil, fmt.Errorf("%w: unknown time zone %q", domain.ErrInvalidInput, input.Timezone)
+		}
+		user.Timezone = input.Timezone
+	}
+
 	// Update password if provided
 	if input.Password != "" {
-		if len(input.Password) < 6 {
-			return nil, errors.New("password must be at least 6 characters long")
+		if err := uc.passwords.Validate(input.Password); err != nil {
+			return nil, err
 		}
 
 		// Hash the new password
-		hashedPassword, err := hashPassword(input.Password)
+		hashedPassword, err := uc.hasher.Hash(input.Password)
 		if err != nil {
 			return nil, err
 		}
 
 		user.Password = hashedPassword
+		user.PasswordChangedAt = time.Now()
 	}
 
 	// Update timestamp
@@ -196,19 +360,300 @@ func (uc *UserUseCase) UpdateUser(input *UpdateUserInput) (*domain.User, error)
 		return nil, err
 	}
 
+	updatedBy, _ := primitive.ObjectIDFromHex(input.UpdatedBy)
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      user.OrgID,
+		Action:     domain.AuditUserUpdated,
+		ActorID:    updatedBy,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   user.ID,
+		Details: map[string]string{
+			"email_changed":    strconv.FormatBool(changesEmail),
+			"password_changed": strconv.FormatBool(input.Password != ""),
+		},
+	}, input.Client)
+
 	return user, nil
 }
 
-// DeleteUser deletes a user by ID
-func (uc *UserUseCase) DeleteUser(id string) error {
-	// Convert ID from string to ObjectID
+// DeleteUserInput represents input data for deleting a user's account
+type DeleteUserInput struct {
+	ID        string
+	DeletedBy string
+	// Policy decides what happens to the user's open tasks; blocking when empty
+	Policy domain.UserDeletionPolicy
+	// ReassignTo is the user ID, username, or email of the user taking over the
+	// open tasks under the reassign policy
+	ReassignTo string
+	Client     ClientInfo
+}
+
+// DeleteUser deletes a user's account. Users may only delete their own account,
+// and an organization admin must leave another admin behind if the organization
+// has other members. No account can be deleted while being impersonated.
+// The open tasks assigned to the user are handled by the input's policy, in the same transaction
+// as the deletion when transactions are enabled. Completed tasks keep pointing at the deleted user;
+// DeactivateUser keeps every reference valid instead.
+func (uc *UserUseCase) DeleteUser(input *DeleteUserInput) error {
+	policy := input.Policy
+	if policy == "" {
+		policy = domain.UserDeletionBlock
+	}
+	if !policy.IsValid() {
+		return fmt.Errorf("%w: unknown deletion policy %q", domain.ErrInvalidInput, policy)
+	}
+	if policy != domain.UserDeletionReassign && input.ReassignTo != "" {
+		return fmt.Errorf("%w: reassign_to requires the reassign policy", domain.ErrInvalidInput)
+	}
+	if input.Client.impersonating() {
+		return errImpersonating
+	}
+
+	user, err := uc.manageableUser(input.ID, input.DeletedBy, ActionDelete)
+	if err != nil {
+		return err
+	}
+
+	if user.IsOrgAdmin() {
+		members, err := uc.userRepo.FindByOrg(user.OrgID)
+		if err != nil {
+			return err
+		}
+		if len(members) > 1 {
+			if err := ensureOtherOrgAdmin(uc.userRepo, user); err != nil {
+				return err
+			}
+		}
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(user.OrgID).FindAll(map[string]interface{}{
+		"assigned_to": user.ID,
+		"status":      map[string]interface{}{"$ne": domain.TaskStatusCompleted},
+	})
+	if err != nil {
+		return err
+	}
+
+	var successor *domain.User
+	switch policy {
+	case domain.UserDeletionBlock:
+		if len(tasks) > 0 {
+			return fmt.Errorf("%w: user has %d open tasks; reassign or unassign them, or deactivate the account instead", domain.ErrInvalidInput, len(tasks))
+		}
+	case domain.UserDeletionReassign:
+		if successor, err = uc.successor(user, input.ReassignTo, tasks); err != nil {
+			return err
+		}
+	}
+
+	// The user is both actor and subject of the unassignments, so they notify no one
+	var events []*domain.Event
+	for _, task := range tasks {
+		task.RemoveAssignee(user.ID)
+		task.RemovePendingAssignment(user.ID)
+		events = append(events, taskEvent(domain.EventTaskUnassigned, user.ID, user.ID, task))
+		if successor != nil && !task.IsAssignedTo(successor.ID) {
+			task.AddAssignee(successor.ID)
+			events = append(events, taskEvent(domain.EventTaskAssigned, user.ID, successor.ID, task))
+		}
+	}
+
+	write := func(taskRepo domain.TaskRepository, userRepo domain.UserRepository) error {
+		for _, task := range tasks {
+			if err := taskRepo.Update(task); err != nil {
+				return err
+			}
+		}
+		return userRepo.Delete(user.ID)
+	}
+
+	if uc.uow != nil {
+		err = uc.uow.DoAll(func(repos *domain.TxRepositories) error {
+			if err := write(repos.Tasks.ForOrg(user.OrgID), repos.Users); err != nil {
+				return err
+			}
+			for _, event := range events {
+				if err := repos.Outbox.Add(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		if err := write(uc.taskRepo.ForOrg(user.OrgID), uc.userRepo); err != nil {
+			return err
+		}
+		if uc.events != nil {
+			for _, event := range events {
+				uc.events.Publish(event)
+			}
+		}
+	}
+
+	deletedBy, _ := primitive.ObjectIDFromHex(input.DeletedBy)
+	uc.audit.record(&domain.AuditEntry{
+		OrgID:      user.OrgID,
+		Action:     domain.AuditUserDeleted,
+		ActorID:    deletedBy,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   user.ID,
+		Details: map[string]string{
+			"username": user.Username,
+			"policy":   string(policy),
+		},
+	}, input.Client)
+	return nil
+}
+
+// successor resolves the user taking over a deleted user's open tasks: an active
+// member of the same organization who can see every one of the tasks
+func (uc *UserUseCase) successor(user *domain.User, ref string, tasks []*domain.Task) (*domain.User, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("%w: reassign_to is required by the reassign policy", domain.ErrInvalidInput)
+	}
+
+	var successor *domain.User
+	var err error
+	if id, idErr := primitive.ObjectIDFromHex(ref); idErr == nil {
+		successor, err = uc.userRepo.FindByID(id)
+	} else if isValidEmail(ref) {
+		successor, err = uc.userRepo.FindByEmail(ref)
+	} else {
+		successor, err = uc.userRepo.FindByUsername(ref)
+	}
+
+	// Users of other organizations are indistinguishable from missing ones
+	if err == nil && successor.OrgID != user.OrgID {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no user with ID, username, or email %q", domain.ErrInvalidInput, ref)
+		}
+		return nil, err
+	}
+
+	if successor.ID == user.ID {
+		return nil, fmt.Errorf("%w: tasks cannot be reassigned to the deleted user", domain.ErrInvalidInput)
+	}
+	if successor.IsDeactivated() {
+		return nil, fmt.Errorf("%w: tasks cannot be reassigned to a deactivated user", domain.ErrInvalidInput)
+	}
+
+	for _, task := range tasks {
+		if err := uc.policy.Can(successor, ActionRead, task); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				ref := task.Key
+				if ref == "" {
+					ref = task.ID.Hex()
+				}
+				return nil, fmt.Errorf("%w: %s is not a member of the project of task %s", domain.ErrInvalidInput, successor.Username, ref)
+			}
+			return nil, err
+		}
+	}
+
+	return successor, nil
+}
+
+// DeactivateUser deactivates an account as an alternative to deleting it: the user
+// is signed out everywhere and can no longer sign in or be assigned tasks, while
+// every task and record keeps pointing at a valid user. Users may deactivate their
+// own account, and organization admins the accounts of their organization; the
+// last admin of an organization cannot be deactivated.
+func (uc *UserUseCase) DeactivateUser(id string, deactivatedBy string) (*domain.User, error) {
+	action := ActionAdminister
+	if id == deactivatedBy {
+		action = ActionWrite
+	}
+
+	user, err := uc.manageableUser(id, deactivatedBy, action)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeactivated() {
+		return user, nil
+	}
+
+	if user.IsOrgAdmin() {
+		if err := ensureOtherOrgAdmin(uc.userRepo, user); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	user.DeactivatedAt = &now
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Revoke every token issued so far
+	if err := uc.userRepo.IncrementTokenGeneration(user.ID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ReactivateUser lets a deactivated account sign in again. Only organization admins may do so.
+func (uc *UserUseCase) ReactivateUser(id string, reactivatedBy string) (*domain.User, error) {
+	user, err := uc.manageableUser(id, reactivatedBy, ActionAdminister)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.ID.Hex() == reactivatedBy {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if !user.IsDeactivated() {
+		return user, nil
+	}
+
+	user.DeactivatedAt = nil
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// manageableUser retrieves a user of the acting user's organization that the
+// acting user may act on as given. Users of other organizations are not found.
+func (uc *UserUseCase) manageableUser(id string, actorID string, action Action) (*domain.User, error) {
 	userID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("invalid user ID format")
+		return nil, errors.New("invalid user ID format")
+	}
+
+	actorObjID, err := primitive.ObjectIDFromHex(actorID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
 	}
 
-	// Delete from repository
-	return uc.userRepo.Delete(userID)
+	actor, err := uc.userRepo.FindByID(actorObjID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	user := actor
+	if userID != actor.ID {
+		if user, err = uc.userRepo.FindByIDInOrg(actor.OrgID, userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.policy.Can(actor, action, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
 
 // ValidateCredentials validates user login credentials
@@ -231,10 +676,14 @@ func (uc *UserUseCase) ValidateCredentials(login string, password string) (*doma
 	}
 
 	// Verify password
-	if !verifyPassword(user.Password, password) {
+	if ok, _ := uc.hasher.Verify(user.Password, password); !ok {
 		return nil, errors.New("invalid login credentials")
 	}
 
+	if user.IsDeactivated() {
+		return nil, errDeactivated
+	}
+
 	return user, nil
 }
 
@@ -244,17 +693,12 @@ func (uc *UserUseCase) ValidateCredentials(login string, password string) (*doma
 func validateUserInput(input *RegisterUserInput) error {
 	// Validate username
 	if len(input.Username) < 3 {
-		return errors.New("username must be at least 3 characters long")
+		return fmt.Errorf("%w: username must be at least 3 characters long", domain.ErrInvalidInput)
 	}
 
 	// Validate email
 	if !isValidEmail(input.Email) {
-		return errors.New("invalid email format")
-	}
-
-	// Validate password
-	if len(input.Password) < 6 {
-		return errors.New("password must be at least 6 characters long")
+		return fmt.Errorf("%w: invalid email format", domain.ErrInvalidInput)
 	}
 
 	return nil
@@ -266,18 +710,3 @@ func isValidEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
 }
-
-// hashPassword hashes a password using bcrypt
-func hashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
-	}
-	return string(hashedBytes), nil
-}
-
-// verifyPassword verifies a password against its hash
-func verifyPassword(hashedPassword, password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
-	return err == nil
-}
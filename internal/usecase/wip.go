@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"fmt"
+
+	"task-management-system/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// validateWIPLimits checks a project's WIP limits and defaults their mode to reject
+func validateWIPLimits(limits *domain.WIPLimits) error {
+	if limits.Project < 0 || limits.PerUser < 0 {
+		return fmt.Errorf("%w: WIP limits cannot be negative", domain.ErrInvalidInput)
+	}
+	if limits.Mode == "" {
+		limits.Mode = domain.WIPLimitReject
+	}
+	if !limits.Mode.IsValid() {
+		return fmt.Errorf("%w: unknown WIP limit mode %q", domain.ErrInvalidInput, limits.Mode)
+	}
+	return nil
+}
+
+// checkWIP enforces the WIP limits of a task's project before the task is put in
+// progress (entering) or, while it is in progress, assigned to more users. The
+// project limit applies only when the task enters; the per-user limit applies to
+// each of the given users. In warn mode, exceeded limits are added to the task's
+// warnings instead of failing. Tasks outside any project have no limits.
+//
+// The counts are read before the write, so concurrent changes can overshoot a limit.
+func (uc *TaskUseCase) checkWIP(task *domain.Task, users []primitive.ObjectID, entering bool) error {
+	if task.ProjectID.IsZero() {
+		return nil
+	}
+
+	project, err := uc.policy.projectRepo.FindByID(task.ProjectID)
+	if err != nil {
+		return err
+	}
+	limits := project.WIPLimits
+	if !limits.Enabled() {
+		return nil
+	}
+
+	repo := uc.taskRepo.ForOrg(task.OrgID)
+	filter := func(user primitive.ObjectID) map[string]interface{} {
+		f := map[string]interface{}{
+			"project_id": project.ID,
+			"status":     domain.TaskStatusInProgress,
+			"_id":        map[string]interface{}{"$ne": task.ID},
+		}
+		if !user.IsZero() {
+			f["assigned_to"] = user
+		}
+		return f
+	}
+
+	var exceeded []string
+	if entering && limits.Project > 0 {
+		count, err := repo.Count(filter(primitive.NilObjectID))
+		if err != nil {
+			return err
+		}
+		if count >= int64(limits.Project) {
+			exceeded = append(exceeded, fmt.Sprintf("project %s already has %d of %d tasks in progress", project.Key, count, limits.Project))
+		}
+	}
+	if limits.PerUser > 0 {
+		for _, user := range users {
+			count, err := repo.Count(filter(user))
+			if err != nil {
+				return err
+			}
+			if count >= int64(limits.PerUser) {
+				exceeded = append(exceeded, fmt.Sprintf("user %s already has %d of %d tasks in progress in project %s", user.Hex(), count, limits.PerUser, project.Key))
+			}
+		}
+	}
+
+	if len(exceeded) == 0 {
+		return nil
+	}
+	if limits.Mode == domain.WIPLimitWarn {
+		for _, message := range exceeded {
+			task.Warnings = append(task.Warnings, "WIP limit exceeded: "+message)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: WIP limit reached: %s", domain.ErrInvalidInput, exceeded[0])
+}
+
+// ProjectWIP reports a project's WIP limits and its tasks in progress
+type ProjectWIP struct {
+	Limits     domain.WIPLimits `json:"limits"`
+	InProgress int64            `json:"in_progress"`
+	// PerUser counts the tasks in progress assigned to each user, by user ID
+	PerUser map[string]int64 `json:"per_user"`
+}
+
+// GetProjectWIP returns the WIP limits of a project the user is a member of and
+// how many of its tasks are in progress, overall and per assignee
+func (uc *ProjectUseCase) GetProjectWIP(orgID string, id string, userID string) (*ProjectWIP, error) {
+	_, project, err := uc.authorize(orgID, id, userID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := uc.taskRepo.ForOrg(project.OrgID).FindAll(map[string]interface{}{
+		"project_id": project.ID,
+		"status":     domain.TaskStatusInProgress,
+	}, domain.ListView())
+	if err != nil {
+		return nil, err
+	}
+
+	wip := &ProjectWIP{
+		Limits:     project.WIPLimits,
+		InProgress: int64(len(tasks)),
+		PerUser:    make(map[string]int64),
+	}
+	for _, task := range tasks {
+		for _, assignee := range task.AssignedTo {
+			wip.PerUser[assignee.Hex()]++
+		}
+	}
+	return wip, nil
+}
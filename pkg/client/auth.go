@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RegisterInput represents a new user's registration
+type RegisterInput struct {
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	// Organization names the organization created for the new user
+	Organization string `json:"organization,omitempty"`
+}
+
+// LoginResult represents a successful login or token refresh
+type LoginResult struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	UserID      string
+	Username    string
+	OrgID       string
+	// Scopes lists the API operations the token grants
+	Scopes []string
+	// PasswordExpired asks the user to change their password
+	PasswordExpired bool
+}
+
+// loginResponse is the wire format of a login result
+type loginResponse struct {
+	AccessToken     string   `json:"access_token"`
+	ExpiresAt       string   `json:"expires_at"`
+	UserID          string   `json:"user_id"`
+	Username        string   `json:"username"`
+	OrgID           string   `json:"org_id"`
+	Scopes          []string `json:"scopes"`
+	PasswordExpired bool     `json:"password_expired"`
+}
+
+// Register registers a new user together with a new organization they administer.
+// It does not sign in.
+func (c *Client) Register(ctx context.Context, input *RegisterInput) (*User, error) {
+	var user userResponse
+	_, err := c.do(ctx, &request{method: http.MethodPost, path: "/auth/register", body: input, public: true}, &user)
+	if err != nil {
+		return nil, err
+	}
+	return user.toUser(), nil
+}
+
+// Login signs in with a username or email and a password, and authenticates the
+// client's following calls with the token. Scopes optionally limit the token to
+// some of the user's scopes, such as "tasks:read".
+func (c *Client) Login(ctx context.Context, login, password string, scopes ...string) (*LoginResult, error) {
+	body := struct {
+		Login    string   `json:"login"`
+		Password string   `json:"password"`
+		Scopes   []string `json:"scopes,omitempty"`
+	}{login, password, scopes}
+
+	return c.authenticate(ctx, "/auth/login", body)
+}
+
+// RefreshToken exchanges the client's token for a new one before it expires.
+// Clients refresh their token automatically; call it only to control when.
+func (c *Client) RefreshToken(ctx context.Context) (*LoginResult, error) {
+	body := struct {
+		Token string `json:"token"`
+	}{c.Token()}
+
+	return c.authenticate(ctx, "/auth/refresh-token", body)
+}
+
+// authenticate sends a login or refresh request and stores the returned token
+func (c *Client) authenticate(ctx context.Context, path string, body interface{}) (*LoginResult, error) {
+	var resp loginResponse
+	_, err := c.do(ctx, &request{method: http.MethodPost, path: path, body: body, public: true}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LoginResult{
+		AccessToken:     resp.AccessToken,
+		UserID:          resp.UserID,
+		Username:        resp.Username,
+		OrgID:           resp.OrgID,
+		Scopes:          resp.Scopes,
+		PasswordExpired: resp.PasswordExpired,
+	}
+	result.ExpiresAt, _ = http.ParseTime(resp.ExpiresAt)
+
+	c.setToken(result.AccessToken, result.ExpiresAt)
+	return result, nil
+}
+
+// Logout ends the client's session; its token stops working
+func (c *Client) Logout(ctx context.Context) error {
+	_, err := c.do(ctx, &request{method: http.MethodPost, path: "/auth/logout"}, nil)
+	if err != nil {
+		return err
+	}
+
+	c.setToken("", time.Time{})
+	return nil
+}
+
+// LogoutAll ends every session of the signed-in user, on all devices
+func (c *Client) LogoutAll(ctx context.Context) error {
+	_, err := c.do(ctx, &request{method: http.MethodPost, path: "/me/logout-all"}, nil)
+	if err != nil {
+		return err
+	}
+
+	c.setToken("", time.Time{})
+	return nil
+}
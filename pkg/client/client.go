@@ -0,0 +1,280 @@
+// Package client is a Go SDK for the task management REST API. It signs in,
+// refreshes tokens before they expire, retries transient failures and pages
+// through lists, so integrators do not have to hand-roll HTTP calls.
+//
+//	c := client.New("https://tasks.example.com/api/v1", client.WithCredentials("johndoe", "secret"))
+//	it := c.Tasks(ctx, &client.ListTasksOptions{Status: client.TaskStatusPending})
+//	for it.Next() {
+//		fmt.Println(it.Task().Title)
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// refreshBefore is how long before its expiry a token is refreshed
+const refreshBefore = time.Minute
+
+// Client calls the REST API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retry      RetryPolicy
+
+	// login and password, when set, sign in on first use and again when the session ends
+	login    string
+	password string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// Option configures a client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken authenticates requests with an access token obtained elsewhere
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithCredentials signs in with a username or email and a password on first use,
+// and signs in again whenever the session can no longer be refreshed
+func WithCredentials(login, password string) Option {
+	return func(c *Client) {
+		c.login = login
+		c.password = password
+	}
+}
+
+// WithRetry sets how failed requests are retried
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// New creates a client for the API at baseURL, such as "http://localhost:8080/api/v1"
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token returns the current access token, or "" before signing in
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// request describes an API call
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+	// public requests are sent without a token
+	public bool
+}
+
+// envelope is the standard response body of the API
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// do sends a request, retrying it as the retry policy allows, and decodes the
+// response data into out unless it is nil. It returns the response headers.
+func (c *Client) do(ctx context.Context, req *request, out interface{}) (http.Header, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	signedInAgain := false
+	for attempt := 1; ; attempt++ {
+		var token string
+		if !req.public {
+			var err error
+			if token, err = c.accessToken(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.send(ctx, req, body, token)
+		if err != nil {
+			if attempt < c.retry.MaxAttempts && idempotent(req.method) && ctx.Err() == nil {
+				if err := c.retry.wait(ctx, attempt, 0); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		// The session ended, e.g. after a logout elsewhere; sign in again once
+		if resp.StatusCode == http.StatusUnauthorized && !req.public && c.login != "" && !signedInAgain {
+			resp.Body.Close()
+			signedInAgain = true
+			if _, err := c.Login(ctx, c.login, c.password); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if attempt < c.retry.MaxAttempts && retryable(req.method, resp.StatusCode) {
+			resp.Body.Close()
+			if err := c.retry.wait(ctx, attempt, retryAfter(resp)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		defer resp.Body.Close()
+		return resp.Header, decode(resp, out)
+	}
+}
+
+// send sends a request once
+func (c *Client) send(ctx context.Context, req *request, body []byte, token string) (*http.Response, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(httpReq)
+}
+
+// decode decodes a response into out, or into an *APIError for error responses
+func decode(resp *http.Response, out interface{}) error {
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		if resp.StatusCode >= 400 {
+			// Some errors, such as those of the authentication middleware, are plain text
+			return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(raw))}
+		}
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		if env.Error != nil {
+			apiErr.Message = env.Error.Message
+		}
+		return apiErr
+	}
+
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// accessToken returns a valid access token, signing in or refreshing the current
+// token first when needed
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	token, expiresAt := c.token, c.expiresAt
+	c.mu.Unlock()
+
+	switch {
+	case token == "" && c.login == "":
+		return "", ErrNotSignedIn
+	case token == "":
+		result, err := c.Login(ctx, c.login, c.password)
+		if err != nil {
+			return "", err
+		}
+		return result.AccessToken, nil
+	case !expiresAt.IsZero() && time.Until(expiresAt) < refreshBefore:
+		result, err := c.RefreshToken(ctx)
+		if err == nil {
+			return result.AccessToken, nil
+		}
+		if c.login == "" {
+			return "", err
+		}
+
+		// The token expired or its session ended; sign in again
+		result, err = c.Login(ctx, c.login, c.password)
+		if err != nil {
+			return "", err
+		}
+		return result.AccessToken, nil
+	}
+
+	return token, nil
+}
+
+// setToken stores the token of a login or refresh
+func (c *Client) setToken(token string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.expiresAt = expiresAt
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// defaultPageSize is the number of tasks fetched per page by iterators
+const defaultPageSize = 100
+
+// TaskIterator pages through a task list, fetching each page when the previous
+// one is used up. Tasks are in ID order and have empty descriptions.
+type TaskIterator struct {
+	client   *Client
+	ctx      context.Context
+	opts     *ListTasksOptions
+	pageSize int
+
+	page  []*Task
+	index int
+	after string
+	done  bool
+	total int64
+	err   error
+}
+
+// Tasks returns an iterator over the matching tasks
+func (c *Client) Tasks(ctx context.Context, opts *ListTasksOptions) *TaskIterator {
+	return &TaskIterator{
+		client:   c,
+		ctx:      ctx,
+		opts:     opts,
+		pageSize: defaultPageSize,
+		index:    -1,
+	}
+}
+
+// PageSize sets the number of tasks fetched per request, at most 500
+func (it *TaskIterator) PageSize(size int) *TaskIterator {
+	it.pageSize = size
+	return it
+}
+
+// Next advances to the next task and reports whether there is one. It returns
+// false when the list is exhausted or fetching a page failed; check Err.
+func (it *TaskIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.page) {
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	// Fetch the next page
+	query := it.opts.query()
+	query.Set("limit", strconv.Itoa(it.pageSize))
+	if it.after != "" {
+		query.Set("after", it.after)
+	}
+
+	var page []*Task
+	header, err := it.client.do(it.ctx, &request{method: http.MethodGet, path: "/tasks", query: query}, &page)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.total, _ = strconv.ParseInt(header.Get("X-Total-Count"), 10, 64)
+
+	it.page, it.index = page, 0
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+	if len(page) == 0 {
+		return false
+	}
+	it.after = page[len(page)-1].ID
+	return true
+}
+
+// Task returns the current task
+func (it *TaskIterator) Task() *Task {
+	return it.page[it.index]
+}
+
+// Total returns the number of matching tasks, known once the first page is fetched
+func (it *TaskIterator) Total() int64 {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *TaskIterator) Err() error {
+	return it.err
+}
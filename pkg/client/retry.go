@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy sets how failed requests are retried. Requests are retried after
+// network errors and 502, 503 and 504 responses when repeating them is safe, and
+// after 429 responses always, since rate-limited requests were not processed.
+// Delays grow exponentially with jitter, or follow the server's Retry-After header.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent at most; 1 disables retries
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy sends requests up to three times
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// wait sleeps before retrying after a failed attempt, or returns the context's error
+func (p RetryPolicy) wait(ctx context.Context, attempt int, after time.Duration) error {
+	delay := after
+	if delay <= 0 {
+		// Exponential backoff with full jitter
+		backoff := p.BaseDelay << (attempt - 1)
+		if backoff <= 0 || backoff > p.MaxDelay {
+			backoff = p.MaxDelay
+		}
+		if backoff > 0 {
+			delay = time.Duration(rand.Int63n(int64(backoff))) + 1
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// idempotent reports whether repeating a request of the method is safe
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryable reports whether a response calls for retrying the request
+func retryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(method)
+	}
+	return false
+}
+
+// retryAfter returns the delay the server asked for in the Retry-After header, if any
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TaskStatus represents the status of a task
+type TaskStatus string
+
+// Task status values
+const (
+	TaskStatusPending    TaskStatus = "pending"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusCompleted  TaskStatus = "completed"
+)
+
+// Task represents a task
+type Task struct {
+	ID          string     `json:"id"`
+	OrgID       string     `json:"org_id"`
+	ProjectID   string     `json:"project_id,omitempty"`
+	Number      int64      `json:"number,omitempty"` // Sequential number within the project
+	Key         string     `json:"key,omitempty"`    // Project key and number, e.g. WR-42
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      TaskStatus `json:"status"`
+	Priority    int        `json:"priority"`
+	DueDate     time.Time  `json:"due_date"`
+	AssignedTo  []string   `json:"assigned_to,omitempty"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Creator     *UserRef   `json:"creator,omitempty"`
+	Assignees   []*UserRef `json:"assignees,omitempty"`
+}
+
+// UserRef is a lightweight reference to a user, embedded in tasks
+type UserRef struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// TaskSearchResult is a task matching a search, with its relevance
+type TaskSearchResult struct {
+	Task  *Task   `json:"task"`
+	Score float64 `json:"score"`
+}
+
+// CreateTaskInput represents a new task
+type CreateTaskInput struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Priority    int       `json:"priority"`
+	DueDate     time.Time `json:"due_date"`
+	ProjectID   string    `json:"project_id,omitempty"`
+}
+
+// UpdateTaskInput represents a task update; zero fields are left unchanged
+type UpdateTaskInput struct {
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Status      TaskStatus `json:"status,omitempty"`
+	Priority    int        `json:"priority,omitempty"`
+	DueDate     time.Time  `json:"due_date,omitempty"`
+}
+
+// ListTasksOptions filters task lists; zero fields do not filter
+type ListTasksOptions struct {
+	Status    TaskStatus
+	ProjectID string
+}
+
+// query encodes the filters as query parameters
+func (o *ListTasksOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Status != "" {
+		query.Set("status", string(o.Status))
+	}
+	if o.ProjectID != "" {
+		query.Set("project_id", o.ProjectID)
+	}
+	return query
+}
+
+// CreateTask creates a task created by the signed-in user
+func (c *Client) CreateTask(ctx context.Context, input *CreateTaskInput) (*Task, error) {
+	var task Task
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/tasks", body: input}, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// GetTask returns a task by its ID or its key, e.g. WR-42
+func (c *Client) GetTask(ctx context.Context, id string) (*Task, error) {
+	var task Task
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: taskPath(id)}, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// UpdateTask updates a task
+func (c *Client) UpdateTask(ctx context.Context, id string, input *UpdateTaskInput) (*Task, error) {
+	var task Task
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: taskPath(id), body: input}, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// DeleteTask deletes a task
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: taskPath(id)}, nil)
+	return err
+}
+
+// ListTasks lists every matching task in one response. Descriptions are left
+// empty; use Tasks to page through large lists.
+func (c *Client) ListTasks(ctx context.Context, opts *ListTasksOptions) ([]*Task, error) {
+	var tasks []*Task
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/tasks", query: opts.query()}, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CountTasks counts the matching tasks
+func (c *Client) CountTasks(ctx context.Context, opts *ListTasksOptions) (int64, error) {
+	var resp struct {
+		Count int64 `json:"count"`
+	}
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/tasks/count", query: opts.query()}, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+// SearchTasks searches task titles and descriptions, most relevant first. A zero
+// limit returns the server's default number of results.
+func (c *Client) SearchTasks(ctx context.Context, query string, limit int) ([]*TaskSearchResult, error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var results []*TaskSearchResult
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/tasks/search", query: params}, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AssignTask assigns a task to a user, given by ID, username or email
+func (c *Client) AssignTask(ctx context.Context, id string, assignee string) (*Task, error) {
+	return c.assignment(ctx, taskPath(id)+"/assign", assignee)
+}
+
+// UnassignTask removes a user, given by ID, username or email, from a task's assignees
+func (c *Client) UnassignTask(ctx context.Context, id string, assignee string) (*Task, error) {
+	return c.assignment(ctx, taskPath(id)+"/unassign", assignee)
+}
+
+// assignment sends an assignment change
+func (c *Client) assignment(ctx context.Context, path string, assignee string) (*Task, error) {
+	body := struct {
+		Assignee string `json:"assignee"`
+	}{assignee}
+
+	var task Task
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: path, body: body}, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// GetUserTasks lists the tasks assigned to a user
+func (c *Client) GetUserTasks(ctx context.Context, userID string) ([]*Task, error) {
+	var tasks []*Task
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/users/" + url.PathEscape(userID) + "/tasks"}, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// taskPath returns the path of a task
+func taskPath(id string) string {
+	return "/tasks/" + url.PathEscape(id)
+}
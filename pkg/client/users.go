@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// User represents a user of the organization
+type User struct {
+	ID        string
+	Username  string
+	Email     string
+	FirstName string
+	LastName  string
+	Timezone  string
+	OrgID     string
+	// OrgRole is "admin" or "member"
+	OrgRole   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// userResponse is the wire format of a user
+type userResponse struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Timezone  string `json:"timezone"`
+	OrgID     string `json:"org_id"`
+	OrgRole   string `json:"org_role"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// toUser converts the wire format to a user
+func (u *userResponse) toUser() *User {
+	user := &User{
+		ID:        u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Timezone:  u.Timezone,
+		OrgID:     u.OrgID,
+		OrgRole:   u.OrgRole,
+	}
+	user.CreatedAt, _ = http.ParseTime(u.CreatedAt)
+	user.UpdatedAt, _ = http.ParseTime(u.UpdatedAt)
+	return user
+}
+
+// UpdateUserInput represents a profile update; empty fields are left unchanged
+type UpdateUserInput struct {
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Timezone  string `json:"timezone,omitempty"`
+	Password  string `json:"password,omitempty"`
+}
+
+// Me returns the signed-in user
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	return c.getUser(ctx, "/me")
+}
+
+// GetUser returns a user of the organization
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	return c.getUser(ctx, "/users/"+url.PathEscape(id))
+}
+
+// getUser returns the user at a path
+func (c *Client) getUser(ctx context.Context, path string) (*User, error) {
+	var user userResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: path}, &user); err != nil {
+		return nil, err
+	}
+	return user.toUser(), nil
+}
+
+// UpdateUser updates a user's profile; users may only update their own
+func (c *Client) UpdateUser(ctx context.Context, id string, input *UpdateUserInput) (*User, error) {
+	var user userResponse
+	_, err := c.do(ctx, &request{method: http.MethodPut, path: "/users/" + url.PathEscape(id), body: input}, &user)
+	if err != nil {
+		return nil, err
+	}
+	return user.toUser(), nil
+}
@@ -0,0 +1,160 @@
+// Package taskapi embeds the task management REST API in other Go services.
+//
+// NewHandler returns the API as an http.Handler, wired to a MongoDB database,
+// for mounting in the router of the host service:
+//
+//	cfg, err := config.LoadConfig("config/config.yaml")
+//	...
+//	api, err := taskapi.NewHandler(cfg, db,
+//		taskapi.WithBasePath("/tasks/api"),
+//		taskapi.WithMiddleware(tracing),
+//	)
+//	...
+//	mux.Handle("/tasks/api/", api)
+//
+// The handler serves the routes of the standalone API server. Background jobs,
+// gRPC and the Swagger UI are not included.
+package taskapi
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"task-management-system/config"
+	"task-management-system/internal/app"
+	"task-management-system/internal/delivery/http/routes"
+	"task-management-system/internal/infrastructure/mongodb"
+	"task-management-system/internal/logger"
+)
+
+// DefaultBasePath is the path prefix of the API routes when none is configured
+const DefaultBasePath = "/api/v1"
+
+// Option configures the handler
+type Option func(*options)
+
+type options struct {
+	basePath        string
+	middlewares     []func(http.Handler) http.Handler
+	runtimeSettings *config.RuntimeSettings
+	log             logger.Logger
+}
+
+// WithBasePath serves the API routes under the given path prefix instead of
+// server.http.base_path, e.g. "/tasks/api"
+func WithBasePath(path string) Option {
+	return func(o *options) {
+		o.basePath = path
+	}
+}
+
+// WithMiddleware runs middleware around the API, in the order given and before
+// the API's own middleware such as authentication
+func WithMiddleware(middlewares ...func(http.Handler) http.Handler) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithRuntimeSettings uses runtime settings owned by the host service, such as
+// the CORS origins and rate limits, so it can reload them. By default they are
+// fixed to the runtime section of the configuration.
+func WithRuntimeSettings(settings *config.RuntimeSettings) Option {
+	return func(o *options) {
+		o.runtimeSettings = settings
+	}
+}
+
+// WithLogHandler writes the API's logs through a log/slog handler of the host
+// service, at the given minimum level, instead of to standard output. Sensitive
+// data is masked as configured under logging before the handler sees it.
+func WithLogHandler(handler slog.Handler, level slog.Level) Option {
+	return func(o *options) {
+		minLevel := logger.LevelDebug
+		switch {
+		case level > slog.LevelWarn:
+			minLevel = logger.LevelError
+		case level > slog.LevelInfo:
+			minLevel = logger.LevelWarn
+		case level > slog.LevelDebug:
+			minLevel = logger.LevelInfo
+		}
+		o.log = logger.NewSlog(handler, minLevel)
+	}
+}
+
+// NewHandler applies pending migrations to the database and returns the REST API
+// served from it. The database is not closed by the handler.
+func NewHandler(cfg *config.Config, db *mongo.Database, opts ...Option) (http.Handler, error) {
+	o := options{basePath: cfg.Server.HTTP.BasePath}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.basePath == "" {
+		o.basePath = DefaultBasePath
+	}
+	o.basePath = strings.TrimRight(o.basePath, "/")
+	if !strings.HasPrefix(o.basePath, "/") {
+		return nil, fmt.Errorf("base path must be a path below /, such as %s", DefaultBasePath)
+	}
+	if o.log == nil {
+		o.log = logger.Default()
+	} else {
+		o.log.SetRedactor(cfg.Logging.Redactor())
+	}
+	if o.runtimeSettings == nil {
+		o.runtimeSettings = config.NewRuntimeSettings(cfg.Runtime, o.log)
+	}
+
+	// Routes read the base path from the configuration; leave the caller's copy alone
+	routeCfg := *cfg
+	routeCfg.Server.HTTP.BasePath = o.basePath
+
+	if err := mongodb.RunMigrations(db, cfg.Database.MongoDB.Timeout, logger.WithComponent(o.log, logger.ComponentMongoDB)); err != nil {
+		return nil, fmt.Errorf("failed to run database migrations: %w", err)
+	}
+	if err := mongodb.EnsureIndexes(db, cfg.Database.MongoDB, cfg.Search.Engine, logger.WithComponent(o.log, logger.ComponentMongoDB)); err != nil {
+		return nil, fmt.Errorf("failed to ensure database indexes: %w", err)
+	}
+
+	application, err := app.New(&routeCfg, o.log, db.Client(), db)
+	if err != nil {
+		return nil, err
+	}
+
+	var handler http.Handler = routes.NewRouter(
+		&routeCfg,
+		logger.WithComponent(o.log, logger.ComponentHTTP),
+		application.Tasks,
+		application.Users,
+		application.Auth,
+		application.Stars,
+		application.Today,
+		application.Notifications,
+		application.Organizations,
+		application.Invitations,
+		application.Projects,
+		application.Audit,
+		application.Attachments,
+		application.Avatars,
+		application.Exports,
+		application.Merges,
+		application.InboundHooks,
+		application.CalendarFeeds,
+		application.Reminders,
+		application.Status,
+		o.runtimeSettings,
+	)
+
+	// Wrap in reverse so the first middleware runs first
+	for i := len(o.middlewares) - 1; i >= 0; i-- {
+		handler = o.middlewares[i](handler)
+	}
+
+	return handler, nil
+}
@@ -12,9 +12,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -22,6 +24,8 @@ import (
 	"task-management-system/config"
 	grpcServer "task-management-system/internal/delivery/grpc"
 	"task-management-system/internal/domain"
+	"task-management-system/internal/infrastructure/events"
+	"task-management-system/internal/infrastructure/hashing"
 	"task-management-system/internal/infrastructure/mongodb"
 	"task-management-system/internal/logger"
 	"task-management-system/internal/usecase"
@@ -32,7 +36,9 @@ const bufSize = 1024 * 1024
 var (
 	listener *bufconn.Listener
 	cfg      *config.Config
+	db       *mongo.Database
 	client   *grpc.ClientConn
+	token    string
 )
 
 func TestMain(m *testing.M) {
@@ -63,33 +69,64 @@ func setup() {
 	cfg.Database.MongoDB.Name = "task_management_test"
 
 	// Create MongoDB client
-	mongoClient, err := mongodb.NewClient(cfg.Database.MongoDB.URI, cfg.Database.MongoDB.Timeout)
+	mongoClient, err := mongodb.NewClient(cfg.Database.MongoDB, nil)
 	if err != nil {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
 
 	// Get MongoDB database
-	db := mongodb.GetDatabase(mongoClient, cfg.Database.MongoDB.Name)
+	db = mongodb.GetDatabase(mongoClient, cfg.Database.MongoDB.Name)
 
 	// Drop database to ensure clean state
 	if err := db.Drop(context.Background()); err != nil {
 		log.Fatalf("Failed to drop test database: %v", err)
 	}
 
+	// Create the indexes the repositories rely on
+	cfg.Database.MongoDB.Indexes.Strict = true
+	if err := mongodb.EnsureIndexes(db, cfg.Database.MongoDB, mongodb.SearchEngineText, logger.Default()); err != nil {
+		log.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
 	// Initialize repositories
 	taskRepo := mongodb.NewTaskRepository(db, cfg.Database.MongoDB.Timeout)
 	userRepo := mongodb.NewUserRepository(db, cfg.Database.MongoDB.Timeout)
+	orgRepo := mongodb.NewOrganizationRepository(db, cfg.Database.MongoDB.Timeout)
 
 	// Initialize usecases
-	taskUseCase := usecase.NewTaskUseCase(taskRepo, userRepo)
-	userUseCase := usecase.NewUserUseCase(userRepo)
-	authUseCase := usecase.NewAuthUseCase(userRepo, cfg.Auth.JWT.Secret, cfg.Auth.JWT.Expiry)
+	projectRepo := mongodb.NewProjectRepository(db, cfg.Database.MongoDB.Timeout)
+	sessionRepo := mongodb.NewSessionRepository(db, cfg.Database.MongoDB.Timeout)
+	loginAttemptRepo := mongodb.NewLoginAttemptRepository(db, cfg.Database.MongoDB.Timeout)
+	policy := usecase.NewPolicy(userRepo, projectRepo)
+	taskUseCase := usecase.NewTaskUseCase(taskRepo, userRepo, mongodb.NewTaskTextSearcher(db, cfg.Database.MongoDB.Timeout), mongodb.NewCounterRepository(db, cfg.Database.MongoDB.Timeout), nil, nil, nil, events.NewBus(logger.Default()), nil, policy, usecase.ContentPolicy{}, usecase.PageLimits{}, usecase.QueryGuardrails{}, logger.Default())
+	passwordPolicy := usecase.PasswordPolicy{
+		MinLength:     cfg.Auth.Password.MinLength,
+		RequireUpper:  cfg.Auth.Password.RequireUpper,
+		RequireLower:  cfg.Auth.Password.RequireLower,
+		RequireDigit:  cfg.Auth.Password.RequireDigit,
+		RequireSymbol: cfg.Auth.Password.RequireSymbol,
+		Banned:        cfg.Auth.Password.Banned,
+		MaxAge:        cfg.Auth.Password.MaxAge,
+	}
+	passwordHasher, err := hashing.NewHasher(cfg.Auth.Password.Hashing)
+	if err != nil {
+		log.Fatalf("Failed to initialize password hashing: %v", err)
+	}
+	tokenOptions := usecase.TokenOptions{
+		Secret:   cfg.Auth.JWT.Secret,
+		Expiry:   cfg.Auth.JWT.Expiry,
+		Issuer:   cfg.Auth.JWT.Issuer,
+		Audience: cfg.Auth.JWT.Audience,
+		Leeway:   cfg.Auth.JWT.Leeway,
+	}
+	userUseCase := usecase.NewUserUseCase(userRepo, orgRepo, taskRepo, nil, events.NewBus(logger.Default()), nil, policy, passwordPolicy, passwordHasher, usecase.PageLimits{}, logger.Default())
+	authUseCase := usecase.NewAuthUseCase(userRepo, sessionRepo, loginAttemptRepo, nil, passwordPolicy, passwordHasher, tokenOptions, logger.Default())
 
 	// Create a buffer for gRPC
 	listener = bufconn.Listen(bufSize)
 
 	// Create and start gRPC server with the buffer listener instead of a real TCP listener
-	server, err := grpcServer.NewServerWithListener(cfg, listener, taskUseCase, userUseCase, authUseCase)
+	server, err := grpcServer.NewServerWithListener(cfg, logger.Default(), listener, taskUseCase, userUseCase, authUseCase)
 	if err != nil {
 		log.Fatalf("Failed to create gRPC server: %v", err)
 	}
@@ -110,8 +147,14 @@ func setup() {
 		log.Fatalf("Failed to dial bufnet: %v", err)
 	}
 
-	// Create a test user
-	createTestUser(userRepo)
+	// Create a test user and sign in as them
+	createTestUser(userRepo, orgRepo)
+
+	login, err := authUseCase.Login(&usecase.LoginInput{Login: "testuser", Password: "password123"})
+	if err != nil {
+		log.Fatalf("Failed to log in test user: %v", err)
+	}
+	token = login.AccessToken
 }
 
 func teardown() {
@@ -124,7 +167,16 @@ func bufDialer(context.Context, string) (net.Conn, error) {
 	return listener.Dial()
 }
 
-func createTestUser(userRepo domain.UserRepository) {
+func createTestUser(userRepo domain.UserRepository, orgRepo domain.OrganizationRepository) {
+	org := &domain.Organization{
+		ID:        testOrgID(),
+		Name:      "Test organization",
+		CreatedBy: testUserID(),
+	}
+	if err := orgRepo.Create(org); err != nil {
+		log.Fatalf("Failed to create test organization: %v", err)
+	}
+
 	// Hash password manually instead of using the usecase function
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 
@@ -135,6 +187,8 @@ func createTestUser(userRepo domain.UserRepository) {
 		Password:  string(hashedPassword),
 		FirstName: "Test",
 		LastName:  "User",
+		OrgID:     org.ID,
+		OrgRole:   domain.OrgRoleAdmin,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -151,11 +205,22 @@ func testUserID() primitive.ObjectID {
 	return id
 }
 
+func testOrgID() primitive.ObjectID {
+	id, _ := primitive.ObjectIDFromHex("60f1a7c9e113d70001234599")
+	return id
+}
+
+// authContext returns a request context carrying the test user's token
+func authContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return metadata.AppendToOutgoingContext(ctx, "authorization", token), cancel
+}
+
 // Test cases
 
 func TestTaskService_CreateTask(t *testing.T) {
 	taskClient := proto.NewTaskServiceClient(client)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := authContext()
 	defer cancel()
 
 	// Create task
@@ -179,7 +244,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 
 func TestTaskService_GetTask(t *testing.T) {
 	taskClient := proto.NewTaskServiceClient(client)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := authContext()
 	defer cancel()
 
 	// First create a task
@@ -205,7 +270,7 @@ func TestTaskService_GetTask(t *testing.T) {
 
 func TestTaskService_UpdateTask(t *testing.T) {
 	taskClient := proto.NewTaskServiceClient(client)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := authContext()
 	defer cancel()
 
 	// First create a task
@@ -241,7 +306,7 @@ func TestTaskService_UpdateTask(t *testing.T) {
 
 func TestTaskService_ListTasks(t *testing.T) {
 	taskClient := proto.NewTaskServiceClient(client)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := authContext()
 	defer cancel()
 
 	// Create multiple tasks
@@ -273,7 +338,7 @@ func TestTaskService_ListTasks(t *testing.T) {
 
 func TestUserService_GetUser(t *testing.T) {
 	userClient := proto.NewUserServiceClient(client)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := authContext()
 	defer cancel()
 
 	// Get the test user
@@ -285,3 +350,41 @@ func TestUserService_GetUser(t *testing.T) {
 	assert.Equal(t, "Test", resp.FirstName)
 	assert.Equal(t, "User", resp.LastName)
 }
+
+func TestTaskService_AssignAndUnassignTask(t *testing.T) {
+	taskClient := proto.NewTaskServiceClient(client)
+	ctx, cancel := authContext()
+	defer cancel()
+
+	// First create a task
+	createResp, err := taskClient.CreateTask(ctx, &proto.CreateTaskRequest{
+		Title:     "Task to Assign",
+		Priority:  2,
+		CreatedBy: testUserID().Hex(),
+	})
+	require.NoError(t, err)
+
+	// Assigning the same user twice must not duplicate the assignee
+	for i := 0; i < 2; i++ {
+		_, err = taskClient.AssignTask(ctx, &proto.AssignTaskRequest{
+			TaskId:     createResp.Id,
+			AssigneeId: testUserID().Hex(),
+			AssignedBy: testUserID().Hex(),
+		})
+		require.NoError(t, err)
+	}
+
+	getResp, err := taskClient.GetTask(ctx, &proto.GetTaskRequest{Id: createResp.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUserID().Hex()}, getResp.AssignedTo)
+	assert.Equal(t, proto.TaskStatus_TASK_STATUS_IN_PROGRESS, getResp.Status)
+
+	// Remove the assignee
+	unassignResp, err := taskClient.UnassignTask(ctx, &proto.UnassignTaskRequest{
+		TaskId:       createResp.Id,
+		AssigneeId:   testUserID().Hex(),
+		UnassignedBy: testUserID().Hex(),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, unassignResp.AssignedTo)
+}
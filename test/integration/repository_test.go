@@ -0,0 +1,16 @@
+package integration
+
+import (
+	"testing"
+
+	"task-management-system/internal/domain/repositorytest"
+	"task-management-system/internal/infrastructure/mongodb"
+)
+
+func TestTaskRepositoryContract(t *testing.T) {
+	repositorytest.Run(t, mongodb.NewTaskRepository(db, cfg.Database.MongoDB.Timeout))
+}
+
+func TestUserRepositoryContract(t *testing.T) {
+	repositorytest.Run(t, mongodb.NewUserRepository(db, cfg.Database.MongoDB.Timeout))
+}